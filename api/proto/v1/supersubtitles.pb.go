@@ -22,6 +22,163 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ShowSource is the show list endpoint a show was listed on
+type ShowSource int32
+
+const (
+	ShowSource_SHOW_SOURCE_UNSPECIFIED           ShowSource = 0
+	ShowSource_SHOW_SOURCE_WAITING               ShowSource = 1 // Subtitles requested, nobody translating yet (varakozik-subrip)
+	ShowSource_SHOW_SOURCE_UNDER_TRANSLATION     ShowSource = 2 // A subtitle is being translated (alatt-subrip)
+	ShowSource_SHOW_SOURCE_NOT_UNDER_TRANSLATION ShowSource = 3 // Nothing is being translated (nem-all-forditas-alatt)
+)
+
+// Enum value maps for ShowSource.
+var (
+	ShowSource_name = map[int32]string{
+		0: "SHOW_SOURCE_UNSPECIFIED",
+		1: "SHOW_SOURCE_WAITING",
+		2: "SHOW_SOURCE_UNDER_TRANSLATION",
+		3: "SHOW_SOURCE_NOT_UNDER_TRANSLATION",
+	}
+	ShowSource_value = map[string]int32{
+		"SHOW_SOURCE_UNSPECIFIED":           0,
+		"SHOW_SOURCE_WAITING":               1,
+		"SHOW_SOURCE_UNDER_TRANSLATION":     2,
+		"SHOW_SOURCE_NOT_UNDER_TRANSLATION": 3,
+	}
+)
+
+func (x ShowSource) Enum() *ShowSource {
+	p := new(ShowSource)
+	*p = x
+	return p
+}
+
+func (x ShowSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShowSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_supersubtitles_proto_enumTypes[0].Descriptor()
+}
+
+func (ShowSource) Type() protoreflect.EnumType {
+	return &file_supersubtitles_proto_enumTypes[0]
+}
+
+func (x ShowSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShowSource.Descriptor instead.
+func (ShowSource) EnumDescriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{0}
+}
+
+// SubtitleOrder is the order GetSubtitles sends the subtitles of each listing page in.
+// Ties are broken by subtitle ID, so identical requests stream identical sequences.
+type SubtitleOrder int32
+
+const (
+	SubtitleOrder_SUBTITLE_ORDER_UNSPECIFIED      SubtitleOrder = 0 // Same as SUBTITLE_ORDER_UPLOAD_TIME_DESC
+	SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_DESC SubtitleOrder = 1 // Newest upload first
+	SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_ASC  SubtitleOrder = 2 // Oldest upload first
+	SubtitleOrder_SUBTITLE_ORDER_SEASON_EPISODE   SubtitleOrder = 3 // By season then episode, newest upload first within an episode
+)
+
+// Enum value maps for SubtitleOrder.
+var (
+	SubtitleOrder_name = map[int32]string{
+		0: "SUBTITLE_ORDER_UNSPECIFIED",
+		1: "SUBTITLE_ORDER_UPLOAD_TIME_DESC",
+		2: "SUBTITLE_ORDER_UPLOAD_TIME_ASC",
+		3: "SUBTITLE_ORDER_SEASON_EPISODE",
+	}
+	SubtitleOrder_value = map[string]int32{
+		"SUBTITLE_ORDER_UNSPECIFIED":      0,
+		"SUBTITLE_ORDER_UPLOAD_TIME_DESC": 1,
+		"SUBTITLE_ORDER_UPLOAD_TIME_ASC":  2,
+		"SUBTITLE_ORDER_SEASON_EPISODE":   3,
+	}
+)
+
+func (x SubtitleOrder) Enum() *SubtitleOrder {
+	p := new(SubtitleOrder)
+	*p = x
+	return p
+}
+
+func (x SubtitleOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubtitleOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_supersubtitles_proto_enumTypes[1].Descriptor()
+}
+
+func (SubtitleOrder) Type() protoreflect.EnumType {
+	return &file_supersubtitles_proto_enumTypes[1]
+}
+
+func (x SubtitleOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubtitleOrder.Descriptor instead.
+func (SubtitleOrder) EnumDescriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{1}
+}
+
+// ContentType is the kind of content a subtitle is for
+type ContentType int32
+
+const (
+	ContentType_CONTENT_TYPE_UNSPECIFIED ContentType = 0
+	ContentType_CONTENT_TYPE_SERIES      ContentType = 1 // A series episode or season pack (sorozat)
+	ContentType_CONTENT_TYPE_FILM        ContentType = 2 // A film (film)
+)
+
+// Enum value maps for ContentType.
+var (
+	ContentType_name = map[int32]string{
+		0: "CONTENT_TYPE_UNSPECIFIED",
+		1: "CONTENT_TYPE_SERIES",
+		2: "CONTENT_TYPE_FILM",
+	}
+	ContentType_value = map[string]int32{
+		"CONTENT_TYPE_UNSPECIFIED": 0,
+		"CONTENT_TYPE_SERIES":      1,
+		"CONTENT_TYPE_FILM":        2,
+	}
+)
+
+func (x ContentType) Enum() *ContentType {
+	p := new(ContentType)
+	*p = x
+	return p
+}
+
+func (x ContentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_supersubtitles_proto_enumTypes[2].Descriptor()
+}
+
+func (ContentType) Type() protoreflect.EnumType {
+	return &file_supersubtitles_proto_enumTypes[2]
+}
+
+func (x ContentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentType.Descriptor instead.
+func (ContentType) EnumDescriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{2}
+}
+
 // Quality represents the video quality of a subtitle
 type Quality int32
 
@@ -65,11 +222,11 @@ func (x Quality) String() string {
 }
 
 func (Quality) Descriptor() protoreflect.EnumDescriptor {
-	return file_supersubtitles_proto_enumTypes[0].Descriptor()
+	return file_supersubtitles_proto_enumTypes[3].Descriptor()
 }
 
 func (Quality) Type() protoreflect.EnumType {
-	return &file_supersubtitles_proto_enumTypes[0]
+	return &file_supersubtitles_proto_enumTypes[3]
 }
 
 func (x Quality) Number() protoreflect.EnumNumber {
@@ -78,7 +235,7 @@ func (x Quality) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Quality.Descriptor instead.
 func (Quality) EnumDescriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{0}
+	return file_supersubtitles_proto_rawDescGZIP(), []int{3}
 }
 
 // Show represents a TV show with basic information
@@ -88,6 +245,8 @@ type Show struct {
 	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	Year          int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
 	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Aliases       []string               `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`                                           // Distinct titles the show is known by (original and Hungarian)
+	Sources       []ShowSource           `protobuf:"varint,6,rep,packed,name=sources,proto3,enum=supersubtitles.v1.ShowSource" json:"sources,omitempty"` // Show list endpoints the show was listed on; only set by GetShowList
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -150,6 +309,20 @@ func (x *Show) GetImageUrl() string {
 	return ""
 }
 
+func (x *Show) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Show) GetSources() []ShowSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
 // ThirdPartyIds represents identifiers from various third-party services
 type ThirdPartyIds struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -221,26 +394,37 @@ func (x *ThirdPartyIds) GetTraktId() int64 {
 
 // Subtitle represents a normalized subtitle
 type Subtitle struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ShowId        int64                  `protobuf:"varint,2,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
-	ShowName      string                 `protobuf:"bytes,3,opt,name=show_name,json=showName,proto3" json:"show_name,omitempty"`
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
-	Season        int32                  `protobuf:"varint,6,opt,name=season,proto3" json:"season,omitempty"`
-	Episode       int32                  `protobuf:"varint,7,opt,name=episode,proto3" json:"episode,omitempty"`
-	Filename      string                 `protobuf:"bytes,8,opt,name=filename,proto3" json:"filename,omitempty"`
-	DownloadUrl   string                 `protobuf:"bytes,9,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
-	Uploader      string                 `protobuf:"bytes,10,opt,name=uploader,proto3" json:"uploader,omitempty"`
-	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
-	Qualities     []Quality              `protobuf:"varint,12,rep,packed,name=qualities,proto3,enum=supersubtitles.v1.Quality" json:"qualities,omitempty"`
-	ReleaseGroups []string               `protobuf:"bytes,13,rep,name=release_groups,json=releaseGroups,proto3" json:"release_groups,omitempty"`
-	Release       string                 `protobuf:"bytes,14,opt,name=release,proto3" json:"release,omitempty"`
-	IsSeasonPack  bool                   `protobuf:"varint,15,opt,name=is_season_pack,json=isSeasonPack,proto3" json:"is_season_pack,omitempty"`
-	RangeStart    *int32                 `protobuf:"varint,16,opt,name=range_start,json=rangeStart,proto3,oneof" json:"range_start,omitempty"`
-	RangeEnd      *int32                 `protobuf:"varint,17,opt,name=range_end,json=rangeEnd,proto3,oneof" json:"range_end,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShowId            int64                  `protobuf:"varint,2,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	ShowName          string                 `protobuf:"bytes,3,opt,name=show_name,json=showName,proto3" json:"show_name,omitempty"`
+	Name              string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Language          string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	Season            int32                  `protobuf:"varint,6,opt,name=season,proto3" json:"season,omitempty"`
+	Episode           int32                  `protobuf:"varint,7,opt,name=episode,proto3" json:"episode,omitempty"`
+	Filename          string                 `protobuf:"bytes,8,opt,name=filename,proto3" json:"filename,omitempty"`
+	DownloadUrl       string                 `protobuf:"bytes,9,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	Uploader          string                 `protobuf:"bytes,10,opt,name=uploader,proto3" json:"uploader,omitempty"`
+	UploadedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	Qualities         []Quality              `protobuf:"varint,12,rep,packed,name=qualities,proto3,enum=supersubtitles.v1.Quality" json:"qualities,omitempty"`
+	ReleaseGroups     []string               `protobuf:"bytes,13,rep,name=release_groups,json=releaseGroups,proto3" json:"release_groups,omitempty"`
+	Release           string                 `protobuf:"bytes,14,opt,name=release,proto3" json:"release,omitempty"`
+	IsSeasonPack      bool                   `protobuf:"varint,15,opt,name=is_season_pack,json=isSeasonPack,proto3" json:"is_season_pack,omitempty"`
+	RangeStart        *int32                 `protobuf:"varint,16,opt,name=range_start,json=rangeStart,proto3,oneof" json:"range_start,omitempty"`
+	RangeEnd          *int32                 `protobuf:"varint,17,opt,name=range_end,json=rangeEnd,proto3,oneof" json:"range_end,omitempty"`
+	UploaderId        string                 `protobuf:"bytes,18,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`                                        // Uploader profile identifier (felt name or numeric user id); empty for unlinked uploaders
+	UploaderVerified  bool                   `protobuf:"varint,19,opt,name=uploader_verified,json=uploaderVerified,proto3" json:"uploader_verified,omitempty"`                     // Uploader name is bold in the listing (official translator or fansub team)
+	IsHearingImpaired bool                   `protobuf:"varint,20,opt,name=is_hearing_impaired,json=isHearingImpaired,proto3" json:"is_hearing_impaired,omitempty"`                // Description or filename marks the subtitle as SDH/CC for the hearing impaired
+	SeasonEnd         *int32                 `protobuf:"varint,21,opt,name=season_end,json=seasonEnd,proto3,oneof" json:"season_end,omitempty"`                                    // Last season of a multi-season pack such as "(1-3. évad)"; unset otherwise
+	IdIsSynthetic     bool                   `protobuf:"varint,22,opt,name=id_is_synthetic,json=idIsSynthetic,proto3" json:"id_is_synthetic,omitempty"`                            // id is a negative hash of download_url because the link has no numeric ID; download such subtitles with DownloadSubtitleByUrl
+	ReleaseVariants   []*ReleaseVariant      `protobuf:"bytes,23,rep,name=release_variants,json=releaseVariants,proto3" json:"release_variants,omitempty"`                         // Each comma-separated release of `release`, in order; qualities and release_groups flatten these
+	ContentType       ContentType            `protobuf:"varint,24,opt,name=content_type,json=contentType,proto3,enum=supersubtitles.v1.ContentType" json:"content_type,omitempty"` // Whether the subtitle is for a series episode or a film
+	MovieTitle        string                 `protobuf:"bytes,25,opt,name=movie_title,json=movieTitle,proto3" json:"movie_title,omitempty"`                                        // Film title without its year; empty for series
+	MovieYear         int32                  `protobuf:"varint,26,opt,name=movie_year,json=movieYear,proto3" json:"movie_year,omitempty"`                                          // Film release year from the title; 0 when missing or for series
+	AirDate           *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=air_date,json=airDate,proto3" json:"air_date,omitempty"`                                                 // Original air date named in the episode's titles; unset when they name none
+	RawName           string                 `protobuf:"bytes,28,opt,name=raw_name,json=rawName,proto3" json:"raw_name,omitempty"`                                                 // Episode title exactly as listed; name drops source artifacts such as "Addic7ed.com"
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Subtitle) Reset() {
@@ -392,18 +576,165 @@ func (x *Subtitle) GetRangeEnd() int32 {
 	return 0
 }
 
+func (x *Subtitle) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *Subtitle) GetUploaderVerified() bool {
+	if x != nil {
+		return x.UploaderVerified
+	}
+	return false
+}
+
+func (x *Subtitle) GetIsHearingImpaired() bool {
+	if x != nil {
+		return x.IsHearingImpaired
+	}
+	return false
+}
+
+func (x *Subtitle) GetSeasonEnd() int32 {
+	if x != nil && x.SeasonEnd != nil {
+		return *x.SeasonEnd
+	}
+	return 0
+}
+
+func (x *Subtitle) GetIdIsSynthetic() bool {
+	if x != nil {
+		return x.IdIsSynthetic
+	}
+	return false
+}
+
+func (x *Subtitle) GetReleaseVariants() []*ReleaseVariant {
+	if x != nil {
+		return x.ReleaseVariants
+	}
+	return nil
+}
+
+func (x *Subtitle) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_CONTENT_TYPE_UNSPECIFIED
+}
+
+func (x *Subtitle) GetMovieTitle() string {
+	if x != nil {
+		return x.MovieTitle
+	}
+	return ""
+}
+
+func (x *Subtitle) GetMovieYear() int32 {
+	if x != nil {
+		return x.MovieYear
+	}
+	return 0
+}
+
+func (x *Subtitle) GetAirDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AirDate
+	}
+	return nil
+}
+
+func (x *Subtitle) GetRawName() string {
+	if x != nil {
+		return x.RawName
+	}
+	return ""
+}
+
+// ReleaseVariant is one comma-separated release of a subtitle's release info, such as "AMZN.WEB-DL.720p-FLUX"
+type ReleaseVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`                                   // Origin such as "AMZN", "NF" or "WEB"; empty when the release names none
+	RipType       string                 `protobuf:"bytes,2,opt,name=rip_type,json=ripType,proto3" json:"rip_type,omitempty"`                  // Rip type such as "WEB-DL", "WEBRip" or "HDTV"; empty when the release names none
+	Quality       Quality                `protobuf:"varint,3,opt,name=quality,proto3,enum=supersubtitles.v1.Quality" json:"quality,omitempty"` // QUALITY_UNSPECIFIED when the release names no resolution
+	Group         string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`                                     // Release group after the last dash; empty when the release names none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseVariant) Reset() {
+	*x = ReleaseVariant{}
+	mi := &file_supersubtitles_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseVariant) ProtoMessage() {}
+
+func (x *ReleaseVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseVariant.ProtoReflect.Descriptor instead.
+func (*ReleaseVariant) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReleaseVariant) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ReleaseVariant) GetRipType() string {
+	if x != nil {
+		return x.RipType
+	}
+	return ""
+}
+
+func (x *ReleaseVariant) GetQuality() Quality {
+	if x != nil {
+		return x.Quality
+	}
+	return Quality_QUALITY_UNSPECIFIED
+}
+
+func (x *ReleaseVariant) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 // ShowInfo represents a TV show with its third-party IDs (sent once per show in a stream)
 type ShowInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Show          *Show                  `protobuf:"bytes,1,opt,name=show,proto3" json:"show,omitempty"`
 	ThirdPartyIds *ThirdPartyIds         `protobuf:"bytes,2,opt,name=third_party_ids,json=thirdPartyIds,proto3" json:"third_party_ids,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // "running" or "ended" from the show's detail page; empty when unknown. show.year is filled from the same page when the listing has none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShowInfo) Reset() {
 	*x = ShowInfo{}
-	mi := &file_supersubtitles_proto_msgTypes[3]
+	mi := &file_supersubtitles_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -415,7 +746,7 @@ func (x *ShowInfo) String() string {
 func (*ShowInfo) ProtoMessage() {}
 
 func (x *ShowInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[3]
+	mi := &file_supersubtitles_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +759,7 @@ func (x *ShowInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShowInfo.ProtoReflect.Descriptor instead.
 func (*ShowInfo) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{3}
+	return file_supersubtitles_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ShowInfo) GetShow() *Show {
@@ -445,6 +776,13 @@ func (x *ShowInfo) GetThirdPartyIds() *ThirdPartyIds {
 	return nil
 }
 
+func (x *ShowInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 // ShowSubtitlesCollection contains a show's complete information and all its subtitles.
 // Streamed by GetShowSubtitles and GetRecentSubtitles — one message per show.
 type ShowSubtitlesCollection struct {
@@ -457,7 +795,7 @@ type ShowSubtitlesCollection struct {
 
 func (x *ShowSubtitlesCollection) Reset() {
 	*x = ShowSubtitlesCollection{}
-	mi := &file_supersubtitles_proto_msgTypes[4]
+	mi := &file_supersubtitles_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +807,7 @@ func (x *ShowSubtitlesCollection) String() string {
 func (*ShowSubtitlesCollection) ProtoMessage() {}
 
 func (x *ShowSubtitlesCollection) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[4]
+	mi := &file_supersubtitles_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +820,7 @@ func (x *ShowSubtitlesCollection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShowSubtitlesCollection.ProtoReflect.Descriptor instead.
 func (*ShowSubtitlesCollection) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{4}
+	return file_supersubtitles_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ShowSubtitlesCollection) GetShowInfo() *ShowInfo {
@@ -501,14 +839,18 @@ func (x *ShowSubtitlesCollection) GetSubtitles() []*Subtitle {
 
 // GetShowListRequest requests the list of all available shows
 type GetShowListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor from the x-next-page-token trailer of a previous call; resumes after the last show sent
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Maximum shows to send; 0 sends all remaining shows
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetShowListRequest) Reset() {
 	*x = GetShowListRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[5]
+	mi := &file_supersubtitles_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -520,7 +862,7 @@ func (x *GetShowListRequest) String() string {
 func (*GetShowListRequest) ProtoMessage() {}
 
 func (x *GetShowListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[5]
+	mi := &file_supersubtitles_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,32 +875,47 @@ func (x *GetShowListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetShowListRequest.ProtoReflect.Descriptor instead.
 func (*GetShowListRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{5}
+	return file_supersubtitles_proto_rawDescGZIP(), []int{6}
 }
 
-// GetSubtitlesRequest requests subtitles for a specific show
-type GetSubtitlesRequest struct {
+func (x *GetShowListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetShowListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListShowsRequest requests one page of the show list
+type ListShowsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum shows to return (0 = 100, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page (empty = first page)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSubtitlesRequest) Reset() {
-	*x = GetSubtitlesRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[6]
+func (x *ListShowsRequest) Reset() {
+	*x = ListShowsRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSubtitlesRequest) String() string {
+func (x *ListShowsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSubtitlesRequest) ProtoMessage() {}
+func (*ListShowsRequest) ProtoMessage() {}
 
-func (x *GetSubtitlesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[6]
+func (x *ListShowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,41 +926,2141 @@ func (x *GetSubtitlesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSubtitlesRequest.ProtoReflect.Descriptor instead.
-func (*GetSubtitlesRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ListShowsRequest.ProtoReflect.Descriptor instead.
+func (*ListShowsRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetSubtitlesRequest) GetShowId() int64 {
+func (x *ListShowsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.ShowId
+		return x.PageSize
 	}
 	return 0
 }
 
-// GetShowSubtitlesRequest requests shows with their subtitles and third-party IDs
-type GetShowSubtitlesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Shows         []*Show                `protobuf:"bytes,1,rep,name=shows,proto3" json:"shows,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListShowsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListShowsResponse holds one page of the show list
+type ListShowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shows         []*Show                `protobuf:"bytes,1,rep,name=shows,proto3" json:"shows,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty on the last page
+	TotalSize     int32                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`              // Number of shows in the whole list
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShowsResponse) Reset() {
+	*x = ListShowsResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShowsResponse) ProtoMessage() {}
+
+func (x *ListShowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShowsResponse.ProtoReflect.Descriptor instead.
+func (*ListShowsResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListShowsResponse) GetShows() []*Show {
+	if x != nil {
+		return x.Shows
+	}
+	return nil
+}
+
+func (x *ListShowsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListShowsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// GetSubtitlesRequest requests subtitles for a specific show
+type GetSubtitlesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ShowId int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	// Order of the subtitles within each listing page; pages are always sent in listing order
+	OrderBy       SubtitleOrder `protobuf:"varint,2,opt,name=order_by,json=orderBy,proto3,enum=supersubtitles.v1.SubtitleOrder" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtitlesRequest) Reset() {
+	*x = GetSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtitlesRequest) ProtoMessage() {}
+
+func (x *GetSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSubtitlesRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *GetSubtitlesRequest) GetOrderBy() SubtitleOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return SubtitleOrder_SUBTITLE_ORDER_UNSPECIFIED
+}
+
+// GetMovieSubtitlesRequest requests subtitles for a specific film
+type GetMovieSubtitlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovieId       int64                  `protobuf:"varint,1,opt,name=movie_id,json=movieId,proto3" json:"movie_id,omitempty"` // Film ID from the site's film listing (fid)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMovieSubtitlesRequest) Reset() {
+	*x = GetMovieSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMovieSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMovieSubtitlesRequest) ProtoMessage() {}
+
+func (x *GetMovieSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMovieSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetMovieSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMovieSubtitlesRequest) GetMovieId() int64 {
+	if x != nil {
+		return x.MovieId
+	}
+	return 0
+}
+
+// GetShowSubtitlesRequest requests shows with their subtitles and third-party IDs
+type GetShowSubtitlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Shows []*Show                `protobuf:"bytes,1,rep,name=shows,proto3" json:"shows,omitempty"`
+	// Language codes (e.g. "hu", "en") whose subtitles are sorted to the front of each collection, in this order
+	PreferredLanguages []string `protobuf:"bytes,2,rep,name=preferred_languages,json=preferredLanguages,proto3" json:"preferred_languages,omitempty"`
+	// Language codes (e.g. "hu"); when set, only subtitles in these languages are sent and shows without any are skipped
+	Languages     []string `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShowSubtitlesRequest) Reset() {
+	*x = GetShowSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShowSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowSubtitlesRequest) ProtoMessage() {}
+
+func (x *GetShowSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetShowSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetShowSubtitlesRequest) GetShows() []*Show {
+	if x != nil {
+		return x.Shows
+	}
+	return nil
+}
+
+func (x *GetShowSubtitlesRequest) GetPreferredLanguages() []string {
+	if x != nil {
+		return x.PreferredLanguages
+	}
+	return nil
+}
+
+func (x *GetShowSubtitlesRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+// CheckForUpdatesRequest checks for new content since a given content ID
+type CheckForUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentId     int64                  `protobuf:"varint,1,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
+	ForceRefresh  bool                   `protobuf:"varint,2,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"` // Skip the server's cached result and ask the site again
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckForUpdatesRequest) Reset() {
+	*x = CheckForUpdatesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckForUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckForUpdatesRequest) ProtoMessage() {}
+
+func (x *CheckForUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckForUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*CheckForUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckForUpdatesRequest) GetContentId() int64 {
+	if x != nil {
+		return x.ContentId
+	}
+	return 0
+}
+
+func (x *CheckForUpdatesRequest) GetForceRefresh() bool {
+	if x != nil {
+		return x.ForceRefresh
+	}
+	return false
+}
+
+// CheckForUpdatesResponse contains update availability information
+type CheckForUpdatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FilmCount     int32                  `protobuf:"varint,1,opt,name=film_count,json=filmCount,proto3" json:"film_count,omitempty"`
+	SeriesCount   int32                  `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+	HasUpdates    bool                   `protobuf:"varint,3,opt,name=has_updates,json=hasUpdates,proto3" json:"has_updates,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"` // When the counts were fetched from the site; older than the call when served from cache
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckForUpdatesResponse) Reset() {
+	*x = CheckForUpdatesResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckForUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckForUpdatesResponse) ProtoMessage() {}
+
+func (x *CheckForUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckForUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*CheckForUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CheckForUpdatesResponse) GetFilmCount() int32 {
+	if x != nil {
+		return x.FilmCount
+	}
+	return 0
+}
+
+func (x *CheckForUpdatesResponse) GetSeriesCount() int32 {
+	if x != nil {
+		return x.SeriesCount
+	}
+	return 0
+}
+
+func (x *CheckForUpdatesResponse) GetHasUpdates() bool {
+	if x != nil {
+		return x.HasUpdates
+	}
+	return false
+}
+
+func (x *CheckForUpdatesResponse) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+// DownloadSubtitleRequest requests a subtitle download
+type DownloadSubtitleRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId       string                 `protobuf:"bytes,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	Episode          *int32                 `protobuf:"varint,2,opt,name=episode,proto3,oneof" json:"episode,omitempty"`                                           // Episode number to extract from season pack (not set = download entire file)
+	EpisodeTitle     *string                `protobuf:"bytes,3,opt,name=episode_title,json=episodeTitle,proto3,oneof" json:"episode_title,omitempty"`              // Episode title to extract from season pack, case/punctuation-insensitive (ignored when episode is set)
+	SourceEncoding   *string                `protobuf:"bytes,4,opt,name=source_encoding,json=sourceEncoding,proto3,oneof" json:"source_encoding,omitempty"`        // Encoding of the subtitle file such as "windows-1250", used instead of detection, also for extracted episodes and single-file archives; an unknown name is logged and detection used, content the encoding cannot decode returns INVALID_ARGUMENT (not set = detect)
+	MaxBytes         *int64                 `protobuf:"varint,5,opt,name=max_bytes,json=maxBytes,proto3,oneof" json:"max_bytes,omitempty"`                         // Largest file to return, lowering the server's download.max_download_size_mb and archive size limits for this download (not set = server limit)
+	HeadOnly         bool                   `protobuf:"varint,6,opt,name=head_only,json=headOnly,proto3" json:"head_only,omitempty"`                               // Only report filename, content_type and content_length without downloading the content (DownloadSubtitle only)
+	EpisodeEnd       *int32                 `protobuf:"varint,7,opt,name=episode_end,json=episodeEnd,proto3,oneof" json:"episode_end,omitempty"`                   // Last episode of a range starting at episode, returned as one ZIP of the episodes found (requires episode)
+	StripStyling     bool                   `protobuf:"varint,8,opt,name=strip_styling,json=stripStyling,proto3" json:"strip_styling,omitempty"`                   // Rewrite an ASS/SSA file as plain dialogue with one default style, dropping override tags (other formats unchanged)
+	Raw              bool                   `protobuf:"varint,9,opt,name=raw,proto3" json:"raw,omitempty"`                                                         // Return text subtitles with their uploaded bytes, skipping the UTF-8 conversion (archives are still sanitized and episodes extracted)
+	ForceRefresh     bool                   `protobuf:"varint,10,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"`                  // Ask upstream even if it answered this subtitle with 404 within download.not_found_ttl
+	KeepBom          bool                   `protobuf:"varint,11,opt,name=keep_bom,json=keepBom,proto3" json:"keep_bom,omitempty"`                                 // Keep a leading UTF-8/UTF-16 byte order mark on text subtitles, which is removed by default
+	FilenameTemplate *string                `protobuf:"bytes,12,opt,name=filename_template,json=filenameTemplate,proto3,oneof" json:"filename_template,omitempty"` // Go text/template naming an episode extracted from a season pack, overriding download.filename_template
+	Validate         bool                   `protobuf:"varint,13,opt,name=validate,proto3" json:"validate,omitempty"`                                              // Check SRT/WebVTT structure after conversion and fail with DATA_LOSS when the file is clearly malformed (other formats unchecked)
+	TimeOffsetMs     int32                  `protobuf:"varint,14,opt,name=time_offset_ms,json=timeOffsetMs,proto3" json:"time_offset_ms,omitempty"`                // Shift every SRT/WebVTT cue by this many milliseconds, negative for earlier, clamped at zero (0 = unchanged, other formats unchanged)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DownloadSubtitleRequest) Reset() {
+	*x = DownloadSubtitleRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadSubtitleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadSubtitleRequest) ProtoMessage() {}
+
+func (x *DownloadSubtitleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadSubtitleRequest.ProtoReflect.Descriptor instead.
+func (*DownloadSubtitleRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DownloadSubtitleRequest) GetSubtitleId() string {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleRequest) GetEpisode() int32 {
+	if x != nil && x.Episode != nil {
+		return *x.Episode
+	}
+	return 0
+}
+
+func (x *DownloadSubtitleRequest) GetEpisodeTitle() string {
+	if x != nil && x.EpisodeTitle != nil {
+		return *x.EpisodeTitle
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleRequest) GetSourceEncoding() string {
+	if x != nil && x.SourceEncoding != nil {
+		return *x.SourceEncoding
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleRequest) GetMaxBytes() int64 {
+	if x != nil && x.MaxBytes != nil {
+		return *x.MaxBytes
+	}
+	return 0
+}
+
+func (x *DownloadSubtitleRequest) GetHeadOnly() bool {
+	if x != nil {
+		return x.HeadOnly
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetEpisodeEnd() int32 {
+	if x != nil && x.EpisodeEnd != nil {
+		return *x.EpisodeEnd
+	}
+	return 0
+}
+
+func (x *DownloadSubtitleRequest) GetStripStyling() bool {
+	if x != nil {
+		return x.StripStyling
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetRaw() bool {
+	if x != nil {
+		return x.Raw
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetForceRefresh() bool {
+	if x != nil {
+		return x.ForceRefresh
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetKeepBom() bool {
+	if x != nil {
+		return x.KeepBom
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetFilenameTemplate() string {
+	if x != nil && x.FilenameTemplate != nil {
+		return *x.FilenameTemplate
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleRequest) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
+func (x *DownloadSubtitleRequest) GetTimeOffsetMs() int32 {
+	if x != nil {
+		return x.TimeOffsetMs
+	}
+	return 0
+}
+
+// DownloadSubtitleResponse contains the downloaded subtitle data
+type DownloadSubtitleResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Filename        string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Content         []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ContentType     string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Sha256          string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`                                          // Lowercase hex SHA-256 of content
+	ContentLength   int64                  `protobuf:"varint,5,opt,name=content_length,json=contentLength,proto3" json:"content_length,omitempty"`      // Size in bytes of the file, set for head_only requests
+	FromCache       bool                   `protobuf:"varint,6,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`                  // head_only answer came from the archive cache without an upstream request
+	LengthUnknown   bool                   `protobuf:"varint,7,opt,name=length_unknown,json=lengthUnknown,proto3" json:"length_unknown,omitempty"`      // head_only answer has no content_length because upstream did not report one
+	SourceFilename  string                 `protobuf:"bytes,8,opt,name=source_filename,json=sourceFilename,proto3" json:"source_filename,omitempty"`    // Name of an extracted episode inside the season pack, when filename was rendered from a template or differs from it
+	DetectedCharset string                 `protobuf:"bytes,9,opt,name=detected_charset,json=detectedCharset,proto3" json:"detected_charset,omitempty"` // Encoding a text subtitle was read as before its conversion to UTF-8, such as "iso-8859-2", or "utf-8" when passed through; comma-separated for an episode range; empty for raw downloads and whole archives
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DownloadSubtitleResponse) Reset() {
+	*x = DownloadSubtitleResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadSubtitleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadSubtitleResponse) ProtoMessage() {}
+
+func (x *DownloadSubtitleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadSubtitleResponse.ProtoReflect.Descriptor instead.
+func (*DownloadSubtitleResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DownloadSubtitleResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DownloadSubtitleResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleResponse) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+func (x *DownloadSubtitleResponse) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+func (x *DownloadSubtitleResponse) GetLengthUnknown() bool {
+	if x != nil {
+		return x.LengthUnknown
+	}
+	return false
+}
+
+func (x *DownloadSubtitleResponse) GetSourceFilename() string {
+	if x != nil {
+		return x.SourceFilename
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleResponse) GetDetectedCharset() string {
+	if x != nil {
+		return x.DetectedCharset
+	}
+	return ""
+}
+
+// GetRecentSubtitlesRequest requests recently uploaded subtitles
+type GetRecentSubtitlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SinceId       int64                  `protobuf:"varint,1,opt,name=since_id,json=sinceId,proto3" json:"since_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentSubtitlesRequest) Reset() {
+	*x = GetRecentSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentSubtitlesRequest) ProtoMessage() {}
+
+func (x *GetRecentSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRecentSubtitlesRequest) GetSinceId() int64 {
+	if x != nil {
+		return x.SinceId
+	}
+	return 0
+}
+
+// InvalidateCacheRequest identifies the subtitle whose cached archives should be dropped
+type InvalidateCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId    string                 `protobuf:"bytes,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateCacheRequest) Reset() {
+	*x = InvalidateCacheRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InvalidateCacheRequest) GetSubtitleId() string {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return ""
+}
+
+// InvalidateCacheResponse reports whether a cached entry was removed
+type InvalidateCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invalidated   bool                   `protobuf:"varint,1,opt,name=invalidated,proto3" json:"invalidated,omitempty"` // True if at least one cached archive existed for the subtitle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateCacheResponse) Reset() {
+	*x = InvalidateCacheResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *InvalidateCacheResponse) GetInvalidated() bool {
+	if x != nil {
+		return x.Invalidated
+	}
+	return false
+}
+
+// ClearCacheRequest requests a full archive cache flush
+type ClearCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCacheRequest) Reset() {
+	*x = ClearCacheRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheRequest) ProtoMessage() {}
+
+func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheRequest.ProtoReflect.Descriptor instead.
+func (*ClearCacheRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{19}
+}
+
+// ClearCacheResponse reports how many entries were flushed
+type ClearCacheResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EntriesCleared int64                  `protobuf:"varint,1,opt,name=entries_cleared,json=entriesCleared,proto3" json:"entries_cleared,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClearCacheResponse) Reset() {
+	*x = ClearCacheResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheResponse) ProtoMessage() {}
+
+func (x *ClearCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheResponse.ProtoReflect.Descriptor instead.
+func (*ClearCacheResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ClearCacheResponse) GetEntriesCleared() int64 {
+	if x != nil {
+		return x.EntriesCleared
+	}
+	return 0
+}
+
+// GetLatestSubtitleIdRequest requests the newest subtitle ID currently listed upstream
+type GetLatestSubtitleIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestSubtitleIdRequest) Reset() {
+	*x = GetLatestSubtitleIdRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestSubtitleIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestSubtitleIdRequest) ProtoMessage() {}
+
+func (x *GetLatestSubtitleIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestSubtitleIdRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestSubtitleIdRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{21}
+}
+
+// GetLatestSubtitleIdResponse contains the newest subtitle ID (high-water mark)
+type GetLatestSubtitleIdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId    int64                  `protobuf:"varint,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"` // Newest subtitle ID on the recent listing; 0 when the listing is empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestSubtitleIdResponse) Reset() {
+	*x = GetLatestSubtitleIdResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestSubtitleIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestSubtitleIdResponse) ProtoMessage() {}
+
+func (x *GetLatestSubtitleIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestSubtitleIdResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestSubtitleIdResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLatestSubtitleIdResponse) GetSubtitleId() int64 {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return 0
+}
+
+// FindSubtitleRequest looks up the subtitles for one episode of a show
+type FindSubtitleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	Season        int32                  `protobuf:"varint,2,opt,name=season,proto3" json:"season,omitempty"`
+	Episode       int32                  `protobuf:"varint,3,opt,name=episode,proto3" json:"episode,omitempty"`
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"` // ISO 639-1 code such as "hu"; empty matches every language
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSubtitleRequest) Reset() {
+	*x = FindSubtitleRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSubtitleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSubtitleRequest) ProtoMessage() {}
+
+func (x *FindSubtitleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSubtitleRequest.ProtoReflect.Descriptor instead.
+func (*FindSubtitleRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FindSubtitleRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *FindSubtitleRequest) GetSeason() int32 {
+	if x != nil {
+		return x.Season
+	}
+	return 0
+}
+
+func (x *FindSubtitleRequest) GetEpisode() int32 {
+	if x != nil {
+		return x.Episode
+	}
+	return 0
+}
+
+func (x *FindSubtitleRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// FindSubtitleResponse lists the matching subtitles: episode subtitles first, then season packs covering the episode
+type FindSubtitleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtitles     []*Subtitle            `protobuf:"bytes,1,rep,name=subtitles,proto3" json:"subtitles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSubtitleResponse) Reset() {
+	*x = FindSubtitleResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSubtitleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSubtitleResponse) ProtoMessage() {}
+
+func (x *FindSubtitleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSubtitleResponse.ProtoReflect.Descriptor instead.
+func (*FindSubtitleResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FindSubtitleResponse) GetSubtitles() []*Subtitle {
+	if x != nil {
+		return x.Subtitles
+	}
+	return nil
+}
+
+// GetBestSubtitlesRequest picks the best subtitle for every episode of a show
+type GetBestSubtitlesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ShowId           int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	Language         string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`                                                                         // ISO 639-1 code such as "hu" ranked first; empty ranks every language equally
+	PreferredQuality Quality                `protobuf:"varint,3,opt,name=preferred_quality,json=preferredQuality,proto3,enum=supersubtitles.v1.Quality" json:"preferred_quality,omitempty"` // Quality ranked next; QUALITY_UNSPECIFIED skips the quality criterion
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetBestSubtitlesRequest) Reset() {
+	*x = GetBestSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBestSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBestSubtitlesRequest) ProtoMessage() {}
+
+func (x *GetBestSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBestSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetBestSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBestSubtitlesRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *GetBestSubtitlesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetBestSubtitlesRequest) GetPreferredQuality() Quality {
+	if x != nil {
+		return x.PreferredQuality
+	}
+	return Quality_QUALITY_UNSPECIFIED
+}
+
+// GetShowLanguageStatsRequest requests per-language statistics for a show
+type GetShowLanguageStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShowLanguageStatsRequest) Reset() {
+	*x = GetShowLanguageStatsRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShowLanguageStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowLanguageStatsRequest) ProtoMessage() {}
+
+func (x *GetShowLanguageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowLanguageStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetShowLanguageStatsRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetShowLanguageStatsRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+// LanguageStats aggregates a show's subtitles in one language
+type LanguageStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Language         string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`                                 // ISO 639-1 code, or "other" for languages the parser could not map
+	SubtitleCount    int32                  `protobuf:"varint,2,opt,name=subtitle_count,json=subtitleCount,proto3" json:"subtitle_count,omitempty"` // All subtitles in the language, season packs included
+	SeasonPackCount  int32                  `protobuf:"varint,3,opt,name=season_pack_count,json=seasonPackCount,proto3" json:"season_pack_count,omitempty"`
+	NewestUploadedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=newest_uploaded_at,json=newestUploadedAt,proto3" json:"newest_uploaded_at,omitempty"`
+	Seasons          []int32                `protobuf:"varint,5,rep,packed,name=seasons,proto3" json:"seasons,omitempty"` // Distinct seasons covered, ascending
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LanguageStats) Reset() {
+	*x = LanguageStats{}
+	mi := &file_supersubtitles_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageStats) ProtoMessage() {}
+
+func (x *LanguageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageStats.ProtoReflect.Descriptor instead.
+func (*LanguageStats) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LanguageStats) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LanguageStats) GetSubtitleCount() int32 {
+	if x != nil {
+		return x.SubtitleCount
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetSeasonPackCount() int32 {
+	if x != nil {
+		return x.SeasonPackCount
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetNewestUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewestUploadedAt
+	}
+	return nil
+}
+
+func (x *LanguageStats) GetSeasons() []int32 {
+	if x != nil {
+		return x.Seasons
+	}
+	return nil
+}
+
+// ShowLanguageStats lists the languages a show has subtitles in, ordered by language code
+type ShowLanguageStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	Languages     []*LanguageStats       `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowLanguageStats) Reset() {
+	*x = ShowLanguageStats{}
+	mi := &file_supersubtitles_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowLanguageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowLanguageStats) ProtoMessage() {}
+
+func (x *ShowLanguageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowLanguageStats.ProtoReflect.Descriptor instead.
+func (*ShowLanguageStats) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ShowLanguageStats) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *ShowLanguageStats) GetLanguages() []*LanguageStats {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+// DownloadSubtitleByUrlRequest requests a subtitle by its download link instead of its ID
+type DownloadSubtitleByUrlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                // Download link on the configured site, such as https://feliratok.eu/index.php?action=letolt&felirat=1
+	Episode       *int32                 `protobuf:"varint,2,opt,name=episode,proto3,oneof" json:"episode,omitempty"` // Episode number to extract from season pack (not set = download entire file)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadSubtitleByUrlRequest) Reset() {
+	*x = DownloadSubtitleByUrlRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadSubtitleByUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadSubtitleByUrlRequest) ProtoMessage() {}
+
+func (x *DownloadSubtitleByUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadSubtitleByUrlRequest.ProtoReflect.Descriptor instead.
+func (*DownloadSubtitleByUrlRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DownloadSubtitleByUrlRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DownloadSubtitleByUrlRequest) GetEpisode() int32 {
+	if x != nil && x.Episode != nil {
+		return *x.Episode
+	}
+	return 0
+}
+
+// GetShowSeasonsRequest requests the season summary of a show
+type GetShowSeasonsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShowSeasonsRequest) Reset() {
+	*x = GetShowSeasonsRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShowSeasonsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowSeasonsRequest) ProtoMessage() {}
+
+func (x *GetShowSeasonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowSeasonsRequest.ProtoReflect.Descriptor instead.
+func (*GetShowSeasonsRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetShowSeasonsRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+// SeasonSummary summarizes the subtitles available for one season
+type SeasonSummary struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Season              int32                  `protobuf:"varint,1,opt,name=season,proto3" json:"season,omitempty"`
+	EpisodeCount        int32                  `protobuf:"varint,2,opt,name=episode_count,json=episodeCount,proto3" json:"episode_count,omitempty"`                        // Distinct episodes with at least one subtitle, ranged season packs included
+	SeasonPackAvailable bool                   `protobuf:"varint,3,opt,name=season_pack_available,json=seasonPackAvailable,proto3" json:"season_pack_available,omitempty"` // True if any season pack covers the season
+	LatestUpload        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=latest_upload,json=latestUpload,proto3" json:"latest_upload,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SeasonSummary) Reset() {
+	*x = SeasonSummary{}
+	mi := &file_supersubtitles_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeasonSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonSummary) ProtoMessage() {}
+
+func (x *SeasonSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonSummary.ProtoReflect.Descriptor instead.
+func (*SeasonSummary) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SeasonSummary) GetSeason() int32 {
+	if x != nil {
+		return x.Season
+	}
+	return 0
+}
+
+func (x *SeasonSummary) GetEpisodeCount() int32 {
+	if x != nil {
+		return x.EpisodeCount
+	}
+	return 0
+}
+
+func (x *SeasonSummary) GetSeasonPackAvailable() bool {
+	if x != nil {
+		return x.SeasonPackAvailable
+	}
+	return false
+}
+
+func (x *SeasonSummary) GetLatestUpload() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestUpload
+	}
+	return nil
+}
+
+// ShowSeasons lists a show's seasons ordered by season number
+type ShowSeasons struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	Seasons       []*SeasonSummary       `protobuf:"bytes,2,rep,name=seasons,proto3" json:"seasons,omitempty"`
+	UnknownCount  int32                  `protobuf:"varint,3,opt,name=unknown_count,json=unknownCount,proto3" json:"unknown_count,omitempty"` // Subtitles whose season could not be parsed, left out of seasons
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowSeasons) Reset() {
+	*x = ShowSeasons{}
+	mi := &file_supersubtitles_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowSeasons) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowSeasons) ProtoMessage() {}
+
+func (x *ShowSeasons) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowSeasons.ProtoReflect.Descriptor instead.
+func (*ShowSeasons) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ShowSeasons) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *ShowSeasons) GetSeasons() []*SeasonSummary {
+	if x != nil {
+		return x.Seasons
+	}
+	return nil
+}
+
+func (x *ShowSeasons) GetUnknownCount() int32 {
+	if x != nil {
+		return x.UnknownCount
+	}
+	return 0
+}
+
+// DownloadChunk is one message of a streamed download. The first message carries the
+// metadata and no data; every following message carries only data.
+type DownloadChunk struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Filename        string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType     string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Sha256          string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`                                          // Lowercase hex SHA-256 of the whole content
+	Size            int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                             // Total content size in bytes
+	Data            []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`                                              // At most 1 MiB of content
+	SourceFilename  string                 `protobuf:"bytes,6,opt,name=source_filename,json=sourceFilename,proto3" json:"source_filename,omitempty"`    // Name of an extracted episode inside the season pack, on the first chunk
+	DetectedCharset string                 `protobuf:"bytes,7,opt,name=detected_charset,json=detectedCharset,proto3" json:"detected_charset,omitempty"` // Encoding the text was read as before its conversion to UTF-8, on the first chunk (see DownloadSubtitleResponse)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DownloadChunk) Reset() {
+	*x = DownloadChunk{}
+	mi := &file_supersubtitles_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadChunk) ProtoMessage() {}
+
+func (x *DownloadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadChunk.ProtoReflect.Descriptor instead.
+func (*DownloadChunk) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DownloadChunk) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DownloadChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DownloadChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DownloadChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DownloadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadChunk) GetSourceFilename() string {
+	if x != nil {
+		return x.SourceFilename
+	}
+	return ""
+}
+
+func (x *DownloadChunk) GetDetectedCharset() string {
+	if x != nil {
+		return x.DetectedCharset
+	}
+	return ""
+}
+
+// ExportShowSubtitlesRequest selects the subtitles of a show to export
+type ExportShowSubtitlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	Languages     []string               `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`                                       // Language codes (e.g. "hu", "en") to export; empty exports every language
+	MaxTotalBytes *int64                 `protobuf:"varint,3,opt,name=max_total_bytes,json=maxTotalBytes,proto3,oneof" json:"max_total_bytes,omitempty"` // Fails with RESOURCE_EXHAUSTED when the exported files would add up to more
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportShowSubtitlesRequest) Reset() {
+	*x = ExportShowSubtitlesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportShowSubtitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportShowSubtitlesRequest) ProtoMessage() {}
+
+func (x *ExportShowSubtitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportShowSubtitlesRequest.ProtoReflect.Descriptor instead.
+func (*ExportShowSubtitlesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportShowSubtitlesRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *ExportShowSubtitlesRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *ExportShowSubtitlesRequest) GetMaxTotalBytes() int64 {
+	if x != nil && x.MaxTotalBytes != nil {
+		return *x.MaxTotalBytes
+	}
+	return 0
+}
+
+// FindShowRequest looks a show up by name
+type FindShowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`        // Matched against show names and aliases, ignoring case and diacritics
+	Year          *int32                 `protobuf:"varint,2,opt,name=year,proto3,oneof" json:"year,omitempty"` // Disambiguates shows sharing a name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindShowRequest) Reset() {
+	*x = FindShowRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindShowRequest) ProtoMessage() {}
+
+func (x *FindShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindShowRequest.ProtoReflect.Descriptor instead.
+func (*FindShowRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FindShowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FindShowRequest) GetYear() int32 {
+	if x != nil && x.Year != nil {
+		return *x.Year
+	}
+	return 0
+}
+
+// GetLanguagesRequest requests the supported subtitle languages
+type GetLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguagesRequest) Reset() {
+	*x = GetLanguagesRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguagesRequest) ProtoMessage() {}
+
+func (x *GetLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*GetLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{36}
+}
+
+// Language is a subtitle language the service recognizes
+type Language struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsoCode       string                 `protobuf:"bytes,1,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`                   // ISO 639-1 code used in Subtitle.language
+	HungarianName string                 `protobuf:"bytes,2,opt,name=hungarian_name,json=hungarianName,proto3" json:"hungarian_name,omitempty"` // Name shown on feliratok.eu
+	EnglishName   string                 `protobuf:"bytes,3,opt,name=english_name,json=englishName,proto3" json:"english_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Language) Reset() {
+	*x = Language{}
+	mi := &file_supersubtitles_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Language) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Language) ProtoMessage() {}
+
+func (x *Language) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Language.ProtoReflect.Descriptor instead.
+func (*Language) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Language) GetIsoCode() string {
+	if x != nil {
+		return x.IsoCode
+	}
+	return ""
+}
+
+func (x *Language) GetHungarianName() string {
+	if x != nil {
+		return x.HungarianName
+	}
+	return ""
+}
+
+func (x *Language) GetEnglishName() string {
+	if x != nil {
+		return x.EnglishName
+	}
+	return ""
+}
+
+// GetLanguagesResponse lists the supported subtitle languages ordered by ISO code
+type GetLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Languages     []*Language            `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLanguagesResponse) Reset() {
+	*x = GetLanguagesResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLanguagesResponse) ProtoMessage() {}
+
+func (x *GetLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*GetLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetLanguagesResponse) GetLanguages() []*Language {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+// GetSubtitleDetailsRequest requests the detail page of a subtitle
+type GetSubtitleDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId    int64                  `protobuf:"varint,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtitleDetailsRequest) Reset() {
+	*x = GetSubtitleDetailsRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtitleDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtitleDetailsRequest) ProtoMessage() {}
+
+func (x *GetSubtitleDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtitleDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtitleDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetSubtitleDetailsRequest) GetSubtitleId() int64 {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return 0
+}
+
+// GetSubtitleRequest looks a subtitle up within its show
+type GetSubtitleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	SubtitleId    int64                  `protobuf:"varint,2,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtitleRequest) Reset() {
+	*x = GetSubtitleRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtitleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtitleRequest) ProtoMessage() {}
+
+func (x *GetSubtitleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtitleRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtitleRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetSubtitleRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+func (x *GetSubtitleRequest) GetSubtitleId() int64 {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return 0
+}
+
+// GetSubtitleByIdRequest requests one subtitle without its show
+type GetSubtitleByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId    int64                  `protobuf:"varint,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtitleByIdRequest) Reset() {
+	*x = GetSubtitleByIdRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtitleByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtitleByIdRequest) ProtoMessage() {}
+
+func (x *GetSubtitleByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtitleByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtitleByIdRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetSubtitleByIdRequest) GetSubtitleId() int64 {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return 0
+}
+
+// SubtitleDetails is what the detail page (adatlap) of a subtitle shows
+type SubtitleDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubtitleId    int64                  `protobuf:"varint,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Uploader      string                 `protobuf:"bytes,3,opt,name=uploader,proto3" json:"uploader,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"` // Uploader's note (megjegyzés), such as the release the subtitle fits; empty when missing
+	ThirdPartyIds *ThirdPartyIds         `protobuf:"bytes,5,opt,name=third_party_ids,json=thirdPartyIds,proto3" json:"third_party_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubtitleDetails) Reset() {
+	*x = SubtitleDetails{}
+	mi := &file_supersubtitles_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubtitleDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubtitleDetails) ProtoMessage() {}
+
+func (x *SubtitleDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubtitleDetails.ProtoReflect.Descriptor instead.
+func (*SubtitleDetails) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SubtitleDetails) GetSubtitleId() int64 {
+	if x != nil {
+		return x.SubtitleId
+	}
+	return 0
+}
+
+func (x *SubtitleDetails) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *SubtitleDetails) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *SubtitleDetails) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *SubtitleDetails) GetThirdPartyIds() *ThirdPartyIds {
+	if x != nil {
+		return x.ThirdPartyIds
+	}
+	return nil
+}
+
+// GetStatusRequest requests the service status
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{43}
+}
+
+// GetStatusResponse reports the upstream mirror requests are sent to
+type GetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveMirror  string                 `protobuf:"bytes,1,opt,name=active_mirror,json=activeMirror,proto3" json:"active_mirror,omitempty"`    // Base URL requests currently go to
+	Mirrors       []string               `protobuf:"bytes,2,rep,name=mirrors,proto3" json:"mirrors,omitempty"`                                  // Configured base URLs in failover order, primary first
+	ActiveSince   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`       // When the active mirror was selected
+	Blocked       bool                   `protobuf:"varint,4,opt,name=blocked,proto3" json:"blocked,omitempty"`                                 // The last listing fetched was a captcha, login or other block page
+	BlockedReason string                 `protobuf:"bytes,5,opt,name=blocked_reason,json=blockedReason,proto3" json:"blocked_reason,omitempty"` // What marked the page as a block page; empty when not blocked
+	BlockedSince  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=blocked_since,json=blockedSince,proto3" json:"blocked_since,omitempty"`    // When the current block was first seen; unset when not blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetShowSubtitlesRequest) Reset() {
-	*x = GetShowSubtitlesRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[7]
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetShowSubtitlesRequest) String() string {
+func (x *GetStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetShowSubtitlesRequest) ProtoMessage() {}
+func (*GetStatusResponse) ProtoMessage() {}
 
-func (x *GetShowSubtitlesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[7]
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,41 +3071,75 @@ func (x *GetShowSubtitlesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetShowSubtitlesRequest.ProtoReflect.Descriptor instead.
-func (*GetShowSubtitlesRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *GetShowSubtitlesRequest) GetShows() []*Show {
+func (x *GetStatusResponse) GetActiveMirror() string {
 	if x != nil {
-		return x.Shows
+		return x.ActiveMirror
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetMirrors() []string {
+	if x != nil {
+		return x.Mirrors
 	}
 	return nil
 }
 
-// CheckForUpdatesRequest checks for new content since a given content ID
-type CheckForUpdatesRequest struct {
+func (x *GetStatusResponse) GetActiveSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveSince
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetBlockedReason() string {
+	if x != nil {
+		return x.BlockedReason
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetBlockedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockedSince
+	}
+	return nil
+}
+
+// SelfCheckRequest requests a parsing self-check against the live site
+type SelfCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ContentId     int64                  `protobuf:"varint,1,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckForUpdatesRequest) Reset() {
-	*x = CheckForUpdatesRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[8]
+func (x *SelfCheckRequest) Reset() {
+	*x = SelfCheckRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckForUpdatesRequest) String() string {
+func (x *SelfCheckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckForUpdatesRequest) ProtoMessage() {}
+func (*SelfCheckRequest) ProtoMessage() {}
 
-func (x *CheckForUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[8]
+func (x *SelfCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,43 +3150,37 @@ func (x *CheckForUpdatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckForUpdatesRequest.ProtoReflect.Descriptor instead.
-func (*CheckForUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *CheckForUpdatesRequest) GetContentId() int64 {
-	if x != nil {
-		return x.ContentId
-	}
-	return 0
+// Deprecated: Use SelfCheckRequest.ProtoReflect.Descriptor instead.
+func (*SelfCheckRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{45}
 }
 
-// CheckForUpdatesResponse contains update availability information
-type CheckForUpdatesResponse struct {
+// SelfCheckResponse reports whether the site's listing still parses
+type SelfCheckResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FilmCount     int32                  `protobuf:"varint,1,opt,name=film_count,json=filmCount,proto3" json:"film_count,omitempty"`
-	SeriesCount   int32                  `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
-	HasUpdates    bool                   `protobuf:"varint,3,opt,name=has_updates,json=hasUpdates,proto3" json:"has_updates,omitempty"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`                                            // At least one plausible subtitle was parsed
+	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`                                   // What was checked, or why the check failed
+	ShowId        int64                  `protobuf:"varint,3,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`                      // Show whose listing was fetched
+	SubtitleCount int32                  `protobuf:"varint,4,opt,name=subtitle_count,json=subtitleCount,proto3" json:"subtitle_count,omitempty"` // Subtitles parsed from the first listing page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckForUpdatesResponse) Reset() {
-	*x = CheckForUpdatesResponse{}
-	mi := &file_supersubtitles_proto_msgTypes[9]
+func (x *SelfCheckResponse) Reset() {
+	*x = SelfCheckResponse{}
+	mi := &file_supersubtitles_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckForUpdatesResponse) String() string {
+func (x *SelfCheckResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckForUpdatesResponse) ProtoMessage() {}
+func (*SelfCheckResponse) ProtoMessage() {}
 
-func (x *CheckForUpdatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[9]
+func (x *SelfCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -706,56 +3191,108 @@ func (x *CheckForUpdatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckForUpdatesResponse.ProtoReflect.Descriptor instead.
-func (*CheckForUpdatesResponse) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SelfCheckResponse.ProtoReflect.Descriptor instead.
+func (*SelfCheckResponse) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *CheckForUpdatesResponse) GetFilmCount() int32 {
+func (x *SelfCheckResponse) GetOk() bool {
 	if x != nil {
-		return x.FilmCount
+		return x.Ok
+	}
+	return false
+}
+
+func (x *SelfCheckResponse) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *SelfCheckResponse) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
 	}
 	return 0
 }
 
-func (x *CheckForUpdatesResponse) GetSeriesCount() int32 {
+func (x *SelfCheckResponse) GetSubtitleCount() int32 {
 	if x != nil {
-		return x.SeriesCount
+		return x.SubtitleCount
 	}
 	return 0
 }
 
-func (x *CheckForUpdatesResponse) GetHasUpdates() bool {
+// GetShowImageRequest requests the poster of a show
+type GetShowImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShowId        int64                  `protobuf:"varint,1,opt,name=show_id,json=showId,proto3" json:"show_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShowImageRequest) Reset() {
+	*x = GetShowImageRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShowImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowImageRequest) ProtoMessage() {}
+
+func (x *GetShowImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[47]
 	if x != nil {
-		return x.HasUpdates
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-// DownloadSubtitleRequest requests a subtitle download
-type DownloadSubtitleRequest struct {
+// Deprecated: Use GetShowImageRequest.ProtoReflect.Descriptor instead.
+func (*GetShowImageRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetShowImageRequest) GetShowId() int64 {
+	if x != nil {
+		return x.ShowId
+	}
+	return 0
+}
+
+// ShowImage is a show's poster image
+type ShowImage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SubtitleId    string                 `protobuf:"bytes,1,opt,name=subtitle_id,json=subtitleId,proto3" json:"subtitle_id,omitempty"`
-	Episode       *int32                 `protobuf:"varint,2,opt,name=episode,proto3,oneof" json:"episode,omitempty"` // Episode number to extract from season pack (not set = download entire file)
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                            // Image bytes
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // "image/jpeg", "image/png" or "image/webp", detected from the content
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadSubtitleRequest) Reset() {
-	*x = DownloadSubtitleRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[10]
+func (x *ShowImage) Reset() {
+	*x = ShowImage{}
+	mi := &file_supersubtitles_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadSubtitleRequest) String() string {
+func (x *ShowImage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadSubtitleRequest) ProtoMessage() {}
+func (*ShowImage) ProtoMessage() {}
 
-func (x *DownloadSubtitleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[10]
+func (x *ShowImage) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -766,50 +3303,90 @@ func (x *DownloadSubtitleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadSubtitleRequest.ProtoReflect.Descriptor instead.
-func (*DownloadSubtitleRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ShowImage.ProtoReflect.Descriptor instead.
+func (*ShowImage) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *DownloadSubtitleRequest) GetSubtitleId() string {
+func (x *ShowImage) GetContent() []byte {
 	if x != nil {
-		return x.SubtitleId
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ShowImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
 	}
 	return ""
 }
 
-func (x *DownloadSubtitleRequest) GetEpisode() int32 {
-	if x != nil && x.Episode != nil {
-		return *x.Episode
+// GetServerInfoRequest requests the server's build, features and limits
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_supersubtitles_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-// DownloadSubtitleResponse contains the downloaded subtitle data
-type DownloadSubtitleResponse struct {
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{49}
+}
+
+// ServerInfo describes the running server
+type ServerInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                         // Semantic version of the build, "dev" for local builds
+	Commit        string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`                           // Git commit the server was built from
+	BuildDate     string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`    // When the server was built
+	ApiVersion    string                 `protobuf:"bytes,4,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"` // Version of this API, "v1"
+	Features      []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                       // Enabled optional features, such as "redis_cache" or "auth"
+	Limits        *ServerLimits          `protobuf:"bytes,6,opt,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadSubtitleResponse) Reset() {
-	*x = DownloadSubtitleResponse{}
-	mi := &file_supersubtitles_proto_msgTypes[11]
+func (x *ServerInfo) Reset() {
+	*x = ServerInfo{}
+	mi := &file_supersubtitles_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadSubtitleResponse) String() string {
+func (x *ServerInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadSubtitleResponse) ProtoMessage() {}
+func (*ServerInfo) ProtoMessage() {}
 
-func (x *DownloadSubtitleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[11]
+func (x *ServerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,55 +3397,79 @@ func (x *DownloadSubtitleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadSubtitleResponse.ProtoReflect.Descriptor instead.
-func (*DownloadSubtitleResponse) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ServerInfo.ProtoReflect.Descriptor instead.
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *DownloadSubtitleResponse) GetFilename() string {
+func (x *ServerInfo) GetVersion() string {
 	if x != nil {
-		return x.Filename
+		return x.Version
 	}
 	return ""
 }
 
-func (x *DownloadSubtitleResponse) GetContent() []byte {
+func (x *ServerInfo) GetCommit() string {
 	if x != nil {
-		return x.Content
+		return x.Commit
 	}
-	return nil
+	return ""
 }
 
-func (x *DownloadSubtitleResponse) GetContentType() string {
+func (x *ServerInfo) GetBuildDate() string {
 	if x != nil {
-		return x.ContentType
+		return x.BuildDate
 	}
 	return ""
 }
 
-// GetRecentSubtitlesRequest requests recently uploaded subtitles
-type GetRecentSubtitlesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SinceId       int64                  `protobuf:"varint,1,opt,name=since_id,json=sinceId,proto3" json:"since_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ServerInfo) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
 }
 
-func (x *GetRecentSubtitlesRequest) Reset() {
-	*x = GetRecentSubtitlesRequest{}
-	mi := &file_supersubtitles_proto_msgTypes[12]
+func (x *ServerInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *ServerInfo) GetLimits() *ServerLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// ServerLimits are the size limits the server applies
+type ServerLimits struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MaxDownloadBytes int64                  `protobuf:"varint,1,opt,name=max_download_bytes,json=maxDownloadBytes,proto3" json:"max_download_bytes,omitempty"` // Largest file downloaded from the site
+	MaxFileBytes     int64                  `protobuf:"varint,2,opt,name=max_file_bytes,json=maxFileBytes,proto3" json:"max_file_bytes,omitempty"`             // Largest subtitle extracted from an archive
+	MaxArchiveBytes  int64                  `protobuf:"varint,3,opt,name=max_archive_bytes,json=maxArchiveBytes,proto3" json:"max_archive_bytes,omitempty"`    // Largest total uncompressed size of an archive
+	MaxMessageBytes  int64                  `protobuf:"varint,4,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`    // Largest gRPC request message accepted
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ServerLimits) Reset() {
+	*x = ServerLimits{}
+	mi := &file_supersubtitles_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRecentSubtitlesRequest) String() string {
+func (x *ServerLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecentSubtitlesRequest) ProtoMessage() {}
+func (*ServerLimits) ProtoMessage() {}
 
-func (x *GetRecentSubtitlesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_supersubtitles_proto_msgTypes[12]
+func (x *ServerLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_supersubtitles_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -879,14 +3480,35 @@ func (x *GetRecentSubtitlesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecentSubtitlesRequest.ProtoReflect.Descriptor instead.
-func (*GetRecentSubtitlesRequest) Descriptor() ([]byte, []int) {
-	return file_supersubtitles_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ServerLimits.ProtoReflect.Descriptor instead.
+func (*ServerLimits) Descriptor() ([]byte, []int) {
+	return file_supersubtitles_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *GetRecentSubtitlesRequest) GetSinceId() int64 {
+func (x *ServerLimits) GetMaxDownloadBytes() int64 {
 	if x != nil {
-		return x.SinceId
+		return x.MaxDownloadBytes
+	}
+	return 0
+}
+
+func (x *ServerLimits) GetMaxFileBytes() int64 {
+	if x != nil {
+		return x.MaxFileBytes
+	}
+	return 0
+}
+
+func (x *ServerLimits) GetMaxArchiveBytes() int64 {
+	if x != nil {
+		return x.MaxArchiveBytes
+	}
+	return 0
+}
+
+func (x *ServerLimits) GetMaxMessageBytes() int64 {
+	if x != nil {
+		return x.MaxMessageBytes
 	}
 	return 0
 }
@@ -895,18 +3517,20 @@ var File_supersubtitles_proto protoreflect.FileDescriptor
 
 const file_supersubtitles_proto_rawDesc = "" +
 	"\n" +
-	"\x14supersubtitles.proto\x12\x11supersubtitles.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"[\n" +
+	"\x14supersubtitles.proto\x12\x11supersubtitles.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x01\n" +
 	"\x04Show\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x1b\n" +
-	"\timage_url\x18\x04 \x01(\tR\bimageUrl\"z\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\x127\n" +
+	"\asources\x18\x06 \x03(\x0e2\x1d.supersubtitles.v1.ShowSourceR\asources\"z\n" +
 	"\rThirdPartyIds\x12\x17\n" +
 	"\aimdb_id\x18\x01 \x01(\tR\x06imdbId\x12\x17\n" +
 	"\atvdb_id\x18\x02 \x01(\x03R\x06tvdbId\x12\x1c\n" +
 	"\n" +
 	"tv_maze_id\x18\x03 \x01(\x03R\btvMazeId\x12\x19\n" +
-	"\btrakt_id\x18\x04 \x01(\x03R\atraktId\"\xd1\x04\n" +
+	"\btrakt_id\x18\x04 \x01(\x03R\atraktId\"\xcd\b\n" +
 	"\bSubtitle\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\ashow_id\x18\x02 \x01(\x03R\x06showId\x12\x1b\n" +
@@ -927,56 +3551,288 @@ const file_supersubtitles_proto_rawDesc = "" +
 	"\x0eis_season_pack\x18\x0f \x01(\bR\fisSeasonPack\x12$\n" +
 	"\vrange_start\x18\x10 \x01(\x05H\x00R\n" +
 	"rangeStart\x88\x01\x01\x12 \n" +
-	"\trange_end\x18\x11 \x01(\x05H\x01R\brangeEnd\x88\x01\x01B\x0e\n" +
+	"\trange_end\x18\x11 \x01(\x05H\x01R\brangeEnd\x88\x01\x01\x12\x1f\n" +
+	"\vuploader_id\x18\x12 \x01(\tR\n" +
+	"uploaderId\x12+\n" +
+	"\x11uploader_verified\x18\x13 \x01(\bR\x10uploaderVerified\x12.\n" +
+	"\x13is_hearing_impaired\x18\x14 \x01(\bR\x11isHearingImpaired\x12\"\n" +
+	"\n" +
+	"season_end\x18\x15 \x01(\x05H\x02R\tseasonEnd\x88\x01\x01\x12&\n" +
+	"\x0fid_is_synthetic\x18\x16 \x01(\bR\ridIsSynthetic\x12L\n" +
+	"\x10release_variants\x18\x17 \x03(\v2!.supersubtitles.v1.ReleaseVariantR\x0freleaseVariants\x12A\n" +
+	"\fcontent_type\x18\x18 \x01(\x0e2\x1e.supersubtitles.v1.ContentTypeR\vcontentType\x12\x1f\n" +
+	"\vmovie_title\x18\x19 \x01(\tR\n" +
+	"movieTitle\x12\x1d\n" +
+	"\n" +
+	"movie_year\x18\x1a \x01(\x05R\tmovieYear\x125\n" +
+	"\bair_date\x18\x1b \x01(\v2\x1a.google.protobuf.TimestampR\aairDate\x12\x19\n" +
+	"\braw_name\x18\x1c \x01(\tR\arawNameB\x0e\n" +
 	"\f_range_startB\f\n" +
 	"\n" +
-	"_range_end\"\x81\x01\n" +
+	"_range_endB\r\n" +
+	"\v_season_end\"\x8f\x01\n" +
+	"\x0eReleaseVariant\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x19\n" +
+	"\brip_type\x18\x02 \x01(\tR\aripType\x124\n" +
+	"\aquality\x18\x03 \x01(\x0e2\x1a.supersubtitles.v1.QualityR\aquality\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\"\x99\x01\n" +
 	"\bShowInfo\x12+\n" +
 	"\x04show\x18\x01 \x01(\v2\x17.supersubtitles.v1.ShowR\x04show\x12H\n" +
-	"\x0fthird_party_ids\x18\x02 \x01(\v2 .supersubtitles.v1.ThirdPartyIdsR\rthirdPartyIds\"\x8e\x01\n" +
+	"\x0fthird_party_ids\x18\x02 \x01(\v2 .supersubtitles.v1.ThirdPartyIdsR\rthirdPartyIds\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x8e\x01\n" +
 	"\x17ShowSubtitlesCollection\x128\n" +
 	"\tshow_info\x18\x01 \x01(\v2\x1b.supersubtitles.v1.ShowInfoR\bshowInfo\x129\n" +
-	"\tsubtitles\x18\x02 \x03(\v2\x1b.supersubtitles.v1.SubtitleR\tsubtitles\"\x14\n" +
-	"\x12GetShowListRequest\".\n" +
+	"\tsubtitles\x18\x02 \x03(\v2\x1b.supersubtitles.v1.SubtitleR\tsubtitles\"P\n" +
+	"\x12GetShowListRequest\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x01 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"N\n" +
+	"\x10ListShowsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x89\x01\n" +
+	"\x11ListShowsResponse\x12-\n" +
+	"\x05shows\x18\x01 \x03(\v2\x17.supersubtitles.v1.ShowR\x05shows\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"k\n" +
 	"\x13GetSubtitlesRequest\x12\x17\n" +
-	"\ashow_id\x18\x01 \x01(\x03R\x06showId\"H\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12;\n" +
+	"\border_by\x18\x02 \x01(\x0e2 .supersubtitles.v1.SubtitleOrderR\aorderBy\"5\n" +
+	"\x18GetMovieSubtitlesRequest\x12\x19\n" +
+	"\bmovie_id\x18\x01 \x01(\x03R\amovieId\"\x97\x01\n" +
 	"\x17GetShowSubtitlesRequest\x12-\n" +
-	"\x05shows\x18\x01 \x03(\v2\x17.supersubtitles.v1.ShowR\x05shows\"7\n" +
+	"\x05shows\x18\x01 \x03(\v2\x17.supersubtitles.v1.ShowR\x05shows\x12/\n" +
+	"\x13preferred_languages\x18\x02 \x03(\tR\x12preferredLanguages\x12\x1c\n" +
+	"\tlanguages\x18\x03 \x03(\tR\tlanguages\"\\\n" +
 	"\x16CheckForUpdatesRequest\x12\x1d\n" +
 	"\n" +
-	"content_id\x18\x01 \x01(\x03R\tcontentId\"|\n" +
+	"content_id\x18\x01 \x01(\x03R\tcontentId\x12#\n" +
+	"\rforce_refresh\x18\x02 \x01(\bR\fforceRefresh\"\xb7\x01\n" +
 	"\x17CheckForUpdatesResponse\x12\x1d\n" +
 	"\n" +
 	"film_count\x18\x01 \x01(\x05R\tfilmCount\x12!\n" +
 	"\fseries_count\x18\x02 \x01(\x05R\vseriesCount\x12\x1f\n" +
 	"\vhas_updates\x18\x03 \x01(\bR\n" +
-	"hasUpdates\"e\n" +
+	"hasUpdates\x129\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"\xe7\x04\n" +
 	"\x17DownloadSubtitleRequest\x12\x1f\n" +
 	"\vsubtitle_id\x18\x01 \x01(\tR\n" +
 	"subtitleId\x12\x1d\n" +
-	"\aepisode\x18\x02 \x01(\x05H\x00R\aepisode\x88\x01\x01B\n" +
+	"\aepisode\x18\x02 \x01(\x05H\x00R\aepisode\x88\x01\x01\x12(\n" +
+	"\repisode_title\x18\x03 \x01(\tH\x01R\fepisodeTitle\x88\x01\x01\x12,\n" +
+	"\x0fsource_encoding\x18\x04 \x01(\tH\x02R\x0esourceEncoding\x88\x01\x01\x12 \n" +
+	"\tmax_bytes\x18\x05 \x01(\x03H\x03R\bmaxBytes\x88\x01\x01\x12\x1b\n" +
+	"\thead_only\x18\x06 \x01(\bR\bheadOnly\x12$\n" +
+	"\vepisode_end\x18\a \x01(\x05H\x04R\n" +
+	"episodeEnd\x88\x01\x01\x12#\n" +
+	"\rstrip_styling\x18\b \x01(\bR\fstripStyling\x12\x10\n" +
+	"\x03raw\x18\t \x01(\bR\x03raw\x12#\n" +
+	"\rforce_refresh\x18\n" +
+	" \x01(\bR\fforceRefresh\x12\x19\n" +
+	"\bkeep_bom\x18\v \x01(\bR\akeepBom\x120\n" +
+	"\x11filename_template\x18\f \x01(\tH\x05R\x10filenameTemplate\x88\x01\x01\x12\x1a\n" +
+	"\bvalidate\x18\r \x01(\bR\bvalidate\x12$\n" +
+	"\x0etime_offset_ms\x18\x0e \x01(\x05R\ftimeOffsetMsB\n" +
 	"\n" +
-	"\b_episode\"s\n" +
+	"\b_episodeB\x10\n" +
+	"\x0e_episode_titleB\x12\n" +
+	"\x10_source_encodingB\f\n" +
+	"\n" +
+	"_max_bytesB\x0e\n" +
+	"\f_episode_endB\x14\n" +
+	"\x12_filename_template\"\xcc\x02\n" +
 	"\x18DownloadSubtitleResponse\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\fR\acontent\x12!\n" +
-	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"6\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12%\n" +
+	"\x0econtent_length\x18\x05 \x01(\x03R\rcontentLength\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\x06 \x01(\bR\tfromCache\x12%\n" +
+	"\x0elength_unknown\x18\a \x01(\bR\rlengthUnknown\x12'\n" +
+	"\x0fsource_filename\x18\b \x01(\tR\x0esourceFilename\x12)\n" +
+	"\x10detected_charset\x18\t \x01(\tR\x0fdetectedCharset\"6\n" +
 	"\x19GetRecentSubtitlesRequest\x12\x19\n" +
-	"\bsince_id\x18\x01 \x01(\x03R\asinceId*~\n" +
+	"\bsince_id\x18\x01 \x01(\x03R\asinceId\"9\n" +
+	"\x16InvalidateCacheRequest\x12\x1f\n" +
+	"\vsubtitle_id\x18\x01 \x01(\tR\n" +
+	"subtitleId\";\n" +
+	"\x17InvalidateCacheResponse\x12 \n" +
+	"\vinvalidated\x18\x01 \x01(\bR\vinvalidated\"\x13\n" +
+	"\x11ClearCacheRequest\"=\n" +
+	"\x12ClearCacheResponse\x12'\n" +
+	"\x0fentries_cleared\x18\x01 \x01(\x03R\x0eentriesCleared\"\x1c\n" +
+	"\x1aGetLatestSubtitleIdRequest\">\n" +
+	"\x1bGetLatestSubtitleIdResponse\x12\x1f\n" +
+	"\vsubtitle_id\x18\x01 \x01(\x03R\n" +
+	"subtitleId\"|\n" +
+	"\x13FindSubtitleRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12\x16\n" +
+	"\x06season\x18\x02 \x01(\x05R\x06season\x12\x18\n" +
+	"\aepisode\x18\x03 \x01(\x05R\aepisode\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"Q\n" +
+	"\x14FindSubtitleResponse\x129\n" +
+	"\tsubtitles\x18\x01 \x03(\v2\x1b.supersubtitles.v1.SubtitleR\tsubtitles\"\x97\x01\n" +
+	"\x17GetBestSubtitlesRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12G\n" +
+	"\x11preferred_quality\x18\x03 \x01(\x0e2\x1a.supersubtitles.v1.QualityR\x10preferredQuality\"6\n" +
+	"\x1bGetShowLanguageStatsRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\"\xe2\x01\n" +
+	"\rLanguageStats\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12%\n" +
+	"\x0esubtitle_count\x18\x02 \x01(\x05R\rsubtitleCount\x12*\n" +
+	"\x11season_pack_count\x18\x03 \x01(\x05R\x0fseasonPackCount\x12H\n" +
+	"\x12newest_uploaded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10newestUploadedAt\x12\x18\n" +
+	"\aseasons\x18\x05 \x03(\x05R\aseasons\"l\n" +
+	"\x11ShowLanguageStats\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12>\n" +
+	"\tlanguages\x18\x02 \x03(\v2 .supersubtitles.v1.LanguageStatsR\tlanguages\"[\n" +
+	"\x1cDownloadSubtitleByUrlRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\aepisode\x18\x02 \x01(\x05H\x00R\aepisode\x88\x01\x01B\n" +
+	"\n" +
+	"\b_episode\"0\n" +
+	"\x15GetShowSeasonsRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\"\xc1\x01\n" +
+	"\rSeasonSummary\x12\x16\n" +
+	"\x06season\x18\x01 \x01(\x05R\x06season\x12#\n" +
+	"\repisode_count\x18\x02 \x01(\x05R\fepisodeCount\x122\n" +
+	"\x15season_pack_available\x18\x03 \x01(\bR\x13seasonPackAvailable\x12?\n" +
+	"\rlatest_upload\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flatestUpload\"\x87\x01\n" +
+	"\vShowSeasons\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12:\n" +
+	"\aseasons\x18\x02 \x03(\v2 .supersubtitles.v1.SeasonSummaryR\aseasons\x12#\n" +
+	"\runknown_count\x18\x03 \x01(\x05R\funknownCount\"\xe2\x01\n" +
+	"\rDownloadChunk\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12'\n" +
+	"\x0fsource_filename\x18\x06 \x01(\tR\x0esourceFilename\x12)\n" +
+	"\x10detected_charset\x18\a \x01(\tR\x0fdetectedCharset\"\x94\x01\n" +
+	"\x1aExportShowSubtitlesRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\x12+\n" +
+	"\x0fmax_total_bytes\x18\x03 \x01(\x03H\x00R\rmaxTotalBytes\x88\x01\x01B\x12\n" +
+	"\x10_max_total_bytes\"G\n" +
+	"\x0fFindShowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\x04year\x18\x02 \x01(\x05H\x00R\x04year\x88\x01\x01B\a\n" +
+	"\x05_year\"\x15\n" +
+	"\x13GetLanguagesRequest\"o\n" +
+	"\bLanguage\x12\x19\n" +
+	"\biso_code\x18\x01 \x01(\tR\aisoCode\x12%\n" +
+	"\x0ehungarian_name\x18\x02 \x01(\tR\rhungarianName\x12!\n" +
+	"\fenglish_name\x18\x03 \x01(\tR\venglishName\"Q\n" +
+	"\x14GetLanguagesResponse\x129\n" +
+	"\tlanguages\x18\x01 \x03(\v2\x1b.supersubtitles.v1.LanguageR\tlanguages\"<\n" +
+	"\x19GetSubtitleDetailsRequest\x12\x1f\n" +
+	"\vsubtitle_id\x18\x01 \x01(\x03R\n" +
+	"subtitleId\"N\n" +
+	"\x12GetSubtitleRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\x12\x1f\n" +
+	"\vsubtitle_id\x18\x02 \x01(\x03R\n" +
+	"subtitleId\"9\n" +
+	"\x16GetSubtitleByIdRequest\x12\x1f\n" +
+	"\vsubtitle_id\x18\x01 \x01(\x03R\n" +
+	"subtitleId\"\xce\x01\n" +
+	"\x0fSubtitleDetails\x12\x1f\n" +
+	"\vsubtitle_id\x18\x01 \x01(\x03R\n" +
+	"subtitleId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1a\n" +
+	"\buploader\x18\x03 \x01(\tR\buploader\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12H\n" +
+	"\x0fthird_party_ids\x18\x05 \x01(\v2 .supersubtitles.v1.ThirdPartyIdsR\rthirdPartyIds\"\x12\n" +
+	"\x10GetStatusRequest\"\x93\x02\n" +
+	"\x11GetStatusResponse\x12#\n" +
+	"\ractive_mirror\x18\x01 \x01(\tR\factiveMirror\x12\x18\n" +
+	"\amirrors\x18\x02 \x03(\tR\amirrors\x12=\n" +
+	"\factive_since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vactiveSince\x12\x18\n" +
+	"\ablocked\x18\x04 \x01(\bR\ablocked\x12%\n" +
+	"\x0eblocked_reason\x18\x05 \x01(\tR\rblockedReason\x12?\n" +
+	"\rblocked_since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fblockedSince\"\x12\n" +
+	"\x10SelfCheckRequest\"}\n" +
+	"\x11SelfCheckResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
+	"\adetails\x18\x02 \x01(\tR\adetails\x12\x17\n" +
+	"\ashow_id\x18\x03 \x01(\x03R\x06showId\x12%\n" +
+	"\x0esubtitle_count\x18\x04 \x01(\x05R\rsubtitleCount\".\n" +
+	"\x13GetShowImageRequest\x12\x17\n" +
+	"\ashow_id\x18\x01 \x01(\x03R\x06showId\"H\n" +
+	"\tShowImage\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xd3\x01\n" +
+	"\n" +
+	"ServerInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\tR\tbuildDate\x12\x1f\n" +
+	"\vapi_version\x18\x04 \x01(\tR\n" +
+	"apiVersion\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\x127\n" +
+	"\x06limits\x18\x06 \x01(\v2\x1f.supersubtitles.v1.ServerLimitsR\x06limits\"\xba\x01\n" +
+	"\fServerLimits\x12,\n" +
+	"\x12max_download_bytes\x18\x01 \x01(\x03R\x10maxDownloadBytes\x12$\n" +
+	"\x0emax_file_bytes\x18\x02 \x01(\x03R\fmaxFileBytes\x12*\n" +
+	"\x11max_archive_bytes\x18\x03 \x01(\x03R\x0fmaxArchiveBytes\x12*\n" +
+	"\x11max_message_bytes\x18\x04 \x01(\x03R\x0fmaxMessageBytes*\x8c\x01\n" +
+	"\n" +
+	"ShowSource\x12\x1b\n" +
+	"\x17SHOW_SOURCE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SHOW_SOURCE_WAITING\x10\x01\x12!\n" +
+	"\x1dSHOW_SOURCE_UNDER_TRANSLATION\x10\x02\x12%\n" +
+	"!SHOW_SOURCE_NOT_UNDER_TRANSLATION\x10\x03*\x9b\x01\n" +
+	"\rSubtitleOrder\x12\x1e\n" +
+	"\x1aSUBTITLE_ORDER_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fSUBTITLE_ORDER_UPLOAD_TIME_DESC\x10\x01\x12\"\n" +
+	"\x1eSUBTITLE_ORDER_UPLOAD_TIME_ASC\x10\x02\x12!\n" +
+	"\x1dSUBTITLE_ORDER_SEASON_EPISODE\x10\x03*[\n" +
+	"\vContentType\x12\x1c\n" +
+	"\x18CONTENT_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CONTENT_TYPE_SERIES\x10\x01\x12\x15\n" +
+	"\x11CONTENT_TYPE_FILM\x10\x02*~\n" +
 	"\aQuality\x12\x17\n" +
 	"\x13QUALITY_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fQUALITY_360P\x10\x01\x12\x10\n" +
 	"\fQUALITY_480P\x10\x02\x12\x10\n" +
 	"\fQUALITY_720P\x10\x03\x12\x11\n" +
 	"\rQUALITY_1080P\x10\x04\x12\x11\n" +
-	"\rQUALITY_2160P\x10\x052\xf6\x04\n" +
+	"\rQUALITY_2160P\x10\x052\xde\x14\n" +
 	"\x15SuperSubtitlesService\x12O\n" +
 	"\vGetShowList\x12%.supersubtitles.v1.GetShowListRequest\x1a\x17.supersubtitles.v1.Show0\x01\x12U\n" +
 	"\fGetSubtitles\x12&.supersubtitles.v1.GetSubtitlesRequest\x1a\x1b.supersubtitles.v1.Subtitle0\x01\x12l\n" +
 	"\x10GetShowSubtitles\x12*.supersubtitles.v1.GetShowSubtitlesRequest\x1a*.supersubtitles.v1.ShowSubtitlesCollection0\x01\x12h\n" +
 	"\x0fCheckForUpdates\x12).supersubtitles.v1.CheckForUpdatesRequest\x1a*.supersubtitles.v1.CheckForUpdatesResponse\x12k\n" +
 	"\x10DownloadSubtitle\x12*.supersubtitles.v1.DownloadSubtitleRequest\x1a+.supersubtitles.v1.DownloadSubtitleResponse\x12p\n" +
-	"\x12GetRecentSubtitles\x12,.supersubtitles.v1.GetRecentSubtitlesRequest\x1a*.supersubtitles.v1.ShowSubtitlesCollection0\x01B8Z6github.com/Belphemur/SuperSubtitles/v2/api/proto/v1;v1b\x06proto3"
+	"\x12GetRecentSubtitles\x12,.supersubtitles.v1.GetRecentSubtitlesRequest\x1a*.supersubtitles.v1.ShowSubtitlesCollection0\x01\x12h\n" +
+	"\x0fInvalidateCache\x12).supersubtitles.v1.InvalidateCacheRequest\x1a*.supersubtitles.v1.InvalidateCacheResponse\x12Y\n" +
+	"\n" +
+	"ClearCache\x12$.supersubtitles.v1.ClearCacheRequest\x1a%.supersubtitles.v1.ClearCacheResponse\x12t\n" +
+	"\x13GetLatestSubtitleId\x12-.supersubtitles.v1.GetLatestSubtitleIdRequest\x1a..supersubtitles.v1.GetLatestSubtitleIdResponse\x12_\n" +
+	"\fFindSubtitle\x12&.supersubtitles.v1.FindSubtitleRequest\x1a'.supersubtitles.v1.FindSubtitleResponse\x12]\n" +
+	"\x10GetBestSubtitles\x12*.supersubtitles.v1.GetBestSubtitlesRequest\x1a\x1b.supersubtitles.v1.Subtitle0\x01\x12l\n" +
+	"\x14GetShowLanguageStats\x12..supersubtitles.v1.GetShowLanguageStatsRequest\x1a$.supersubtitles.v1.ShowLanguageStats\x12u\n" +
+	"\x15DownloadSubtitleByUrl\x12/.supersubtitles.v1.DownloadSubtitleByUrlRequest\x1a+.supersubtitles.v1.DownloadSubtitleResponse\x12Z\n" +
+	"\x0eGetShowSeasons\x12(.supersubtitles.v1.GetShowSeasonsRequest\x1a\x1e.supersubtitles.v1.ShowSeasons\x12h\n" +
+	"\x16DownloadSubtitleStream\x12*.supersubtitles.v1.DownloadSubtitleRequest\x1a .supersubtitles.v1.DownloadChunk0\x01\x12G\n" +
+	"\bFindShow\x12\".supersubtitles.v1.FindShowRequest\x1a\x17.supersubtitles.v1.Show\x12_\n" +
+	"\fGetLanguages\x12&.supersubtitles.v1.GetLanguagesRequest\x1a'.supersubtitles.v1.GetLanguagesResponse\x12f\n" +
+	"\x12GetSubtitleDetails\x12,.supersubtitles.v1.GetSubtitleDetailsRequest\x1a\".supersubtitles.v1.SubtitleDetails\x12Q\n" +
+	"\vGetSubtitle\x12%.supersubtitles.v1.GetSubtitleRequest\x1a\x1b.supersubtitles.v1.Subtitle\x12Y\n" +
+	"\x0fGetSubtitleById\x12).supersubtitles.v1.GetSubtitleByIdRequest\x1a\x1b.supersubtitles.v1.Subtitle\x12V\n" +
+	"\tGetStatus\x12#.supersubtitles.v1.GetStatusRequest\x1a$.supersubtitles.v1.GetStatusResponse\x12V\n" +
+	"\tListShows\x12#.supersubtitles.v1.ListShowsRequest\x1a$.supersubtitles.v1.ListShowsResponse\x12V\n" +
+	"\tSelfCheck\x12#.supersubtitles.v1.SelfCheckRequest\x1a$.supersubtitles.v1.SelfCheckResponse\x12T\n" +
+	"\fGetShowImage\x12&.supersubtitles.v1.GetShowImageRequest\x1a\x1c.supersubtitles.v1.ShowImage\x12_\n" +
+	"\x11GetMovieSubtitles\x12+.supersubtitles.v1.GetMovieSubtitlesRequest\x1a\x1b.supersubtitles.v1.Subtitle0\x01\x12h\n" +
+	"\x13ExportShowSubtitles\x12-.supersubtitles.v1.ExportShowSubtitlesRequest\x1a .supersubtitles.v1.DownloadChunk0\x01\x12W\n" +
+	"\rGetServerInfo\x12'.supersubtitles.v1.GetServerInfoRequest\x1a\x1d.supersubtitles.v1.ServerInfoB8Z6github.com/Belphemur/SuperSubtitles/v2/api/proto/v1;v1b\x06proto3"
 
 var (
 	file_supersubtitles_proto_rawDescOnce sync.Once
@@ -990,50 +3846,153 @@ func file_supersubtitles_proto_rawDescGZIP() []byte {
 	return file_supersubtitles_proto_rawDescData
 }
 
-var file_supersubtitles_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_supersubtitles_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_supersubtitles_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_supersubtitles_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_supersubtitles_proto_goTypes = []any{
-	(Quality)(0),                      // 0: supersubtitles.v1.Quality
-	(*Show)(nil),                      // 1: supersubtitles.v1.Show
-	(*ThirdPartyIds)(nil),             // 2: supersubtitles.v1.ThirdPartyIds
-	(*Subtitle)(nil),                  // 3: supersubtitles.v1.Subtitle
-	(*ShowInfo)(nil),                  // 4: supersubtitles.v1.ShowInfo
-	(*ShowSubtitlesCollection)(nil),   // 5: supersubtitles.v1.ShowSubtitlesCollection
-	(*GetShowListRequest)(nil),        // 6: supersubtitles.v1.GetShowListRequest
-	(*GetSubtitlesRequest)(nil),       // 7: supersubtitles.v1.GetSubtitlesRequest
-	(*GetShowSubtitlesRequest)(nil),   // 8: supersubtitles.v1.GetShowSubtitlesRequest
-	(*CheckForUpdatesRequest)(nil),    // 9: supersubtitles.v1.CheckForUpdatesRequest
-	(*CheckForUpdatesResponse)(nil),   // 10: supersubtitles.v1.CheckForUpdatesResponse
-	(*DownloadSubtitleRequest)(nil),   // 11: supersubtitles.v1.DownloadSubtitleRequest
-	(*DownloadSubtitleResponse)(nil),  // 12: supersubtitles.v1.DownloadSubtitleResponse
-	(*GetRecentSubtitlesRequest)(nil), // 13: supersubtitles.v1.GetRecentSubtitlesRequest
-	(*timestamppb.Timestamp)(nil),     // 14: google.protobuf.Timestamp
+	(ShowSource)(0),                      // 0: supersubtitles.v1.ShowSource
+	(SubtitleOrder)(0),                   // 1: supersubtitles.v1.SubtitleOrder
+	(ContentType)(0),                     // 2: supersubtitles.v1.ContentType
+	(Quality)(0),                         // 3: supersubtitles.v1.Quality
+	(*Show)(nil),                         // 4: supersubtitles.v1.Show
+	(*ThirdPartyIds)(nil),                // 5: supersubtitles.v1.ThirdPartyIds
+	(*Subtitle)(nil),                     // 6: supersubtitles.v1.Subtitle
+	(*ReleaseVariant)(nil),               // 7: supersubtitles.v1.ReleaseVariant
+	(*ShowInfo)(nil),                     // 8: supersubtitles.v1.ShowInfo
+	(*ShowSubtitlesCollection)(nil),      // 9: supersubtitles.v1.ShowSubtitlesCollection
+	(*GetShowListRequest)(nil),           // 10: supersubtitles.v1.GetShowListRequest
+	(*ListShowsRequest)(nil),             // 11: supersubtitles.v1.ListShowsRequest
+	(*ListShowsResponse)(nil),            // 12: supersubtitles.v1.ListShowsResponse
+	(*GetSubtitlesRequest)(nil),          // 13: supersubtitles.v1.GetSubtitlesRequest
+	(*GetMovieSubtitlesRequest)(nil),     // 14: supersubtitles.v1.GetMovieSubtitlesRequest
+	(*GetShowSubtitlesRequest)(nil),      // 15: supersubtitles.v1.GetShowSubtitlesRequest
+	(*CheckForUpdatesRequest)(nil),       // 16: supersubtitles.v1.CheckForUpdatesRequest
+	(*CheckForUpdatesResponse)(nil),      // 17: supersubtitles.v1.CheckForUpdatesResponse
+	(*DownloadSubtitleRequest)(nil),      // 18: supersubtitles.v1.DownloadSubtitleRequest
+	(*DownloadSubtitleResponse)(nil),     // 19: supersubtitles.v1.DownloadSubtitleResponse
+	(*GetRecentSubtitlesRequest)(nil),    // 20: supersubtitles.v1.GetRecentSubtitlesRequest
+	(*InvalidateCacheRequest)(nil),       // 21: supersubtitles.v1.InvalidateCacheRequest
+	(*InvalidateCacheResponse)(nil),      // 22: supersubtitles.v1.InvalidateCacheResponse
+	(*ClearCacheRequest)(nil),            // 23: supersubtitles.v1.ClearCacheRequest
+	(*ClearCacheResponse)(nil),           // 24: supersubtitles.v1.ClearCacheResponse
+	(*GetLatestSubtitleIdRequest)(nil),   // 25: supersubtitles.v1.GetLatestSubtitleIdRequest
+	(*GetLatestSubtitleIdResponse)(nil),  // 26: supersubtitles.v1.GetLatestSubtitleIdResponse
+	(*FindSubtitleRequest)(nil),          // 27: supersubtitles.v1.FindSubtitleRequest
+	(*FindSubtitleResponse)(nil),         // 28: supersubtitles.v1.FindSubtitleResponse
+	(*GetBestSubtitlesRequest)(nil),      // 29: supersubtitles.v1.GetBestSubtitlesRequest
+	(*GetShowLanguageStatsRequest)(nil),  // 30: supersubtitles.v1.GetShowLanguageStatsRequest
+	(*LanguageStats)(nil),                // 31: supersubtitles.v1.LanguageStats
+	(*ShowLanguageStats)(nil),            // 32: supersubtitles.v1.ShowLanguageStats
+	(*DownloadSubtitleByUrlRequest)(nil), // 33: supersubtitles.v1.DownloadSubtitleByUrlRequest
+	(*GetShowSeasonsRequest)(nil),        // 34: supersubtitles.v1.GetShowSeasonsRequest
+	(*SeasonSummary)(nil),                // 35: supersubtitles.v1.SeasonSummary
+	(*ShowSeasons)(nil),                  // 36: supersubtitles.v1.ShowSeasons
+	(*DownloadChunk)(nil),                // 37: supersubtitles.v1.DownloadChunk
+	(*ExportShowSubtitlesRequest)(nil),   // 38: supersubtitles.v1.ExportShowSubtitlesRequest
+	(*FindShowRequest)(nil),              // 39: supersubtitles.v1.FindShowRequest
+	(*GetLanguagesRequest)(nil),          // 40: supersubtitles.v1.GetLanguagesRequest
+	(*Language)(nil),                     // 41: supersubtitles.v1.Language
+	(*GetLanguagesResponse)(nil),         // 42: supersubtitles.v1.GetLanguagesResponse
+	(*GetSubtitleDetailsRequest)(nil),    // 43: supersubtitles.v1.GetSubtitleDetailsRequest
+	(*GetSubtitleRequest)(nil),           // 44: supersubtitles.v1.GetSubtitleRequest
+	(*GetSubtitleByIdRequest)(nil),       // 45: supersubtitles.v1.GetSubtitleByIdRequest
+	(*SubtitleDetails)(nil),              // 46: supersubtitles.v1.SubtitleDetails
+	(*GetStatusRequest)(nil),             // 47: supersubtitles.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),            // 48: supersubtitles.v1.GetStatusResponse
+	(*SelfCheckRequest)(nil),             // 49: supersubtitles.v1.SelfCheckRequest
+	(*SelfCheckResponse)(nil),            // 50: supersubtitles.v1.SelfCheckResponse
+	(*GetShowImageRequest)(nil),          // 51: supersubtitles.v1.GetShowImageRequest
+	(*ShowImage)(nil),                    // 52: supersubtitles.v1.ShowImage
+	(*GetServerInfoRequest)(nil),         // 53: supersubtitles.v1.GetServerInfoRequest
+	(*ServerInfo)(nil),                   // 54: supersubtitles.v1.ServerInfo
+	(*ServerLimits)(nil),                 // 55: supersubtitles.v1.ServerLimits
+	(*timestamppb.Timestamp)(nil),        // 56: google.protobuf.Timestamp
 }
 var file_supersubtitles_proto_depIdxs = []int32{
-	14, // 0: supersubtitles.v1.Subtitle.uploaded_at:type_name -> google.protobuf.Timestamp
-	0,  // 1: supersubtitles.v1.Subtitle.qualities:type_name -> supersubtitles.v1.Quality
-	1,  // 2: supersubtitles.v1.ShowInfo.show:type_name -> supersubtitles.v1.Show
-	2,  // 3: supersubtitles.v1.ShowInfo.third_party_ids:type_name -> supersubtitles.v1.ThirdPartyIds
-	4,  // 4: supersubtitles.v1.ShowSubtitlesCollection.show_info:type_name -> supersubtitles.v1.ShowInfo
-	3,  // 5: supersubtitles.v1.ShowSubtitlesCollection.subtitles:type_name -> supersubtitles.v1.Subtitle
-	1,  // 6: supersubtitles.v1.GetShowSubtitlesRequest.shows:type_name -> supersubtitles.v1.Show
-	6,  // 7: supersubtitles.v1.SuperSubtitlesService.GetShowList:input_type -> supersubtitles.v1.GetShowListRequest
-	7,  // 8: supersubtitles.v1.SuperSubtitlesService.GetSubtitles:input_type -> supersubtitles.v1.GetSubtitlesRequest
-	8,  // 9: supersubtitles.v1.SuperSubtitlesService.GetShowSubtitles:input_type -> supersubtitles.v1.GetShowSubtitlesRequest
-	9,  // 10: supersubtitles.v1.SuperSubtitlesService.CheckForUpdates:input_type -> supersubtitles.v1.CheckForUpdatesRequest
-	11, // 11: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitle:input_type -> supersubtitles.v1.DownloadSubtitleRequest
-	13, // 12: supersubtitles.v1.SuperSubtitlesService.GetRecentSubtitles:input_type -> supersubtitles.v1.GetRecentSubtitlesRequest
-	1,  // 13: supersubtitles.v1.SuperSubtitlesService.GetShowList:output_type -> supersubtitles.v1.Show
-	3,  // 14: supersubtitles.v1.SuperSubtitlesService.GetSubtitles:output_type -> supersubtitles.v1.Subtitle
-	5,  // 15: supersubtitles.v1.SuperSubtitlesService.GetShowSubtitles:output_type -> supersubtitles.v1.ShowSubtitlesCollection
-	10, // 16: supersubtitles.v1.SuperSubtitlesService.CheckForUpdates:output_type -> supersubtitles.v1.CheckForUpdatesResponse
-	12, // 17: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitle:output_type -> supersubtitles.v1.DownloadSubtitleResponse
-	5,  // 18: supersubtitles.v1.SuperSubtitlesService.GetRecentSubtitles:output_type -> supersubtitles.v1.ShowSubtitlesCollection
-	13, // [13:19] is the sub-list for method output_type
-	7,  // [7:13] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	0,  // 0: supersubtitles.v1.Show.sources:type_name -> supersubtitles.v1.ShowSource
+	56, // 1: supersubtitles.v1.Subtitle.uploaded_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: supersubtitles.v1.Subtitle.qualities:type_name -> supersubtitles.v1.Quality
+	7,  // 3: supersubtitles.v1.Subtitle.release_variants:type_name -> supersubtitles.v1.ReleaseVariant
+	2,  // 4: supersubtitles.v1.Subtitle.content_type:type_name -> supersubtitles.v1.ContentType
+	56, // 5: supersubtitles.v1.Subtitle.air_date:type_name -> google.protobuf.Timestamp
+	3,  // 6: supersubtitles.v1.ReleaseVariant.quality:type_name -> supersubtitles.v1.Quality
+	4,  // 7: supersubtitles.v1.ShowInfo.show:type_name -> supersubtitles.v1.Show
+	5,  // 8: supersubtitles.v1.ShowInfo.third_party_ids:type_name -> supersubtitles.v1.ThirdPartyIds
+	8,  // 9: supersubtitles.v1.ShowSubtitlesCollection.show_info:type_name -> supersubtitles.v1.ShowInfo
+	6,  // 10: supersubtitles.v1.ShowSubtitlesCollection.subtitles:type_name -> supersubtitles.v1.Subtitle
+	4,  // 11: supersubtitles.v1.ListShowsResponse.shows:type_name -> supersubtitles.v1.Show
+	1,  // 12: supersubtitles.v1.GetSubtitlesRequest.order_by:type_name -> supersubtitles.v1.SubtitleOrder
+	4,  // 13: supersubtitles.v1.GetShowSubtitlesRequest.shows:type_name -> supersubtitles.v1.Show
+	56, // 14: supersubtitles.v1.CheckForUpdatesResponse.checked_at:type_name -> google.protobuf.Timestamp
+	6,  // 15: supersubtitles.v1.FindSubtitleResponse.subtitles:type_name -> supersubtitles.v1.Subtitle
+	3,  // 16: supersubtitles.v1.GetBestSubtitlesRequest.preferred_quality:type_name -> supersubtitles.v1.Quality
+	56, // 17: supersubtitles.v1.LanguageStats.newest_uploaded_at:type_name -> google.protobuf.Timestamp
+	31, // 18: supersubtitles.v1.ShowLanguageStats.languages:type_name -> supersubtitles.v1.LanguageStats
+	56, // 19: supersubtitles.v1.SeasonSummary.latest_upload:type_name -> google.protobuf.Timestamp
+	35, // 20: supersubtitles.v1.ShowSeasons.seasons:type_name -> supersubtitles.v1.SeasonSummary
+	41, // 21: supersubtitles.v1.GetLanguagesResponse.languages:type_name -> supersubtitles.v1.Language
+	5,  // 22: supersubtitles.v1.SubtitleDetails.third_party_ids:type_name -> supersubtitles.v1.ThirdPartyIds
+	56, // 23: supersubtitles.v1.GetStatusResponse.active_since:type_name -> google.protobuf.Timestamp
+	56, // 24: supersubtitles.v1.GetStatusResponse.blocked_since:type_name -> google.protobuf.Timestamp
+	55, // 25: supersubtitles.v1.ServerInfo.limits:type_name -> supersubtitles.v1.ServerLimits
+	10, // 26: supersubtitles.v1.SuperSubtitlesService.GetShowList:input_type -> supersubtitles.v1.GetShowListRequest
+	13, // 27: supersubtitles.v1.SuperSubtitlesService.GetSubtitles:input_type -> supersubtitles.v1.GetSubtitlesRequest
+	15, // 28: supersubtitles.v1.SuperSubtitlesService.GetShowSubtitles:input_type -> supersubtitles.v1.GetShowSubtitlesRequest
+	16, // 29: supersubtitles.v1.SuperSubtitlesService.CheckForUpdates:input_type -> supersubtitles.v1.CheckForUpdatesRequest
+	18, // 30: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitle:input_type -> supersubtitles.v1.DownloadSubtitleRequest
+	20, // 31: supersubtitles.v1.SuperSubtitlesService.GetRecentSubtitles:input_type -> supersubtitles.v1.GetRecentSubtitlesRequest
+	21, // 32: supersubtitles.v1.SuperSubtitlesService.InvalidateCache:input_type -> supersubtitles.v1.InvalidateCacheRequest
+	23, // 33: supersubtitles.v1.SuperSubtitlesService.ClearCache:input_type -> supersubtitles.v1.ClearCacheRequest
+	25, // 34: supersubtitles.v1.SuperSubtitlesService.GetLatestSubtitleId:input_type -> supersubtitles.v1.GetLatestSubtitleIdRequest
+	27, // 35: supersubtitles.v1.SuperSubtitlesService.FindSubtitle:input_type -> supersubtitles.v1.FindSubtitleRequest
+	29, // 36: supersubtitles.v1.SuperSubtitlesService.GetBestSubtitles:input_type -> supersubtitles.v1.GetBestSubtitlesRequest
+	30, // 37: supersubtitles.v1.SuperSubtitlesService.GetShowLanguageStats:input_type -> supersubtitles.v1.GetShowLanguageStatsRequest
+	33, // 38: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitleByUrl:input_type -> supersubtitles.v1.DownloadSubtitleByUrlRequest
+	34, // 39: supersubtitles.v1.SuperSubtitlesService.GetShowSeasons:input_type -> supersubtitles.v1.GetShowSeasonsRequest
+	18, // 40: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitleStream:input_type -> supersubtitles.v1.DownloadSubtitleRequest
+	39, // 41: supersubtitles.v1.SuperSubtitlesService.FindShow:input_type -> supersubtitles.v1.FindShowRequest
+	40, // 42: supersubtitles.v1.SuperSubtitlesService.GetLanguages:input_type -> supersubtitles.v1.GetLanguagesRequest
+	43, // 43: supersubtitles.v1.SuperSubtitlesService.GetSubtitleDetails:input_type -> supersubtitles.v1.GetSubtitleDetailsRequest
+	44, // 44: supersubtitles.v1.SuperSubtitlesService.GetSubtitle:input_type -> supersubtitles.v1.GetSubtitleRequest
+	45, // 45: supersubtitles.v1.SuperSubtitlesService.GetSubtitleById:input_type -> supersubtitles.v1.GetSubtitleByIdRequest
+	47, // 46: supersubtitles.v1.SuperSubtitlesService.GetStatus:input_type -> supersubtitles.v1.GetStatusRequest
+	11, // 47: supersubtitles.v1.SuperSubtitlesService.ListShows:input_type -> supersubtitles.v1.ListShowsRequest
+	49, // 48: supersubtitles.v1.SuperSubtitlesService.SelfCheck:input_type -> supersubtitles.v1.SelfCheckRequest
+	51, // 49: supersubtitles.v1.SuperSubtitlesService.GetShowImage:input_type -> supersubtitles.v1.GetShowImageRequest
+	14, // 50: supersubtitles.v1.SuperSubtitlesService.GetMovieSubtitles:input_type -> supersubtitles.v1.GetMovieSubtitlesRequest
+	38, // 51: supersubtitles.v1.SuperSubtitlesService.ExportShowSubtitles:input_type -> supersubtitles.v1.ExportShowSubtitlesRequest
+	53, // 52: supersubtitles.v1.SuperSubtitlesService.GetServerInfo:input_type -> supersubtitles.v1.GetServerInfoRequest
+	4,  // 53: supersubtitles.v1.SuperSubtitlesService.GetShowList:output_type -> supersubtitles.v1.Show
+	6,  // 54: supersubtitles.v1.SuperSubtitlesService.GetSubtitles:output_type -> supersubtitles.v1.Subtitle
+	9,  // 55: supersubtitles.v1.SuperSubtitlesService.GetShowSubtitles:output_type -> supersubtitles.v1.ShowSubtitlesCollection
+	17, // 56: supersubtitles.v1.SuperSubtitlesService.CheckForUpdates:output_type -> supersubtitles.v1.CheckForUpdatesResponse
+	19, // 57: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitle:output_type -> supersubtitles.v1.DownloadSubtitleResponse
+	9,  // 58: supersubtitles.v1.SuperSubtitlesService.GetRecentSubtitles:output_type -> supersubtitles.v1.ShowSubtitlesCollection
+	22, // 59: supersubtitles.v1.SuperSubtitlesService.InvalidateCache:output_type -> supersubtitles.v1.InvalidateCacheResponse
+	24, // 60: supersubtitles.v1.SuperSubtitlesService.ClearCache:output_type -> supersubtitles.v1.ClearCacheResponse
+	26, // 61: supersubtitles.v1.SuperSubtitlesService.GetLatestSubtitleId:output_type -> supersubtitles.v1.GetLatestSubtitleIdResponse
+	28, // 62: supersubtitles.v1.SuperSubtitlesService.FindSubtitle:output_type -> supersubtitles.v1.FindSubtitleResponse
+	6,  // 63: supersubtitles.v1.SuperSubtitlesService.GetBestSubtitles:output_type -> supersubtitles.v1.Subtitle
+	32, // 64: supersubtitles.v1.SuperSubtitlesService.GetShowLanguageStats:output_type -> supersubtitles.v1.ShowLanguageStats
+	19, // 65: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitleByUrl:output_type -> supersubtitles.v1.DownloadSubtitleResponse
+	36, // 66: supersubtitles.v1.SuperSubtitlesService.GetShowSeasons:output_type -> supersubtitles.v1.ShowSeasons
+	37, // 67: supersubtitles.v1.SuperSubtitlesService.DownloadSubtitleStream:output_type -> supersubtitles.v1.DownloadChunk
+	4,  // 68: supersubtitles.v1.SuperSubtitlesService.FindShow:output_type -> supersubtitles.v1.Show
+	42, // 69: supersubtitles.v1.SuperSubtitlesService.GetLanguages:output_type -> supersubtitles.v1.GetLanguagesResponse
+	46, // 70: supersubtitles.v1.SuperSubtitlesService.GetSubtitleDetails:output_type -> supersubtitles.v1.SubtitleDetails
+	6,  // 71: supersubtitles.v1.SuperSubtitlesService.GetSubtitle:output_type -> supersubtitles.v1.Subtitle
+	6,  // 72: supersubtitles.v1.SuperSubtitlesService.GetSubtitleById:output_type -> supersubtitles.v1.Subtitle
+	48, // 73: supersubtitles.v1.SuperSubtitlesService.GetStatus:output_type -> supersubtitles.v1.GetStatusResponse
+	12, // 74: supersubtitles.v1.SuperSubtitlesService.ListShows:output_type -> supersubtitles.v1.ListShowsResponse
+	50, // 75: supersubtitles.v1.SuperSubtitlesService.SelfCheck:output_type -> supersubtitles.v1.SelfCheckResponse
+	52, // 76: supersubtitles.v1.SuperSubtitlesService.GetShowImage:output_type -> supersubtitles.v1.ShowImage
+	6,  // 77: supersubtitles.v1.SuperSubtitlesService.GetMovieSubtitles:output_type -> supersubtitles.v1.Subtitle
+	37, // 78: supersubtitles.v1.SuperSubtitlesService.ExportShowSubtitles:output_type -> supersubtitles.v1.DownloadChunk
+	54, // 79: supersubtitles.v1.SuperSubtitlesService.GetServerInfo:output_type -> supersubtitles.v1.ServerInfo
+	53, // [53:80] is the sub-list for method output_type
+	26, // [26:53] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_supersubtitles_proto_init() }
@@ -1042,14 +4001,17 @@ func file_supersubtitles_proto_init() {
 		return
 	}
 	file_supersubtitles_proto_msgTypes[2].OneofWrappers = []any{}
-	file_supersubtitles_proto_msgTypes[10].OneofWrappers = []any{}
+	file_supersubtitles_proto_msgTypes[14].OneofWrappers = []any{}
+	file_supersubtitles_proto_msgTypes[29].OneofWrappers = []any{}
+	file_supersubtitles_proto_msgTypes[34].OneofWrappers = []any{}
+	file_supersubtitles_proto_msgTypes[35].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supersubtitles_proto_rawDesc), len(file_supersubtitles_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   13,
+			NumEnums:      4,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
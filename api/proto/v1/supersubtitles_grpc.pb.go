@@ -19,12 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SuperSubtitlesService_GetShowList_FullMethodName        = "/supersubtitles.v1.SuperSubtitlesService/GetShowList"
-	SuperSubtitlesService_GetSubtitles_FullMethodName       = "/supersubtitles.v1.SuperSubtitlesService/GetSubtitles"
-	SuperSubtitlesService_GetShowSubtitles_FullMethodName   = "/supersubtitles.v1.SuperSubtitlesService/GetShowSubtitles"
-	SuperSubtitlesService_CheckForUpdates_FullMethodName    = "/supersubtitles.v1.SuperSubtitlesService/CheckForUpdates"
-	SuperSubtitlesService_DownloadSubtitle_FullMethodName   = "/supersubtitles.v1.SuperSubtitlesService/DownloadSubtitle"
-	SuperSubtitlesService_GetRecentSubtitles_FullMethodName = "/supersubtitles.v1.SuperSubtitlesService/GetRecentSubtitles"
+	SuperSubtitlesService_GetShowList_FullMethodName            = "/supersubtitles.v1.SuperSubtitlesService/GetShowList"
+	SuperSubtitlesService_GetSubtitles_FullMethodName           = "/supersubtitles.v1.SuperSubtitlesService/GetSubtitles"
+	SuperSubtitlesService_GetShowSubtitles_FullMethodName       = "/supersubtitles.v1.SuperSubtitlesService/GetShowSubtitles"
+	SuperSubtitlesService_CheckForUpdates_FullMethodName        = "/supersubtitles.v1.SuperSubtitlesService/CheckForUpdates"
+	SuperSubtitlesService_DownloadSubtitle_FullMethodName       = "/supersubtitles.v1.SuperSubtitlesService/DownloadSubtitle"
+	SuperSubtitlesService_GetRecentSubtitles_FullMethodName     = "/supersubtitles.v1.SuperSubtitlesService/GetRecentSubtitles"
+	SuperSubtitlesService_InvalidateCache_FullMethodName        = "/supersubtitles.v1.SuperSubtitlesService/InvalidateCache"
+	SuperSubtitlesService_ClearCache_FullMethodName             = "/supersubtitles.v1.SuperSubtitlesService/ClearCache"
+	SuperSubtitlesService_GetLatestSubtitleId_FullMethodName    = "/supersubtitles.v1.SuperSubtitlesService/GetLatestSubtitleId"
+	SuperSubtitlesService_FindSubtitle_FullMethodName           = "/supersubtitles.v1.SuperSubtitlesService/FindSubtitle"
+	SuperSubtitlesService_GetBestSubtitles_FullMethodName       = "/supersubtitles.v1.SuperSubtitlesService/GetBestSubtitles"
+	SuperSubtitlesService_GetShowLanguageStats_FullMethodName   = "/supersubtitles.v1.SuperSubtitlesService/GetShowLanguageStats"
+	SuperSubtitlesService_DownloadSubtitleByUrl_FullMethodName  = "/supersubtitles.v1.SuperSubtitlesService/DownloadSubtitleByUrl"
+	SuperSubtitlesService_GetShowSeasons_FullMethodName         = "/supersubtitles.v1.SuperSubtitlesService/GetShowSeasons"
+	SuperSubtitlesService_DownloadSubtitleStream_FullMethodName = "/supersubtitles.v1.SuperSubtitlesService/DownloadSubtitleStream"
+	SuperSubtitlesService_FindShow_FullMethodName               = "/supersubtitles.v1.SuperSubtitlesService/FindShow"
+	SuperSubtitlesService_GetLanguages_FullMethodName           = "/supersubtitles.v1.SuperSubtitlesService/GetLanguages"
+	SuperSubtitlesService_GetSubtitleDetails_FullMethodName     = "/supersubtitles.v1.SuperSubtitlesService/GetSubtitleDetails"
+	SuperSubtitlesService_GetSubtitle_FullMethodName            = "/supersubtitles.v1.SuperSubtitlesService/GetSubtitle"
+	SuperSubtitlesService_GetSubtitleById_FullMethodName        = "/supersubtitles.v1.SuperSubtitlesService/GetSubtitleById"
+	SuperSubtitlesService_GetStatus_FullMethodName              = "/supersubtitles.v1.SuperSubtitlesService/GetStatus"
+	SuperSubtitlesService_ListShows_FullMethodName              = "/supersubtitles.v1.SuperSubtitlesService/ListShows"
+	SuperSubtitlesService_SelfCheck_FullMethodName              = "/supersubtitles.v1.SuperSubtitlesService/SelfCheck"
+	SuperSubtitlesService_GetShowImage_FullMethodName           = "/supersubtitles.v1.SuperSubtitlesService/GetShowImage"
+	SuperSubtitlesService_GetServerInfo_FullMethodName          = "/supersubtitles.v1.SuperSubtitlesService/GetServerInfo"
+	SuperSubtitlesService_GetMovieSubtitles_FullMethodName      = "/supersubtitles.v1.SuperSubtitlesService/GetMovieSubtitles"
+	SuperSubtitlesService_ExportShowSubtitles_FullMethodName    = "/supersubtitles.v1.SuperSubtitlesService/ExportShowSubtitles"
 )
 
 // SuperSubtitlesServiceClient is the client API for SuperSubtitlesService service.
@@ -35,7 +56,9 @@ const (
 // Uses server-side streaming for list/collection endpoints to improve
 // time-to-first-result and reduce memory usage.
 type SuperSubtitlesServiceClient interface {
-	// GetShowList streams all available TV shows
+	// GetShowList streams all available TV shows. Shows are merged across the show list endpoints,
+	// so none is sent until every endpoint's last page has loaded. Setting page_token or page_size
+	// streams them ordered by ID and returns a cursor for the next page in the x-next-page-token trailer.
 	GetShowList(ctx context.Context, in *GetShowListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Show], error)
 	// GetSubtitles streams all subtitles for a specific show
 	GetSubtitles(ctx context.Context, in *GetSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Subtitle], error)
@@ -50,6 +73,69 @@ type SuperSubtitlesServiceClient interface {
 	// Streams ShowSubtitlesCollection messages: each message contains a show's
 	// complete information and all its recent subtitles.
 	GetRecentSubtitles(ctx context.Context, in *GetRecentSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ShowSubtitlesCollection], error)
+	// InvalidateCache removes the cached archives of a single subtitle so the next
+	// download fetches a fresh copy from upstream.
+	InvalidateCache(ctx context.Context, in *InvalidateCacheRequest, opts ...grpc.CallOption) (*InvalidateCacheResponse, error)
+	// ClearCache flushes every cached archive.
+	ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*ClearCacheResponse, error)
+	// GetLatestSubtitleId returns the newest subtitle ID on the recent listing, a cheap
+	// high-water mark for incremental sync.
+	GetLatestSubtitleId(ctx context.Context, in *GetLatestSubtitleIdRequest, opts ...grpc.CallOption) (*GetLatestSubtitleIdResponse, error)
+	// FindSubtitle returns the subtitles for one episode from the in-memory index,
+	// fetching and indexing the show's subtitles on a miss.
+	FindSubtitle(ctx context.Context, in *FindSubtitleRequest, opts ...grpc.CallOption) (*FindSubtitleResponse, error)
+	// GetBestSubtitles streams the single best subtitle for each episode of a show,
+	// ranked by language, then quality, then most recent upload.
+	GetBestSubtitles(ctx context.Context, in *GetBestSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Subtitle], error)
+	// GetShowLanguageStats summarizes which languages a show has subtitles in
+	// and how fresh they are.
+	GetShowLanguageStats(ctx context.Context, in *GetShowLanguageStatsRequest, opts ...grpc.CallOption) (*ShowLanguageStats, error)
+	// DownloadSubtitleByUrl downloads a subtitle from a full feliratok download link.
+	// Links that do not point at the configured site are rejected with INVALID_ARGUMENT.
+	DownloadSubtitleByUrl(ctx context.Context, in *DownloadSubtitleByUrlRequest, opts ...grpc.CallOption) (*DownloadSubtitleResponse, error)
+	// GetShowSeasons lists the seasons of a show that have subtitles
+	// with the number of episodes covered in each.
+	GetShowSeasons(ctx context.Context, in *GetShowSeasonsRequest, opts ...grpc.CallOption) (*ShowSeasons, error)
+	// DownloadSubtitleStream downloads a subtitle like DownloadSubtitle but streams it:
+	// the first message carries the metadata, the following ones the content in chunks.
+	DownloadSubtitleStream(ctx context.Context, in *DownloadSubtitleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error)
+	// FindShow returns the show matching a name, ignoring case and diacritics.
+	// The optional year disambiguates shows sharing a name; without it several matches fail with INVALID_ARGUMENT.
+	FindShow(ctx context.Context, in *FindShowRequest, opts ...grpc.CallOption) (*Show, error)
+	// GetLanguages lists the subtitle languages the service recognizes, with their ISO code
+	// and Hungarian and English names, for building language pickers.
+	GetLanguages(ctx context.Context, in *GetLanguagesRequest, opts ...grpc.CallOption) (*GetLanguagesResponse, error)
+	// GetSubtitleDetails returns the detail page of a subtitle: filename, uploader, the uploader's
+	// comment (such as the release it fits) and the show's third-party IDs.
+	GetSubtitleDetails(ctx context.Context, in *GetSubtitleDetailsRequest, opts ...grpc.CallOption) (*SubtitleDetails, error)
+	// GetSubtitle returns one subtitle of a show by ID, such as one kept from an earlier listing.
+	// The ID is looked up in the given show's subtitles, so the result carries the listing's fields.
+	// GetSubtitleDetails returns the subtitle's detail page, and GetSubtitleById needs no show ID.
+	GetSubtitle(ctx context.Context, in *GetSubtitleRequest, opts ...grpc.CallOption) (*Subtitle, error)
+	// GetSubtitleById returns one subtitle by ID alone, such as one from an old bookmark or log line.
+	// Recent uploads come from the listing; older ones are built from the detail page and filename.
+	GetSubtitleById(ctx context.Context, in *GetSubtitleByIdRequest, opts ...grpc.CallOption) (*Subtitle, error)
+	// GetStatus reports which upstream mirror requests are sent to, for diagnosing failovers.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// ListShows returns one page of the show list ordered by year, then name. Pass next_page_token
+	// as page_token to get the following page.
+	ListShows(ctx context.Context, in *ListShowsRequest, opts ...grpc.CallOption) (*ListShowsResponse, error)
+	// SelfCheck fetches the listing of a known show and checks that it still parses into plausible
+	// subtitles, as a canary for changes to the site's HTML.
+	SelfCheck(ctx context.Context, in *SelfCheckRequest, opts ...grpc.CallOption) (*SelfCheckResponse, error)
+	// GetShowImage returns the poster of a show, fetched from the site and cached, so clients do
+	// not depend on the site allowing hotlinked images.
+	GetShowImage(ctx context.Context, in *GetShowImageRequest, opts ...grpc.CallOption) (*ShowImage, error)
+	// GetServerInfo reports the server's version, the API version it serves, the optional
+	// features its configuration enables and the limits it applies, so clients can adapt to it.
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error)
+	// GetMovieSubtitles streams all subtitles for a specific film. Films have no season or
+	// episode, so both are -1 and content_type is CONTENT_TYPE_FILM.
+	GetMovieSubtitles(ctx context.Context, in *GetMovieSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Subtitle], error)
+	// ExportShowSubtitles streams every subtitle file of a show as one ZIP archive built on the fly,
+	// in the same chunks as DownloadSubtitleStream. The archive ends with a manifest.json listing
+	// each file and each download that failed.
+	ExportShowSubtitles(ctx context.Context, in *ExportShowSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error)
 }
 
 type superSubtitlesServiceClient struct {
@@ -156,6 +242,252 @@ func (c *superSubtitlesServiceClient) GetRecentSubtitles(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SuperSubtitlesService_GetRecentSubtitlesClient = grpc.ServerStreamingClient[ShowSubtitlesCollection]
 
+func (c *superSubtitlesServiceClient) InvalidateCache(ctx context.Context, in *InvalidateCacheRequest, opts ...grpc.CallOption) (*InvalidateCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateCacheResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_InvalidateCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*ClearCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearCacheResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_ClearCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetLatestSubtitleId(ctx context.Context, in *GetLatestSubtitleIdRequest, opts ...grpc.CallOption) (*GetLatestSubtitleIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLatestSubtitleIdResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetLatestSubtitleId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) FindSubtitle(ctx context.Context, in *FindSubtitleRequest, opts ...grpc.CallOption) (*FindSubtitleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSubtitleResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_FindSubtitle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetBestSubtitles(ctx context.Context, in *GetBestSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Subtitle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperSubtitlesService_ServiceDesc.Streams[4], SuperSubtitlesService_GetBestSubtitles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetBestSubtitlesRequest, Subtitle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_GetBestSubtitlesClient = grpc.ServerStreamingClient[Subtitle]
+
+func (c *superSubtitlesServiceClient) GetShowLanguageStats(ctx context.Context, in *GetShowLanguageStatsRequest, opts ...grpc.CallOption) (*ShowLanguageStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShowLanguageStats)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetShowLanguageStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) DownloadSubtitleByUrl(ctx context.Context, in *DownloadSubtitleByUrlRequest, opts ...grpc.CallOption) (*DownloadSubtitleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadSubtitleResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_DownloadSubtitleByUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetShowSeasons(ctx context.Context, in *GetShowSeasonsRequest, opts ...grpc.CallOption) (*ShowSeasons, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShowSeasons)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetShowSeasons_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) DownloadSubtitleStream(ctx context.Context, in *DownloadSubtitleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperSubtitlesService_ServiceDesc.Streams[5], SuperSubtitlesService_DownloadSubtitleStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadSubtitleRequest, DownloadChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_DownloadSubtitleStreamClient = grpc.ServerStreamingClient[DownloadChunk]
+
+func (c *superSubtitlesServiceClient) FindShow(ctx context.Context, in *FindShowRequest, opts ...grpc.CallOption) (*Show, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Show)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_FindShow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetLanguages(ctx context.Context, in *GetLanguagesRequest, opts ...grpc.CallOption) (*GetLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLanguagesResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetSubtitleDetails(ctx context.Context, in *GetSubtitleDetailsRequest, opts ...grpc.CallOption) (*SubtitleDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubtitleDetails)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetSubtitleDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetSubtitle(ctx context.Context, in *GetSubtitleRequest, opts ...grpc.CallOption) (*Subtitle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subtitle)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetSubtitle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetSubtitleById(ctx context.Context, in *GetSubtitleByIdRequest, opts ...grpc.CallOption) (*Subtitle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subtitle)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetSubtitleById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) ListShows(ctx context.Context, in *ListShowsRequest, opts ...grpc.CallOption) (*ListShowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShowsResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_ListShows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) SelfCheck(ctx context.Context, in *SelfCheckRequest, opts ...grpc.CallOption) (*SelfCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SelfCheckResponse)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_SelfCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetShowImage(ctx context.Context, in *GetShowImageRequest, opts ...grpc.CallOption) (*ShowImage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShowImage)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetShowImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, SuperSubtitlesService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superSubtitlesServiceClient) GetMovieSubtitles(ctx context.Context, in *GetMovieSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Subtitle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperSubtitlesService_ServiceDesc.Streams[6], SuperSubtitlesService_GetMovieSubtitles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetMovieSubtitlesRequest, Subtitle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_GetMovieSubtitlesClient = grpc.ServerStreamingClient[Subtitle]
+
+func (c *superSubtitlesServiceClient) ExportShowSubtitles(ctx context.Context, in *ExportShowSubtitlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperSubtitlesService_ServiceDesc.Streams[7], SuperSubtitlesService_ExportShowSubtitles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportShowSubtitlesRequest, DownloadChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_ExportShowSubtitlesClient = grpc.ServerStreamingClient[DownloadChunk]
+
 // SuperSubtitlesServiceServer is the server API for SuperSubtitlesService service.
 // All implementations must embed UnimplementedSuperSubtitlesServiceServer
 // for forward compatibility.
@@ -164,7 +496,9 @@ type SuperSubtitlesService_GetRecentSubtitlesClient = grpc.ServerStreamingClient
 // Uses server-side streaming for list/collection endpoints to improve
 // time-to-first-result and reduce memory usage.
 type SuperSubtitlesServiceServer interface {
-	// GetShowList streams all available TV shows
+	// GetShowList streams all available TV shows. Shows are merged across the show list endpoints,
+	// so none is sent until every endpoint's last page has loaded. Setting page_token or page_size
+	// streams them ordered by ID and returns a cursor for the next page in the x-next-page-token trailer.
 	GetShowList(*GetShowListRequest, grpc.ServerStreamingServer[Show]) error
 	// GetSubtitles streams all subtitles for a specific show
 	GetSubtitles(*GetSubtitlesRequest, grpc.ServerStreamingServer[Subtitle]) error
@@ -179,6 +513,69 @@ type SuperSubtitlesServiceServer interface {
 	// Streams ShowSubtitlesCollection messages: each message contains a show's
 	// complete information and all its recent subtitles.
 	GetRecentSubtitles(*GetRecentSubtitlesRequest, grpc.ServerStreamingServer[ShowSubtitlesCollection]) error
+	// InvalidateCache removes the cached archives of a single subtitle so the next
+	// download fetches a fresh copy from upstream.
+	InvalidateCache(context.Context, *InvalidateCacheRequest) (*InvalidateCacheResponse, error)
+	// ClearCache flushes every cached archive.
+	ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error)
+	// GetLatestSubtitleId returns the newest subtitle ID on the recent listing, a cheap
+	// high-water mark for incremental sync.
+	GetLatestSubtitleId(context.Context, *GetLatestSubtitleIdRequest) (*GetLatestSubtitleIdResponse, error)
+	// FindSubtitle returns the subtitles for one episode from the in-memory index,
+	// fetching and indexing the show's subtitles on a miss.
+	FindSubtitle(context.Context, *FindSubtitleRequest) (*FindSubtitleResponse, error)
+	// GetBestSubtitles streams the single best subtitle for each episode of a show,
+	// ranked by language, then quality, then most recent upload.
+	GetBestSubtitles(*GetBestSubtitlesRequest, grpc.ServerStreamingServer[Subtitle]) error
+	// GetShowLanguageStats summarizes which languages a show has subtitles in
+	// and how fresh they are.
+	GetShowLanguageStats(context.Context, *GetShowLanguageStatsRequest) (*ShowLanguageStats, error)
+	// DownloadSubtitleByUrl downloads a subtitle from a full feliratok download link.
+	// Links that do not point at the configured site are rejected with INVALID_ARGUMENT.
+	DownloadSubtitleByUrl(context.Context, *DownloadSubtitleByUrlRequest) (*DownloadSubtitleResponse, error)
+	// GetShowSeasons lists the seasons of a show that have subtitles
+	// with the number of episodes covered in each.
+	GetShowSeasons(context.Context, *GetShowSeasonsRequest) (*ShowSeasons, error)
+	// DownloadSubtitleStream downloads a subtitle like DownloadSubtitle but streams it:
+	// the first message carries the metadata, the following ones the content in chunks.
+	DownloadSubtitleStream(*DownloadSubtitleRequest, grpc.ServerStreamingServer[DownloadChunk]) error
+	// FindShow returns the show matching a name, ignoring case and diacritics.
+	// The optional year disambiguates shows sharing a name; without it several matches fail with INVALID_ARGUMENT.
+	FindShow(context.Context, *FindShowRequest) (*Show, error)
+	// GetLanguages lists the subtitle languages the service recognizes, with their ISO code
+	// and Hungarian and English names, for building language pickers.
+	GetLanguages(context.Context, *GetLanguagesRequest) (*GetLanguagesResponse, error)
+	// GetSubtitleDetails returns the detail page of a subtitle: filename, uploader, the uploader's
+	// comment (such as the release it fits) and the show's third-party IDs.
+	GetSubtitleDetails(context.Context, *GetSubtitleDetailsRequest) (*SubtitleDetails, error)
+	// GetSubtitle returns one subtitle of a show by ID, such as one kept from an earlier listing.
+	// The ID is looked up in the given show's subtitles, so the result carries the listing's fields.
+	// GetSubtitleDetails returns the subtitle's detail page, and GetSubtitleById needs no show ID.
+	GetSubtitle(context.Context, *GetSubtitleRequest) (*Subtitle, error)
+	// GetSubtitleById returns one subtitle by ID alone, such as one from an old bookmark or log line.
+	// Recent uploads come from the listing; older ones are built from the detail page and filename.
+	GetSubtitleById(context.Context, *GetSubtitleByIdRequest) (*Subtitle, error)
+	// GetStatus reports which upstream mirror requests are sent to, for diagnosing failovers.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// ListShows returns one page of the show list ordered by year, then name. Pass next_page_token
+	// as page_token to get the following page.
+	ListShows(context.Context, *ListShowsRequest) (*ListShowsResponse, error)
+	// SelfCheck fetches the listing of a known show and checks that it still parses into plausible
+	// subtitles, as a canary for changes to the site's HTML.
+	SelfCheck(context.Context, *SelfCheckRequest) (*SelfCheckResponse, error)
+	// GetShowImage returns the poster of a show, fetched from the site and cached, so clients do
+	// not depend on the site allowing hotlinked images.
+	GetShowImage(context.Context, *GetShowImageRequest) (*ShowImage, error)
+	// GetServerInfo reports the server's version, the API version it serves, the optional
+	// features its configuration enables and the limits it applies, so clients can adapt to it.
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*ServerInfo, error)
+	// GetMovieSubtitles streams all subtitles for a specific film. Films have no season or
+	// episode, so both are -1 and content_type is CONTENT_TYPE_FILM.
+	GetMovieSubtitles(*GetMovieSubtitlesRequest, grpc.ServerStreamingServer[Subtitle]) error
+	// ExportShowSubtitles streams every subtitle file of a show as one ZIP archive built on the fly,
+	// in the same chunks as DownloadSubtitleStream. The archive ends with a manifest.json listing
+	// each file and each download that failed.
+	ExportShowSubtitles(*ExportShowSubtitlesRequest, grpc.ServerStreamingServer[DownloadChunk]) error
 	mustEmbedUnimplementedSuperSubtitlesServiceServer()
 }
 
@@ -207,6 +604,69 @@ func (UnimplementedSuperSubtitlesServiceServer) DownloadSubtitle(context.Context
 func (UnimplementedSuperSubtitlesServiceServer) GetRecentSubtitles(*GetRecentSubtitlesRequest, grpc.ServerStreamingServer[ShowSubtitlesCollection]) error {
 	return status.Error(codes.Unimplemented, "method GetRecentSubtitles not implemented")
 }
+func (UnimplementedSuperSubtitlesServiceServer) InvalidateCache(context.Context, *InvalidateCacheRequest) (*InvalidateCacheResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InvalidateCache not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) ClearCache(context.Context, *ClearCacheRequest) (*ClearCacheResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearCache not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetLatestSubtitleId(context.Context, *GetLatestSubtitleIdRequest) (*GetLatestSubtitleIdResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLatestSubtitleId not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) FindSubtitle(context.Context, *FindSubtitleRequest) (*FindSubtitleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindSubtitle not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetBestSubtitles(*GetBestSubtitlesRequest, grpc.ServerStreamingServer[Subtitle]) error {
+	return status.Error(codes.Unimplemented, "method GetBestSubtitles not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetShowLanguageStats(context.Context, *GetShowLanguageStatsRequest) (*ShowLanguageStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShowLanguageStats not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) DownloadSubtitleByUrl(context.Context, *DownloadSubtitleByUrlRequest) (*DownloadSubtitleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownloadSubtitleByUrl not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetShowSeasons(context.Context, *GetShowSeasonsRequest) (*ShowSeasons, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShowSeasons not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) DownloadSubtitleStream(*DownloadSubtitleRequest, grpc.ServerStreamingServer[DownloadChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadSubtitleStream not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) FindShow(context.Context, *FindShowRequest) (*Show, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindShow not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetLanguages(context.Context, *GetLanguagesRequest) (*GetLanguagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLanguages not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetSubtitleDetails(context.Context, *GetSubtitleDetailsRequest) (*SubtitleDetails, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSubtitleDetails not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetSubtitle(context.Context, *GetSubtitleRequest) (*Subtitle, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSubtitle not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetSubtitleById(context.Context, *GetSubtitleByIdRequest) (*Subtitle, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSubtitleById not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) ListShows(context.Context, *ListShowsRequest) (*ListShowsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListShows not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) SelfCheck(context.Context, *SelfCheckRequest) (*SelfCheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SelfCheck not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetShowImage(context.Context, *GetShowImageRequest) (*ShowImage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShowImage not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*ServerInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) GetMovieSubtitles(*GetMovieSubtitlesRequest, grpc.ServerStreamingServer[Subtitle]) error {
+	return status.Error(codes.Unimplemented, "method GetMovieSubtitles not implemented")
+}
+func (UnimplementedSuperSubtitlesServiceServer) ExportShowSubtitles(*ExportShowSubtitlesRequest, grpc.ServerStreamingServer[DownloadChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportShowSubtitles not implemented")
+}
 func (UnimplementedSuperSubtitlesServiceServer) mustEmbedUnimplementedSuperSubtitlesServiceServer() {}
 func (UnimplementedSuperSubtitlesServiceServer) testEmbeddedByValue()                               {}
 
@@ -308,6 +768,356 @@ func _SuperSubtitlesService_GetRecentSubtitles_Handler(srv interface{}, stream g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SuperSubtitlesService_GetRecentSubtitlesServer = grpc.ServerStreamingServer[ShowSubtitlesCollection]
 
+func _SuperSubtitlesService_InvalidateCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).InvalidateCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_InvalidateCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).InvalidateCache(ctx, req.(*InvalidateCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_ClearCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).ClearCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_ClearCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).ClearCache(ctx, req.(*ClearCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetLatestSubtitleId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestSubtitleIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetLatestSubtitleId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetLatestSubtitleId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetLatestSubtitleId(ctx, req.(*GetLatestSubtitleIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_FindSubtitle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSubtitleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).FindSubtitle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_FindSubtitle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).FindSubtitle(ctx, req.(*FindSubtitleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetBestSubtitles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBestSubtitlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuperSubtitlesServiceServer).GetBestSubtitles(m, &grpc.GenericServerStream[GetBestSubtitlesRequest, Subtitle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_GetBestSubtitlesServer = grpc.ServerStreamingServer[Subtitle]
+
+func _SuperSubtitlesService_GetShowLanguageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowLanguageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetShowLanguageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetShowLanguageStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetShowLanguageStats(ctx, req.(*GetShowLanguageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_DownloadSubtitleByUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadSubtitleByUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).DownloadSubtitleByUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_DownloadSubtitleByUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).DownloadSubtitleByUrl(ctx, req.(*DownloadSubtitleByUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetShowSeasons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowSeasonsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetShowSeasons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetShowSeasons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetShowSeasons(ctx, req.(*GetShowSeasonsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_DownloadSubtitleStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadSubtitleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuperSubtitlesServiceServer).DownloadSubtitleStream(m, &grpc.GenericServerStream[DownloadSubtitleRequest, DownloadChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_DownloadSubtitleStreamServer = grpc.ServerStreamingServer[DownloadChunk]
+
+func _SuperSubtitlesService_FindShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).FindShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_FindShow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).FindShow(ctx, req.(*FindShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetLanguages(ctx, req.(*GetLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetSubtitleDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubtitleDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitleDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetSubtitleDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitleDetails(ctx, req.(*GetSubtitleDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetSubtitle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubtitleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetSubtitle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitle(ctx, req.(*GetSubtitleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetSubtitleById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubtitleByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitleById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetSubtitleById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetSubtitleById(ctx, req.(*GetSubtitleByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_ListShows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).ListShows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_ListShows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).ListShows(ctx, req.(*ListShowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_SelfCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).SelfCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_SelfCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).SelfCheck(ctx, req.(*SelfCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetShowImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetShowImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetShowImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetShowImage(ctx, req.(*GetShowImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperSubtitlesServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperSubtitlesService_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperSubtitlesServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperSubtitlesService_GetMovieSubtitles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetMovieSubtitlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuperSubtitlesServiceServer).GetMovieSubtitles(m, &grpc.GenericServerStream[GetMovieSubtitlesRequest, Subtitle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_GetMovieSubtitlesServer = grpc.ServerStreamingServer[Subtitle]
+
+func _SuperSubtitlesService_ExportShowSubtitles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportShowSubtitlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuperSubtitlesServiceServer).ExportShowSubtitles(m, &grpc.GenericServerStream[ExportShowSubtitlesRequest, DownloadChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperSubtitlesService_ExportShowSubtitlesServer = grpc.ServerStreamingServer[DownloadChunk]
+
 // SuperSubtitlesService_ServiceDesc is the grpc.ServiceDesc for SuperSubtitlesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +1133,74 @@ var SuperSubtitlesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DownloadSubtitle",
 			Handler:    _SuperSubtitlesService_DownloadSubtitle_Handler,
 		},
+		{
+			MethodName: "InvalidateCache",
+			Handler:    _SuperSubtitlesService_InvalidateCache_Handler,
+		},
+		{
+			MethodName: "ClearCache",
+			Handler:    _SuperSubtitlesService_ClearCache_Handler,
+		},
+		{
+			MethodName: "GetLatestSubtitleId",
+			Handler:    _SuperSubtitlesService_GetLatestSubtitleId_Handler,
+		},
+		{
+			MethodName: "FindSubtitle",
+			Handler:    _SuperSubtitlesService_FindSubtitle_Handler,
+		},
+		{
+			MethodName: "GetShowLanguageStats",
+			Handler:    _SuperSubtitlesService_GetShowLanguageStats_Handler,
+		},
+		{
+			MethodName: "DownloadSubtitleByUrl",
+			Handler:    _SuperSubtitlesService_DownloadSubtitleByUrl_Handler,
+		},
+		{
+			MethodName: "GetShowSeasons",
+			Handler:    _SuperSubtitlesService_GetShowSeasons_Handler,
+		},
+		{
+			MethodName: "FindShow",
+			Handler:    _SuperSubtitlesService_FindShow_Handler,
+		},
+		{
+			MethodName: "GetLanguages",
+			Handler:    _SuperSubtitlesService_GetLanguages_Handler,
+		},
+		{
+			MethodName: "GetSubtitleDetails",
+			Handler:    _SuperSubtitlesService_GetSubtitleDetails_Handler,
+		},
+		{
+			MethodName: "GetSubtitle",
+			Handler:    _SuperSubtitlesService_GetSubtitle_Handler,
+		},
+		{
+			MethodName: "GetSubtitleById",
+			Handler:    _SuperSubtitlesService_GetSubtitleById_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _SuperSubtitlesService_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListShows",
+			Handler:    _SuperSubtitlesService_ListShows_Handler,
+		},
+		{
+			MethodName: "SelfCheck",
+			Handler:    _SuperSubtitlesService_SelfCheck_Handler,
+		},
+		{
+			MethodName: "GetShowImage",
+			Handler:    _SuperSubtitlesService_GetShowImage_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _SuperSubtitlesService_GetServerInfo_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -345,6 +1223,26 @@ var SuperSubtitlesService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SuperSubtitlesService_GetRecentSubtitles_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetBestSubtitles",
+			Handler:       _SuperSubtitlesService_GetBestSubtitles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadSubtitleStream",
+			Handler:       _SuperSubtitlesService_DownloadSubtitleStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMovieSubtitles",
+			Handler:       _SuperSubtitlesService_GetMovieSubtitles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportShowSubtitles",
+			Handler:       _SuperSubtitlesService_ExportShowSubtitles_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "supersubtitles.proto",
 }
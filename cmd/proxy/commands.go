@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// errMissingFlag is returned when a required command flag is not provided.
+var errMissingFlag = errors.New("missing required flag")
+
+var showsCommand = &command{
+	name:    "shows",
+	summary: "Print the show list",
+	setup: func(_ *flag.FlagSet) runFunc {
+		return func(ctx context.Context, c *cli, _ *config.Config, cl client.Client) error {
+			shows, err := collect(ctx, cl.StreamShowList(ctx, 0))
+			if err != nil {
+				return fmt.Errorf("failed to fetch show list: %w", err)
+			}
+			if c.opts.json {
+				return c.writeJSON(shows)
+			}
+			return c.writeTable([]string{"ID", "NAME", "YEAR"}, len(shows), func(i int) []any {
+				return []any{shows[i].ID, shows[i].Name, shows[i].Year}
+			})
+		}
+	},
+}
+
+var subtitlesCommand = &command{
+	name:    "subtitles",
+	summary: "Print the subtitles of a show",
+	setup: func(fs *flag.FlagSet) runFunc {
+		showID := fs.Int("show-id", 0, "Show ID to list subtitles for (required)")
+		lang := fs.String("lang", "", "Only list subtitles in this ISO 639-1 language, e.g. hu")
+		return func(ctx context.Context, c *cli, _ *config.Config, cl client.Client) error {
+			if *showID <= 0 {
+				return fmt.Errorf("%w: --show-id", errMissingFlag)
+			}
+			subtitles, err := collect(ctx, cl.StreamSubtitles(ctx, *showID))
+			if err != nil {
+				return fmt.Errorf("failed to fetch subtitles for show %d: %w", *showID, err)
+			}
+			if *lang != "" {
+				filtered := make([]models.Subtitle, 0, len(subtitles))
+				for _, subtitle := range subtitles {
+					if strings.EqualFold(subtitle.Language, *lang) {
+						filtered = append(filtered, subtitle)
+					}
+				}
+				subtitles = filtered
+			}
+			if c.opts.json {
+				return c.writeJSON(subtitles)
+			}
+			return c.writeTable([]string{"ID", "LANG", "SEASON", "EPISODE", "NAME"}, len(subtitles), func(i int) []any {
+				s := subtitles[i]
+				return []any{s.ID, s.Language, s.Season, s.Episode, s.Name}
+			})
+		}
+	},
+}
+
+// downloadOutput describes a downloaded subtitle file in --json mode.
+type downloadOutput struct {
+	Path        string `json:"path"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+}
+
+var downloadCommand = &command{
+	name:    "download",
+	summary: "Download a subtitle file",
+	setup: func(fs *flag.FlagSet) runFunc {
+		subtitleID := fs.String("subtitle-id", "", "Subtitle ID to download (required)")
+		episode := fs.Int("episode", 0, "Episode number to extract from a season pack (0 selects specials such as S00E00)")
+		episodeTitle := fs.String("episode-title", "", "Episode title to extract from a season pack, used when --episode is not set")
+		outDir := fs.String("out", ".", "Directory to write the subtitle file to")
+		return func(ctx context.Context, c *cli, _ *config.Config, cl client.Client) error {
+			if *subtitleID == "" {
+				return fmt.Errorf("%w: --subtitle-id", errMissingFlag)
+			}
+			opts := models.DownloadOptions{EpisodeTitle: *episodeTitle}
+			// Episode 0 is a valid choice, so tell an explicit --episode 0 apart from no flag
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "episode" {
+					opts.Episode = episode
+				}
+			})
+
+			result, err := cl.DownloadSubtitle(ctx, *subtitleID, opts)
+			if err != nil {
+				return fmt.Errorf("failed to download subtitle %s: %w", *subtitleID, err)
+			}
+
+			if err := os.MkdirAll(*outDir, 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			// The filename comes from the remote server, so never let it escape the output directory
+			path := filepath.Join(*outDir, filepath.Base(result.Filename))
+			if err := os.WriteFile(path, result.Content, 0o644); err != nil {
+				return fmt.Errorf("failed to write subtitle file: %w", err)
+			}
+
+			if c.opts.json {
+				return c.writeJSON(downloadOutput{
+					Path:        path,
+					Filename:    result.Filename,
+					ContentType: result.ContentType,
+					Size:        len(result.Content),
+				})
+			}
+			_, err = fmt.Fprintf(c.stdout, "Wrote %s (%d bytes, %s)\n", path, len(result.Content), result.ContentType)
+			return err
+		}
+	},
+}
+
+var checkUpdatesCommand = &command{
+	name:    "check-updates",
+	summary: "Check for new content since a content ID",
+	setup: func(fs *flag.FlagSet) runFunc {
+		contentID := fs.Int64("content-id", 0, "Content ID to check for updates since (required)")
+		return func(ctx context.Context, c *cli, _ *config.Config, cl client.Client) error {
+			if *contentID <= 0 {
+				return fmt.Errorf("%w: --content-id", errMissingFlag)
+			}
+			result, err := cl.CheckForUpdates(ctx, *contentID, false)
+			if err != nil {
+				return fmt.Errorf("failed to check for updates: %w", err)
+			}
+			if c.opts.json {
+				return c.writeJSON(result)
+			}
+			return c.writeTable([]string{"FILMS", "SERIES", "HAS UPDATES"}, 1, func(int) []any {
+				return []any{result.FilmCount, result.SeriesCount, result.HasUpdates}
+			})
+		}
+	},
+}
+
+// collect drains a stream into a slice, stopping at the first error.
+func collect[T any](ctx context.Context, stream <-chan models.StreamResult[T]) ([]T, error) {
+	items := []T{}
+	for {
+		select {
+		case result, ok := <-stream:
+			if !ok {
+				return items, nil
+			}
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			items = append(items, result.Value)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *cli) writeJSON(v any) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeTable writes rows aligned under the given headers.
+func (c *cli) writeTable(headers []string, rows int, row func(i int) []any) error {
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for i := range rows {
+		values := row(i)
+		cells := make([]string, len(values))
+		for j, value := range values {
+			cells[j] = fmt.Sprint(value)
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
@@ -3,130 +3,214 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"net"
-	"net/http"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/Belphemur/SuperSubtitles/v2/internal/buildinfo"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
-	grpcserver "github.com/Belphemur/SuperSubtitles/v2/internal/grpc"
-	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
-	"github.com/Belphemur/SuperSubtitles/v2/internal/sentryio"
+	"google.golang.org/grpc/codes"
 )
 
-func main() {
-	cfg := config.GetConfig()
-	logger := config.GetLogger()
-	defer config.FlushSentry()
-
-	// Log application configuration at startup
-	logEvent := logger.Info().
-		Str("version", buildinfo.Version).
-		Str("commit", buildinfo.Commit).
-		Str("build_date", buildinfo.Date).
-		Str("proxy_connection_string", cfg.ProxyConnectionString).
-		Str("super_subtitle_domain", cfg.SuperSubtitleDomain).
-		Int("server_port", cfg.Server.Port).
-		Str("server_address", cfg.Server.Address)
-
-	// Log cache configuration
-	cacheType := cfg.Cache.Type
-	if cacheType == "" {
-		cacheType = "memory" // default
-	}
-	logEvent = logEvent.
-		Str("cache_type", cacheType).
-		Int("cache_size", cfg.Cache.Size).
-		Str("cache_ttl", cfg.Cache.TTL)
-
-	// Log Redis-specific configuration if using Redis cache
-	if cacheType == "redis" {
-		logEvent = logEvent.
-			Str("cache_redis_address", cfg.Cache.Redis.Address).
-			Int("cache_redis_db", cfg.Cache.Redis.DB)
-	}
-
-	// Log metrics configuration
-	logEvent = logEvent.
-		Bool("metrics_enabled", cfg.Metrics.Enabled)
-	if cfg.Metrics.Enabled {
-		logEvent = logEvent.Int("metrics_port", cfg.Metrics.Port)
-	}
-
-	// Log retry configuration
-	logEvent = logEvent.
-		Int("retry_max_attempts", cfg.Retry.MaxAttempts).
-		Str("retry_initial_delay", cfg.Retry.InitialDelay).
-		Str("retry_max_delay", cfg.Retry.MaxDelay)
-
-	logEvent.Msg("Application started with configuration")
-
-	// Create a client instance
-	httpClient := client.NewClient(cfg)
-	defer func() {
-		if err := httpClient.Close(); err != nil {
-			logger.Error().Err(err).Msg("Failed to close client")
-		}
-	}()
+// Process exit codes returned by the CLI.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+)
 
-	// Create and configure the gRPC server
-	grpcServer := grpcserver.NewGRPCServer(httpClient)
-
-	// Start Prometheus metrics HTTP server
-	if cfg.Metrics.Enabled {
-		metricsServer := metrics.NewHTTPServer(cfg.Server.Address, cfg.Metrics.Port)
-		go func() {
-			logger.Info().Str("address", metricsServer.Addr).Msg("Starting Prometheus metrics HTTP server")
-			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-				sentryio.CaptureException(err, nil)
-				logger.Error().Err(err).Msg("Failed to serve metrics")
-				config.FlushSentry()
-				os.Exit(1)
-			}
-		}()
-		defer func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			if err := metricsServer.Shutdown(ctx); err != nil {
-				logger.Error().Err(err).Msg("Failed to shutdown metrics server")
+// runFunc executes a command against a configured client.
+type runFunc func(ctx context.Context, c *cli, cfg *config.Config, cl client.Client) error
+
+// command is a CLI subcommand. setup registers the command-specific flags and
+// returns the function that runs the command once the flags are parsed.
+type command struct {
+	name    string
+	summary string
+	// longRunning commands are not bounded by the global --timeout flag.
+	longRunning bool
+	setup       func(fs *flag.FlagSet) runFunc
+}
+
+// globalOptions holds the flags shared by every command.
+type globalOptions struct {
+	configFile   string
+	timeout      time.Duration
+	json         bool
+	validateOnly bool
+}
+
+// cli wires the commands to their dependencies so they can be replaced in tests.
+type cli struct {
+	stdout     io.Writer
+	stderr     io.Writer
+	loadConfig func(opts config.InitOptions) (*config.Config, error)
+	newClient  func(cfg *config.Config) client.Client
+	commands   []*command
+	opts       globalOptions
+}
+
+func newCLI(stdout, stderr io.Writer) *cli {
+	return &cli{
+		stdout: stdout,
+		stderr: stderr,
+		loadConfig: func(opts config.InitOptions) (*config.Config, error) {
+			if err := config.Init(opts); err != nil {
+				return nil, err
 			}
-		}()
+			return config.GetConfig(), nil
+		},
+		newClient: client.NewClient,
+		commands: []*command{
+			serveCommand,
+			showsCommand,
+			subtitlesCommand,
+			downloadCommand,
+			checkUpdatesCommand,
+		},
+	}
+}
+
+func main() {
+	code := newCLI(os.Stdout, os.Stderr).run(os.Args[1:])
+	config.FlushSentry()
+	os.Exit(code)
+}
+
+// run parses the arguments, executes the selected command and returns the process exit code.
+// Without a command, the servers are started to stay compatible with the container entrypoint.
+func (c *cli) run(args []string) int {
+	globalFlags := flag.NewFlagSet("proxy", flag.ContinueOnError)
+	globalFlags.SetOutput(c.stderr)
+	globalFlags.Usage = c.usage
+	c.bindGlobalFlags(globalFlags)
+	if err := globalFlags.Parse(args); err != nil {
+		return parseExitCode(err)
 	}
 
-	// Create a listener
-	address := fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.Port)
-	listener, err := net.Listen("tcp", address)
+	name := "serve"
+	rest := globalFlags.Args()
+	if len(rest) > 0 {
+		name, rest = rest[0], rest[1:]
+	}
+
+	cmd := c.lookup(name)
+	if cmd == nil {
+		_, _ = fmt.Fprintf(c.stderr, "unknown command %q\n\n", name)
+		c.usage()
+		return exitUsage
+	}
+
+	// Global flags are accepted after the command name as well
+	cmdFlags := flag.NewFlagSet("proxy "+cmd.name, flag.ContinueOnError)
+	cmdFlags.SetOutput(c.stderr)
+	c.bindGlobalFlags(cmdFlags)
+	runCmd := cmd.setup(cmdFlags)
+	if err := cmdFlags.Parse(rest); err != nil {
+		return parseExitCode(err)
+	}
+	if cmdFlags.NArg() > 0 {
+		_, _ = fmt.Fprintf(c.stderr, "unexpected arguments: %v\n", cmdFlags.Args())
+		cmdFlags.Usage()
+		return exitUsage
+	}
+
+	// One-off commands log to stderr so stdout only carries their output
+	initOpts := config.InitOptions{ConfigFile: c.opts.configFile}
+	if !cmd.longRunning || c.opts.validateOnly {
+		initOpts.LogOutput = c.stderr
+	}
+	cfg, err := c.loadConfig(initOpts)
 	if err != nil {
-		sentryio.CaptureException(err, nil)
-		logger.Error().Err(err).Str("address", address).Msg("Failed to create listener")
-		config.FlushSentry()
-		os.Exit(1)
+		_, _ = fmt.Fprintf(c.stderr, "Error: failed to load config: %v\n", err)
+		return exitError
 	}
 
-	logger.Info().Str("address", address).Msg("Starting gRPC server")
+	// Refuse to start on a bad configuration instead of running on silent fallbacks
+	if errs := cfg.Validate(); len(errs) > 0 {
+		_, _ = fmt.Fprintln(c.stderr, "Error: invalid configuration:")
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(c.stderr, "  - %v\n", err)
+		}
+		return exitError
+	}
+	if c.opts.validateOnly {
+		_, _ = fmt.Fprintln(c.stdout, "Configuration is valid")
+		return exitOK
+	}
 
-	// Handle graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if c.opts.timeout > 0 && !cmd.longRunning {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
 
-	go func() {
-		sig := <-sigChan
-		logger.Info().Str("signal", sig.String()).Msg("Received shutdown signal")
-		grpcServer.GracefulStop()
+	cl := c.newClient(cfg)
+	defer func() {
+		if err := cl.Close(); err != nil {
+			_, _ = fmt.Fprintf(c.stderr, "Error: failed to close client: %v\n", err)
+		}
 	}()
 
-	// Start serving
-	if err := grpcServer.Serve(listener); err != nil {
-		sentryio.CaptureException(err, nil)
-		logger.Error().Err(err).Msg("Failed to serve gRPC")
-		config.FlushSentry()
-		os.Exit(1)
+	if err := runCmd(ctx, c, cfg, cl); err != nil {
+		_, _ = fmt.Fprintf(c.stderr, "Error: %v\n", err)
+		return errorExitCode(err)
 	}
+	return exitOK
+}
 
-	logger.Info().Msg("Server stopped gracefully")
+func (c *cli) bindGlobalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.opts.configFile, "config", c.opts.configFile, "Path to a YAML config file (default: config.yaml in . or ./config)")
+	fs.DurationVar(&c.opts.timeout, "timeout", c.opts.timeout, "Maximum duration of a one-off command, e.g. 30s (0 disables the limit)")
+	fs.BoolVar(&c.opts.json, "json", c.opts.json, "Write machine-readable JSON output")
+	fs.BoolVar(&c.opts.validateOnly, "validate-only", c.opts.validateOnly, "Validate the configuration and exit without running the command")
+}
+
+func (c *cli) lookup(name string) *command {
+	for _, cmd := range c.commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (c *cli) usage() {
+	_, _ = fmt.Fprintln(c.stderr, "Usage: proxy [--config file] [--timeout duration] [--json] [--validate-only] <command> [flags]")
+	_, _ = fmt.Fprintln(c.stderr)
+	_, _ = fmt.Fprintln(c.stderr, "Commands:")
+	for _, cmd := range c.commands {
+		_, _ = fmt.Fprintf(c.stderr, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	_, _ = fmt.Fprintln(c.stderr)
+	_, _ = fmt.Fprintln(c.stderr, "Run \"proxy <command> --help\" for the flags of a command. Without a command, serve is run.")
+}
+
+// parseExitCode maps a flag parsing error to an exit code; --help is not an error.
+func parseExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return exitUsage
+}
+
+// errorExitCode maps a command error to an exit code, reserving exitNotFound
+// for apperrors.ErrNotFound and the other errors the API reports as NotFound.
+func errorExitCode(err error) int {
+	if errors.Is(err, errMissingFlag) {
+		return exitUsage
+	}
+	var bindable apperrors.GRPCBindableError
+	if errors.As(err, &bindable) && bindable.GRPCCode() == codes.NotFound {
+		return exitNotFound
+	}
+	return exitError
 }
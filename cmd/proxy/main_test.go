@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// mockClient implements client.Client for testing
+type mockClient struct {
+	checkForUpdatesFunc  func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error)
+	downloadSubtitleFunc func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error)
+	shows                []models.Show
+	subtitles            []models.Subtitle
+	streamErr            error
+	closed               bool
+}
+
+func (m *mockClient) CheckForUpdates(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
+	if m.checkForUpdatesFunc != nil {
+		return m.checkForUpdatesFunc(ctx, contentID, forceRefresh)
+	}
+	return &models.UpdateCheckResult{}, nil
+}
+
+func (m *mockClient) DownloadSubtitle(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+	if m.downloadSubtitleFunc != nil {
+		return m.downloadSubtitleFunc(ctx, subtitleID, opts)
+	}
+	return &models.DownloadResult{}, nil
+}
+
+func (m *mockClient) DownloadSubtitleByURL(context.Context, string, models.DownloadOptions) (*models.DownloadResult, error) {
+	return &models.DownloadResult{}, nil
+}
+
+func (m *mockClient) ApplyConfig(*config.Config) {}
+
+func (m *mockClient) GetLatestSubtitleID(context.Context) (int, error) { return 0, nil }
+
+func (m *mockClient) FindSubtitle(context.Context, int, int, int, string) ([]models.Subtitle, error) {
+	return nil, nil
+}
+
+func (m *mockClient) GetShowSeasons(context.Context, int) (*models.ShowSeasons, error) {
+	return &models.ShowSeasons{}, nil
+}
+
+func (m *mockClient) FindShow(context.Context, string, *int) (*models.Show, error) {
+	return &models.Show{}, nil
+}
+
+func (m *mockClient) GetSubtitleDetails(context.Context, int) (*models.SubtitleDetails, error) {
+	return &models.SubtitleDetails{}, nil
+}
+
+func (m *mockClient) GetSubtitle(context.Context, int, int) (*models.Subtitle, error) {
+	return &models.Subtitle{}, nil
+}
+
+func (m *mockClient) GetSubtitleInfo(context.Context, int) (*models.Subtitle, error) {
+	return &models.Subtitle{}, nil
+}
+
+func (m *mockClient) DownloadEpisodeRangeAsZip(context.Context, string, int, int, int64) (*models.DownloadResult, error) {
+	return &models.DownloadResult{}, nil
+}
+
+func (m *mockClient) ExportShowSubtitles(context.Context, int, models.ExportOptions, io.Writer) (*models.ExportManifest, error) {
+	return &models.ExportManifest{}, nil
+}
+
+func (m *mockClient) EstimateDownload(context.Context, string) (*models.DownloadEstimate, error) {
+	return &models.DownloadEstimate{}, nil
+}
+
+func (m *mockClient) Preload(context.Context, []int) int { return 0 }
+
+func (m *mockClient) InvalidateCache(string) (bool, error) { return false, nil }
+
+func (m *mockClient) ClearCache() int { return 0 }
+
+func (m *mockClient) UpstreamStatus() models.UpstreamStatus { return models.UpstreamStatus{} }
+
+func (m *mockClient) SelfCheck(context.Context) models.SelfCheckResult {
+	return models.SelfCheckResult{}
+}
+
+func (m *mockClient) GetShowImage(context.Context, int) (*models.ShowImage, error) {
+	return &models.ShowImage{}, nil
+}
+
+func (m *mockClient) StreamShowList(context.Context, int) <-chan models.StreamResult[models.Show] {
+	return streamOf(m.shows, m.streamErr)
+}
+
+func (m *mockClient) StreamSubtitles(context.Context, int) <-chan models.StreamResult[models.Subtitle] {
+	return streamOf(m.subtitles, m.streamErr)
+}
+
+func (m *mockClient) StreamSubtitlesInOrder(context.Context, int, models.SubtitleOrder) <-chan models.StreamResult[models.Subtitle] {
+	return streamOf(m.subtitles, m.streamErr)
+}
+
+func (m *mockClient) StreamMovieSubtitles(context.Context, int) <-chan models.StreamResult[models.Subtitle] {
+	return streamOf(m.subtitles, m.streamErr)
+}
+
+func (m *mockClient) StreamShowSubtitles(context.Context, []models.Show, []string) <-chan models.StreamResult[models.ShowSubtitles] {
+	return streamOf[models.ShowSubtitles](nil, m.streamErr)
+}
+
+func (m *mockClient) StreamRecentSubtitles(context.Context, int) <-chan models.StreamResult[models.ShowSubtitles] {
+	return streamOf[models.ShowSubtitles](nil, m.streamErr)
+}
+
+func (m *mockClient) Close() error {
+	m.closed = true
+	return nil
+}
+
+func streamOf[T any](items []T, err error) <-chan models.StreamResult[T] {
+	ch := make(chan models.StreamResult[T], len(items)+1)
+	for _, item := range items {
+		ch <- models.StreamResult[T]{Value: item}
+	}
+	if err != nil {
+		ch <- models.StreamResult[T]{Err: err}
+	}
+	close(ch)
+	return ch
+}
+
+// newTestCLI returns a CLI wired to the mock client with captured output.
+func newTestCLI(mock *mockClient) (*cli, *bytes.Buffer, *bytes.Buffer, *config.InitOptions) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	c := newCLI(stdout, stderr)
+	initOpts := &config.InitOptions{}
+	c.loadConfig = func(opts config.InitOptions) (*config.Config, error) {
+		*initOpts = opts
+		return validTestConfig(), nil
+	}
+	c.newClient = func(*config.Config) client.Client { return mock }
+	return c, stdout, stderr, initOpts
+}
+
+// validTestConfig returns the smallest configuration that passes validation.
+func validTestConfig() *config.Config {
+	cfg := &config.Config{SuperSubtitleDomain: "https://feliratok.eu"}
+	cfg.Server.Port = 8080
+	return cfg
+}
+
+func TestCLI_Run_ShowsTable(t *testing.T) {
+	mock := &mockClient{shows: []models.Show{{ID: 1, Name: "Ted Lasso", Year: 2020}}}
+	c, stdout, stderr, initOpts := newTestCLI(mock)
+
+	if code := c.run([]string{"--config", "custom.yaml", "shows"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout.String(), "NAME") || !strings.Contains(stdout.String(), "Ted Lasso") {
+		t.Errorf("expected table with show, got %q", stdout.String())
+	}
+	if initOpts.ConfigFile != "custom.yaml" {
+		t.Errorf("expected config file custom.yaml, got %q", initOpts.ConfigFile)
+	}
+	if initOpts.LogOutput != stderr {
+		t.Error("expected one-off command logs to go to stderr")
+	}
+	if !mock.closed {
+		t.Error("expected client to be closed")
+	}
+}
+
+func TestCLI_Run_ShowsJSON(t *testing.T) {
+	mock := &mockClient{shows: []models.Show{{ID: 1, Name: "Ted Lasso", Year: 2020}, {ID: 2, Name: "Severance", Year: 2022}}}
+	c, stdout, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"shows", "--json"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	var shows []models.Show
+	if err := json.Unmarshal(stdout.Bytes(), &shows); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout.String(), err)
+	}
+	if len(shows) != 2 || shows[1].Name != "Severance" {
+		t.Errorf("unexpected shows: %+v", shows)
+	}
+}
+
+func TestCLI_Run_SubtitlesFiltersLanguage(t *testing.T) {
+	mock := &mockClient{subtitles: []models.Subtitle{
+		{ID: 10, Language: "hu", Name: "Magyar"},
+		{ID: 11, Language: "en", Name: "English"},
+	}}
+	c, stdout, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"--json", "subtitles", "--show-id", "42", "--lang", "hu"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	var subtitles []models.Subtitle
+	if err := json.Unmarshal(stdout.Bytes(), &subtitles); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout.String(), err)
+	}
+	if len(subtitles) != 1 || subtitles[0].ID != 10 {
+		t.Errorf("expected only the Hungarian subtitle, got %+v", subtitles)
+	}
+}
+
+func TestCLI_Run_SubtitlesMissingShowID(t *testing.T) {
+	c, _, stderr, _ := newTestCLI(&mockClient{})
+
+	if code := c.run([]string{"subtitles"}); code != exitUsage {
+		t.Fatalf("expected exit code %d, got %d", exitUsage, code)
+	}
+	if !strings.Contains(stderr.String(), "--show-id") {
+		t.Errorf("expected missing flag in error, got %q", stderr.String())
+	}
+}
+
+func TestCLI_Run_SubtitlesNotFound(t *testing.T) {
+	mock := &mockClient{streamErr: apperrors.NewNotFoundError("show", 42)}
+	c, _, _, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"subtitles", "--show-id", "42"}); code != exitNotFound {
+		t.Fatalf("expected exit code %d, got %d", exitNotFound, code)
+	}
+}
+
+func TestCLI_Run_Download(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "subs")
+	var gotID string
+	var gotOpts models.DownloadOptions
+	mock := &mockClient{
+		downloadSubtitleFunc: func(_ context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			gotID, gotOpts = subtitleID, opts
+			return &models.DownloadResult{Filename: "../show.S01E03.srt", Content: []byte("1\n"), ContentType: "application/x-subrip"}, nil
+		},
+	}
+	c, stdout, stderr, _ := newTestCLI(mock)
+
+	code := c.run([]string{"download", "--subtitle-id", "101", "--episode", "3", "--out", outDir, "--json"})
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if gotID != "101" || gotOpts.Episode == nil || *gotOpts.Episode != 3 {
+		t.Errorf("unexpected download call: id=%q opts=%+v", gotID, gotOpts)
+	}
+
+	var out downloadOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout.String(), err)
+	}
+	wantPath := filepath.Join(outDir, "show.S01E03.srt")
+	if out.Path != wantPath || out.Size != 2 {
+		t.Errorf("unexpected output: %+v", out)
+	}
+	content, err := os.ReadFile(wantPath)
+	if err != nil {
+		t.Fatalf("expected subtitle file to be written: %v", err)
+	}
+	if string(content) != "1\n" {
+		t.Errorf("unexpected file content %q", content)
+	}
+}
+
+func TestCLI_Run_DownloadWholeFileWithoutEpisode(t *testing.T) {
+	var gotOpts models.DownloadOptions
+	mock := &mockClient{
+		downloadSubtitleFunc: func(_ context.Context, _ string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			gotOpts = opts
+			return &models.DownloadResult{Filename: "pack.zip", ContentType: "application/zip"}, nil
+		},
+	}
+	c, _, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"download", "--subtitle-id", "101", "--out", t.TempDir()}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if gotOpts.WantsEpisode() {
+		t.Errorf("expected whole file download, got %+v", gotOpts)
+	}
+}
+
+func TestCLI_Run_DownloadEpisodeZero(t *testing.T) {
+	var gotOpts models.DownloadOptions
+	mock := &mockClient{
+		downloadSubtitleFunc: func(_ context.Context, _ string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			gotOpts = opts
+			return &models.DownloadResult{Filename: "show.S00E00.srt", ContentType: "application/x-subrip"}, nil
+		},
+	}
+	c, _, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"download", "--subtitle-id", "101", "--episode", "0", "--out", t.TempDir()}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if gotOpts.Episode == nil || *gotOpts.Episode != 0 {
+		t.Errorf("expected episode 0 to be requested for specials, got %+v", gotOpts)
+	}
+}
+
+func TestCLI_Run_CheckUpdates(t *testing.T) {
+	var gotID int64
+	mock := &mockClient{
+		checkForUpdatesFunc: func(_ context.Context, contentID int64, _ bool) (*models.UpdateCheckResult, error) {
+			gotID = contentID
+			return &models.UpdateCheckResult{FilmCount: 1, SeriesCount: 4, HasUpdates: true}, nil
+		},
+	}
+	c, stdout, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"--json", "check-updates", "--content-id", "1234"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if gotID != 1234 {
+		t.Errorf("expected content ID 1234, got %d", gotID)
+	}
+	var result models.UpdateCheckResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", stdout.String(), err)
+	}
+	if result.SeriesCount != 4 || !result.HasUpdates {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestCLI_Run_TimeoutBoundsCommand(t *testing.T) {
+	mock := &mockClient{
+		checkForUpdatesFunc: func(ctx context.Context, _ int64, _ bool) (*models.UpdateCheckResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	c, _, stderr, _ := newTestCLI(mock)
+
+	if code := c.run([]string{"--timeout", "10ms", "check-updates", "--content-id", "1"}); code != exitError {
+		t.Fatalf("expected exit code %d, got %d", exitError, code)
+	}
+	if !strings.Contains(stderr.String(), "deadline exceeded") {
+		t.Errorf("expected deadline error, got %q", stderr.String())
+	}
+}
+
+func TestCLI_Run_UnknownCommand(t *testing.T) {
+	c, _, stderr, _ := newTestCLI(&mockClient{})
+
+	if code := c.run([]string{"bogus"}); code != exitUsage {
+		t.Fatalf("expected exit code %d, got %d", exitUsage, code)
+	}
+	if !strings.Contains(stderr.String(), "check-updates") {
+		t.Errorf("expected usage listing commands, got %q", stderr.String())
+	}
+}
+
+func TestCLI_Run_Help(t *testing.T) {
+	c, _, _, _ := newTestCLI(&mockClient{})
+
+	if code := c.run([]string{"download", "--help"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d", exitOK, code)
+	}
+}
+
+func TestCLI_Run_InvalidConfigFailsFast(t *testing.T) {
+	mock := &mockClient{}
+	c, stdout, stderr, _ := newTestCLI(mock)
+	c.loadConfig = func(config.InitOptions) (*config.Config, error) {
+		cfg := validTestConfig()
+		cfg.ClientTimeout = "30 seconds"
+		cfg.Cache.Type = "redis"
+		return cfg, nil
+	}
+
+	if code := c.run([]string{"shows"}); code != exitError {
+		t.Fatalf("expected exit code %d, got %d", exitError, code)
+	}
+	for _, want := range []string{"client_timeout", "cache.redis.address"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("expected %q in error report, got %q", want, stderr.String())
+		}
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("expected no command output, got %q", stdout.String())
+	}
+}
+
+func TestCLI_Run_ValidateOnly(t *testing.T) {
+	c, stdout, stderr, initOpts := newTestCLI(&mockClient{})
+
+	if code := c.run([]string{"--validate-only"}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr: %s)", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout.String(), "Configuration is valid") {
+		t.Errorf("expected validation success message, got %q", stdout.String())
+	}
+	if initOpts.LogOutput != stderr {
+		t.Error("expected validation logs to go to stderr")
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/buildinfo"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	grpcserver "github.com/Belphemur/SuperSubtitles/v2/internal/grpc"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/sentryio"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/tracing"
+)
+
+// serveCommand runs the gRPC server, the optional Prometheus metrics server and the optional
+// WebSocket bridge until the context is cancelled by a shutdown signal.
+var serveCommand = &command{
+	name:        "serve",
+	summary:     "Run the gRPC and metrics servers",
+	longRunning: true,
+	setup: func(_ *flag.FlagSet) runFunc {
+		return runServe
+	},
+}
+
+func runServe(ctx context.Context, _ *cli, cfg *config.Config, httpClient client.Client) error {
+	logger := config.GetLogger()
+	logStartupConfig(cfg)
+	metrics.BuildInfo.WithLabelValues(buildinfo.Version, buildinfo.Commit, buildinfo.Date).Set(1)
+
+	// Export traces before the gRPC server is created so its handler uses the provider
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		Version:     buildinfo.Version,
+	})
+	if err != nil {
+		sentryio.CaptureException(err, nil)
+		logger.Error().Err(err).Msg("Failed to configure tracing")
+		return fmt.Errorf("failed to configure tracing: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Error().Err(err).Msg("Failed to flush traces")
+		}
+	}()
+
+	// Create and configure the gRPC server with optional TLS and token authentication
+	serverOpts, err := grpcserver.ServerOptionsFromConfig(cfg)
+	if err != nil {
+		sentryio.CaptureException(err, nil)
+		logger.Error().Err(err).Msg("Failed to configure gRPC server security")
+		return fmt.Errorf("failed to configure gRPC server security: %w", err)
+	}
+	grpcServer := grpcserver.NewGRPCServer(httpClient, serverOpts...)
+
+	// Reapply dynamic settings when the config file changes or on SIGHUP
+	config.OnReload(httpClient.ApplyConfig)
+	config.Watch()
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				logger.Info().Msg("Received SIGHUP, reloading configuration")
+				if err := config.Reload(); err != nil {
+					logger.Warn().Err(err).Msg("Failed to reload configuration, keeping the current settings")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Start Prometheus metrics HTTP server
+	errCh := make(chan error, 3)
+	if cfg.Metrics.Enabled {
+		metricsServer := metrics.NewHTTPServer(cfg.Server.Address, cfg.Metrics.Port)
+		go func() {
+			logger.Info().Str("address", metricsServer.Addr).Msg("Starting Prometheus metrics HTTP server")
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				sentryio.CaptureException(err, nil)
+				logger.Error().Err(err).Msg("Failed to serve metrics")
+				errCh <- fmt.Errorf("failed to serve metrics: %w", err)
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error().Err(err).Msg("Failed to shutdown metrics server")
+			}
+		}()
+	}
+
+	// Start the WebSocket bridge for browser clients. It is closed before the gRPC server
+	// drains, ending its streams, so no connection still uses the client once runServe returns.
+	if cfg.Server.HTTPPort > 0 {
+		bridge, err := grpcserver.NewWebSocketBridge(httpClient, cfg)
+		if err != nil {
+			sentryio.CaptureException(err, nil)
+			logger.Error().Err(err).Msg("Failed to configure WebSocket bridge")
+			return fmt.Errorf("failed to configure WebSocket bridge: %w", err)
+		}
+		bridgeServer, err := grpcserver.NewWebSocketHTTPServer(cfg, bridge)
+		if err != nil {
+			bridge.Close()
+			sentryio.CaptureException(err, nil)
+			logger.Error().Err(err).Msg("Failed to configure WebSocket bridge")
+			return err
+		}
+		go func() {
+			useTLS := bridgeServer.TLSConfig != nil
+			logger.Info().Str("address", bridgeServer.Addr).Str("path", grpcserver.WebSocketPath).Bool("tls", useTLS).Msg("Starting WebSocket bridge HTTP server")
+			serve := bridgeServer.ListenAndServe
+			if useTLS {
+				// The certificate is already in TLSConfig
+				serve = func() error { return bridgeServer.ListenAndServeTLS("", "") }
+			}
+			if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				sentryio.CaptureException(err, nil)
+				logger.Error().Err(err).Msg("Failed to serve WebSocket bridge")
+				errCh <- fmt.Errorf("failed to serve WebSocket bridge: %w", err)
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := bridgeServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error().Err(err).Msg("Failed to shutdown WebSocket bridge server")
+			}
+			bridge.Close()
+		}()
+	}
+
+	// Create a listener
+	address := fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.Port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		sentryio.CaptureException(err, nil)
+		logger.Error().Err(err).Str("address", address).Msg("Failed to create listener")
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	logger.Info().Str("address", address).Msg("Starting gRPC server")
+
+	// Warm the archive cache in the background so it never delays serving. It is cancelled
+	// and awaited on return, before the caller closes the client and its cache.
+	if len(cfg.Cache.PreloadShowIDs) > 0 {
+		preloadCtx, cancelPreload := context.WithCancel(ctx)
+		preloadDone := make(chan struct{})
+		go func() {
+			defer close(preloadDone)
+			httpClient.Preload(preloadCtx, cfg.Cache.PreloadShowIDs)
+		}()
+		defer func() {
+			cancelPreload()
+			<-preloadDone
+		}()
+	}
+
+	// Handle graceful shutdown on signal or when the metrics server fails. In-flight RPCs
+	// are drained before runServe returns, so the caller closes the client, downloader and
+	// cache only once no request can still use them.
+	shutdownTimeout := 30 * time.Second
+	if cfg.Server.ShutdownTimeout != "" {
+		if parsed, err := config.ParseDuration("server.shutdown_timeout", cfg.Server.ShutdownTimeout); err != nil {
+			logger.Warn().Err(err).Dur("fallback", shutdownTimeout).Msg("Invalid shutdown timeout, using default")
+		} else {
+			shutdownTimeout = parsed
+		}
+	}
+	stopped := make(chan error, 1)
+	go func() {
+		var stopErr error
+		select {
+		case <-ctx.Done():
+			logger.Info().Dur("timeout", shutdownTimeout).Msg("Received shutdown signal, draining in-flight requests")
+		case stopErr = <-errCh:
+		}
+		if !grpcserver.Shutdown(grpcServer, shutdownTimeout) {
+			logger.Warn().Dur("timeout", shutdownTimeout).Msg("In-flight requests did not finish in time, forced gRPC server stop")
+		}
+		stopped <- stopErr
+	}()
+
+	// Start serving
+	if err := grpcServer.Serve(listener); err != nil {
+		sentryio.CaptureException(err, nil)
+		logger.Error().Err(err).Msg("Failed to serve gRPC")
+		return fmt.Errorf("failed to serve gRPC: %w", err)
+	}
+	if err := <-stopped; err != nil {
+		return err
+	}
+
+	logger.Info().Msg("Server stopped gracefully")
+	return nil
+}
+
+// logStartupConfig logs the effective application configuration at startup.
+func logStartupConfig(cfg *config.Config) {
+	logger := config.GetLogger()
+
+	logEvent := logger.Info().
+		Str("version", buildinfo.Version).
+		Str("commit", buildinfo.Commit).
+		Str("build_date", buildinfo.Date).
+		Strs("features", cfg.Features()).
+		Str("proxy_connection_string", cfg.ProxyConnectionString).
+		Str("super_subtitle_domain", cfg.SuperSubtitleDomain).
+		Strs("super_subtitle_domains", cfg.SuperSubtitleDomains).
+		Int("client_show_subtitles_concurrency", cfg.Client.ShowSubtitlesConcurrency).
+		Str("client_update_check_ttl", cfg.Client.UpdateCheckTTL).
+		Str("client_per_show_timeout", cfg.Client.PerShowTimeout).
+		Str("client_mirror_cooldown", cfg.Client.MirrorCooldown).
+		Int("server_port", cfg.Server.Port).
+		Int("server_http_port", cfg.Server.HTTPPort).
+		Str("server_address", cfg.Server.Address).
+		Str("server_shutdown_timeout", cfg.Server.ShutdownTimeout).
+		Str("server_rpc_timeout", cfg.Server.RPCTimeout).
+		Str("server_stream_timeout", cfg.Server.StreamTimeout).
+		Bool("server_tls_enabled", cfg.Server.TLS.CertFile != "" || cfg.Server.TLS.KeyFile != "").
+		Bool("server_mtls_enabled", cfg.Server.TLS.ClientCAFile != "").
+		Int("auth_token_count", len(cfg.Auth.Tokens))
+
+	// Log cache configuration
+	cacheType := cfg.Cache.Type
+	if cacheType == "" {
+		cacheType = "memory" // default
+	}
+	logEvent = logEvent.
+		Str("cache_type", cacheType).
+		Int("cache_size", cfg.Cache.Size).
+		Int64("cache_max_bytes", cfg.Cache.MaxBytes).
+		Str("cache_ttl", cfg.Cache.TTL).
+		Ints("cache_preload_show_ids", cfg.Cache.PreloadShowIDs)
+
+	// Log Redis-specific configuration if using Redis cache
+	if cacheType == "redis" {
+		logEvent = logEvent.
+			Str("cache_redis_address", cfg.Cache.Redis.Address).
+			Int("cache_redis_db", cfg.Cache.Redis.DB).
+			Str("cache_redis_key_prefix", cfg.Cache.Redis.KeyPrefix)
+	}
+
+	// Log metrics configuration
+	logEvent = logEvent.
+		Bool("metrics_enabled", cfg.Metrics.Enabled)
+	if cfg.Metrics.Enabled {
+		logEvent = logEvent.Int("metrics_port", cfg.Metrics.Port)
+	}
+
+	// Log tracing configuration
+	logEvent = logEvent.
+		Bool("tracing_enabled", cfg.Tracing.Enabled)
+	if cfg.Tracing.Enabled {
+		logEvent = logEvent.
+			Str("tracing_otlp_endpoint", cfg.Tracing.OTLPEndpoint).
+			Float64("tracing_sample_ratio", cfg.Tracing.SampleRatio)
+	}
+
+	// Log retry configuration
+	logEvent = logEvent.
+		Int("retry_max_attempts", cfg.Retry.MaxAttempts).
+		Str("retry_initial_delay", cfg.Retry.InitialDelay).
+		Str("retry_max_delay", cfg.Retry.MaxDelay)
+
+	logEvent.Msg("Application started with configuration")
+}
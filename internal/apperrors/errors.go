@@ -1,9 +1,14 @@
 package apperrors
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"google.golang.org/grpc/codes"
 )
 
@@ -15,6 +20,13 @@ type GRPCBindableError interface {
 	HTTPStatusCode() int
 }
 
+// MetadataError is implemented by errors that carry extra key/value details for API
+// translation layers, such as the limit that was exceeded.
+type MetadataError interface {
+	error
+	Metadata() map[string]string
+}
+
 // ErrNotFound represents an error when a requested resource is not found.
 type ErrNotFound struct {
 	Resource string
@@ -62,13 +74,25 @@ func NewSubtitlesNotFoundError(showID int) *ErrNotFound {
 }
 
 // ErrSubtitleNotFoundInArchive is returned when the requested episode subtitle is not found in a season-pack archive.
+// When the lookup was by episode title, EpisodeTitle is set and AvailableTitles lists the archive's file names.
+// When the lookup was for a range of episodes, none of which were found, EpisodeEnd is the last episode of the range.
 type ErrSubtitleNotFoundInArchive struct {
-	Episode   int
-	FileCount int
+	Episode         int
+	EpisodeEnd      int
+	EpisodeTitle    string
+	FileCount       int
+	AvailableTitles []string
 }
 
 // Error implements the error interface.
 func (e *ErrSubtitleNotFoundInArchive) Error() string {
+	if e.EpisodeTitle != "" {
+		return fmt.Sprintf("episode titled %q not found in season pack archive (searched %d files, available: %s)",
+			e.EpisodeTitle, e.FileCount, strings.Join(e.AvailableTitles, ", "))
+	}
+	if e.EpisodeEnd > e.Episode {
+		return fmt.Sprintf("episodes %d-%d not found in season pack archive (searched %d files)", e.Episode, e.EpisodeEnd, e.FileCount)
+	}
 	return fmt.Sprintf("episode %d not found in season pack archive (searched %d files)", e.Episode, e.FileCount)
 }
 
@@ -113,3 +137,459 @@ func (e *ErrSubtitleResourceNotFound) GRPCCode() codes.Code {
 func (e *ErrSubtitleResourceNotFound) HTTPStatusCode() int {
 	return http.StatusNotFound
 }
+
+// ErrInvalidDownloadURL is returned when a caller-supplied download URL does not point at the configured subtitle site.
+type ErrInvalidDownloadURL struct {
+	URL    string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrInvalidDownloadURL) Error() string {
+	return fmt.Sprintf("invalid download URL %q: %s", e.URL, e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrInvalidDownloadURL) Is(target error) bool {
+	_, ok := target.(*ErrInvalidDownloadURL)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrInvalidDownloadURL) GRPCCode() codes.Code {
+	return codes.InvalidArgument
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrInvalidDownloadURL) HTTPStatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrZipBombDetected is returned when a downloaded archive exceeds the uncompressed size or
+// compression ratio limits. Err is the archive error that detected it.
+type ErrZipBombDetected struct {
+	URL    string
+	Reason string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *ErrZipBombDetected) Error() string {
+	if e.URL != "" {
+		return fmt.Sprintf("ZIP bomb detected (url: %s): %s", e.URL, e.Reason)
+	}
+	return fmt.Sprintf("ZIP bomb detected: %s", e.Reason)
+}
+
+// Unwrap returns the wrapped cause.
+func (e *ErrZipBombDetected) Unwrap() error {
+	return e.Err
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrZipBombDetected) Is(target error) bool {
+	_, ok := target.(*ErrZipBombDetected)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrZipBombDetected) GRPCCode() codes.Code {
+	return codes.ResourceExhausted
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrZipBombDetected) HTTPStatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ErrDownloadTooLarge is returned when a download is larger than the configured size limit,
+// or when the returned file is larger than the limit the caller asked for. The body is not
+// read past the configured limit, so in that case Size is the number of bytes read when the
+// limit was hit rather than the full size of the resource.
+type ErrDownloadTooLarge struct {
+	Size  int64
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e *ErrDownloadTooLarge) Error() string {
+	return fmt.Sprintf("download size (%d bytes) exceeds limit (%d bytes)", e.Size, e.Limit)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrDownloadTooLarge) Is(target error) bool {
+	_, ok := target.(*ErrDownloadTooLarge)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrDownloadTooLarge) GRPCCode() codes.Code {
+	return codes.ResourceExhausted
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrDownloadTooLarge) HTTPStatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// Metadata returns the size limit that was exceeded, in bytes.
+func (e *ErrDownloadTooLarge) Metadata() map[string]string {
+	return map[string]string{"limit_bytes": strconv.FormatInt(e.Limit, 10)}
+}
+
+// ErrInvalidArchive is returned when downloaded content cannot be read as a ZIP or RAR archive,
+// or is in a format that cannot be searched for an episode. Err is the underlying archive error.
+type ErrInvalidArchive struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ErrInvalidArchive) Error() string {
+	return fmt.Sprintf("invalid archive: %v", e.Err)
+}
+
+// Unwrap returns the wrapped cause.
+func (e *ErrInvalidArchive) Unwrap() error {
+	return e.Err
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrInvalidArchive) Is(target error) bool {
+	_, ok := target.(*ErrInvalidArchive)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrInvalidArchive) GRPCCode() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrInvalidArchive) HTTPStatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ErrMalformedEncoding is returned when an upstream response body cannot be decompressed with the
+// Content-Encoding it was sent with. Err is the decompressor's error.
+type ErrMalformedEncoding struct {
+	Encoding string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *ErrMalformedEncoding) Error() string {
+	return fmt.Sprintf("malformed %s response body: %v", e.Encoding, e.Err)
+}
+
+// Unwrap returns the wrapped cause.
+func (e *ErrMalformedEncoding) Unwrap() error {
+	return e.Err
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrMalformedEncoding) Is(target error) bool {
+	_, ok := target.(*ErrMalformedEncoding)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrMalformedEncoding) GRPCCode() codes.Code {
+	return codes.Unavailable
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrMalformedEncoding) HTTPStatusCode() int {
+	return http.StatusBadGateway
+}
+
+// ErrUpstreamStatus is returned when the subtitle site answers a download with an unexpected
+// HTTP status. Code is the upstream status code.
+type ErrUpstreamStatus struct {
+	Code int
+}
+
+// Error implements the error interface.
+func (e *ErrUpstreamStatus) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.Code)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrUpstreamStatus) Is(target error) bool {
+	_, ok := target.(*ErrUpstreamStatus)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error: NotFound for 404,
+// Unavailable for 5xx, and Internal for any other status.
+func (e *ErrUpstreamStatus) GRPCCode() codes.Code {
+	switch {
+	case e.Code == http.StatusNotFound:
+		return codes.NotFound
+	case e.Code >= http.StatusInternalServerError:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrUpstreamStatus) HTTPStatusCode() int {
+	switch {
+	case e.Code == http.StatusNotFound:
+		return http.StatusNotFound
+	case e.Code >= http.StatusInternalServerError:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// ErrAmbiguousShow is returned when a show name matches several shows and no year was given
+// to tell them apart. Candidates lists the matching shows.
+type ErrAmbiguousShow struct {
+	Name       string
+	Candidates []models.Show
+}
+
+// Error implements the error interface.
+func (e *ErrAmbiguousShow) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for i, show := range e.Candidates {
+		candidates[i] = fmt.Sprintf("%s (%d, ID %d)", show.Name, show.Year, show.ID)
+	}
+	return fmt.Sprintf("show name %q is ambiguous, specify a year: %s", e.Name, strings.Join(candidates, ", "))
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrAmbiguousShow) Is(target error) bool {
+	_, ok := target.(*ErrAmbiguousShow)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrAmbiguousShow) GRPCCode() codes.Code {
+	return codes.InvalidArgument
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrAmbiguousShow) HTTPStatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrShowTimeout is returned for a single show whose fetch ran past client.per_show_timeout
+// while other shows of the same stream kept going. ShowID names the slow show.
+type ErrShowTimeout struct {
+	ShowID  int
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *ErrShowTimeout) Error() string {
+	return fmt.Sprintf("show %d timed out after %s", e.ShowID, e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded, so the error also matches that sentinel.
+func (e *ErrShowTimeout) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrShowTimeout) Is(target error) bool {
+	_, ok := target.(*ErrShowTimeout)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrShowTimeout) GRPCCode() codes.Code {
+	return codes.DeadlineExceeded
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrShowTimeout) HTTPStatusCode() int {
+	return http.StatusGatewayTimeout
+}
+
+// Metadata returns the ID of the show that timed out.
+func (e *ErrShowTimeout) Metadata() map[string]string {
+	return map[string]string{"show_id": strconv.Itoa(e.ShowID)}
+}
+
+// ErrNotAnImage is returned when the site answers an image request with content that is not a
+// JPEG, PNG or WebP image, such as an HTML error page. ContentType is the type upstream sent.
+type ErrNotAnImage struct {
+	URL         string
+	ContentType string
+}
+
+// Error implements the error interface.
+func (e *ErrNotAnImage) Error() string {
+	return fmt.Sprintf("upstream returned a non-image response (content-type: %s) for %s", e.ContentType, e.URL)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrNotAnImage) Is(target error) bool {
+	_, ok := target.(*ErrNotAnImage)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrNotAnImage) GRPCCode() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrNotAnImage) HTTPStatusCode() int {
+	return http.StatusBadGateway
+}
+
+// ErrUpstreamBlocked is returned when the site answers with a page that shows the service is
+// blocked or throttled, such as a captcha or a login page, instead of the requested content.
+// Reason names the marker that was found.
+type ErrUpstreamBlocked struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrUpstreamBlocked) Error() string {
+	return fmt.Sprintf("upstream blocked the request: %s", e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrUpstreamBlocked) Is(target error) bool {
+	_, ok := target.(*ErrUpstreamBlocked)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrUpstreamBlocked) GRPCCode() codes.Code {
+	return codes.Unavailable
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrUpstreamBlocked) HTTPStatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// ErrUpstreamMaintenance is returned when the site answers with a maintenance or server error
+// page, with HTTP 200, instead of the requested content, so the outage is not mistaken for an
+// empty result. Reason names the marker that was found.
+type ErrUpstreamMaintenance struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrUpstreamMaintenance) Error() string {
+	return fmt.Sprintf("upstream is unavailable: %s", e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrUpstreamMaintenance) Is(target error) bool {
+	_, ok := target.(*ErrUpstreamMaintenance)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrUpstreamMaintenance) GRPCCode() codes.Code {
+	return codes.Unavailable
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrUpstreamMaintenance) HTTPStatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// ErrUnexpectedPage is returned when an upstream page lacks the structure its parser expects,
+// so it cannot be told apart from a listing that is merely empty. Page names the kind of page
+// expected and Reason what was missing.
+type ErrUnexpectedPage struct {
+	Page   string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrUnexpectedPage) Error() string {
+	return fmt.Sprintf("upstream page is not a %s: %s", e.Page, e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrUnexpectedPage) Is(target error) bool {
+	_, ok := target.(*ErrUnexpectedPage)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrUnexpectedPage) GRPCCode() codes.Code {
+	return codes.Unavailable
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrUnexpectedPage) HTTPStatusCode() int {
+	return http.StatusBadGateway
+}
+
+// ErrMalformedSubtitle is returned when a downloaded SRT or WebVTT file fails the structural
+// check requested with validation. Format is "srt" or "vtt", Line the 1-based line where the
+// problem was found (0 for the whole file) and Reason what was wrong.
+type ErrMalformedSubtitle struct {
+	Format string
+	Line   int
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrMalformedSubtitle) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("malformed %s subtitle at line %d: %s", e.Format, e.Line, e.Reason)
+	}
+	return fmt.Sprintf("malformed %s subtitle: %s", e.Format, e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrMalformedSubtitle) Is(target error) bool {
+	_, ok := target.(*ErrMalformedSubtitle)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrMalformedSubtitle) GRPCCode() codes.Code {
+	return codes.DataLoss
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrMalformedSubtitle) HTTPStatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// Metadata returns the subtitle format and the line of the problem.
+func (e *ErrMalformedSubtitle) Metadata() map[string]string {
+	return map[string]string{"format": e.Format, "line": strconv.Itoa(e.Line)}
+}
+
+// ErrInvalidSourceEncoding is returned when a download asks for its text to be read in a known
+// encoding that cannot decode the file, rather than returning the bytes undecoded. An unknown
+// encoding name falls back to detection instead.
+type ErrInvalidSourceEncoding struct {
+	Encoding string
+	Reason   string
+}
+
+// Error implements the error interface.
+func (e *ErrInvalidSourceEncoding) Error() string {
+	return fmt.Sprintf("cannot decode subtitle as %q: %s", e.Encoding, e.Reason)
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrInvalidSourceEncoding) Is(target error) bool {
+	_, ok := target.(*ErrInvalidSourceEncoding)
+	return ok
+}
+
+// GRPCCode returns the gRPC status code for this error.
+func (e *ErrInvalidSourceEncoding) GRPCCode() codes.Code {
+	return codes.InvalidArgument
+}
+
+// HTTPStatusCode returns the HTTP status code equivalent for this error.
+func (e *ErrInvalidSourceEncoding) HTTPStatusCode() int {
+	return http.StatusBadRequest
+}
@@ -1,13 +1,22 @@
 // Package apperrors tests verify the custom app-level error types
-// (ErrNotFound, ErrSubtitleNotFoundInArchive, ErrSubtitleResourceNotFound),
+// (ErrNotFound, ErrSubtitleNotFoundInArchive, ErrSubtitleResourceNotFound,
+// ErrInvalidDownloadURL, ErrZipBombDetected, ErrDownloadTooLarge, ErrInvalidArchive,
+// ErrUpstreamStatus, ErrAmbiguousShow, ErrShowTimeout, ErrNotAnImage, ErrUpstreamBlocked,
+// ErrUpstreamMaintenance, ErrUnexpectedPage, ErrMalformedSubtitle, ErrInvalidSourceEncoding),
 // their Error() messages, Is() matching semantics, constructor helpers, and
 // compatibility with errors.Is() including through fmt.Errorf wrapping.
 package apperrors
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"google.golang.org/grpc/codes"
 )
 
 // ---------------------------------------------------------------------------
@@ -216,6 +225,28 @@ func TestErrSubtitleNotFoundInArchive_Error(t *testing.T) {
 	}
 }
 
+func TestErrSubtitleNotFoundInArchive_ErrorWithTitle(t *testing.T) {
+	t.Parallel()
+	err := &ErrSubtitleNotFoundInArchive{
+		EpisodeTitle:    "pilot",
+		FileCount:       2,
+		AvailableTitles: []string{"Show.S01E02.Second", "Show.S01E03.Third"},
+	}
+	want := `episode titled "pilot" not found in season pack archive (searched 2 files, available: Show.S01E02.Second, Show.S01E03.Third)`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestErrSubtitleNotFoundInArchive_ErrorWithRange(t *testing.T) {
+	t.Parallel()
+	err := &ErrSubtitleNotFoundInArchive{Episode: 1, EpisodeEnd: 5, FileCount: 8}
+	want := "episodes 1-5 not found in season pack archive (searched 8 files)"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
 func TestErrSubtitleNotFoundInArchive_Is(t *testing.T) {
 	t.Parallel()
 	err := &ErrSubtitleNotFoundInArchive{Episode: 3, FileCount: 10}
@@ -355,6 +386,271 @@ func TestErrSubtitleResourceNotFound_Is(t *testing.T) {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// ErrInvalidDownloadURL
+// ---------------------------------------------------------------------------
+
+func TestErrInvalidDownloadURL_Error(t *testing.T) {
+	t.Parallel()
+	err := &ErrInvalidDownloadURL{URL: "https://evil.example/x.zip", Reason: "host must be feliratok.eu"}
+	expected := `invalid download URL "https://evil.example/x.zip": host must be feliratok.eu`
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+}
+
+func TestErrInvalidDownloadURL_Is(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("download: %w", &ErrInvalidDownloadURL{URL: "ftp://x", Reason: "bad scheme"})
+	if !errors.Is(err, &ErrInvalidDownloadURL{}) {
+		t.Error("expected errors.Is to match *ErrInvalidDownloadURL through wrapping")
+	}
+	if errors.Is(err, &ErrSubtitleResourceNotFound{}) {
+		t.Error("expected errors.Is not to match *ErrSubtitleResourceNotFound")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Download errors
+// ---------------------------------------------------------------------------
+
+func TestErrZipBombDetected_Error(t *testing.T) {
+	t.Parallel()
+	err := &ErrZipBombDetected{Reason: "overall compression ratio is suspicious (500.00 > 100)"}
+	expected := "ZIP bomb detected: overall compression ratio is suspicious (500.00 > 100)"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+
+	err.URL = "http://x/a.zip"
+	expected = "ZIP bomb detected (url: http://x/a.zip): overall compression ratio is suspicious (500.00 > 100)"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+}
+
+func TestErrZipBombDetected_IsAndUnwrap(t *testing.T) {
+	t.Parallel()
+	cause := errors.New("archive error")
+	err := fmt.Errorf("download: %w", &ErrZipBombDetected{Reason: "too big", Err: cause})
+	if !errors.Is(err, &ErrZipBombDetected{}) {
+		t.Error("expected errors.Is to match *ErrZipBombDetected through wrapping")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected errors.Is to reach the wrapped cause")
+	}
+}
+
+func TestErrDownloadTooLarge_Error(t *testing.T) {
+	t.Parallel()
+	err := &ErrDownloadTooLarge{Size: 2049, Limit: 2048}
+	expected := "download size (2049 bytes) exceeds limit (2048 bytes)"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+}
+
+func TestErrDownloadTooLarge_As(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("download: %w", &ErrDownloadTooLarge{Size: 2049, Limit: 2048})
+	var target *ErrDownloadTooLarge
+	if !errors.As(err, &target) {
+		t.Fatal("expected errors.As to extract *ErrDownloadTooLarge")
+	}
+	if target.Limit != 2048 {
+		t.Errorf("Limit = %d, want 2048", target.Limit)
+	}
+}
+
+func TestErrDownloadTooLarge_Metadata(t *testing.T) {
+	t.Parallel()
+	var err error = &ErrDownloadTooLarge{Size: 2049, Limit: 2048}
+	var metadataErr MetadataError
+	if !errors.As(err, &metadataErr) {
+		t.Fatal("expected ErrDownloadTooLarge to implement MetadataError")
+	}
+	if got := metadataErr.Metadata()["limit_bytes"]; got != "2048" {
+		t.Errorf("limit_bytes = %q, want %q", got, "2048")
+	}
+}
+
+func TestErrInvalidArchive_ErrorAndUnwrap(t *testing.T) {
+	t.Parallel()
+	cause := errors.New("zip: not a valid zip file")
+	err := &ErrInvalidArchive{Err: cause}
+	if got, want := err.Error(), "invalid archive: zip: not a valid zip file"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(fmt.Errorf("download: %w", err), cause) {
+		t.Error("expected errors.Is to reach the wrapped cause")
+	}
+}
+
+func TestErrUpstreamStatus_Codes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		code     int
+		wantGRPC codes.Code
+		wantHTTP int
+	}{
+		{code: http.StatusNotFound, wantGRPC: codes.NotFound, wantHTTP: http.StatusNotFound},
+		{code: http.StatusInternalServerError, wantGRPC: codes.Unavailable, wantHTTP: http.StatusServiceUnavailable},
+		{code: http.StatusServiceUnavailable, wantGRPC: codes.Unavailable, wantHTTP: http.StatusServiceUnavailable},
+		{code: http.StatusForbidden, wantGRPC: codes.Internal, wantHTTP: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			t.Parallel()
+			err := &ErrUpstreamStatus{Code: tt.code}
+			if got := err.GRPCCode(); got != tt.wantGRPC {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.wantGRPC)
+			}
+			if got := err.HTTPStatusCode(); got != tt.wantHTTP {
+				t.Errorf("HTTPStatusCode() = %d, want %d", got, tt.wantHTTP)
+			}
+		})
+	}
+}
+
+func TestErrAmbiguousShow_Error(t *testing.T) {
+	t.Parallel()
+	err := &ErrAmbiguousShow{Name: "Dallas", Candidates: []models.Show{
+		{ID: 1, Name: "Dallas", Year: 1978},
+		{ID: 2, Name: "Dallas", Year: 2012},
+	}}
+	expected := `show name "Dallas" is ambiguous, specify a year: Dallas (1978, ID 1), Dallas (2012, ID 2)`
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+	if got := err.GRPCCode(); got != codes.InvalidArgument {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.InvalidArgument)
+	}
+	var target *ErrAmbiguousShow
+	if !errors.As(fmt.Errorf("find show: %w", err), &target) || len(target.Candidates) != 2 {
+		t.Error("expected errors.As to extract *ErrAmbiguousShow with its candidates")
+	}
+}
+
+func TestErrShowTimeout(t *testing.T) {
+	t.Parallel()
+	err := &ErrShowTimeout{ShowID: 42, Timeout: 30 * time.Second}
+	if got, want := err.Error(), "show 42 timed out after 30s"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.DeadlineExceeded {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.DeadlineExceeded)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusGatewayTimeout {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusGatewayTimeout)
+	}
+	if got := err.Metadata()["show_id"]; got != "42" {
+		t.Errorf("Metadata()[show_id] = %q, want %q", got, "42")
+	}
+
+	wrapped := fmt.Errorf("stream: %w", err)
+	if !errors.Is(wrapped, &ErrShowTimeout{}) {
+		t.Error("expected errors.Is to match ErrShowTimeout through wrapping")
+	}
+	if !errors.Is(wrapped, context.DeadlineExceeded) {
+		t.Error("expected errors.Is to match context.DeadlineExceeded")
+	}
+}
+
+func TestErrNotAnImage(t *testing.T) {
+	t.Parallel()
+	err := &ErrNotAnImage{URL: "https://feliratok.eu/sorozat_cat.php?kep=1", ContentType: "text/html"}
+	if got, want := err.Error(), "upstream returned a non-image response (content-type: text/html) for https://feliratok.eu/sorozat_cat.php?kep=1"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.FailedPrecondition {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.FailedPrecondition)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusBadGateway {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusBadGateway)
+	}
+	if !errors.Is(fmt.Errorf("image: %w", err), &ErrNotAnImage{}) {
+		t.Error("expected errors.Is to match ErrNotAnImage through wrapping")
+	}
+}
+
+func TestErrUpstreamBlocked(t *testing.T) {
+	t.Parallel()
+	err := &ErrUpstreamBlocked{Reason: "captcha page"}
+	if got, want := err.Error(), "upstream blocked the request: captcha page"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.Unavailable {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.Unavailable)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusServiceUnavailable {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+	if !errors.Is(fmt.Errorf("first page: %w", err), &ErrUpstreamBlocked{}) {
+		t.Error("expected errors.Is to match ErrUpstreamBlocked through wrapping")
+	}
+}
+
+func TestErrUpstreamMaintenance(t *testing.T) {
+	t.Parallel()
+	err := &ErrUpstreamMaintenance{Reason: "maintenance page titled Karbantartás"}
+	if got, want := err.Error(), "upstream is unavailable: maintenance page titled Karbantartás"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.Unavailable {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.Unavailable)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusServiceUnavailable {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+	if !errors.Is(fmt.Errorf("first page: %w", err), &ErrUpstreamMaintenance{}) {
+		t.Error("expected errors.Is to match ErrUpstreamMaintenance through wrapping")
+	}
+	if errors.Is(err, &ErrUpstreamBlocked{}) {
+		t.Error("expected a maintenance page not to count as a block")
+	}
+}
+
+func TestErrUnexpectedPage(t *testing.T) {
+	t.Parallel()
+	err := &ErrUnexpectedPage{Page: "subtitle listing", Reason: "no result table"}
+	if got, want := err.Error(), "upstream page is not a subtitle listing: no result table"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.Unavailable {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.Unavailable)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusBadGateway {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusBadGateway)
+	}
+	if !errors.Is(fmt.Errorf("first page: %w", err), &ErrUnexpectedPage{}) {
+		t.Error("expected errors.Is to match ErrUnexpectedPage through wrapping")
+	}
+}
+
+func TestErrMalformedSubtitle(t *testing.T) {
+	t.Parallel()
+	err := &ErrMalformedSubtitle{Format: "srt", Line: 6, Reason: "unparseable timecode"}
+	if got, want := err.Error(), "malformed srt subtitle at line 6: unparseable timecode"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := (&ErrMalformedSubtitle{Format: "vtt", Reason: "no cues"}).Error(), "malformed vtt subtitle: no cues"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.DataLoss {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.DataLoss)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusUnprocessableEntity {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusUnprocessableEntity)
+	}
+	if got := err.Metadata(); got["format"] != "srt" || got["line"] != "6" {
+		t.Errorf("Metadata() = %v, want format srt and line 6", got)
+	}
+	if !errors.Is(fmt.Errorf("download: %w", err), &ErrMalformedSubtitle{}) {
+		t.Error("expected errors.Is to match ErrMalformedSubtitle through wrapping")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Cross-type isolation: no error type matches any other type
 // ---------------------------------------------------------------------------
@@ -365,6 +661,17 @@ func TestErrorTypes_CrossTypeIsolation(t *testing.T) {
 		&ErrNotFound{Resource: "x", ID: 1},
 		&ErrSubtitleNotFoundInArchive{Episode: 1, FileCount: 1},
 		&ErrSubtitleResourceNotFound{URL: "http://x"},
+		&ErrInvalidDownloadURL{URL: "http://x", Reason: "y"},
+		&ErrZipBombDetected{Reason: "x"},
+		&ErrDownloadTooLarge{Size: 2, Limit: 1},
+		&ErrInvalidArchive{},
+		&ErrUpstreamStatus{Code: 500},
+		&ErrAmbiguousShow{Name: "x"},
+		&ErrShowTimeout{ShowID: 1},
+		&ErrNotAnImage{URL: "http://x"},
+		&ErrUpstreamBlocked{Reason: "x"},
+		&ErrUnexpectedPage{Page: "x"},
+		&ErrMalformedSubtitle{Format: "srt"},
 	}
 
 	for i, a := range errs {
@@ -391,4 +698,35 @@ func TestErrorTypes_ImplementErrorInterface(t *testing.T) {
 	var _ GRPCBindableError = &ErrNotFound{}
 	var _ GRPCBindableError = &ErrSubtitleNotFoundInArchive{}
 	var _ GRPCBindableError = &ErrSubtitleResourceNotFound{}
+	var _ error = &ErrInvalidDownloadURL{}
+	var _ GRPCBindableError = &ErrInvalidDownloadURL{}
+	var _ GRPCBindableError = &ErrZipBombDetected{}
+	var _ GRPCBindableError = &ErrDownloadTooLarge{}
+	var _ GRPCBindableError = &ErrInvalidArchive{}
+	var _ GRPCBindableError = &ErrUpstreamStatus{}
+	var _ GRPCBindableError = &ErrAmbiguousShow{}
+	var _ GRPCBindableError = &ErrShowTimeout{}
+	var _ MetadataError = &ErrShowTimeout{}
+	var _ GRPCBindableError = &ErrNotAnImage{}
+	var _ GRPCBindableError = &ErrUpstreamBlocked{}
+	var _ GRPCBindableError = &ErrUnexpectedPage{}
+	var _ GRPCBindableError = &ErrMalformedSubtitle{}
+	var _ MetadataError = &ErrMalformedSubtitle{}
+}
+
+func TestErrInvalidSourceEncoding(t *testing.T) {
+	t.Parallel()
+	err := &ErrInvalidSourceEncoding{Encoding: "utf-16le", Reason: "invalid input"}
+	if got, want := err.Error(), `cannot decode subtitle as "utf-16le": invalid input`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := err.GRPCCode(); got != codes.InvalidArgument {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.InvalidArgument)
+	}
+	if got := err.HTTPStatusCode(); got != http.StatusBadRequest {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusBadRequest)
+	}
+	if !errors.Is(fmt.Errorf("download: %w", err), &ErrInvalidSourceEncoding{}) {
+		t.Error("expected errors.Is to match ErrInvalidSourceEncoding through wrapping")
+	}
 }
@@ -11,31 +11,6 @@ import (
 	"github.com/nwaples/rardecode/v2"
 )
 
-// Size limit constants for archive operations.
-const (
-	// Maximum compression ratio (uncompressed/compressed).
-	// Highly repetitive content can legitimately compress to 1000:1 or more.
-	// Real subtitle files rarely exceed 20:1, but we set a generous limit to avoid false positives.
-	MaxCompressionRatio = 10000
-	// Maximum uncompressed size for a single file (20 MB).
-	MaxUncompressedFileSize = 20 * 1024 * 1024
-	// Maximum uncompressed size for a single ASS file (100 MB).
-	// ASS files can legitimately be large because they often embed font data as base64.
-	MaxUncompressedAssFileSize = 100 * 1024 * 1024
-	// Maximum total uncompressed size for all files in an archive (100 MB).
-	MaxTotalUncompressedSize = 100 * 1024 * 1024
-)
-
-// maxFileSizeForExtension returns the maximum allowed uncompressed size for a file
-// based on its extension. ASS subtitle files can legitimately contain embedded fonts
-// and may exceed the standard limit, so they receive a higher allowance.
-func maxFileSizeForExtension(filename string) int64 {
-	if strings.ToLower(path.Ext(filename)) == ".ass" {
-		return MaxUncompressedAssFileSize
-	}
-	return MaxUncompressedFileSize
-}
-
 // archiveLimitWriter is an io.Writer that enforces per-file and total uncompressed size limits
 // to guard against ZIP/RAR bomb extraction attacks.
 type archiveLimitWriter struct {
@@ -43,22 +18,23 @@ type archiveLimitWriter struct {
 	fileName     string
 	fileWritten  int64
 	totalWritten *int64
+	limits       Limits
 }
 
 func (w *archiveLimitWriter) Write(p []byte) (int, error) {
 	fileSize := w.fileWritten + int64(len(p))
-	if fileSize > maxFileSizeForExtension(w.fileName) {
+	if fileSize > w.limits.maxFileSizeForExtension(w.fileName) {
 		return 0, NewUnrecoverableError(
 			"RAR archive entry exceeds maximum uncompressed size",
-			fmt.Errorf("entry %s is %d bytes > %d bytes limit", w.fileName, fileSize, maxFileSizeForExtension(w.fileName)),
+			&ErrDecompressionBomb{Reason: fmt.Sprintf("entry %s exceeds maximum uncompressed size (%d bytes > %d bytes limit)", w.fileName, fileSize, w.limits.maxFileSizeForExtension(w.fileName))},
 		)
 	}
 
 	totalSize := *w.totalWritten + int64(len(p))
-	if totalSize > MaxTotalUncompressedSize {
+	if totalSize > w.limits.MaxTotalSize {
 		return 0, NewUnrecoverableError(
 			"RAR archive total uncompressed size exceeds limit",
-			fmt.Errorf("%d bytes > %d bytes limit", totalSize, MaxTotalUncompressedSize),
+			&ErrDecompressionBomb{Reason: fmt.Sprintf("total uncompressed size exceeds limit (%d bytes > %d bytes limit)", totalSize, w.limits.MaxTotalSize)},
 		)
 	}
 
@@ -70,18 +46,48 @@ func (w *archiveLimitWriter) Write(p []byte) (int, error) {
 
 // ConvertRarToZip converts a RAR archive to a ZIP archive.
 // It sanitizes entry names to prevent path traversal attacks and enforces
-// per-file and total uncompressed size limits.
-func ConvertRarToZip(rarContent []byte) ([]byte, error) {
+// the per-file and total uncompressed size limits in limits.
+func ConvertRarToZip(rarContent []byte, limits Limits) ([]byte, error) {
+	zipBuffer := new(bytes.Buffer)
+	if err := ConvertRarToZipTo(zipBuffer, bytes.NewReader(rarContent), limits); err != nil {
+		return nil, err
+	}
+	return zipBuffer.Bytes(), nil
+}
+
+// PackZip writes files into a new ZIP archive, one entry per file named by its Filename.
+func PackZip(files []*EpisodeFile) ([]byte, error) {
+	zipBuffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipBuffer)
+	for _, file := range files {
+		entryWriter, err := zipWriter.Create(file.Filename)
+		if err != nil {
+			return nil, NewError(fmt.Sprintf("failed to create ZIP entry %s", file.Filename), err)
+		}
+		if _, err := entryWriter.Write(file.Content); err != nil {
+			return nil, NewError(fmt.Sprintf("failed to write ZIP entry %s", file.Filename), err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, NewError("failed to finalize ZIP archive", err)
+	}
+	return zipBuffer.Bytes(), nil
+}
+
+// ConvertRarToZipTo is ConvertRarToZip for a RAR archive read from r, writing the ZIP
+// archive to w. RAR archives are decoded sequentially, so neither archive has to be
+// held in memory.
+func ConvertRarToZipTo(w io.Writer, r io.Reader, limits Limits) error {
+	limits = limits.withDefaults()
 	rarReader, err := rardecode.NewReader(
-		bytes.NewReader(rarContent),
-		rardecode.MaxDictionarySize(MaxTotalUncompressedSize),
+		r,
+		rardecode.MaxDictionarySize(limits.MaxTotalSize),
 	)
 	if err != nil {
-		return nil, NewUnrecoverableError("failed to open RAR archive", err)
+		return NewUnrecoverableError("failed to open RAR archive", &ErrMalformedArchive{Format: "RAR", Err: err})
 	}
 
-	zipBuffer := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(zipBuffer)
+	zipWriter := zip.NewWriter(w)
 	var totalWritten int64
 
 	for {
@@ -90,7 +96,7 @@ func ConvertRarToZip(rarContent []byte) ([]byte, error) {
 			break
 		}
 		if err != nil {
-			return nil, NewUnrecoverableError("failed to read RAR entry", err)
+			return NewUnrecoverableError("failed to read RAR entry", &ErrMalformedArchive{Format: "RAR", Err: err})
 		}
 		if header.IsDir {
 			continue
@@ -105,7 +111,7 @@ func ConvertRarToZip(rarContent []byte) ([]byte, error) {
 		entryName = strings.TrimLeft(entryName, "/")
 		for _, component := range strings.Split(entryName, "/") {
 			if component == ".." {
-				return nil, NewUnrecoverableError(
+				return NewUnrecoverableError(
 					"RAR archive contains path traversal in entry name",
 					fmt.Errorf("%q", header.Name),
 				)
@@ -115,32 +121,33 @@ func ConvertRarToZip(rarContent []byte) ([]byte, error) {
 			entryName = "subtitle"
 		}
 
-		if header.UnPackedSize > maxFileSizeForExtension(entryName) {
-			return nil, NewUnrecoverableError(
+		if header.UnPackedSize > limits.maxFileSizeForExtension(entryName) {
+			return NewUnrecoverableError(
 				"RAR archive entry exceeds maximum uncompressed size",
-				fmt.Errorf("entry %s is %d bytes > %d bytes limit", entryName, header.UnPackedSize, maxFileSizeForExtension(entryName)),
+				&ErrDecompressionBomb{Reason: fmt.Sprintf("entry %s exceeds maximum uncompressed size (%d bytes > %d bytes limit)", entryName, header.UnPackedSize, limits.maxFileSizeForExtension(entryName))},
 			)
 		}
 
 		entryWriter, err := zipWriter.Create(entryName)
 		if err != nil {
-			return nil, NewError(fmt.Sprintf("failed to create ZIP entry %s", entryName), err)
+			return NewError(fmt.Sprintf("failed to create ZIP entry %s", entryName), err)
 		}
 
 		limitWriter := &archiveLimitWriter{
 			writer:       entryWriter,
 			fileName:     entryName,
 			totalWritten: &totalWritten,
+			limits:       limits,
 		}
 
 		if _, err := io.Copy(limitWriter, rarReader); err != nil {
-			return nil, NewUnrecoverableError(fmt.Sprintf("failed to copy RAR entry %s", entryName), err)
+			return NewUnrecoverableError(fmt.Sprintf("failed to copy RAR entry %s", entryName), err)
 		}
 	}
 
 	if err := zipWriter.Close(); err != nil {
-		return nil, NewError("failed to finalize ZIP archive", err)
+		return NewError("failed to finalize ZIP archive", err)
 	}
 
-	return zipBuffer.Bytes(), nil
+	return nil
 }
@@ -3,6 +3,7 @@ package archive
 import (
 	"archive/zip"
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -74,20 +75,43 @@ func TestConvertRarToZip_RenegadeFixture(t *testing.T) {
 
 	rarContent := readRARFixtureByName(t, "Renegade.S01.WEB-DL.H.264-JiTB.eng.rar")
 
-	zipContent, err := ConvertRarToZip(rarContent)
+	zipContent, err := ConvertRarToZip(rarContent, DefaultLimits())
 	if err != nil {
 		t.Fatalf("ConvertRarToZip returned unexpected error: %v", err)
 	}
 	assertValidConvertedZIP(t, zipContent)
 }
 
+func TestConvertRarToZipTo_MatchesConvertRarToZip(t *testing.T) {
+	t.Parallel()
+
+	rarContent := readRARFixtureByName(t, "Renegade.S01.WEB-DL.H.264-JiTB.eng.rar")
+
+	want, err := ConvertRarToZip(rarContent, DefaultLimits())
+	if err != nil {
+		t.Fatalf("ConvertRarToZip returned unexpected error: %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := ConvertRarToZipTo(&got, bytes.NewReader(rarContent), DefaultLimits()); err != nil {
+		t.Fatalf("ConvertRarToZipTo returned unexpected error: %v", err)
+	}
+	assertValidConvertedZIP(t, got.Bytes())
+
+	wantNames, _ := zipEntries(t, want)
+	gotNames, _ := zipEntries(t, got.Bytes())
+	if strings.Join(gotNames, ",") != strings.Join(wantNames, ",") {
+		t.Errorf("expected entries %v, got %v", wantNames, gotNames)
+	}
+}
+
 func TestConvertRarToZip_AncladosFixture(t *testing.T) {
 	t.Parallel()
 	t.Skip("Currently unsupported by rardecode, issue opened")
 
 	rarContent := readRARFixtureByName(t, "Anclados.S01.1080p.AMZN.WEB-DL.DD+2.0.H.264-CasStudio_eng.rar")
 
-	zipContent, err := ConvertRarToZip(rarContent)
+	zipContent, err := ConvertRarToZip(rarContent, DefaultLimits())
 	if err != nil {
 		t.Fatalf("ConvertRarToZip returned unexpected error: %v", err)
 	}
@@ -97,7 +121,7 @@ func TestConvertRarToZip_AncladosFixture(t *testing.T) {
 func TestConvertRarToZip_InvalidInput(t *testing.T) {
 	t.Parallel()
 
-	_, err := ConvertRarToZip([]byte("this is not a rar file"))
+	_, err := ConvertRarToZip([]byte("this is not a rar file"), DefaultLimits())
 	if err == nil {
 		t.Fatal("expected error for invalid RAR input, got nil")
 	}
@@ -112,6 +136,7 @@ func TestArchiveLimitWriter_Write_WithinLimits(t *testing.T) {
 		writer:       &buf,
 		fileName:     "test.srt",
 		totalWritten: &total,
+		limits:       DefaultLimits(),
 	}
 
 	data := []byte("hello subtitle")
@@ -140,6 +165,7 @@ func TestArchiveLimitWriter_Write_ExceedsFileLimit(t *testing.T) {
 		fileName:     "big.srt",
 		fileWritten:  MaxUncompressedFileSize - 1,
 		totalWritten: &total,
+		limits:       DefaultLimits(),
 	}
 
 	_, err := w.Write([]byte("xx"))
@@ -149,6 +175,9 @@ func TestArchiveLimitWriter_Write_ExceedsFileLimit(t *testing.T) {
 	if !strings.Contains(err.Error(), "big.srt") {
 		t.Errorf("error message should mention the filename, got: %v", err)
 	}
+	if !errors.Is(err, &ErrDecompressionBomb{}) {
+		t.Errorf("expected ErrDecompressionBomb cause, got: %v", err)
+	}
 }
 
 func TestArchiveLimitWriter_Write_ExceedsTotalLimit(t *testing.T) {
@@ -160,6 +189,7 @@ func TestArchiveLimitWriter_Write_ExceedsTotalLimit(t *testing.T) {
 		writer:       &buf,
 		fileName:     "entry.srt",
 		totalWritten: &total,
+		limits:       DefaultLimits(),
 	}
 
 	_, err := w.Write([]byte("xx"))
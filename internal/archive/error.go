@@ -84,3 +84,43 @@ func NewUnrecoverableError(message string, err error) *ArchiveError {
 func NewUnrecoverableErrorWithURL(message, url string, err error) *ArchiveError {
 	return &ArchiveError{Message: message, URL: url, Err: err, Unrecoverable: true}
 }
+
+// ErrDecompressionBomb is the cause of an ArchiveError returned when an archive would
+// decompress past the size limits or has a suspicious compression ratio.
+type ErrDecompressionBomb struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ErrDecompressionBomb) Error() string {
+	return e.Reason
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrDecompressionBomb) Is(target error) bool {
+	_, ok := target.(*ErrDecompressionBomb)
+	return ok
+}
+
+// ErrMalformedArchive is the cause of an ArchiveError returned when content cannot be
+// read as the archive Format ("ZIP" or "RAR") it was detected as.
+type ErrMalformedArchive struct {
+	Format string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *ErrMalformedArchive) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped cause.
+func (e *ErrMalformedArchive) Unwrap() error {
+	return e.Err
+}
+
+// Is allows for error checking with errors.Is().
+func (e *ErrMalformedArchive) Is(target error) bool {
+	_, ok := target.(*ErrMalformedArchive)
+	return ok
+}
@@ -3,29 +3,44 @@ package archive
 import (
 	"archive/zip"
 	"bytes"
+	"cmp"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/rs/zerolog"
 )
 
 // EpisodeFile contains the result of extracting an episode from an archive.
 type EpisodeFile struct {
-	Filename string
+	Filename string // Base name of the entry
+	Path     string // Full entry path inside the archive, with forward slashes
 	Content  []byte
+	Charset  string // Encoding SanitizeZip converted the entry from, such as "iso-8859-2"; empty when not recorded
 }
 
 // ErrEpisodeNotFound is returned when the requested episode cannot be found in an archive.
+// Title is set instead of Episode when the lookup was by episode title, in which case
+// Available lists the archive's file names (without extension).
 type ErrEpisodeNotFound struct {
 	Episode   int
+	Title     string
 	FileCount int
+	Available []string
 }
 
 func (e *ErrEpisodeNotFound) Error() string {
+	if e.Title != "" {
+		return fmt.Sprintf("episode titled %q not found in season pack archive (searched %d files, available: %s)",
+			e.Title, e.FileCount, strings.Join(e.Available, ", "))
+	}
 	return fmt.Sprintf("episode %d not found in season pack archive (searched %d files)", e.Episode, e.FileCount)
 }
 
@@ -34,14 +49,23 @@ func (e *ErrEpisodeNotFound) Is(target error) bool {
 	return ok
 }
 
-// DetectZipBomb analyzes a ZIP file for characteristics of a ZIP bomb.
-func DetectZipBomb(zipContent []byte) error {
-	zipReader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+// DetectZipBomb analyzes a ZIP file for characteristics of a ZIP bomb, rejecting entries
+// and totals above the uncompressed size limits in limits.
+func DetectZipBomb(zipContent []byte, limits Limits) error {
+	return DetectZipBombReaderAt(bytes.NewReader(zipContent), int64(len(zipContent)), limits)
+}
+
+// DetectZipBombReaderAt is DetectZipBomb for a ZIP of the given size read through r,
+// so archives spooled to disk are checked without loading them. Only the central
+// directory is read.
+func DetectZipBombReaderAt(r io.ReaderAt, size int64, limits Limits) error {
+	limits = limits.withDefaults()
+	zipReader, err := zip.NewReader(r, size)
 	if err != nil {
-		return NewUnrecoverableError("failed to open ZIP for bomb detection", err)
+		return NewUnrecoverableError("failed to open ZIP for bomb detection", &ErrMalformedArchive{Format: "ZIP", Err: err})
 	}
 
-	compressedSize := int64(len(zipContent))
+	compressedSize := size
 	var totalUncompressedSize uint64
 
 	for _, file := range zipReader.File {
@@ -52,11 +76,11 @@ func DetectZipBomb(zipContent []byte) error {
 		uncompressedSize := file.UncompressedSize64
 		totalUncompressedSize += uncompressedSize
 
-		fileLimit := uint64(maxFileSizeForExtension(file.Name))
+		fileLimit := uint64(limits.maxFileSizeForExtension(file.Name))
 		if uncompressedSize > fileLimit {
 			return NewUnrecoverableError(
 				"ZIP bomb detected",
-				fmt.Errorf("file %s exceeds maximum uncompressed size (%d bytes > %d bytes limit)", file.Name, uncompressedSize, fileLimit),
+				&ErrDecompressionBomb{Reason: fmt.Sprintf("file %s exceeds maximum uncompressed size (%d bytes > %d bytes limit)", file.Name, uncompressedSize, fileLimit)},
 			)
 		}
 
@@ -65,16 +89,16 @@ func DetectZipBomb(zipContent []byte) error {
 			if ratio > MaxCompressionRatio {
 				return NewUnrecoverableError(
 					"ZIP bomb detected",
-					fmt.Errorf("file %s has suspicious compression ratio (%.2f > %d)", file.Name, ratio, MaxCompressionRatio),
+					&ErrDecompressionBomb{Reason: fmt.Sprintf("file %s has suspicious compression ratio (%.2f > %d)", file.Name, ratio, MaxCompressionRatio)},
 				)
 			}
 		}
 	}
 
-	if totalUncompressedSize > MaxTotalUncompressedSize {
+	if totalUncompressedSize > uint64(limits.MaxTotalSize) {
 		return NewUnrecoverableError(
 			"ZIP bomb detected",
-			fmt.Errorf("total uncompressed size exceeds limit (%d bytes > %d bytes limit)", totalUncompressedSize, MaxTotalUncompressedSize),
+			&ErrDecompressionBomb{Reason: fmt.Sprintf("total uncompressed size exceeds limit (%d bytes > %d bytes limit)", totalUncompressedSize, limits.MaxTotalSize)},
 		)
 	}
 
@@ -83,7 +107,7 @@ func DetectZipBomb(zipContent []byte) error {
 		if overallRatio > MaxCompressionRatio {
 			return NewUnrecoverableError(
 				"ZIP bomb detected",
-				fmt.Errorf("overall compression ratio is suspicious (%.2f > %d)", overallRatio, MaxCompressionRatio),
+				&ErrDecompressionBomb{Reason: fmt.Sprintf("overall compression ratio is suspicious (%.2f > %d)", overallRatio, MaxCompressionRatio)},
 			)
 		}
 	}
@@ -91,40 +115,181 @@ func DetectZipBomb(zipContent []byte) error {
 	return nil
 }
 
+// EpisodePattern returns a case-insensitive pattern matching the episode marker of episode in a
+// file name or path: "S01E05", "E05" or "1x05", with the number unpadded or zero-padded to up to
+// three digits ("E5", "E05", "E005"). A bare "E" must not follow a letter or digit, so "Dune2"
+// is not episode 2, and the number must not be followed by another digit, so episode 1 does not
+// match "E10" or "E100". Episode 0 matches specials such as "S00E00" or "E00".
+func EpisodePattern(episode int) *regexp.Regexp {
+	number := strconv.Itoa(episode)
+	if pad := 3 - len(number); pad > 0 {
+		number = fmt.Sprintf("0{0,%d}%s", pad, number)
+	}
+	return regexp.MustCompile(`(?i)(?:s\d+e|(?:^|[^a-z0-9])e|\d+x)` + number + `(?:\D|$)`)
+}
+
 // ExtractEpisodeFromZip extracts a specific episode's subtitle from a ZIP archive.
 // It performs ZIP bomb detection before processing.
-func ExtractEpisodeFromZip(zipContent []byte, episode int, logger zerolog.Logger) (*EpisodeFile, error) {
-	if err := DetectZipBomb(zipContent); err != nil {
-		logger.Warn().Err(err).Msg("ZIP bomb detected and blocked")
+func ExtractEpisodeFromZip(zipContent []byte, episode int, limits Limits, logger zerolog.Logger) (*EpisodeFile, error) {
+	episodePattern := EpisodePattern(episode)
+
+	logger.Debug().
+		Int("episode", episode).
+		Msg("Searching for episode in archive")
+
+	return extractBestMatchFromZip(
+		zipContent,
+		limits,
+		logger,
+		func(filename, fullPath string) bool {
+			return episodePattern.MatchString(filename) || episodePattern.MatchString(fullPath)
+		},
+		func(fileCount int, _ []string) error {
+			return &ErrEpisodeNotFound{Episode: episode, FileCount: fileCount}
+		},
+	)
+}
+
+// ExtractEpisodeByTitleFromZip extracts the subtitle whose filename or path contains the given
+// episode title. Both sides are normalized with NormalizeEpisodeTitle before comparison, so the
+// match ignores case and punctuation. It performs ZIP bomb detection before processing.
+func ExtractEpisodeByTitleFromZip(zipContent []byte, title string, limits Limits, logger zerolog.Logger) (*EpisodeFile, error) {
+	normalizedTitle := NormalizeEpisodeTitle(title)
+	if normalizedTitle == "" {
+		return nil, NewUnrecoverableError(fmt.Sprintf("episode title %q is empty after normalization", title), nil)
+	}
+
+	logger.Debug().
+		Str("episodeTitle", title).
+		Str("normalizedTitle", normalizedTitle).
+		Msg("Searching for episode title in archive")
+
+	return extractBestMatchFromZip(
+		zipContent,
+		limits,
+		logger,
+		func(filename, fullPath string) bool {
+			return strings.Contains(NormalizeEpisodeTitle(filename), normalizedTitle) ||
+				strings.Contains(NormalizeEpisodeTitle(fullPath), normalizedTitle)
+		},
+		func(fileCount int, available []string) error {
+			return &ErrEpisodeNotFound{Title: title, FileCount: fileCount, Available: available}
+		},
+	)
+}
+
+// ExtractSingleSubtitleFromZip returns the archive's only subtitle file, or nil when the archive
+// holds no subtitle or more than one. Other entries are ignored. It performs ZIP bomb detection
+// before processing.
+func ExtractSingleSubtitleFromZip(zipContent []byte, limits Limits) (*EpisodeFile, error) {
+	limits = limits.withDefaults()
+	if err := DetectZipBomb(zipContent, limits); err != nil {
 		return nil, err
 	}
 
 	zipReader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
 	if err != nil {
-		return nil, NewUnrecoverableError("failed to open ZIP archive", err)
+		return nil, NewUnrecoverableError("failed to open ZIP archive", &ErrMalformedArchive{Format: "ZIP", Err: err})
 	}
 
-	episodePattern := regexp.MustCompile(fmt.Sprintf(`(?i)(?:s\d+e%02d(?:\D|$)|e%02d(?:\D|$)|\d+x%02d(?:\D|$))`, episode, episode, episode))
+	var single *zip.File
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || !isSubtitleFile(file.Name) {
+			continue
+		}
+		if single != nil {
+			return nil, nil
+		}
+		single = file
+	}
+	if single == nil {
+		return nil, nil
+	}
+
+	rc, err := single.Open()
+	if err != nil {
+		return nil, NewUnrecoverableError(fmt.Sprintf("failed to open file %s in ZIP", single.Name), err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, NewUnrecoverableError(fmt.Sprintf("failed to read file %s from ZIP", single.Name), err)
+	}
+
+	fullPath := entryPath(single)
+	return &EpisodeFile{
+		Filename: path.Base(fullPath),
+		Path:     fullPath,
+		Content:  content,
+		Charset:  entryCharset(single),
+	}, nil
+}
+
+// entryPath returns the path file had in the uploaded archive, with forward slashes: the path
+// SanitizeZip recorded in the comment of a flattened entry, or else the entry name.
+func entryPath(file *zip.File) string {
+	return strings.ToValidUTF8(strings.ReplaceAll(cmp.Or(file.Comment, file.Name), "\\", "/"), "�")
+}
+
+// NormalizeEpisodeTitle lowercases s and collapses every run of punctuation, separators
+// and whitespace into a single space, so "Show.S03E02.I-Said_No" becomes "show s03e02 i said no".
+func NormalizeEpisodeTitle(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// subtitleExtensionPriority ranks matched files by extension. Lower is better.
+var subtitleExtensionPriority = map[string]int{
+	".srt": 0,
+	".ass": 1,
+	".vtt": 2,
+	".sub": 3,
+}
+
+// unknownExtensionPriority is assigned to matched files that are not a known subtitle type.
+const unknownExtensionPriority = 4
+
+// extractBestMatchFromZip returns the best file accepted by match, skipping files whose extension
+// limits deny. It prefers subtitle extensions, then files not marked for the hearing impaired
+// (SDH/CC), then the highest resolution named in the filename, and breaks remaining ties
+// alphabetically. When nothing matches, notFound receives the archive's file count and the
+// names (without extension) of every file it may return.
+func extractBestMatchFromZip(
+	zipContent []byte,
+	limits Limits,
+	logger zerolog.Logger,
+	match func(filename, fullPath string) bool,
+	notFound func(fileCount int, available []string) error,
+) (*EpisodeFile, error) {
+	limits = limits.withDefaults()
+	if err := DetectZipBomb(zipContent, limits); err != nil {
+		logger.Warn().Err(err).Msg("ZIP bomb detected and blocked")
+		return nil, err
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+	if err != nil {
+		return nil, NewUnrecoverableError("failed to open ZIP archive", &ErrMalformedArchive{Format: "ZIP", Err: err})
+	}
 
 	logger.Debug().
 		Int("fileCount", len(zipReader.File)).
-		Int("episode", episode).
-		Msg("Searching for episode in archive")
+		Msg("Scanning archive entries")
 
 	type matchedFile struct {
 		file     *zip.File
 		filename string
 		fullPath string
 		priority int // Lower is better: .srt=0, .ass=1, .vtt=2, .sub=3, other=4
+		// hearingImpaired files lose ties on priority, as most viewers do not want sound descriptions
+		hearingImpaired bool
+		// quality is the resolution named in the filename; higher wins the remaining ties
+		quality models.Quality
 	}
 	var matches []matchedFile
-
-	subtitleExtensions := map[string]int{
-		".srt": 0,
-		".ass": 1,
-		".vtt": 2,
-		".sub": 3,
-	}
+	var available []string
 
 	for _, file := range zipReader.File {
 		if file.FileInfo().IsDir() {
@@ -132,23 +297,28 @@ func ExtractEpisodeFromZip(zipContent []byte, episode int, logger zerolog.Logger
 		}
 
 		filename := strings.ToValidUTF8(filepath.Base(file.Name), "�")
-		fullPath := strings.ToValidUTF8(file.Name, "�")
+		fullPath := entryPath(file)
+		if limits.isDenied(filename) {
+			logger.Debug().
+				Str("filename", filename).
+				Msg("Skipping file with a denied extension")
+			continue
+		}
+		available = append(available, strings.TrimSuffix(filename, filepath.Ext(filename)))
 
-		matchesFilename := episodePattern.MatchString(filename)
-		matchesPath := episodePattern.MatchString(fullPath)
-		matchesEpisode := matchesFilename || matchesPath
+		matched := match(filename, fullPath)
 
 		logger.Debug().
 			Str("filename", filename).
 			Str("fullPath", fullPath).
-			Bool("matches", matchesEpisode).
+			Bool("matches", matched).
 			Msg("Checking file in archive")
 
-		if matchesEpisode {
+		if matched {
 			ext := strings.ToLower(filepath.Ext(filename))
-			priority, isSubtitle := subtitleExtensions[ext]
+			priority, isSubtitle := subtitleExtensionPriority[ext]
 			if !isSubtitle {
-				priority = 4
+				priority = unknownExtensionPriority
 				logger.Debug().
 					Str("filename", filename).
 					Str("extension", ext).
@@ -156,22 +326,31 @@ func ExtractEpisodeFromZip(zipContent []byte, episode int, logger zerolog.Logger
 			}
 
 			matches = append(matches, matchedFile{
-				file:     file,
-				filename: filename,
-				fullPath: fullPath,
-				priority: priority,
+				file:            file,
+				filename:        filename,
+				fullPath:        fullPath,
+				priority:        priority,
+				hearingImpaired: models.IsHearingImpaired(filename),
+				quality:         models.DetectQuality(filename),
 			})
 		}
 	}
 
 	if len(matches) == 0 {
-		return nil, &ErrEpisodeNotFound{Episode: episode, FileCount: len(zipReader.File)}
+		sort.Strings(available)
+		return nil, notFound(len(zipReader.File), available)
 	}
 
 	sort.Slice(matches, func(i, j int) bool {
 		if matches[i].priority != matches[j].priority {
 			return matches[i].priority < matches[j].priority
 		}
+		if matches[i].hearingImpaired != matches[j].hearingImpaired {
+			return !matches[i].hearingImpaired
+		}
+		if matches[i].quality != matches[j].quality {
+			return matches[i].quality > matches[j].quality
+		}
 		return matches[i].filename < matches[j].filename
 	})
 
@@ -180,6 +359,8 @@ func ExtractEpisodeFromZip(zipContent []byte, episode int, logger zerolog.Logger
 	logger.Info().
 		Str("filename", bestMatch.filename).
 		Int("priority", bestMatch.priority).
+		Bool("hearingImpaired", bestMatch.hearingImpaired).
+		Stringer("quality", bestMatch.quality).
 		Int("totalMatches", len(matches)).
 		Msg("Selected best matching subtitle from archive")
 
@@ -195,7 +376,9 @@ func ExtractEpisodeFromZip(zipContent []byte, episode int, logger zerolog.Logger
 	}
 
 	return &EpisodeFile{
-		Filename: bestMatch.filename,
+		Filename: path.Base(bestMatch.fullPath),
+		Path:     bestMatch.fullPath,
 		Content:  content,
+		Charset:  entryCharset(bestMatch.file),
 	}, nil
 }
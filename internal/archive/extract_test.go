@@ -105,13 +105,15 @@ func TestDetectZipBomb(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			zipContent := createTestZip(t, tt.files)
-			err := DetectZipBomb(zipContent)
+			err := DetectZipBomb(zipContent, DefaultLimits())
 
 			if tt.shouldError {
 				if err == nil {
 					t.Errorf("Expected error containing '%s', got nil", tt.errorMsg)
 				} else if !strings.Contains(err.Error(), tt.errorMsg) {
 					t.Errorf("Expected error containing '%s', got: %v", tt.errorMsg, err)
+				} else if !errors.Is(err, &ErrDecompressionBomb{}) {
+					t.Errorf("Expected ErrDecompressionBomb cause, got: %v", err)
 				}
 			} else {
 				if err != nil {
@@ -130,7 +132,7 @@ func TestDetectZipBomb_CompressionRatio(t *testing.T) {
 	}
 
 	zipContent := createTestZip(t, normalFiles)
-	err := DetectZipBomb(zipContent)
+	err := DetectZipBomb(zipContent, DefaultLimits())
 
 	if err != nil {
 		t.Errorf("Normal files should not trigger ZIP bomb detection, got: %v", err)
@@ -140,13 +142,38 @@ func TestDetectZipBomb_CompressionRatio(t *testing.T) {
 func TestDetectZipBomb_InvalidZip(t *testing.T) {
 	t.Parallel()
 
-	err := DetectZipBomb([]byte("not a zip"))
+	err := DetectZipBomb([]byte("not a zip"), DefaultLimits())
 	if err == nil {
 		t.Fatal("expected error for invalid zip content")
 	}
 	if !strings.Contains(err.Error(), "failed to open ZIP") {
 		t.Errorf("expected invalid zip error, got: %v", err)
 	}
+	var malformedErr *ErrMalformedArchive
+	if !errors.As(err, &malformedErr) || malformedErr.Format != "ZIP" {
+		t.Errorf("expected ErrMalformedArchive for ZIP, got: %v", err)
+	}
+}
+
+func TestDetectZipBombReaderAt_ConfiguredLimits(t *testing.T) {
+	t.Parallel()
+
+	zipContent := createTestZip(t, map[string]string{
+		"show.s01e01.srt": strings.Repeat("A", 2048),
+	})
+	limits := DefaultLimits()
+	limits.MaxFileSize = 1024
+
+	err := DetectZipBombReaderAt(bytes.NewReader(zipContent), int64(len(zipContent)), limits)
+	if err == nil {
+		t.Fatal("expected per-file limit error")
+	}
+	if !strings.Contains(err.Error(), "exceeds maximum uncompressed size") {
+		t.Errorf("expected per-file size error, got: %v", err)
+	}
+	if !errors.Is(err, &ErrDecompressionBomb{}) {
+		t.Errorf("expected ErrDecompressionBomb cause, got: %v", err)
+	}
 }
 
 func TestDetectZipBomb_TotalUncompressedSize(t *testing.T) {
@@ -157,13 +184,80 @@ func TestDetectZipBomb_TotalUncompressedSize(t *testing.T) {
 		files["show.s01e"+strconv.Itoa(i+1)+".ass"] = strings.Repeat("A", 18*1024*1024)
 	}
 
-	err := DetectZipBomb(createTestZip(t, files))
+	err := DetectZipBomb(createTestZip(t, files), DefaultLimits())
 	if err == nil {
 		t.Fatal("expected total uncompressed size error")
 	}
 	if !strings.Contains(err.Error(), "total uncompressed size exceeds limit") {
 		t.Errorf("expected total size error, got: %v", err)
 	}
+	if !errors.Is(err, &ErrDecompressionBomb{}) {
+		t.Errorf("expected ErrDecompressionBomb cause, got: %v", err)
+	}
+}
+
+func TestDetectZipBomb_ConfiguredLimits(t *testing.T) {
+	t.Parallel()
+
+	// 25 MB exceeds the 20 MB default per-file limit
+	zipContent := createTestZip(t, map[string]string{
+		"show.s01e01.srt": strings.Repeat("A", 25*1024*1024),
+	})
+
+	if err := DetectZipBomb(zipContent, DefaultLimits()); err == nil {
+		t.Fatal("expected default limits to reject a 25 MB entry")
+	}
+
+	limits := DefaultLimits()
+	limits.MaxFileSize = 30 * 1024 * 1024
+	if err := DetectZipBomb(zipContent, limits); err != nil {
+		t.Errorf("expected a 30 MB per-file limit to accept a 25 MB entry, got: %v", err)
+	}
+	if _, err := SanitizeZip(zipContent, limits); err != nil {
+		t.Errorf("expected sanitization with a 30 MB per-file limit to succeed, got: %v", err)
+	}
+
+	limits.MaxTotalSize = 10 * 1024 * 1024
+	if err := DetectZipBomb(zipContent, limits); err == nil {
+		t.Error("expected a 10 MB total limit to reject a 25 MB entry")
+	}
+}
+
+func TestEpisodePattern(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		episode int
+		name    string
+		want    bool
+	}{
+		{5, "Show.S01E05.srt", true},
+		{5, "Show.S01E5.srt", true},
+		{5, "Show.S01E005.srt", true},
+		{5, "Show.E5.srt", true},
+		{5, "show - e05 - title.srt", true},
+		{5, "Show.1x5.srt", true},
+		{5, "Show.1x005.srt", true},
+		{5, "Show.S01E50.srt", false},
+		{5, "Show.S01E0050.srt", false},
+		{5, "Rogue5.srt", false},
+		{1, "show.s03e10.srt", false},
+		{1, "show.s03e100.srt", false},
+		{1, "Show.S01E01.srt", true},
+		{100, "One.Piece.E100.srt", true},
+		{100, "One.Piece.S01E100.1080p.srt", true},
+		{100, "One.Piece.E1000.srt", false},
+		{101, "One.Piece.E100.srt", false},
+		{0, "Show.S00E00.Special.srt", true},
+		{0, "Show.E00.srt", true},
+		{0, "Show.S00E01.srt", false},
+		{1, "Show.S00E01.srt", true},
+	}
+
+	for _, tt := range tests {
+		if got := EpisodePattern(tt.episode).MatchString(tt.name); got != tt.want {
+			t.Errorf("EpisodePattern(%d).MatchString(%q) = %v, want %v", tt.episode, tt.name, got, tt.want)
+		}
+	}
 }
 
 func TestExtractEpisodeFromZip_Basic(t *testing.T) {
@@ -173,7 +267,7 @@ func TestExtractEpisodeFromZip_Basic(t *testing.T) {
 		"Show.S03E02.srt": "Episode 2 content",
 	})
 
-	result, err := ExtractEpisodeFromZip(zipContent, 1, testLogger())
+	result, err := ExtractEpisodeFromZip(zipContent, 1, DefaultLimits(), testLogger())
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -193,7 +287,7 @@ func TestExtractEpisodeFromZip_NotFound(t *testing.T) {
 		"Show.S03E01.srt": "Episode 1 content",
 	})
 
-	_, err := ExtractEpisodeFromZip(zipContent, 5, testLogger())
+	_, err := ExtractEpisodeFromZip(zipContent, 5, DefaultLimits(), testLogger())
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -218,7 +312,7 @@ func TestExtractEpisodeFromZip_PrefersSrt(t *testing.T) {
 		"show.s03e01.vtt": "VTT content",
 	})
 
-	result, err := ExtractEpisodeFromZip(zipContent, 1, testLogger())
+	result, err := ExtractEpisodeFromZip(zipContent, 1, DefaultLimits(), testLogger())
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -232,6 +326,168 @@ func TestExtractEpisodeFromZip_PrefersSrt(t *testing.T) {
 	}
 }
 
+func TestExtractEpisodeFromZip_PrefersNonHearingImpaired(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en[cc].srt": "CC content",
+		"Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.ass":     "ASS content",
+		"Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.srt":     "SRT content",
+	})
+
+	result, err := ExtractEpisodeFromZip(zipContent, 1, DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != "SRT content" {
+		t.Errorf("Expected the non-HI .srt to win the tie, got %q from %s", result.Content, result.Filename)
+	}
+
+	// Format priority still comes first: an HI .srt beats a non-HI .ass
+	zipContent = createTestZip(t, map[string]string{
+		"Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en[cc].srt": "CC content",
+		"Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.ass":     "ASS content",
+	})
+
+	result, err = ExtractEpisodeFromZip(zipContent, 1, DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != "CC content" {
+		t.Errorf("Expected the HI .srt to win on format, got %q from %s", result.Content, result.Filename)
+	}
+}
+
+func TestExtractEpisodeFromZip_PrefersHigherResolution(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "1080p over 720p",
+			files: map[string]string{
+				"Show.S02E03.1080p.WEB.srt": "1080p content",
+				"Show.S02E03.720p.WEB.srt":  "720p content",
+			},
+			want: "1080p content",
+		},
+		{
+			name: "any resolution over none",
+			files: map[string]string{
+				"Show.S02E03.WEB.srt":      "plain content",
+				"Show.S02E03.480p.WEB.srt": "480p content",
+			},
+			want: "480p content",
+		},
+		{
+			name: "format still comes first",
+			files: map[string]string{
+				"Show.S02E03.2160p.WEB.ass": "2160p ass content",
+				"Show.S02E03.720p.WEB.srt":  "720p content",
+			},
+			want: "720p content",
+		},
+		{
+			name: "same resolution falls back to name",
+			files: map[string]string{
+				"Show.S02E03.1080p.WEB-B.srt": "B content",
+				"Show.S02E03.1080p.WEB-A.srt": "A content",
+			},
+			want: "A content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := ExtractEpisodeFromZip(createTestZip(t, tt.files), 3, DefaultLimits(), testLogger())
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if string(result.Content) != tt.want {
+				t.Errorf("Expected %q, got %q from %s", tt.want, result.Content, result.Filename)
+			}
+		})
+	}
+}
+
+func TestZeroLimitsUseDefaults(t *testing.T) {
+	t.Parallel()
+
+	// A zero size would otherwise reject every entry
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E04.exe": "MZ",
+		"Show.S01E04.srt": "SRT content",
+	})
+	result, err := ExtractEpisodeFromZip(zipContent, 4, Limits{}, testLogger())
+	if err != nil {
+		t.Fatalf("Expected the zero limits to accept a small archive, got: %v", err)
+	}
+	if result.Filename != "Show.S01E04.srt" {
+		t.Errorf("Expected the default denylist to skip the .exe, got %s", result.Filename)
+	}
+	if _, err := SanitizeZip(zipContent, Limits{}); err != nil {
+		t.Errorf("Expected sanitization with the zero limits to succeed, got: %v", err)
+	}
+
+	// 25 MB exceeds the 20 MB default per-file limit
+	bomb := createTestZip(t, map[string]string{
+		"show.s01e01.srt": strings.Repeat("A", 25*1024*1024),
+	})
+	if err := DetectZipBomb(bomb, Limits{}); !errors.Is(err, &ErrDecompressionBomb{}) {
+		t.Errorf("Expected the zero limits to apply the default per-file limit, got: %v", err)
+	}
+
+	// Only the zero fields take their defaults
+	if err := DetectZipBomb(bomb, Limits{MaxFileSize: 30 * 1024 * 1024}); err != nil {
+		t.Errorf("Expected a 30 MB per-file limit to accept a 25 MB entry, got: %v", err)
+	}
+}
+
+func TestExtractEpisodeFromZip_SkipsDeniedExtensions(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E04.exe":        "MZ",
+		"Show.S01E04.readme.HTM": "<html></html>",
+		"Show.S01E04.srt":        "SRT content",
+	})
+
+	result, err := ExtractEpisodeFromZip(zipContent, 4, DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != "SRT content" {
+		t.Errorf("Expected the .srt, got %q from %s", result.Content, result.Filename)
+	}
+
+	// A denied file is skipped even when it is the only match
+	zipContent = createTestZip(t, map[string]string{
+		"Show.S01E04.exe": "MZ",
+		"Show.S01E05.srt": "SRT content",
+	})
+	_, err = ExtractEpisodeFromZip(zipContent, 4, DefaultLimits(), testLogger())
+	var notFound *ErrEpisodeNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected ErrEpisodeNotFound, got: %v", err)
+	}
+
+	// Configured entries may omit the dot and replace the default list
+	limits := DefaultLimits()
+	limits.DeniedExtensions = []string{"txt"}
+	zipContent = createTestZip(t, map[string]string{
+		"Show.S01E04.txt": "text content",
+		"Show.S01E04.nfo": "nfo content",
+	})
+	result, err = ExtractEpisodeFromZip(zipContent, 4, limits, testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Filename != "Show.S01E04.nfo" {
+		t.Errorf("Expected the .nfo once .txt is denied, got %s", result.Filename)
+	}
+}
+
 func TestExtractEpisodeFromZip_MatchesPathAndPrefersSubtitleType(t *testing.T) {
 	t.Parallel()
 
@@ -240,7 +496,7 @@ func TestExtractEpisodeFromZip_MatchesPathAndPrefersSubtitleType(t *testing.T) {
 		"Show/1x07/subtitle.ass": "ass content",
 	})
 
-	result, err := ExtractEpisodeFromZip(zipContent, 7, testLogger())
+	result, err := ExtractEpisodeFromZip(zipContent, 7, DefaultLimits(), testLogger())
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -253,10 +509,34 @@ func TestExtractEpisodeFromZip_MatchesPathAndPrefersSubtitleType(t *testing.T) {
 	}
 }
 
+func TestExtractEpisodeFromZip_SanitizedKeepsFolderPath(t *testing.T) {
+	t.Parallel()
+
+	// Sanitizing flattens both files to English.srt and English_2.srt; the episode is only in the folder names
+	sanitized, err := SanitizeZip(createTestZip(t, map[string]string{
+		"Dark.S01E02/English.srt": "episode 2",
+		"Dark.S01E03/English.srt": "episode 3",
+	}), DefaultLimits())
+	if err != nil {
+		t.Fatalf("SanitizeZip failed: %v", err)
+	}
+
+	result, err := ExtractEpisodeFromZip(sanitized, 3, DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != "episode 3" {
+		t.Errorf("Expected episode 3, got %q", result.Content)
+	}
+	if result.Filename != "English.srt" || result.Path != "Dark.S01E03/English.srt" {
+		t.Errorf("Expected the original name and path, got %q and %q", result.Filename, result.Path)
+	}
+}
+
 func TestExtractEpisodeFromZip_InvalidZip(t *testing.T) {
 	t.Parallel()
 
-	_, err := ExtractEpisodeFromZip([]byte("not a zip"), 1, testLogger())
+	_, err := ExtractEpisodeFromZip([]byte("not a zip"), 1, DefaultLimits(), testLogger())
 	if err == nil {
 		t.Fatal("expected invalid zip error")
 	}
@@ -264,3 +544,154 @@ func TestExtractEpisodeFromZip_InvalidZip(t *testing.T) {
 		t.Errorf("expected bomb detection open error, got: %v", err)
 	}
 }
+
+func TestExtractEpisodeByTitleFromZip_Basic(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S03E01.Pilot.srt":     "Episode 1 content",
+		"Show.S03E02.I.Said.No.srt": "Episode 2 content",
+	})
+
+	result, err := ExtractEpisodeByTitleFromZip(zipContent, "i said no", DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.Filename != "Show.S03E02.I.Said.No.srt" {
+		t.Errorf("Expected filename 'Show.S03E02.I.Said.No.srt', got '%s'", result.Filename)
+	}
+	if string(result.Content) != "Episode 2 content" {
+		t.Errorf("Expected 'Episode 2 content', got '%s'", string(result.Content))
+	}
+}
+
+func TestExtractEpisodeByTitleFromZip_IgnoresPunctuationAndPrefersSrt(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show - 3x02 - I Said No!.ass": "ASS content",
+		"Show_3x02_I-Said-No.srt":      "SRT content",
+	})
+
+	result, err := ExtractEpisodeByTitleFromZip(zipContent, "I Said, No", DefaultLimits(), testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.Filename != "Show_3x02_I-Said-No.srt" {
+		t.Errorf("Expected .srt file, got '%s'", result.Filename)
+	}
+}
+
+func TestExtractEpisodeByTitleFromZip_NotFoundListsTitles(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S03E01.Pilot.srt":     "Episode 1 content",
+		"Show.S03E02.I.Said.No.srt": "Episode 2 content",
+	})
+
+	_, err := ExtractEpisodeByTitleFromZip(zipContent, "finale", DefaultLimits(), testLogger())
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	var episodeErr *ErrEpisodeNotFound
+	if !errors.As(err, &episodeErr) {
+		t.Fatalf("Expected ErrEpisodeNotFound, got: %v", err)
+	}
+	if episodeErr.Title != "finale" {
+		t.Errorf("Expected title 'finale', got %q", episodeErr.Title)
+	}
+	want := []string{"Show.S03E01.Pilot", "Show.S03E02.I.Said.No"}
+	if strings.Join(episodeErr.Available, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected available titles %v, got %v", want, episodeErr.Available)
+	}
+	if !strings.Contains(err.Error(), "Show.S03E02.I.Said.No") {
+		t.Errorf("Expected error message to list available titles, got: %v", err)
+	}
+}
+
+func TestExtractEpisodeByTitleFromZip_EmptyTitle(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S03E01.srt": "Episode 1 content",
+	})
+
+	_, err := ExtractEpisodeByTitleFromZip(zipContent, " .-", DefaultLimits(), testLogger())
+	if err == nil {
+		t.Fatal("Expected error for title that normalizes to nothing")
+	}
+	if errors.Is(err, &ErrEpisodeNotFound{}) {
+		t.Errorf("Expected a validation error rather than ErrEpisodeNotFound, got: %v", err)
+	}
+}
+
+func TestNormalizeEpisodeTitle(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"Show.S03E02.I.Said.No.srt": "show s03e02 i said no srt",
+		"I-Said_No!":                "i said no",
+		"  Árvíztűrő  Tükör ":       "árvíztűrő tükör",
+		"...":                       "",
+	}
+
+	for input, want := range tests {
+		if got := NormalizeEpisodeTitle(input); got != want {
+			t.Errorf("NormalizeEpisodeTitle(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestExtractSingleSubtitleFromZip(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		files        map[string]string
+		wantFilename string
+	}{
+		{
+			name:         "single subtitle in a folder",
+			files:        map[string]string{"Season 1/Show.S01E01.srt": "content", "info.nfo": "ignored"},
+			wantFilename: "Show.S01E01.srt",
+		},
+		{
+			name:  "several subtitles",
+			files: map[string]string{"Show.S01E01.srt": "one", "Show.S01E02.srt": "two"},
+		},
+		{
+			name:  "no subtitles",
+			files: map[string]string{"readme.txt": "nothing here"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file, err := ExtractSingleSubtitleFromZip(createTestZip(t, tt.files), DefaultLimits())
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if tt.wantFilename == "" {
+				if file != nil {
+					t.Errorf("Expected no single subtitle, got %q", file.Filename)
+				}
+				return
+			}
+			if file == nil {
+				t.Fatal("Expected the single subtitle, got nil")
+			}
+			if file.Filename != tt.wantFilename {
+				t.Errorf("Expected filename %q, got %q", tt.wantFilename, file.Filename)
+			}
+			if string(file.Content) != "content" {
+				t.Errorf("Expected content %q, got %q", "content", string(file.Content))
+			}
+		})
+	}
+}
+
+func TestExtractSingleSubtitleFromZip_InvalidZip(t *testing.T) {
+	t.Parallel()
+	if _, err := ExtractSingleSubtitleFromZip([]byte("not a zip"), DefaultLimits()); err == nil {
+		t.Fatal("Expected error for invalid ZIP, got nil")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"mime"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -14,6 +15,13 @@ const (
 	FormatRAR     = "rar"
 )
 
+// SignatureSize is the number of leading bytes DetectFormat needs to recognize
+// the ZIP and RAR signatures.
+const SignatureSize = 8
+
+// srtCuePattern matches the first cue of a SubRip file: a cue number line followed by a timing line.
+var srtCuePattern = regexp.MustCompile(`^\d+[ \t]*\r?\n[ \t]*\d{1,2}:\d{2}:\d{2}[,.]\d{1,3}[ \t]*-->`)
+
 // IsZipFile checks if the content is a ZIP file using magic number detection.
 // ZIP files start with PK\x03\x04 (0x504B0304) or PK\x05\x06 (empty archive) or PK\x07\x08 (spanned archive).
 func IsZipFile(content []byte) bool {
@@ -139,3 +147,41 @@ func ContentTypeForFilename(filename string) string {
 		return "application/octet-stream"
 	}
 }
+
+// IsGenericContentType reports whether a MIME type says nothing about the subtitle format,
+// as with application/octet-stream or a missing type, so the content should be sniffed.
+func IsGenericContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	switch mediaType {
+	case "", "application/octet-stream", "binary/octet-stream", "application/force-download",
+		"application/download", "application/x-download", "text/plain":
+		return true
+	default:
+		return false
+	}
+}
+
+// SniffSubtitleContentType returns the MIME type of a text subtitle recognized from its first
+// bytes: a WEBVTT header, an ASS/SSA [Script Info] section or a SubRip cue. A UTF-8 byte order
+// mark and leading blank lines are skipped. It returns "" when nothing matches.
+func SniffSubtitleContentType(content []byte) string {
+	head := content[:min(len(content), 512)]
+	head = bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF"))
+	head = bytes.TrimLeft(head, " \t\r\n")
+
+	switch {
+	case bytes.HasPrefix(head, []byte("WEBVTT")) &&
+		(len(head) == 6 || strings.IndexByte(" \t\r\n", head[6]) >= 0):
+		return "text/vtt"
+	case len(head) >= 13 && bytes.EqualFold(head[:13], []byte("[Script Info]")):
+		return "application/x-ass"
+	case srtCuePattern.Match(head):
+		return "application/x-subrip"
+	default:
+		return ""
+	}
+}
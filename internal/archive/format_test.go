@@ -256,3 +256,56 @@ func TestContentTypeForFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestSniffSubtitleContentType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "webvtt", content: "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n", want: "text/vtt"},
+		{name: "webvtt with title", content: "WEBVTT - Episode 1\n", want: "text/vtt"},
+		{name: "webvtt only", content: "WEBVTT", want: "text/vtt"},
+		{name: "webvtt prefix of a word", content: "WEBVTTX\n", want: ""},
+		{name: "ass", content: "[Script Info]\nScriptType: v4.00+\n", want: "application/x-ass"},
+		{name: "ass lowercase", content: "[script info]\n", want: "application/x-ass"},
+		{name: "srt", content: "1\n00:00:01,000 --> 00:00:02,000\nHello\n", want: "application/x-subrip"},
+		{name: "srt crlf with bom", content: "\xEF\xBB\xBF1\r\n00:00:01,000 --> 00:00:02,000\r\n", want: "application/x-subrip"},
+		{name: "srt after blank lines", content: "\r\n\r\n12\n0:00:01.5 --> 0:00:02.0\n", want: "application/x-subrip"},
+		{name: "plain text", content: "Test content", want: ""},
+		{name: "binary", content: "\x00\x01\x02\x03\xff\xfe", want: ""},
+		{name: "empty", content: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := SniffSubtitleContentType([]byte(tt.content)); got != tt.want {
+				t.Errorf("SniffSubtitleContentType(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsGenericContentType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"application/octet-stream", true},
+		{"", true},
+		{"text/plain; charset=utf-8", true},
+		{"application/force-download", true},
+		{"application/x-subrip", false},
+		{"text/vtt", false},
+		{"application/zip", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGenericContentType(tt.contentType); got != tt.want {
+			t.Errorf("IsGenericContentType(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"path"
+	"strings"
+)
+
+// Default size limit constants for archive operations.
+const (
+	// Maximum compression ratio (uncompressed/compressed).
+	// Highly repetitive content can legitimately compress to 1000:1 or more.
+	// Real subtitle files rarely exceed 20:1, but we set a generous limit to avoid false positives.
+	MaxCompressionRatio = 10000
+	// Maximum uncompressed size for a single file (20 MB).
+	MaxUncompressedFileSize = 20 * 1024 * 1024
+	// Maximum uncompressed size for a single ASS file (100 MB).
+	// ASS files can legitimately be large because they often embed font data as base64.
+	MaxUncompressedAssFileSize = 100 * 1024 * 1024
+	// Maximum total uncompressed size for all files in an archive (100 MB).
+	MaxTotalUncompressedSize = 100 * 1024 * 1024
+)
+
+// DefaultDeniedExtensions lists the extensions of executables, web pages and release notes that
+// season packs sometimes bundle. They are never returned from an episode search.
+var DefaultDeniedExtensions = []string{".exe", ".com", ".bat", ".cmd", ".scr", ".lnk", ".url", ".html", ".htm", ".js", ".vbs", ".nfo"}
+
+// Limits holds the uncompressed size limits enforced while reading archives, and the extensions
+// an episode search must never return. Zero sizes and a nil DeniedExtensions take their values
+// from DefaultLimits, so the zero Limits is the default one.
+type Limits struct {
+	MaxFileSize      int64    // Maximum uncompressed size of a single entry
+	MaxAssFileSize   int64    // Maximum uncompressed size of a single .ass entry
+	MaxTotalSize     int64    // Maximum uncompressed size of all entries combined
+	DeniedExtensions []string // Extensions skipped by episode searches, compared case-insensitively
+	DefaultCharset   string   // Encoding assumed for text entries that are not valid UTF-8, such as "iso-8859-2" (empty detects it)
+}
+
+// DefaultLimits returns the limits built from the package's default size constants and
+// DefaultDeniedExtensions.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxFileSize:      MaxUncompressedFileSize,
+		MaxAssFileSize:   MaxUncompressedAssFileSize,
+		MaxTotalSize:     MaxTotalUncompressedSize,
+		DeniedExtensions: DefaultDeniedExtensions,
+	}
+}
+
+// withDefaults returns l with its zero sizes and nil DeniedExtensions set from DefaultLimits.
+// Without it a zero size would reject every entry.
+func (l Limits) withDefaults() Limits {
+	defaults := DefaultLimits()
+	if l.MaxFileSize == 0 {
+		l.MaxFileSize = defaults.MaxFileSize
+	}
+	if l.MaxAssFileSize == 0 {
+		l.MaxAssFileSize = defaults.MaxAssFileSize
+	}
+	if l.MaxTotalSize == 0 {
+		l.MaxTotalSize = defaults.MaxTotalSize
+	}
+	if l.DeniedExtensions == nil {
+		l.DeniedExtensions = defaults.DeniedExtensions
+	}
+	return l
+}
+
+// isDenied reports whether filename has one of the denied extensions. Entries may omit the
+// leading dot.
+func (l Limits) isDenied(filename string) bool {
+	ext := strings.ToLower(path.Ext(filename))
+	if ext == "" {
+		return false
+	}
+	for _, denied := range l.DeniedExtensions {
+		if strings.EqualFold(ext, "."+strings.TrimPrefix(denied, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxFileSizeForExtension returns the maximum allowed uncompressed size for a file
+// based on its extension. ASS subtitle files can legitimately contain embedded fonts
+// and may exceed the standard limit, so they receive a higher allowance.
+func (l Limits) maxFileSizeForExtension(filename string) int64 {
+	if strings.ToLower(path.Ext(filename)) == ".ass" {
+		return l.MaxAssFileSize
+	}
+	return l.MaxFileSize
+}
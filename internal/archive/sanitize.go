@@ -3,8 +3,10 @@ package archive
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
 	"strings"
 	"unicode/utf8"
@@ -29,21 +31,46 @@ func isSubtitleFile(name string) bool {
 
 // SanitizeZip removes non-subtitle files from a ZIP archive and flattens the directory structure.
 // Only files with recognized subtitle extensions (.srt, .ass, .vtt, .sub) are kept.
-// All retained files are placed at the root level of the resulting archive.
+// All retained files are placed at the root level of the resulting archive, each with its
+// original path as its entry comment when that differs from its new name.
 // Duplicate filenames after flattening are disambiguated with a numeric suffix.
-// It performs ZIP bomb detection before processing.
-func SanitizeZip(zipContent []byte) ([]byte, error) {
-	if err := DetectZipBomb(zipContent); err != nil {
+// Entries are converted to UTF-8, each recording the encoding it was read as, which
+// extraction reports as EpisodeFile.Charset.
+// It performs ZIP bomb detection before processing and enforces the size limits in limits.
+func SanitizeZip(zipContent []byte, limits Limits) ([]byte, error) {
+	outBuf := new(bytes.Buffer)
+	if err := SanitizeZipTo(outBuf, bytes.NewReader(zipContent), int64(len(zipContent)), limits); err != nil {
 		return nil, err
 	}
+	return outBuf.Bytes(), nil
+}
+
+// SanitizeZipTo is SanitizeZip for a ZIP of the given size read through r, writing the
+// sanitized archive to w. Neither archive has to be held in memory; only one entry at
+// a time is.
+func SanitizeZipTo(w io.Writer, r io.ReaderAt, size int64, limits Limits) error {
+	return sanitizeZipTo(w, r, size, limits, true)
+}
+
+// SanitizeZipKeepEncodingTo is SanitizeZipTo without the conversion to UTF-8: entries keep
+// the bytes they were uploaded with.
+func SanitizeZipKeepEncodingTo(w io.Writer, r io.ReaderAt, size int64, limits Limits) error {
+	return sanitizeZipTo(w, r, size, limits, false)
+}
+
+// sanitizeZipTo implements SanitizeZipTo, converting entries to UTF-8 when toUTF8 is set.
+func sanitizeZipTo(w io.Writer, r io.ReaderAt, size int64, limits Limits, toUTF8 bool) error {
+	limits = limits.withDefaults()
+	if err := DetectZipBombReaderAt(r, size, limits); err != nil {
+		return err
+	}
 
-	zipReader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+	zipReader, err := zip.NewReader(r, size)
 	if err != nil {
-		return nil, NewUnrecoverableError("failed to open ZIP archive for sanitization", err)
+		return NewUnrecoverableError("failed to open ZIP archive for sanitization", &ErrMalformedArchive{Format: "ZIP", Err: err})
 	}
 
-	outBuf := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(outBuf)
+	zipWriter := zip.NewWriter(w)
 
 	usedNames := make(map[string]int)
 	var totalRead int64
@@ -66,50 +93,58 @@ func SanitizeZip(zipContent []byte) ([]byte, error) {
 
 		rc, err := file.Open()
 		if err != nil {
-			return nil, NewUnrecoverableError(fmt.Sprintf("failed to open ZIP entry %s", file.Name), err)
-		}
-
-		writer, err := zipWriter.Create(flatName)
-		if err != nil {
-			rc.Close()
-			return nil, NewError(fmt.Sprintf("failed to create ZIP entry %s", flatName), err)
+			return NewUnrecoverableError(fmt.Sprintf("failed to open ZIP entry %s", file.Name), err)
 		}
 
 		// Enforce per-file size limit during decompression to guard against
 		// spoofed ZIP headers that pass DetectZipBomb but expand beyond limits.
-		fileLimit := maxFileSizeForExtension(flatName)
+		fileLimit := limits.maxFileSizeForExtension(flatName)
 		limitedReader := io.LimitReader(rc, fileLimit+1)
 		content, err := io.ReadAll(limitedReader)
 		rc.Close()
 		if err != nil {
-			return nil, NewUnrecoverableError(fmt.Sprintf("failed to read ZIP entry %s", flatName), err)
+			return NewUnrecoverableError(fmt.Sprintf("failed to read ZIP entry %s", flatName), err)
 		}
 		if int64(len(content)) > fileLimit {
-			return nil, NewUnrecoverableError(
+			return NewUnrecoverableError(
 				"ZIP entry exceeds maximum uncompressed size",
-				fmt.Errorf("entry %s is %d bytes > %d bytes limit", flatName, len(content), fileLimit),
+				&ErrDecompressionBomb{Reason: fmt.Sprintf("entry %s exceeds maximum uncompressed size (%d bytes > %d bytes limit)", flatName, len(content), fileLimit)},
 			)
 		}
 		totalRead += int64(len(content))
-		if totalRead > MaxTotalUncompressedSize {
-			return nil, NewUnrecoverableError(
+		if totalRead > limits.MaxTotalSize {
+			return NewUnrecoverableError(
 				"ZIP archive total uncompressed size exceeds limit",
-				fmt.Errorf("%d bytes > %d bytes limit", totalRead, MaxTotalUncompressedSize),
+				&ErrDecompressionBomb{Reason: fmt.Sprintf("total uncompressed size exceeds limit (%d bytes > %d bytes limit)", totalRead, limits.MaxTotalSize)},
 			)
 		}
 
-		content = convertToUTF8(content)
+		// The flattened entry keeps its original path in its comment, so an episode marker found
+		// only on a folder, as in "Show.S01E03/English.srt", can still select it
+		header := &zip.FileHeader{Name: flatName, Method: zip.Deflate}
+		if normalized != flatName {
+			header.Comment = strings.ToValidUTF8(normalized, "�")
+		}
+		if toUTF8 {
+			var charsetName string
+			content, charsetName = convertToUTF8(content, limits.DefaultCharset)
+			header.Extra = charsetExtra(charsetName)
+		}
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return NewError(fmt.Sprintf("failed to create ZIP entry %s", flatName), err)
+		}
 
 		if _, err := writer.Write(content); err != nil {
-			return nil, NewError(fmt.Sprintf("failed to write ZIP entry %s", flatName), err)
+			return NewError(fmt.Sprintf("failed to write ZIP entry %s", flatName), err)
 		}
 	}
 
 	if err := zipWriter.Close(); err != nil {
-		return nil, NewError("failed to finalize sanitized ZIP archive", err)
+		return NewError("failed to finalize sanitized ZIP archive", err)
 	}
 
-	return outBuf.Bytes(), nil
+	return nil
 }
 
 // deduplicate returns a unique filename by appending a numeric suffix when needed.
@@ -127,20 +162,59 @@ func deduplicate(name string, used map[string]int) string {
 	return fmt.Sprintf("%s_%d%s", base, count+1, ext)
 }
 
-// convertToUTF8 detects the character encoding of text content and converts it to UTF-8.
-// It handles BOM detection and uses heuristic charset detection.
-// If the content is already valid UTF-8, it is returned as-is.
-func convertToUTF8(content []byte) []byte {
+// charsetExtraID is the header ID of the ZIP extra field in which a sanitized archive records
+// the encoding an entry was converted from. Other ZIP readers skip extra fields they do not know.
+const charsetExtraID = 0x4353
+
+// charsetExtra returns the extra field recording charsetName, or nil when it is empty.
+func charsetExtra(charsetName string) []byte {
+	if charsetName == "" || len(charsetName) > math.MaxUint16 {
+		return nil
+	}
+	extra := binary.LittleEndian.AppendUint16(nil, charsetExtraID)
+	extra = binary.LittleEndian.AppendUint16(extra, uint16(len(charsetName)))
+	return append(extra, charsetName...)
+}
+
+// entryCharset returns the encoding SanitizeZip converted file from, or "" when it did not
+// record one: entries of an archive sanitized without conversion, or cached before the
+// encoding was recorded.
+func entryCharset(file *zip.File) string {
+	extra := file.Extra
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		if len(extra) < 4+size {
+			return ""
+		}
+		if id == charsetExtraID {
+			return string(extra[4 : 4+size])
+		}
+		extra = extra[4+size:]
+	}
+	return ""
+}
+
+// convertToUTF8 detects the character encoding of text content and converts it to UTF-8,
+// returning the name of the encoding it was read as. It handles BOM detection, then reads
+// the content as defaultCharset when set and uses heuristic charset detection otherwise.
+// If the content is already valid UTF-8, it is returned as-is as "utf-8". If the conversion
+// fails, the original content is returned with an empty name.
+func convertToUTF8(content []byte, defaultCharset string) ([]byte, string) {
 	if len(content) == 0 || utf8.Valid(content) {
-		return content
+		return content, "utf-8"
 	}
 
-	encoding, _, _ := charset.DetermineEncoding(content, "text/plain")
+	contentType := "text/plain"
+	if defaultCharset != "" {
+		contentType += "; charset=" + defaultCharset
+	}
+	encoding, charsetName, _ := charset.DetermineEncoding(content, contentType)
 
 	decoded, _, err := transform.Bytes(encoding.NewDecoder(), content)
 	if err != nil {
-		return content
+		return content, ""
 	}
 
-	return decoded
+	return decoded, charsetName
 }
@@ -3,6 +3,7 @@ package archive
 import (
 	"archive/zip"
 	"bytes"
+	"errors"
 	"io"
 	"sort"
 	"strings"
@@ -48,7 +49,7 @@ func TestSanitizeZip_RemovesNonSubtitleFiles(t *testing.T) {
 		"show.s01e02.ass": "ass subtitle",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -74,6 +75,28 @@ func TestSanitizeZip_RemovesNonSubtitleFiles(t *testing.T) {
 	}
 }
 
+func TestSanitizeZipTo_ReadsFromReaderAt(t *testing.T) {
+	t.Parallel()
+
+	input := createTestZip(t, map[string]string{
+		"extras/sample.mkv":      strings.Repeat("V", 64*1024),
+		"season/show.s01e01.srt": "subtitle content",
+	})
+
+	var out bytes.Buffer
+	if err := SanitizeZipTo(&out, bytes.NewReader(input), int64(len(input)), DefaultLimits()); err != nil {
+		t.Fatalf("SanitizeZipTo returned unexpected error: %v", err)
+	}
+
+	names, contents := zipEntries(t, out.Bytes())
+	if len(names) != 1 || names[0] != "show.s01e01.srt" {
+		t.Fatalf("expected only show.s01e01.srt, got %v", names)
+	}
+	if string(contents["show.s01e01.srt"]) != "subtitle content" {
+		t.Errorf("unexpected content for show.s01e01.srt: %q", contents["show.s01e01.srt"])
+	}
+}
+
 func TestSanitizeZip_FlattensDirectoryStructure(t *testing.T) {
 	t.Parallel()
 
@@ -83,7 +106,7 @@ func TestSanitizeZip_FlattensDirectoryStructure(t *testing.T) {
 		"Season 1/Deep/Sub/show.s01e03.sub": "ep3 content",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -123,7 +146,7 @@ func TestSanitizeZip_DeduplicatesAfterFlattening(t *testing.T) {
 		"Season 3/subtitle.srt": "third",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -172,7 +195,7 @@ func TestSanitizeZip_AllExtensionsKept(t *testing.T) {
 		"d.sub": "sub",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -200,7 +223,7 @@ func TestSanitizeZip_NoSubtitlesReturnsEmptyZip(t *testing.T) {
 		"video.mp4":   "video",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -235,7 +258,7 @@ func TestSanitizeZip_SkipsDirectoryEntries(t *testing.T) {
 
 	w.Close()
 
-	result, err := SanitizeZip(buf.Bytes())
+	result, err := SanitizeZip(buf.Bytes(), DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -261,7 +284,7 @@ func TestSanitizeZip_CaseInsensitiveExtensions(t *testing.T) {
 		"readme.TXT": "readme",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -283,7 +306,7 @@ func TestSanitizeZip_CaseInsensitiveExtensions(t *testing.T) {
 func TestSanitizeZip_InvalidZip(t *testing.T) {
 	t.Parallel()
 
-	_, err := SanitizeZip([]byte("this is not a zip file"))
+	_, err := SanitizeZip([]byte("this is not a zip file"), DefaultLimits())
 	if err == nil {
 		t.Error("expected error for invalid ZIP, got nil")
 	}
@@ -297,13 +320,16 @@ func TestSanitizeZip_ZipBombDetected(t *testing.T) {
 		"malicious.srt": strings.Repeat("X", 25*1024*1024), // 25 MB > 20 MB limit
 	})
 
-	_, err := SanitizeZip(input)
+	_, err := SanitizeZip(input, DefaultLimits())
 	if err == nil {
-		t.Error("expected ZIP bomb detection error, got nil")
+		t.Fatal("expected ZIP bomb detection error, got nil")
 	}
 	if !strings.Contains(err.Error(), "exceeds maximum uncompressed size") {
 		t.Errorf("expected ZIP bomb error, got: %v", err)
 	}
+	if !errors.Is(err, &ErrDecompressionBomb{}) {
+		t.Errorf("expected ErrDecompressionBomb cause, got: %v", err)
+	}
 }
 
 func TestSanitizeZip_LargeAssFileAllowed(t *testing.T) {
@@ -314,7 +340,7 @@ func TestSanitizeZip_LargeAssFileAllowed(t *testing.T) {
 		"show.s01e01.ass": strings.Repeat("A", 37*1024*1024), // 37 MB — exceeds standard limit but within ASS limit
 	})
 
-	_, err := SanitizeZip(input)
+	_, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Errorf("expected large ASS file to be allowed, got error: %v", err)
 	}
@@ -337,7 +363,7 @@ func TestSanitizeZip_BackslashPathsAndUTF8Conversion(t *testing.T) {
 		t.Fatalf("failed to close zip: %v", err)
 	}
 
-	result, err := SanitizeZip(buf.Bytes())
+	result, err := SanitizeZip(buf.Bytes(), DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -363,7 +389,7 @@ func TestSanitizeZip_PreservesContent(t *testing.T) {
 		"dir/nfo.txt":         "info",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -383,7 +409,7 @@ func TestSanitizeZip_ConvertsContentToUTF8(t *testing.T) {
 		"show.s01e01.srt": iso88591Content,
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -395,6 +421,66 @@ func TestSanitizeZip_ConvertsContentToUTF8(t *testing.T) {
 	}
 }
 
+func TestSanitizeZip_RecordsEntryCharset(t *testing.T) {
+	t.Parallel()
+
+	input := createTestZip(t, map[string]string{
+		"season/show.s01e01.srt": "1\r\n00:00:01,000 --> 00:00:02,000\r\n\xd5szi sz\xe9l\r\n",
+	})
+	limits := DefaultLimits()
+	limits.DefaultCharset = "iso-8859-2"
+
+	converted, err := SanitizeZip(input, limits)
+	if err != nil {
+		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
+	}
+	file, err := ExtractSingleSubtitleFromZip(converted, limits)
+	if err != nil || file == nil {
+		t.Fatalf("Expected the converted entry, got %v, %v", file, err)
+	}
+	if file.Charset != "iso-8859-2" || string(file.Content) != "1\r\n00:00:01,000 --> 00:00:02,000\r\nŐszi szél\r\n" {
+		t.Errorf("Expected the entry converted from iso-8859-2, got %q read as %q", file.Content, file.Charset)
+	}
+	if file.Path != "season/show.s01e01.srt" {
+		t.Errorf("Expected the original path kept next to the charset, got %q", file.Path)
+	}
+
+	var kept bytes.Buffer
+	if err := SanitizeZipKeepEncodingTo(&kept, bytes.NewReader(input), int64(len(input)), limits); err != nil {
+		t.Fatalf("SanitizeZipKeepEncodingTo returned unexpected error: %v", err)
+	}
+	file, err = ExtractSingleSubtitleFromZip(kept.Bytes(), limits)
+	if err != nil || file == nil {
+		t.Fatalf("Expected the unconverted entry, got %v, %v", file, err)
+	}
+	if file.Charset != "" {
+		t.Errorf("Expected no charset for an entry that kept its encoding, got %q", file.Charset)
+	}
+}
+
+func TestSanitizeZipKeepEncodingTo_KeepsOriginalBytes(t *testing.T) {
+	t.Parallel()
+
+	iso88591Content := "1\r\n00:00:01,000 --> 00:00:02,000\r\nCaf\xe9\r\n"
+	input := createTestZip(t, map[string]string{
+		"extras/readme.txt":      "not a subtitle",
+		"season/show.s01e01.srt": iso88591Content,
+	})
+
+	var out bytes.Buffer
+	if err := SanitizeZipKeepEncodingTo(&out, bytes.NewReader(input), int64(len(input)), DefaultLimits()); err != nil {
+		t.Fatalf("SanitizeZipKeepEncodingTo returned unexpected error: %v", err)
+	}
+
+	names, contents := zipEntries(t, out.Bytes())
+	if len(names) != 1 || names[0] != "show.s01e01.srt" {
+		t.Fatalf("expected only show.s01e01.srt, got %v", names)
+	}
+	if string(contents["show.s01e01.srt"]) != iso88591Content {
+		t.Errorf("expected the ISO-8859-1 bytes unchanged, got %q", contents["show.s01e01.srt"])
+	}
+}
+
 func TestSanitizeZip_UTF8ContentPassesThrough(t *testing.T) {
 	t.Parallel()
 
@@ -403,7 +489,7 @@ func TestSanitizeZip_UTF8ContentPassesThrough(t *testing.T) {
 		"show.s01e01.srt": utf8Content,
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -426,7 +512,7 @@ func TestSanitizeZip_RarConvertedThenSanitized(t *testing.T) {
 		"Renegade.S01/cover.jpg":           "cover image",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -465,7 +551,7 @@ func TestSanitizeZip_BackslashPaths(t *testing.T) {
 		"Season 2\\show.s01e02.srt": "ep2",
 	})
 
-	result, err := SanitizeZip(input)
+	result, err := SanitizeZip(input, DefaultLimits())
 	if err != nil {
 		t.Fatalf("SanitizeZip returned unexpected error: %v", err)
 	}
@@ -1,9 +1,48 @@
 package cache
 
-// EvictCallback is called when an entry is evicted from the cache.
-// Support for eviction callbacks is provider-specific. For example, the Redis/Valkey
-// provider performs application-level LRU eviction and can invoke this callback.
-type EvictCallback func(key string, value []byte)
+import (
+	"io"
+	"time"
+)
+
+// EvictCallback is called when an entry is evicted from the cache, with the reason and
+// what is known about the entry. Support for eviction callbacks is provider-specific. For
+// example, the Redis/Valkey provider performs application-level LRU eviction and can invoke
+// this callback, but does not read back the evicted value.
+type EvictCallback func(key string, value []byte, info EvictionInfo)
+
+// EvictReason says why an entry left the cache.
+type EvictReason int
+
+const (
+	// EvictReasonCapacity means the entry was evicted to make room for a newer one.
+	EvictReasonCapacity EvictReason = iota
+	// EvictReasonExpired means the entry's TTL lapsed.
+	EvictReasonExpired
+	// EvictReasonExplicit means the entry was removed by Delete or Clear.
+	EvictReasonExplicit
+)
+
+// String returns the reason as used in logs and the "reason" metric label.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonCapacity:
+		return "capacity"
+	case EvictReasonExpired:
+		return "expired"
+	case EvictReasonExplicit:
+		return "explicit"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictionInfo describes an evicted entry.
+type EvictionInfo struct {
+	Reason EvictReason
+	Age    time.Duration // Time since the entry was stored; zero when the provider does not track it
+	Size   int           // Length of the value in bytes; zero when the provider does not read it back
+}
 
 // Logger is a minimal logging interface for cache providers to report errors.
 // This avoids coupling the cache package to a specific logging framework.
@@ -24,11 +63,45 @@ type Cache interface {
 	// Contains checks whether a key exists in the cache without affecting LRU ordering.
 	Contains(key string) bool
 
+	// Delete removes the entry for key. Deleting a missing key is a no-op.
+	Delete(key string)
+
+	// Clear removes every entry from the cache.
+	Clear()
+
+	// SetTTL changes the time-to-live of entries stored from now on.
+	// It is safe to call concurrently with the other methods.
+	SetTTL(ttl time.Duration)
+
 	// Len returns the number of entries currently in the cache.
-	// For external backends like Redis, this may reflect the total key count in the configured database.
+	// For Redis/Valkey, only entries under the provider's key prefix are counted.
 	Len() int
 
 	// Close releases any resources held by the cache (e.g., network connections).
 	// For in-memory caches, this is a no-op.
 	Close() error
 }
+
+// ReaderSetter is implemented by caches that can store a value read from a stream,
+// so callers holding a large value on disk do not have to load it into memory first.
+// It is optional; use SetFromReader to store through it when available.
+type ReaderSetter interface {
+	// SetReader stores the bytes read from r under key, like Set. Errors reading r are
+	// returned and leave the cache unchanged; backend failures are reported to the
+	// provider's Logger, as with Set.
+	SetReader(key string, r io.Reader) error
+}
+
+// SetFromReader stores the bytes read from r under key. Caches implementing ReaderSetter
+// receive the stream; others get the value read into memory and passed to Set.
+func SetFromReader(c Cache, key string, r io.Reader) error {
+	if setter, ok := c.(ReaderSetter); ok {
+		return setter.SetReader(key, r)
+	}
+	value, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	c.Set(key, value)
+	return nil
+}
@@ -12,6 +12,10 @@ type ProviderConfig struct {
 	// Size is the maximum number of entries for LRU caches.
 	Size int
 
+	// MaxBytes bounds the total size of the cached values instead of the entry count.
+	// Only the memory provider supports it; zero keeps the entry-count limit of Size.
+	MaxBytes int64
+
 	// TTL is the time-to-live for cache entries.
 	TTL time.Duration
 
@@ -30,6 +34,10 @@ type ProviderConfig struct {
 	// RedisDB is the Redis/Valkey database number.
 	RedisDB int
 
+	// RedisKeyPrefix namespaces the Redis/Valkey keys so several deployments can
+	// share one server. Empty keeps the un-namespaced keys.
+	RedisKeyPrefix string
+
 	// Group is an optional label value used to namespace Prometheus metrics
 	// (cache_hits_total, cache_misses_total, etc.).
 	// When non-empty the cache is automatically wrapped with metric instrumentation.
@@ -80,10 +88,10 @@ func New(name string, cfg ProviderConfig) (Cache, error) {
 	group := cfg.Group
 	// Wrap OnEvict so the cache layer counts evictions itself.
 	original := cfg.OnEvict
-	cfg.OnEvict = func(key string, value []byte) {
-		EvictionsTotal.WithLabelValues(group).Inc()
+	cfg.OnEvict = func(key string, value []byte, info EvictionInfo) {
+		EvictionsTotal.WithLabelValues(group, info.Reason.String()).Inc()
 		if original != nil {
-			original(key, value)
+			original(key, value, info)
 		}
 	}
 
@@ -144,7 +144,7 @@ func TestFactory_New_WithGroupAndOnEvict(t *testing.T) {
 		Size:  1, // size=1 forces eviction on second Set
 		TTL:   time.Hour,
 		Group: "evict-group",
-		OnEvict: func(key string, value []byte) {
+		OnEvict: func(key string, value []byte, _ EvictionInfo) {
 			evicted <- key
 		},
 	})
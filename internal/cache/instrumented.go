@@ -1,5 +1,10 @@
 package cache
 
+import (
+	"io"
+	"time"
+)
+
 // instrumentedCache wraps a Cache and automatically records Prometheus metrics
 // for hits, misses, evictions, and current entry count under the given group label.
 // All metric tracking lives in the cache layer so callers do not need to manage it.
@@ -32,10 +37,27 @@ func (c *instrumentedCache) Set(key string, value []byte) {
 	c.inner.Set(key, value)
 }
 
+// SetReader forwards to the inner cache, streaming when it implements ReaderSetter.
+func (c *instrumentedCache) SetReader(key string, r io.Reader) error {
+	return SetFromReader(c.inner, key, r)
+}
+
 func (c *instrumentedCache) Contains(key string) bool {
 	return c.inner.Contains(key)
 }
 
+func (c *instrumentedCache) Delete(key string) {
+	c.inner.Delete(key)
+}
+
+func (c *instrumentedCache) Clear() {
+	c.inner.Clear()
+}
+
+func (c *instrumentedCache) SetTTL(ttl time.Duration) {
+	c.inner.SetTTL(ttl)
+}
+
 func (c *instrumentedCache) Len() int {
 	return c.inner.Len()
 }
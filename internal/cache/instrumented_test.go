@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -8,9 +9,9 @@ import (
 	dto "github.com/prometheus/client_model/go"
 )
 
-// getCounterVecValue reads the current value of a CounterVec for the given label.
-func getCounterVecValue(cv *prometheus.CounterVec, label string) float64 {
-	c, err := cv.GetMetricWithLabelValues(label)
+// getCounterVecValue reads the current value of a CounterVec for the given label values.
+func getCounterVecValue(cv *prometheus.CounterVec, labels ...string) float64 {
+	c, err := cv.GetMetricWithLabelValues(labels...)
 	if err != nil {
 		return 0
 	}
@@ -65,7 +66,7 @@ func TestInstrumentedCache_Misses(t *testing.T) {
 func TestInstrumentedCache_Evictions(t *testing.T) {
 	t.Parallel()
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -76,13 +77,13 @@ func TestInstrumentedCache_Evictions(t *testing.T) {
 	}
 	defer c.Close()
 
-	before := getCounterVecValue(EvictionsTotal, "test-evict")
+	before := getCounterVecValue(EvictionsTotal, "test-evict", "capacity")
 
 	c.Set("a", []byte("1"))
 	c.Set("b", []byte("2"))
 	c.Set("c", []byte("3")) // evicts "a"
 
-	after := getCounterVecValue(EvictionsTotal, "test-evict")
+	after := getCounterVecValue(EvictionsTotal, "test-evict", "capacity")
 	if after != before+1 {
 		t.Errorf("Expected evictions to increment by 1, got diff %.0f", after-before)
 	}
@@ -196,3 +197,18 @@ func TestInstrumentedCache_Len(t *testing.T) {
 		t.Errorf("Expected Len() == 3 after three Sets, got %d", c.Len())
 	}
 }
+
+func TestInstrumentedCache_SetReader(t *testing.T) {
+	t.Parallel()
+	c := newInstrumentedTestCache(t, "test-set-reader")
+
+	if _, ok := c.(ReaderSetter); !ok {
+		t.Fatal("Expected the instrumented cache to implement ReaderSetter")
+	}
+	if err := SetFromReader(c, "k", strings.NewReader("v")); err != nil {
+		t.Fatalf("SetFromReader: %v", err)
+	}
+	if val, ok := c.Get("k"); !ok || string(val) != "v" {
+		t.Errorf("Expected 'v' after SetFromReader, got %q (hit=%v)", string(val), ok)
+	}
+}
@@ -1,6 +1,10 @@
 package cache
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	lru "github.com/hashicorp/golang-lru/v2/expirable"
 )
 
@@ -9,38 +13,191 @@ func init() {
 }
 
 // memoryCache wraps hashicorp/golang-lru/v2/expirable to implement the Cache interface.
+// The expirable LRU has a fixed TTL, so SetTTL swaps in a new LRU under mu.
+// With maxBytes set, the LRU is unbounded in entries and Set evicts the least recently
+// used entries until the cached values fit in maxBytes.
+//
+// The LRU does not say why it evicts, so meta keeps each entry's store and expiry times:
+// an entry removed past its expiry was expired, one marked by Delete or Clear was removed
+// explicitly, and any other was evicted for capacity.
 type memoryCache struct {
-	inner *lru.LRU[string, []byte]
+	mu       sync.RWMutex
+	setMu    sync.Mutex // serializes Set so a replaced value is subtracted from bytes exactly once
+	inner    *lru.LRU[string, []byte]
+	discard  *atomic.Bool // silences the eviction callback of inner once it has been replaced
+	size     int
+	maxBytes int64
+	bytes    atomic.Int64 // total length of the cached values
+	onEvict  EvictCallback
+
+	metaMu sync.Mutex
+	meta   map[string]memoryEntryMeta
+	ttl    time.Duration // TTL of inner, guarded by mu
+}
+
+// memoryEntryMeta records when an entry was stored and expires, for eviction reasons.
+type memoryEntryMeta struct {
+	storedAt  time.Time
+	expiresAt time.Time
+	explicit  bool // set by Delete and Clear just before the removal
 }
 
 func newMemoryCache(cfg ProviderConfig) (Cache, error) {
-	var onEvict func(string, []byte)
-	if cfg.OnEvict != nil {
-		onEvict = func(key string, value []byte) {
-			cfg.OnEvict(key, value)
+	m := &memoryCache{size: cfg.Size, maxBytes: cfg.MaxBytes, onEvict: cfg.OnEvict, meta: make(map[string]memoryEntryMeta), ttl: cfg.TTL}
+	if m.maxBytes > 0 {
+		m.size = 0
+	}
+	m.inner, m.discard = m.newLRU(cfg.TTL)
+	return m, nil
+}
+
+func (m *memoryCache) newLRU(ttl time.Duration) (*lru.LRU[string, []byte], *atomic.Bool) {
+	discard := &atomic.Bool{}
+	onEvict := func(key string, value []byte) {
+		if discard.Load() {
+			return
+		}
+		m.bytes.Add(-int64(len(value)))
+		info := m.evictionInfo(key, value)
+		if m.onEvict != nil {
+			m.onEvict(key, value, info)
+		}
+	}
+	return lru.NewLRU[string, []byte](m.size, onEvict, ttl), discard
+}
+
+// evictionInfo drops the metadata of an evicted entry and derives why it was evicted.
+func (m *memoryCache) evictionInfo(key string, value []byte) EvictionInfo {
+	m.metaMu.Lock()
+	meta, ok := m.meta[key]
+	delete(m.meta, key)
+	m.metaMu.Unlock()
+
+	info := EvictionInfo{Reason: EvictReasonCapacity, Size: len(value)}
+	if !ok {
+		return info
+	}
+	now := time.Now()
+	info.Age = now.Sub(meta.storedAt)
+	switch {
+	case meta.explicit:
+		info.Reason = EvictReasonExplicit
+	case !now.Before(meta.expiresAt):
+		info.Reason = EvictReasonExpired
+	}
+	return info
+}
+
+// markExplicit flags the entries about to be removed by Delete or Clear.
+func (m *memoryCache) markExplicit(keys ...string) {
+	m.metaMu.Lock()
+	defer m.metaMu.Unlock()
+	for _, key := range keys {
+		if meta, ok := m.meta[key]; ok {
+			meta.explicit = true
+			m.meta[key] = meta
 		}
 	}
-	return &memoryCache{
-		inner: lru.NewLRU[string, []byte](cfg.Size, onEvict, cfg.TTL),
-	}, nil
 }
 
 func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.inner.Get(key)
 }
 
+// Set stores value under key. With maxBytes set, least recently used entries are evicted
+// until the cache fits its byte budget, and a value larger than the whole budget is not
+// cached (an older value under key is removed).
 func (m *memoryCache) Set(key string, value []byte) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.setMu.Lock()
+	defer m.setMu.Unlock()
+
+	if m.maxBytes > 0 && int64(len(value)) > m.maxBytes {
+		m.markExplicit(key)
+		m.inner.Remove(key)
+		return
+	}
+
+	// Replacing a value does not invoke the eviction callback
+	if previous, ok := m.inner.Peek(key); ok {
+		m.bytes.Add(-int64(len(previous)))
+	}
 	m.inner.Add(key, value)
+	m.bytes.Add(int64(len(value)))
+
+	now := time.Now()
+	m.metaMu.Lock()
+	m.meta[key] = memoryEntryMeta{storedAt: now, expiresAt: now.Add(m.ttl)}
+	m.metaMu.Unlock()
+
+	for m.maxBytes > 0 && m.bytes.Load() > m.maxBytes {
+		if _, _, ok := m.inner.RemoveOldest(); !ok {
+			break
+		}
+	}
 }
 
 func (m *memoryCache) Contains(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.inner.Contains(key)
 }
 
+// Delete removes key from the LRU. The eviction callback is invoked for the removed entry.
+func (m *memoryCache) Delete(key string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.markExplicit(key)
+	m.inner.Remove(key)
+}
+
+// Clear purges all entries. The eviction callback is invoked for each removed entry.
+func (m *memoryCache) Clear() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.markExplicit(m.inner.Keys()...)
+	m.inner.Purge()
+}
+
+// SetTTL replaces the LRU with one using ttl. Cached entries are carried over in LRU
+// order and expire ttl after the change; the eviction callback is not invoked for the move.
+func (m *memoryCache) SetTTL(ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next, discard := m.newLRU(ttl)
+	for _, key := range m.inner.Keys() {
+		if value, ok := m.inner.Peek(key); ok {
+			next.Add(key, value)
+		}
+	}
+	m.discard.Store(true)
+	m.inner.Purge()
+	m.inner, m.discard, m.ttl = next, discard, ttl
+
+	expiresAt := time.Now().Add(ttl)
+	m.metaMu.Lock()
+	for key, meta := range m.meta {
+		meta.expiresAt = expiresAt
+		m.meta[key] = meta
+	}
+	m.metaMu.Unlock()
+}
+
 func (m *memoryCache) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.inner.Len()
 }
 
+// Bytes returns the total length of the cached values.
+func (m *memoryCache) Bytes() int64 {
+	return m.bytes.Load()
+}
+
 func (m *memoryCache) Close() error {
 	return nil
 }
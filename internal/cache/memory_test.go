@@ -1,7 +1,11 @@
 package cache
 
 import (
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -51,6 +55,50 @@ func TestMemoryCache_Contains(t *testing.T) {
 	}
 }
 
+func TestMemoryCache_Delete(t *testing.T) {
+	t.Parallel()
+	c, err := New("memory", ProviderConfig{Size: 10, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Delete("a")
+	c.Delete("missing")
+
+	if c.Contains("a") {
+		t.Fatal("Expected deleted key to be absent")
+	}
+	if !c.Contains("b") {
+		t.Fatal("Expected untouched key to remain")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Expected Len 1, got %d", c.Len())
+	}
+}
+
+func TestMemoryCache_Clear(t *testing.T) {
+	t.Parallel()
+	c, err := New("memory", ProviderConfig{Size: 10, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Clear()
+
+	if c.Len() != 0 {
+		t.Fatalf("Expected Len 0 after Clear, got %d", c.Len())
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Expected miss after Clear")
+	}
+}
+
 func TestMemoryCache_Len(t *testing.T) {
 	t.Parallel()
 	c, err := New("memory", ProviderConfig{Size: 10, TTL: time.Hour})
@@ -73,7 +121,7 @@ func TestMemoryCache_Len(t *testing.T) {
 func TestMemoryCache_Eviction(t *testing.T) {
 	t.Parallel()
 	evictedKeys := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evictedKeys = append(evictedKeys, key)
 	}
 
@@ -136,3 +184,259 @@ func TestMemoryCache_Close(t *testing.T) {
 		t.Fatalf("Close: %v", err)
 	}
 }
+
+func TestMemoryCache_SetTTL(t *testing.T) {
+	t.Parallel()
+	evictedKeys := make([]string, 0)
+	var mu sync.Mutex
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		evictedKeys = append(evictedKeys, key)
+	}
+
+	c, err := New("memory", ProviderConfig{Size: 2, TTL: time.Hour, OnEvict: onEvict})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.SetTTL(50 * time.Millisecond)
+
+	// Entries are carried over without eviction callbacks, keeping LRU order
+	mu.Lock()
+	if len(evictedKeys) != 0 {
+		t.Fatalf("Expected no eviction callbacks when changing TTL, got %v", evictedKeys)
+	}
+	mu.Unlock()
+	if !c.Contains("a") || !c.Contains("b") {
+		t.Fatal("Expected existing entries to survive the TTL change")
+	}
+	c.Set("c", []byte("3")) // should evict "a", the least recently used
+	if c.Contains("a") {
+		t.Fatal("Expected LRU order to be preserved across the TTL change")
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("Expected carried-over entry to expire with the new TTL")
+	}
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("Expected new entry to expire with the new TTL")
+	}
+}
+
+func TestSetFromReader_MemoryFallsBackToSet(t *testing.T) {
+	t.Parallel()
+	c, err := New("memory", ProviderConfig{Size: 10, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("New memory cache: %v", err)
+	}
+	defer c.Close()
+
+	if err := SetFromReader(c, "streamed", strings.NewReader("streamed value")); err != nil {
+		t.Fatalf("SetFromReader: %v", err)
+	}
+	val, ok := c.Get("streamed")
+	if !ok || string(val) != "streamed value" {
+		t.Fatalf("Expected 'streamed value', got %q (hit=%v)", string(val), ok)
+	}
+
+	readErr := errors.New("read failed")
+	if err := SetFromReader(c, "broken", iotest.ErrReader(readErr)); !errors.Is(err, readErr) {
+		t.Fatalf("Expected the read error, got %v", err)
+	}
+	if c.Contains("broken") {
+		t.Fatal("Expected a failed read to store nothing")
+	}
+}
+
+func TestMemoryCache_MaxBytesEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	var evicted []string
+	c, err := New("memory", ProviderConfig{
+		Size:     1, // ignored when MaxBytes is set
+		MaxBytes: 10,
+		TTL:      time.Hour,
+		OnEvict:  func(key string, _ []byte, _ EvictionInfo) { evicted = append(evicted, key) },
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	mc := c.(*memoryCache)
+
+	c.Set("a", []byte("aaaa"))
+	c.Set("b", []byte("bbbb"))
+	if c.Len() != 2 || mc.Bytes() != 8 {
+		t.Fatalf("Expected 2 entries and 8 bytes within budget, got %d entries and %d bytes", c.Len(), mc.Bytes())
+	}
+
+	// Touch "a" so "b" becomes the least recently used entry
+	c.Get("a")
+	c.Set("c", []byte("cccc"))
+
+	if c.Contains("b") {
+		t.Error("Expected the least recently used entry b to be evicted")
+	}
+	if !c.Contains("a") || !c.Contains("c") {
+		t.Error("Expected a and c to remain cached")
+	}
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("Expected eviction callback for [b], got %v", evicted)
+	}
+	if mc.Bytes() != 8 {
+		t.Errorf("Expected 8 cached bytes, got %d", mc.Bytes())
+	}
+}
+
+func TestMemoryCache_MaxBytesReplaceAndOversized(t *testing.T) {
+	t.Parallel()
+	c, err := New("memory", ProviderConfig{MaxBytes: 10, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+	mc := c.(*memoryCache)
+
+	c.Set("a", []byte("aaaa"))
+	c.Set("a", []byte("aaaaaa"))
+	if mc.Bytes() != 6 {
+		t.Errorf("Expected a replaced value to count once (6 bytes), got %d", mc.Bytes())
+	}
+
+	// A value over the whole budget is not cached and drops the older value
+	c.Set("a", []byte("aaaaaaaaaaaa"))
+	if c.Contains("a") {
+		t.Error("Expected a value larger than the budget not to be cached")
+	}
+	if mc.Bytes() != 0 {
+		t.Errorf("Expected 0 cached bytes, got %d", mc.Bytes())
+	}
+
+	c.Set("b", []byte("bb"))
+	c.Delete("b")
+	if mc.Bytes() != 0 {
+		t.Errorf("Expected Delete to release the bytes, got %d", mc.Bytes())
+	}
+}
+
+func TestMemoryCache_EntryCountDefault(t *testing.T) {
+	t.Parallel()
+	c, err := New("memory", ProviderConfig{Size: 2, TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		c.Set(key, []byte(strings.Repeat(key, 1000)))
+	}
+	if c.Len() != 2 || c.Contains("a") {
+		t.Errorf("Expected the entry count to bound the cache without MaxBytes, got %d entries", c.Len())
+	}
+}
+
+// recordEvictions returns an OnEvict callback that collects EvictionInfo by key.
+func recordEvictions() (EvictCallback, func(key string) (EvictionInfo, bool)) {
+	var mu sync.Mutex
+	infos := make(map[string]EvictionInfo)
+	onEvict := func(key string, _ []byte, info EvictionInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		infos[key] = info
+	}
+	lookup := func(key string) (EvictionInfo, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		info, ok := infos[key]
+		return info, ok
+	}
+	return onEvict, lookup
+}
+
+func TestMemoryCache_EvictReasonCapacity(t *testing.T) {
+	t.Parallel()
+	onEvict, evicted := recordEvictions()
+	c, err := New("memory", ProviderConfig{Size: 2, TTL: time.Hour, OnEvict: onEvict})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("12345"))
+	c.Set("b", []byte("2"))
+	c.Set("c", []byte("3")) // overflows the LRU, evicting "a"
+
+	info, ok := evicted("a")
+	if !ok {
+		t.Fatal("Expected an eviction callback for a")
+	}
+	if info.Reason != EvictReasonCapacity {
+		t.Errorf("Expected reason %s, got %s", EvictReasonCapacity, info.Reason)
+	}
+	if info.Size != 5 {
+		t.Errorf("Expected size 5, got %d", info.Size)
+	}
+	if info.Age <= 0 || info.Age > time.Minute {
+		t.Errorf("Expected a small positive age, got %s", info.Age)
+	}
+}
+
+func TestMemoryCache_EvictReasonExpired(t *testing.T) {
+	t.Parallel()
+	onEvict, evicted := recordEvictions()
+	c, err := New("memory", ProviderConfig{Size: 10, TTL: 50 * time.Millisecond, OnEvict: onEvict})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("1"))
+
+	// The expirable LRU drops expired entries from a background cleanup
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		c.Get("a")
+		if info, ok := evicted("a"); ok {
+			if info.Reason != EvictReasonExpired {
+				t.Errorf("Expected reason %s, got %s", EvictReasonExpired, info.Reason)
+			}
+			if info.Age < 50*time.Millisecond {
+				t.Errorf("Expected age of at least the TTL, got %s", info.Age)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the entry to expire")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestMemoryCache_EvictReasonExplicit(t *testing.T) {
+	t.Parallel()
+	onEvict, evicted := recordEvictions()
+	c, err := New("memory", ProviderConfig{Size: 10, TTL: time.Hour, OnEvict: onEvict})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer c.Close()
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Delete("a")
+	c.Clear()
+
+	for _, key := range []string{"a", "b"} {
+		info, ok := evicted(key)
+		if !ok {
+			t.Fatalf("Expected an eviction callback for %s", key)
+		}
+		if info.Reason != EvictReasonExplicit {
+			t.Errorf("Expected reason %s for %s, got %s", EvictReasonExplicit, key, info.Reason)
+		}
+	}
+}
@@ -28,13 +28,13 @@ var (
 		[]string{"cache"},
 	)
 
-	// EvictionsTotal counts evicted entries per group.
+	// EvictionsTotal counts evicted entries per group and EvictReason.
 	EvictionsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "cache_evictions_total",
-			Help: "Total number of entries evicted from the cache.",
+			Help: "Total number of entries evicted from the cache, by reason.",
 		},
-		[]string{"cache"},
+		[]string{"cache", "reason"},
 	)
 )
 
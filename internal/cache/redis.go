@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -13,8 +16,25 @@ import (
 const (
 	// defaultKeyPrefix namespaces all cache keys in Redis to avoid collisions.
 	defaultKeyPrefix = "sscache:"
+
+	// stagingChunkSize is the size of each APPEND sent by SetReader.
+	stagingChunkSize = 1 << 20
+
+	// stagingTTL bounds how long an abandoned SetReader upload stays in Redis.
+	stagingTTL = 5 * time.Minute
 )
 
+// redisKeyPrefix returns the prefix of the hash and sorted set keys. An empty
+// namespace keeps the keys used by earlier releases ("sscache:data"); otherwise
+// the namespace is inserted after the default prefix ("sscache:prod:data").
+func redisKeyPrefix(namespace string) string {
+	namespace = strings.TrimSuffix(namespace, ":")
+	if namespace == "" {
+		return defaultKeyPrefix
+	}
+	return defaultKeyPrefix + namespace + ":"
+}
+
 func init() {
 	Register("redis", newRedisCache)
 }
@@ -34,54 +54,60 @@ func init() {
 //   - {prefix}lru  — a Sorted Set that tracks LRU ordering (member = user key,
 //     score = last-access µs timestamp).
 //
+// SetReader uploads large values in chunks to a temporary {prefix}staging:... string and
+// then moves them into the hash in one script, so a value is never sent in one command.
+//
+// Providers configured with different RedisKeyPrefix values use separate keys,
+// so instances sharing one Redis never see, count, evict or clear each other's entries.
+//
 // Lua scripts ensure that Get (touch) and Set (write + evict) are each executed atomically.
 // Stale LRU entries (whose hash field has expired) are lazily cleaned during eviction.
 type redisCache struct {
 	client  *redis.Client
-	ttl     time.Duration
+	ttl     atomic.Int64 // time.Duration, swapped by SetTTL
 	maxSize int
 	onEvict EvictCallback
 	logger  Logger
-	dataKey string // hash key, e.g. "sscache:data"
-	lruKey  string // sorted set key, e.g. "sscache:lru"
+	dataKey string // hash key, e.g. "sscache:data" or "sscache:prod:data"
+	lruKey  string // sorted set key, e.g. "sscache:lru" or "sscache:prod:lru"
+
+	stagingPrefix string        // prefix of the strings SetReader uploads into, e.g. "sscache:staging:"
+	stagingSeq    atomic.Uint64 // makes concurrent uploads of the same key use distinct staging strings
 }
 
 // getAndTouch atomically retrieves a value from the hash and refreshes
-// the LRU score when the entry exists.
+// the LRU score when the entry exists. A miss whose member is still in the
+// sorted set means Redis expired the hash field, so the stale member is removed.
 //
 // KEYS[1] = data hash, KEYS[2] = LRU sorted set
 // ARGV[1] = current µs timestamp, ARGV[2] = member (user key)
 //
-// Returns the value on hit, or nil on miss (including expired fields).
+// Returns the value on hit, 1 on a miss that found an expired field, or nil on any other miss.
 var getAndTouch = redis.NewScript(`
 local val = redis.call('HGET', KEYS[1], ARGV[2])
 if val then
     redis.call('ZADD', KEYS[2], ARGV[1], ARGV[2])
+    return val
 end
-return val
+return redis.call('ZREM', KEYS[2], ARGV[2]) == 1 and 1 or nil
 `)
 
-// setAndEvict atomically stores a value in the hash, sets per-field TTL via
-// HPEXPIRE, updates LRU tracking, and evicts the least-recently-used entries
-// when the cache exceeds maxSize. Stale sorted-set members whose hash field
-// has already expired are silently cleaned up during eviction.
+// storeAndEvict is the shared body of setAndEvict and commitStaged. It stores value in
+// the hash, sets its per-field TTL via HPEXPIRE, updates LRU tracking, and evicts the
+// least-recently-used entries when the cache exceeds maxSize. Stale sorted-set members
+// whose hash field has already expired are silently cleaned up during eviction.
 //
-// KEYS[1] = data hash, KEYS[2] = LRU sorted set
-// ARGV[1] = value, ARGV[2] = current µs timestamp, ARGV[3] = member (user key),
-// ARGV[4] = maxSize, ARGV[5] = TTL in milliseconds
+// The including script defines the locals value, now, member, maxSize and ttlMs, with
+// KEYS[1] = data hash and KEYS[2] = LRU sorted set.
 //
 // Returns a list of evicted member names (may be empty).
-var setAndEvict = redis.NewScript(`
-local member  = ARGV[3]
-local maxSize = tonumber(ARGV[4])
-local ttlMs   = tonumber(ARGV[5])
-
+const storeAndEvict = `
 -- Store value and set per-field TTL
-redis.call('HSET', KEYS[1], member, ARGV[1])
+redis.call('HSET', KEYS[1], member, value)
 redis.call('HPEXPIRE', KEYS[1], ttlMs, 'FIELDS', 1, member)
 
 -- Update LRU score
-redis.call('ZADD', KEYS[2], ARGV[2], member)
+redis.call('ZADD', KEYS[2], now, member)
 
 -- Evict least-recently-used entries if over capacity.
 -- If the hash field was already expired by Redis, HDEL is a harmless no-op
@@ -98,7 +124,37 @@ while size > maxSize do
 end
 
 return evicted
-`)
+`
+
+// setAndEvict atomically stores a value and evicts as described in storeAndEvict.
+//
+// KEYS[1] = data hash, KEYS[2] = LRU sorted set
+// ARGV[1] = value, ARGV[2] = current µs timestamp, ARGV[3] = member (user key),
+// ARGV[4] = maxSize, ARGV[5] = TTL in milliseconds
+var setAndEvict = redis.NewScript(`
+local value   = ARGV[1]
+local now     = ARGV[2]
+local member  = ARGV[3]
+local maxSize = tonumber(ARGV[4])
+local ttlMs   = tonumber(ARGV[5])
+` + storeAndEvict)
+
+// commitStaged atomically moves a value uploaded by SetReader from its staging string
+// into the hash and evicts as described in storeAndEvict. The value is copied inside
+// Redis, so the client never sends it in one command. A missing staging key stores an
+// empty value.
+//
+// KEYS[1] = data hash, KEYS[2] = LRU sorted set, KEYS[3] = staging string
+// ARGV[1] = current µs timestamp, ARGV[2] = member (user key),
+// ARGV[3] = maxSize, ARGV[4] = TTL in milliseconds
+var commitStaged = redis.NewScript(`
+local value   = redis.call('GET', KEYS[3]) or ''
+redis.call('DEL', KEYS[3])
+local now     = ARGV[1]
+local member  = ARGV[2]
+local maxSize = tonumber(ARGV[3])
+local ttlMs   = tonumber(ARGV[4])
+` + storeAndEvict)
 
 func newRedisCache(cfg ProviderConfig) (Cache, error) {
 	client := redis.NewClient(&redis.Options{
@@ -115,16 +171,18 @@ func newRedisCache(cfg ProviderConfig) (Cache, error) {
 		return nil, fmt.Errorf("redis ping failed: %w", err)
 	}
 
-	prefix := defaultKeyPrefix
-	return &redisCache{
-		client:  client,
-		ttl:     cfg.TTL,
-		maxSize: cfg.Size,
-		onEvict: cfg.OnEvict,
-		logger:  cfg.Logger,
-		dataKey: prefix + "data",
-		lruKey:  prefix + "lru",
-	}, nil
+	prefix := redisKeyPrefix(cfg.RedisKeyPrefix)
+	c := &redisCache{
+		client:        client,
+		maxSize:       cfg.Size,
+		onEvict:       cfg.OnEvict,
+		logger:        cfg.Logger,
+		dataKey:       prefix + "data",
+		lruKey:        prefix + "lru",
+		stagingPrefix: prefix + "staging:",
+	}
+	c.ttl.Store(int64(cfg.TTL))
+	return c, nil
 }
 
 func (r *redisCache) keys() []string {
@@ -142,7 +200,7 @@ func (r *redisCache) Get(key string) ([]byte, bool) {
 	defer cancel()
 
 	now := strconv.FormatInt(time.Now().UnixMicro(), 10)
-	result, err := getAndTouch.Run(ctx, r.client, r.keys(), now, key).Text()
+	result, err := getAndTouch.Run(ctx, r.client, r.keys(), now, key).Result()
 	if err != nil {
 		// redis.Nil means the key doesn't exist — a normal cache miss.
 		if !errors.Is(err, redis.Nil) {
@@ -150,7 +208,15 @@ func (r *redisCache) Get(key string) ([]byte, bool) {
 		}
 		return nil, false
 	}
-	return []byte(result), true
+	value, ok := result.(string)
+	if !ok {
+		// Redis expired the field; its age and size are no longer known.
+		if r.onEvict != nil {
+			r.onEvict(key, nil, EvictionInfo{Reason: EvictReasonExpired})
+		}
+		return nil, false
+	}
+	return []byte(value), true
 }
 
 func (r *redisCache) Set(key string, value []byte) {
@@ -159,7 +225,7 @@ func (r *redisCache) Set(key string, value []byte) {
 
 	now := strconv.FormatInt(time.Now().UnixMicro(), 10)
 	maxSize := strconv.Itoa(r.maxSize)
-	ttlMs := strconv.FormatInt(r.ttl.Milliseconds(), 10)
+	ttlMs := strconv.FormatInt(time.Duration(r.ttl.Load()).Milliseconds(), 10)
 
 	evicted, err := setAndEvict.Run(ctx, r.client, r.keys(),
 		value, now, key, maxSize, ttlMs,
@@ -169,18 +235,85 @@ func (r *redisCache) Set(key string, value []byte) {
 		r.logError("redis cache Set failed", err)
 		return
 	}
+	r.notifyEvicted(evicted)
+}
 
-	if len(evicted) == 0 {
-		return
-	}
+// SetReader stores the bytes read from src under key without holding them in memory:
+// they are appended to a staging string in chunks of stagingChunkSize, which
+// commitStaged then moves into the hash. A read error discards the staging string.
+func (r *redisCache) SetReader(key string, src io.Reader) error {
+	staging := r.stagingPrefix + key + ":" + strconv.FormatUint(r.stagingSeq.Add(1), 10) + ":" + strconv.FormatInt(time.Now().UnixNano(), 36)
 
-	if r.onEvict != nil {
-		// Value is nil because retrieving evicted values from Redis would require
-		// additional roundtrips. Callers should only rely on the key for bookkeeping.
-		for _, evictedKey := range evicted {
-			r.onEvict(evictedKey, nil)
+	buf := make([]byte, stagingChunkSize)
+	for {
+		n, readErr := io.ReadFull(src, buf)
+		if n > 0 {
+			if err := r.appendStaging(staging, buf[:n]); err != nil {
+				r.logError("redis cache SetReader upload failed", err)
+				r.discardStaging(staging)
+				return nil
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			r.discardStaging(staging)
+			return readErr
 		}
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	now := strconv.FormatInt(time.Now().UnixMicro(), 10)
+	maxSize := strconv.Itoa(r.maxSize)
+	ttlMs := strconv.FormatInt(time.Duration(r.ttl.Load()).Milliseconds(), 10)
+
+	evicted, err := commitStaged.Run(ctx, r.client, []string{r.dataKey, r.lruKey, staging},
+		now, key, maxSize, ttlMs,
+	).StringSlice()
+	if err != nil {
+		r.logError("redis cache SetReader failed", err)
+		r.discardStaging(staging)
+		return nil
+	}
+	r.notifyEvicted(evicted)
+	return nil
+}
+
+// appendStaging appends chunk to the staging string and refreshes its expiry, so an
+// upload abandoned by a crash is removed by Redis.
+func (r *redisCache) appendStaging(staging string, chunk []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pipe := r.client.TxPipeline()
+	pipe.Append(ctx, staging, string(chunk))
+	pipe.PExpire(ctx, staging, stagingTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisCache) discardStaging(staging string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := r.client.Del(ctx, staging).Err(); err != nil {
+		r.logError("redis cache staging cleanup failed", err)
+	}
+}
+
+// notifyEvicted reports the members evicted by setAndEvict or commitStaged to onEvict.
+func (r *redisCache) notifyEvicted(evicted []string) {
+	if len(evicted) == 0 || r.onEvict == nil {
+		return
+	}
+	// Value is nil because retrieving evicted values from Redis would require
+	// additional roundtrips. Callers should only rely on the key for bookkeeping.
+	for _, evictedKey := range evicted {
+		r.onEvict(evictedKey, nil, EvictionInfo{Reason: EvictReasonCapacity})
+	}
 }
 
 func (r *redisCache) Contains(key string) bool {
@@ -194,6 +327,38 @@ func (r *redisCache) Contains(key string) bool {
 	return err == nil && n
 }
 
+// Delete removes key from both the data hash and the LRU sorted set.
+func (r *redisCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pipe := r.client.TxPipeline()
+	removed := pipe.HDel(ctx, r.dataKey, key)
+	pipe.ZRem(ctx, r.lruKey, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logError("redis cache Delete failed", err)
+		return
+	}
+	if removed.Val() > 0 && r.onEvict != nil {
+		r.onEvict(key, nil, EvictionInfo{Reason: EvictReasonExplicit})
+	}
+}
+
+// Clear drops the data hash and LRU sorted set, removing every entry under the prefix.
+func (r *redisCache) Clear() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := r.client.Del(ctx, r.keys()...).Err(); err != nil {
+		r.logError("redis cache Clear failed", err)
+	}
+}
+
+// SetTTL changes the per-field TTL applied by later Set calls. Fields already stored keep their expiry.
+func (r *redisCache) SetTTL(ttl time.Duration) {
+	r.ttl.Store(int64(ttl))
+}
+
 func (r *redisCache) Len() int {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
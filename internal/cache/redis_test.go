@@ -1,9 +1,13 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"os"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -107,9 +111,86 @@ func TestRedisCache_Len(t *testing.T) {
 	}
 }
 
+func TestRedisCache_Delete(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	c.Set("redis-del-a", []byte("1"))
+	c.Set("redis-del-b", []byte("2"))
+	c.Delete("redis-del-a")
+
+	if c.Contains("redis-del-a") {
+		t.Fatal("Expected deleted key to be absent")
+	}
+	if !c.Contains("redis-del-b") {
+		t.Fatal("Expected untouched key to remain")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Expected Len 1, got %d", c.Len())
+	}
+}
+
+func TestRedisCache_Clear(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	c.Set("redis-clear-a", []byte("1"))
+	c.Set("redis-clear-b", []byte("2"))
+	c.Clear()
+
+	if c.Len() != 0 {
+		t.Fatalf("Expected Len 0 after Clear, got %d", c.Len())
+	}
+	if _, ok := c.Get("redis-clear-a"); ok {
+		t.Fatal("Expected miss after Clear")
+	}
+}
+
+func TestRedisCache_SetReader(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	// Larger than one staging chunk so the value is uploaded with several APPENDs.
+	value := bytes.Repeat([]byte("0123456789"), stagingChunkSize/4)
+	if err := SetFromReader(c, "redis-reader-key", bytes.NewReader(value)); err != nil {
+		t.Fatalf("SetFromReader: %v", err)
+	}
+
+	got, ok := c.Get("redis-reader-key")
+	if !ok {
+		t.Fatal("Expected hit after SetFromReader")
+	}
+	if !bytes.Equal(got, value) {
+		t.Fatalf("Expected %d stored bytes, got %d", len(value), len(got))
+	}
+	if c.Len() != 1 {
+		t.Fatalf("Expected Len 1, got %d", c.Len())
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: os.Getenv("REDIS_ADDRESS"), DB: 15})
+	defer client.Close()
+	staging, err := client.Keys(context.Background(), defaultKeyPrefix+"staging:*").Result()
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	if len(staging) != 0 {
+		t.Fatalf("Expected staging strings to be removed, found %v", staging)
+	}
+}
+
+func TestRedisCache_SetReaderReadError(t *testing.T) {
+	c := newTestRedisCache(t)
+
+	readErr := errors.New("disk gone")
+	src := io.MultiReader(bytes.NewReader([]byte("partial")), iotest.ErrReader(readErr))
+	if err := SetFromReader(c, "redis-reader-broken", src); !errors.Is(err, readErr) {
+		t.Fatalf("Expected the read error, got %v", err)
+	}
+	if c.Contains("redis-reader-broken") {
+		t.Fatal("Expected a failed upload to store nothing")
+	}
+}
+
 func TestRedisCache_LRU_Eviction(t *testing.T) {
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -153,7 +234,7 @@ func TestRedisCache_LRU_TouchPromotesEntry(t *testing.T) {
 
 func TestRedisCache_MultipleEvictions(t *testing.T) {
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -196,7 +277,7 @@ func TestRedisCache_MultipleEvictions(t *testing.T) {
 
 func TestRedisCache_EvictionWithLRUOrdering(t *testing.T) {
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -246,7 +327,7 @@ func TestRedisCache_EvictionWithLRUOrdering(t *testing.T) {
 
 func TestRedisCache_EvictionCallbackInvoked(t *testing.T) {
 	callbackInvocations := make(map[string]int)
-	onEvict := func(key string, value []byte) {
+	onEvict := func(key string, value []byte, _ EvictionInfo) {
 		callbackInvocations[key]++
 		// Redis cache doesn't retrieve evicted values, so value should be nil.
 		if value != nil {
@@ -310,7 +391,7 @@ func TestRedisCache_StaleLRUCleanup(t *testing.T) {
 	// are cleaned up during eviction, as documented in the redisCache implementation.
 
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -349,7 +430,7 @@ func TestRedisCache_StaleLRUCleanup(t *testing.T) {
 
 func TestRedisCache_EvictionAtExactCapacity(t *testing.T) {
 	evicted := make([]string, 0)
-	onEvict := func(key string, _ []byte) {
+	onEvict := func(key string, _ []byte, _ EvictionInfo) {
 		evicted = append(evicted, key)
 	}
 
@@ -399,3 +480,101 @@ func TestRedisCache_Close(t *testing.T) {
 		t.Fatalf("Close: %v", err)
 	}
 }
+
+func TestRedisKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		namespace string
+		want      string
+	}{
+		{namespace: "", want: "sscache:"},
+		{namespace: "prod", want: "sscache:prod:"},
+		{namespace: "prod:", want: "sscache:prod:"},
+	}
+	for _, tt := range tests {
+		if got := redisKeyPrefix(tt.namespace); got != tt.want {
+			t.Errorf("redisKeyPrefix(%q) = %q, want %q", tt.namespace, got, tt.want)
+		}
+	}
+}
+
+func TestRedisCache_KeyPrefixIsolation(t *testing.T) {
+	addr := skipIfNoRedis(t)
+	flushTestRedisDB(t, addr)
+
+	newPrefixed := func(prefix string) Cache {
+		c, err := New("redis", ProviderConfig{
+			Size:           10,
+			TTL:            time.Minute,
+			RedisAddress:   addr,
+			RedisDB:        15,
+			RedisKeyPrefix: prefix,
+		})
+		if err != nil {
+			t.Fatalf("New redis cache with prefix %q: %v", prefix, err)
+		}
+		t.Cleanup(func() { _ = c.Close() })
+		return c
+	}
+	dev := newPrefixed("dev")
+	prod := newPrefixed("prod")
+
+	dev.Set("shared-key", []byte("dev-value"))
+	if prod.Contains("shared-key") {
+		t.Fatal("Expected prod not to see dev's entry")
+	}
+	if _, ok := prod.Get("shared-key"); ok {
+		t.Fatal("Expected prod Get to miss dev's entry")
+	}
+
+	prod.Set("shared-key", []byte("prod-value"))
+	prod.Set("prod-only", []byte("x"))
+
+	val, ok := dev.Get("shared-key")
+	if !ok || string(val) != "dev-value" {
+		t.Fatalf("Expected dev value to be unchanged, got %q (hit=%v)", string(val), ok)
+	}
+	if dev.Len() != 1 {
+		t.Fatalf("Expected dev Len 1, got %d", dev.Len())
+	}
+	if prod.Len() != 2 {
+		t.Fatalf("Expected prod Len 2, got %d", prod.Len())
+	}
+
+	prod.Delete("shared-key")
+	if !dev.Contains("shared-key") {
+		t.Fatal("Expected prod Delete to leave dev's entry")
+	}
+
+	prod.Clear()
+	if dev.Len() != 1 {
+		t.Fatalf("Expected prod Clear to leave dev's entries, got dev Len %d", dev.Len())
+	}
+}
+
+func TestRedisCache_EvictReasons(t *testing.T) {
+	reasons := make(map[string]EvictReason)
+	onEvict := func(key string, _ []byte, info EvictionInfo) {
+		reasons[key] = info.Reason
+	}
+
+	c := newTestRedisCacheWithConfig(t, 2, 100*time.Millisecond, onEvict)
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Set("c", []byte("3")) // overflows, evicting "a"
+	c.Delete("b")
+
+	time.Sleep(250 * time.Millisecond)
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("Expected 'c' to expire")
+	}
+
+	expected := map[string]EvictReason{"a": EvictReasonCapacity, "b": EvictReasonExplicit, "c": EvictReasonExpired}
+	for key, want := range expected {
+		if got, ok := reasons[key]; !ok || got != want {
+			t.Errorf("Expected reason %s for %s, got %s (reported=%v)", want, key, got, ok)
+		}
+	}
+}
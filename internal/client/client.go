@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
-	"net/url"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
@@ -16,15 +19,75 @@ import (
 
 // Client defines the interface for querying the SuperSubtitles website
 type Client interface {
-	CheckForUpdates(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error)
-	DownloadSubtitle(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error)
+	// CheckForUpdates counts the films and episodes added since contentID. Results are reused for
+	// client.update_check_ttl per content ID; forceRefresh skips the cached result.
+	CheckForUpdates(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error)
+	DownloadSubtitle(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error)
+	// DownloadEpisodeRangeAsZip extracts episodes start through end from a season pack and returns
+	// them as one ZIP archive, skipping episodes the pack does not contain. A positive maxBytes
+	// caps the download and the archive like models.DownloadOptions.MaxBytes.
+	DownloadEpisodeRangeAsZip(ctx context.Context, subtitleID string, start, end int, maxBytes int64) (*models.DownloadResult, error)
+	// EstimateDownload reports the size and type of a subtitle download without fetching its content.
+	EstimateDownload(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error)
+	// DownloadSubtitleByURL downloads from a full download link, which must point at the configured site.
+	DownloadSubtitleByURL(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error)
+	// ExportShowSubtitles writes the subtitle files of a show to w as one ZIP archive ending with a
+	// manifest.json that lists every file and each failed download.
+	ExportShowSubtitles(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error)
+	// GetLatestSubtitleID returns the newest subtitle ID on the recent listing, or 0 when it is empty.
+	GetLatestSubtitleID(ctx context.Context) (int, error)
+	// FindSubtitle returns the subtitles for one episode in a language (empty matches every language),
+	// answering from the in-memory index and fetching and indexing the show's subtitles on a miss.
+	FindSubtitle(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error)
+	// GetSubtitle returns one subtitle of a show by ID, from the FindSubtitle index or by fetching
+	// the show's subtitles. Unknown subtitle IDs return apperrors.ErrNotFound.
+	GetSubtitle(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error)
+	// GetSubtitleInfo returns one subtitle by ID without its show, from the recent uploads listing
+	// or, for older subtitles, its detail page. Unknown subtitle IDs return apperrors.ErrNotFound.
+	GetSubtitleInfo(ctx context.Context, subtitleID int) (*models.Subtitle, error)
+	// GetShowSeasons summarizes the seasons of a show that have subtitles, with episode counts per season.
+	GetShowSeasons(ctx context.Context, showID int) (*models.ShowSeasons, error)
+	// FindShow returns the show whose name or alias matches name, ignoring case and diacritics.
+	// A non-nil year disambiguates shows sharing a name; without it several matches return
+	// apperrors.ErrAmbiguousShow listing the candidates.
+	FindShow(ctx context.Context, name string, year *int) (*models.Show, error)
+	// GetSubtitleDetails returns the detail page of a subtitle: filename, uploader, the uploader's
+	// comment and the show's third-party IDs. Unknown subtitle IDs return apperrors.ErrNotFound.
+	GetSubtitleDetails(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error)
+
+	// Preload downloads the newest season packs of the given shows into the archive cache so that
+	// episode downloads after a cold start are cache hits. It is best-effort: failures are logged
+	// and skipped. Returns the number of archives downloaded.
+	Preload(ctx context.Context, showIDs []int) int
+
+	// InvalidateCache drops cached archives for a subtitle so the next download re-fetches it.
+	// Returns true if a cached entry existed.
+	InvalidateCache(subtitleID string) (bool, error)
+	// ClearCache drops every cached archive and returns the number of entries removed.
+	ClearCache() int
+	// UpstreamStatus reports the upstream mirror requests are sent to and the configured mirrors.
+	UpstreamStatus() models.UpstreamStatus
+	// SelfCheck fetches the listing of a known show and reports whether it still parses into
+	// plausible subtitles. It never fails; problems are described in the result.
+	SelfCheck(ctx context.Context) models.SelfCheckResult
+	// GetShowImage returns the poster of a show, cached for cache.image_ttl. Upstream 404 returns
+	// apperrors.ErrNotFound, and content that is not a JPEG, PNG or WebP image apperrors.ErrNotAnImage.
+	GetShowImage(ctx context.Context, showID int) (*models.ShowImage, error)
+	// ApplyConfig applies the dynamic settings of a reloaded configuration, such as the cache TTL.
+	ApplyConfig(cfg *config.Config)
 
 	// Streaming methods return channels that emit results as they become available.
 	// The channel is closed when all results have been sent.
 	// Errors are sent as StreamResult with a non-nil Err field.
-	StreamShowList(ctx context.Context) <-chan models.StreamResult[models.Show]
+	StreamShowList(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show]
 	StreamSubtitles(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle]
-	StreamShowSubtitles(ctx context.Context, shows []models.Show) <-chan models.StreamResult[models.ShowSubtitles]
+	// StreamSubtitlesInOrder sorts each page by order before sending it; pages keep listing order.
+	StreamSubtitlesInOrder(ctx context.Context, showID int, order models.SubtitleOrder) <-chan models.StreamResult[models.Subtitle]
+	// StreamMovieSubtitles streams the subtitles of a film, with Season and Episode set to -1.
+	StreamMovieSubtitles(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle]
+	// StreamShowSubtitles keeps only subtitles in languages when it is not empty, skipping shows left
+	// without any; see client.language_early_exit_pages for when it stops paginating such shows.
+	StreamShowSubtitles(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles]
 	StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles]
 
 	// Close releases any resources held by the client (e.g., cache connections).
@@ -33,13 +96,27 @@ type Client interface {
 
 // client implements the Client interface
 type client struct {
-	httpClient         *http.Client
-	baseURL            string
-	showParser         parser.PaginatedParser[models.Show]
-	thirdPartyParser   parser.SingleResultParser[models.ThirdPartyIds]
-	subtitleDownloader services.SubtitleDownloader
-	subtitleParser     *parser.SubtitleParser
-	baseTransport      *http.Transport // retained for testing / proxy verification
+	httpClient               *http.Client
+	baseURL                  string
+	showParser               parser.PaginatedParser[models.Show]
+	subtitleDetailsParser    parser.SingleResultParser[models.SubtitleDetails]
+	subtitleDownloader       services.SubtitleDownloader
+	subtitleIndex            services.SubtitleIndex
+	showImages               services.ShowImageFetcher
+	showDetails              *services.ShowDetailsCache
+	subtitleParser           *parser.SubtitleParser
+	baseTransport            *http.Transport // retained for testing / proxy verification
+	showSubtitlesConcurrency int             // maximum shows fetched at once by StreamShowSubtitles
+	perShowTimeout           time.Duration   // deadline for each show's fetch; zero disables it
+	hedgeDelay               time.Duration   // wait before a slow show list page is requested again in parallel; zero disables hedging
+	languageEarlyExitPages   int             // pages fetched before a language-filtered show without matches is abandoned; zero disables it
+	selfCheckShowID          int             // show whose listing SelfCheck parses
+	updateChecks             *updateCheckCache
+	uploaderFilter           atomic.Pointer[services.UploaderFilter] // drops subtitles of blocked or non-allowed uploaders; nil keeps all
+	blockedUploaders         []string                                // lists uploaderFilter was built from, compared by ApplyConfig
+	allowedUploaders         []string
+	mirrors                  *mirrorTransport // fails over between upstream mirrors and reports the active one
+	blocks                   blockTracker     // whether the site is serving block pages instead of listings
 }
 
 // NewClient creates a new client instance with proxy configuration if provided
@@ -49,7 +126,7 @@ func NewClient(cfg *config.Config) Client {
 	// Parse timeout duration
 	timeout := 30 * time.Second // default
 	if cfg.ClientTimeout != "" {
-		if parsedTimeout, err := time.ParseDuration(cfg.ClientTimeout); err != nil {
+		if parsedTimeout, err := config.ParseDuration("client_timeout", cfg.ClientTimeout); err != nil {
 			logger.Warn().Err(err).Str("timeout", cfg.ClientTimeout).Msg("Invalid timeout duration, using default 30s")
 		} else {
 			timeout = parsedTimeout
@@ -61,13 +138,13 @@ func NewClient(cfg *config.Config) Client {
 	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
 
 	if cfg.ProxyConnectionString != "" {
-		proxyURL, err := url.Parse(cfg.ProxyConnectionString)
+		proxyURL, err := config.ParseProxyURL(cfg.ProxyConnectionString)
+		if err == nil {
+			err = configureProxy(baseTransport, proxyURL, cfg.ProxyNoProxy)
+		}
 		if err != nil {
-			// Log error but continue without proxy
+			// Log error but continue with a direct connection
 			logger.Warn().Err(err).Str("proxy", cfg.ProxyConnectionString).Msg("Invalid proxy URL, continuing without proxy")
-		} else {
-			// Override only the Proxy field
-			baseTransport.Proxy = http.ProxyURL(proxyURL)
 		}
 	}
 
@@ -94,13 +171,13 @@ func NewClient(cfg *config.Config) Client {
 		})
 
 	if cfg.Retry.InitialDelay != "" {
-		initialDelay, err := time.ParseDuration(cfg.Retry.InitialDelay)
+		initialDelay, err := config.ParseDuration("retry.initial_delay", cfg.Retry.InitialDelay)
 		if err != nil {
 			logger.Warn().Err(err).Str("initial_delay", cfg.Retry.InitialDelay).Msg("Invalid retry initial delay, using no delay")
 		} else {
 			maxDelay := initialDelay
 			if cfg.Retry.MaxDelay != "" {
-				if parsedMax, err := time.ParseDuration(cfg.Retry.MaxDelay); err != nil {
+				if parsedMax, err := config.ParseDuration("retry.max_delay", cfg.Retry.MaxDelay); err != nil {
 					logger.Warn().Err(err).Str("max_delay", cfg.Retry.MaxDelay).Msg("Invalid retry max delay, using initial delay as max")
 				} else {
 					maxDelay = parsedMax
@@ -112,28 +189,126 @@ func NewClient(cfg *config.Config) Client {
 
 	retryPolicy := retryBuilder.Build()
 
-	// Wrap transport with compression support (gzip, brotli, zstd), then wrap the
-	// compression transport with the failsafe retry round-tripper so that every
-	// HTTP call made through httpClient is automatically retried on transient failures.
-	resilientTransport := failsafehttp.NewRoundTripper(newCompressionTransport(baseTransport), retryPolicy)
+	showSubtitlesConcurrency := cfg.Client.ShowSubtitlesConcurrency
+	if showSubtitlesConcurrency <= 0 {
+		showSubtitlesConcurrency = 4 // default
+	}
+
+	languageEarlyExitPages := cfg.Client.LanguageEarlyExitPages
+	if languageEarlyExitPages == 0 {
+		languageEarlyExitPages = defaultLanguageEarlyExitPages
+	} else if languageEarlyExitPages < 0 {
+		languageEarlyExitPages = 0
+	}
+
+	selfCheckShowID := cfg.Client.SelfCheckShowID
+	if selfCheckShowID <= 0 {
+		selfCheckShowID = defaultSelfCheckShowID
+	}
+
+	updateCheckTTL := defaultUpdateCheckTTL
+	if cfg.Client.UpdateCheckTTL != "" {
+		if parsedTTL, err := config.ParseDuration("client.update_check_ttl", cfg.Client.UpdateCheckTTL); err != nil {
+			logger.Warn().Err(err).Str("update_check_ttl", cfg.Client.UpdateCheckTTL).Msg("Invalid update check TTL, using default 60s")
+		} else {
+			updateCheckTTL = parsedTTL
+		}
+	}
+
+	perShowTimeout := defaultPerShowTimeout
+	if cfg.Client.PerShowTimeout != "" {
+		if parsedTimeout, err := config.ParseDuration("client.per_show_timeout", cfg.Client.PerShowTimeout); err != nil {
+			logger.Warn().Err(err).Str("per_show_timeout", cfg.Client.PerShowTimeout).Msg("Invalid per-show timeout, using default 30s")
+		} else {
+			perShowTimeout = parsedTimeout
+		}
+	}
+
+	mirrorCooldown := defaultMirrorCooldown
+	if cfg.Client.MirrorCooldown != "" {
+		if parsedCooldown, err := config.ParseDuration("client.mirror_cooldown", cfg.Client.MirrorCooldown); err != nil {
+			logger.Warn().Err(err).Str("mirror_cooldown", cfg.Client.MirrorCooldown).Msg("Invalid mirror cooldown, using default 5m")
+		} else {
+			mirrorCooldown = parsedCooldown
+		}
+	}
+
+	var hedgeDelay time.Duration
+	if cfg.Client.HedgeDelay != "" {
+		if parsedDelay, err := config.ParseDuration("client.hedge_delay", cfg.Client.HedgeDelay); err != nil {
+			logger.Warn().Err(err).Str("hedge_delay", cfg.Client.HedgeDelay).Msg("Invalid hedge delay, hedging disabled")
+		} else {
+			hedgeDelay = parsedDelay
+		}
+	}
+
+	// Wrap transport with compression support (gzip, brotli, zstd), then with mirror failover,
+	// then with the failsafe retry round-tripper so that every HTTP call made through httpClient
+	// is automatically retried on transient failures once every mirror has failed.
+	domains := cfg.UpstreamDomains()
+	mirrors := newMirrorTransport(newCompressionTransport(baseTransport), domains, mirrorCooldown)
+	resilientTransport := failsafehttp.NewRoundTripper(mirrors, retryPolicy)
 
 	httpClient := &http.Client{
 		Timeout:   timeout,
 		Transport: resilientTransport,
 	}
 
-	return &client{
-		httpClient:         httpClient,
-		baseURL:            cfg.SuperSubtitleDomain,
-		showParser:         parser.NewShowParser(cfg.SuperSubtitleDomain),
-		thirdPartyParser:   parser.NewThirdPartyIdParser(),
-		subtitleDownloader: services.NewSubtitleDownloader(httpClient),
-		subtitleParser:     parser.NewSubtitleParser(cfg.SuperSubtitleDomain),
-		baseTransport:      baseTransport,
+	c := &client{
+		httpClient:               httpClient,
+		baseURL:                  domains[0],
+		showParser:               parser.NewShowParser(domains[0]),
+		subtitleDetailsParser:    parser.NewSubtitleDetailsParser(),
+		subtitleDownloader:       services.NewSubtitleDownloader(httpClient),
+		subtitleIndex:            services.NewSubtitleIndex(cfg.Client.SubtitleIndexMaxShows),
+		showImages:               services.NewShowImageFetcher(httpClient, domains[0]),
+		showDetails:              services.NewShowDetailsCache(),
+		blockedUploaders:         cfg.Client.BlockedUploaders,
+		allowedUploaders:         cfg.Client.AllowedUploaders,
+		subtitleParser:           parser.NewSubtitleParser(domains[0]).WithTitleNormalization(cfg.TitleNormalization()),
+		baseTransport:            baseTransport,
+		mirrors:                  mirrors,
+		showSubtitlesConcurrency: showSubtitlesConcurrency,
+		perShowTimeout:           perShowTimeout,
+		hedgeDelay:               hedgeDelay,
+		languageEarlyExitPages:   languageEarlyExitPages,
+		selfCheckShowID:          selfCheckShowID,
+		updateChecks:             newUpdateCheckCache(updateCheckTTL),
 	}
+	c.uploaderFilter.Store(services.NewUploaderFilter(cfg.Client.BlockedUploaders, cfg.Client.AllowedUploaders))
+	return c
+}
+
+// ApplyConfig applies the dynamic settings of a reloaded configuration: the cache TTL goes to the
+// downloader, and changed uploader lists replace the uploader filter. The FindSubtitle index was
+// built with the old filter, so it is cleared and shows are fetched again on their next lookup.
+// Calls are serialized by config.Reload.
+func (c *client) ApplyConfig(cfg *config.Config) {
+	c.subtitleDownloader.ApplyConfig(cfg)
+
+	if slices.Equal(c.blockedUploaders, cfg.Client.BlockedUploaders) && slices.Equal(c.allowedUploaders, cfg.Client.AllowedUploaders) {
+		return
+	}
+	c.blockedUploaders = cfg.Client.BlockedUploaders
+	c.allowedUploaders = cfg.Client.AllowedUploaders
+	c.uploaderFilter.Store(services.NewUploaderFilter(c.blockedUploaders, c.allowedUploaders))
+	c.subtitleIndex.Clear()
+
+	logger := config.GetLogger()
+	logger.Info().
+		Strs("blocked_uploaders", c.blockedUploaders).
+		Strs("allowed_uploaders", c.allowedUploaders).
+		Msg("Uploader filter updated, subtitle index cleared")
+}
+
+// UpstreamStatus reports the active upstream mirror.
+func (c *client) UpstreamStatus() models.UpstreamStatus {
+	status := c.mirrors.Status()
+	c.blocks.apply(&status)
+	return status
 }
 
 // Close releases any resources held by the client, such as cache connections.
 func (c *client) Close() error {
-	return c.subtitleDownloader.Close()
+	return errors.Join(c.subtitleDownloader.Close(), c.showImages.Close(), c.showDetails.Close())
 }
@@ -1,14 +1,19 @@
 package client
 
 import (
+	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
 
 	"github.com/andybalholm/brotli"
@@ -58,7 +63,7 @@ func TestClient_GetShowList_WithGzipCompression(t *testing.T) {
 
 	client := NewClient(testConfig)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	if err != nil {
 		t.Fatalf("StreamShowList failed: %v", err)
@@ -114,7 +119,7 @@ func TestClient_GetShowList_WithBrotliCompression(t *testing.T) {
 
 	client := NewClient(testConfig)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	if err != nil {
 		t.Fatalf("StreamShowList failed: %v", err)
@@ -171,7 +176,7 @@ func TestClient_GetShowList_WithZstdCompression(t *testing.T) {
 
 	client := NewClient(testConfig)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	if err != nil {
 		t.Fatalf("StreamShowList failed: %v", err)
@@ -378,3 +383,53 @@ func TestClient_GetSubtitles_WithZstdCompression(t *testing.T) {
 		t.Errorf("Expected episode 5, got %d", subtitles.Subtitles[0].Episode)
 	}
 }
+
+// TestClient_DownloadSubtitle_WithCompression tests that downloads go through the compression
+// transport: a brotli-encoded ZIP is decoded before extraction, and a corrupt gzip body fails
+// with ErrMalformedEncoding instead of reaching the archive layer
+func TestClient_DownloadSubtitle_WithCompression(t *testing.T) {
+	t.Parallel()
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	fw, _ := zw.Create("show.s01e02.srt")
+	_, _ = fw.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nSecond episode\n"))
+	_ = zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "br") {
+			t.Errorf("Expected Accept-Encoding to contain 'br', got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		if r.URL.Query().Get("felirat") == "1" {
+			w.Header().Set("Content-Encoding", "br")
+			w.WriteHeader(http.StatusOK)
+			bw := brotli.NewWriter(w)
+			_, _ = bw.Write(archive.Bytes())
+			_ = bw.Close()
+			return
+		}
+		// A valid gzip header followed by garbage
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte{0x1f, 0x8b, 0x08, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff})
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	testConfig.Retry.MaxAttempts = 1
+	client := NewClient(testConfig)
+	defer client.Close()
+
+	result, err := client.DownloadSubtitle(context.Background(), "1", models.DownloadOptions{Episode: new(2)})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(string(result.Content), "Second episode") {
+		t.Errorf("Expected the episode extracted from the brotli-encoded ZIP, got %q", result.Content)
+	}
+
+	_, err = client.DownloadSubtitle(context.Background(), "2", models.DownloadOptions{Episode: new(2)})
+	if !errors.Is(err, &apperrors.ErrMalformedEncoding{}) {
+		t.Errorf("Expected ErrMalformedEncoding, got %v", err)
+	}
+}
@@ -102,7 +102,7 @@ func TestClient_StreamShowSubtitles_ThirdPartyIdsServerError(t *testing.T) {
 	ctx := context.Background()
 
 	shows := []models.Show{{Name: "Test Show", ID: 123}}
-	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows))
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows, nil))
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -121,10 +121,12 @@ func TestClient_StreamShowSubtitles_ThirdPartyIdsServerError(t *testing.T) {
 	}
 }
 
-func TestClient_StreamShowSubtitles_InvalidSubtitleIDSkipped(t *testing.T) {
+func TestClient_StreamShowSubtitles_NonNumericSubtitleIDKept(t *testing.T) {
 	t.Parallel()
+	var detailSubtitleID atomic.Value
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("tipus") == "adatlap" {
+			detailSubtitleID.Store(r.URL.Query().Get("azon"))
 			html := testutil.GenerateThirdPartyIDHTML("", 0, 0, 0)
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte(html))
@@ -132,11 +134,10 @@ func TestClient_StreamShowSubtitles_InvalidSubtitleIDSkipped(t *testing.T) {
 		}
 		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
 			{
-				SubtitleID:       -1,
-				ShowID:           123,
-				MagyarTitle:      "Invalid Sub",
-				EredetiTitle:     "Test Show - 1x01 - Invalid Episode (720p-Grp)",
-				DownloadFilename: "invalid.srt",
+				ShowID:             123,
+				MagyarTitle:        "Token Sub",
+				EredetiTitle:       "Test Show - 1x01 - Token Episode (720p-Grp)",
+				CustomDownloadHref: "/index.php?action=letolt&fnev=token.srt&felirat=abc123",
 			},
 			{
 				SubtitleID:       1770600001,
@@ -159,21 +160,38 @@ func TestClient_StreamShowSubtitles_InvalidSubtitleIDSkipped(t *testing.T) {
 	ctx := context.Background()
 
 	shows := []models.Show{{Name: "Test Show", ID: 123}}
-	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows))
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows, nil))
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 	if len(showSubtitles) != 1 {
 		t.Fatalf("Expected 1 show, got %d", len(showSubtitles))
 	}
-	if showSubtitles[0].SubtitleCollection.Total != 1 {
-		t.Errorf("Expected 1 subtitle (invalid filtered out), got %d", showSubtitles[0].SubtitleCollection.Total)
+	subtitles := showSubtitles[0].SubtitleCollection.Subtitles
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles (non-numeric ID kept), got %d", len(subtitles))
+	}
+	if !subtitles[0].IDIsSynthetic || !models.IsSyntheticSubtitleID(subtitles[0].ID) {
+		t.Errorf("Expected synthetic ID for non-numeric download token, got ID %d (synthetic=%v)", subtitles[0].ID, subtitles[0].IDIsSynthetic)
+	}
+	if subtitles[1].IDIsSynthetic {
+		t.Errorf("Expected numeric ID %d not to be synthetic", subtitles[1].ID)
+	}
+	// Third-party IDs must be looked up with the real felirat ID, not the synthetic one
+	if got, _ := detailSubtitleID.Load().(string); got != "a_1770600001" {
+		t.Errorf("Expected detail page fetched for subtitle a_1770600001, got %q", got)
 	}
 }
 
-func TestClient_StreamShowSubtitles_NoValidSubtitleIDs(t *testing.T) {
+func TestClient_StreamShowSubtitles_NoNumericSubtitleIDs(t *testing.T) {
 	t.Parallel()
+	var detailPageFetched atomic.Bool
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			detailPageFetched.Store(true)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
 			{
 				SubtitleID:       -1,
@@ -183,11 +201,10 @@ func TestClient_StreamShowSubtitles_NoValidSubtitleIDs(t *testing.T) {
 				DownloadFilename: "invalid1.srt",
 			},
 			{
-				SubtitleID:       -2,
-				ShowID:           123,
-				MagyarTitle:      "Invalid Sub 2",
-				EredetiTitle:     "Test Show - 1x02 - Episode Two (720p-Grp)",
-				DownloadFilename: "invalid2.srt",
+				ShowID:             123,
+				MagyarTitle:        "Token Sub 2",
+				EredetiTitle:       "Test Show - 1x02 - Episode Two (720p-Grp)",
+				CustomDownloadHref: "/index.php?action=letolt&fnev=tokenb.srt",
 			},
 		})
 		w.WriteHeader(http.StatusOK)
@@ -203,15 +220,23 @@ func TestClient_StreamShowSubtitles_NoValidSubtitleIDs(t *testing.T) {
 	ctx := context.Background()
 
 	shows := []models.Show{{Name: "Test Show", ID: 123}}
-	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows))
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows, nil))
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 	if len(showSubtitles) != 1 {
 		t.Fatalf("Expected 1 show, got %d", len(showSubtitles))
 	}
-	if showSubtitles[0].SubtitleCollection.Total != 0 {
-		t.Errorf("Expected 0 subtitles (all invalid), got %d", showSubtitles[0].SubtitleCollection.Total)
+	if showSubtitles[0].SubtitleCollection.Total != 2 {
+		t.Errorf("Expected 2 subtitles with synthetic IDs, got %d", showSubtitles[0].SubtitleCollection.Total)
+	}
+	for _, subtitle := range showSubtitles[0].SubtitleCollection.Subtitles {
+		if !subtitle.IDIsSynthetic {
+			t.Errorf("Expected subtitle %q to have a synthetic ID, got %d", subtitle.Filename, subtitle.ID)
+		}
+	}
+	if detailPageFetched.Load() {
+		t.Error("Expected no detail page fetch without a numeric subtitle ID")
 	}
 	if showSubtitles[0].ThirdPartyIds.IMDBID != "" {
 		t.Errorf("Expected empty IMDB ID, got %s", showSubtitles[0].ThirdPartyIds.IMDBID)
@@ -329,7 +354,7 @@ func TestClient_NewClient_InvalidTimeout(t *testing.T) {
 	defer c.Close()
 
 	// Verify the client is functional by making a request
-	result, err := c.CheckForUpdates(context.Background(), 1)
+	result, err := c.CheckForUpdates(context.Background(), 1, false)
 	if err != nil {
 		t.Fatalf("Expected client to work with default timeout, got: %v", err)
 	}
@@ -398,7 +423,7 @@ func TestClient_DownloadSubtitle_InvalidBaseURL(t *testing.T) {
 	c := &client{
 		baseURL: "://",
 	}
-	_, err := c.DownloadSubtitle(context.Background(), "123", nil)
+	_, err := c.DownloadSubtitle(context.Background(), "123", models.DownloadOptions{})
 	if err == nil {
 		t.Fatal("Expected error for invalid base URL in DownloadSubtitle")
 	}
@@ -520,7 +545,7 @@ func TestClient_StreamShowList_AllEndpointsFail(t *testing.T) {
 	c := NewClient(testConfig)
 	ctx := context.Background()
 
-	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
 	if err == nil {
 		t.Fatal("Expected error when all endpoints fail")
 	}
@@ -555,7 +580,7 @@ func TestClient_StreamShowList_PartialEndpointFailure(t *testing.T) {
 	c := NewClient(testConfig)
 	ctx := context.Background()
 
-	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
 	if err != nil {
 		t.Fatalf("Expected partial success (no error), got: %v", err)
 	}
@@ -584,7 +609,7 @@ func TestClient_CheckForUpdates_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	_, err := c.CheckForUpdates(ctx, 1)
+	_, err := c.CheckForUpdates(ctx, 1, false)
 	if err == nil {
 		t.Fatal("Expected error with cancelled context")
 	}
@@ -661,7 +686,7 @@ func TestClient_StreamShowSubtitles_SubtitleStreamError(t *testing.T) {
 	ctx := context.Background()
 
 	shows := []models.Show{{Name: "Failing Show", ID: 999}}
-	_, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows))
+	_, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, shows, nil))
 	if err == nil {
 		t.Fatal("Expected error when all shows fail")
 	}
@@ -743,7 +768,7 @@ func TestClient_StreamShowList_ContextCancelledBetweenBatches(t *testing.T) {
 	}
 	c := NewClient(testConfig)
 
-	ch := c.StreamShowList(ctx)
+	ch := c.StreamShowList(ctx, 0)
 	var count int
 	for range ch {
 		count++
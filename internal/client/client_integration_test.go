@@ -36,7 +36,7 @@ func TestClient_GetShowList_Integration(t *testing.T) {
 
 	// Call GetShowList with the real website
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call succeeds
 	if err != nil {
@@ -200,7 +200,7 @@ func TestClient_GetShowSubtitles_Integration(t *testing.T) {
 
 	// First get a list of shows to pick from
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 	if err != nil {
 		t.Fatalf("Integration test failed: GetShowList returned error: %v", err)
 	}
@@ -214,7 +214,7 @@ func TestClient_GetShowSubtitles_Integration(t *testing.T) {
 	t.Logf("Testing GetShowSubtitles with show: ID=%d, Name=%s", testShow.ID, testShow.Name)
 
 	// Call StreamShowSubtitles with the test show
-	showSubtitlesList, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, []models.Show{testShow}))
+	showSubtitlesList, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, []models.Show{testShow}, nil))
 
 	// Test that the call succeeds
 	if err != nil {
@@ -1,17 +1,30 @@
 package client
 
 import (
+	"bufio"
+	"compress/flate"
 	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/andybalholm/brotli"
 	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// compressionTransport wraps an http.RoundTripper to automatically handle
-// response decompression for gzip, brotli, and zstd encodings
+// acceptEncoding lists the content encodings compressionTransport decodes
+const acceptEncoding = "gzip, deflate, br, zstd"
+
+// compressionTransport wraps an http.RoundTripper to automatically handle response
+// decompression for gzip, deflate, brotli, and zstd encodings. Body sizes before and after
+// decompression are counted per encoding, and a body that does not decode returns
+// apperrors.ErrMalformedEncoding instead of undecoded bytes.
 type compressionTransport struct {
 	transport http.RoundTripper
 }
@@ -32,7 +45,7 @@ func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, err
 
 	// Add Accept-Encoding header to indicate supported compression formats
 	if req.Header.Get("Accept-Encoding") == "" {
-		req.Header.Set("Accept-Encoding", "gzip, br, zstd")
+		req.Header.Set("Accept-Encoding", acceptEncoding)
 	}
 
 	// Execute the request
@@ -49,56 +62,129 @@ func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, err
 	// Decompress response body based on Content-Encoding header
 	// Parse the Content-Encoding header to handle comma-separated lists and whitespace
 	encoding := parseContentEncoding(resp.Header.Get("Content-Encoding"))
-	if encoding == "" {
-		return resp, nil
-	}
+	wire := &wireReader{body: resp.Body}
 
 	var reader io.ReadCloser
 	switch encoding {
+	case "", "identity":
+		// Uncompressed bodies, including from servers that ignore Accept-Encoding, are only counted
+		metrics.UpstreamCompressionTotal.WithLabelValues("identity").Inc()
+		resp.Body = newDecompressReadCloser("identity", io.NopCloser(wire), wire, resp.Body)
+		return resp, nil
 	case "gzip":
-		reader, err = gzip.NewReader(resp.Body)
-		if err != nil {
-			resp.Body.Close()
-			return nil, err
-		}
+		reader, err = gzip.NewReader(wire)
+	case "deflate":
+		reader, err = newDeflateReader(wire)
 	case "br":
-		reader = io.NopCloser(brotli.NewReader(resp.Body))
+		reader = io.NopCloser(brotli.NewReader(wire))
 	case "zstd":
-		zr, err := zstd.NewReader(resp.Body)
-		if err != nil {
-			resp.Body.Close()
-			return nil, err
+		var zr *zstd.Decoder
+		zr, err = zstd.NewReader(wire)
+		if err == nil {
+			reader = zr.IOReadCloser()
 		}
-		reader = zr.IOReadCloser()
 	default:
 		// Unknown encoding, return response as-is
+		metrics.UpstreamCompressionTotal.WithLabelValues("other").Inc()
 		return resp, nil
 	}
+	metrics.UpstreamCompressionTotal.WithLabelValues(encoding).Inc()
+	if err != nil {
+		resp.Body.Close()
+		return nil, decodeError(encoding, err, wire)
+	}
 
 	// Wrap the reader to close both the decompressor and original body
-	resp.Body = &decompressReadCloser{
-		reader:       reader,
-		originalBody: resp.Body,
-	}
+	resp.Body = newDecompressReadCloser(encoding, reader, wire, resp.Body)
 
 	// Remove Content-Encoding header since we've decompressed
 	resp.Header.Del("Content-Encoding")
 	// Remove Content-Length as it's no longer valid after decompression
 	resp.Header.Del("Content-Length")
 	resp.ContentLength = -1
+	// Byte offsets into the body no longer match the wire, which rules out Range requests
+	resp.Uncompressed = true
 
 	return resp, nil
 }
 
+// newDeflateReader decodes a deflate body. HTTP defines deflate as a zlib stream, but some
+// servers send raw DEFLATE data, so the zlib header is checked before choosing the decoder.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+// decodeError returns err as an ErrMalformedEncoding, unless it came from reading the body
+// itself, such as a dropped connection or a cancelled request.
+func decodeError(encoding string, err error, wire *wireReader) error {
+	if wire.err != nil && errors.Is(err, wire.err) {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &apperrors.ErrMalformedEncoding{Encoding: encoding, Err: err}
+}
+
+// wireReader counts the bytes read from a response body as received and keeps the last error
+// it returned, so body errors can be told apart from decompressor errors.
+type wireReader struct {
+	body io.Reader
+	n    int64
+	err  error
+}
+
+func (w *wireReader) Read(p []byte) (int, error) {
+	n, err := w.body.Read(p)
+	w.n += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		w.err = err
+	}
+	return n, err
+}
+
 // decompressReadCloser wraps a decompressor reader and ensures both
 // the decompressor and the original body are closed
 type decompressReadCloser struct {
 	reader       io.ReadCloser
 	originalBody io.ReadCloser
+	encoding     string
+	wire         *wireReader
+	wireCounted  int64
+	compressed   prometheus.Counter
+	decompressed prometheus.Counter
+}
+
+func newDecompressReadCloser(encoding string, reader io.ReadCloser, wire *wireReader, originalBody io.ReadCloser) *decompressReadCloser {
+	return &decompressReadCloser{
+		reader:       reader,
+		originalBody: originalBody,
+		encoding:     encoding,
+		wire:         wire,
+		compressed:   metrics.UpstreamCompressedBytesTotal.WithLabelValues(encoding),
+		decompressed: metrics.UpstreamDecompressedBytesTotal.WithLabelValues(encoding),
+	}
 }
 
 func (d *decompressReadCloser) Read(p []byte) (int, error) {
-	return d.reader.Read(p)
+	n, err := d.reader.Read(p)
+	if read := d.wire.n - d.wireCounted; read > 0 {
+		d.compressed.Add(float64(read))
+		d.wireCounted = d.wire.n
+	}
+	d.decompressed.Add(float64(n))
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = decodeError(d.encoding, err, d.wire)
+	}
+	return n, err
 }
 
 func (d *decompressReadCloser) Close() error {
@@ -2,14 +2,20 @@ package client
 
 import (
 	"bytes"
+	"compress/flate"
 	"compress/gzip"
+	"compress/zlib"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/andybalholm/brotli"
 	"github.com/klauspost/compress/zstd"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestCompressionTransport_Gzip(t *testing.T) {
@@ -18,8 +24,8 @@ func TestCompressionTransport_Gzip(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify Accept-Encoding header was set
-		if r.Header.Get("Accept-Encoding") != "gzip, br, zstd" {
-			t.Errorf("Expected Accept-Encoding header to be 'gzip, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip, deflate, br, zstd" {
+			t.Errorf("Expected Accept-Encoding header to be 'gzip, deflate, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
 		}
 
 		w.Header().Set("Content-Encoding", "gzip")
@@ -56,6 +62,9 @@ func TestCompressionTransport_Gzip(t *testing.T) {
 	if resp.Header.Get("Content-Encoding") != "" {
 		t.Errorf("Expected Content-Encoding header to be removed, got %q", resp.Header.Get("Content-Encoding"))
 	}
+	if !resp.Uncompressed {
+		t.Error("Expected the response to be marked as decompressed")
+	}
 }
 
 func TestCompressionTransport_Brotli(t *testing.T) {
@@ -64,8 +73,8 @@ func TestCompressionTransport_Brotli(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify Accept-Encoding header was set
-		if r.Header.Get("Accept-Encoding") != "gzip, br, zstd" {
-			t.Errorf("Expected Accept-Encoding header to be 'gzip, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip, deflate, br, zstd" {
+			t.Errorf("Expected Accept-Encoding header to be 'gzip, deflate, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
 		}
 
 		w.Header().Set("Content-Encoding", "br")
@@ -110,8 +119,8 @@ func TestCompressionTransport_Zstd(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify Accept-Encoding header was set
-		if r.Header.Get("Accept-Encoding") != "gzip, br, zstd" {
-			t.Errorf("Expected Accept-Encoding header to be 'gzip, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip, deflate, br, zstd" {
+			t.Errorf("Expected Accept-Encoding header to be 'gzip, deflate, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
 		}
 
 		w.Header().Set("Content-Encoding", "zstd")
@@ -157,8 +166,8 @@ func TestCompressionTransport_NoCompression(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify Accept-Encoding header was set
-		if r.Header.Get("Accept-Encoding") != "gzip, br, zstd" {
-			t.Errorf("Expected Accept-Encoding header to be 'gzip, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip, deflate, br, zstd" {
+			t.Errorf("Expected Accept-Encoding header to be 'gzip, deflate, br, zstd', got %q", r.Header.Get("Accept-Encoding"))
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -186,6 +195,9 @@ func TestCompressionTransport_NoCompression(t *testing.T) {
 	if !bytes.Equal(body, testData) {
 		t.Errorf("Expected body %q, got %q", testData, body)
 	}
+	if resp.Uncompressed {
+		t.Error("Expected an identity response not to be marked as decompressed")
+	}
 }
 
 func TestCompressionTransport_PreserveExistingAcceptEncoding(t *testing.T) {
@@ -402,3 +414,179 @@ func TestParseContentEncoding(t *testing.T) {
 		})
 	}
 }
+
+func TestCompressionTransport_Deflate(t *testing.T) {
+	t.Parallel()
+	testData := []byte("This is test data that should be compressed with deflate")
+
+	var zlibBody bytes.Buffer
+	zw := zlib.NewWriter(&zlibBody)
+	_, _ = zw.Write(testData)
+	_ = zw.Close()
+	var rawBody bytes.Buffer
+	fw, _ := flate.NewWriter(&rawBody, flate.DefaultCompression)
+	_, _ = fw.Write(testData)
+	_ = fw.Close()
+
+	// HTTP deflate is zlib-wrapped, but some servers send a raw DEFLATE stream
+	for name, body := range map[string][]byte{"zlib": zlibBody.Bytes(), "raw": rawBody.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "deflate")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newCompressionTransport(nil)}
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+			if !bytes.Equal(got, testData) {
+				t.Errorf("Expected body %q, got %q", testData, got)
+			}
+		})
+	}
+}
+
+func TestCompressionTransport_MalformedBody(t *testing.T) {
+	t.Parallel()
+	var gzBody bytes.Buffer
+	gw := gzip.NewWriter(&gzBody)
+	_, _ = gw.Write(bytes.Repeat([]byte("subtitle line\n"), 100))
+	_ = gw.Close()
+	corruptGzip := gzBody.Bytes()
+	corruptGzip = append(append([]byte(nil), corruptGzip[:20]...), bytes.Repeat([]byte{0xff}, 40)...)
+
+	tests := []struct {
+		encoding string
+		body     []byte
+	}{
+		{encoding: "gzip", body: []byte("plain text, not gzip")},
+		{encoding: "gzip", body: corruptGzip},
+		{encoding: "deflate", body: []byte{0xff, 0xff, 0xff, 0xff}},
+		{encoding: "br", body: []byte("plain text, not brotli")},
+		{encoding: "zstd", body: []byte("plain text, not zstd")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", tt.encoding)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newCompressionTransport(nil)}
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				defer resp.Body.Close()
+				_, err = io.ReadAll(resp.Body)
+			}
+
+			var malformed *apperrors.ErrMalformedEncoding
+			if !errors.As(err, &malformed) {
+				t.Fatalf("Expected ErrMalformedEncoding, got %v", err)
+			}
+			if malformed.Encoding != tt.encoding {
+				t.Errorf("Expected encoding %q, got %q", tt.encoding, malformed.Encoding)
+			}
+		})
+	}
+}
+
+// TestCompressionTransport_ByteCounters is not parallel: it reads the shared deflate counters
+func TestCompressionTransport_ByteCounters(t *testing.T) {
+	testData := bytes.Repeat([]byte("compressible subtitle line\n"), 200)
+	var body bytes.Buffer
+	zw := zlib.NewWriter(&body)
+	_, _ = zw.Write(testData)
+	_ = zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body.Bytes())
+	}))
+	defer server.Close()
+
+	compressedBefore := promtestutil.ToFloat64(metrics.UpstreamCompressedBytesTotal.WithLabelValues("deflate"))
+	decompressedBefore := promtestutil.ToFloat64(metrics.UpstreamDecompressedBytesTotal.WithLabelValues("deflate"))
+
+	client := &http.Client{Transport: newCompressionTransport(nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := promtestutil.ToFloat64(metrics.UpstreamCompressedBytesTotal.WithLabelValues("deflate")) - compressedBefore; got != float64(body.Len()) {
+		t.Errorf("Expected %d compressed bytes counted, got %.0f", body.Len(), got)
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamDecompressedBytesTotal.WithLabelValues("deflate")) - decompressedBefore; got != float64(len(testData)) {
+		t.Errorf("Expected %d decompressed bytes counted, got %.0f", len(testData), got)
+	}
+}
+
+// TestCompressionTransport_NegotiatedEncodingCounter is not parallel: it reads the shared
+// negotiated encoding counter
+func TestCompressionTransport_NegotiatedEncodingCounter(t *testing.T) {
+	testData := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ignore") != "" {
+			// Ignores Accept-Encoding and answers uncompressed
+			_, _ = w.Write(testData)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gzWriter := gzip.NewWriter(w)
+		_, _ = gzWriter.Write(testData)
+		_ = gzWriter.Close()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newCompressionTransport(nil)}
+	get := func(url string) {
+		t.Helper()
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil || !bytes.Equal(body, testData) {
+			t.Fatalf("Expected the decoded body, got %q, %v", body, err)
+		}
+	}
+
+	gzipBefore := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("gzip"))
+	identityBefore := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("identity"))
+
+	get(server.URL)
+	if got := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("gzip")) - gzipBefore; got != 1 {
+		t.Errorf("Expected the gzip response to be counted once, got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("identity")) - identityBefore; got != 0 {
+		t.Errorf("Expected no identity response, got %.0f", got)
+	}
+
+	get(server.URL + "?ignore=1")
+	if got := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("identity")) - identityBefore; got != 1 {
+		t.Errorf("Expected the response that ignored Accept-Encoding to count as identity, got %.0f", got)
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamCompressionTotal.WithLabelValues("gzip")) - gzipBefore; got != 1 {
+		t.Errorf("Expected the gzip count to stay at 1, got %.0f", got)
+	}
+}
@@ -6,19 +6,68 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 // DownloadSubtitle downloads a subtitle file, with support for extracting specific episodes from season packs.
 // The download URL is derived from the subtitle ID.
-// If episode is nil, the entire file is returned without extraction.
-func (c *client) DownloadSubtitle(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
+// If opts selects no episode, the entire file is returned without extraction.
+func (c *client) DownloadSubtitle(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
 	downloadURL, err := c.buildDownloadURL(subtitleID)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.subtitleDownloader.DownloadSubtitle(ctx, downloadURL, episode)
+	return c.subtitleDownloader.DownloadSubtitle(ctx, downloadURL, opts)
+}
+
+// DownloadEpisodeRangeAsZip returns episodes start through end of the season pack identified by
+// subtitleID as one ZIP archive, within maxBytes when it is positive.
+func (c *client) DownloadEpisodeRangeAsZip(ctx context.Context, subtitleID string, start, end int, maxBytes int64) (*models.DownloadResult, error) {
+	downloadURL, err := c.buildDownloadURL(subtitleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.subtitleDownloader.DownloadEpisodeRangeAsZip(ctx, downloadURL, start, end, maxBytes)
+}
+
+// EstimateDownload reports the filename, type and size of the subtitle identified by subtitleID
+// without downloading its content.
+func (c *client) EstimateDownload(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error) {
+	downloadURL, err := c.buildDownloadURL(subtitleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.subtitleDownloader.EstimateDownload(ctx, downloadURL)
+}
+
+// DownloadSubtitleByURL downloads a subtitle from a full download link, such as one copied from the website.
+// The link must use http or https and point at the configured site, so callers cannot make the service
+// fetch arbitrary hosts.
+func (c *client) DownloadSubtitleByURL(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+	if err := c.validateDownloadURL(downloadURL); err != nil {
+		return nil, err
+	}
+
+	return c.subtitleDownloader.DownloadSubtitle(ctx, downloadURL, opts)
+}
+
+// InvalidateCache removes cached archives for the subtitle identified by subtitleID.
+func (c *client) InvalidateCache(subtitleID string) (bool, error) {
+	downloadURL, err := c.buildDownloadURL(subtitleID)
+	if err != nil {
+		return false, err
+	}
+
+	return c.subtitleDownloader.InvalidateCache(downloadURL), nil
+}
+
+// ClearCache removes every cached archive.
+func (c *client) ClearCache() int {
+	return c.subtitleDownloader.ClearCache()
 }
 
 func (c *client) buildDownloadURL(subtitleID string) (string, error) {
@@ -35,3 +84,28 @@ func (c *client) buildDownloadURL(subtitleID string) (string, error) {
 
 	return baseURL.String(), nil
 }
+
+// validateDownloadURL checks that downloadURL is an absolute http(s) URL on the host of the configured
+// site or one of its mirrors.
+func (c *client) validateDownloadURL(downloadURL string) error {
+	baseURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	parsed, err := url.Parse(downloadURL)
+	if err != nil {
+		return &apperrors.ErrInvalidDownloadURL{URL: downloadURL, Reason: "not a valid URL"}
+	}
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return &apperrors.ErrInvalidDownloadURL{URL: downloadURL, Reason: "scheme must be http or https"}
+	}
+	if parsed.User != nil {
+		return &apperrors.ErrInvalidDownloadURL{URL: downloadURL, Reason: "credentials are not allowed"}
+	}
+	if !strings.EqualFold(parsed.Host, baseURL.Host) && (c.mirrors == nil || c.mirrors.match(parsed) < 0) {
+		return &apperrors.ErrInvalidDownloadURL{URL: downloadURL, Reason: fmt.Sprintf("host must be %s", baseURL.Host)}
+	}
+
+	return nil
+}
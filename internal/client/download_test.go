@@ -2,11 +2,16 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 func TestClient_DownloadSubtitle(t *testing.T) {
@@ -40,7 +45,7 @@ func TestClient_DownloadSubtitle(t *testing.T) {
 	client := NewClient(testConfig)
 	ctx := context.Background()
 
-	result, err := client.DownloadSubtitle(ctx, expectedSubtitleID, nil)
+	result, err := client.DownloadSubtitle(ctx, expectedSubtitleID, models.DownloadOptions{})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -60,3 +65,64 @@ func TestClient_DownloadSubtitle(t *testing.T) {
 		t.Error("Expected Filename to be set")
 	}
 }
+
+func TestClient_DownloadSubtitleByURL(t *testing.T) {
+	t.Parallel()
+	subtitleContent := "1\n00:00:01,000 --> 00:00:02,000\nTest subtitle line\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtitleID := r.URL.Query().Get("felirat"); subtitleID != "1700000000" {
+			t.Errorf("Expected subtitle ID '1700000000', got '%s'", subtitleID)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(subtitleContent))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	downloadURL := strings.ToUpper(server.URL[:4]) + server.URL[4:] + "/index.php?action=letolt&fnev=show.srt&felirat=1700000000"
+
+	result, err := client.DownloadSubtitleByURL(context.Background(), downloadURL, models.DownloadOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != subtitleContent {
+		t.Errorf("Expected content %q, got %q", subtitleContent, string(result.Content))
+	}
+}
+
+func TestClient_DownloadSubtitleByURL_RejectsOtherHosts(t *testing.T) {
+	t.Parallel()
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"external host", "https://evil.example/index.php?action=letolt&felirat=1"},
+		{"host as user info", "http://" + host + "@evil.example/index.php"},
+		{"different port", "http://127.0.0.1:1/index.php"},
+		{"file scheme", "file:///etc/passwd"},
+		{"relative URL", "/index.php?action=letolt&felirat=1"},
+		{"unparseable", "http://%zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.DownloadSubtitleByURL(context.Background(), tt.url, models.DownloadOptions{})
+			if !errors.Is(err, &apperrors.ErrInvalidDownloadURL{}) {
+				t.Errorf("Expected ErrInvalidDownloadURL, got: %v", err)
+			}
+		})
+	}
+	if requested.Load() {
+		t.Error("Expected no request to reach the configured site")
+	}
+}
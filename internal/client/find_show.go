@@ -0,0 +1,54 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/services"
+)
+
+// FindShow looks a show up by name in the show list, ignoring case and diacritics, and returns the
+// single match. When year is non-nil only shows from that year are considered. It returns
+// apperrors.ErrNotFound when nothing matches and apperrors.ErrAmbiguousShow, listing the
+// candidates ordered by year, when several shows share the name.
+func (c *client) FindShow(ctx context.Context, name string, year *int) (*models.Show, error) {
+	logger := config.GetLogger()
+
+	var shows []models.Show
+	for result := range c.StreamShowList(ctx, 0) {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to fetch show list: %w", result.Err)
+		}
+		shows = append(shows, result.Value)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	matches := services.MatchShows(shows, name, year)
+	logger.Debug().Str("name", name).Int("shows", len(shows)).Int("matches", len(matches)).Msg("Matched show name against show list")
+
+	switch len(matches) {
+	case 0:
+		if year != nil {
+			return nil, &apperrors.ErrNotFound{Resource: fmt.Sprintf("show %q from %d", name, *year)}
+		}
+		return nil, &apperrors.ErrNotFound{Resource: fmt.Sprintf("show %q", name)}
+	case 1:
+		return &matches[0], nil
+	}
+
+	// The show list streams endpoints concurrently, so order the candidates for a stable message
+	slices.SortFunc(matches, func(a, b models.Show) int {
+		if byYear := cmp.Compare(a.Year, b.Year); byYear != 0 {
+			return byYear
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return nil, &apperrors.ErrAmbiguousShow{Name: name, Candidates: matches}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func newFindShowTestClient(t *testing.T) Client {
+	t.Helper()
+	waitingHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 2012, ShowName: "Dallas", Year: 2012},
+		{ShowID: 1978, ShowName: "Dallas", Year: 1978},
+	})
+	underHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 5001, ShowName: "Szívek szállodája", Year: 2000},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("sorf") {
+		case "varakozik-subrip":
+			_, _ = w.Write([]byte(waitingHTML))
+		case "alatt-subrip":
+			_, _ = w.Write([]byte(underHTML))
+		default:
+			_, _ = w.Write([]byte(testutil.GenerateShowTableHTML(nil)))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClient_FindShow_DisambiguatesByYear(t *testing.T) {
+	t.Parallel()
+	c := newFindShowTestClient(t)
+
+	for _, year := range []int{1978, 2012} {
+		show, err := c.FindShow(context.Background(), "dallas", new(year))
+		if err != nil {
+			t.Fatalf("Expected no error for year %d, got: %v", year, err)
+		}
+		if show.ID != year || show.Year != year {
+			t.Errorf("Expected Dallas (%d), got ID %d from %d", year, show.ID, show.Year)
+		}
+	}
+}
+
+func TestClient_FindShow_AmbiguousWithoutYear(t *testing.T) {
+	t.Parallel()
+	c := newFindShowTestClient(t)
+
+	_, err := c.FindShow(context.Background(), "Dallas", nil)
+	var ambiguous *apperrors.ErrAmbiguousShow
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Expected ErrAmbiguousShow, got: %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0].Year != 1978 || ambiguous.Candidates[1].Year != 2012 {
+		t.Errorf("Expected both Dallas shows ordered by year, got %+v", ambiguous.Candidates)
+	}
+}
+
+func TestClient_FindShow_AccentInsensitive(t *testing.T) {
+	t.Parallel()
+	c := newFindShowTestClient(t)
+
+	show, err := c.FindShow(context.Background(), "SZIVEK szallodaja", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if show.ID != 5001 {
+		t.Errorf("Expected show 5001, got %d", show.ID)
+	}
+}
+
+func TestClient_FindShow_NotFound(t *testing.T) {
+	t.Parallel()
+	c := newFindShowTestClient(t)
+
+	if _, err := c.FindShow(context.Background(), "Dallas", new(1999)); !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound for a year without a match, got: %v", err)
+	}
+	if _, err := c.FindShow(context.Background(), "Knots Landing", nil); !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound for an unknown show, got: %v", err)
+	}
+}
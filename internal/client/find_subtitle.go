@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// FindSubtitle returns the subtitles for one episode, episode subtitles first and then season packs
+// covering it. Shows already indexed (by an earlier FindSubtitle or StreamShowSubtitles) are answered
+// from memory. On a miss all of the show's subtitles are fetched and indexed; a failed fetch is
+// returned as an error and indexes nothing, so a partial listing never hides subtitles later.
+func (c *client) FindSubtitle(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error) {
+	logger := config.GetLogger()
+
+	if subtitles, ok := c.subtitleIndex.Lookup(showID, season, episode, language); ok {
+		logger.Debug().Int("showID", showID).Int("season", season).Int("episode", episode).Str("language", language).
+			Int("matches", len(subtitles)).Msg("Subtitle index hit")
+		return subtitles, nil
+	}
+
+	indexed, err := c.indexShow(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	matches, _ := c.subtitleIndex.Lookup(showID, season, episode, language)
+	logger.Debug().Int("showID", showID).Int("indexed", indexed).Int("matches", len(matches)).Msg("Subtitle index miss, show fetched and indexed")
+	return matches, nil
+}
+
+// GetSubtitle returns one subtitle of a show by ID. It is answered from the FindSubtitle index
+// when the show is indexed. Otherwise, or when the indexed show lacks the ID because the subtitle
+// is newer than the index, the show's subtitles are fetched and indexed again. A subtitle the
+// show does not have returns apperrors.ErrNotFound.
+func (c *client) GetSubtitle(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error) {
+	logger := config.GetLogger()
+
+	if subtitle, found, _ := c.subtitleIndex.LookupByID(showID, subtitleID); found {
+		logger.Debug().Int("showID", showID).Int("subtitleID", subtitleID).Msg("Subtitle index hit")
+		return &subtitle, nil
+	}
+
+	indexed, err := c.indexShow(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+	subtitle, found, _ := c.subtitleIndex.LookupByID(showID, subtitleID)
+	logger.Debug().Int("showID", showID).Int("subtitleID", subtitleID).Int("indexed", indexed).Bool("found", found).
+		Msg("Subtitle index miss, show fetched and indexed")
+	if !found {
+		return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
+	}
+	return &subtitle, nil
+}
+
+// indexShow fetches all of a show's subtitles and indexes them, returning how many were indexed.
+// A failed fetch is returned as an error and indexes nothing, so a partial listing never hides
+// subtitles later.
+func (c *client) indexShow(ctx context.Context, showID int) (int, error) {
+	var subtitles []models.Subtitle
+	for result := range c.StreamSubtitles(ctx, showID) {
+		if result.Err != nil {
+			return 0, fmt.Errorf("failed to fetch subtitles for show %d: %w", showID, result.Err)
+		}
+		subtitles = append(subtitles, result.Value)
+	}
+
+	c.subtitleIndex.Ingest(models.ShowSubtitles{
+		Show: models.Show{ID: showID},
+		SubtitleCollection: models.SubtitleCollection{
+			Subtitles: subtitles,
+			Total:     len(subtitles),
+		},
+	})
+	return len(subtitles), nil
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestClient_FindSubtitle_IndexesShowOnMiss(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("sid") != "123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600005, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", DownloadFilename: "billy.s02e05.srt"},
+			{SubtitleID: 1770600004, ShowID: 123, Language: "Angol", FlagImage: "uk.gif", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", DownloadFilename: "billy.s02e05.en.srt"},
+			{SubtitleID: 1770600003, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid - 2x04", EredetiTitle: "Billy the Kid - 2x04 - Outlaw (WEB.720p-EDITH)", DownloadFilename: "billy.s02e04.srt"},
+			{SubtitleID: 1770600002, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid (2. évad)", EredetiTitle: "Billy the Kid (Season 2) (WEB.720p-EDITH)", DownloadFilename: "billy.s02.zip"},
+		}, 1, 1, true)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	subtitles, err := c.FindSubtitle(context.Background(), 123, 2, 5, "hu")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(subtitles) != 2 || subtitles[0].ID != 1770600005 || subtitles[1].ID != 1770600002 {
+		t.Fatalf("Expected episode subtitle then season pack, got %+v", subtitles)
+	}
+	if !subtitles[1].IsSeasonPack {
+		t.Error("Expected second match to be the season pack")
+	}
+	fetches := requests.Load()
+
+	// Another episode of the same show is answered from the index
+	subtitles, err = c.FindSubtitle(context.Background(), 123, 2, 4, "hu")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(subtitles) != 2 || subtitles[0].ID != 1770600003 {
+		t.Errorf("Expected episode 4 subtitle then season pack, got %+v", subtitles)
+	}
+	if n := requests.Load(); n != fetches {
+		t.Errorf("Expected index hit without new requests, got %d extra", n-fetches)
+	}
+}
+
+func TestClient_FindSubtitle_FailedFetchIsNotIndexed(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	for range 2 {
+		if _, err := c.FindSubtitle(context.Background(), 999, 1, 1, "hu"); err == nil {
+			t.Fatal("Expected error for unknown show, got nil")
+		}
+	}
+	if n := requests.Load(); n < 2 {
+		t.Errorf("Expected every lookup to retry the fetch, got %d requests", n)
+	}
+}
+
+func TestClient_GetSubtitle(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600005, ShowID: 123, Language: "Angol", FlagImage: "uk.gif", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", Uploader: "kissoreg", DownloadFilename: "billy.s02e05.en.srt"},
+		}, 1, 1, true)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	subtitle, err := c.GetSubtitle(context.Background(), 123, 1770600005)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if subtitle.ID != 1770600005 || subtitle.Language != "en" || subtitle.Season != 2 || subtitle.Episode != 5 {
+		t.Errorf("Unexpected subtitle: %+v", subtitle)
+	}
+	fetches := requests.Load()
+
+	// The indexed show is searched again for a subtitle it lacks, which may be newer than the index
+	_, err = c.GetSubtitle(context.Background(), 123, 42)
+	if !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound for an unknown subtitle, got: %v", err)
+	}
+	if n := requests.Load(); n == fetches {
+		t.Error("Expected a miss on an indexed show to fetch the show again")
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+)
+
+// hedgedAttempt is the outcome of one of the two requests of a hedged fetch.
+type hedgedAttempt struct {
+	body  []byte
+	err   error
+	hedge bool // The second request, sent after hedgeDelay
+}
+
+// fetchPageHedged fetches a show list page like fetchPage. When client.hedge_delay is set and the
+// page has not arrived after it, the same page is requested a second time in parallel; the first
+// successful body is returned and the other request is cancelled, so the page is parsed once.
+// At most one extra request is sent per page, through the same HTTP client, so it goes through
+// the same retries and mirror failover and is counted in the upstream metrics. A request that
+// fails before the delay returns its error without a hedge; once hedged, the first error is
+// returned only when both requests fail.
+func (c *client) fetchPageHedged(ctx context.Context, url string) ([]byte, error) {
+	if c.hedgeDelay <= 0 {
+		return c.fetchPage(ctx, url)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // Cancels the request that lost
+
+	// Buffered for both attempts, so the one that lost never blocks on its send
+	results := make(chan hedgedAttempt, 2)
+	send := func(hedge bool) {
+		go func() {
+			body, err := c.fetchPage(ctx, url)
+			results <- hedgedAttempt{body: body, err: err, hedge: hedge}
+		}()
+	}
+	send(false)
+
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	hedged, pending := false, 1
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			hedged, pending = true, pending+1
+			logger := config.GetLogger()
+			logger.Debug().Str("url", url).Dur("hedgeDelay", c.hedgeDelay).Msg("Show list page is slow, sending a hedged request")
+			send(true)
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if hedged {
+					winner := "primary"
+					if result.hedge {
+						winner = "hedge"
+					}
+					metrics.UpstreamHedgedRequestsTotal.WithLabelValues(winner).Inc()
+				}
+				return result.body, nil
+			}
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if pending == 0 {
+				if hedged {
+					metrics.UpstreamHedgedRequestsTotal.WithLabelValues("none").Inc()
+				}
+				return nil, firstErr
+			}
+		}
+	}
+}
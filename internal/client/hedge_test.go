@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Not parallel: reads the upstream_hedged_requests_total counter shared by every client
+func TestClient_StreamShowList_HedgeWins(t *testing.T) {
+	waitingHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12190, ShowName: "7 Bears", Year: 2025},
+	})
+	underHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12076, ShowName: "Adults", Year: 2024},
+	})
+
+	var waitingRequests atomic.Int32
+	slowCancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("sorf") {
+		case "varakozik-subrip":
+			if waitingRequests.Add(1) == 1 {
+				// The first request stalls until the hedge has won and it is cancelled
+				select {
+				case <-r.Context().Done():
+					close(slowCancelled)
+				case <-time.After(10 * time.Second):
+				}
+				return
+			}
+			_, _ = w.Write([]byte(waitingHTML))
+		case "alatt-subrip":
+			_, _ = w.Write([]byte(underHTML))
+		default:
+			_, _ = w.Write([]byte(testutil.GenerateShowTableHTML(nil)))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "30s"}
+	cfg.Client.HedgeDelay = "50ms"
+	c := NewClient(cfg)
+	defer c.Close()
+
+	hedgeWinsBefore := promtestutil.ToFloat64(metrics.UpstreamHedgedRequestsTotal.WithLabelValues("hedge"))
+	ctx := context.Background()
+	started := time.Now()
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Expected the hedge to answer before the stalled request, took %v", elapsed)
+	}
+	if len(shows) != 2 {
+		t.Fatalf("Expected each show once, got %d: %+v", len(shows), shows)
+	}
+	if got := waitingRequests.Load(); got != 2 {
+		t.Errorf("Expected the slow endpoint to be requested twice, got %d", got)
+	}
+	select {
+	case <-slowCancelled:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected the stalled request to be cancelled once the hedge won")
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamHedgedRequestsTotal.WithLabelValues("hedge")) - hedgeWinsBefore; got != 1 {
+		t.Errorf("Expected one hedge win to be counted, got %v", got)
+	}
+}
+
+func TestClient_FetchPageHedged_FastPageIsNotHedged(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("page"))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	cfg.Client.HedgeDelay = "5s"
+	c := NewClient(cfg).(*client)
+	defer c.Close()
+
+	body, err := c.fetchPageHedged(context.Background(), server.URL+"/index.php?sorf=alatt-subrip")
+	if err != nil || string(body) != "page" {
+		t.Fatalf("Expected the page, got %q, %v", body, err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single request for a fast page, got %d", got)
+	}
+}
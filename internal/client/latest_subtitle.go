@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+)
+
+// GetLatestSubtitleID returns the highest subtitle ID on the first page of the recent listing.
+// Only that page is fetched: pagination and third-party IDs are skipped so the call stays cheap.
+// An empty listing returns 0 without error.
+func (c *client) GetLatestSubtitleID(ctx context.Context) (int, error) {
+	logger := config.GetLogger()
+
+	endpoint := fmt.Sprintf("%s/index.php?tab=sorozat", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointSubtitles, resp, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch recent subtitles: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("recent subtitles returned status %d", resp.StatusCode)
+	}
+
+	page, size, err := c.parseListingPage(resp, metrics.UpstreamEndpointSubtitles)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse recent subtitles: %w", err)
+	}
+
+	latestID := 0
+	for _, subtitle := range page.Subtitles {
+		latestID = max(latestID, subtitle.ID)
+	}
+
+	logger.Debug().Int("latestSubtitleID", latestID).Int("subtitles", len(page.Subtitles)).Int64("bytes", size).Msg("Fetched latest subtitle ID")
+	return latestID, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestClient_GetLatestSubtitleID(t *testing.T) {
+	t.Parallel()
+	var otherRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") != "sorozat" || r.URL.Query().Get("page") != "" || r.URL.Query().Get("oldal") != "" {
+			// Neither detail pages nor further listing pages should be fetched
+			otherRequests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// IDs are deliberately out of order so the maximum is not the first row
+		html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600002, MagyarTitle: "Show A", EredetiTitle: "Show A - 1x02", DownloadFilename: "a2.srt", ShowID: 123},
+			{SubtitleID: 1770600010, MagyarTitle: "Show B", EredetiTitle: "Show B - 2x01", DownloadFilename: "b1.srt", ShowID: 456},
+			{SubtitleID: 1770600001, MagyarTitle: "Show A", EredetiTitle: "Show A - 1x01", DownloadFilename: "a1.srt", ShowID: 123},
+		}, 1, 3, true)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	latestID, err := client.GetLatestSubtitleID(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if latestID != 1770600010 {
+		t.Errorf("Expected latest subtitle ID 1770600010, got %d", latestID)
+	}
+	if n := otherRequests.Load(); n != 0 {
+		t.Errorf("Expected only the first listing page to be fetched, got %d extra requests", n)
+	}
+}
+
+func TestClient_GetLatestSubtitleID_EmptyListing(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(nil)))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	latestID, err := client.GetLatestSubtitleID(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if latestID != 0 {
+		t.Errorf("Expected 0 for an empty listing, got %d", latestID)
+	}
+}
+
+func TestClient_GetLatestSubtitleID_ServerError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	if _, err := client.GetLatestSubtitleID(context.Background()); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
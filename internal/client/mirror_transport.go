@@ -0,0 +1,188 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// defaultMirrorCooldown is how long requests stay on a failover mirror before the primary is tried again.
+const defaultMirrorCooldown = 5 * time.Minute
+
+// mirrorTransport sends requests for the upstream site to the active mirror and fails over to the
+// other mirrors, in configured order, on connection errors and 5xx responses. The mirror that
+// answered stays active for the cooldown, after which the primary is probed again. Requests for
+// other hosts pass through unchanged.
+type mirrorTransport struct {
+	transport http.RoundTripper
+	mirrors   []*url.URL
+	domains   []string // mirrors as configured, used in logs, metrics and UpstreamStatus
+	cooldown  time.Duration
+
+	mu          sync.Mutex
+	active      int
+	activeSince time.Time
+}
+
+// newMirrorTransport creates a transport over the given mirror base URLs, primary first.
+// Domains that do not parse are dropped with a warning.
+func newMirrorTransport(base http.RoundTripper, domains []string, cooldown time.Duration) *mirrorTransport {
+	logger := config.GetLogger()
+	t := &mirrorTransport{transport: base, cooldown: cooldown, activeSince: time.Now()}
+	for _, domain := range domains {
+		u, err := url.Parse(domain)
+		if err != nil || u.Host == "" {
+			logger.Warn().Str("domain", domain).Msg("Invalid upstream mirror domain, skipping")
+			continue
+		}
+		u.Path = strings.TrimRight(u.Path, "/")
+		t.mirrors = append(t.mirrors, u)
+		t.domains = append(t.domains, domain)
+	}
+	t.recordActive()
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *mirrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	matched := t.match(req.URL)
+	if matched < 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	start, probing := t.start()
+	candidates := []int{start}
+	// A body that cannot be replayed is only sent once
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		for i := range t.mirrors {
+			if i != start {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+
+	logger := config.GetLogger()
+	for n, i := range candidates {
+		r := t.rewrite(req, matched, i)
+		if n > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		resp, err := t.transport.RoundTrip(r)
+		last := n == len(candidates)-1
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			t.markHealthy(i, probing)
+			return resp, nil
+		}
+		if last || (err != nil && req.Context().Err() != nil) {
+			return resp, err
+		}
+
+		logEvent := logger.Warn().Str("mirror", t.domains[i]).Str("next_mirror", t.domains[candidates[n+1]])
+		if err != nil {
+			logEvent = logEvent.Err(err)
+		} else {
+			logEvent = logEvent.Int("status", resp.StatusCode)
+			_ = resp.Body.Close()
+		}
+		logEvent.Msg("Upstream mirror failed, trying next mirror")
+	}
+	// Unreachable: the last candidate always returns
+	return nil, nil
+}
+
+// Status reports the active mirror and when it became active.
+func (t *mirrorTransport) Status() models.UpstreamStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	status := models.UpstreamStatus{Mirrors: append([]string(nil), t.domains...), ActiveSince: t.activeSince}
+	if len(t.domains) > 0 {
+		status.ActiveMirror = t.domains[t.active]
+	}
+	return status
+}
+
+// match returns the index of the mirror serving u, or -1 when u is not on any mirror.
+func (t *mirrorTransport) match(u *url.URL) int {
+	for i, m := range t.mirrors {
+		if !strings.EqualFold(u.Host, m.Host) {
+			continue
+		}
+		if m.Path == "" || u.Path == m.Path || strings.HasPrefix(u.Path, m.Path+"/") {
+			return i
+		}
+	}
+	return -1
+}
+
+// start returns the mirror a request tries first: the active one, or the primary once the
+// cooldown has elapsed. probing is true when the primary is retried after a failover.
+func (t *mirrorTransport) start() (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active != 0 && time.Since(t.activeSince) >= t.cooldown {
+		return 0, true
+	}
+	return t.active, false
+}
+
+// markHealthy makes mirror i the active one. When a probe of the primary failed and the
+// request landed on the mirror that was already active, the cooldown starts over.
+func (t *mirrorTransport) markHealthy(i int, probing bool) {
+	t.mu.Lock()
+	previous := t.active
+	switch {
+	case i != previous:
+		t.active = i
+		t.activeSince = time.Now()
+	case probing:
+		t.activeSince = time.Now()
+	}
+	t.mu.Unlock()
+
+	if i == previous {
+		return
+	}
+	logger := config.GetLogger()
+	logger.Info().Str("from", t.domains[previous]).Str("to", t.domains[i]).Msg("Switched active upstream mirror")
+	t.recordActive()
+}
+
+// recordActive sets the UpstreamActiveMirror gauge to 1 for the active mirror and 0 for the others.
+func (t *mirrorTransport) recordActive() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, domain := range t.domains {
+		value := 0.0
+		if i == t.active {
+			value = 1
+		}
+		metrics.UpstreamActiveMirror.WithLabelValues(domain).Set(value)
+	}
+}
+
+// rewrite clones req and points it at mirror to, replacing the base URL of mirror from.
+func (t *mirrorTransport) rewrite(req *http.Request, from, to int) *http.Request {
+	r := cloneRequest(req)
+	if from == to {
+		return r
+	}
+	u := *req.URL
+	u.Scheme = t.mirrors[to].Scheme
+	u.Host = t.mirrors[to].Host
+	u.Path = t.mirrors[to].Path + strings.TrimPrefix(u.Path, t.mirrors[from].Path)
+	u.RawPath = ""
+	r.URL = &u
+	r.Host = ""
+	return r
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+)
+
+// newTestClientWithMirrors creates a client that fails over between the given mirrors without retries.
+func newTestClientWithMirrors(mirrorCooldown string, domains ...string) Client {
+	cfg := config.Config{
+		SuperSubtitleDomains: domains,
+		ClientTimeout:        "10s",
+	}
+	cfg.Client.MirrorCooldown = mirrorCooldown
+	cfg.Retry.MaxAttempts = 1
+	return NewClient(&cfg)
+}
+
+func updatesHandler(requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"film":"2","sorozat":"1"}`))
+	}
+}
+
+func TestClient_Mirror_FailsOverOn5xx(t *testing.T) {
+	t.Parallel()
+
+	var primaryRequests, secondaryRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(updatesHandler(&secondaryRequests))
+	defer secondary.Close()
+
+	c := newTestClientWithMirrors("5m", primary.URL, secondary.URL)
+	for i := range 2 {
+		result, err := c.CheckForUpdates(context.Background(), 1234, true)
+		if err != nil {
+			t.Fatalf("Request %d: expected failover to hide the primary's error, got: %v", i, err)
+		}
+		if result.FilmCount != 2 || result.SeriesCount != 1 {
+			t.Errorf("Request %d: unexpected result: %+v", i, result)
+		}
+	}
+
+	if got := primaryRequests.Load(); got != 1 {
+		t.Errorf("Expected the primary to be skipped during the cooldown after 1 failure, got %d requests", got)
+	}
+	if got := secondaryRequests.Load(); got != 2 {
+		t.Errorf("Expected both requests on the secondary, got %d", got)
+	}
+	status := c.UpstreamStatus()
+	if status.ActiveMirror != secondary.URL {
+		t.Errorf("Expected active mirror %s, got %s", secondary.URL, status.ActiveMirror)
+	}
+	if len(status.Mirrors) != 2 || status.Mirrors[0] != primary.URL {
+		t.Errorf("Expected configured mirrors in order, got %v", status.Mirrors)
+	}
+}
+
+func TestClient_Mirror_FailsOverOnConnectionError(t *testing.T) {
+	t.Parallel()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+	var requests atomic.Int32
+	secondary := httptest.NewServer(updatesHandler(&requests))
+	defer secondary.Close()
+
+	c := newTestClientWithMirrors("5m", downURL, secondary.URL)
+	if _, err := c.CheckForUpdates(context.Background(), 1234, false); err != nil {
+		t.Fatalf("Expected failover to the secondary, got: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected 1 request on the secondary, got %d", requests.Load())
+	}
+}
+
+func TestClient_Mirror_ReturnsToPrimaryAfterCooldown(t *testing.T) {
+	t.Parallel()
+
+	var primaryRequests, secondaryRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if primaryRequests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"film":"2","sorozat":"1"}`))
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(updatesHandler(&secondaryRequests))
+	defer secondary.Close()
+
+	// A zero cooldown probes the primary on every request
+	c := newTestClientWithMirrors("0s", primary.URL, secondary.URL)
+	for i := range 2 {
+		if _, err := c.CheckForUpdates(context.Background(), 1234, true); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+
+	if primaryRequests.Load() != 2 || secondaryRequests.Load() != 1 {
+		t.Errorf("Expected 2 primary and 1 secondary requests, got %d and %d", primaryRequests.Load(), secondaryRequests.Load())
+	}
+	if active := c.UpstreamStatus().ActiveMirror; active != primary.URL {
+		t.Errorf("Expected the recovered primary to be active, got %s", active)
+	}
+}
+
+func TestClient_Mirror_ReturnsLastErrorWhenAllMirrorsFail(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	primary := httptest.NewServer(failing)
+	defer primary.Close()
+	secondary := httptest.NewServer(failing)
+	defer secondary.Close()
+
+	c := newTestClientWithMirrors("5m", primary.URL, secondary.URL)
+	if _, err := c.CheckForUpdates(context.Background(), 1234, false); err == nil {
+		t.Fatal("Expected an error when every mirror fails")
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 1 request per mirror, got %d", requests.Load())
+	}
+	if active := c.UpstreamStatus().ActiveMirror; active != primary.URL {
+		t.Errorf("Expected the primary to stay active, got %s", active)
+	}
+}
+
+func TestMirrorTransport_RewritesPathPrefixAndPassesOtherHosts(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer secondary.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	transport := newMirrorTransport(http.DefaultTransport, []string{downURL + "/site/", secondary.URL + "/mirror"}, defaultMirrorCooldown)
+	httpClient := &http.Client{Transport: transport}
+
+	// A download link built on the primary is sent to the secondary under its own prefix
+	resp, err := httpClient.Get(downURL + "/site/index.php?action=letolt&felirat=42")
+	if err != nil {
+		t.Fatalf("Expected failover, got: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// Other paths on a mirror's host are not rewritten
+	resp, err = httpClient.Get(secondary.URL + "/other")
+	if err != nil {
+		t.Fatalf("Expected pass-through request to succeed, got: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	want := []string{"/mirror/index.php?action=letolt&felirat=42", "/other"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("Expected requests %v, got %v", want, paths)
+	}
+}
+
+func TestClient_Mirror_AcceptsDownloadURLOnAnyMirror(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClientWithMirrors("5m", "https://feliratok.eu", "https://mirror.example.com").(*client)
+	if err := c.validateDownloadURL("https://mirror.example.com/index.php?action=letolt&felirat=1"); err != nil {
+		t.Errorf("Expected a mirror download URL to be accepted, got: %v", err)
+	}
+	if err := c.validateDownloadURL("https://evil.example.com/index.php?action=letolt&felirat=1"); err == nil {
+		t.Error("Expected a download URL on another host to be rejected")
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// preloadSeasonPacksPerShow is how many of a show's newest season packs Preload caches.
+const preloadSeasonPacksPerShow = 3
+
+// Preload downloads the newest season-pack archives of each show into the archive cache, so
+// episode downloads after a cold start are cache hits. At most showSubtitlesConcurrency shows
+// are processed at once, and each show's archives are fetched one after another. It is
+// best-effort: a show or archive that fails is logged and skipped. It returns the number of
+// archives downloaded; archives that were already cached are not counted.
+func (c *client) Preload(ctx context.Context, showIDs []int) int {
+	logger := config.GetLogger()
+	logger.Info().Ints("showIDs", showIDs).Int("concurrency", c.showSubtitlesConcurrency).Msg("Preloading season packs")
+
+	var downloaded atomic.Int64
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.showSubtitlesConcurrency)
+
+schedule:
+	for _, showID := range showIDs {
+		// Stop scheduling new shows once the context is cancelled
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break schedule
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			n, err := c.preloadShow(ctx, showID)
+			downloaded.Add(int64(n))
+			if err != nil && ctx.Err() == nil {
+				logger.Warn().Err(err).Int("showID", showID).Msg("Failed to preload season packs for show")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		logger.Warn().Err(ctx.Err()).Int64("downloaded", downloaded.Load()).Msg("Season pack preload cancelled")
+	} else {
+		logger.Info().Int64("downloaded", downloaded.Load()).Int("shows", len(showIDs)).Msg("Finished preloading season packs")
+	}
+	return int(downloaded.Load())
+}
+
+// preloadShow caches the newest season packs of one show and returns how many it downloaded.
+// Archive failures are logged and skipped; only a failed subtitle listing is returned.
+func (c *client) preloadShow(ctx context.Context, showID int) (int, error) {
+	logger := config.GetLogger()
+
+	var packs []models.Subtitle
+	for result := range c.StreamSubtitles(ctx, showID) {
+		if result.Err != nil {
+			return 0, fmt.Errorf("failed to fetch subtitles for show %d: %w", showID, result.Err)
+		}
+		if result.Value.IsSeasonPack {
+			packs = append(packs, result.Value)
+		}
+	}
+
+	// Pages may complete out of order, so sort rather than rely on listing order
+	slices.SortFunc(packs, func(a, b models.Subtitle) int {
+		if byDate := b.UploadedAt.Compare(a.UploadedAt); byDate != 0 {
+			return byDate
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	if len(packs) > preloadSeasonPacksPerShow {
+		packs = packs[:preloadSeasonPacksPerShow]
+	}
+
+	downloaded := 0
+	for _, pack := range packs {
+		if ctx.Err() != nil {
+			return downloaded, ctx.Err()
+		}
+		cached, err := c.subtitleDownloader.PreloadArchive(ctx, pack.DownloadURL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return downloaded, ctx.Err()
+			}
+			logger.Warn().Err(err).Int("showID", showID).Int("subtitleID", pack.ID).Msg("Failed to preload season pack")
+			continue
+		}
+		if !cached {
+			downloaded++
+		}
+	}
+
+	logger.Debug().Int("showID", showID).Int("seasonPacks", len(packs)).Int("downloaded", downloaded).Msg("Preloaded season packs for show")
+	return downloaded, nil
+}
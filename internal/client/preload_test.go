@@ -0,0 +1,115 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestClient_Preload_CachesNewestSeasonPacks(t *testing.T) {
+	t.Parallel()
+
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, content := range map[string]string{
+		"billy.s02e01.srt": "1\n00:00:01,000 --> 00:00:02,000\nEpisode 1\n",
+		"billy.s02e02.srt": "1\n00:00:01,000 --> 00:00:02,000\nEpisode 2\n",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create ZIP entry: %v", err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to finalize ZIP: %v", err)
+	}
+
+	var mu sync.Mutex
+	downloads := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("action") == "letolt" {
+			mu.Lock()
+			downloads[query.Get("felirat")]++
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(zipBuf.Bytes())
+			return
+		}
+		if query.Get("sid") != "123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600006, ShowID: 123, Language: "Magyar", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", DownloadAction: "letolt", DownloadFilename: "billy.s02e05.srt", UploadDate: "2026-03-14"},
+			{SubtitleID: 1770600005, ShowID: 123, Language: "Magyar", MagyarTitle: "Billy the Kid (2. évad)", EredetiTitle: "Billy the Kid (Season 2) (WEB.720p-EDITH)", DownloadAction: "letolt", DownloadFilename: "billy.s02.zip", UploadDate: "2026-03-13"},
+			{SubtitleID: 1770600004, ShowID: 123, Language: "Angol", MagyarTitle: "Billy the Kid (2. évad)", EredetiTitle: "Billy the Kid (Season 2) (WEB.720p-EDITH)", DownloadAction: "letolt", DownloadFilename: "billy.s02.en.zip", UploadDate: "2026-03-12"},
+			{SubtitleID: 1770600003, ShowID: 123, Language: "Magyar", MagyarTitle: "Billy the Kid (1. évad)", EredetiTitle: "Billy the Kid (Season 1) (WEB.720p-EDITH)", DownloadAction: "letolt", DownloadFilename: "billy.s01.zip", UploadDate: "2025-03-11"},
+			{SubtitleID: 1770600002, ShowID: 123, Language: "Angol", MagyarTitle: "Billy the Kid (1. évad)", EredetiTitle: "Billy the Kid (Season 1) (WEB.720p-EDITH)", DownloadAction: "letolt", DownloadFilename: "billy.s01.en.zip", UploadDate: "2025-03-10"},
+		}, 1, 1, true)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	// The unknown show fails its listing and is skipped
+	if got := c.Preload(context.Background(), []int{123, 999}); got != preloadSeasonPacksPerShow {
+		t.Fatalf("Expected %d season packs downloaded, got %d", preloadSeasonPacksPerShow, got)
+	}
+	mu.Lock()
+	for _, id := range []string{"1770600005", "1770600004", "1770600003"} {
+		if downloads[id] != 1 {
+			t.Errorf("Expected season pack %s to be downloaded once, got %d", id, downloads[id])
+		}
+	}
+	if downloads["1770600002"] != 0 || downloads["1770600006"] != 0 {
+		t.Errorf("Expected only the newest season packs to be preloaded, got %v", downloads)
+	}
+	mu.Unlock()
+
+	// Preloading again finds everything cached
+	if got := c.Preload(context.Background(), []int{123}); got != 0 {
+		t.Errorf("Expected a second preload to download nothing, got %d", got)
+	}
+
+	result, err := c.DownloadSubtitle(context.Background(), "1770600005", models.DownloadOptions{Episode: new(2)})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Filename != "billy.s02e02.srt" {
+		t.Errorf("Expected episode 2 from the season pack, got %q", result.Filename)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if downloads["1770600005"] != 1 {
+		t.Errorf("Expected the episode download to be a cache hit, got %d upstream downloads", downloads["1770600005"])
+	}
+}
+
+func TestClient_Preload_StopsWhenCancelled(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request after cancellation, got %s", r.URL)
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := c.Preload(ctx, []int{123, 456}); got != 0 {
+		t.Errorf("Expected nothing downloaded, got %d", got)
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// configureProxy routes the transport through proxyURL. HTTP and HTTPS proxies use
+// the transport's Proxy hook; SOCKS5 proxies replace its dialer, so every connection
+// (including TLS) is tunnelled. socks5h resolves host names on the proxy, which is
+// what an IPv6-only or DNS-restricted host needs. Hosts matching an entry of noProxy
+// (see bypassesProxy) are dialled directly with either kind of proxy.
+func configureProxy(transport *http.Transport, proxyURL *url.URL, noProxy []string) error {
+	switch proxyURL.Scheme {
+	case "http", "https":
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassesProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+		return nil
+	case "socks5", "socks5h":
+		// Same dial settings as http.DefaultTransport for the hop to the proxy
+		forward := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		dialer, err := proxy.FromURL(proxyURL, forward)
+		if err != nil {
+			return fmt.Errorf("failed to create SOCKS5 dialer: %w", err)
+		}
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return fmt.Errorf("SOCKS5 dialer for %s does not support contexts", proxyURL.Redacted())
+		}
+		// Environment proxies must not be chained in front of the SOCKS5 tunnel
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if host, _, err := net.SplitHostPort(addr); err == nil && bypassesProxy(host, noProxy) {
+				return forward.DialContext(ctx, network, addr)
+			}
+			return contextDialer.DialContext(ctx, network, addr)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+}
+
+// bypassesProxy reports whether host matches an entry of noProxy. An entry matches the
+// host itself and every subdomain of it, so "internal" covers "redis.internal"; a leading
+// dot is ignored. Matching is case-insensitive, and IP addresses must match exactly.
+func bypassesProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, entry := range noProxy {
+		suffix := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry), "."))
+		if suffix == "" {
+			continue
+		}
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
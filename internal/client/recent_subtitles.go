@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
 )
 
 // StreamRecentSubtitles streams recently uploaded subtitles, grouped by show as ShowSubtitles entries.
@@ -15,8 +18,12 @@ import (
 // ShowInfo is fetched once per unique show_id using an in-memory cache.
 //
 // When sinceID > 0, pages are fetched sequentially until a subtitle with ID <= sinceID is
-// encountered, ensuring all newer subtitles from each page are collected.
+// encountered, ensuring all newer subtitles from each page are collected. Subtitles with
+// synthetic IDs are not compared against sinceID and are always included.
 // When sinceID == 0, only the first page is fetched.
+//
+// A detail page that runs past perShowTimeout is sent as an ErrShowTimeout result, followed by the
+// show's subtitles without show details.
 func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles] {
 	ch := make(chan models.StreamResult[models.ShowSubtitles])
 
@@ -32,65 +39,52 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 			showName        string
 		}
 		showDataMap := make(map[int]*showData)
-		thirdPartyIDsByShow := make(map[int]models.ThirdPartyIds)
+		detailsByShow := make(map[int]models.SubtitleDetails)
 		totalEmitted := 0
 
-		buildShowSubtitles := func(showID int) models.ShowSubtitles {
+		// buildShowSubtitles returns the show's bundle so far. When its detail page runs past
+		// perShowTimeout, the bundle is built without details and an ErrShowTimeout is returned
+		// alongside; the details are fetched again on the show's next update.
+		buildShowSubtitles := func(showID int) (models.ShowSubtitles, error) {
 			sd := showDataMap[showID]
-			show := models.Show{ID: showID, Name: sd.showName}
+			show := models.Show{ID: showID, Name: sd.showName, Aliases: showAliases(nil, sd.subtitles)}
 
-			if _, exists := thirdPartyIDsByShow[showID]; !exists {
+			details, exists := detailsByShow[showID]
+			var timeoutErr error
+			if !exists {
 				if sd.firstValidSubID > 0 {
-					thirdPartyIDsByShow[showID] = c.fetchThirdPartyIds(ctx, show, sd.firstValidSubID)
+					fetchCtx, cancel := c.withShowTimeout(ctx)
+					details = c.fetchShowDetails(fetchCtx, show, sd.firstValidSubID)
+					timeoutErr = c.showTimeoutError(ctx, fetchCtx, showID)
+					cancel()
 				} else {
 					logger.Warn().Int("showID", showID).Msg("No valid subtitle ID to fetch third-party IDs")
-					thirdPartyIDsByShow[showID] = models.ThirdPartyIds{}
+				}
+				if timeoutErr == nil {
+					detailsByShow[showID] = details
 				}
 			}
 
+			// Bundles sent earlier share the accumulated slice, so a sorted copy is sent
+			subtitles := slices.Clone(sd.subtitles)
+			models.SortSubtitles(subtitles, models.SubtitleOrderSeasonEpisode)
 			return models.ShowSubtitles{
-				Show:          show,
-				ThirdPartyIds: thirdPartyIDsByShow[showID],
+				Show:          applyShowDetails(show, details),
+				ThirdPartyIds: details.ThirdPartyIds,
 				SubtitleCollection: models.SubtitleCollection{
 					ShowName:  sd.showName,
-					Subtitles: sd.subtitles,
-					Total:     len(sd.subtitles),
+					Subtitles: subtitles,
+					Total:     len(subtitles),
 				},
-			}
+			}, timeoutErr
 		}
 
 		// Fetch pages sequentially until we reach the sinceID boundary
-		baseEndpoint := fmt.Sprintf("%s/index.php?tab=sorozat", c.baseURL)
 		reachedBoundary := false
 		for page := 1; !reachedBoundary; page++ {
-			endpoint := baseEndpoint
-			if page > 1 {
-				endpoint = fmt.Sprintf("%s&page=%d", baseEndpoint, page)
-			}
-
-			req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
-			if err != nil {
-				sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: fmt.Errorf("failed to create request for page %d: %w", page, err)})
-				return
-			}
-			req.Header.Set("User-Agent", config.GetUserAgent())
-
-			resp, err := c.httpClient.Do(req)
-			if err != nil {
-				sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: fmt.Errorf("failed to fetch page %d: %w", page, err)})
-				return
-			}
-
-			if resp.StatusCode != http.StatusOK {
-				resp.Body.Close()
-				sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: fmt.Errorf("page %d returned status %d", page, resp.StatusCode)})
-				return
-			}
-
-			pageResult, err := c.subtitleParser.ParseHtmlWithPagination(resp.Body)
-			resp.Body.Close()
+			pageResult, pageBytes, err := c.fetchRecentPage(ctx, page)
 			if err != nil {
-				sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: fmt.Errorf("failed to parse page %d: %w", page, err)})
+				sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: err})
 				return
 			}
 
@@ -98,12 +92,14 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 				Int("page", page).
 				Int("totalPages", pageResult.TotalPages).
 				Int("subtitles", len(pageResult.Subtitles)).
+				Int64("bytes", pageBytes).
 				Msg("Parsed subtitles from page")
 
 			pageShowOrder := make([]int, 0, 20)
 			pageShowSeen := make(map[int]bool)
+			uploaderFilter := c.uploaderFilter.Load()
 			for _, subtitle := range pageResult.Subtitles {
-				if subtitle.ID <= 0 {
+				if subtitle.ID <= 0 && !subtitle.IDIsSynthetic {
 					logger.Error().
 						Str("showName", subtitle.ShowName).
 						Str("downloadURL", subtitle.DownloadURL).
@@ -115,11 +111,17 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 					continue
 				}
 
-				if sinceID > 0 && subtitle.ID <= sinceID {
+				// Synthetic IDs are URL hashes rather than upload order, so they never mark the boundary
+				if sinceID > 0 && !subtitle.IDIsSynthetic && subtitle.ID <= sinceID {
 					reachedBoundary = true
 					break
 				}
 
+				if !uploaderFilter.Allows(subtitle.Uploader) {
+					logger.Debug().Int("subtitleID", subtitle.ID).Str("uploader", subtitle.Uploader).Msg("Skipping subtitle from filtered uploader")
+					continue
+				}
+
 				showID := subtitle.ShowID
 				if showID == 0 {
 					logger.Warn().Int("subtitleID", subtitle.ID).Str("showName", subtitle.ShowName).Msg("Skipping subtitle with missing show_id")
@@ -132,7 +134,8 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 					showDataMap[showID] = sd
 				}
 
-				if sd.firstValidSubID == 0 {
+				// Detail pages are only reachable through a real felirat ID
+				if sd.firstValidSubID == 0 && !subtitle.IDIsSynthetic {
 					sd.firstValidSubID = subtitle.ID
 				}
 				sd.subtitles = append(sd.subtitles, subtitle)
@@ -144,7 +147,11 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 			}
 
 			for _, showID := range pageShowOrder {
-				showSubtitles := buildShowSubtitles(showID)
+				showSubtitles, err := buildShowSubtitles(showID)
+				if err != nil {
+					logger.Warn().Err(err).Int("showID", showID).Msg("Show detail page timed out, sending subtitles without show details")
+					sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: err})
+				}
 				select {
 				case ch <- models.StreamResult[models.ShowSubtitles]{Value: showSubtitles}:
 					totalEmitted++
@@ -171,3 +178,35 @@ func (c *client) StreamRecentSubtitles(ctx context.Context, sinceID int) <-chan
 
 	return ch
 }
+
+// fetchRecentPage fetches and parses page pageNum of the recent uploads listing, the site's
+// main series tab. It returns the parsed page and the size of its body.
+func (c *client) fetchRecentPage(ctx context.Context, pageNum int) (*parser.SubtitlePageResult, int64, error) {
+	endpoint := fmt.Sprintf("%s/index.php?tab=sorozat", c.baseURL)
+	if pageNum > 1 {
+		endpoint = fmt.Sprintf("%s&page=%d", endpoint, pageNum)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request for page %d: %w", pageNum, err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointSubtitles, resp, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch page %d: %w", pageNum, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("page %d returned status %d", pageNum, resp.StatusCode)
+	}
+
+	pageResult, pageBytes, err := c.parseListingPage(resp, metrics.UpstreamEndpointSubtitles)
+	if err != nil {
+		return nil, pageBytes, fmt.Errorf("failed to parse page %d: %w", pageNum, err)
+	}
+	return pageResult, pageBytes, nil
+}
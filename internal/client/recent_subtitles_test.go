@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
@@ -472,11 +475,12 @@ func TestClient_StreamRecentSubtitles_SinceZeroFetchesOnlyPage1(t *testing.T) {
 	}
 }
 
-func TestClient_StreamRecentSubtitles_InvalidIDSkippedNotBoundary(t *testing.T) {
+func TestClient_StreamRecentSubtitles_SyntheticIDNotBoundary(t *testing.T) {
 	t.Parallel()
-	// Page 1: one valid subtitle (ID=5000) and one with an unparseable download link (ID=-1).
+	// Page 1: one valid subtitle (ID=5000) and one whose download link has no numeric ID,
+	// which gets a negative synthetic ID.
 	// Page 2: one subtitle below sinceID=1000 to trigger the real boundary.
-	// The invalid-ID row must not stop pagination early; only the real boundary should.
+	// The synthetic-ID row must not stop pagination early; only the real boundary should.
 	var page2Fetched atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("tab") != "sorozat" {
@@ -534,25 +538,155 @@ func TestClient_StreamRecentSubtitles_InvalidIDSkippedNotBoundary(t *testing.T)
 	ctx := context.Background()
 
 	// sinceID=1000: subtitles with ID > 1000 should be included; ID=500 triggers the boundary.
-	// The subtitle with ID=-1 (unparseable) must be skipped, not treated as the boundary.
+	// The synthetic-ID subtitle must be kept, not treated as the boundary.
 	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamRecentSubtitles(ctx, 1000))
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	// Only the valid subtitle (ID=5000) should be collected; the bad-ID row is skipped
-	// and the old sub (ID=500) is below sinceID.
+	// The valid subtitle (ID=5000) and the synthetic-ID row are collected; the old sub
+	// (ID=500) is below sinceID.
 	if len(showSubtitles) != 1 {
 		t.Fatalf("Expected 1 show, got %d", len(showSubtitles))
 	}
-	if len(showSubtitles[0].SubtitleCollection.Subtitles) != 1 {
-		t.Errorf("Expected 1 subtitle for show 10, got %d", len(showSubtitles[0].SubtitleCollection.Subtitles))
+	subtitles := showSubtitles[0].SubtitleCollection.Subtitles
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles for show 10, got %d", len(subtitles))
+	}
+	if subtitles[0].ID != 5000 {
+		t.Errorf("Expected subtitle ID 5000, got %d", subtitles[0].ID)
 	}
-	if showSubtitles[0].SubtitleCollection.Subtitles[0].ID != 5000 {
-		t.Errorf("Expected subtitle ID 5000, got %d", showSubtitles[0].SubtitleCollection.Subtitles[0].ID)
+	if !subtitles[1].IDIsSynthetic {
+		t.Errorf("Expected second subtitle to have a synthetic ID, got %d", subtitles[1].ID)
 	}
 	// Confirm that pagination actually continued past the invalid-ID row to page 2.
 	if page2Fetched.Load() != 1 {
-		t.Errorf("Expected page 2 to be fetched exactly once (confirming pagination continued past invalid-ID row), got %d", page2Fetched.Load())
+		t.Errorf("Expected page 2 to be fetched exactly once (confirming pagination continued past synthetic-ID row), got %d", page2Fetched.Load())
+	}
+}
+
+func TestClient_StreamRecentSubtitles_SyntheticIDSkippedForThirdPartyIDs(t *testing.T) {
+	t.Parallel()
+	var detailSubtitleID atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") == "sorozat" {
+			html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+				{EredetiTitle: "Show A - 1x02", ShowID: 10, CustomDownloadHref: "/index.php?action=letolt&fnev=token.srt&felirat=abc"},
+				{SubtitleID: 5000, EredetiTitle: "Show A - 1x01", DownloadFilename: "valid.srt", ShowID: 10},
+			})
+			_, _ = w.Write([]byte(html))
+		} else if r.URL.Query().Get("tipus") == "adatlap" {
+			detailSubtitleID.Store(r.URL.Query().Get("azon"))
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("tt1234567", 0, 0, 0)))
+		}
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	c := NewClient(testConfig)
+	ctx := context.Background()
+
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, c.StreamRecentSubtitles(ctx, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(showSubtitles) != 1 {
+		t.Fatalf("Expected 1 show, got %d", len(showSubtitles))
+	}
+	if got, _ := detailSubtitleID.Load().(string); got != "a_5000" {
+		t.Errorf("Expected detail page fetched for subtitle a_5000, got %q", got)
+	}
+	if showSubtitles[0].ThirdPartyIds.IMDBID != "tt1234567" {
+		t.Errorf("Expected IMDB ID tt1234567, got %q", showSubtitles[0].ThirdPartyIds.IMDBID)
+	}
+}
+
+func TestClient_GetRecentSubtitles_FiltersUploaders(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") == "sorozat" {
+			html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+				{SubtitleID: 1770600003, EredetiTitle: "Test Show 1 - 1x03", Uploader: "AutoSub", DownloadFilename: "recent3.srt", ShowID: 123},
+				{SubtitleID: 1770600002, EredetiTitle: "Test Show 1 - 1x02", Uploader: "gricsi", DownloadFilename: "recent2.srt", ShowID: 123},
+				{SubtitleID: 1770600001, EredetiTitle: "Test Show 2 - 1x01", Uploader: "autosub", DownloadFilename: "recent1.srt", ShowID: 456},
+			})
+			_, _ = w.Write([]byte(html))
+		} else if r.URL.Query().Get("tipus") == "adatlap" {
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("", 0, 0, 0)))
+		}
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	testConfig.Client.BlockedUploaders = []string{"AutoSub"}
+	client := NewClient(testConfig)
+	ctx := context.Background()
+
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamRecentSubtitles(ctx, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Show 456 only had subtitles from the blocked uploader, so it is not sent at all
+	if len(showSubtitles) != 1 {
+		t.Fatalf("Expected 1 show, got %d", len(showSubtitles))
+	}
+	subtitles := showSubtitles[0].SubtitleCollection.Subtitles
+	if showSubtitles[0].ID != 123 || len(subtitles) != 1 || subtitles[0].ID != 1770600002 {
+		t.Errorf("Expected only subtitle 1770600002 for show 123, got show %d with %+v", showSubtitles[0].ID, subtitles)
+	}
+}
+
+func TestClient_StreamRecentSubtitles_PerShowTimeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") == "sorozat" {
+			html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+				{SubtitleID: 1770600002, EredetiTitle: "Slow Show - 1x02", DownloadFilename: "recent2.srt", ShowID: 123},
+				{SubtitleID: 1770600001, EredetiTitle: "Fast Show - 1x01", DownloadFilename: "recent1.srt", ShowID: 456},
+			})
+			_, _ = w.Write([]byte(html))
+			return
+		}
+		if r.URL.Query().Get("azon") == "a_1770600002" {
+			// Hang well past the per-show timeout
+			select {
+			case <-time.After(5 * time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("tt7654321", 0, 0, 0)))
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	testConfig.Client.PerShowTimeout = "200ms"
+	client := NewClient(testConfig)
+
+	var timeoutErr *apperrors.ErrShowTimeout
+	items := make(map[int]models.ShowSubtitles)
+	for result := range client.StreamRecentSubtitles(context.Background(), 0) {
+		if result.Err != nil {
+			if !errors.As(result.Err, &timeoutErr) {
+				t.Fatalf("Expected ErrShowTimeout, got: %v", result.Err)
+			}
+			continue
+		}
+		items[result.Value.ID] = result.Value
+	}
+
+	if timeoutErr == nil || timeoutErr.ShowID != 123 {
+		t.Fatalf("Expected an ErrShowTimeout for show 123, got %v", timeoutErr)
+	}
+	slow, ok := items[123]
+	if !ok {
+		t.Fatal("Expected the slow show's subtitles to still be sent")
+	}
+	if slow.ThirdPartyIds.IMDBID != "" || slow.SubtitleCollection.Total != 1 {
+		t.Errorf("Expected show 123 with its subtitle and no third-party IDs, got %+v", slow)
+	}
+	if fast := items[456]; fast.ThirdPartyIds.IMDBID != "tt7654321" {
+		t.Errorf("Expected show 456 to keep its third-party IDs, got %+v", fast.ThirdPartyIds)
 	}
 }
@@ -48,7 +48,7 @@ func TestClient_Retry_SucceedsAfterTransientError(t *testing.T) {
 	defer server.Close()
 
 	c := newTestClientWithRetry(server.URL, 3)
-	result, err := c.CheckForUpdates(context.Background(), 1234)
+	result, err := c.CheckForUpdates(context.Background(), 1234, false)
 
 	if err != nil {
 		t.Fatalf("Expected success after retry, got error: %v", err)
@@ -76,7 +76,7 @@ func TestClient_Retry_ExhaustsAttemptsAndFails(t *testing.T) {
 
 	const maxAttempts = 3
 	c := newTestClientWithRetry(server.URL, maxAttempts)
-	_, err := c.CheckForUpdates(context.Background(), 1234)
+	_, err := c.CheckForUpdates(context.Background(), 1234, false)
 
 	if err == nil {
 		t.Fatal("Expected error after retries exhausted, got nil")
@@ -104,7 +104,7 @@ func TestClient_Retry_429TooManyRequestsIsRetried(t *testing.T) {
 	defer server.Close()
 
 	c := newTestClientWithRetry(server.URL, 3)
-	result, err := c.CheckForUpdates(context.Background(), 5678)
+	result, err := c.CheckForUpdates(context.Background(), 5678, false)
 
 	if err != nil {
 		t.Fatalf("Expected success after retry on 429, got: %v", err)
@@ -174,7 +174,7 @@ func TestClient_Retry_ShowListSucceedsAfterTransientError(t *testing.T) {
 
 	c := newTestClientWithRetry(server.URL, 3)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
 
 	if err != nil {
 		t.Fatalf("Expected success after retry, got: %v", err)
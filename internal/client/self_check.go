@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+const (
+	// defaultSelfCheckShowID is the show checked when client.self_check_show_id is zero. Its
+	// listing has been on the site for years and spans several pages.
+	defaultSelfCheckShowID = 3217
+	// selfCheckTimeout bounds the self-check's fetch, retries included.
+	selfCheckTimeout = 10 * time.Second
+)
+
+// SelfCheck fetches the first listing page of selfCheckShowID and checks that it parses into at
+// least one subtitle with a language and a valid ID. Failures, including a panic while parsing,
+// are reported in the result rather than returned.
+func (c *client) SelfCheck(ctx context.Context) (result models.SelfCheckResult) {
+	logger := config.GetLogger()
+	result.ShowID = c.selfCheckShowID
+	defer func() {
+		if r := recover(); r != nil {
+			result.OK = false
+			result.Details = fmt.Sprintf("parsing panicked: %v", r)
+		}
+		logEvent := logger.Info()
+		if !result.OK {
+			logEvent = logger.Warn()
+		}
+		logEvent.Int("showID", result.ShowID).Int("subtitles", result.SubtitleCount).Str("details", result.Details).Msg("Self-check completed")
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+	defer cancel()
+
+	page, _, err := c.fetchFirstSubtitlePage(ctx, showListing(c.selfCheckShowID))
+	if err != nil {
+		result.Details = fmt.Sprintf("failed to fetch show %d: %v", c.selfCheckShowID, err)
+		return result
+	}
+	result.SubtitleCount = len(page.Subtitles)
+
+	plausible := 0
+	for _, subtitle := range page.Subtitles {
+		if subtitle.Language != "" && subtitle.ID > 0 && !subtitle.IDIsSynthetic {
+			plausible++
+		}
+	}
+	if plausible == 0 {
+		result.Details = fmt.Sprintf("parsed %d subtitles from show %d, none with a language and a valid ID", result.SubtitleCount, c.selfCheckShowID)
+		return result
+	}
+	result.OK = true
+	result.Details = fmt.Sprintf("parsed %d subtitles from show %d, %d with a language and a valid ID", result.SubtitleCount, c.selfCheckShowID, plausible)
+	return result
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestClient_SelfCheck(t *testing.T) {
+	t.Parallel()
+	goodHTML := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+		{SubtitleID: 1770600005, ShowID: 42, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB.720p-EDITH)", DownloadFilename: "dark.s01e01.srt"},
+	}, 1, 3, true)
+	// A redesigned page: the listing moved out of the table the parser expects
+	brokenHTML := `<html><body><div class="listing">` +
+		strings.Repeat(`<div class="row"><span>Dark - 1x01</span><a href="/index.php?action=letolt&felirat=1770600005">Download</a></div>`, 30) +
+		`</div></body></html>`
+
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantOK    bool
+		wantCount int
+		wantIn    string
+	}{
+		{name: "good listing", status: http.StatusOK, body: goodHTML, wantOK: true, wantCount: 1, wantIn: "1 with a language"},
+		{name: "broken listing", status: http.StatusOK, body: brokenHTML, wantIn: "not a subtitle listing"},
+		{name: "empty listing", status: http.StatusOK, body: testutil.GenerateSubtitleTableHTML(nil), wantIn: "none with a language"},
+		{name: "captcha page", status: http.StatusOK, body: testutil.GenerateCaptchaPageHTML(), wantIn: "upstream blocked the request: captcha page"},
+		{name: "truncated listing", status: http.StatusOK, body: goodHTML[:len(goodHTML)/3], wantIn: "show 42"},
+		{name: "upstream error", status: http.StatusBadGateway, wantIn: "failed to fetch show 42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("sid") != "42" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+			testConfig.Client.SelfCheckShowID = 42
+			testConfig.Retry.MaxAttempts = 1
+			c := NewClient(testConfig)
+			defer c.Close()
+
+			result := c.SelfCheck(context.Background())
+			if result.OK != tt.wantOK {
+				t.Errorf("Expected ok=%v, got %+v", tt.wantOK, result)
+			}
+			if result.ShowID != 42 {
+				t.Errorf("Expected show 42 to be checked, got %d", result.ShowID)
+			}
+			if tt.wantOK && result.SubtitleCount != tt.wantCount {
+				t.Errorf("Expected %d subtitles, got %d", tt.wantCount, result.SubtitleCount)
+			}
+			if !strings.Contains(result.Details, tt.wantIn) {
+				t.Errorf("Expected details to mention %q, got %q", tt.wantIn, result.Details)
+			}
+		})
+	}
+}
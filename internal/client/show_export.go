@@ -0,0 +1,129 @@
+package client
+
+import (
+	"archive/zip"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// exportManifestName is the archive entry holding the ExportManifest of a show export
+const exportManifestName = "manifest.json"
+
+// ExportShowSubtitles writes every subtitle of a show in opts.Languages (all languages when empty)
+// to w as one ZIP archive, one entry per subtitle file under a folder named after its language,
+// followed by manifest.json. Files are downloaded one at a time through the subtitle downloader,
+// so cached archives are reused, and each is written to w before the next download starts.
+//
+// A failed download is recorded in the manifest and the export continues. The export stops with
+// *apperrors.ErrDownloadTooLarge when the next file would take the exported bytes over
+// opts.MaxTotalBytes, and with the context's error once ctx is done; w then holds an incomplete
+// archive. Failing to list the show's subtitles or to write to w also stops the export.
+func (c *client) ExportShowSubtitles(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // Stops the listing when the export ends early
+
+	logger := config.GetLogger()
+	manifest := &models.ExportManifest{ShowID: showID, Files: []models.ExportedFile{}}
+	zipWriter := zip.NewWriter(w)
+	var total int64
+
+	// The whole listing is read: the language early exit of GetShowSubtitles could miss old subtitles
+	for result := range c.streamSubtitles(ctx, showListing(showID), nil, models.SubtitleOrderListing) {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		subtitle := result.Value
+		if len(opts.Languages) > 0 && !hasLanguage(subtitle, opts.Languages) {
+			continue
+		}
+
+		file := models.ExportedFile{
+			SubtitleID: subtitle.ID,
+			Name:       subtitle.Name,
+			Language:   subtitle.Language,
+			Season:     subtitle.Season,
+			Episode:    subtitle.Episode,
+		}
+		download, err := c.downloadListedSubtitle(ctx, subtitle)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			logger.Warn().Err(err).Int("showID", showID).Int("subtitleID", subtitle.ID).Msg("Failed to download subtitle for export, recording it in the manifest")
+			file.Error = err.Error()
+			manifest.Files = append(manifest.Files, file)
+			manifest.Failed++
+			continue
+		}
+
+		size := int64(len(download.Content))
+		if opts.MaxTotalBytes > 0 && total+size > opts.MaxTotalBytes {
+			return nil, &apperrors.ErrDownloadTooLarge{Size: total + size, Limit: opts.MaxTotalBytes}
+		}
+
+		file.Path = exportEntryPath(subtitle, cmp.Or(subtitle.Filename, download.Filename))
+		entry, err := zipWriter.CreateHeader(&zip.FileHeader{Name: file.Path, Method: zip.Deflate, Modified: subtitle.UploadedAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to export: %w", file.Path, err)
+		}
+		if _, err := entry.Write(download.Content); err != nil {
+			return nil, fmt.Errorf("failed to write %s to export: %w", file.Path, err)
+		}
+		// Hand the finished entry on so the export streams file by file
+		if err := zipWriter.Flush(); err != nil {
+			return nil, fmt.Errorf("failed to write %s to export: %w", file.Path, err)
+		}
+		total += size
+		file.Size, file.Sha256 = size, download.Sha256
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	// A cancelled listing closes its channel without an error
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entry, err := zipWriter.Create(exportManifestName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to export: %w", exportManifestName, err)
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write %s to export: %w", exportManifestName, err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish export: %w", err)
+	}
+
+	logger.Info().Int("showID", showID).Int("files", len(manifest.Files)-manifest.Failed).Int("failed", manifest.Failed).Int64("bytes", total).Msg("Exported show subtitles")
+	return manifest, nil
+}
+
+// downloadListedSubtitle downloads the whole file of a subtitle from a listing. Synthetic IDs
+// cannot be turned into a link, so those subtitles are downloaded from their listed URL.
+func (c *client) downloadListedSubtitle(ctx context.Context, subtitle models.Subtitle) (*models.DownloadResult, error) {
+	if subtitle.IDIsSynthetic {
+		return c.DownloadSubtitleByURL(ctx, subtitle.DownloadURL, models.DownloadOptions{})
+	}
+	return c.DownloadSubtitle(ctx, strconv.Itoa(subtitle.ID), models.DownloadOptions{})
+}
+
+// exportEntryPath names the archive entry of a subtitle saved as filename, as in
+// "en/1770600001-show.s01e03.srt". The subtitle ID keeps releases sharing a filename apart.
+func exportEntryPath(subtitle models.Subtitle, filename string) string {
+	filename = strings.NewReplacer("/", "_", "\\", "_").Replace(filename)
+	if filename == "" {
+		return fmt.Sprintf("%s/%d", cmp.Or(subtitle.Language, "unknown"), subtitle.ID)
+	}
+	return fmt.Sprintf("%s/%d-%s", cmp.Or(subtitle.Language, "unknown"), subtitle.ID, filename)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+// newExportServer serves a listing of show 1 with two Hungarian subtitles and one English one.
+// Subtitle 1770600002 fails to download.
+func newExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	listing := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{SubtitleID: 1770600001, ShowID: 1, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB)", DownloadFilename: "dark.s01e01.srt"},
+		{SubtitleID: 1770600002, ShowID: 1, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Dark - 1x02", EredetiTitle: "Dark - 1x02 (WEB)", DownloadFilename: "dark.s01e02.srt"},
+		{SubtitleID: 1770600003, ShowID: 1, Language: "Angol", FlagImage: "uk.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB)", DownloadFilename: "dark.s01e01.srt"},
+	})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") != "letolt" {
+			_, _ = w.Write([]byte(listing))
+			return
+		}
+		id := r.URL.Query().Get("felirat")
+		if id == "1770600002" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.Header().Set("Content-Disposition", `attachment; filename="dark.s01e01.srt"`)
+		_, _ = w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nSubtitle " + id + "\n"))
+	}))
+}
+
+func TestClient_ExportShowSubtitles(t *testing.T) {
+	t.Parallel()
+	server := newExportServer(t)
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	var buf bytes.Buffer
+	manifest, err := c.ExportShowSubtitles(context.Background(), 1, models.ExportOptions{}, &buf)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(manifest.Files) != 3 || manifest.Failed != 1 {
+		t.Fatalf("Expected 3 files with 1 failure, got %+v", manifest)
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Expected a valid ZIP archive, got: %v", err)
+	}
+	entries := map[string]string{}
+	for _, file := range zipReader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		entries[file.Name] = string(content)
+	}
+	if len(entries) != 3 {
+		t.Errorf("Expected two subtitles and the manifest, got %v", entries)
+	}
+	for _, name := range []string{"hu/1770600001-dark.s01e01.srt", "en/1770600003-dark.s01e01.srt"} {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("Expected entry %s, got %v", name, entries)
+		}
+	}
+
+	var written models.ExportManifest
+	if err := json.Unmarshal([]byte(entries["manifest.json"]), &written); err != nil {
+		t.Fatalf("Expected manifest.json to hold the manifest, got: %v", err)
+	}
+	for _, file := range written.Files {
+		if file.SubtitleID == 1770600002 && (file.Error == "" || file.Path != "") {
+			t.Errorf("Expected the failed download to be recorded with its error, got %+v", file)
+		}
+		if file.SubtitleID != 1770600002 && (file.Error != "" || file.Size == 0 || file.Sha256 == "") {
+			t.Errorf("Expected the exported file to be recorded with its size and checksum, got %+v", file)
+		}
+	}
+}
+
+func TestClient_ExportShowSubtitles_Languages(t *testing.T) {
+	t.Parallel()
+	server := newExportServer(t)
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	manifest, err := c.ExportShowSubtitles(context.Background(), 1, models.ExportOptions{Languages: []string{"EN"}}, io.Discard)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(manifest.Files) != 1 || manifest.Files[0].SubtitleID != 1770600003 {
+		t.Errorf("Expected only the English subtitle, got %+v", manifest.Files)
+	}
+}
+
+func TestClient_ExportShowSubtitles_MaxTotalBytes(t *testing.T) {
+	t.Parallel()
+	server := newExportServer(t)
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	// Room for the first file only
+	_, err := c.ExportShowSubtitles(context.Background(), 1, models.ExportOptions{MaxTotalBytes: 60}, io.Discard)
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 60 {
+		t.Fatalf("Expected ErrDownloadTooLarge with limit 60, got %v", err)
+	}
+}
+
+func TestClient_ExportShowSubtitles_CancelledContext(t *testing.T) {
+	t.Parallel()
+	server := newExportServer(t)
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ExportShowSubtitles(ctx, 1, models.ExportOptions{}, io.Discard); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled instead of an archive of failures, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// GetShowImage returns the poster of a show, from the image cache or downloaded from the site.
+func (c *client) GetShowImage(ctx context.Context, showID int) (*models.ShowImage, error) {
+	return c.showImages.FetchShowImage(ctx, showID)
+}
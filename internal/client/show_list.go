@@ -2,117 +2,162 @@ package client
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"sync"
-	"sync/atomic"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 // pageBatchSize controls how many pages are fetched in parallel at once.
 const pageBatchSize = 10
 
-// streamState holds the shared state used across goroutines when streaming shows.
+// showListEndpoint is a show list page and the source reported for the shows it lists.
+type showListEndpoint struct {
+	sorf   string
+	source models.ShowSource
+}
+
+// showListEndpoints are queried in parallel; their order is the order of Show.Sources.
+var showListEndpoints = []showListEndpoint{
+	{sorf: "varakozik-subrip", source: models.ShowSourceWaiting},
+	{sorf: "alatt-subrip", source: models.ShowSourceUnderTranslation},
+	{sorf: "nem-all-forditas-alatt", source: models.ShowSourceNotUnderTranslation},
+}
+
+// streamState holds the shared state used across goroutines when collecting shows.
 type streamState struct {
-	seen           *sync.Map
-	sentShows      *int64
-	errsMu         *sync.Mutex
-	endpointErrors *[]error
-	ch             chan<- models.StreamResult[models.Show]
+	afterID        int
+	showsMu        sync.Mutex
+	shows          []models.Show // In first-seen order
+	showIndex      map[int]int   // Show ID to its position in shows
+	errsMu         sync.Mutex
+	endpointErrors []error
 }
 
-// StreamShowList streams shows as they become available from multiple endpoints.
-// Shows are deduplicated by ID on the fly. The channel is closed when all endpoints have been processed.
+// addShow records that source lists show, merging it into an earlier sighting of the same ID.
+func (s *streamState) addShow(show models.Show, source models.ShowSource) {
+	s.showsMu.Lock()
+	defer s.showsMu.Unlock()
+
+	if i, ok := s.showIndex[show.ID]; ok {
+		if !slices.Contains(s.shows[i].Sources, source) {
+			s.shows[i].Sources = append(s.shows[i].Sources, source)
+		}
+		return
+	}
+	show.Sources = []models.ShowSource{source}
+	s.showIndex[show.ID] = len(s.shows)
+	s.shows = append(s.shows, show)
+}
+
+// StreamShowList streams the shows listed on the show list endpoints.
+// Shows are deduplicated by ID, and Show.Sources reports every endpoint that listed the show,
+// so a show that is both waiting and under translation is sent once with both sources.
+// Because a later page of another endpoint can still add a source, shows are sent once every
+// endpoint has been processed; the channel is closed after the last one.
 // Paginated endpoints are detected automatically: page 1 is fetched first to discover the total page count,
 // then remaining pages are fetched in parallel batches of pageBatchSize.
-func (c *client) StreamShowList(ctx context.Context) <-chan models.StreamResult[models.Show] {
+// When afterID is positive, shows with that ID or lower are skipped so an interrupted sync can resume;
+// the listings are not ordered by ID, so every page is still fetched.
+func (c *client) StreamShowList(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show] {
 	ch := make(chan models.StreamResult[models.Show])
 
 	go func() {
 		defer close(ch)
 		logger := config.GetLogger()
-		logger.Info().Str("baseURL", c.baseURL).Msg("Streaming show list from multiple endpoints in parallel")
-
-		// Endpoints to query in parallel
-		endpoints := []string{
-			fmt.Sprintf("%s/index.php?sorf=varakozik-subrip", c.baseURL),
-			fmt.Sprintf("%s/index.php?sorf=alatt-subrip", c.baseURL),
-			fmt.Sprintf("%s/index.php?sorf=nem-all-forditas-alatt", c.baseURL),
-		}
-
-		var seen sync.Map
-		var sentShows int64
-		var errsMu sync.Mutex
-		var endpointErrors []error
+		logger.Info().Str("baseURL", c.baseURL).Int("afterID", afterID).Msg("Streaming show list from multiple endpoints in parallel")
 
 		state := &streamState{
-			seen:           &seen,
-			sentShows:      &sentShows,
-			errsMu:         &errsMu,
-			endpointErrors: &endpointErrors,
-			ch:             ch,
+			afterID:   afterID,
+			showIndex: make(map[int]int),
 		}
 
-		// Run all fetches in parallel and stream results as they arrive
+		// Run all fetches in parallel and merge their shows
 		var wg sync.WaitGroup
-		wg.Add(len(endpoints))
+		wg.Add(len(showListEndpoints))
 
-		for _, ep := range endpoints {
+		for _, ep := range showListEndpoints {
 			go func() {
 				defer wg.Done()
-				c.fetchEndpointPages(ctx, ep, state)
+				c.fetchEndpointPages(ctx, fmt.Sprintf("%s/index.php?sorf=%s", c.baseURL, ep.sorf), ep.source, state)
 			}()
 		}
 
 		// Wait for all endpoints to complete
 		wg.Wait()
 
+		sentShows := 0
+		for _, show := range state.shows {
+			slices.SortFunc(show.Sources, compareShowSources)
+			select {
+			case ch <- models.StreamResult[models.Show]{Value: show}:
+				sentShows++
+			case <-ctx.Done():
+				return
+			}
+		}
+
 		// Check final status
-		errsMu.Lock()
-		errs := endpointErrors
-		errsMu.Unlock()
+		errs := state.endpointErrors
 
-		if atomic.LoadInt64(&sentShows) == 0 && len(errs) == len(endpoints) {
+		if sentShows == 0 && len(errs) == len(showListEndpoints) {
 			select {
-			case ch <- models.StreamResult[models.Show]{Err: fmt.Errorf("all show list endpoints failed: %v", errors.Join(errs...))}:
+			case ch <- models.StreamResult[models.Show]{Err: fmt.Errorf("all show list endpoints failed: %w", errors.Join(errs...))}:
 			case <-ctx.Done():
 			}
 		} else if len(errs) > 0 {
-			logger.Warn().Err(errors.Join(errs...)).Int("successful_endpoints", len(endpoints)-len(errs)).Msg("Partial success fetching show lists")
-		} else if atomic.LoadInt64(&sentShows) > 0 {
-			logger.Info().Msg("Successfully fetched show lists from all endpoints")
+			logger.Warn().Err(errors.Join(errs...)).Int("successful_endpoints", len(showListEndpoints)-len(errs)).Msg("Partial success fetching show lists")
+		} else if sentShows > 0 {
+			logger.Info().Int("shows", sentShows).Msg("Successfully fetched show lists from all endpoints")
 		}
 	}()
 
 	return ch
 }
 
+// compareShowSources orders sources like showListEndpoints.
+func compareShowSources(a, b models.ShowSource) int {
+	position := func(source models.ShowSource) int {
+		return slices.IndexFunc(showListEndpoints, func(ep showListEndpoint) bool { return ep.source == source })
+	}
+	return cmp.Compare(position(a), position(b))
+}
+
 // fetchEndpointPages fetches page 1 of the endpoint, discovers the total page count from
 // the pagination HTML, then fetches remaining pages in parallel batches.
-func (c *client) fetchEndpointPages(ctx context.Context, endpoint string, state *streamState) {
+func (c *client) fetchEndpointPages(ctx context.Context, endpoint string, source models.ShowSource, state *streamState) {
 	logger := config.GetLogger()
 
 	// Helper to record an endpoint-level error
 	recordError := func(err error) {
 		state.errsMu.Lock()
-		*state.endpointErrors = append(*state.endpointErrors, err)
+		state.endpointErrors = append(state.endpointErrors, err)
 		state.errsMu.Unlock()
 	}
 
 	// --- Fetch page 1 ---
-	bodyBytes, err := c.fetchPage(ctx, endpoint)
+	bodyBytes, err := c.fetchPageHedged(ctx, endpoint)
 	if err != nil {
 		logger.Warn().Err(err).Str("endpoint", endpoint).Msg("Failed to fetch first page")
 		recordError(err)
 		return
 	}
 
-	c.streamShowsFromBody(ctx, bodyBytes, state)
+	// A block or maintenance page on page 1 fails the endpoint, so a ban or an outage is not
+	// mistaken for an empty list
+	if err := c.collectShowsFromBody(bodyBytes, source, state); errors.Is(err, &apperrors.ErrUpstreamBlocked{}) || errors.Is(err, &apperrors.ErrUpstreamMaintenance{}) {
+		recordError(err)
+		return
+	}
 
 	// --- Discover total pages ---
 	lastPage := c.showParser.ExtractLastPage(bytes.NewReader(bodyBytes))
@@ -136,13 +181,13 @@ func (c *client) fetchEndpointPages(ctx context.Context, endpoint string, state
 			go func() {
 				defer batchWg.Done()
 
-				pageBody, err := c.fetchPage(ctx, pageURL)
+				pageBody, err := c.fetchPageHedged(ctx, pageURL)
 				if err != nil {
 					logger.Warn().Err(err).Str("url", pageURL).Msg("Failed to fetch page")
 					return
 				}
 
-				c.streamShowsFromBody(ctx, pageBody, state)
+				_ = c.collectShowsFromBody(pageBody, source, state)
 			}()
 		}
 
@@ -166,6 +211,7 @@ func (c *client) fetchPage(ctx context.Context, url string) ([]byte, error) {
 	req.Header.Set("User-Agent", config.GetUserAgent())
 
 	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointShowList, resp, err)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
@@ -175,32 +221,31 @@ func (c *client) fetchPage(ctx context.Context, url string) ([]byte, error) {
 		return nil, fmt.Errorf("page returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(metrics.NewUpstreamBody(metrics.UpstreamEndpointShowList, resp.Body))
 	if err != nil {
 		return nil, fmt.Errorf("read body: %w", err)
 	}
 	return body, nil
 }
 
-// streamShowsFromBody parses shows from HTML bytes and sends them to the channel,
-// deduplicating by show ID.
-func (c *client) streamShowsFromBody(ctx context.Context, bodyBytes []byte, state *streamState) {
+// collectShowsFromBody parses shows from HTML bytes and merges them into the state under source.
+// It returns the parse error, which has already been logged; the outcome updates the client's
+// block state.
+func (c *client) collectShowsFromBody(bodyBytes []byte, source models.ShowSource, state *streamState) error {
+	logger := config.GetLogger()
 	shows, err := c.showParser.ParseHtml(bytes.NewReader(bodyBytes))
+	c.blocks.observe(err)
 	if err != nil {
-		logger := config.GetLogger()
-		logger.Warn().Err(err).Msg("Failed to parse shows from page body")
-		return
+		logger.Warn().Err(err).Int("bytes", len(bodyBytes)).Msg("Failed to parse shows from page body")
+		return err
 	}
+	logger.Debug().Int("shows", len(shows)).Int("bytes", len(bodyBytes)).Str("source", string(source)).Msg("Parsed shows from page body")
 
 	for _, s := range shows {
-		if _, exists := state.seen.LoadOrStore(s.ID, struct{}{}); exists {
+		if s.ID <= state.afterID {
 			continue
 		}
-		select {
-		case state.ch <- models.StreamResult[models.Show]{Value: s}:
-			atomic.AddInt64(state.sentShows, 1)
-		case <-ctx.Done():
-			return
-		}
+		state.addShow(s, source)
 	}
+	return nil
 }
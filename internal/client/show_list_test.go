@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
@@ -51,7 +52,7 @@ func TestClient_GetShowList(t *testing.T) {
 
 	// Call GetShowList
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call succeeds
 	if err != nil {
@@ -125,7 +126,7 @@ func TestClient_GetShowList_ServerError(t *testing.T) {
 
 	// Call GetShowList
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call fails with an error
 	if err == nil {
@@ -158,7 +159,7 @@ func TestClient_GetShowList_PartialFailure(t *testing.T) {
 	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "5s"}
 	client := NewClient(testConfig)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	if err != nil { // Should not fail completely when one endpoint succeeds
 		t.Fatalf("Expected no error with partial success, got: %v", err)
@@ -192,7 +193,7 @@ func TestClient_GetShowList_Timeout(t *testing.T) {
 
 	// Call GetShowList
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call fails with timeout error
 	if err == nil {
@@ -224,7 +225,7 @@ func TestClient_GetShowList_InvalidHTML(t *testing.T) {
 
 	// Call GetShowList
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call succeeds but returns empty results
 	if err != nil {
@@ -262,7 +263,7 @@ func TestClient_GetShowList_WithProxy(t *testing.T) {
 
 	// Call GetShowList
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	// Test that the call succeeds (proxy configuration should not break the request)
 	if err != nil {
@@ -323,7 +324,7 @@ func TestClient_GetShowList_WithPagination(t *testing.T) {
 
 	client := NewClient(testConfig)
 	ctx := context.Background()
-	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx))
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
 
 	if err != nil {
 		t.Fatalf("Expected no error with pagination, got: %v", err)
@@ -348,3 +349,97 @@ func TestClient_GetShowList_WithPagination(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_GetShowList_AfterID(t *testing.T) {
+	t.Parallel()
+	waitingHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12190, ShowName: "7 Bears", Year: 2025},
+		{ShowID: 12007, ShowName: "Asura", Year: 2024},
+	})
+	underHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12076, ShowName: "Adults", Year: 2024},
+		{ShowID: 12549, ShowName: "A Thousand Blows", Year: 2025},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("sorf") {
+		case "varakozik-subrip":
+			_, _ = w.Write([]byte(waitingHTML))
+		case "alatt-subrip":
+			_, _ = w.Write([]byte(underHTML))
+		default:
+			_, _ = w.Write([]byte(testutil.GenerateShowTableHTML(nil)))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	})
+	ctx := context.Background()
+
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 12076))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	got := make(map[int]bool)
+	for _, show := range shows {
+		got[show.ID] = true
+	}
+	if len(shows) != 2 || !got[12190] || !got[12549] {
+		t.Errorf("Expected only shows 12190 and 12549 after ID 12076, got %+v", shows)
+	}
+}
+
+func TestClient_GetShowList_MergesSources(t *testing.T) {
+	t.Parallel()
+	waitingHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12190, ShowName: "7 Bears", Year: 2025},
+		{ShowID: 12007, ShowName: "Asura", Year: 2024},
+	})
+	underHTML := testutil.GenerateShowTableHTML([]testutil.ShowRowOptions{
+		{ShowID: 12007, ShowName: "Asura", Year: 2024},
+		{ShowID: 12076, ShowName: "Adults", Year: 2024},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("sorf") {
+		case "varakozik-subrip":
+			_, _ = w.Write([]byte(waitingHTML))
+		case "alatt-subrip":
+			_, _ = w.Write([]byte(underHTML))
+		default:
+			_, _ = w.Write([]byte(testutil.GenerateShowTableHTML(nil)))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	})
+	ctx := context.Background()
+
+	shows, err := testutil.CollectShows(ctx, client.StreamShowList(ctx, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(shows) != 3 {
+		t.Fatalf("Expected the show listed on both endpoints once, got %+v", shows)
+	}
+
+	expected := map[int][]models.ShowSource{
+		12190: {models.ShowSourceWaiting},
+		12007: {models.ShowSourceWaiting, models.ShowSourceUnderTranslation},
+		12076: {models.ShowSourceUnderTranslation},
+	}
+	for _, show := range shows {
+		if !slices.Equal(show.Sources, expected[show.ID]) {
+			t.Errorf("Show %d: expected sources %v, got %v", show.ID, expected[show.ID], show.Sources)
+		}
+	}
+}
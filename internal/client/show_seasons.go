@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/services"
+)
+
+// GetShowSeasons summarizes which seasons of a show have subtitles. The whole listing is streamed:
+// pages are ordered by upload date rather than by season, so an older page can still add episodes
+// to any season and stopping early would undercount. A failed fetch is returned as an error.
+func (c *client) GetShowSeasons(ctx context.Context, showID int) (*models.ShowSeasons, error) {
+	var subtitles []models.Subtitle
+	for result := range c.StreamSubtitles(ctx, showID) {
+		if result.Err != nil {
+			return nil, fmt.Errorf("failed to fetch subtitles for show %d: %w", showID, result.Err)
+		}
+		subtitles = append(subtitles, result.Value)
+	}
+
+	seasons := services.SummarizeSeasons(subtitles)
+	logger := config.GetLogger()
+	logger.Debug().
+		Int("showID", showID).
+		Int("subtitles", len(subtitles)).
+		Int("seasons", len(seasons.Seasons)).
+		Int("unknown", seasons.UnknownCount).
+		Msg("Summarized show seasons")
+	return &seasons, nil
+}
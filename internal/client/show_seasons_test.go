@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestClient_GetShowSeasons(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sid") != "123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600006, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid - Special", EredetiTitle: "Billy the Kid - Special (WEB.720p-EDITH)", DownloadFilename: "billy.special.srt"},
+			{SubtitleID: 1770600005, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", DownloadFilename: "billy.s02e05.srt", UploadDate: "2026-03-13"},
+			{SubtitleID: 1770600004, ShowID: 123, Language: "Angol", FlagImage: "uk.gif", MagyarTitle: "Billy the Kid - 2x05", EredetiTitle: "Billy the Kid - 2x05 - Hunted (WEB.720p-EDITH)", DownloadFilename: "billy.s02e05.en.srt"},
+			{SubtitleID: 1770600003, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid - 2x04", EredetiTitle: "Billy the Kid - 2x04 - Outlaw (WEB.720p-EDITH)", DownloadFilename: "billy.s02e04.srt"},
+			{SubtitleID: 1770600002, ShowID: 123, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Billy the Kid (1. évad)", EredetiTitle: "Billy the Kid (Season 1) (WEB.720p-EDITH)", DownloadFilename: "billy.s01.zip"},
+		}, 1, 1, true)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	seasons, err := c.GetShowSeasons(context.Background(), 123)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if seasons.UnknownCount != 1 {
+		t.Errorf("Expected 1 subtitle with an unknown season, got %d", seasons.UnknownCount)
+	}
+	if len(seasons.Seasons) != 2 {
+		t.Fatalf("Expected 2 seasons, got %+v", seasons.Seasons)
+	}
+	if s := seasons.Seasons[0]; s.Season != 1 || s.EpisodeCount != 0 || !s.SeasonPackAvailable {
+		t.Errorf("Unexpected season 1 summary: %+v", s)
+	}
+	if s := seasons.Seasons[1]; s.Season != 2 || s.EpisodeCount != 2 || s.SeasonPackAvailable || s.LatestUpload.IsZero() {
+		t.Errorf("Unexpected season 2 summary: %+v", s)
+	}
+}
+
+func TestClient_GetShowSeasons_FetchError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	if _, err := c.GetShowSeasons(context.Background(), 999); err == nil {
+		t.Fatal("Expected error for unknown show, got nil")
+	}
+}
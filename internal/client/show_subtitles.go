@@ -5,38 +5,82 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
+const (
+	// defaultPerShowTimeout bounds each show's fetch when client.per_show_timeout is empty.
+	defaultPerShowTimeout = 30 * time.Second
+	// defaultLanguageEarlyExitPages is used when client.language_early_exit_pages is zero.
+	defaultLanguageEarlyExitPages = 2
+)
+
 // StreamShowSubtitles streams complete ShowSubtitles (show info + all subtitles) for multiple shows.
 // For each show, it accumulates all subtitles, fetches third-party IDs, then sends the complete collection.
-// Shows are processed in batches of 20 to limit concurrency.
-func (c *client) StreamShowSubtitles(ctx context.Context, shows []models.Show) <-chan models.StreamResult[models.ShowSubtitles] {
+// At most showSubtitlesConcurrency shows are fetched at once; results are sent as each show completes.
+// A show whose fetch runs past perShowTimeout is sent as an ErrShowTimeout result and the others continue.
+// When languages is not empty, only subtitles in those languages are sent and shows without any are
+// skipped. A show with no match on its first languageEarlyExitPages listing pages is not paginated
+// further, so a language that only appears on later pages is missed.
+func (c *client) StreamShowSubtitles(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles] {
 	ch := make(chan models.StreamResult[models.ShowSubtitles])
 
 	go func() {
 		defer close(ch)
 		logger := config.GetLogger()
-		logger.Info().Int("showCount", len(shows)).Msg("Streaming show subtitles in batches")
+		logger.Info().Int("showCount", len(shows)).Int("concurrency", c.showSubtitlesConcurrency).Strs("languages", languages).Msg("Streaming show subtitles")
 
-		const batchSize = 20
+		var errorsMu sync.Mutex
 		var allErrors []error
-		successCount := 0
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, c.showSubtitlesConcurrency)
+		scheduled := 0
 
-		for i := 0; i < len(shows); i += batchSize {
-			end := min(i+batchSize, len(shows))
+	schedule:
+		for _, show := range shows {
+			// Stop scheduling new shows once the context is cancelled
+			if ctx.Err() != nil {
+				break
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break schedule
+			}
+			scheduled++
 
-			batch := shows[i:end]
-			logger.Info().Int("batchStart", i).Int("batchEnd", end-1).Int("batchSize", len(batch)).Msg("Processing batch of shows")
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
 
-			batchErrors := c.streamShowBatch(ctx, batch, ch)
-			successCount += len(batch) - len(batchErrors)
-			allErrors = append(allErrors, batchErrors...)
+				if err := c.streamShow(ctx, show, languages, ch); err != nil {
+					var timeoutErr *apperrors.ErrShowTimeout
+					if errors.As(err, &timeoutErr) {
+						sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: err})
+					}
+					errorsMu.Lock()
+					allErrors = append(allErrors, err)
+					errorsMu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			logger.Warn().Err(ctx.Err()).Int("scheduledShows", scheduled).Int("totalShows", len(shows)).Msg("Show subtitles streaming cancelled")
+			return
 		}
 
+		successCount := scheduled - len(allErrors)
 		if successCount == 0 && len(allErrors) > 0 {
 			sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Err: fmt.Errorf("all shows failed processing: %v", errors.Join(allErrors...))})
 		} else if len(allErrors) > 0 {
@@ -49,103 +93,189 @@ func (c *client) StreamShowSubtitles(ctx context.Context, shows []models.Show) <
 	return ch
 }
 
-// streamShowBatch processes a batch of shows concurrently, streaming results to the channel.
-// Returns a list of errors for shows that failed.
-func (c *client) streamShowBatch(ctx context.Context, shows []models.Show, ch chan<- models.StreamResult[models.ShowSubtitles]) []error {
+// streamShow accumulates all subtitles and third-party IDs for a single show and sends the
+// complete ShowSubtitles to the channel. When languages is not empty, only subtitles in those
+// languages are kept and a show left without any is not sent. Returns an error if the show's
+// subtitles could not be streamed, or an ErrShowTimeout when fetching them ran past perShowTimeout.
+func (c *client) streamShow(ctx context.Context, show models.Show, languages []string, ch chan<- models.StreamResult[models.ShowSubtitles]) error {
 	logger := config.GetLogger()
 
-	var errorsMu sync.Mutex
-	var errs []error
-	var wg sync.WaitGroup
-	wg.Add(len(shows))
+	// The deadline covers fetching only; sending the result waits on the caller's context
+	fetchCtx, cancel := c.withShowTimeout(ctx)
+	defer cancel()
 
-	for _, show := range shows {
-		go func() {
-			defer wg.Done()
+	// Accumulate all subtitles for this show
+	var subtitles []models.Subtitle
+	var firstValidSubtitleID int
 
-			// Accumulate all subtitles for this show
-			var subtitles []models.Subtitle
-			var firstValidSubtitleID int
+	for result := range c.streamSubtitles(fetchCtx, showListing(show.ID), languages, models.SubtitleOrderListing) {
+		if err := c.showTimeoutError(ctx, fetchCtx, show.ID); err != nil {
+			logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Show fetch timed out")
+			return err
+		}
+		if result.Err != nil {
+			logger.Warn().Err(result.Err).Int("showID", show.ID).Str("showName", show.Name).Msg("Failed to stream subtitles for show")
+			return fmt.Errorf("failed to stream subtitles for show %d: %w", show.ID, result.Err)
+		}
+		// Log error and skip subtitle if ID is invalid
+		if result.Value.ID <= 0 && !result.Value.IDIsSynthetic {
+			logger.Error().
+				Int("showID", show.ID).
+				Str("showName", show.Name).
+				Int("subtitleID", result.Value.ID).
+				Str("subtitleName", result.Value.Name).
+				Int("season", result.Value.Season).
+				Int("episode", result.Value.Episode).
+				Str("language", result.Value.Language).
+				Str("filename", result.Value.Filename).
+				Msg("Received subtitle with invalid ID, discarding")
+			continue
+		}
 
-			for result := range c.StreamSubtitles(ctx, show.ID) {
-				if result.Err != nil {
-					logger.Warn().Err(result.Err).Int("showID", show.ID).Str("showName", show.Name).Msg("Failed to stream subtitles for show")
-					errorsMu.Lock()
-					errs = append(errs, fmt.Errorf("failed to stream subtitles for show %d: %w", show.ID, result.Err))
-					errorsMu.Unlock()
-					return
-				}
-				// Log error and skip subtitle if ID is invalid
-				if result.Value.ID <= 0 {
-					logger.Error().
-						Int("showID", show.ID).
-						Str("showName", show.Name).
-						Int("subtitleID", result.Value.ID).
-						Str("subtitleName", result.Value.Name).
-						Int("season", result.Value.Season).
-						Int("episode", result.Value.Episode).
-						Str("language", result.Value.Language).
-						Str("filename", result.Value.Filename).
-						Msg("Received subtitle with invalid ID, discarding")
-					continue
-				}
+		// Detail pages are only reachable through a real felirat ID
+		if firstValidSubtitleID == 0 && !result.Value.IDIsSynthetic {
+			firstValidSubtitleID = result.Value.ID
+		}
+		subtitles = append(subtitles, result.Value)
+	}
+	if err := c.showTimeoutError(ctx, fetchCtx, show.ID); err != nil {
+		logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Show fetch timed out")
+		return err
+	}
 
-				if firstValidSubtitleID == 0 {
-					firstValidSubtitleID = result.Value.ID
-				}
-				subtitles = append(subtitles, result.Value)
-			}
+	if len(languages) > 0 {
+		subtitles = slices.DeleteFunc(subtitles, func(subtitle models.Subtitle) bool {
+			return !hasLanguage(subtitle, languages)
+		})
+		if len(subtitles) == 0 {
+			logger.Debug().Int("showID", show.ID).Str("showName", show.Name).Strs("languages", languages).Msg("No subtitles in the requested languages, skipping show")
+			return nil
+		}
+	}
 
-			// Fetch third-party IDs using first valid subtitle ID
-			var thirdPartyIds models.ThirdPartyIds
-			if firstValidSubtitleID > 0 {
-				thirdPartyIds = c.fetchThirdPartyIds(ctx, show, firstValidSubtitleID)
-				foundThirdPartyIds := thirdPartyIds.IMDBID != "" || thirdPartyIds.TVDBID != 0
-				logger.Debug().
-					Int("showID", show.ID).
-					Str("showName", show.Name).
-					Str("imdbId", thirdPartyIds.IMDBID).
-					Int("tvdbId", thirdPartyIds.TVDBID).
-					Bool("foundThirdPartyIds", foundThirdPartyIds).
-					Msg("Fetched third-party IDs")
-			} else {
-				logger.Warn().Int("showID", show.ID).Str("showName", show.Name).Msg("No valid subtitle ID found, sending with empty third-party IDs")
-			}
+	// Fetch third-party IDs, year and status using first valid subtitle ID
+	var thirdPartyIds models.ThirdPartyIds
+	if firstValidSubtitleID > 0 {
+		details := c.fetchShowDetails(fetchCtx, show, firstValidSubtitleID)
+		if err := c.showTimeoutError(ctx, fetchCtx, show.ID); err != nil {
+			logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Show detail page timed out")
+			return err
+		}
+		thirdPartyIds = details.ThirdPartyIds
+		show = applyShowDetails(show, details)
+		foundThirdPartyIds := thirdPartyIds.IMDBID != "" || thirdPartyIds.TVDBID != 0
+		logger.Debug().
+			Int("showID", show.ID).
+			Str("showName", show.Name).
+			Str("imdbId", thirdPartyIds.IMDBID).
+			Int("tvdbId", thirdPartyIds.TVDBID).
+			Int("year", show.Year).
+			Str("status", show.Status).
+			Bool("foundThirdPartyIds", foundThirdPartyIds).
+			Msg("Fetched show details")
+	} else {
+		logger.Warn().Int("showID", show.ID).Str("showName", show.Name).Msg("No valid subtitle ID found, sending with empty third-party IDs")
+	}
 
-			// Build show name from subtitles if available
-			showName := show.Name
-			if len(subtitles) > 0 {
-				showName = subtitles[0].ShowName
-			}
+	// Build show name from subtitles if available
+	showName := show.Name
+	if len(subtitles) > 0 {
+		showName = subtitles[0].ShowName
+	}
+	show.Aliases = showAliases(show.Aliases, subtitles)
 
-			// Send complete ShowSubtitles
-			showSubtitles := models.ShowSubtitles{
-				Show:          show,
-				ThirdPartyIds: thirdPartyIds,
-				SubtitleCollection: models.SubtitleCollection{
-					ShowName:  showName,
-					Subtitles: subtitles,
-					Total:     len(subtitles),
-				},
-			}
+	// The collection is sorted so it does not depend on page order
+	models.SortSubtitles(subtitles, models.SubtitleOrderSeasonEpisode)
 
-			select {
-			case ch <- models.StreamResult[models.ShowSubtitles]{Value: showSubtitles}:
-			case <-ctx.Done():
-				return
-			}
-		}()
+	// Send complete ShowSubtitles
+	showSubtitles := models.ShowSubtitles{
+		Show:          show,
+		ThirdPartyIds: thirdPartyIds,
+		SubtitleCollection: models.SubtitleCollection{
+			ShowName:  showName,
+			Subtitles: subtitles,
+			Total:     len(subtitles),
+		},
 	}
 
-	wg.Wait()
-	return errs
+	// A complete listing refreshes the FindSubtitle index for this show; a language-filtered one is partial
+	if len(languages) == 0 {
+		c.subtitleIndex.Ingest(showSubtitles)
+	}
+
+	sendResult(ctx, ch, models.StreamResult[models.ShowSubtitles]{Value: showSubtitles})
+	return nil
+}
+
+// hasLanguage reports whether subtitle is in one of languages, ignoring case.
+func hasLanguage(subtitle models.Subtitle, languages []string) bool {
+	return slices.ContainsFunc(languages, func(language string) bool {
+		return strings.EqualFold(subtitle.Language, language)
+	})
 }
 
-// fetchThirdPartyIds fetches third-party IDs for a show using the given episode ID.
-// Returns empty ThirdPartyIds on error (logs warning but doesn't fail).
-func (c *client) fetchThirdPartyIds(ctx context.Context, show models.Show, episodeID int) models.ThirdPartyIds {
+// withShowTimeout derives the context bounding a single show's fetch from ctx.
+// A zero perShowTimeout only adds cancellation.
+func (c *client) withShowTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.perShowTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.perShowTimeout)
+}
+
+// showTimeoutError returns an ErrShowTimeout when fetchCtx ran past the per-show deadline while
+// ctx is still live, and nil otherwise; a cancelled caller is not a slow show.
+func (c *client) showTimeoutError(ctx, fetchCtx context.Context, showID int) error {
+	if ctx.Err() == nil && errors.Is(fetchCtx.Err(), context.DeadlineExceeded) {
+		return &apperrors.ErrShowTimeout{ShowID: showID, Timeout: c.perShowTimeout}
+	}
+	return nil
+}
+
+// showAliases merges the original and Hungarian show titles carried by the subtitles into aliases.
+// Titles are trimmed and deduplicated case-insensitively, keeping the first spelling encountered.
+func showAliases(aliases []string, subtitles []models.Subtitle) []string {
+	seen := make(map[string]struct{}, len(aliases)+2)
+	result := make([]string, 0, len(aliases)+2)
+	add := func(title string) {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return
+		}
+		key := strings.ToLower(title)
+		if _, exists := seen[key]; exists {
+			return
+		}
+		seen[key] = struct{}{}
+		result = append(result, title)
+	}
+
+	for _, alias := range aliases {
+		add(alias)
+	}
+	for _, subtitle := range subtitles {
+		add(subtitle.ShowName)
+		add(subtitle.HungarianShowName)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// fetchShowDetails fetches the detail page of the given episode ID for its third-party IDs, show year and status.
+// They are answered from the show details cache when it has the show; a parsed page is cached, while a
+// failed fetch returns empty details and is not cached, so the next listing tries again.
+// Returns empty SubtitleDetails on error (logs warning but doesn't fail).
+func (c *client) fetchShowDetails(ctx context.Context, show models.Show, episodeID int) models.SubtitleDetails {
 	logger := config.GetLogger()
 
+	if details, ok := c.showDetails.Get(show.ID); ok {
+		logger.Debug().Int("showID", show.ID).Msg("Show details served from cache")
+		return details
+	}
+
 	// Construct detail page URL
 	detailURL := fmt.Sprintf("%s/index.php?tipus=adatlap&azon=a_%d", c.baseURL, episodeID)
 
@@ -153,28 +283,40 @@ func (c *client) fetchThirdPartyIds(ctx context.Context, show models.Show, episo
 	req, err := http.NewRequestWithContext(ctx, "GET", detailURL, nil)
 	if err != nil {
 		logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Failed to create detail page request")
-		return models.ThirdPartyIds{}
+		return models.SubtitleDetails{}
 	}
 	req.Header.Set("User-Agent", config.GetUserAgent())
 
 	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointDetail, resp, err)
 	if err != nil {
 		logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Str("detailURL", detailURL).Msg("Failed to fetch detail page")
-		return models.ThirdPartyIds{}
+		return models.SubtitleDetails{}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		logger.Warn().Int("statusCode", resp.StatusCode).Int("showID", show.ID).Str("showName", show.Name).Str("detailURL", detailURL).Msg("Detail page returned non-OK status")
-		return models.ThirdPartyIds{}
+		return models.SubtitleDetails{}
 	}
 
-	// Parse third-party IDs from HTML
-	ids, err := c.thirdPartyParser.ParseHtml(resp.Body)
+	body := metrics.NewUpstreamBody(metrics.UpstreamEndpointDetail, resp.Body)
+	details, err := c.subtitleDetailsParser.ParseHtml(body)
 	if err != nil {
-		logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Failed to parse third-party IDs")
-		return models.ThirdPartyIds{}
+		logger.Warn().Err(err).Int("showID", show.ID).Str("showName", show.Name).Msg("Failed to parse detail page")
+		return models.SubtitleDetails{}
 	}
+	logger.Debug().Int("showID", show.ID).Int64("bytes", body.Bytes()).Msg("Parsed show detail page")
 
-	return ids
+	c.showDetails.Set(show.ID, details)
+	return details
+}
+
+// applyShowDetails fills the show's year when the listing left it zero and sets its status from the detail page.
+func applyShowDetails(show models.Show, details models.SubtitleDetails) models.Show {
+	if show.Year == 0 {
+		show.Year = details.ShowYear
+	}
+	show.Status = details.ShowStatus
+	return show
 }
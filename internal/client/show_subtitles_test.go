@@ -2,11 +2,17 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
@@ -33,7 +39,7 @@ func TestClient_GetShowSubtitles(t *testing.T) {
 		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
 			{
 				SubtitleID:       1770600001,
-				MagyarTitle:      "Test Subtitle",
+				MagyarTitle:      "Teszt Sorozat - 1x01",
 				EredetiTitle:     "Test Show - 1x01",
 				DownloadFilename: "test.srt",
 				ShowID:           showID,
@@ -62,7 +68,7 @@ func TestClient_GetShowSubtitles(t *testing.T) {
 
 	// Call StreamShowSubtitles and collect results
 	ctx := context.Background()
-	showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, shows))
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, shows, nil))
 
 	// Test that the call succeeds
 	if err != nil {
@@ -105,4 +111,460 @@ func TestClient_GetShowSubtitles(t *testing.T) {
 	if result.SubtitleCollection.ShowName != "Test Show" {
 		t.Errorf("Expected subtitle show name 'Test Show', got %s", result.SubtitleCollection.ShowName)
 	}
+
+	// Test aliases merge the original and Hungarian titles
+	if len(result.Aliases) != 2 || result.Aliases[0] != "Test Show" || result.Aliases[1] != "Teszt Sorozat" {
+		t.Errorf("Expected aliases [Test Show Teszt Sorozat], got %v", result.Aliases)
+	}
+}
+
+func TestClient_StreamShowSubtitles_YearAndStatusFromDetailPage(t *testing.T) {
+	t.Parallel()
+	detailPageHTML := testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{
+		Filename: "Show.S01E01.srt",
+		Uploader: "TestUser",
+		IMDBID:   "tt12345678",
+		Year:     2019,
+		Status:   "Befejezett",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(detailPageHTML))
+			return
+		}
+
+		showID, _ := strconv.Atoi(r.URL.Query().Get("sid"))
+		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+			{
+				SubtitleID:       1770600001,
+				MagyarTitle:      "Teszt Sorozat - 1x01",
+				EredetiTitle:     "Test Show - 1x01",
+				DownloadFilename: "test.srt",
+				ShowID:           showID,
+			},
+		})
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	shows := []models.Show{
+		{Name: "Test Show", ID: 1},
+		{Name: "Dated Show", ID: 2, Year: 2021},
+	}
+
+	ctx := context.Background()
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, shows, nil))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(showSubtitles) != 2 {
+		t.Fatalf("Expected 2 show results, got %d", len(showSubtitles))
+	}
+
+	years := make(map[int]int, len(showSubtitles))
+	for _, result := range showSubtitles {
+		years[result.ID] = result.Year
+		if result.Status != models.ShowStatusEnded {
+			t.Errorf("Show %d: expected status %q, got %q", result.ID, models.ShowStatusEnded, result.Status)
+		}
+	}
+	if years[1] != 2019 {
+		t.Errorf("Expected a zero year to be filled from the detail page, got %d", years[1])
+	}
+	if years[2] != 2021 {
+		t.Errorf("Expected the listing year to be kept, got %d", years[2])
+	}
+}
+
+func TestClient_StreamShowSubtitles_ConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+	const concurrency = 3
+	const showCount = 12
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("tt12345678", 987654, 555666, 987654)))
+			return
+		}
+
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		// Hold the request so that unbounded fan-out would overlap every show
+		time.Sleep(20 * time.Millisecond)
+
+		showID, _ := strconv.Atoi(r.URL.Query().Get("sid"))
+		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+			{
+				SubtitleID:       1770600000 + showID,
+				MagyarTitle:      "Test Subtitle",
+				EredetiTitle:     "Test Show - 1x01",
+				DownloadFilename: "test.srt",
+				ShowID:           showID,
+			},
+		})
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	}
+	testConfig.Client.ShowSubtitlesConcurrency = concurrency
+	client := NewClient(testConfig)
+
+	shows := make([]models.Show, showCount)
+	for i := range shows {
+		shows[i] = models.Show{Name: "Test Show", ID: i + 1}
+	}
+
+	ctx := context.Background()
+	results, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, shows, nil))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != showCount {
+		t.Fatalf("Expected %d show results, got %d", showCount, len(results))
+	}
+	if got := maxInFlight.Load(); got > concurrency {
+		t.Errorf("Expected at most %d shows fetched simultaneously, got %d", concurrency, got)
+	}
+}
+
+func TestClient_StreamShowSubtitles_PerShowTimeout(t *testing.T) {
+	t.Parallel()
+	const slowShowID = 2
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("tt12345678", 987654, 555666, 987654)))
+			return
+		}
+
+		showID, _ := strconv.Atoi(r.URL.Query().Get("sid"))
+		if showID == slowShowID {
+			// Hang well past the per-show timeout
+			select {
+			case <-time.After(5 * time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+			{
+				SubtitleID:       1770600000 + showID,
+				MagyarTitle:      "Test Subtitle",
+				EredetiTitle:     "Test Show - 1x01",
+				DownloadFilename: "test.srt",
+				ShowID:           showID,
+			},
+		})
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	testConfig := &config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	}
+	testConfig.Client.PerShowTimeout = "200ms"
+	client := NewClient(testConfig)
+
+	shows := []models.Show{{Name: "Fast One", ID: 1}, {Name: "Slow", ID: slowShowID}, {Name: "Fast Two", ID: 3}}
+
+	start := time.Now()
+	var received []int
+	var timeoutErr *apperrors.ErrShowTimeout
+	for result := range client.StreamShowSubtitles(context.Background(), shows, nil) {
+		if result.Err != nil {
+			if !errors.As(result.Err, &timeoutErr) {
+				t.Fatalf("Expected ErrShowTimeout, got: %v", result.Err)
+			}
+			continue
+		}
+		received = append(received, result.Value.ID)
+	}
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected the slow show to be cut off by the per-show timeout, stream took %v", elapsed)
+	}
+	if timeoutErr == nil {
+		t.Fatal("Expected an ErrShowTimeout result for the slow show")
+	}
+	if timeoutErr.ShowID != slowShowID {
+		t.Errorf("Expected timeout for show %d, got show %d", slowShowID, timeoutErr.ShowID)
+	}
+	slices.Sort(received)
+	if !slices.Equal(received, []int{1, 3}) {
+		t.Errorf("Expected the fast shows [1 3] to arrive, got %v", received)
+	}
+}
+
+func TestClient_StreamShowSubtitles_CancellationStopsScheduling(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	testConfig := &config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	}
+	testConfig.Client.ShowSubtitlesConcurrency = 1
+	testConfig.Retry.MaxAttempts = 1
+	client := NewClient(testConfig)
+
+	shows := []models.Show{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := client.StreamShowSubtitles(ctx, shows, nil)
+
+	// Wait for the first show to occupy the only slot, then cancel
+	deadline := time.Now().Add(5 * time.Second)
+	for requests.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the first show request")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-stream:
+		for ok {
+			_, ok = <-stream
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected stream to close after cancellation")
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected no shows scheduled after cancellation, got %d requests", got)
+	}
+}
+
+func TestClient_StreamShowSubtitles_LanguageEarlyExit(t *testing.T) {
+	t.Parallel()
+	const totalPages = 5
+	tests := []struct {
+		name           string
+		earlyExitPages int
+		hungarianPage  int // page carrying the only Hungarian subtitle; 0 for none
+		wantPages      []int
+		wantIDs        []int
+	}{
+		{name: "match on first page fetches every page", hungarianPage: 1, wantPages: []int{1, 2, 3, 4, 5}, wantIDs: []int{101}},
+		{name: "match on last page of the window fetches every page", hungarianPage: 2, wantPages: []int{1, 2, 3, 4, 5}, wantIDs: []int{102}},
+		{name: "no match stops after the window", wantPages: []int{1, 2}},
+		{name: "match past the window is missed", hungarianPage: 3, wantPages: []int{1, 2}},
+		{name: "one page window", earlyExitPages: 1, hungarianPage: 2, wantPages: []int{1}},
+		{name: "window of three", earlyExitPages: 3, hungarianPage: 3, wantPages: []int{1, 2, 3, 4, 5}, wantIDs: []int{103}},
+		{name: "negative disables the early exit", earlyExitPages: -1, hungarianPage: 5, wantPages: []int{1, 2, 3, 4, 5}, wantIDs: []int{105}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			var pages []int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("tipus") == "adatlap" {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("tt1234567", 1, 1, 1)))
+					return
+				}
+				page := 1
+				if oldal := r.URL.Query().Get("oldal"); oldal != "" {
+					page, _ = strconv.Atoi(oldal)
+				}
+				mu.Lock()
+				pages = append(pages, page)
+				mu.Unlock()
+
+				row := testutil.SubtitleRowOptions{SubtitleID: 200 + page, ShowID: 7, Language: "Angol", FlagImage: "uk.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01", DownloadFilename: "dark.srt"}
+				if page == tt.hungarianPage {
+					row.SubtitleID, row.Language, row.FlagImage = 100+page, "Magyar", "hungary.gif"
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{row}, page, totalPages, true)))
+			}))
+			defer server.Close()
+
+			testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+			testConfig.Client.LanguageEarlyExitPages = tt.earlyExitPages
+			client := NewClient(testConfig)
+
+			ctx := context.Background()
+			results, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, []models.Show{{ID: 7, Name: "Dark"}}, []string{"HU"}))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			mu.Lock()
+			slices.Sort(pages)
+			if !slices.Equal(pages, tt.wantPages) {
+				t.Errorf("Expected pages %v fetched, got %v", tt.wantPages, pages)
+			}
+			mu.Unlock()
+
+			var ids []int
+			for _, result := range results {
+				for _, subtitle := range result.SubtitleCollection.Subtitles {
+					ids = append(ids, subtitle.ID)
+				}
+			}
+			if len(tt.wantIDs) == 0 && len(results) != 0 {
+				t.Errorf("Expected the show to be skipped, got %+v", results)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("Expected subtitles %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestClient_StreamShowSubtitles_SortsCollection(t *testing.T) {
+	t.Parallel()
+	rows := []testutil.SubtitleRowOptions{
+		{SubtitleID: 1770600004, MagyarTitle: "Teszt - 2x01", EredetiTitle: "Test - 2x01 (WEB)", UploadDate: "2025-02-04", DownloadFilename: "test.s02e01.srt"},
+		{SubtitleID: 1770600003, MagyarTitle: "Teszt - 1x02", EredetiTitle: "Test - 1x02 (WEB)", UploadDate: "2025-02-03", DownloadFilename: "test.s01e02.srt"},
+		{SubtitleID: 1770600002, MagyarTitle: "Teszt - 1x01", EredetiTitle: "Test - 1x01 (WEB)", UploadDate: "2025-02-01", DownloadFilename: "test.s01e01.srt"},
+		{SubtitleID: 1770600001, MagyarTitle: "Teszt - 1x01", EredetiTitle: "Test - 1x01 (WEB)", UploadDate: "2025-02-02", DownloadFilename: "test.s01e01.en.srt"},
+		{SubtitleID: 1770600005, MagyarTitle: "Teszt - 1x01", EredetiTitle: "Test - 1x01 (WEB)", UploadDate: "2025-02-02", DownloadFilename: "test.s01e01.hi.srt"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Show 2 lists the same subtitles in reverse
+		showID, _ := strconv.Atoi(r.URL.Query().Get("sid"))
+		listed := slices.Clone(rows)
+		if showID == 2 {
+			slices.Reverse(listed)
+		}
+		for i := range listed {
+			listed[i].ShowID = showID
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(listed)))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer client.Close()
+	ctx := context.Background()
+
+	showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, []models.Show{{Name: "Test", ID: 1}, {Name: "Test", ID: 2}}, nil))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(showSubtitles) != 2 {
+		t.Fatalf("Expected 2 shows, got %d", len(showSubtitles))
+	}
+
+	// Season, then episode, then newest upload, then highest ID
+	want := []int{1770600005, 1770600001, 1770600002, 1770600003, 1770600004}
+	for _, result := range showSubtitles {
+		var ids []int
+		for _, subtitle := range result.SubtitleCollection.Subtitles {
+			ids = append(ids, subtitle.ID)
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("Show %d: expected %v, got %v", result.ID, want, ids)
+		}
+	}
+}
+
+func TestClient_StreamShowSubtitles_CachesShowDetails(t *testing.T) {
+	t.Parallel()
+	detailPageHTML := testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{
+		Filename: "Show.S01E01.srt",
+		IMDBID:   "tt12345678",
+		TVDBID:   987654,
+		Year:     2019,
+		Status:   "Befejezett",
+	})
+
+	var detailRequests atomic.Int32
+	var detailFailing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			detailRequests.Add(1)
+			if detailFailing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(detailPageHTML))
+			return
+		}
+		showID, _ := strconv.Atoi(r.URL.Query().Get("sid"))
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600000 + showID, MagyarTitle: "Teszt Sorozat - 1x01", EredetiTitle: "Test Show - 1x01", DownloadFilename: "test.srt", ShowID: showID},
+		})))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	cfg.Retry.MaxAttempts = 1
+	c := NewClient(cfg)
+	defer c.Close()
+	ctx := context.Background()
+	fetch := func(showID int) models.ShowSubtitles {
+		t.Helper()
+		results, err := testutil.CollectShowSubtitles(ctx, c.StreamShowSubtitles(ctx, []models.Show{{Name: "Test Show", ID: showID}}, nil))
+		if err != nil || len(results) != 1 {
+			t.Fatalf("Expected one show, got %d and %v", len(results), err)
+		}
+		return results[0]
+	}
+
+	// A failed detail page is not cached: the next listing asks again
+	detailFailing.Store(true)
+	if got := fetch(2).ThirdPartyIds; got != (models.ThirdPartyIds{}) {
+		t.Errorf("Expected empty IDs from a failed detail page, got %+v", got)
+	}
+	detailFailing.Store(false)
+	fetch(2)
+	if got := detailRequests.Load(); got != 2 {
+		t.Fatalf("Expected the failed detail page to be fetched again, got %d detail requests", got)
+	}
+
+	detailRequests.Store(0)
+	first := fetch(1)
+	second := fetch(1)
+	if got := detailRequests.Load(); got != 1 {
+		t.Errorf("Expected one detail request for two listings of the show, got %d", got)
+	}
+	if second.ThirdPartyIds != first.ThirdPartyIds || second.ThirdPartyIds.IMDBID != "tt12345678" {
+		t.Errorf("Expected cached IDs %+v, got %+v", first.ThirdPartyIds, second.ThirdPartyIds)
+	}
+	if second.Year != 2019 || second.Status != models.ShowStatusEnded {
+		t.Errorf("Expected the cached year and status, got %d and %q", second.Year, second.Status)
+	}
 }
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
+)
+
+// GetSubtitleDetails fetches the detail page (adatlap) of a subtitle, the page the listing opens
+// with adatlapnyitas, and returns its filename, uploader, comment and third-party IDs.
+// An unknown subtitle ID returns apperrors.ErrNotFound.
+func (c *client) GetSubtitleDetails(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error) {
+	logger := config.GetLogger()
+
+	detailURL := fmt.Sprintf("%s/index.php?tipus=adatlap&azon=a_%d", c.baseURL, subtitleID)
+	req, err := http.NewRequestWithContext(ctx, "GET", detailURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointDetail, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subtitle details: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &apperrors.ErrUpstreamStatus{Code: resp.StatusCode}
+	}
+
+	body := metrics.NewUpstreamBody(metrics.UpstreamEndpointDetail, resp.Body)
+	details, err := c.subtitleDetailsParser.ParseHtml(body)
+	if errors.Is(err, parser.ErrSubtitleDetailsNotFound) {
+		return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subtitle details: %w", err)
+	}
+	details.SubtitleID = subtitleID
+
+	logger.Debug().Int("subtitleID", subtitleID).Bool("hasComment", details.Comment != "").Int64("bytes", body.Bytes()).Msg("Fetched subtitle details")
+	return &details, nil
+}
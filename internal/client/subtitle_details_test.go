@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestClient_GetSubtitleDetails(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") != "adatlap" || r.URL.Query().Get("azon") != "a_1737439811" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testutil.GenerateEmptyHTML()))
+			return
+		}
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{
+			Filename: "outlander.s07e16.srt",
+			Uploader: "kissoreg",
+			Comment:  "csak a WEB-DL-hez jó",
+			IMDBID:   "tt3006802",
+			TVDBID:   270408,
+		})))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	details, err := c.GetSubtitleDetails(context.Background(), 1737439811)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if details.SubtitleID != 1737439811 {
+		t.Errorf("Expected subtitle ID 1737439811, got %d", details.SubtitleID)
+	}
+	if details.Filename != "outlander.s07e16.srt" || details.Uploader != "kissoreg" {
+		t.Errorf("Expected filename and uploader from the detail page, got %q and %q", details.Filename, details.Uploader)
+	}
+	if details.Comment != "csak a WEB-DL-hez jó" {
+		t.Errorf("Expected comment %q, got %q", "csak a WEB-DL-hez jó", details.Comment)
+	}
+	if details.ThirdPartyIds.IMDBID != "tt3006802" || details.ThirdPartyIds.TVDBID != 270408 {
+		t.Errorf("Expected third-party IDs from the detail page, got %+v", details.ThirdPartyIds)
+	}
+
+	if _, err := c.GetSubtitleDetails(context.Background(), 42); !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound for an unknown subtitle, got: %v", err)
+	}
+}
+
+func TestClient_GetSubtitleDetails_UpstreamError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	_, err := c.GetSubtitleDetails(context.Background(), 1737439811)
+	var upstream *apperrors.ErrUpstreamStatus
+	if !errors.As(err, &upstream) || upstream.Code != http.StatusForbidden {
+		t.Errorf("Expected ErrUpstreamStatus 403, got: %v", err)
+	}
+}
+
+func TestClient_GetSubtitleDetails_ObservesResponseBytes(t *testing.T) {
+	t.Parallel()
+	page := testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{Filename: "outlander.s07e16.srt"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	countBefore, sumBefore := upstreamResponseBytes(t, metrics.UpstreamEndpointDetail)
+	if _, err := c.GetSubtitleDetails(context.Background(), 1737439811); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	countAfter, sumAfter := upstreamResponseBytes(t, metrics.UpstreamEndpointDetail)
+
+	// Other tests may fetch detail pages concurrently, so only a lower bound holds
+	if countAfter <= countBefore {
+		t.Error("Expected the detail page size to be observed")
+	}
+	if sumAfter-sumBefore < float64(len(page)) {
+		t.Errorf("Expected at least %d observed bytes, got %.0f", len(page), sumAfter-sumBefore)
+	}
+}
+
+// upstreamResponseBytes reads the sample count and sum of the response size histogram of endpoint.
+func upstreamResponseBytes(t *testing.T, endpoint string) (uint64, float64) {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.UpstreamResponseBytes.WithLabelValues(endpoint).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
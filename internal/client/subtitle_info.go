@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// subtitleInfoMaxPages caps how many pages of the recent uploads listing GetSubtitleInfo searches
+const subtitleInfoMaxPages = 5
+
+// GetSubtitleInfo returns a subtitle's metadata from its ID alone, without its show. The detail
+// page (adatlap) tells whether the subtitle exists; an unknown ID returns apperrors.ErrNotFound.
+// The site has no other per-subtitle page, so the full listing row is looked up in the recent
+// uploads listing, newest first, until the listing is older than the ID (IDs are upload times)
+// or subtitleInfoMaxPages pages were read. A subtitle older than that is built from the detail
+// page: show name, season, episode and language are then read from its filename and may be
+// missing, and Release, Qualities and UploadedAt are empty.
+func (c *client) GetSubtitleInfo(ctx context.Context, subtitleID int) (*models.Subtitle, error) {
+	logger := config.GetLogger()
+
+	details, err := c.GetSubtitleDetails(ctx, subtitleID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtitle, err := c.findRecentSubtitle(ctx, subtitleID)
+	if err != nil {
+		return nil, err
+	}
+	if subtitle != nil {
+		logger.Debug().Int("subtitleID", subtitleID).Int("showID", subtitle.ShowID).Msg("Found subtitle in the recent uploads listing")
+		return subtitle, nil
+	}
+
+	logger.Debug().Int("subtitleID", subtitleID).Str("filename", details.Filename).Msg("Subtitle is not among recent uploads, building it from its detail page")
+	return c.subtitleFromDetails(details)
+}
+
+// findRecentSubtitle searches the recent uploads listing for subtitleID. It returns nil when the
+// listing reaches older uploads, ends, or runs past subtitleInfoMaxPages without the subtitle.
+func (c *client) findRecentSubtitle(ctx context.Context, subtitleID int) (*models.Subtitle, error) {
+	for page := 1; page <= subtitleInfoMaxPages; page++ {
+		pageResult, _, err := c.fetchRecentPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		reachedOlder := false
+		for _, subtitle := range pageResult.Subtitles {
+			if subtitle.ID == subtitleID {
+				return &subtitle, nil
+			}
+			// Synthetic IDs are URL hashes rather than upload times, so they say nothing about order
+			if !subtitle.IDIsSynthetic && subtitle.ID > 0 && subtitle.ID < subtitleID {
+				reachedOlder = true
+			}
+		}
+		if reachedOlder || !pageResult.HasNextPage {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// subtitleFromDetails builds a subtitle from its detail page, reading the show name, season,
+// episode and language from the filename, as in "Dark.S01E03.en.srt". Season and Episode are -1
+// and ContentType is empty when the filename names no episode.
+func (c *client) subtitleFromDetails(details *models.SubtitleDetails) (*models.Subtitle, error) {
+	downloadURL, err := c.buildDownloadURL(strconv.Itoa(details.SubtitleID))
+	if err != nil {
+		return nil, err
+	}
+
+	fromFilename := models.NewFilenameTemplateData(details.Filename, 0)
+	subtitle := &models.Subtitle{
+		ID:                details.SubtitleID,
+		ShowName:          fromFilename.ShowName,
+		Language:          fromFilename.Language,
+		Season:            -1,
+		Episode:           -1,
+		Filename:          details.Filename,
+		DownloadURL:       downloadURL,
+		Uploader:          details.Uploader,
+		IsHearingImpaired: models.IsHearingImpaired(details.Filename),
+	}
+	if fromFilename.Season > 0 {
+		subtitle.Season, subtitle.Episode = fromFilename.Season, fromFilename.Episode
+		subtitle.ContentType = models.ContentTypeSeries
+	}
+	return subtitle, nil
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+// newSubtitleInfoServer serves detail pages for the subtitles in details, an empty page for any
+// other ID as the site does, and two pages of recent uploads: 1770600050, 40 and 30, then
+// 1770600020 and 10. recentPages counts the listing requests.
+func newSubtitleInfoServer(t *testing.T, details map[int]testutil.SubtitleDetailsOptions, recentPages *atomic.Int32) *httptest.Server {
+	t.Helper()
+	row := func(subtitleID, episode int) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			SubtitleID:       subtitleID,
+			ShowID:           3217,
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Outlander - 7x" + strconv.Itoa(episode),
+			EredetiTitle:     "Outlander - 7x" + strconv.Itoa(episode) + " (AMZN.WEB-DL.1080p-FLUX)",
+			Uploader:         "kissoreg",
+			UploadDate:       "2026-02-16",
+			DownloadFilename: "outlander.s07e" + strconv.Itoa(episode) + ".srt",
+		}
+	}
+	pages := map[string]string{
+		"":  testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{row(1770600050, 15), row(1770600040, 14), row(1770600030, 13)}, 1, 2, false),
+		"2": testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{row(1770600020, 12), row(1770600010, 11)}, 2, 2, false),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("tipus") == "adatlap":
+			id, _ := strconv.Atoi(query.Get("azon")[len("a_"):])
+			opts, ok := details[id]
+			if !ok {
+				_, _ = w.Write([]byte(testutil.GenerateEmptyHTML()))
+				return
+			}
+			_, _ = w.Write([]byte(testutil.GenerateSubtitleDetailsHTML(opts)))
+		case query.Get("tab") == "sorozat":
+			recentPages.Add(1)
+			_, _ = w.Write([]byte(pages[query.Get("page")]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_GetSubtitleInfo_FromRecentListing(t *testing.T) {
+	t.Parallel()
+	var recentPages atomic.Int32
+	server := newSubtitleInfoServer(t, map[int]testutil.SubtitleDetailsOptions{
+		1770600020: {Filename: "outlander.s07e12.srt", Uploader: "kissoreg"},
+	}, &recentPages)
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	subtitle, err := c.GetSubtitleInfo(context.Background(), 1770600020)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if subtitle.ID != 1770600020 || subtitle.ShowID != 3217 || subtitle.Language != "en" || subtitle.Season != 7 || subtitle.Episode != 12 {
+		t.Errorf("Expected the listing row of episode 7x12, got %+v", subtitle)
+	}
+	if subtitle.Release != "AMZN.WEB-DL.1080p-FLUX" || subtitle.UploadedAt.IsZero() {
+		t.Errorf("Expected the release and upload date of the listing row, got %q and %v", subtitle.Release, subtitle.UploadedAt)
+	}
+	if got := recentPages.Load(); got != 2 {
+		t.Errorf("Expected both recent pages to be read, got %d", got)
+	}
+}
+
+func TestClient_GetSubtitleInfo_OlderThanRecentListing(t *testing.T) {
+	t.Parallel()
+	var recentPages atomic.Int32
+	server := newSubtitleInfoServer(t, map[int]testutil.SubtitleDetailsOptions{
+		1700000000: {Filename: "Outlander.S02E05.en.srt", Uploader: "gricsi"},
+	}, &recentPages)
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	subtitle, err := c.GetSubtitleInfo(context.Background(), 1700000000)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := models.Subtitle{
+		ID:          1700000000,
+		ShowName:    "Outlander",
+		Language:    "en",
+		Season:      2,
+		Episode:     5,
+		Filename:    "Outlander.S02E05.en.srt",
+		DownloadURL: server.URL + "/index.php?action=letolt&felirat=1700000000",
+		Uploader:    "gricsi",
+		ContentType: models.ContentTypeSeries,
+	}
+	if subtitle.ID != want.ID || subtitle.ShowName != want.ShowName || subtitle.Language != want.Language ||
+		subtitle.Season != want.Season || subtitle.Episode != want.Episode || subtitle.Filename != want.Filename ||
+		subtitle.DownloadURL != want.DownloadURL || subtitle.Uploader != want.Uploader || subtitle.ContentType != want.ContentType {
+		t.Errorf("Expected %+v from the detail page, got %+v", want, *subtitle)
+	}
+	// Every listed upload is newer, so the search reads the listing to its end
+	if got := recentPages.Load(); got != 2 {
+		t.Errorf("Expected both recent pages to be read, got %d", got)
+	}
+}
+
+func TestClient_GetSubtitleInfo_StopsAtOlderUploads(t *testing.T) {
+	t.Parallel()
+	var recentPages atomic.Int32
+	// Between two listed uploads but not listed itself, as when the listing row was removed
+	server := newSubtitleInfoServer(t, map[int]testutil.SubtitleDetailsOptions{
+		1770600035: {Filename: "Outlander.S07E14.srt", Uploader: "gricsi"},
+	}, &recentPages)
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	subtitle, err := c.GetSubtitleInfo(context.Background(), 1770600035)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if subtitle.ID != 1770600035 || subtitle.Filename != "Outlander.S07E14.srt" || subtitle.Release != "" {
+		t.Errorf("Expected the subtitle from its detail page, got %+v", subtitle)
+	}
+	if got := recentPages.Load(); got != 1 {
+		t.Errorf("Expected the search to stop at the first page reaching older uploads, got %d pages", got)
+	}
+}
+
+func TestClient_GetSubtitleInfo_NotFound(t *testing.T) {
+	t.Parallel()
+	var recentPages atomic.Int32
+	server := newSubtitleInfoServer(t, nil, &recentPages)
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+
+	_, err := c.GetSubtitleInfo(context.Background(), 42)
+	if !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Fatalf("Expected ErrNotFound for an unknown subtitle, got: %v", err)
+	}
+	if got := recentPages.Load(); got != 0 {
+		t.Errorf("Expected no listing request for an unknown subtitle, got %d", got)
+	}
+}
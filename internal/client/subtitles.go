@@ -5,90 +5,185 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sync"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // StreamSubtitles streams subtitles for a given show ID as they are parsed from each page.
+// A subtitle repeated on a later page is sent only once.
 // The channel is closed when all pages have been processed.
 func (c *client) StreamSubtitles(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
-	ch := make(chan models.StreamResult[models.Subtitle])
+	return c.streamSubtitles(ctx, showListing(showID), nil, models.SubtitleOrderListing)
+}
 
-	go func() {
-		defer close(ch)
-		logger := config.GetLogger()
-		logger.Info().Int("showID", showID).Msg("Streaming subtitles for show via HTML with pagination")
+// StreamSubtitlesInOrder streams subtitles for a given show ID like StreamSubtitles, with each
+// page sorted by order before it is sent. Pages are still sent in listing order, since sorting
+// across pages would mean waiting for the last one.
+func (c *client) StreamSubtitlesInOrder(ctx context.Context, showID int, order models.SubtitleOrder) <-chan models.StreamResult[models.Subtitle] {
+	return c.streamSubtitles(ctx, showListing(showID), nil, order)
+}
 
-		// Fetch first page
-		endpoint := fmt.Sprintf("%s/index.php?sid=%d", c.baseURL, showID)
+// StreamMovieSubtitles streams the subtitles of a film from its listing like StreamSubtitles.
+// Every subtitle is marked as a film, with Season and Episode set to -1.
+func (c *client) StreamMovieSubtitles(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle] {
+	return c.streamSubtitles(ctx, movieListing(movieID), nil, models.SubtitleOrderListing)
+}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
-		if err != nil {
-			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: fmt.Errorf("failed to create request for first page: %w", err)})
-			return
-		}
-		req.Header.Set("User-Agent", config.GetUserAgent())
+// subtitleListing identifies the paginated subtitle listing of a show or a film
+type subtitleListing struct {
+	kind  string // "show" or "movie", used in errors, log fields and span attributes
+	param string // Query parameter selecting the listing: sid for shows, fid for films
+	id    int
+}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: fmt.Errorf("failed to fetch first page: %w", err)})
-			return
-		}
-		defer resp.Body.Close()
+func showListing(showID int) subtitleListing {
+	return subtitleListing{kind: "show", param: "sid", id: showID}
+}
 
-		if resp.StatusCode == http.StatusNotFound {
-			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: apperrors.NewNotFoundError("show", showID)})
-			return
-		}
+func movieListing(movieID int) subtitleListing {
+	return subtitleListing{kind: "movie", param: "fid", id: movieID}
+}
 
-		if resp.StatusCode != http.StatusOK {
-			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: fmt.Errorf("first page returned status %d", resp.StatusCode)})
-			return
-		}
+// url returns the address of listing page pageNum; the first page has no page parameter.
+func (l subtitleListing) url(baseURL string, pageNum int) string {
+	if pageNum <= 1 {
+		return fmt.Sprintf("%s/index.php?%s=%d", baseURL, l.param, l.id)
+	}
+	return fmt.Sprintf("%s/index.php?%s=%d&oldal=%d", baseURL, l.param, l.id, pageNum)
+}
+
+// idField is the name of the log field carrying the listing's ID, such as showID.
+func (l subtitleListing) idField() string {
+	return l.kind + "ID"
+}
+
+// normalize marks subtitles of a film listing as films. Film listings can also use the show
+// layout, whose rows the parser reads as episodes; a film has no season or episode.
+func (l subtitleListing) normalize(subtitle models.Subtitle) models.Subtitle {
+	if l.kind != "movie" {
+		return subtitle
+	}
+	subtitle.ContentType = models.ContentTypeFilm
+	subtitle.Season, subtitle.Episode = -1, -1
+	subtitle.SeasonEnd, subtitle.RangeStart, subtitle.RangeEnd = nil, nil, nil
+	subtitle.IsSeasonPack = false
+	if subtitle.MovieTitle == "" {
+		subtitle.MovieTitle = subtitle.ShowName
+	}
+	return subtitle
+}
+
+// streamSubtitles implements StreamSubtitles. When languages is not empty and none of the
+// subtitles sent from the first languageEarlyExitPages pages is in one of them, the remaining
+// pages are not fetched; all subtitles are still sent, whatever their language. Each page is
+// sorted by order before it is sent.
+func (c *client) streamSubtitles(ctx context.Context, listing subtitleListing, languages []string, order models.SubtitleOrder) <-chan models.StreamResult[models.Subtitle] {
+	ch := make(chan models.StreamResult[models.Subtitle])
+
+	go func() {
+		defer close(ch)
+		ctx, span := tracing.Start(ctx, "client.StreamSubtitles", attribute.Int(listing.kind+"_id", listing.id))
+		defer span.End()
+		logger := config.GetLogger()
+		logger.Info().Int(listing.idField(), listing.id).Msgf("Streaming subtitles for %s via HTML with pagination", listing.kind)
 
-		// Parse first page with pagination info
-		firstPageResult, err := c.subtitleParser.ParseHtmlWithPagination(resp.Body)
+		// Fetch first page
+		firstPageResult, firstPageBytes, err := c.fetchFirstSubtitlePage(ctx, listing)
 		if err != nil {
-			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: fmt.Errorf("failed to parse first page: %w", err)})
+			sendResult(ctx, ch, models.StreamResult[models.Subtitle]{Err: err})
 			return
 		}
 
 		logger.Info().
-			Int("showID", showID).
+			Int(listing.idField(), listing.id).
 			Int("currentPage", firstPageResult.CurrentPage).
 			Int("totalPages", firstPageResult.TotalPages).
 			Int("subtitles", len(firstPageResult.Subtitles)).
+			Int64("bytes", firstPageBytes).
 			Msg("Fetched first page")
 
-		// Stream first page subtitles immediately
-		for _, subtitle := range firstPageResult.Subtitles {
+		// Pages can overlap when uploads shift the listing while it is paginated, so each
+		// subtitle ID is sent once, at its first position. Rows without an ID are always sent.
+		// Subtitles from uploaders excluded by client.blocked_uploaders/allowed_uploaders are dropped.
+		seen := make(map[int]struct{})
+		uploaderFilter := c.uploaderFilter.Load()
+		languageMatched := false
+		send := func(subtitle models.Subtitle) bool {
+			subtitle = listing.normalize(subtitle)
+			if subtitle.ID != 0 {
+				if _, duplicate := seen[subtitle.ID]; duplicate {
+					logger.Debug().Int("subtitleID", subtitle.ID).Int(listing.idField(), listing.id).Msg("Skipping subtitle already seen on an earlier page")
+					metrics.SubtitleListingDuplicatesDroppedTotal.WithLabelValues(listing.kind).Inc()
+					return true
+				}
+				seen[subtitle.ID] = struct{}{}
+			}
+			if !uploaderFilter.Allows(subtitle.Uploader) {
+				logger.Debug().Int("subtitleID", subtitle.ID).Str("uploader", subtitle.Uploader).Msg("Skipping subtitle from filtered uploader")
+				return true
+			}
+			if !languageMatched && hasLanguage(subtitle, languages) {
+				languageMatched = true
+			}
 			select {
 			case ch <- models.StreamResult[models.Subtitle]{Value: subtitle}:
+				return true
 			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// Stream first page subtitles immediately
+		for _, subtitle := range sortedPage(firstPageResult.Subtitles, order) {
+			if !send(subtitle) {
 				return
 			}
 		}
+		lastID := lastSubtitleID(firstPageResult.Subtitles)
 
 		// If only one page, we're done
 		if firstPageResult.TotalPages <= 1 {
 			return
 		}
 
+		// A language-filtered show stops after earlyExitPages pages unless one of them matched
+		earlyExitPages := 0
+		if len(languages) > 0 {
+			earlyExitPages = c.languageEarlyExitPages
+		}
+
 		// Fetch remaining pages in parallel (2 at a time)
 		const batchSize = 2
 
-		for page := 2; page <= firstPageResult.TotalPages; page += batchSize {
-			endPage := min(page+batchSize-1, firstPageResult.TotalPages)
+		for page, endPage := 2, 0; page <= firstPageResult.TotalPages; page = endPage + 1 {
+			endPage = min(page+batchSize-1, firstPageResult.TotalPages)
+			if earlyExitPages > 0 && !languageMatched {
+				if page > earlyExitPages {
+					logger.Info().
+						Int(listing.idField(), listing.id).
+						Strs("languages", languages).
+						Int("fetchedPages", page-1).
+						Int("totalPages", firstPageResult.TotalPages).
+						Msg("No subtitles in the requested languages, skipping remaining pages")
+					return
+				}
+				endPage = min(endPage, earlyExitPages)
+			}
 
 			pageNumbers := make([]int, 0)
 			for p := page; p <= endPage; p++ {
 				pageNumbers = append(pageNumbers, p)
 			}
 
-			logger.Debug().Ints("pages", pageNumbers).Int("showID", showID).Msg("Fetching batch of pages in parallel")
+			logger.Debug().Ints("pages", pageNumbers).Int(listing.idField(), listing.id).Msg("Fetching batch of pages in parallel")
 
 			type pageResult struct {
 				pageNum   int
@@ -104,38 +199,14 @@ func (c *client) StreamSubtitles(ctx context.Context, showID int) <-chan models.
 				go func() {
 					defer wg.Done()
 
-					pageEndpoint := fmt.Sprintf("%s/index.php?sid=%d&oldal=%d", c.baseURL, showID, pageNum)
-
-					pageReq, err := http.NewRequestWithContext(ctx, "GET", pageEndpoint, nil)
-					if err != nil {
-						logger.Warn().Err(err).Int("pageNum", pageNum).Int("showID", showID).Msg("Failed to create request for page")
-						results[i] = pageResult{pageNum: pageNum, err: fmt.Errorf("failed to create request: %w", err)}
-						return
-					}
-					pageReq.Header.Set("User-Agent", config.GetUserAgent())
-
-					pageResp, err := c.httpClient.Do(pageReq)
-					if err != nil {
-						logger.Warn().Err(err).Int("pageNum", pageNum).Int("showID", showID).Msg("Failed to fetch page")
-						results[i] = pageResult{pageNum: pageNum, err: fmt.Errorf("failed to fetch page: %w", err)}
-						return
-					}
-					defer pageResp.Body.Close()
-
-					if pageResp.StatusCode != http.StatusOK {
-						logger.Warn().Int("statusCode", pageResp.StatusCode).Int("pageNum", pageNum).Int("showID", showID).Msg("Page returned non-OK status")
-						results[i] = pageResult{pageNum: pageNum, err: fmt.Errorf("page returned status %d", pageResp.StatusCode)}
-						return
-					}
-
-					pageData, err := c.subtitleParser.ParseHtml(pageResp.Body)
+					pageData, pageBytes, err := c.fetchSubtitlePage(ctx, listing, pageNum)
 					if err != nil {
-						logger.Warn().Err(err).Int("pageNum", pageNum).Int("showID", showID).Msg("Failed to parse page")
-						results[i] = pageResult{pageNum: pageNum, err: fmt.Errorf("failed to parse page: %w", err)}
+						logger.Warn().Err(err).Int("pageNum", pageNum).Int(listing.idField(), listing.id).Msg("Failed to fetch page")
+						results[i] = pageResult{pageNum: pageNum, err: err}
 						return
 					}
 
-					logger.Debug().Int("pageNum", pageNum).Int("showID", showID).Int("subtitles", len(pageData)).Msg("Successfully fetched page")
+					logger.Debug().Int("pageNum", pageNum).Int(listing.idField(), listing.id).Int("subtitles", len(pageData)).Int64("bytes", pageBytes).Msg("Successfully fetched page")
 					results[i] = pageResult{pageNum: pageNum, subtitles: pageData}
 				}()
 			}
@@ -148,24 +219,39 @@ func (c *client) StreamSubtitles(ctx context.Context, showID int) <-chan models.
 				if result.err != nil {
 					logger.Warn().Err(result.err).Int("pageNum", result.pageNum).Msg("Error fetching page")
 					batchErrors = append(batchErrors, result.err)
+					lastID = 0 // The next page has no known boundary to check
 				} else {
-					for _, subtitle := range result.subtitles {
-						select {
-						case ch <- models.StreamResult[models.Subtitle]{Value: subtitle}:
-						case <-ctx.Done():
+					if repeated, missing := checkPageBoundary(lastID, result.subtitles, seen); missing {
+						logger.Warn().
+							Int(listing.idField(), listing.id).
+							Int("pageNum", result.pageNum).
+							Int("previousLastID", lastID).
+							Int("repeated", repeated).
+							Msg("Listing moved while paginating and the previous page's last subtitle is gone; a subtitle may have been skipped")
+						metrics.SubtitleListingSuspectedGapsTotal.WithLabelValues(listing.kind).Inc()
+					} else if repeated > 0 {
+						logger.Info().
+							Int(listing.idField(), listing.id).
+							Int("pageNum", result.pageNum).
+							Int("repeated", repeated).
+							Msg("Listing shifted down while paginating, dropping rows repeated from the previous page")
+					}
+					for _, subtitle := range sortedPage(result.subtitles, order) {
+						if !send(subtitle) {
 							return
 						}
 					}
+					lastID = lastSubtitleID(result.subtitles)
 				}
 			}
 
 			if len(batchErrors) > 0 {
-				logger.Warn().Err(errors.Join(batchErrors...)).Int("showID", showID).Msg("Some pages in batch failed, continuing with successful results")
+				logger.Warn().Err(errors.Join(batchErrors...)).Int(listing.idField(), listing.id).Msg("Some pages in batch failed, continuing with successful results")
 			}
 		}
 
 		logger.Info().
-			Int("showID", showID).
+			Int(listing.idField(), listing.id).
 			Int("totalPages", firstPageResult.TotalPages).
 			Msg("Successfully streamed all pages")
 	}()
@@ -173,6 +259,116 @@ func (c *client) StreamSubtitles(ctx context.Context, showID int) <-chan models.
 	return ch
 }
 
+// checkPageBoundary compares a page with lastID, the last subtitle of the page before it in
+// listing order. When an upload pushes the listing down while it is paginated, the page starts
+// with the previous page's last rows again, ending with lastID; repeated counts those leading
+// rows already in seen. missing reports that the page repeats earlier rows but the one just
+// before its new rows is not lastID, so lastID was removed or moved and a subtitle after it may
+// have been skipped. A lastID of 0 means the previous page is unknown and only repeated is set.
+func checkPageBoundary(lastID int, page []models.Subtitle, seen map[int]struct{}) (repeated int, missing bool) {
+	for _, subtitle := range page {
+		if _, ok := seen[subtitle.ID]; !ok {
+			break
+		}
+		repeated++
+	}
+	if lastID == 0 || repeated == 0 {
+		return repeated, false
+	}
+	return repeated, page[repeated-1].ID != lastID
+}
+
+// lastSubtitleID returns the ID of the last subtitle of a page in listing order, or 0 for an empty page
+func lastSubtitleID(page []models.Subtitle) int {
+	if len(page) == 0 {
+		return 0
+	}
+	return page[len(page)-1].ID
+}
+
+// sortedPage returns the subtitles of a page sorted by order. The page is copied first, so
+// parsed results shared with other callers are never reordered.
+func sortedPage(subtitles []models.Subtitle, order models.SubtitleOrder) []models.Subtitle {
+	if order == models.SubtitleOrderListing {
+		return subtitles
+	}
+	sorted := slices.Clone(subtitles)
+	models.SortSubtitles(sorted, order)
+	return sorted
+}
+
+// fetchFirstSubtitlePage fetches and parses the first page of a listing, which also reports
+// the number of pages, in a span of its own. It returns the parsed page and the size of its
+// body. A show or film the site does not know returns apperrors.ErrNotFound.
+func (c *client) fetchFirstSubtitlePage(ctx context.Context, listing subtitleListing) (result *parser.SubtitlePageResult, size int64, err error) {
+	ctx, span := tracing.Start(ctx, "client.fetchSubtitlePage", attribute.Int(listing.kind+"_id", listing.id), attribute.Int("page", 1))
+	defer func() {
+		span.SetAttributes(attribute.Int64("size", size))
+		tracing.End(span, err)
+	}()
+
+	endpoint := listing.url(c.baseURL, 1)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request for first page: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointSubtitles, resp, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch first page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, 0, apperrors.NewNotFoundError(listing.kind, listing.id)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("first page returned status %d", resp.StatusCode)
+	}
+
+	result, size, err = c.parseListingPage(resp, metrics.UpstreamEndpointSubtitles)
+	if err != nil {
+		return nil, size, fmt.Errorf("failed to parse first page: %w", err)
+	}
+	return result, size, nil
+}
+
+// fetchSubtitlePage fetches and parses page pageNum of a listing in a span of its own.
+// It returns the page's subtitles and the size of its body.
+func (c *client) fetchSubtitlePage(ctx context.Context, listing subtitleListing, pageNum int) (subtitles []models.Subtitle, size int64, err error) {
+	ctx, span := tracing.Start(ctx, "client.fetchSubtitlePage", attribute.Int(listing.kind+"_id", listing.id), attribute.Int("page", pageNum))
+	defer func() {
+		span.SetAttributes(attribute.Int64("size", size))
+		tracing.End(span, err)
+	}()
+
+	endpoint := listing.url(c.baseURL, pageNum)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointSubtitles, resp, err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("page returned status %d", resp.StatusCode)
+	}
+
+	result, size, err := c.parseListingPage(resp, metrics.UpstreamEndpointSubtitles)
+	if err != nil {
+		return nil, size, fmt.Errorf("failed to parse page: %w", err)
+	}
+	return result.Subtitles, size, nil
+}
+
 // sendResult sends a result to the channel, respecting context cancellation
 func sendResult[T any](ctx context.Context, ch chan<- models.StreamResult[T], result models.StreamResult[T]) {
 	select {
@@ -2,14 +2,20 @@ package client
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestClient_GetSubtitles_WithPagination(t *testing.T) {
@@ -195,3 +201,433 @@ func TestClient_GetSubtitles_NetworkError(t *testing.T) {
 		t.Fatalf("Expected nil result for error case, got: %v", result)
 	}
 }
+
+func TestClient_GetSubtitles_DeduplicatesAcrossPages(t *testing.T) {
+	t.Parallel()
+	row := func(subtitleID int) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			ShowID:           3217,
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "Stranger Things S01E0" + strconv.Itoa(subtitleID%10),
+			EredetiTitle:     "Stranger Things S01E0" + strconv.Itoa(subtitleID%10) + " - Episode Title (1080p-RelGroup)",
+			Uploader:         "Uploader",
+			UploadDate:       "2025-02-08",
+			DownloadAction:   "letolt",
+			DownloadFilename: "stranger.things.s01e0" + strconv.Itoa(subtitleID%10) + ".srt",
+			SubtitleID:       subtitleID,
+		}
+	}
+
+	// Page 2 repeats the last subtitle of page 1, as when a new upload shifts the listing.
+	// Page 3 fails, which must still leave the subtitles of the other pages.
+	pages := map[string]string{
+		"sid=3217":         testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{row(101), row(102)}, 1, 3, true),
+		"sid=3217&oldal=2": testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{row(102), row(103)}, 2, 3, true),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.RawQuery]
+		if r.URL.Path != "/index.php" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{
+		SuperSubtitleDomain: server.URL,
+		ClientTimeout:       "10s",
+	})
+	ctx := context.Background()
+
+	result, err := testutil.CollectSubtitles(ctx, client.StreamSubtitles(ctx, 3217))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	wantIDs := []int{101, 102, 103}
+	if len(result.Subtitles) != len(wantIDs) {
+		t.Fatalf("Expected %d unique subtitles, got %d", len(wantIDs), len(result.Subtitles))
+	}
+	for i, want := range wantIDs {
+		if got := result.Subtitles[i].ID; got != want {
+			t.Errorf("Subtitle %d: expected ID %d, got %d", i, want, got)
+		}
+	}
+}
+
+// Not parallel: the listing counters are shared by every client in the process
+func TestClient_GetSubtitles_ShiftedPagesMetrics(t *testing.T) {
+	row := func(subtitleID int) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			ShowID:           3217,
+			EredetiTitle:     "Stranger Things - 1x0" + strconv.Itoa(subtitleID%10) + " (WEB.1080p-RelGroup)",
+			DownloadFilename: "stranger.things.s01e0" + strconv.Itoa(subtitleID%10) + ".srt",
+			SubtitleID:       subtitleID,
+		}
+	}
+	tests := []struct {
+		name           string
+		page1, page2   []int
+		wantIDs        []int
+		wantDuplicates float64
+		wantGaps       float64
+	}{
+		{
+			name:           "new upload shifts the listing down",
+			page1:          []int{101, 102, 103},
+			page2:          []int{103, 104, 105},
+			wantIDs:        []int{101, 102, 103, 104, 105},
+			wantDuplicates: 1,
+		},
+		{
+			name:           "last row of the previous page disappears",
+			page1:          []int{101, 102, 103},
+			page2:          []int{102, 104, 105},
+			wantIDs:        []int{101, 102, 103, 104, 105},
+			wantDuplicates: 1,
+			wantGaps:       1,
+		},
+		{
+			name:    "stable listing",
+			page1:   []int{101, 102, 103},
+			page2:   []int{104, 105},
+			wantIDs: []int{101, 102, 103, 104, 105},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := func(ids []int) []testutil.SubtitleRowOptions {
+				var result []testutil.SubtitleRowOptions
+				for _, id := range ids {
+					result = append(result, row(id))
+				}
+				return result
+			}
+			pages := map[string]string{
+				"sid=3217":         testutil.GenerateSubtitleTableHTMLWithPagination(rows(tt.page1), 1, 2, true),
+				"sid=3217&oldal=2": testutil.GenerateSubtitleTableHTMLWithPagination(rows(tt.page2), 2, 2, true),
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(pages[r.URL.RawQuery]))
+			}))
+			defer server.Close()
+
+			c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+			defer c.Close()
+			duplicatesBefore := promtestutil.ToFloat64(metrics.SubtitleListingDuplicatesDroppedTotal.WithLabelValues("show"))
+			gapsBefore := promtestutil.ToFloat64(metrics.SubtitleListingSuspectedGapsTotal.WithLabelValues("show"))
+			ctx := context.Background()
+
+			result, err := testutil.CollectSubtitles(ctx, c.StreamSubtitles(ctx, 3217))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			var gotIDs []int
+			for _, subtitle := range result.Subtitles {
+				gotIDs = append(gotIDs, subtitle.ID)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("Expected IDs %v, got %v", tt.wantIDs, gotIDs)
+			}
+			if got := promtestutil.ToFloat64(metrics.SubtitleListingDuplicatesDroppedTotal.WithLabelValues("show")) - duplicatesBefore; got != tt.wantDuplicates {
+				t.Errorf("Expected %v duplicates dropped, got %v", tt.wantDuplicates, got)
+			}
+			if got := promtestutil.ToFloat64(metrics.SubtitleListingSuspectedGapsTotal.WithLabelValues("show")) - gapsBefore; got != tt.wantGaps {
+				t.Errorf("Expected %v suspected gaps, got %v", tt.wantGaps, got)
+			}
+		})
+	}
+}
+
+func TestCheckPageBoundary(t *testing.T) {
+	t.Parallel()
+	page := func(ids ...int) []models.Subtitle {
+		var subtitles []models.Subtitle
+		for _, id := range ids {
+			subtitles = append(subtitles, models.Subtitle{ID: id})
+		}
+		return subtitles
+	}
+	seen := map[int]struct{}{101: {}, 102: {}, 103: {}}
+	tests := []struct {
+		name         string
+		lastID       int
+		page         []models.Subtitle
+		wantRepeated int
+		wantMissing  bool
+	}{
+		{"no overlap", 103, page(104, 105), 0, false},
+		{"shifted by one", 103, page(103, 104), 1, false},
+		{"shifted by two", 103, page(102, 103, 104), 2, false},
+		{"last row gone", 103, page(102, 104), 1, true},
+		{"unknown previous page", 0, page(102, 104), 1, false},
+		{"repeat after new rows is not an overlap", 103, page(104, 102), 0, false},
+		{"empty page", 103, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repeated, missing := checkPageBoundary(tt.lastID, tt.page, seen)
+			if repeated != tt.wantRepeated || missing != tt.wantMissing {
+				t.Errorf("checkPageBoundary() = %d, %v, want %d, %v", repeated, missing, tt.wantRepeated, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestClient_GetSubtitles_FiltersUploaders(t *testing.T) {
+	t.Parallel()
+	rows := []testutil.SubtitleRowOptions{
+		{ShowID: 3217, SubtitleID: 101, EredetiTitle: "Stranger Things - 1x01 (WEB.1080p)", Uploader: "gricsi", DownloadFilename: "s01e01.srt"},
+		{ShowID: 3217, SubtitleID: 102, EredetiTitle: "Stranger Things - 1x02 (WEB.1080p)", Uploader: "AutoSub", DownloadFilename: "s01e02.srt"},
+		{ShowID: 3217, SubtitleID: 103, EredetiTitle: "Stranger Things - 1x03 (WEB.1080p)", Uploader: "Kovacs", DownloadFilename: "s01e03.srt"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("", 0, 0, 0)))
+			return
+		}
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(rows)))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		blocked []string
+		allowed []string
+		wantIDs []int
+	}{
+		{name: "no filter", wantIDs: []int{101, 102, 103}},
+		{name: "blocked", blocked: []string{"autosub"}, wantIDs: []int{101, 103}},
+		{name: "allowed", allowed: []string{"GRICSI", "kovacs"}, wantIDs: []int{101, 103}},
+		{name: "blocked and allowed", blocked: []string{"Kovacs"}, allowed: []string{"gricsi", "kovacs"}, wantIDs: []int{101}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+			testConfig.Client.BlockedUploaders = tt.blocked
+			testConfig.Client.AllowedUploaders = tt.allowed
+			client := NewClient(testConfig)
+			ctx := context.Background()
+
+			result, err := testutil.CollectSubtitles(ctx, client.StreamSubtitles(ctx, 3217))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Total != len(tt.wantIDs) {
+				t.Fatalf("Expected %d subtitles, got %d", len(tt.wantIDs), result.Total)
+			}
+			for i, id := range tt.wantIDs {
+				if result.Subtitles[i].ID != id {
+					t.Errorf("Subtitle %d: expected ID %d, got %d", i, id, result.Subtitles[i].ID)
+				}
+			}
+
+			showSubtitles, err := testutil.CollectShowSubtitles(ctx, client.StreamShowSubtitles(ctx, []models.Show{{ID: 3217, Name: "Stranger Things"}}, nil))
+			if err != nil {
+				t.Fatalf("Expected no error from show subtitles, got: %v", err)
+			}
+			if len(showSubtitles) != 1 || showSubtitles[0].SubtitleCollection.Total != len(tt.wantIDs) {
+				t.Errorf("Expected %d subtitles for the show, got %+v", len(tt.wantIDs), showSubtitles)
+			}
+		})
+	}
+}
+
+func TestClient_ApplyConfig_ReloadsUploaderFilter(t *testing.T) {
+	t.Parallel()
+	rows := []testutil.SubtitleRowOptions{
+		{ShowID: 3217, SubtitleID: 101, EredetiTitle: "Stranger Things - 1x01 (WEB.1080p)", Uploader: "gricsi", DownloadFilename: "s01e01.srt"},
+		{ShowID: 3217, SubtitleID: 102, EredetiTitle: "Stranger Things - 1x01 (WEB.720p)", Uploader: "AutoSub", DownloadFilename: "s01e01.720p.srt"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tipus") == "adatlap" {
+			_, _ = w.Write([]byte(testutil.GenerateThirdPartyIDHTML("", 0, 0, 0)))
+			return
+		}
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(rows)))
+	}))
+	t.Cleanup(server.Close)
+
+	testConfig := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	testConfig.Client.BlockedUploaders = []string{"AutoSub"}
+	client := NewClient(testConfig)
+	ctx := context.Background()
+
+	ids := func() []int {
+		t.Helper()
+		subtitles, err := client.FindSubtitle(ctx, 3217, 1, 1, "")
+		if err != nil {
+			t.Fatalf("FindSubtitle failed: %v", err)
+		}
+		var ids []int
+		for _, subtitle := range subtitles {
+			ids = append(ids, subtitle.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	if got := ids(); !slices.Equal(got, []int{101}) {
+		t.Fatalf("Expected the blocked uploader to be filtered, got %v", got)
+	}
+
+	reloaded := *testConfig
+	reloaded.Client.BlockedUploaders = nil
+	client.ApplyConfig(&reloaded)
+
+	if got := ids(); !slices.Equal(got, []int{101, 102}) {
+		t.Errorf("Expected the reloaded filter to apply to the re-indexed show, got %v", got)
+	}
+	result, err := testutil.CollectSubtitles(ctx, client.StreamSubtitles(ctx, 3217))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Expected both subtitles after the reload, got %d", result.Total)
+	}
+}
+
+func TestClient_StreamSubtitlesInOrder_SortsEachPage(t *testing.T) {
+	t.Parallel()
+	// Page 1 lists episodes 3, 1, 2 and page 2 lists episodes 6, 4, 5, all uploaded the same day
+	pageEpisodes := map[string][]int{"1": {3, 1, 2}, "2": {6, 4, 5}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("oldal")
+		if page == "" {
+			page = "1"
+		}
+		episodes, ok := pageEpisodes[page]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var rows []testutil.SubtitleRowOptions
+		for _, episode := range episodes {
+			rows = append(rows, testutil.SubtitleRowOptions{
+				ShowID:           3217,
+				Language:         "Magyar",
+				FlagImage:        "hungary.gif",
+				MagyarTitle:      "Stranger Things - 1x0" + strconv.Itoa(episode),
+				EredetiTitle:     "Stranger Things - 1x0" + strconv.Itoa(episode) + " (1080p-RelGroup)",
+				UploadDate:       "2025-02-08",
+				DownloadAction:   "letolt",
+				DownloadFilename: "stranger.things.s01e0" + strconv.Itoa(episode) + ".srt",
+				SubtitleID:       1000 + episode,
+			})
+		}
+		pageNum, _ := strconv.Atoi(page)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTMLWithPagination(rows, pageNum, 2, true)))
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer client.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		order models.SubtitleOrder
+		want  []int
+	}{
+		{name: "listing", order: models.SubtitleOrderListing, want: []int{1003, 1001, 1002, 1006, 1004, 1005}},
+		{name: "upload time desc", order: models.SubtitleOrderUploadTimeDesc, want: []int{1003, 1002, 1001, 1006, 1005, 1004}},
+		{name: "upload time asc", order: models.SubtitleOrderUploadTimeAsc, want: []int{1001, 1002, 1003, 1004, 1005, 1006}},
+		{name: "season episode", order: models.SubtitleOrderSeasonEpisode, want: []int{1001, 1002, 1003, 1004, 1005, 1006}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := testutil.CollectSubtitles(ctx, client.StreamSubtitlesInOrder(ctx, 3217, tt.order))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			var ids []int
+			for _, subtitle := range result.Subtitles {
+				ids = append(ids, subtitle.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestClient_StreamMovieSubtitles(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		switch r.URL.RawQuery {
+		case "fid=7":
+			// Film listings can use the show layout; its rows have no NxNN pattern
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{{
+				SkipShowIDDefault: true,
+				Language:          "Magyar",
+				FlagImage:         "hungary.gif",
+				MagyarTitle:       "Dűne: Második rész",
+				EredetiTitle:      "Dune Part Two (WEB.1080p-FLUX)",
+				UploadDate:        "2024-04-02",
+				DownloadAction:    "letolt",
+				DownloadFilename:  "dune.part.two.srt",
+				SubtitleID:        1712000001,
+			}}, 1, 2, true)))
+		case "fid=7&oldal=2":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testutil.GenerateMovieSubtitleTableHTML([]testutil.SubtitleRowOptions{{
+				Language:         "Angol",
+				MagyarTitle:      "Oppenheimer (2023)",
+				EredetiTitle:     "Oppenheimer (2023) (AMZN.WEB-DL.1080p-FLUX)",
+				UploadDate:       "2024-03-02",
+				DownloadAction:   "letolt",
+				DownloadFilename: "oppenheimer.2023.1080p.srt",
+				SubtitleID:       1709380001,
+			}})))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	ctx := context.Background()
+
+	result, err := testutil.CollectSubtitles(ctx, c.StreamMovieSubtitles(ctx, 7))
+	if err != nil {
+		t.Fatalf("StreamMovieSubtitles failed: %v", err)
+	}
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", result.Total)
+	}
+	for _, subtitle := range result.Subtitles {
+		if subtitle.ContentType != models.ContentTypeFilm || subtitle.Season != -1 || subtitle.Episode != -1 || subtitle.IsSeasonPack {
+			t.Errorf("Expected a film without season or episode, got %+v", subtitle)
+		}
+	}
+	if title := result.Subtitles[0].MovieTitle; title != "Dune Part Two" {
+		t.Errorf("Expected the show layout row to be titled Dune Part Two, got %q", title)
+	}
+	if movie := result.Subtitles[1]; movie.MovieTitle != "Oppenheimer" || movie.MovieYear != 2023 {
+		t.Errorf("Expected Oppenheimer (2023), got %q (%d)", movie.MovieTitle, movie.MovieYear)
+	}
+	mu.Lock()
+	if !slices.Equal(queries, []string{"fid=7", "fid=7&oldal=2"}) {
+		t.Errorf("Expected the film listing pages to be fetched, got %v", queries)
+	}
+	mu.Unlock()
+
+	_, err = testutil.CollectSubtitles(ctx, c.StreamMovieSubtitles(ctx, 8))
+	if !errors.Is(err, &apperrors.ErrNotFound{}) {
+		t.Errorf("Expected ErrNotFound for an unknown film, got %v", err)
+	}
+}
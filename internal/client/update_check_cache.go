@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// defaultUpdateCheckTTL is how long an update check is reused when client.update_check_ttl is empty.
+	defaultUpdateCheckTTL = 60 * time.Second
+	// updateCheckCacheSize bounds how many content IDs keep a cached update check.
+	updateCheckCacheSize = 1024
+)
+
+// updateCheckCall is a single shared update check. Its result fields are written once
+// by the leader before done is closed and are read-only afterwards.
+type updateCheckCall struct {
+	done   chan struct{}
+	result models.UpdateCheckResult
+	err    error
+}
+
+// updateCheckCache reuses update-check results per content ID for a short TTL and coalesces
+// concurrent checks for the same content ID into one upstream request, so clients polling on
+// their own schedules do not multiply identical requests.
+type updateCheckCache struct {
+	results *lru.LRU[int64, models.UpdateCheckResult] // nil when caching is disabled
+
+	mu    sync.Mutex
+	calls map[int64]*updateCheckCall
+}
+
+// newUpdateCheckCache creates an update-check cache. A zero ttl disables caching, but
+// concurrent checks are still coalesced.
+func newUpdateCheckCache(ttl time.Duration) *updateCheckCache {
+	u := &updateCheckCache{calls: make(map[int64]*updateCheckCall)}
+	if ttl > 0 {
+		u.results = lru.NewLRU[int64, models.UpdateCheckResult](updateCheckCacheSize, nil, ttl)
+	}
+	return u
+}
+
+// get returns the cached result for contentID, or runs fetch for it. forceRefresh skips the
+// cached result but still joins a check already in flight, which is at least as fresh. fetch
+// runs detached from ctx cancellation so one caller giving up never fails the others; the HTTP
+// client timeout still bounds it. Failed checks are not cached.
+func (u *updateCheckCache) get(ctx context.Context, contentID int64, forceRefresh bool, fetch func(ctx context.Context) (models.UpdateCheckResult, error)) (*models.UpdateCheckResult, error) {
+	if !forceRefresh && u.results != nil {
+		if result, ok := u.results.Get(contentID); ok {
+			return &result, nil
+		}
+	}
+
+	u.mu.Lock()
+	call, ok := u.calls[contentID]
+	if !ok {
+		call = &updateCheckCall{done: make(chan struct{})}
+		u.calls[contentID] = call
+		go func() {
+			call.result, call.err = fetch(context.WithoutCancel(ctx))
+			if call.err == nil && u.results != nil {
+				u.results.Add(contentID, call.result)
+			}
+
+			u.mu.Lock()
+			delete(u.calls, contentID)
+			u.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	u.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		result := call.result
+		return &result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
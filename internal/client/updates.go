@@ -5,13 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
-// CheckForUpdates checks if there are any updates available since a specific content ID
-func (c *client) CheckForUpdates(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error) {
+// CheckForUpdates checks if there are any updates available since a specific content ID.
+// Results are reused for client.update_check_ttl and concurrent checks for the same content ID
+// share one upstream request; forceRefresh skips the cached result.
+func (c *client) CheckForUpdates(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
+	return c.updateChecks.get(ctx, contentID, forceRefresh, func(ctx context.Context) (models.UpdateCheckResult, error) {
+		return c.fetchUpdateCheck(ctx, contentID)
+	})
+}
+
+// fetchUpdateCheck asks the recheck endpoint for the number of updates since contentID.
+func (c *client) fetchUpdateCheck(ctx context.Context, contentID int64) (models.UpdateCheckResult, error) {
 	logger := config.GetLogger()
 
 	// Convert int64 to string for the API
@@ -24,32 +35,34 @@ func (c *client) CheckForUpdates(ctx context.Context, contentID int64) (*models.
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return models.UpdateCheckResult{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set user agent to avoid being blocked
 	req.Header.Set("User-Agent", config.GetUserAgent())
 
 	resp, err := c.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointUpdates, resp, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check for updates: %w", err)
+		return models.UpdateCheckResult{}, fmt.Errorf("failed to check for updates: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return models.UpdateCheckResult{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	// Parse JSON response
 	var updateResponse models.UpdateCheckResponse
 	if err := json.NewDecoder(resp.Body).Decode(&updateResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
+		return models.UpdateCheckResult{}, fmt.Errorf("failed to decode JSON response: %w", err)
 	}
 
-	result := &models.UpdateCheckResult{
+	result := models.UpdateCheckResult{
 		FilmCount:   updateResponse.Film,
 		SeriesCount: updateResponse.Sorozat,
 		HasUpdates:  updateResponse.Film > 0 || updateResponse.Sorozat > 0,
+		CheckedAt:   time.Now().UTC(),
 	}
 
 	logger.Info().
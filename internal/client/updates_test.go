@@ -4,9 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 func TestClient_CheckForUpdates(t *testing.T) {
@@ -36,7 +40,7 @@ func TestClient_CheckForUpdates(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1760700519)
+	result, err := client.CheckForUpdates(ctx, 1760700519, false)
 
 	// Test that the call succeeds
 	if err != nil {
@@ -85,7 +89,7 @@ func TestClient_CheckForUpdates_NoUpdates(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1760700519)
+	result, err := client.CheckForUpdates(ctx, 1760700519, false)
 
 	// Test that the call succeeds
 	if err != nil {
@@ -129,7 +133,7 @@ func TestClient_CheckForUpdates_ServerError(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1760700519)
+	result, err := client.CheckForUpdates(ctx, 1760700519, false)
 
 	// Test that the call fails with an error
 	if err == nil {
@@ -161,7 +165,7 @@ func TestClient_CheckForUpdates_InvalidJSON(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1760700519)
+	result, err := client.CheckForUpdates(ctx, 1760700519, false)
 
 	// Test that the call fails with JSON decode error
 	if err == nil {
@@ -197,7 +201,7 @@ func TestClient_CheckForUpdates_StringValues(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1771493497)
+	result, err := client.CheckForUpdates(ctx, 1771493497, false)
 
 	// Test that the call succeeds even with string values
 	if err != nil {
@@ -246,7 +250,7 @@ func TestClient_CheckForUpdates_MixedTypes(t *testing.T) {
 
 	// Call CheckForUpdates
 	ctx := context.Background()
-	result, err := client.CheckForUpdates(ctx, 1771493497)
+	result, err := client.CheckForUpdates(ctx, 1771493497, false)
 
 	// Test that the call succeeds with mixed types
 	if err != nil {
@@ -270,3 +274,105 @@ func TestClient_CheckForUpdates_MixedTypes(t *testing.T) {
 		t.Error("Expected HasUpdates to be true")
 	}
 }
+
+// newUpdateCheckServer returns a recheck endpoint that counts its requests and waits delay before answering.
+func newUpdateCheckServer(t *testing.T, delay time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"film":"1","sorozat":"2"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestClient_CheckForUpdates_CoalescesConcurrentCallers(t *testing.T) {
+	t.Parallel()
+	const callers = 8
+	server, hits := newUpdateCheckServer(t, 300*time.Millisecond)
+	cfg := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	// Caching is disabled so only coalescing can keep the callers to one request
+	cfg.Client.UpdateCheckTTL = "0s"
+	c := NewClient(cfg)
+
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Go(func() {
+			var result *models.UpdateCheckResult
+			result, errs[i] = c.CheckForUpdates(context.Background(), 42, false)
+			if errs[i] == nil && result.SeriesCount != 2 {
+				t.Errorf("Caller %d: expected 2 series updates, got %d", i, result.SeriesCount)
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Caller %d failed: %v", i, err)
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected 1 upstream request for %d concurrent callers, got %d", callers, got)
+	}
+}
+
+func TestClient_CheckForUpdates_RefetchesAfterTTL(t *testing.T) {
+	t.Parallel()
+	server, hits := newUpdateCheckServer(t, 0)
+	cfg := &config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"}
+	cfg.Client.UpdateCheckTTL = "100ms"
+	c := NewClient(cfg)
+
+	first, err := c.CheckForUpdates(context.Background(), 42, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if first.CheckedAt.IsZero() {
+		t.Error("Expected CheckedAt to be set")
+	}
+	cached, err := c.CheckForUpdates(context.Background(), 42, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected the second check to be cached, got %d upstream requests", got)
+	}
+	if !cached.CheckedAt.Equal(first.CheckedAt) {
+		t.Errorf("Expected the cached result to keep CheckedAt %v, got %v", first.CheckedAt, cached.CheckedAt)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if _, err := c.CheckForUpdates(context.Background(), 42, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected a new upstream request after the TTL, got %d", got)
+	}
+}
+
+func TestClient_CheckForUpdates_ForceRefreshBypassesCache(t *testing.T) {
+	t.Parallel()
+	server, hits := newUpdateCheckServer(t, 0)
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+
+	for _, forceRefresh := range []bool{false, false, true} {
+		if _, err := c.CheckForUpdates(context.Background(), 42, forceRefresh); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected one cached check and one forced refresh, got %d upstream requests", got)
+	}
+	// A different content ID has its own entry
+	if _, err := c.CheckForUpdates(context.Background(), 43, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("Expected a separate request for another content ID, got %d", got)
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
+)
+
+// minListingPageBytes is the size below which a page that is not a listing is taken for a block
+// page even without a known marker; real listing pages, even empty ones, are far larger.
+const minListingPageBytes = 2048
+
+// loginPathMarkers identify login pages in the URL a request was redirected to.
+var loginPathMarkers = []string{"login", "belepes", "bejelentkezes"}
+
+// blockTracker remembers whether the site currently serves block pages, such as a captcha,
+// instead of listings. A block page marks the upstream as blocked and the next listing that
+// parses clears it; other failures leave the state unchanged.
+type blockTracker struct {
+	mu      sync.Mutex
+	blocked bool
+	reason  string
+	since   time.Time
+}
+
+// observe updates the state from the outcome of parsing a listing page.
+func (b *blockTracker) observe(err error) {
+	var blocked *apperrors.ErrUpstreamBlocked
+	switch {
+	case errors.As(err, &blocked):
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.reason = blocked.Reason
+		if b.blocked {
+			return
+		}
+		b.blocked = true
+		b.since = time.Now()
+		metrics.UpstreamBlocked.Set(1)
+		logger := config.GetLogger()
+		logger.Error().Str("reason", blocked.Reason).Msg("Upstream is serving block pages instead of listings")
+	case err == nil:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if !b.blocked {
+			return
+		}
+		logger := config.GetLogger()
+		logger.Info().Dur("blockedFor", time.Since(b.since)).Msg("Upstream is serving listings again")
+		b.blocked, b.reason, b.since = false, "", time.Time{}
+		metrics.UpstreamBlocked.Set(0)
+	}
+}
+
+// apply copies the block state into status.
+func (b *blockTracker) apply(status *models.UpstreamStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status.Blocked = b.blocked
+	status.BlockedReason = b.reason
+	status.BlockedSince = b.since
+}
+
+// parseListingPage parses a subtitle listing response fetched for the given endpoint kind and
+// returns the page with the size of its body. A redirect to a login page, a block page, or a
+// page too small to be a listing returns apperrors.ErrUpstreamBlocked; the outcome updates
+// the client's block state.
+func (c *client) parseListingPage(resp *http.Response, endpoint string) (*parser.SubtitlePageResult, int64, error) {
+	if reason := loginRedirect(resp); reason != "" {
+		err := &apperrors.ErrUpstreamBlocked{Reason: reason}
+		c.blocks.observe(err)
+		return nil, 0, err
+	}
+
+	body := metrics.NewUpstreamBody(endpoint, resp.Body)
+	result, err := c.subtitleParser.ParseHtmlWithPagination(body)
+	if errors.Is(err, &apperrors.ErrUnexpectedPage{}) && body.Bytes() < minListingPageBytes {
+		err = &apperrors.ErrUpstreamBlocked{Reason: fmt.Sprintf("%d-byte page instead of a listing", body.Bytes())}
+	}
+	c.blocks.observe(err)
+	return result, body.Bytes(), err
+}
+
+// loginRedirect returns why resp looks like the result of a redirect to a login page, or an
+// empty string when it was not redirected to one.
+func loginRedirect(resp *http.Response) string {
+	// The final request only carries a response when it was created by a redirect
+	if resp.Request == nil || resp.Request.Response == nil {
+		return ""
+	}
+	target := strings.ToLower(resp.Request.URL.Path + "?" + resp.Request.URL.RawQuery)
+	for _, marker := range loginPathMarkers {
+		if strings.Contains(target, marker) {
+			return "redirected to login page " + resp.Request.URL.Redacted()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Not parallel: the upstream_blocked gauge is shared by every client in the process
+func TestClient_UpstreamBlocked_CaptchaThenRecovery(t *testing.T) {
+	var banned atomic.Bool
+	banned.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if banned.Load() {
+			_, _ = w.Write([]byte(testutil.GenerateCaptchaPageHTML()))
+			return
+		}
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+			{SubtitleID: 1770600001, ShowID: 1, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB)", DownloadFilename: "dark.s01e01.srt"},
+		})))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+	ctx := context.Background()
+
+	_, err := testutil.CollectSubtitles(ctx, c.StreamSubtitles(ctx, 1))
+	var blocked *apperrors.ErrUpstreamBlocked
+	if !errors.As(err, &blocked) || blocked.Reason != "captcha page" {
+		t.Fatalf("Expected ErrUpstreamBlocked for a captcha page, got %v", err)
+	}
+	status := c.UpstreamStatus()
+	if !status.Blocked || status.BlockedReason != "captcha page" || status.BlockedSince.IsZero() {
+		t.Errorf("Expected the status to report the captcha block, got %+v", status)
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamBlocked); got != 1 {
+		t.Errorf("Expected upstream_blocked 1, got %v", got)
+	}
+
+	banned.Store(false)
+	result, err := testutil.CollectSubtitles(ctx, c.StreamSubtitles(ctx, 1))
+	if err != nil || result.Total != 1 {
+		t.Fatalf("Expected the listing once the ban is lifted, got %v, %v", result, err)
+	}
+	status = c.UpstreamStatus()
+	if status.Blocked || status.BlockedReason != "" || !status.BlockedSince.IsZero() {
+		t.Errorf("Expected the block to be cleared, got %+v", status)
+	}
+	if got := promtestutil.ToFloat64(metrics.UpstreamBlocked); got != 0 {
+		t.Errorf("Expected upstream_blocked 0, got %v", got)
+	}
+}
+
+func TestClient_UpstreamBlocked_Detection(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantReason string
+	}{
+		{
+			name: "redirect to login",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("tab") == "belepes" {
+					_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(nil)))
+					return
+				}
+				http.Redirect(w, r, "/index.php?tab=belepes", http.StatusFound)
+			},
+			wantReason: "redirected to login page",
+		},
+		{
+			name: "tiny page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testutil.GenerateHTMLWithBody("Szolgáltatás átmenetileg nem elérhető")))
+			},
+			wantReason: "instead of a listing",
+		},
+		{
+			name: "login form",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testutil.GenerateLoginPageHTML()))
+			},
+			wantReason: "login page",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+			defer c.Close()
+
+			_, err := c.GetLatestSubtitleID(context.Background())
+			var blocked *apperrors.ErrUpstreamBlocked
+			if !errors.As(err, &blocked) || !strings.Contains(blocked.Reason, tt.wantReason) {
+				t.Fatalf("Expected ErrUpstreamBlocked mentioning %q, got %v", tt.wantReason, err)
+			}
+			if !c.UpstreamStatus().Blocked {
+				t.Error("Expected the status to report the block")
+			}
+		})
+	}
+}
+
+func TestClient_StreamShowList_CaptchaIsAnError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testutil.GenerateCaptchaPageHTML()))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+	ctx := context.Background()
+
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
+	if !errors.Is(err, &apperrors.ErrUpstreamBlocked{}) {
+		t.Fatalf("Expected ErrUpstreamBlocked instead of an empty list, got %d shows and %v", len(shows), err)
+	}
+}
+
+func TestClient_UpstreamMaintenance(t *testing.T) {
+	t.Parallel()
+	var maintenance atomic.Bool
+	maintenance.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if maintenance.Load() {
+			_, _ = w.Write([]byte(testutil.GenerateMaintenancePageHTML()))
+			return
+		}
+		_, _ = w.Write([]byte(testutil.GenerateSubtitleTableHTML(nil)))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+	ctx := context.Background()
+
+	_, err := testutil.CollectSubtitles(ctx, c.StreamSubtitles(ctx, 1))
+	if !errors.Is(err, &apperrors.ErrUpstreamMaintenance{}) {
+		t.Fatalf("Expected ErrUpstreamMaintenance instead of an empty listing, got %v", err)
+	}
+	if c.UpstreamStatus().Blocked {
+		t.Error("Expected maintenance not to be reported as a block")
+	}
+
+	// A genuinely empty listing stays an empty result
+	maintenance.Store(false)
+	result, err := testutil.CollectSubtitles(ctx, c.StreamSubtitles(ctx, 1))
+	if err != nil || result.Total != 0 {
+		t.Fatalf("Expected an empty listing without error, got %v, %v", result, err)
+	}
+}
+
+func TestClient_StreamShowList_MaintenanceIsAnError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testutil.GenerateMaintenancePageHTML()))
+	}))
+	defer server.Close()
+
+	c := NewClient(&config.Config{SuperSubtitleDomain: server.URL, ClientTimeout: "10s"})
+	defer c.Close()
+	ctx := context.Background()
+
+	shows, err := testutil.CollectShows(ctx, c.StreamShowList(ctx, 0))
+	if !errors.Is(err, &apperrors.ErrUpstreamMaintenance{}) {
+		t.Fatalf("Expected ErrUpstreamMaintenance instead of an empty list, got %d shows and %v", len(shows), err)
+	}
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Belphemur/SuperSubtitles/v2/internal/buildinfo"
@@ -16,30 +20,82 @@ import (
 const DefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:147.0) Gecko/20100101 Firefox/147.0"
 
 type Config struct {
-	ProxyConnectionString string `mapstructure:"proxy_connection_string"`
-	SuperSubtitleDomain   string `mapstructure:"super_subtitle_domain"`
-	ClientTimeout         string `mapstructure:"client_timeout"` // Go duration string like "30s", "1h", etc.
-	UserAgent             string `mapstructure:"user_agent"`
-	Server                struct {
-		Port    int    `mapstructure:"port"`
-		Address string `mapstructure:"address"`
+	ProxyConnectionString string   `mapstructure:"proxy_connection_string"`
+	ProxyNoProxy          []string `mapstructure:"proxy_no_proxy"` // Host names or domain suffixes dialled directly instead of through the proxy
+	SuperSubtitleDomain   string   `mapstructure:"super_subtitle_domain"`
+	SuperSubtitleDomains  []string `mapstructure:"super_subtitle_domains"` // Ordered mirror base URLs, primary first, replacing super_subtitle_domain when set
+	ClientTimeout         string   `mapstructure:"client_timeout"`         // Go duration string like "30s", "1h", etc.
+	UserAgent             string   `mapstructure:"user_agent"`
+	Client                struct {
+		ShowSubtitlesConcurrency int      `mapstructure:"show_subtitles_concurrency"` // Maximum shows fetched concurrently when streaming show subtitles (0 uses default of 4)
+		SubtitleIndexMaxShows    int      `mapstructure:"subtitle_index_max_shows"`   // Maximum shows kept in the FindSubtitle index before the least recently used is evicted (0 uses default of 500)
+		UpdateCheckTTL           string   `mapstructure:"update_check_ttl"`           // Go duration an update check is reused per content ID (empty uses default of 60s, "0s" disables caching)
+		PerShowTimeout           string   `mapstructure:"per_show_timeout"`           // Go duration bounding each show's fetch when streaming show subtitles (empty uses default of 30s, "0s" disables)
+		LanguageEarlyExitPages   int      `mapstructure:"language_early_exit_pages"`  // Listing pages fetched for a show before a language-filtered stream gives up on it when none matched (0 uses default of 2, negative disables)
+		SelfCheckShowID          int      `mapstructure:"self_check_show_id"`         // Show whose listing the SelfCheck RPC parses (0 uses default of 3217)
+		MirrorCooldown           string   `mapstructure:"mirror_cooldown"`            // Go duration requests stay on a failover mirror before the primary is tried again (empty uses default of 5m)
+		HedgeDelay               string   `mapstructure:"hedge_delay"`                // Go duration after which a slow show list page is requested a second time in parallel (empty or "0s" disables)
+		BlockedUploaders         []string `mapstructure:"blocked_uploaders"`          // Uploader names whose subtitles are dropped (case-insensitive exact match)
+		AllowedUploaders         []string `mapstructure:"allowed_uploaders"`          // When set, only subtitles from these uploaders are kept (case-insensitive exact match)
+	} `mapstructure:"client"`
+	Parser struct {
+		NormalizeTitles *bool `mapstructure:"normalize_titles"` // Strip source artifacts such as "Addic7ed.com" from episode titles (unset uses default of true)
+	} `mapstructure:"parser"`
+	Server struct {
+		Port            int      `mapstructure:"port"`
+		Address         string   `mapstructure:"address"`
+		ShutdownTimeout string   `mapstructure:"shutdown_timeout"` // Go duration to drain in-flight RPCs on shutdown before forcing a stop (empty uses default of 30s)
+		RPCTimeout      string   `mapstructure:"rpc_timeout"`      // Go duration bounding unary RPCs sent without a deadline (empty uses default of 2m, "0s" disables)
+		StreamTimeout   string   `mapstructure:"stream_timeout"`   // Go duration bounding streaming RPCs sent without a deadline (empty uses default of 30m, "0s" disables)
+		HTTPPort        int      `mapstructure:"http_port"`        // Port of the WebSocket bridge for browser clients (0 disables it)
+		AllowedOrigins  []string `mapstructure:"allowed_origins"`  // Origins such as "https://ui.example.com" whose pages may open the WebSocket bridge, "*" for any (empty allows the bridge's own host only)
+		TLS             struct {
+			CertFile     string `mapstructure:"cert_file"`      // PEM server certificate; TLS is enabled when set together with key_file
+			KeyFile      string `mapstructure:"key_file"`       // PEM private key matching cert_file
+			ClientCAFile string `mapstructure:"client_ca_file"` // Optional PEM CA bundle; when set, client certificates are required (mTLS)
+		} `mapstructure:"tls"`
 	} `mapstructure:"server"`
+	Auth struct {
+		Tokens []string `mapstructure:"tokens"` // Static bearer tokens accepted in the "authorization" metadata; empty disables auth
+	} `mapstructure:"auth"`
 	LogLevel  string `mapstructure:"log_level"`
 	LogFormat string `mapstructure:"log_format"` // Log output format: "console" (default) or "json"
 	Cache     struct {
-		Type  string `mapstructure:"type"` // Cache backend: "memory" (default) or "redis"
-		Size  int    `mapstructure:"size"` // Maximum number of entries in the LRU cache
-		TTL   string `mapstructure:"ttl"`  // Go duration string like "1h", "24h", etc.
-		Redis struct {
-			Address  string `mapstructure:"address"`  // Redis/Valkey server address (e.g., "localhost:6379")
-			Password string `mapstructure:"password"` // Redis/Valkey password (optional)
-			DB       int    `mapstructure:"db"`       // Redis/Valkey database number (default 0)
+		Type           string `mapstructure:"type"`             // Cache backend: "memory" (default) or "redis"
+		Size           int    `mapstructure:"size"`             // Maximum number of entries in the LRU cache
+		MaxBytes       int64  `mapstructure:"max_bytes"`        // Memory backend: evict by total cached bytes instead of entry count (0 keeps the entry count)
+		TTL            string `mapstructure:"ttl"`              // Go duration string like "1h", "24h", etc.
+		ImageTTL       string `mapstructure:"image_ttl"`        // Go duration show posters are cached (empty uses default of 168h)
+		ThirdPartyTTL  string `mapstructure:"third_party_ttl"`  // Go duration a show's third-party IDs, year and status are cached (empty uses default of 168h, "0s" disables)
+		PreloadShowIDs []int  `mapstructure:"preload_show_ids"` // Shows whose newest season packs are cached in the background at startup (optional)
+		Redis          struct {
+			Address   string `mapstructure:"address"`    // Redis/Valkey server address (e.g., "localhost:6379")
+			Password  string `mapstructure:"password"`   // Redis/Valkey password (optional)
+			DB        int    `mapstructure:"db"`         // Redis/Valkey database number (default 0)
+			KeyPrefix string `mapstructure:"key_prefix"` // Namespace for cache keys when several deployments share one Redis (optional)
 		} `mapstructure:"redis"`
 	} `mapstructure:"cache"`
+	Download struct {
+		MaxFileSizeMB               int      `mapstructure:"max_file_size_mb"`              // Largest uncompressed subtitle accepted in an archive (0 uses default of 20)
+		MaxAssFileSizeMB            int      `mapstructure:"max_ass_file_size_mb"`          // Largest uncompressed .ass subtitle, which may embed fonts (0 uses default of 100)
+		MaxArchiveSizeMB            int      `mapstructure:"max_archive_size_mb"`           // Largest total uncompressed size of an archive (0 uses default of 100)
+		MaxDownloadSizeMB           int      `mapstructure:"max_download_size_mb"`          // Largest response body accepted from the upstream download (0 uses default of 150)
+		NotFoundTTL                 string   `mapstructure:"not_found_ttl"`                 // Go duration an upstream 404 is remembered per download (empty uses default of 10m, "0s" disables)
+		ExtractionExtensionDenylist []string `mapstructure:"extraction_extension_denylist"` // Extensions never returned when searching an archive for an episode, such as ".exe" (empty uses the built-in list)
+		FilenameTemplate            string   `mapstructure:"filename_template"`             // Go text/template naming episodes extracted from season packs (empty keeps the name inside the archive)
+		MaxResumeAttempts           int      `mapstructure:"max_resume_attempts"`           // Times a download cut off mid-body is resumed with a Range request (0 uses default of 3, negative disables)
+		DefaultCharset              string   `mapstructure:"default_charset"`               // Encoding assumed for text subtitles that are not valid UTF-8, such as "iso-8859-2" (empty uses detection)
+	} `mapstructure:"download"`
 	Metrics struct {
 		Enabled bool `mapstructure:"enabled"` // Whether to expose Prometheus metrics
 		Port    int  `mapstructure:"port"`    // Port for the metrics HTTP server
 	} `mapstructure:"metrics"`
+	Tracing struct {
+		Enabled      bool    `mapstructure:"enabled"`       // Whether to export OpenTelemetry traces (default false)
+		OTLPEndpoint string  `mapstructure:"otlp_endpoint"` // OTLP/gRPC collector address, e.g. "localhost:4317" (required when enabled)
+		Insecure     bool    `mapstructure:"insecure"`      // Connect to the collector without TLS
+		SampleRatio  float64 `mapstructure:"sample_ratio"`  // Fraction of root traces sampled, between 0 and 1 (0 uses default of 1)
+	} `mapstructure:"tracing"`
 	Sentry struct {
 		DSN          string `mapstructure:"dsn"`           // Sentry DSN; empty disables Sentry reporting
 		Environment  string `mapstructure:"environment"`   // Optional Sentry environment override
@@ -55,20 +111,64 @@ type Config struct {
 }
 
 var (
-	globalConfig *Config
+	globalConfig atomic.Pointer[Config]
 	logger       zerolog.Logger
+	initOnce     sync.Once
+	initErr      error
 )
 
-func init() {
+// InitOptions customizes how the configuration and logger are initialized.
+type InitOptions struct {
+	// ConfigFile is an explicit path to a YAML config file. When empty, config.yaml
+	// is searched for in the working directory and ./config.
+	ConfigFile string
+	// LogOutput receives all log output. Defaults to os.Stdout.
+	LogOutput io.Writer
+}
+
+// Init loads the configuration and configures logging with the given options.
+// It must be called before any other function in this package; otherwise the
+// defaults are applied on first access. Calling it again has no effect and
+// returns an error.
+func Init(opts InitOptions) error {
+	called := false
+	initOnce.Do(func() {
+		called = true
+		initErr = initialize(opts)
+	})
+	if !called {
+		return errors.New("configuration already initialized")
+	}
+	return initErr
+}
+
+// ensureInitialized applies the default initialization on first access.
+// A configuration that cannot be loaded is fatal here, as there is no caller
+// to report the error to.
+func ensureInitialized() {
+	initOnce.Do(func() {
+		initErr = initialize(InitOptions{})
+		if initErr != nil {
+			logger.Fatal().Err(initErr).Msg("Failed to load config")
+		}
+	})
+}
+
+func initialize(opts InitOptions) error {
+	out := opts.LogOutput
+	if out == nil {
+		out = os.Stdout
+	}
+
 	// Initialize zerolog with console writer for human-readable output (default before config loads)
 	logger = zerolog.New(zerolog.ConsoleWriter{
-		Out:     os.Stdout,
+		Out:     out,
 		NoColor: false,
 	}).With().Timestamp().Logger()
 
-	config, err := LoadConfig()
+	config, err := loadConfig(opts.ConfigFile)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to load config")
+		return err
 	}
 
 	// Determine the base output writer from the log_format setting before
@@ -76,30 +176,22 @@ func init() {
 	var baseWriter io.Writer
 	switch config.LogFormat {
 	case "json":
-		baseWriter = os.Stdout
+		baseWriter = out
 	case "console", "":
-		baseWriter = zerolog.ConsoleWriter{Out: os.Stdout, NoColor: false}
+		baseWriter = zerolog.ConsoleWriter{Out: out, NoColor: false}
 	default:
 		logger.Warn().Str("invalid_format", config.LogFormat).Msg("Invalid log format, using default 'console'")
-		baseWriter = zerolog.ConsoleWriter{Out: os.Stdout, NoColor: false}
-	}
-
-	// Parse and set log level from config
-	level := zerolog.InfoLevel // default
-	if config.LogLevel != "" {
-		if parsedLevel, err := zerolog.ParseLevel(config.LogLevel); err == nil {
-			level = parsedLevel
-		} else {
-			logger.Warn().Str("invalid_level", config.LogLevel).Msg("Invalid log level, using default 'info'")
-		}
+		baseWriter = zerolog.ConsoleWriter{Out: out, NoColor: false}
 	}
 
-	// Set the global log level
+	// Set the global log level. Loggers are not given their own level so that
+	// a reload can change it for every copy handed out by GetLogger.
+	level := parseLogLevel(config.LogLevel)
 	zerolog.SetGlobalLevel(level)
 
-	// Rebuild the logger with the configured format and level so that
+	// Rebuild the logger with the configured format so that
 	// Sentry-init messages (below) are already using the right writer.
-	logger = zerolog.New(baseWriter).With().Timestamp().Logger().Level(level)
+	logger = zerolog.New(baseWriter).With().Timestamp().Logger()
 
 	// Initialize Sentry after the logger is configured so any warnings or
 	// info messages emitted during init use the correct log format/level.
@@ -111,19 +203,43 @@ func init() {
 	// recorded as Sentry breadcrumbs and structured logs.
 	if sentryio.Enabled() {
 		writer := zerolog.MultiLevelWriter(baseWriter, sentryio.NewWriter())
-		logger = zerolog.New(writer).With().Timestamp().Logger().Level(level)
+		logger = zerolog.New(writer).With().Timestamp().Logger()
 	}
 
 	logger.Info().Str("level", level.String()).Msg("Logging configured")
-	globalConfig = config
+	globalConfig.Store(config)
 	logger.Info().Msg("Configuration loaded successfully")
+	return nil
+}
+
+// parseLogLevel returns the zerolog level for value, falling back to info when it is empty or invalid.
+func parseLogLevel(value string) zerolog.Level {
+	if value == "" {
+		return zerolog.InfoLevel
+	}
+	level, err := zerolog.ParseLevel(value)
+	if err != nil {
+		logger.Warn().Str("invalid_level", value).Msg("Invalid log level, using default 'info'")
+		return zerolog.InfoLevel
+	}
+	return level
 }
 
+// LoadConfig reads config.yaml from the working directory or ./config, merged with
+// APP_-prefixed environment variables.
 func LoadConfig() (*Config, error) {
-	viper.SetConfigName("config")
+	return loadConfig("")
+}
+
+func loadConfig(configFile string) (*Config, error) {
 	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("./config")
+	if configFile != "" {
+		viper.SetConfigFile(configFile)
+	} else {
+		viper.SetConfigName("config")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("./config")
+	}
 
 	// Environment variable support
 	viper.AutomaticEnv()
@@ -135,13 +251,18 @@ func LoadConfig() (*Config, error) {
 	// Add specific environment variable for log format
 	_ = viper.BindEnv("log_format", "LOG_FORMAT")
 
-	// Read config file
+	// Read config file; a missing file is only tolerated when searching default locations
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, err
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok || configFile != "" {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
 	}
 
+	return unmarshalConfig()
+}
+
+// unmarshalConfig decodes the values currently held by viper.
+func unmarshalConfig() (*Config, error) {
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, err
@@ -154,18 +275,21 @@ func LoadConfig() (*Config, error) {
 }
 
 func GetConfig() *Config {
-	return globalConfig
+	ensureInitialized()
+	return globalConfig.Load()
 }
 
 func GetUserAgent() string {
-	if globalConfig != nil && globalConfig.UserAgent != "" {
-		return globalConfig.UserAgent
+	ensureInitialized()
+	if cfg := globalConfig.Load(); cfg != nil && cfg.UserAgent != "" {
+		return cfg.UserAgent
 	}
 
 	return DefaultUserAgent
 }
 
 func GetLogger() zerolog.Logger {
+	ensureInitialized()
 	return logger
 }
 
@@ -177,7 +301,7 @@ func FlushSentry() bool {
 func initSentry(cfg *Config) error {
 	flushTimeout := 2 * time.Second
 	if cfg.Sentry.FlushTimeout != "" {
-		parsedTimeout, err := time.ParseDuration(cfg.Sentry.FlushTimeout)
+		parsedTimeout, err := ParseDuration("sentry.flush_timeout", cfg.Sentry.FlushTimeout)
 		if err != nil {
 			logger.Warn().
 				Err(err).
@@ -0,0 +1,42 @@
+package config
+
+// Default download size limits in megabytes, used when the matching download setting is zero.
+const (
+	DefaultMaxFileSizeMB     = 20
+	DefaultMaxAssFileSizeMB  = 100
+	DefaultMaxArchiveSizeMB  = 100
+	DefaultMaxDownloadSizeMB = 150
+)
+
+// DownloadLimits holds the resolved download size limits in bytes.
+type DownloadLimits struct {
+	MaxFileSize     int64 // Largest uncompressed archive entry
+	MaxAssFileSize  int64 // Largest uncompressed .ass archive entry
+	MaxArchiveSize  int64 // Largest total uncompressed size of an archive
+	MaxDownloadSize int64 // Largest response body read from the upstream download
+}
+
+// DownloadLimits returns the download size limits in bytes, applying the defaults to unset
+// (zero) settings. It is safe to call on a nil Config, which yields the defaults.
+func (c *Config) DownloadLimits() DownloadLimits {
+	fileMB, assMB, archiveMB, downloadMB := 0, 0, 0, 0
+	if c != nil {
+		fileMB = c.Download.MaxFileSizeMB
+		assMB = c.Download.MaxAssFileSizeMB
+		archiveMB = c.Download.MaxArchiveSizeMB
+		downloadMB = c.Download.MaxDownloadSizeMB
+	}
+	return DownloadLimits{
+		MaxFileSize:     megabytesOrDefault(fileMB, DefaultMaxFileSizeMB),
+		MaxAssFileSize:  megabytesOrDefault(assMB, DefaultMaxAssFileSizeMB),
+		MaxArchiveSize:  megabytesOrDefault(archiveMB, DefaultMaxArchiveSizeMB),
+		MaxDownloadSize: megabytesOrDefault(downloadMB, DefaultMaxDownloadSizeMB),
+	}
+}
+
+func megabytesOrDefault(value, fallback int) int64 {
+	if value <= 0 {
+		value = fallback
+	}
+	return int64(value) * 1024 * 1024
+}
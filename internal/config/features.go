@@ -0,0 +1,44 @@
+package config
+
+// Optional features reported by Features
+const (
+	FeatureAuth               = "auth"                // Bearer token authentication (auth.tokens)
+	FeatureTLS                = "tls"                 // TLS on the gRPC port (server.tls)
+	FeatureMTLS               = "mtls"                // Client certificates required (server.tls.client_ca_file)
+	FeatureWebSocketBridge    = "websocket_bridge"    // WebSocket bridge for browsers (server.http_port)
+	FeatureRedisCache         = "redis_cache"         // Redis/Valkey cache shared between instances (cache.type)
+	FeatureMetrics            = "metrics"             // Prometheus metrics endpoint (metrics.enabled)
+	FeatureTracing            = "tracing"             // OpenTelemetry trace export (tracing.enabled)
+	FeatureSentry             = "sentry"              // Sentry error reporting (sentry.dsn)
+	FeatureMirrorFailover     = "mirror_failover"     // More than one upstream base URL (super_subtitle_domains)
+	FeatureUpstreamProxy      = "upstream_proxy"      // Upstream requests sent through a proxy (proxy_connection_string)
+	FeatureTitleNormalization = "title_normalization" // Source artifacts stripped from episode titles (parser.normalize_titles)
+	FeatureDownloadResume     = "download_resume"     // Interrupted downloads resumed with Range requests (download.max_resume_attempts)
+	FeatureConfigWatcher      = "config_watcher"      // Config file changes applied without a restart, see Watching
+)
+
+// Features lists the optional features the configuration enables, in the order of the
+// Feature constants. FeatureConfigWatcher depends on how the process was started rather than
+// on a setting, so it is not included; see Watching.
+func (c *Config) Features() []string {
+	features := []string{}
+	add := func(feature string, enabled bool) {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	tlsEnabled := c.Server.TLS.CertFile != "" && c.Server.TLS.KeyFile != ""
+	add(FeatureAuth, len(c.Auth.Tokens) > 0)
+	add(FeatureTLS, tlsEnabled)
+	add(FeatureMTLS, tlsEnabled && c.Server.TLS.ClientCAFile != "")
+	add(FeatureWebSocketBridge, c.Server.HTTPPort > 0)
+	add(FeatureRedisCache, c.Cache.Type == "redis")
+	add(FeatureMetrics, c.Metrics.Enabled)
+	add(FeatureTracing, c.Tracing.Enabled)
+	add(FeatureSentry, c.Sentry.DSN != "")
+	add(FeatureMirrorFailover, len(c.UpstreamDomains()) > 1)
+	add(FeatureUpstreamProxy, c.ProxyConnectionString != "")
+	add(FeatureTitleNormalization, c.TitleNormalization())
+	add(FeatureDownloadResume, c.Download.MaxResumeAttempts >= 0)
+	return features
+}
@@ -0,0 +1,7 @@
+package config
+
+// TitleNormalization reports whether episode titles are normalized, as set by
+// parser.normalize_titles. It is on unless the setting is explicitly false.
+func (c *Config) TitleNormalization() bool {
+	return c.Parser.NormalizeTitles == nil || *c.Parser.NormalizeTitles
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// dynamicKeys lists the settings that Reload applies without a restart.
+var dynamicKeys = map[string]bool{
+	"log_level":                true,
+	"cache.ttl":                true,
+	"client.blocked_uploaders": true,
+	"client.allowed_uploaders": true,
+}
+
+var (
+	reloadMu    sync.Mutex
+	reloadHooks []func(cfg *Config)
+	watching    atomic.Bool
+)
+
+// OnReload registers fn to be called with the updated configuration after every successful reload.
+func OnReload(fn func(cfg *Config)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, fn)
+}
+
+// Watch reloads the configuration whenever the config file changes.
+// It does nothing when the configuration was not loaded from a file.
+func Watch() {
+	ensureInitialized()
+	if viper.ConfigFileUsed() == "" {
+		logger.Info().Msg("No config file loaded, configuration hot-reload disabled")
+		return
+	}
+
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		logger.Info().Str("file", event.Name).Msg("Config file changed, reloading")
+		if err := Reload(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to reload configuration, keeping the current settings")
+		}
+	})
+	viper.WatchConfig()
+	watching.Store(true)
+	logger.Info().Str("file", viper.ConfigFileUsed()).Msg("Watching config file for changes")
+}
+
+// Watching reports whether Watch is applying config file changes.
+func Watching() bool {
+	return watching.Load()
+}
+
+// Reload re-reads the config file and applies the dynamic settings (log level, cache TTL and
+// uploader filter lists).
+// Changes to any other setting are logged as requiring a restart and are not applied.
+// An invalid configuration is rejected as a whole and the current settings are kept.
+func Reload() error {
+	ensureInitialized()
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	next, err := unmarshalConfig()
+	if err != nil {
+		return err
+	}
+	if errs := next.Validate(); len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	current := globalConfig.Load()
+	if changed := staticChanges("", reflect.ValueOf(*current), reflect.ValueOf(*next)); len(changed) > 0 {
+		logger.Warn().Strs("keys", changed).Msg("Configuration changes require a restart to take effect")
+	}
+
+	updated := *current
+	updated.LogLevel = next.LogLevel
+	updated.Cache.TTL = next.Cache.TTL
+	updated.Client.BlockedUploaders = next.Client.BlockedUploaders
+	updated.Client.AllowedUploaders = next.Client.AllowedUploaders
+
+	level := parseLogLevel(updated.LogLevel)
+	zerolog.SetGlobalLevel(level)
+	globalConfig.Store(&updated)
+
+	for _, hook := range reloadHooks {
+		hook(&updated)
+	}
+
+	logger.Info().
+		Str("log_level", level.String()).
+		Str("cache_ttl", updated.Cache.TTL).
+		Strs("client_blocked_uploaders", updated.Client.BlockedUploaders).
+		Strs("client_allowed_uploaders", updated.Client.AllowedUploaders).
+		Msg("Configuration reloaded")
+	return nil
+}
+
+// staticChanges returns the keys, outside dynamicKeys, whose values differ between current and next.
+func staticChanges(prefix string, current, next reflect.Value) []string {
+	var changed []string
+	for i := range current.NumField() {
+		key := current.Type().Field(i).Tag.Get("mapstructure")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if dynamicKeys[key] {
+			continue
+		}
+		if current.Field(i).Kind() == reflect.Struct {
+			changed = append(changed, staticChanges(key, current.Field(i), next.Field(i))...)
+			continue
+		}
+		if !reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func writeTestConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+}
+
+// TestReload_AppliesDynamicSettings initializes the package-wide configuration, so it must not run in parallel.
+func TestReload_AppliesDynamicSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, `
+super_subtitle_domain: "https://feliratok.eu"
+log_level: "info"
+log_format: "json"
+server:
+  port: 8080
+cache:
+  ttl: "24h"
+`)
+
+	var logs bytes.Buffer
+	if err := Init(InitOptions{ConfigFile: path, LogOutput: &logs}); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	t.Cleanup(func() { zerolog.SetGlobalLevel(zerolog.InfoLevel) })
+
+	var reloaded *Config
+	OnReload(func(cfg *Config) { reloaded = cfg })
+
+	writeTestConfig(t, path, `
+super_subtitle_domain: "https://feliratok.eu"
+log_level: "debug"
+log_format: "json"
+client:
+  blocked_uploaders: ["AutoSub"]
+server:
+  port: 9000
+cache:
+  ttl: "1h"
+`)
+	if err := Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+
+	if reloaded == nil || reloaded.Cache.TTL != "1h" {
+		t.Fatalf("Expected reload hook to receive cache TTL 1h, got %+v", reloaded)
+	}
+	if got := GetConfig().Cache.TTL; got != "1h" {
+		t.Errorf("Expected GetConfig to return cache TTL 1h, got %q", got)
+	}
+	if got := GetConfig().Client.BlockedUploaders; len(got) != 1 || got[0] != "AutoSub" {
+		t.Errorf("Expected blocked uploaders [AutoSub], got %v", got)
+	}
+	if got := zerolog.GlobalLevel(); got != zerolog.DebugLevel {
+		t.Errorf("Expected global log level debug, got %v", got)
+	}
+	if got := GetConfig().Server.Port; got != 8080 {
+		t.Errorf("Expected server port to keep 8080 until restart, got %d", got)
+	}
+	if !strings.Contains(logs.String(), "require a restart") || !strings.Contains(logs.String(), "server.port") {
+		t.Errorf("Expected restart warning naming server.port, got logs: %s", logs.String())
+	}
+	if strings.Contains(logs.String(), `"client.blocked_uploaders"`) {
+		t.Errorf("Expected blocked uploaders to apply without a restart warning, got logs: %s", logs.String())
+	}
+
+	// An invalid file is rejected as a whole
+	writeTestConfig(t, path, `
+super_subtitle_domain: "https://feliratok.eu"
+log_level: "info"
+server:
+  port: 8080
+cache:
+  ttl: "forever"
+`)
+	if err := Reload(); err == nil || !strings.Contains(err.Error(), "cache.ttl") {
+		t.Fatalf("Expected validation error naming cache.ttl, got: %v", err)
+	}
+	if got := GetConfig().Cache.TTL; got != "1h" {
+		t.Errorf("Expected rejected reload to keep cache TTL 1h, got %q", got)
+	}
+}
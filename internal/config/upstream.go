@@ -0,0 +1,11 @@
+package config
+
+// UpstreamDomains returns the base URLs of the upstream site in failover order: the
+// super_subtitle_domains list when set, otherwise super_subtitle_domain alone. The first
+// entry is the primary.
+func (c *Config) UpstreamDomains() []string {
+	if len(c.SuperSubtitleDomains) > 0 {
+		return c.SuperSubtitleDomains
+	}
+	return []string{c.SuperSubtitleDomain}
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// FieldError describes a configuration value that failed validation.
+type FieldError struct {
+	Field  string // Config key, e.g. "cache.ttl"
+	Value  string // Offending value as written in the config
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: invalid value %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// Validate checks the configuration and returns every problem found, or nil when it is valid.
+// Empty optional values are accepted; their defaults are applied where they are used.
+func (c *Config) Validate() []error {
+	var errs []error
+	add := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	var err error
+	if len(c.SuperSubtitleDomains) > 0 {
+		for _, domain := range c.SuperSubtitleDomains {
+			_, err = parseAbsoluteURL("super_subtitle_domains", domain)
+			add(err)
+		}
+	} else {
+		_, err = parseAbsoluteURL("super_subtitle_domain", c.SuperSubtitleDomain)
+		add(err)
+	}
+	if c.ProxyConnectionString != "" {
+		_, err = ParseProxyURL(c.ProxyConnectionString)
+		add(err)
+	}
+	for _, entry := range c.ProxyNoProxy {
+		if net.ParseIP(entry) == nil && (strings.TrimSpace(entry) == "" || strings.ContainsAny(entry, "/: ")) {
+			add(&FieldError{Field: "proxy_no_proxy", Value: entry, Reason: "must be a host name or domain suffix such as \"redis.internal\" or \".internal\""})
+		}
+	}
+
+	for _, d := range []struct{ field, value string }{
+		{"client_timeout", c.ClientTimeout},
+		{"client.update_check_ttl", c.Client.UpdateCheckTTL},
+		{"client.per_show_timeout", c.Client.PerShowTimeout},
+		{"client.mirror_cooldown", c.Client.MirrorCooldown},
+		{"client.hedge_delay", c.Client.HedgeDelay},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"server.rpc_timeout", c.Server.RPCTimeout},
+		{"server.stream_timeout", c.Server.StreamTimeout},
+		{"cache.ttl", c.Cache.TTL},
+		{"cache.image_ttl", c.Cache.ImageTTL},
+		{"cache.third_party_ttl", c.Cache.ThirdPartyTTL},
+		{"download.not_found_ttl", c.Download.NotFoundTTL},
+		{"retry.initial_delay", c.Retry.InitialDelay},
+		{"retry.max_delay", c.Retry.MaxDelay},
+		{"sentry.flush_timeout", c.Sentry.FlushTimeout},
+	} {
+		if d.value != "" {
+			_, err = ParseDuration(d.field, d.value)
+			add(err)
+		}
+	}
+
+	add(validatePort("server.port", c.Server.Port))
+	if c.Metrics.Enabled {
+		add(validatePort("metrics.port", c.Metrics.Port))
+	}
+	if c.Server.HTTPPort != 0 {
+		if err := validatePort("server.http_port", c.Server.HTTPPort); err != nil {
+			add(err)
+		} else if c.Server.HTTPPort == c.Server.Port || (c.Metrics.Enabled && c.Server.HTTPPort == c.Metrics.Port) {
+			add(&FieldError{Field: "server.http_port", Value: strconv.Itoa(c.Server.HTTPPort), Reason: "must differ from server.port and metrics.port"})
+		}
+	}
+	add(c.validateTLS())
+	for _, origin := range c.Server.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" {
+			add(&FieldError{Field: "server.allowed_origins", Value: origin, Reason: "must be \"*\" or an origin such as \"https://ui.example.com\""})
+		}
+	}
+	if c.Tracing.Enabled && strings.TrimSpace(c.Tracing.OTLPEndpoint) == "" {
+		add(&FieldError{Field: "tracing.otlp_endpoint", Value: c.Tracing.OTLPEndpoint, Reason: "required when tracing.enabled is true"})
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add(&FieldError{Field: "tracing.sample_ratio", Value: strconv.FormatFloat(c.Tracing.SampleRatio, 'g', -1, 64), Reason: "must be between 0 and 1"})
+	}
+
+	add(c.validateCache())
+	for _, showID := range c.Cache.PreloadShowIDs {
+		if showID <= 0 {
+			add(&FieldError{Field: "cache.preload_show_ids", Value: strconv.Itoa(showID), Reason: "must be a positive show ID"})
+		}
+	}
+	for _, err := range c.validateDownloadLimits() {
+		add(err)
+	}
+	for _, ext := range c.Download.ExtractionExtensionDenylist {
+		if trimmed := strings.TrimPrefix(ext, "."); trimmed == "" || strings.ContainsAny(trimmed, "./\\ ") {
+			add(&FieldError{Field: "download.extraction_extension_denylist", Value: ext, Reason: "must be a file extension such as \".exe\""})
+		}
+	}
+	if c.Download.DefaultCharset != "" {
+		if _, err := htmlindex.Get(c.Download.DefaultCharset); err != nil {
+			add(&FieldError{Field: "download.default_charset", Value: c.Download.DefaultCharset, Reason: "not a known encoding such as \"iso-8859-2\" or \"windows-1250\""})
+		}
+	}
+	if c.Download.FilenameTemplate != "" {
+		if _, err := models.ParseFilenameTemplate(c.Download.FilenameTemplate); err != nil {
+			add(&FieldError{Field: "download.filename_template", Value: c.Download.FilenameTemplate, Reason: "not a valid filename template: " + err.Error()})
+		}
+	}
+	return errs
+}
+
+// ParseDuration parses a non-negative Go duration for the given config key.
+// Runtime fallbacks use it so their warnings carry the same message as Validate.
+func ParseDuration(field, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, &FieldError{Field: field, Value: value, Reason: "not a Go duration such as \"30s\" or \"1h\""}
+	}
+	if d < 0 {
+		return 0, &FieldError{Field: field, Value: value, Reason: "duration must not be negative"}
+	}
+	return d, nil
+}
+
+// proxySchemes lists the proxy_connection_string schemes the HTTP client can dial through.
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// ParseProxyURL parses proxy_connection_string, which must include a host and one of proxySchemes.
+func ParseProxyURL(value string) (*url.URL, error) {
+	u, err := parseAbsoluteURL("proxy_connection_string", value)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(proxySchemes, u.Scheme) {
+		return nil, &FieldError{Field: "proxy_connection_string", Value: value, Reason: fmt.Sprintf("scheme must be one of %v", proxySchemes)}
+	}
+	return u, nil
+}
+
+func parseAbsoluteURL(field, value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, &FieldError{Field: field, Value: value, Reason: err.Error()}
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, &FieldError{Field: field, Value: value, Reason: "must be an absolute URL such as \"https://example.com\""}
+	}
+	return u, nil
+}
+
+func validatePort(field string, port int) error {
+	if port < 1 || port > 65535 {
+		return &FieldError{Field: field, Value: fmt.Sprint(port), Reason: "port must be between 1 and 65535"}
+	}
+	return nil
+}
+
+// validateTLS requires server.tls.cert_file and server.tls.key_file to be set together, and
+// both to be set when server.tls.client_ca_file is, since client certificates are only
+// checked over TLS.
+func (c *Config) validateTLS() error {
+	tls := c.Server.TLS
+	switch {
+	case tls.CertFile == "" && tls.KeyFile == "" && tls.ClientCAFile != "":
+		return &FieldError{Field: "server.tls.client_ca_file", Value: tls.ClientCAFile, Reason: "requires server.tls.cert_file and server.tls.key_file"}
+	case tls.CertFile == "" && tls.KeyFile != "":
+		return &FieldError{Field: "server.tls.cert_file", Value: tls.CertFile, Reason: "required when server.tls.key_file is set"}
+	case tls.CertFile != "" && tls.KeyFile == "":
+		return &FieldError{Field: "server.tls.key_file", Value: tls.KeyFile, Reason: "required when server.tls.cert_file is set"}
+	}
+	return nil
+}
+
+// validateDownloadLimits rejects negative download sizes and requires each per-file limit to fit
+// within the archive limit, and the archive limit within the download limit. Unset values are
+// compared using their defaults.
+func (c *Config) validateDownloadLimits() []error {
+	var errs []error
+	for _, s := range []struct {
+		field string
+		value int
+	}{
+		{"download.max_file_size_mb", c.Download.MaxFileSizeMB},
+		{"download.max_ass_file_size_mb", c.Download.MaxAssFileSizeMB},
+		{"download.max_archive_size_mb", c.Download.MaxArchiveSizeMB},
+		{"download.max_download_size_mb", c.Download.MaxDownloadSizeMB},
+	} {
+		if s.value < 0 {
+			errs = append(errs, &FieldError{Field: s.field, Value: fmt.Sprint(s.value), Reason: "size must not be negative"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	limits := c.DownloadLimits()
+	mb := func(size int64) string { return fmt.Sprint(size / (1024 * 1024)) }
+	if limits.MaxFileSize > limits.MaxArchiveSize {
+		errs = append(errs, &FieldError{Field: "download.max_file_size_mb", Value: mb(limits.MaxFileSize), Reason: "must not exceed download.max_archive_size_mb"})
+	}
+	if limits.MaxAssFileSize > limits.MaxArchiveSize {
+		errs = append(errs, &FieldError{Field: "download.max_ass_file_size_mb", Value: mb(limits.MaxAssFileSize), Reason: "must not exceed download.max_archive_size_mb"})
+	}
+	if limits.MaxArchiveSize > limits.MaxDownloadSize {
+		errs = append(errs, &FieldError{Field: "download.max_archive_size_mb", Value: mb(limits.MaxArchiveSize), Reason: "must not exceed download.max_download_size_mb"})
+	}
+	return errs
+}
+
+func (c *Config) validateCache() error {
+	if c.Cache.MaxBytes < 0 {
+		return &FieldError{Field: "cache.max_bytes", Value: strconv.FormatInt(c.Cache.MaxBytes, 10), Reason: "size must not be negative"}
+	}
+	cacheType := c.Cache.Type
+	if cacheType == "" {
+		return nil
+	}
+	if providers := cache.RegisteredProviders(); !slices.Contains(providers, cacheType) {
+		return &FieldError{Field: "cache.type", Value: cacheType, Reason: fmt.Sprintf("must be one of %v", providers)}
+	}
+	if cacheType == "redis" && c.Cache.Redis.Address == "" {
+		return &FieldError{Field: "cache.redis.address", Value: "", Reason: "required when cache.type is \"redis\""}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func validConfig() *Config {
+	cfg := &Config{
+		SuperSubtitleDomain:   "https://feliratok.eu",
+		ProxyConnectionString: "socks5h://[2001:db8::1]:1080",
+		ProxyNoProxy:          []string{".internal", "metrics.example.com", "2001:db8::2"},
+		ClientTimeout:         "30s",
+	}
+	cfg.Server.Port = 8080
+	cfg.Server.HTTPPort = 8081
+	cfg.Server.AllowedOrigins = []string{"https://ui.example.com", "http://localhost:3000/"}
+	cfg.Cache.Type = "memory"
+	cfg.Cache.TTL = "24h"
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Port = 9090
+	cfg.Retry.InitialDelay = "1s"
+	cfg.Retry.MaxDelay = "10s"
+	cfg.Sentry.FlushTimeout = "2s"
+	cfg.Download.DefaultCharset = "iso-8859-2"
+	cfg.Download.FilenameTemplate = "{{.ShowName}} - S{{printf \"%02d\" .Season}}E{{printf \"%02d\" .Episode}}{{.Extension}}"
+	return cfg
+}
+
+func TestConfig_Validate_Valid(t *testing.T) {
+	t.Parallel()
+	if errs := validConfig().Validate(); len(errs) != 0 {
+		t.Fatalf("Expected no errors, got: %v", errs)
+	}
+}
+
+func TestConfig_Validate_SingleProblem(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		mutate func(cfg *Config)
+		field  string
+	}{
+		{"missing domain", func(cfg *Config) { cfg.SuperSubtitleDomain = "" }, "super_subtitle_domain"},
+		{"relative domain", func(cfg *Config) { cfg.SuperSubtitleDomain = "feliratok.eu" }, "super_subtitle_domain"},
+		{"relative mirror domain", func(cfg *Config) {
+			cfg.SuperSubtitleDomains = []string{"https://feliratok.eu", "mirror.feliratok.eu"}
+		}, "super_subtitle_domains"},
+		{"proxy without scheme", func(cfg *Config) { cfg.ProxyConnectionString = "proxy.example.com:8080" }, "proxy_connection_string"},
+		{"unsupported proxy scheme", func(cfg *Config) { cfg.ProxyConnectionString = "ftp://proxy.example.com:21" }, "proxy_connection_string"},
+		{"no-proxy entry with port", func(cfg *Config) { cfg.ProxyNoProxy = []string{".internal", "redis:6379"} }, "proxy_no_proxy"},
+		{"bad client timeout", func(cfg *Config) { cfg.ClientTimeout = "30 seconds" }, "client_timeout"},
+		{"negative update check ttl", func(cfg *Config) { cfg.Client.UpdateCheckTTL = "-1m" }, "client.update_check_ttl"},
+		{"negative shutdown timeout", func(cfg *Config) { cfg.Server.ShutdownTimeout = "-5s" }, "server.shutdown_timeout"},
+		{"negative rpc timeout", func(cfg *Config) { cfg.Server.RPCTimeout = "-1m" }, "server.rpc_timeout"},
+		{"negative cache ttl", func(cfg *Config) { cfg.Cache.TTL = "-1h" }, "cache.ttl"},
+		{"bad third-party ttl", func(cfg *Config) { cfg.Cache.ThirdPartyTTL = "a week" }, "cache.third_party_ttl"},
+		{"negative not found ttl", func(cfg *Config) { cfg.Download.NotFoundTTL = "-1m" }, "download.not_found_ttl"},
+		{"bad mirror cooldown", func(cfg *Config) { cfg.Client.MirrorCooldown = "5 minutes" }, "client.mirror_cooldown"},
+		{"negative hedge delay", func(cfg *Config) { cfg.Client.HedgeDelay = "-1s" }, "client.hedge_delay"},
+		{"bad retry delay", func(cfg *Config) { cfg.Retry.InitialDelay = "soon" }, "retry.initial_delay"},
+		{"bad sentry flush timeout", func(cfg *Config) { cfg.Sentry.FlushTimeout = "2" }, "sentry.flush_timeout"},
+		{"server port out of range", func(cfg *Config) { cfg.Server.Port = 70000 }, "server.port"},
+		{"http port out of range", func(cfg *Config) { cfg.Server.HTTPPort = -1 }, "server.http_port"},
+		{"http port shared with metrics", func(cfg *Config) { cfg.Server.HTTPPort = cfg.Metrics.Port }, "server.http_port"},
+		{"metrics port unset", func(cfg *Config) { cfg.Metrics.Port = 0 }, "metrics.port"},
+		{"tls cert without key", func(cfg *Config) { cfg.Server.TLS.CertFile = "server.crt" }, "server.tls.key_file"},
+		{"tls key without cert", func(cfg *Config) { cfg.Server.TLS.KeyFile = "server.key" }, "server.tls.cert_file"},
+		{"client CA without key pair", func(cfg *Config) { cfg.Server.TLS.ClientCAFile = "clients.pem" }, "server.tls.client_ca_file"},
+		{"allowed origin with a path", func(cfg *Config) { cfg.Server.AllowedOrigins = []string{"*", "https://ui.example.com/app"} }, "server.allowed_origins"},
+		{"allowed origin without scheme", func(cfg *Config) { cfg.Server.AllowedOrigins = []string{"ui.example.com"} }, "server.allowed_origins"},
+		{"tracing without endpoint", func(cfg *Config) { cfg.Tracing.Enabled = true }, "tracing.otlp_endpoint"},
+		{"sample ratio above one", func(cfg *Config) { cfg.Tracing.SampleRatio = 1.5 }, "tracing.sample_ratio"},
+		{"unknown cache type", func(cfg *Config) { cfg.Cache.Type = "memcached" }, "cache.type"},
+		{"redis without address", func(cfg *Config) { cfg.Cache.Type = "redis" }, "cache.redis.address"},
+		{"negative cache max bytes", func(cfg *Config) { cfg.Cache.MaxBytes = -1 }, "cache.max_bytes"},
+		{"non-positive preload show ID", func(cfg *Config) { cfg.Cache.PreloadShowIDs = []int{1234, 0} }, "cache.preload_show_ids"},
+		{"negative download size", func(cfg *Config) { cfg.Download.MaxDownloadSizeMB = -1 }, "download.max_download_size_mb"},
+		{"file limit above archive limit", func(cfg *Config) { cfg.Download.MaxFileSizeMB = 120 }, "download.max_file_size_mb"},
+		{"archive limit above download limit", func(cfg *Config) { cfg.Download.MaxArchiveSizeMB = 200 }, "download.max_archive_size_mb"},
+		{"denylist entry with a path", func(cfg *Config) { cfg.Download.ExtractionExtensionDenylist = []string{".exe", "x/.html"} }, "download.extraction_extension_denylist"},
+		{"empty denylist entry", func(cfg *Config) { cfg.Download.ExtractionExtensionDenylist = []string{"."} }, "download.extraction_extension_denylist"},
+		{"unparsable filename template", func(cfg *Config) { cfg.Download.FilenameTemplate = "{{.ShowName" }, "download.filename_template"},
+		{"filename template with unknown field", func(cfg *Config) { cfg.Download.FilenameTemplate = "{{.Title}}.srt" }, "download.filename_template"},
+		{"unknown default charset", func(cfg *Config) { cfg.Download.DefaultCharset = "klingon-8" }, "download.default_charset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			errs := cfg.Validate()
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %d: %v", len(errs), errs)
+			}
+			var fieldErr *FieldError
+			if !errors.As(errs[0], &fieldErr) {
+				t.Fatalf("Expected *FieldError, got %T", errs[0])
+			}
+			if fieldErr.Field != tt.field {
+				t.Errorf("Expected field %q, got %q", tt.field, fieldErr.Field)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_AggregatesProblems(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	cfg.SuperSubtitleDomain = ""
+	cfg.ClientTimeout = "abc"
+	cfg.Server.Port = 0
+	cfg.Cache.Type = "redis"
+
+	errs := cfg.Validate()
+	if len(errs) != 4 {
+		t.Fatalf("Expected 4 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestConfig_Validate_OptionalValuesMayBeEmpty(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{SuperSubtitleDomain: "https://feliratok.eu"}
+	cfg.Server.Port = 8080
+
+	if errs := cfg.Validate(); len(errs) != 0 {
+		t.Fatalf("Expected no errors, got: %v", errs)
+	}
+}
+
+func TestParseDuration_ErrorNamesField(t *testing.T) {
+	t.Parallel()
+	_, err := ParseDuration("cache.ttl", "1 day")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "cache.ttl") || !strings.Contains(err.Error(), `"1 day"`) {
+		t.Errorf("Expected error to name field and value, got: %v", err)
+	}
+}
+
+func TestConfig_DownloadLimits(t *testing.T) {
+	t.Parallel()
+	const mb = 1024 * 1024
+
+	defaults := (*Config)(nil).DownloadLimits()
+	if defaults.MaxFileSize != 20*mb || defaults.MaxAssFileSize != 100*mb ||
+		defaults.MaxArchiveSize != 100*mb || defaults.MaxDownloadSize != 150*mb {
+		t.Errorf("Expected default limits, got %+v", defaults)
+	}
+
+	cfg := validConfig()
+	cfg.Download.MaxFileSizeMB = 300
+	cfg.Download.MaxArchiveSizeMB = 400
+	cfg.Download.MaxDownloadSizeMB = 500
+	if errs := cfg.Validate(); len(errs) != 0 {
+		t.Fatalf("Expected raised limits to be valid, got: %v", errs)
+	}
+	limits := cfg.DownloadLimits()
+	if limits.MaxFileSize != 300*mb || limits.MaxAssFileSize != 100*mb ||
+		limits.MaxArchiveSize != 400*mb || limits.MaxDownloadSize != 500*mb {
+		t.Errorf("Expected configured limits with the .ass default, got %+v", limits)
+	}
+}
+
+func TestConfig_UpstreamDomains(t *testing.T) {
+	t.Parallel()
+	cfg := validConfig()
+	if got := cfg.UpstreamDomains(); len(got) != 1 || got[0] != "https://feliratok.eu" {
+		t.Errorf("Expected super_subtitle_domain alone, got %v", got)
+	}
+
+	cfg.SuperSubtitleDomains = []string{"https://mirror-a.example", "https://mirror-b.example"}
+	if errs := cfg.Validate(); len(errs) != 0 {
+		t.Fatalf("Expected mirror list to be valid, got: %v", errs)
+	}
+	got := cfg.UpstreamDomains()
+	if len(got) != 2 || got[0] != "https://mirror-a.example" || got[1] != "https://mirror-b.example" {
+		t.Errorf("Expected mirror list in order, got %v", got)
+	}
+}
+
+func TestConfig_Features(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{SuperSubtitleDomain: "https://feliratok.eu"}
+	if got := cfg.Features(); !slices.Equal(got, []string{FeatureTitleNormalization, FeatureDownloadResume}) {
+		t.Errorf("Expected only the features on by default, got %v", got)
+	}
+
+	disabled := false
+	cfg.Auth.Tokens = []string{"secret"}
+	cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile, cfg.Server.TLS.ClientCAFile = "server.crt", "server.key", "ca.crt"
+	cfg.Server.HTTPPort = 8081
+	cfg.Cache.Type = "redis"
+	cfg.Metrics.Enabled = true
+	cfg.Tracing.Enabled = true
+	cfg.Sentry.DSN = "https://key@sentry.example/1"
+	cfg.SuperSubtitleDomains = []string{"https://mirror-a.example", "https://mirror-b.example"}
+	cfg.ProxyConnectionString = "http://proxy.example:3128"
+	cfg.Parser.NormalizeTitles = &disabled
+	cfg.Download.MaxResumeAttempts = -1
+	want := []string{
+		FeatureAuth, FeatureTLS, FeatureMTLS, FeatureWebSocketBridge, FeatureRedisCache, FeatureMetrics,
+		FeatureTracing, FeatureSentry, FeatureMirrorFailover, FeatureUpstreamProxy,
+	}
+	if got := cfg.Features(); !slices.Equal(got, want) {
+		t.Errorf("Expected toggled features %v, got %v", want, got)
+	}
+
+	// A client CA alone does not enable TLS
+	cfg.Server.TLS.CertFile = ""
+	if got := cfg.Features(); slices.Contains(got, FeatureTLS) || slices.Contains(got, FeatureMTLS) {
+		t.Errorf("Expected no TLS features without a certificate, got %v", got)
+	}
+}
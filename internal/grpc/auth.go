@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationMetadataKey is the metadata key carrying the bearer token.
+	authorizationMetadataKey = "authorization"
+	bearerPrefix             = "bearer "
+)
+
+// healthMethodPrefix matches every RPC of the standard health service, which
+// must stay reachable for probes that cannot present credentials.
+var healthMethodPrefix = "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+
+// tokenAuthenticator validates static bearer tokens sent in the "authorization"
+// metadata of incoming RPCs.
+type tokenAuthenticator struct {
+	tokens [][]byte
+}
+
+// newTokenAuthenticator creates an authenticator for the given tokens.
+// Empty tokens are ignored. Returns nil when no usable token is configured.
+func newTokenAuthenticator(tokens []string) *tokenAuthenticator {
+	valid := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		valid = append(valid, []byte(token))
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	return &tokenAuthenticator{tokens: valid}
+}
+
+// authenticate checks the bearer token in the incoming context metadata.
+// Health-check RPCs are always allowed.
+func (a *tokenAuthenticator) authenticate(ctx context.Context, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	header := strings.TrimSpace(values[0])
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "authorization metadata must use the Bearer scheme")
+	}
+
+	if !a.isValid([]byte(strings.TrimSpace(header[len(bearerPrefix):]))) {
+		return status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+
+	return nil
+}
+
+// isValid compares the presented token against every configured token in constant time.
+func (a *tokenAuthenticator) isValid(presented []byte) bool {
+	matched := 0
+	for _, token := range a.tokens {
+		matched |= subtle.ConstantTimeCompare(presented, token)
+	}
+	return matched == 1
+}
+
+// UnaryServerInterceptor rejects unary RPCs that do not carry a valid bearer token.
+func (a *tokenAuthenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authenticate(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming RPCs that do not carry a valid bearer token.
+func (a *tokenAuthenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
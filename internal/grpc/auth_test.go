@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// startAuthServer starts an in-process gRPC server that requires the given tokens
+// and returns a connected client connection.
+func startAuthServer(t *testing.T, tokens ...string) *grpc.ClientConn {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Auth.Tokens = tokens
+	opts, err := ServerOptionsFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("ServerOptionsFromConfig returned error: %v", err)
+	}
+
+	mock := &mockClient{
+		checkForUpdatesFunc: func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
+			return &models.UpdateCheckResult{FilmCount: 1, HasUpdates: true}, nil
+		},
+		getShowListFunc: func(ctx context.Context) ([]models.Show, error) {
+			return []models.Show{{Name: "Test Show", ID: 1}}, nil
+		},
+	}
+	srv := NewGRPCServer(mock, opts...)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.GracefulStop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestTokenAuth_UnaryRPC(t *testing.T) {
+	t.Parallel()
+	conn := startAuthServer(t, "secret-one", "secret-two")
+	client := pb.NewSuperSubtitlesServiceClient(conn)
+
+	tests := []struct {
+		name     string
+		metadata []string
+		wantCode codes.Code
+	}{
+		{name: "valid token", metadata: []string{"authorization", "Bearer secret-two"}, wantCode: codes.OK},
+		{name: "lowercase scheme", metadata: []string{"authorization", "bearer secret-one"}, wantCode: codes.OK},
+		{name: "missing token", metadata: nil, wantCode: codes.Unauthenticated},
+		{name: "wrong token", metadata: []string{"authorization", "Bearer nope"}, wantCode: codes.Unauthenticated},
+		{name: "wrong scheme", metadata: []string{"authorization", "Basic secret-one"}, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.AppendToOutgoingContext(ctx, tt.metadata...)
+			}
+
+			resp, err := client.CheckForUpdates(ctx, &pb.CheckForUpdatesRequest{ContentId: 1})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("Expected code %v, got %v (err: %v)", tt.wantCode, got, err)
+			}
+			if tt.wantCode == codes.OK && !resp.HasUpdates {
+				t.Error("Expected HasUpdates to be true")
+			}
+		})
+	}
+}
+
+func TestTokenAuth_StreamingRPC(t *testing.T) {
+	t.Parallel()
+	conn := startAuthServer(t, "secret")
+	client := pb.NewSuperSubtitlesServiceClient(conn)
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode codes.Code
+	}{
+		{name: "valid token", token: "secret", wantCode: codes.OK},
+		{name: "missing token", token: "", wantCode: codes.Unauthenticated},
+		{name: "wrong token", token: "other", wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tt.token)
+			}
+
+			stream, err := client.GetShowList(ctx, &pb.GetShowListRequest{})
+			if err != nil {
+				t.Fatalf("Failed to open stream: %v", err)
+			}
+			_, err = stream.Recv()
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("Expected code %v, got %v (err: %v)", tt.wantCode, got, err)
+			}
+		})
+	}
+}
+
+func TestTokenAuth_HealthCheckExempt(t *testing.T) {
+	t.Parallel()
+	conn := startAuthServer(t, "secret")
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Health check without token failed: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING status, got %v", resp.Status)
+	}
+}
+
+func TestNewTokenAuthenticator_NoTokens(t *testing.T) {
+	t.Parallel()
+	if auth := newTokenAuthenticator([]string{"", "  "}); auth != nil {
+		t.Error("Expected nil authenticator when no usable tokens are configured")
+	}
+}
+
+func TestServerOptionsFromConfig_ClientCAWithoutKeyPair(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{}
+	cfg.Server.TLS.ClientCAFile = "clients.pem"
+
+	// Serving plaintext here would drop the client authentication the operator asked for
+	if _, err := ServerOptionsFromConfig(cfg); err == nil {
+		t.Fatal("Expected error when client_ca_file is set without cert_file and key_file")
+	}
+}
+
+func TestServerOptionsFromConfig_PartialTLS(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{}
+	cfg.Server.TLS.CertFile = "server.crt"
+
+	if _, err := ServerOptionsFromConfig(cfg); err == nil {
+		t.Fatal("Expected error when key_file is missing")
+	}
+}
@@ -1,7 +1,9 @@
 package grpc
 
 import (
+	"cmp"
 	"math"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -9,6 +11,7 @@ import (
 
 	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/services"
 )
 
 // sanitizeUTF8 ensures a string contains only valid UTF-8 sequences.
@@ -66,6 +69,8 @@ func convertShowToProto(show models.Show) *pb.Show {
 		Id:       safeInt64(show.ID),
 		Year:     safeInt32(show.Year),
 		ImageUrl: sanitizeUTF8(show.ImageURL),
+		Aliases:  sanitizeUTF8Slice(show.Aliases),
+		Sources:  convertShowSourcesToProto(show.Sources),
 	}
 }
 
@@ -79,9 +84,49 @@ func convertShowFromProto(pbShow *pb.Show) models.Show {
 		ID:       int(pbShow.Id),
 		Year:     int(pbShow.Year),
 		ImageURL: pbShow.ImageUrl,
+		Aliases:  pbShow.Aliases,
+		Sources:  convertShowSourcesFromProto(pbShow.Sources),
 	}
 }
 
+// convertShowSourcesToProto converts models.ShowSource values to proto ShowSource enums
+func convertShowSourcesToProto(sources []models.ShowSource) []pb.ShowSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]pb.ShowSource, len(sources))
+	for i, source := range sources {
+		switch source {
+		case models.ShowSourceWaiting:
+			result[i] = pb.ShowSource_SHOW_SOURCE_WAITING
+		case models.ShowSourceUnderTranslation:
+			result[i] = pb.ShowSource_SHOW_SOURCE_UNDER_TRANSLATION
+		case models.ShowSourceNotUnderTranslation:
+			result[i] = pb.ShowSource_SHOW_SOURCE_NOT_UNDER_TRANSLATION
+		default:
+			result[i] = pb.ShowSource_SHOW_SOURCE_UNSPECIFIED
+		}
+	}
+	return result
+}
+
+// convertShowSourcesFromProto converts proto ShowSource enums to models.ShowSource values,
+// dropping unspecified ones
+func convertShowSourcesFromProto(sources []pb.ShowSource) []models.ShowSource {
+	var result []models.ShowSource
+	for _, source := range sources {
+		switch source {
+		case pb.ShowSource_SHOW_SOURCE_WAITING:
+			result = append(result, models.ShowSourceWaiting)
+		case pb.ShowSource_SHOW_SOURCE_UNDER_TRANSLATION:
+			result = append(result, models.ShowSourceUnderTranslation)
+		case pb.ShowSource_SHOW_SOURCE_NOT_UNDER_TRANSLATION:
+			result = append(result, models.ShowSourceNotUnderTranslation)
+		}
+	}
+	return result
+}
+
 // convertThirdPartyIdsToProto converts models.ThirdPartyIds to proto ThirdPartyIds message
 func convertThirdPartyIdsToProto(ids models.ThirdPartyIds) *pb.ThirdPartyIds {
 	return &pb.ThirdPartyIds{
@@ -110,6 +155,152 @@ func convertQualityToProto(quality models.Quality) pb.Quality {
 	}
 }
 
+// convertQualityFromProto converts a proto Quality enum to a models.Quality
+func convertQualityFromProto(quality pb.Quality) models.Quality {
+	switch quality {
+	case pb.Quality_QUALITY_360P:
+		return models.Quality360p
+	case pb.Quality_QUALITY_480P:
+		return models.Quality480p
+	case pb.Quality_QUALITY_720P:
+		return models.Quality720p
+	case pb.Quality_QUALITY_1080P:
+		return models.Quality1080p
+	case pb.Quality_QUALITY_2160P:
+		return models.Quality2160p
+	default:
+		return models.QualityUnknown
+	}
+}
+
+// convertSubtitleOrderFromProto converts a proto SubtitleOrder enum to a models.SubtitleOrder,
+// defaulting to newest upload first
+func convertSubtitleOrderFromProto(order pb.SubtitleOrder) models.SubtitleOrder {
+	switch order {
+	case pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_ASC:
+		return models.SubtitleOrderUploadTimeAsc
+	case pb.SubtitleOrder_SUBTITLE_ORDER_SEASON_EPISODE:
+		return models.SubtitleOrderSeasonEpisode
+	default:
+		return models.SubtitleOrderUploadTimeDesc
+	}
+}
+
+// convertLanguageStatsToProto converts per-language statistics to a proto ShowLanguageStats message
+func convertLanguageStatsToProto(showID int64, stats []services.LanguageStats) *pb.ShowLanguageStats {
+	languages := make([]*pb.LanguageStats, len(stats))
+	for i, s := range stats {
+		seasons := make([]int32, len(s.Seasons))
+		for j, season := range s.Seasons {
+			seasons[j] = safeInt32(season)
+		}
+
+		var newestUploadedAt *timestamppb.Timestamp
+		if !s.NewestUploadedAt.IsZero() {
+			newestUploadedAt = timestamppb.New(s.NewestUploadedAt)
+		}
+
+		languages[i] = &pb.LanguageStats{
+			Language:         sanitizeUTF8(s.Language),
+			SubtitleCount:    safeInt32(s.SubtitleCount),
+			SeasonPackCount:  safeInt32(s.SeasonPackCount),
+			NewestUploadedAt: newestUploadedAt,
+			Seasons:          seasons,
+		}
+	}
+	return &pb.ShowLanguageStats{ShowId: showID, Languages: languages}
+}
+
+// convertShowSeasonsToProto converts a show's season summary to a proto ShowSeasons message
+func convertShowSeasonsToProto(showID int64, showSeasons *models.ShowSeasons) *pb.ShowSeasons {
+	seasons := make([]*pb.SeasonSummary, len(showSeasons.Seasons))
+	for i, s := range showSeasons.Seasons {
+		var latestUpload *timestamppb.Timestamp
+		if !s.LatestUpload.IsZero() {
+			latestUpload = timestamppb.New(s.LatestUpload)
+		}
+
+		seasons[i] = &pb.SeasonSummary{
+			Season:              safeInt32(s.Season),
+			EpisodeCount:        safeInt32(s.EpisodeCount),
+			SeasonPackAvailable: s.SeasonPackAvailable,
+			LatestUpload:        latestUpload,
+		}
+	}
+	return &pb.ShowSeasons{ShowId: showID, Seasons: seasons, UnknownCount: safeInt32(showSeasons.UnknownCount)}
+}
+
+// convertLanguagesToProto converts the language table to a proto GetLanguagesResponse message
+func convertLanguagesToProto(languages []models.Language) *pb.GetLanguagesResponse {
+	pbLanguages := make([]*pb.Language, len(languages))
+	for i, language := range languages {
+		pbLanguages[i] = &pb.Language{
+			IsoCode:       language.ISOCode,
+			HungarianName: language.HungarianName,
+			EnglishName:   language.EnglishName,
+		}
+	}
+	return &pb.GetLanguagesResponse{Languages: pbLanguages}
+}
+
+// convertUpstreamStatusToProto converts models.UpstreamStatus to a proto GetStatusResponse message
+func convertUpstreamStatusToProto(status models.UpstreamStatus) *pb.GetStatusResponse {
+	var activeSince *timestamppb.Timestamp
+	if !status.ActiveSince.IsZero() {
+		activeSince = timestamppb.New(status.ActiveSince)
+	}
+	var blockedSince *timestamppb.Timestamp
+	if !status.BlockedSince.IsZero() {
+		blockedSince = timestamppb.New(status.BlockedSince)
+	}
+	return &pb.GetStatusResponse{
+		ActiveMirror:  status.ActiveMirror,
+		Mirrors:       status.Mirrors,
+		ActiveSince:   activeSince,
+		Blocked:       status.Blocked,
+		BlockedReason: status.BlockedReason,
+		BlockedSince:  blockedSince,
+	}
+}
+
+// convertServerInfoToProto converts models.ServerInfo to a proto ServerInfo
+func convertServerInfoToProto(info models.ServerInfo) *pb.ServerInfo {
+	return &pb.ServerInfo{
+		Version:    info.Version,
+		Commit:     info.Commit,
+		BuildDate:  info.BuildDate,
+		ApiVersion: info.APIVersion,
+		Features:   info.Features,
+		Limits: &pb.ServerLimits{
+			MaxDownloadBytes: info.Limits.MaxDownloadBytes,
+			MaxFileBytes:     info.Limits.MaxFileBytes,
+			MaxArchiveBytes:  info.Limits.MaxArchiveBytes,
+			MaxMessageBytes:  info.Limits.MaxMessageBytes,
+		},
+	}
+}
+
+// convertSelfCheckToProto converts models.SelfCheckResult to a proto SelfCheckResponse
+func convertSelfCheckToProto(result models.SelfCheckResult) *pb.SelfCheckResponse {
+	return &pb.SelfCheckResponse{
+		Ok:            result.OK,
+		Details:       result.Details,
+		ShowId:        safeInt64(result.ShowID),
+		SubtitleCount: int32(result.SubtitleCount),
+	}
+}
+
+// convertSubtitleDetailsToProto converts models.SubtitleDetails to a proto SubtitleDetails message
+func convertSubtitleDetailsToProto(details *models.SubtitleDetails) *pb.SubtitleDetails {
+	return &pb.SubtitleDetails{
+		SubtitleId:    safeInt64(details.SubtitleID),
+		Filename:      sanitizeUTF8(details.Filename),
+		Uploader:      sanitizeUTF8(details.Uploader),
+		Comment:       sanitizeUTF8(details.Comment),
+		ThirdPartyIds: convertThirdPartyIdsToProto(details.ThirdPartyIds),
+	}
+}
+
 // convertSubtitleToProto converts a models.Subtitle to a proto Subtitle message
 func convertSubtitleToProto(subtitle models.Subtitle) *pb.Subtitle {
 	qualities := make([]pb.Quality, len(subtitle.Qualities))
@@ -123,28 +314,72 @@ func convertSubtitleToProto(subtitle models.Subtitle) *pb.Subtitle {
 	if !subtitle.UploadedAt.IsZero() {
 		uploadedAt = timestamppb.New(subtitle.UploadedAt)
 	}
+	var airDate *timestamppb.Timestamp
+	if !subtitle.AirDate.IsZero() {
+		airDate = timestamppb.New(subtitle.AirDate)
+	}
 
 	return &pb.Subtitle{
-		Id:            safeInt64(subtitle.ID),
-		ShowId:        safeInt64(subtitle.ShowID),
-		ShowName:      sanitizeUTF8(subtitle.ShowName),
-		Name:          sanitizeUTF8(subtitle.Name),
-		Language:      sanitizeUTF8(subtitle.Language),
-		Season:        safeInt32(subtitle.Season),
-		Episode:       safeInt32(subtitle.Episode),
-		Filename:      sanitizeUTF8(subtitle.Filename),
-		DownloadUrl:   sanitizeUTF8(subtitle.DownloadURL),
-		Uploader:      sanitizeUTF8(subtitle.Uploader),
-		UploadedAt:    uploadedAt,
-		Qualities:     qualities,
-		ReleaseGroups: sanitizeUTF8Slice(subtitle.ReleaseGroups),
-		Release:       sanitizeUTF8(subtitle.Release),
-		IsSeasonPack:  subtitle.IsSeasonPack,
-		RangeStart:    safeOptionalInt32(subtitle.RangeStart),
-		RangeEnd:      safeOptionalInt32(subtitle.RangeEnd),
+		Id:                safeInt64(subtitle.ID),
+		IdIsSynthetic:     subtitle.IDIsSynthetic,
+		ShowId:            safeInt64(subtitle.ShowID),
+		ShowName:          sanitizeUTF8(subtitle.ShowName),
+		Name:              sanitizeUTF8(subtitle.Name),
+		RawName:           sanitizeUTF8(subtitle.RawName),
+		Language:          sanitizeUTF8(subtitle.Language),
+		Season:            safeInt32(subtitle.Season),
+		Episode:           safeInt32(subtitle.Episode),
+		Filename:          sanitizeUTF8(subtitle.Filename),
+		DownloadUrl:       sanitizeUTF8(subtitle.DownloadURL),
+		Uploader:          sanitizeUTF8(subtitle.Uploader),
+		UploadedAt:        uploadedAt,
+		Qualities:         qualities,
+		ReleaseGroups:     sanitizeUTF8Slice(subtitle.ReleaseGroups),
+		ReleaseVariants:   convertReleaseVariantsToProto(subtitle.ReleaseVariants),
+		Release:           sanitizeUTF8(subtitle.Release),
+		IsSeasonPack:      subtitle.IsSeasonPack,
+		RangeStart:        safeOptionalInt32(subtitle.RangeStart),
+		RangeEnd:          safeOptionalInt32(subtitle.RangeEnd),
+		SeasonEnd:         safeOptionalInt32(subtitle.SeasonEnd),
+		UploaderId:        sanitizeUTF8(subtitle.UploaderID),
+		UploaderVerified:  subtitle.UploaderVerified,
+		IsHearingImpaired: subtitle.IsHearingImpaired,
+		ContentType:       convertContentTypeToProto(subtitle.ContentType),
+		MovieTitle:        sanitizeUTF8(subtitle.MovieTitle),
+		MovieYear:         safeInt32(subtitle.MovieYear),
+		AirDate:           airDate,
 	}
 }
 
+// convertContentTypeToProto converts a models.ContentType to a proto ContentType enum
+func convertContentTypeToProto(contentType models.ContentType) pb.ContentType {
+	switch contentType {
+	case models.ContentTypeSeries:
+		return pb.ContentType_CONTENT_TYPE_SERIES
+	case models.ContentTypeFilm:
+		return pb.ContentType_CONTENT_TYPE_FILM
+	default:
+		return pb.ContentType_CONTENT_TYPE_UNSPECIFIED
+	}
+}
+
+// convertReleaseVariantsToProto converts models.ReleaseVariant values to proto ReleaseVariant messages
+func convertReleaseVariantsToProto(variants []models.ReleaseVariant) []*pb.ReleaseVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]*pb.ReleaseVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = &pb.ReleaseVariant{
+			Source:  sanitizeUTF8(variant.Source),
+			RipType: sanitizeUTF8(variant.RipType),
+			Quality: convertQualityToProto(variant.Quality),
+			Group:   sanitizeUTF8(variant.Group),
+		}
+	}
+	return result
+}
+
 // convertShowSubtitlesToProto converts a models.ShowSubtitles to a proto ShowSubtitlesCollection
 func convertShowSubtitlesToProto(ss models.ShowSubtitles) *pb.ShowSubtitlesCollection {
 	subtitles := make([]*pb.Subtitle, len(ss.SubtitleCollection.Subtitles))
@@ -156,7 +391,33 @@ func convertShowSubtitlesToProto(ss models.ShowSubtitles) *pb.ShowSubtitlesColle
 		ShowInfo: &pb.ShowInfo{
 			Show:          convertShowToProto(ss.Show),
 			ThirdPartyIds: convertThirdPartyIdsToProto(ss.ThirdPartyIds),
+			Status:        ss.Show.Status,
 		},
 		Subtitles: subtitles,
 	}
 }
+
+// sortByPreferredLanguages stably reorders subtitles so that those in preferred languages come
+// first, in the order given; the rest keep their position after them. Language codes are
+// compared case-insensitively. Stability keeps the upload-time order within each language group.
+func sortByPreferredLanguages(subtitles []*pb.Subtitle, preferred []string) {
+	if len(preferred) == 0 {
+		return
+	}
+	rank := make(map[string]int, len(preferred))
+	for i, lang := range preferred {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if _, exists := rank[lang]; !exists {
+			rank[lang] = i
+		}
+	}
+	rankOf := func(subtitle *pb.Subtitle) int {
+		if r, ok := rank[strings.ToLower(subtitle.GetLanguage())]; ok {
+			return r
+		}
+		return len(preferred)
+	}
+	slices.SortStableFunc(subtitles, func(a, b *pb.Subtitle) int {
+		return cmp.Compare(rankOf(a), rankOf(b))
+	})
+}
@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
 )
 
 // TestQualityConversion tests quality enum conversion
@@ -40,6 +43,8 @@ func TestConvertShowToProto(t *testing.T) {
 		ID:       42,
 		Year:     2008,
 		ImageURL: "http://example.com/image.jpg",
+		Aliases:  []string{"Breaking Bad", "Totál szívás"},
+		Sources:  []models.ShowSource{models.ShowSourceWaiting, models.ShowSourceUnderTranslation},
 	}
 
 	result := convertShowToProto(show)
@@ -56,6 +61,12 @@ func TestConvertShowToProto(t *testing.T) {
 	if result.ImageUrl != "http://example.com/image.jpg" {
 		t.Errorf("Expected image URL 'http://example.com/image.jpg', got '%s'", result.ImageUrl)
 	}
+	if len(result.Aliases) != 2 || result.Aliases[0] != "Breaking Bad" || result.Aliases[1] != "Totál szívás" {
+		t.Errorf("Expected aliases [Breaking Bad Totál szívás], got %v", result.Aliases)
+	}
+	if len(result.Sources) != 2 || result.Sources[0] != pb.ShowSource_SHOW_SOURCE_WAITING || result.Sources[1] != pb.ShowSource_SHOW_SOURCE_UNDER_TRANSLATION {
+		t.Errorf("Expected sources [WAITING UNDER_TRANSLATION], got %v", result.Sources)
+	}
 }
 
 // TestConvertShowFromProto_NilShow tests nil handling in show conversion
@@ -75,6 +86,8 @@ func TestConvertShowFromProto(t *testing.T) {
 		Id:       123,
 		Year:     2011,
 		ImageUrl: "http://example.com/got.jpg",
+		Aliases:  []string{"Game of Thrones", "Trónok harca"},
+		Sources:  []pb.ShowSource{pb.ShowSource_SHOW_SOURCE_NOT_UNDER_TRANSLATION},
 	}
 
 	result := convertShowFromProto(pbShow)
@@ -91,6 +104,12 @@ func TestConvertShowFromProto(t *testing.T) {
 	if result.ImageURL != "http://example.com/got.jpg" {
 		t.Errorf("Expected image URL 'http://example.com/got.jpg', got '%s'", result.ImageURL)
 	}
+	if len(result.Aliases) != 2 || result.Aliases[1] != "Trónok harca" {
+		t.Errorf("Expected aliases [Game of Thrones Trónok harca], got %v", result.Aliases)
+	}
+	if len(result.Sources) != 1 || result.Sources[0] != models.ShowSourceNotUnderTranslation {
+		t.Errorf("Expected sources [not_under_translation], got %v", result.Sources)
+	}
 }
 
 // TestConvertThirdPartyIdsToProto tests ThirdPartyIds conversion
@@ -119,26 +138,91 @@ func TestConvertThirdPartyIdsToProto(t *testing.T) {
 	}
 }
 
+// TestConvertSubtitleToProto_RelativeUploadDate tests that a relative Hungarian upload date
+// resolved by the parser is carried through to the proto timestamp
+func TestConvertSubtitleToProto_RelativeUploadDate(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	subtitleParser := parser.NewSubtitleParserWithClock("https://feliratok.eu", func() time.Time { return now })
+
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{
+			ShowID:           2967,
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "Outlander - Az idegen - 7x16",
+			EredetiTitle:     "Outlander - 7x16 - A Hundred Thousand Angels (WEB.1080p-SuccessfulCrab)",
+			Uploader:         "kissoreg",
+			UploadDate:       "tegnap",
+			DownloadAction:   "letolt",
+			DownloadFilename: "outlander.s07e16.srt",
+			SubtitleID:       1737439811,
+		},
+		{
+			ShowID:           2967,
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "Outlander - Az idegen - 7x15",
+			EredetiTitle:     "Outlander - 7x15 - Written in My Own Heart's Blood (WEB.1080p-SuccessfulCrab)",
+			Uploader:         "kissoreg",
+			UploadDate:       "3 órája",
+			DownloadAction:   "letolt",
+			DownloadFilename: "outlander.s07e15.srt",
+			SubtitleID:       1737439812,
+		},
+	})
+
+	subtitles, err := subtitleParser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(subtitles))
+	}
+
+	expected := []time.Time{
+		time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC),
+	}
+	for i, want := range expected {
+		result := convertSubtitleToProto(subtitles[i])
+		if result.UploadedAt == nil {
+			t.Errorf("Subtitle %d: expected non-nil UploadedAt", i)
+		} else if !result.UploadedAt.AsTime().Equal(want) {
+			t.Errorf("Subtitle %d: expected upload time %v, got %v", i, want, result.UploadedAt.AsTime())
+		}
+	}
+}
+
 // TestConvertSubtitleToProto tests subtitle conversion with valid timestamp
 func TestConvertSubtitleToProto(t *testing.T) {
 	t.Parallel()
 	uploadTime := time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC)
 	subtitle := models.Subtitle{
-		ID:            101,
-		ShowID:        1,
-		ShowName:      "Breaking Bad",
-		Name:          "S01E01",
-		Language:      "hun",
-		Season:        1,
-		Episode:       1,
-		Filename:      "breaking.bad.s01e01.srt",
-		DownloadURL:   "http://example.com/download/101",
-		Uploader:      "testuser",
-		UploadedAt:    uploadTime,
-		Qualities:     []models.Quality{models.Quality720p, models.Quality1080p},
-		ReleaseGroups: []string{"DIMENSION", "LOL"},
-		Release:       "720p/1080p",
-		IsSeasonPack:  false,
+		ID:                101,
+		ShowID:            1,
+		ShowName:          "Breaking Bad",
+		Name:              "S01E01",
+		RawName:           "S01E01.DIMENSION.English.orig.Addic7ed.com",
+		Language:          "hun",
+		Season:            1,
+		Episode:           1,
+		Filename:          "breaking.bad.s01e01.srt",
+		DownloadURL:       "http://example.com/download/101",
+		Uploader:          "testuser",
+		UploaderID:        "testuser",
+		UploaderVerified:  true,
+		IsHearingImpaired: true,
+		UploadedAt:        uploadTime,
+		Qualities:         []models.Quality{models.Quality720p, models.Quality1080p},
+		ReleaseGroups:     []string{"DIMENSION", "LOL"},
+		ReleaseVariants: []models.ReleaseVariant{
+			{RipType: "HDTV", Quality: models.Quality720p, Group: "DIMENSION"},
+			{Source: "AMZN", RipType: "WEB-DL", Quality: models.Quality1080p, Group: "LOL"},
+		},
+		Release:      "HDTV.720p-DIMENSION, AMZN.WEB-DL.1080p-LOL",
+		IsSeasonPack: false,
+		AirDate:      time.Date(2008, 1, 20, 0, 0, 0, 0, time.UTC),
 	}
 
 	result := convertSubtitleToProto(subtitle)
@@ -158,11 +242,29 @@ func TestConvertSubtitleToProto(t *testing.T) {
 	if result.Episode != 1 {
 		t.Errorf("Expected episode 1, got %d", result.Episode)
 	}
+	if result.UploaderId != "testuser" {
+		t.Errorf("Expected uploader ID 'testuser', got '%s'", result.UploaderId)
+	}
+	if !result.UploaderVerified {
+		t.Error("Expected UploaderVerified to be true")
+	}
+	if !result.IsHearingImpaired {
+		t.Error("Expected IsHearingImpaired to be true")
+	}
+	if result.IdIsSynthetic {
+		t.Error("Expected IdIsSynthetic to be false")
+	}
 	if result.UploadedAt == nil {
 		t.Error("Expected non-nil UploadedAt")
 	} else if !result.UploadedAt.AsTime().Equal(uploadTime) {
 		t.Errorf("Expected upload time %v, got %v", uploadTime, result.UploadedAt.AsTime())
 	}
+	if result.Name != subtitle.Name || result.RawName != subtitle.RawName {
+		t.Errorf("Expected name %q and raw name %q, got %q and %q", subtitle.Name, subtitle.RawName, result.Name, result.RawName)
+	}
+	if result.AirDate == nil || !result.AirDate.AsTime().Equal(subtitle.AirDate) {
+		t.Errorf("Expected air date %v, got %v", subtitle.AirDate, result.AirDate)
+	}
 	if len(result.Qualities) != 2 {
 		t.Errorf("Expected 2 qualities, got %d", len(result.Qualities))
 	}
@@ -175,6 +277,12 @@ func TestConvertSubtitleToProto(t *testing.T) {
 	if len(result.ReleaseGroups) != 2 {
 		t.Errorf("Expected 2 release groups, got %d", len(result.ReleaseGroups))
 	}
+	if len(result.ReleaseVariants) != 2 {
+		t.Fatalf("Expected 2 release variants, got %d", len(result.ReleaseVariants))
+	}
+	if v := result.ReleaseVariants[1]; v.Source != "AMZN" || v.RipType != "WEB-DL" || v.Quality != pb.Quality_QUALITY_1080P || v.Group != "LOL" {
+		t.Errorf("Expected AMZN WEB-DL 1080p LOL as second variant, got %v", v)
+	}
 	if result.IsSeasonPack {
 		t.Error("Expected IsSeasonPack to be false")
 	}
@@ -194,6 +302,9 @@ func TestConvertSubtitleToProto_ZeroTimestamp(t *testing.T) {
 	if result.UploadedAt != nil {
 		t.Error("Expected nil UploadedAt for zero time, got non-nil")
 	}
+	if result.AirDate != nil {
+		t.Error("Expected nil AirDate for zero time, got non-nil")
+	}
 }
 
 func TestConvertSubtitleToProto_RangeFields(t *testing.T) {
@@ -220,6 +331,19 @@ func TestConvertSubtitleToProto_RangeFields(t *testing.T) {
 	}
 }
 
+func TestConvertSubtitleToProto_SeasonEnd(t *testing.T) {
+	t.Parallel()
+	seasonEnd := 3
+	result := convertSubtitleToProto(models.Subtitle{ID: 103, Season: 1, Episode: -1, IsSeasonPack: true, SeasonEnd: &seasonEnd})
+	if result.SeasonEnd == nil || *result.SeasonEnd != 3 {
+		t.Errorf("Expected season_end=3, got %v", result.SeasonEnd)
+	}
+
+	if result := convertSubtitleToProto(models.Subtitle{ID: 104, Season: 2, Episode: -1}); result.SeasonEnd != nil {
+		t.Errorf("Expected season_end to stay unset for a single season, got %v", *result.SeasonEnd)
+	}
+}
+
 func TestConvertShowSubtitlesToProto(t *testing.T) {
 	t.Parallel()
 	uploadTime := time.Date(2024, 2, 5, 8, 15, 0, 0, time.UTC)
@@ -229,6 +353,7 @@ func TestConvertShowSubtitlesToProto(t *testing.T) {
 			ID:       204,
 			Year:     2015,
 			ImageURL: "http://example.com/expanse.jpg",
+			Status:   models.ShowStatusEnded,
 		},
 		ThirdPartyIds: models.ThirdPartyIds{
 			IMDBID:   "tt3230854",
@@ -286,6 +411,9 @@ func TestConvertShowSubtitlesToProto(t *testing.T) {
 	if result.ShowInfo.Show.Year != 2015 {
 		t.Errorf("Expected show year 2015, got %d", result.ShowInfo.Show.Year)
 	}
+	if result.ShowInfo.Status != models.ShowStatusEnded {
+		t.Errorf("Expected status %q, got '%s'", models.ShowStatusEnded, result.ShowInfo.Status)
+	}
 	if result.ShowInfo.ThirdPartyIds == nil {
 		t.Fatal("Expected ThirdPartyIds to be set")
 	}
@@ -472,6 +600,7 @@ func TestConvertSubtitleToProto_InvalidUTF8(t *testing.T) {
 		Filename:      "file\xfc.srt",
 		DownloadURL:   "http://example.com/\xfb",
 		Uploader:      "user\xfa123",
+		UploaderID:    "user\xf9id",
 		ReleaseGroups: []string{"DIM\xffENSION", "L\xfeOL"},
 		Release:       "720p\xff",
 	}
@@ -496,6 +625,9 @@ func TestConvertSubtitleToProto_InvalidUTF8(t *testing.T) {
 	if result.Uploader != "user�123" {
 		t.Errorf("Expected sanitized Uploader 'user�123', got '%s'", result.Uploader)
 	}
+	if result.UploaderId != "user�id" {
+		t.Errorf("Expected sanitized UploaderId 'user�id', got '%s'", result.UploaderId)
+	}
 	if result.Release != "720p�" {
 		t.Errorf("Expected sanitized Release '720p�', got '%s'", result.Release)
 	}
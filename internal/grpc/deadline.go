@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultRPCTimeout bounds unary RPCs whose caller did not set a deadline.
+	defaultRPCTimeout = 2 * time.Minute
+	// defaultStreamTimeout bounds streaming RPCs, which may page through a whole show or the recent uploads.
+	defaultStreamTimeout = 30 * time.Minute
+)
+
+// rpcDeadlines applies a server-side deadline to RPCs that arrive without one, so a
+// client that never sets a deadline cannot keep a handler waiting on a slow upstream.
+// A deadline set by the client is always kept as is. A zero timeout disables the default.
+type rpcDeadlines struct {
+	unary  time.Duration
+	stream time.Duration
+}
+
+// newRPCDeadlines reads server.rpc_timeout and server.stream_timeout, using the defaults when empty.
+func newRPCDeadlines(cfg *config.Config) (*rpcDeadlines, error) {
+	unary, err := timeoutOrDefault("server.rpc_timeout", cfg.Server.RPCTimeout, defaultRPCTimeout)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := timeoutOrDefault("server.stream_timeout", cfg.Server.StreamTimeout, defaultStreamTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcDeadlines{unary: unary, stream: stream}, nil
+}
+
+func timeoutOrDefault(field, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return config.ParseDuration(field, value)
+}
+
+// withDeadline returns ctx bounded by timeout unless the timeout is disabled or the
+// method is exempt. Health checks are exempt so Watch streams stay open.
+func withDeadline(ctx context.Context, fullMethod string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 || strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// deadlineError reports a handler failure caused by the server-side deadline as
+// DEADLINE_EXCEEDED; handlers would otherwise map the context error to INTERNAL.
+func deadlineError(ctx context.Context, fullMethod string, timeout time.Duration, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, fmt.Sprintf("%s did not finish within %s", fullMethod, timeout))
+	}
+	return err
+}
+
+// UnaryServerInterceptor applies the unary timeout to RPCs without a deadline.
+func (d *rpcDeadlines) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return handler(ctx, req)
+		}
+		ctx, cancel := withDeadline(ctx, info.FullMethod, d.unary)
+		defer cancel()
+		resp, err := handler(ctx, req)
+		return resp, deadlineError(ctx, info.FullMethod, d.unary, err)
+	}
+}
+
+// StreamServerInterceptor applies the stream timeout to RPCs without a deadline.
+func (d *rpcDeadlines) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := ss.Context().Deadline(); ok {
+			return handler(srv, ss)
+		}
+		ctx, cancel := withDeadline(ss.Context(), info.FullMethod, d.stream)
+		defer cancel()
+		err := handler(srv, &deadlineServerStream{ServerStream: ss, ctx: ctx})
+		return deadlineError(ctx, info.FullMethod, d.stream, err)
+	}
+}
+
+// deadlineServerStream overrides the stream context with the bounded one.
+type deadlineServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the bounded context.
+func (s *deadlineServerStream) Context() context.Context {
+	return s.ctx
+}
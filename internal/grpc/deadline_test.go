@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// startDeadlineServer starts an in-process gRPC server with the given RPC timeouts whose
+// handlers block until their context is done, and returns a client for it.
+func startDeadlineServer(t *testing.T, rpcTimeout, streamTimeout string) pb.SuperSubtitlesServiceClient {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Server.RPCTimeout = rpcTimeout
+	cfg.Server.StreamTimeout = streamTimeout
+	opts, err := ServerOptionsFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("ServerOptionsFromConfig returned error: %v", err)
+	}
+
+	mock := &mockClient{
+		getLatestSubtitleFunc: func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle], 1)
+			go func() {
+				defer close(ch)
+				<-ctx.Done()
+				ch <- models.StreamResult[models.Subtitle]{Err: ctx.Err()}
+			}()
+			return ch
+		},
+	}
+	srv := NewGRPCServer(mock, opts...)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewSuperSubtitlesServiceClient(conn)
+}
+
+func TestRPCDeadlines_UnaryWithoutDeadline(t *testing.T) {
+	t.Parallel()
+	client := startDeadlineServer(t, "50ms", "1h")
+
+	start := time.Now()
+	_, err := client.GetLatestSubtitleId(context.Background(), &pb.GetLatestSubtitleIdRequest{})
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v (err: %v)", got, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the RPC to be cancelled after the configured timeout, took %v", elapsed)
+	}
+}
+
+func TestRPCDeadlines_StreamWithoutDeadline(t *testing.T) {
+	t.Parallel()
+	client := startDeadlineServer(t, "1h", "50ms")
+
+	stream, err := client.GetSubtitles(context.Background(), &pb.GetSubtitlesRequest{ShowId: 1})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	_, err = stream.Recv()
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v (err: %v)", got, err)
+	}
+}
+
+func TestRPCDeadlines_ClientDeadlineKept(t *testing.T) {
+	t.Parallel()
+	client := startDeadlineServer(t, "1h", "1h")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetLatestSubtitleId(ctx, &pb.GetLatestSubtitleIdRequest{})
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Fatalf("Expected the shorter client deadline to apply, got %v (err: %v)", got, err)
+	}
+}
+
+func TestServerOptionsFromConfig_InvalidRPCTimeout(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{}
+	cfg.Server.RPCTimeout = "soon"
+
+	if _, err := ServerOptionsFromConfig(cfg); err == nil {
+		t.Fatal("Expected error for an invalid server.rpc_timeout")
+	}
+}
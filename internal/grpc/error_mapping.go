@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"errors"
+	"maps"
 	"net/http"
 	"strconv"
 
@@ -18,13 +19,20 @@ func toStatusError(fallbackMessage string, err error) error {
 
 	var bindable apperrors.GRPCBindableError
 	if errors.As(err, &bindable) {
-		return statusForBindableError(bindable.GRPCCode(), err.Error(), bindable.HTTPStatusCode())
+		var metadata map[string]string
+		var metadataErr apperrors.MetadataError
+		if errors.As(err, &metadataErr) {
+			metadata = metadataErr.Metadata()
+		}
+		return statusForBindableError(bindable.GRPCCode(), err.Error(), bindable.HTTPStatusCode(), metadata)
 	}
 
 	return status.Errorf(codes.Internal, "%s: %v", fallbackMessage, err)
 }
 
-func statusForBindableError(code codes.Code, message string, httpStatus int) error {
+// statusForBindableError builds a status carrying an ErrorInfo with the HTTP status and any
+// extra metadata of the error, such as the limit_bytes of an oversized download.
+func statusForBindableError(code codes.Code, message string, httpStatus int, metadata map[string]string) error {
 	st := status.New(code, message)
 	if httpStatus <= 0 {
 		return st.Err()
@@ -35,11 +43,14 @@ func statusForBindableError(code codes.Code, message string, httpStatus int) err
 		reason = "UNPROCESSABLE_ENTITY"
 	}
 
+	infoMetadata := map[string]string{
+		"http_status": strconv.Itoa(httpStatus),
+	}
+	maps.Copy(infoMetadata, metadata)
+
 	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
-		Reason: reason,
-		Metadata: map[string]string{
-			"http_status": strconv.Itoa(httpStatus),
-		},
+		Reason:   reason,
+		Metadata: infoMetadata,
 	})
 	if err != nil {
 		return status.Errorf(code, "%s (http_status=%d)", message, httpStatus)
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+)
+
+// Trailing metadata keys that tell clients a stream ended cleanly but skipped some data.
+const (
+	partialErrorsKey      = "x-partial-errors"
+	partialErrorDetailKey = "x-partial-error-detail"
+
+	maxPartialErrorDetails   = 5   // Only the first errors are detailed; the count covers all of them
+	maxPartialErrorDetailLen = 200 // Bytes kept from each error message
+)
+
+// partialErrors collects the non-fatal errors of a streaming RPC that keeps sending results
+// after a failure, so the client can learn that the stream may be incomplete.
+type partialErrors struct {
+	method  string
+	count   int
+	details []string
+}
+
+func newPartialErrors(method string) *partialErrors {
+	return &partialErrors{method: method}
+}
+
+// add records a skipped error.
+func (p *partialErrors) add(err error) {
+	p.count++
+	if len(p.details) < maxPartialErrorDetails {
+		p.details = append(p.details, partialErrorDetail(err.Error()))
+	}
+}
+
+// setTrailer attaches the error summary to the stream's trailing metadata and counts the
+// errors in metrics. It does nothing when no error was recorded.
+func (p *partialErrors) setTrailer(stream grpc.ServerStream) {
+	if p.count == 0 {
+		return
+	}
+	md := metadata.Pairs(partialErrorsKey, strconv.Itoa(p.count))
+	md.Append(partialErrorDetailKey, p.details...)
+	stream.SetTrailer(md)
+	metrics.GRPCStreamPartialErrorsTotal.WithLabelValues(p.method).Add(float64(p.count))
+}
+
+// partialErrorDetail makes an error message safe for an ASCII metadata value: non-printable
+// and non-ASCII characters (e.g. accented show names) become '?', and the result is truncated.
+func partialErrorDetail(msg string) string {
+	detail := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, msg)
+	if len(detail) > maxPartialErrorDetailLen {
+		detail = detail[:maxPartialErrorDetailLen-3] + "..."
+	}
+	return detail
+}
+
+// isShowTimeout reports whether err is a single show running past client.per_show_timeout.
+// Such errors never fail the stream, even before the first result, since the other shows are still coming.
+func isShowTimeout(err error) bool {
+	return errors.Is(err, &apperrors.ErrShowTimeout{})
+}
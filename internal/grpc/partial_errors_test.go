@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPartialErrorDetail(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"ascii kept", "page 2 failed: status 502", "page 2 failed: status 502"},
+		{"non-ascii replaced", "show Ügyvéd failed", "show ?gyv?d failed"},
+		{"control characters replaced", "line1\nline2", "line1?line2"},
+		{"long message truncated", strings.Repeat("x", 300), strings.Repeat("x", maxPartialErrorDetailLen-3) + "..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := partialErrorDetail(tt.msg); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPartialErrors_LimitsDetails(t *testing.T) {
+	t.Parallel()
+	partial := newPartialErrors("GetShowList")
+	for i := range maxPartialErrorDetails + 3 {
+		partial.add(fmt.Errorf("page %d failed", i+2))
+	}
+
+	stream := newMockServerStream[struct{}]()
+	partial.setTrailer(stream)
+
+	details := make([]string, maxPartialErrorDetails)
+	for i := range details {
+		details[i] = fmt.Sprintf("page %d failed", i+2)
+	}
+	assertPartialErrorTrailer(t, stream.trailer, fmt.Sprint(maxPartialErrorDetails+3), details...)
+}
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+)
+
+// requestMetricsUnaryInterceptor counts every unary RPC in metrics.GRPCRequestsTotal and
+// observes its duration in metrics.GRPCRequestDurationSeconds.
+func requestMetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		startedAt := time.Now()
+		resp, err := handler(ctx, req)
+		recordRequest(info.FullMethod, startedAt, err)
+		return resp, err
+	}
+}
+
+// requestMetricsStreamInterceptor is requestMetricsUnaryInterceptor for streaming RPCs, which
+// are recorded once the handler returns and the stream ends.
+func requestMetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startedAt := time.Now()
+		err := handler(srv, ss)
+		recordRequest(info.FullMethod, startedAt, err)
+		return err
+	}
+}
+
+// recordRequest records an RPC that started at startedAt and returned err. The method label is
+// the method name without its service, as in grpc_stream_partial_errors_total. The code is the
+// one the client receives: errors without a gRPC status map like grpc-go maps them, so a
+// cancelled context is Canceled rather than Unknown.
+func recordRequest(fullMethod string, startedAt time.Time, err error) {
+	method := path.Base(fullMethod)
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	metrics.GRPCRequestsTotal.WithLabelValues(method, st.Code().String()).Inc()
+	metrics.GRPCRequestDurationSeconds.WithLabelValues(method).Observe(time.Since(startedAt).Seconds())
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// durationSamples returns how many durations were observed for method.
+func durationSamples(t *testing.T, method string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.GRPCRequestDurationSeconds.WithLabelValues(method).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+// newNotFoundDownloadConn serves a mock client whose downloads fail with an upstream 404 and
+// returns a client connection to it.
+func newNotFoundDownloadConn(t *testing.T) pb.SuperSubtitlesServiceClient {
+	t.Helper()
+	srv := NewGRPCServer(&mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, fmt.Errorf("failed to download subtitle: %w", &apperrors.ErrSubtitleResourceNotFound{URL: "http://example.com/download/101"})
+		},
+	})
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return pb.NewSuperSubtitlesServiceClient(conn)
+}
+
+// Not parallel: reads the global gRPC request metrics
+func TestRequestMetrics_UnaryNotFound(t *testing.T) {
+	client := newNotFoundDownloadConn(t)
+	counter := metrics.GRPCRequestsTotal.WithLabelValues("DownloadSubtitle", codes.NotFound.String())
+	before, samples := promtestutil.ToFloat64(counter), durationSamples(t, "DownloadSubtitle")
+
+	_, err := client.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected codes.NotFound, got %v", err)
+	}
+
+	if got := promtestutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("Expected the NotFound counter of DownloadSubtitle to increment by 1, got %v", got)
+	}
+	if got := durationSamples(t, "DownloadSubtitle") - samples; got != 1 {
+		t.Errorf("Expected one duration to be observed, got %d", got)
+	}
+}
+
+// Not parallel: reads the global gRPC request metrics
+func TestRequestMetrics_StreamNotFound(t *testing.T) {
+	client := newNotFoundDownloadConn(t)
+	counter := metrics.GRPCRequestsTotal.WithLabelValues("DownloadSubtitleStream", codes.NotFound.String())
+	before, samples := promtestutil.ToFloat64(counter), durationSamples(t, "DownloadSubtitleStream")
+
+	stream, err := client.DownloadSubtitleStream(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101"})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	// The stream's final status reaches the client only after the handler, and so the
+	// interceptor, returned
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected the stream to end with codes.NotFound, got %v", err)
+	}
+
+	if got := promtestutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("Expected the NotFound counter of DownloadSubtitleStream to increment by 1, got %v", got)
+	}
+	if got := durationSamples(t, "DownloadSubtitleStream") - samples; got != 1 {
+		t.Errorf("Expected one duration to be observed when the stream ended, got %d", got)
+	}
+}
+
+func TestRecordRequest_Codes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "success", err: nil, want: codes.OK},
+		{name: "status error", err: status.Error(codes.InvalidArgument, "bad request"), want: codes.InvalidArgument},
+		{name: "cancelled context", err: context.Canceled, want: codes.Canceled},
+		{name: "plain error", err: fmt.Errorf("boom"), want: codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// A method per case keeps the parallel cases from sharing a series
+			method := "/supersubtitles.v1.SuperSubtitlesService/RecordRequestTest" + tt.name
+			recordRequest(method, time.Now(), tt.err)
+			got := promtestutil.ToFloat64(metrics.GRPCRequestsTotal.WithLabelValues("RecordRequestTest"+tt.name, tt.want.String()))
+			if got != 1 {
+				t.Errorf("Expected one request counted with code %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
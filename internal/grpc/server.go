@@ -1,8 +1,16 @@
 package grpc
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/archive"
@@ -10,11 +18,14 @@ import (
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/sentryio"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/services"
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // server implements the SuperSubtitlesServiceServer interface
@@ -32,39 +43,169 @@ func NewServer(c client.Client) pb.SuperSubtitlesServiceServer {
 	}
 }
 
-// GetShowList streams all available TV shows
+// nextPageTokenKey is the trailing metadata key carrying the GetShowList cursor for the next page.
+const nextPageTokenKey = "x-next-page-token"
+
+// GetShowList streams all available TV shows. StreamShowList merges each show's sources across
+// the endpoints, so no show is sent until every endpoint's last page has loaded. Without
+// page_token and page_size shows are sent in the order they were first listed. With either set,
+// they are sent ordered by ID so the stream can be resumed: when page_size cuts the list short,
+// the ID of the last show sent is returned as the cursor in the x-next-page-token trailer.
 func (s *server) GetShowList(req *pb.GetShowListRequest, stream grpc.ServerStreamingServer[pb.Show]) error {
-	s.logger.Debug().Msg("GetShowList called")
+	s.logger.Debug().Str("page_token", req.PageToken).Int32("page_size", req.PageSize).Msg("GetShowList called")
+
+	if req.PageSize < 0 {
+		return status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	afterID, err := parseShowListPageToken(req.PageToken)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	paged := req.PageToken != "" || req.PageSize > 0
 
 	count := 0
-	for result := range s.client.StreamShowList(stream.Context()) {
+	var buffered []models.Show
+	partial := newPartialErrors("GetShowList")
+	for result := range s.client.StreamShowList(stream.Context(), afterID) {
 		if result.Err != nil {
 			if count == 0 {
-				// No shows sent yet — return an error
+				// No shows received yet — return an error
 				reportGRPCError("GetShowList", result.Err, nil)
 				s.logger.Error().Err(result.Err).Msg("Failed to get show list")
 				return status.Errorf(codes.Internal, "failed to get show list: %v", result.Err)
 			}
-			// Some shows already sent — log and continue
+			// Some shows already received — log and continue, reporting the gap in the trailer
 			s.logger.Warn().Err(result.Err).Msg("Error while streaming shows")
+			partial.add(result.Err)
+			continue
+		}
+		count++
+		if paged {
+			buffered = append(buffered, result.Value)
 			continue
 		}
 		if err := stream.Send(convertShowToProto(result.Value)); err != nil {
 			return status.Errorf(codes.Internal, "failed to stream show: %v", err)
 		}
-		count++
 	}
 
-	s.logger.Debug().Int("count", count).Msg("GetShowList completed")
+	if paged {
+		slices.SortFunc(buffered, func(a, b models.Show) int { return cmp.Compare(a.ID, b.ID) })
+		if req.PageSize > 0 && len(buffered) > int(req.PageSize) {
+			buffered = buffered[:req.PageSize]
+			stream.SetTrailer(metadata.Pairs(nextPageTokenKey, strconv.Itoa(buffered[len(buffered)-1].ID)))
+		}
+		for _, show := range buffered {
+			if err := stream.Send(convertShowToProto(show)); err != nil {
+				return status.Errorf(codes.Internal, "failed to stream show: %v", err)
+			}
+		}
+		count = len(buffered)
+	}
+
+	partial.setTrailer(stream)
+	s.logger.Debug().Int("count", count).Int("partial_errors", partial.count).Msg("GetShowList completed")
 	return nil
 }
 
+// parseShowListPageToken returns the show ID a GetShowList cursor resumes after, or 0 for no cursor.
+func parseShowListPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	afterID, err := strconv.Atoi(token)
+	if err != nil || afterID <= 0 {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	return afterID, nil
+}
+
+const (
+	// defaultListShowsPageSize is the ListShows page size when the request leaves it at zero.
+	defaultListShowsPageSize = 100
+	// maxListShowsPageSize caps the ListShows page size.
+	maxListShowsPageSize = 1000
+	// listShowsPageTokenPrefix is prepended to the offset before it is encoded as a ListShows token.
+	listShowsPageTokenPrefix = "offset:"
+)
+
+// ListShows implements SuperSubtitlesServiceServer.ListShows. The full show list is collected,
+// ordered by year, then name, then ID, and the page starting at the token's offset is returned.
+// Shows added or removed between calls shift the later pages.
+func (s *server) ListShows(ctx context.Context, req *pb.ListShowsRequest) (*pb.ListShowsResponse, error) {
+	s.logger.Debug().Str("page_token", req.PageToken).Int32("page_size", req.PageSize).Msg("ListShows called")
+
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	offset, err := parseListShowsPageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultListShowsPageSize
+	}
+	pageSize = min(pageSize, maxListShowsPageSize)
+
+	var shows []models.Show
+	for result := range s.client.StreamShowList(ctx, 0) {
+		if result.Err != nil {
+			reportGRPCError("ListShows", result.Err, nil)
+			s.logger.Error().Err(result.Err).Msg("Failed to get show list")
+			return nil, status.Errorf(codes.Internal, "failed to get show list: %v", result.Err)
+		}
+		shows = append(shows, result.Value)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	slices.SortFunc(shows, func(a, b models.Show) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+
+	resp := &pb.ListShowsResponse{TotalSize: int32(len(shows))}
+	end := min(offset+pageSize, len(shows))
+	for _, show := range shows[min(offset, len(shows)):end] {
+		resp.Shows = append(resp.Shows, convertShowToProto(show))
+	}
+	if end < len(shows) {
+		resp.NextPageToken = encodeListShowsPageToken(end)
+	}
+
+	s.logger.Debug().Int("offset", offset).Int("count", len(resp.Shows)).Int("total", len(shows)).Msg("ListShows completed")
+	return resp, nil
+}
+
+// encodeListShowsPageToken returns the opaque ListShows token for the page starting at offset.
+func encodeListShowsPageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(listShowsPageTokenPrefix + strconv.Itoa(offset)))
+}
+
+// parseListShowsPageToken returns the offset a ListShows token starts at, or 0 for no token.
+func parseListShowsPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	value, found := strings.CutPrefix(string(decoded), listShowsPageTokenPrefix)
+	offset, err := strconv.Atoi(value)
+	if !found || err != nil || offset <= 0 {
+		return 0, fmt.Errorf("invalid page_token %q", token)
+	}
+	return offset, nil
+}
+
 // GetSubtitles streams all subtitles for a specific show
 func (s *server) GetSubtitles(req *pb.GetSubtitlesRequest, stream grpc.ServerStreamingServer[pb.Subtitle]) error {
-	s.logger.Debug().Int64("show_id", req.ShowId).Msg("GetSubtitles called")
+	s.logger.Debug().Int64("show_id", req.ShowId).Str("order_by", req.OrderBy.String()).Msg("GetSubtitles called")
 
 	count := 0
-	for result := range s.client.StreamSubtitles(stream.Context(), int(req.ShowId)) {
+	for result := range s.client.StreamSubtitlesInOrder(stream.Context(), int(req.ShowId), convertSubtitleOrderFromProto(req.OrderBy)) {
 		if result.Err != nil {
 			reportGRPCError("GetSubtitles", result.Err, map[string]any{"show_id": req.ShowId})
 			s.logger.Error().Err(result.Err).Int64("show_id", req.ShowId).Msg("Failed to get subtitles")
@@ -80,9 +221,34 @@ func (s *server) GetSubtitles(req *pb.GetSubtitlesRequest, stream grpc.ServerStr
 	return nil
 }
 
+// GetMovieSubtitles streams all subtitles for a specific film
+func (s *server) GetMovieSubtitles(req *pb.GetMovieSubtitlesRequest, stream grpc.ServerStreamingServer[pb.Subtitle]) error {
+	s.logger.Debug().Int64("movie_id", req.MovieId).Msg("GetMovieSubtitles called")
+
+	if req.MovieId <= 0 {
+		return status.Error(codes.InvalidArgument, "movie_id must be positive")
+	}
+
+	count := 0
+	for result := range s.client.StreamMovieSubtitles(stream.Context(), int(req.MovieId)) {
+		if result.Err != nil {
+			reportGRPCError("GetMovieSubtitles", result.Err, map[string]any{"movie_id": req.MovieId})
+			s.logger.Error().Err(result.Err).Int64("movie_id", req.MovieId).Msg("Failed to get movie subtitles")
+			return toStatusError("failed to get movie subtitles", result.Err)
+		}
+		if err := stream.Send(convertSubtitleToProto(result.Value)); err != nil {
+			return status.Errorf(codes.Internal, "failed to stream subtitle: %v", err)
+		}
+		count++
+	}
+
+	s.logger.Debug().Int64("movie_id", req.MovieId).Int("count", count).Msg("GetMovieSubtitles completed")
+	return nil
+}
+
 // GetShowSubtitles streams complete show subtitle collections for multiple shows
 func (s *server) GetShowSubtitles(req *pb.GetShowSubtitlesRequest, stream grpc.ServerStreamingServer[pb.ShowSubtitlesCollection]) error {
-	s.logger.Debug().Int("show_count", len(req.Shows)).Msg("GetShowSubtitles called")
+	s.logger.Debug().Int("show_count", len(req.Shows)).Strs("languages", req.Languages).Msg("GetShowSubtitles called")
 
 	// Filter out nil entries and convert proto shows to models
 	shows := make([]models.Show, 0, len(req.Shows))
@@ -99,32 +265,36 @@ func (s *server) GetShowSubtitles(req *pb.GetShowSubtitlesRequest, stream grpc.S
 	}
 
 	count := 0
-	for result := range s.client.StreamShowSubtitles(stream.Context(), shows) {
+	partial := newPartialErrors("GetShowSubtitles")
+	for result := range s.client.StreamShowSubtitles(stream.Context(), shows, req.Languages) {
 		if result.Err != nil {
-			if count == 0 {
+			if count == 0 && !isShowTimeout(result.Err) {
 				reportGRPCError("GetShowSubtitles", result.Err, map[string]any{"show_count": len(req.Shows)})
 				s.logger.Error().Err(result.Err).Int("show_count", len(req.Shows)).Msg("Failed to get show subtitles")
 				return status.Errorf(codes.Internal, "failed to get show subtitles: %v", result.Err)
 			}
 			s.logger.Warn().Err(result.Err).Msg("Error while streaming show subtitles")
+			partial.add(result.Err)
 			continue
 		}
 		pbItem := convertShowSubtitlesToProto(result.Value)
+		sortByPreferredLanguages(pbItem.Subtitles, req.PreferredLanguages)
 		if err := stream.Send(pbItem); err != nil {
 			return status.Errorf(codes.Internal, "failed to stream show subtitles collection: %v", err)
 		}
 		count++
 	}
 
-	s.logger.Debug().Int("count", count).Msg("GetShowSubtitles completed")
+	partial.setTrailer(stream)
+	s.logger.Debug().Int("count", count).Int("partial_errors", partial.count).Msg("GetShowSubtitles completed")
 	return nil
 }
 
 // CheckForUpdates implements SuperSubtitlesServiceServer.CheckForUpdates
 func (s *server) CheckForUpdates(ctx context.Context, req *pb.CheckForUpdatesRequest) (*pb.CheckForUpdatesResponse, error) {
-	s.logger.Debug().Int64("content_id", req.ContentId).Msg("CheckForUpdates called")
+	s.logger.Debug().Int64("content_id", req.ContentId).Bool("force_refresh", req.ForceRefresh).Msg("CheckForUpdates called")
 
-	result, err := s.client.CheckForUpdates(ctx, req.ContentId)
+	result, err := s.client.CheckForUpdates(ctx, req.ContentId, req.ForceRefresh)
 	if err != nil {
 		reportGRPCError("CheckForUpdates", err, map[string]any{"content_id": req.ContentId})
 		s.logger.Error().Err(err).Int64("content_id", req.ContentId).Msg("Failed to check for updates")
@@ -138,30 +308,312 @@ func (s *server) CheckForUpdates(ctx context.Context, req *pb.CheckForUpdatesReq
 		Bool("has_updates", result.HasUpdates).
 		Msg("CheckForUpdates completed")
 
+	var checkedAt *timestamppb.Timestamp
+	if !result.CheckedAt.IsZero() {
+		checkedAt = timestamppb.New(result.CheckedAt)
+	}
+
 	return &pb.CheckForUpdatesResponse{
 		FilmCount:   int32(result.FilmCount),
 		SeriesCount: int32(result.SeriesCount),
 		HasUpdates:  result.HasUpdates,
+		CheckedAt:   checkedAt,
 	}, nil
 }
 
+// downloadChunkSize bounds the content carried by one DownloadSubtitleStream message,
+// keeping large season packs well below the default 4 MiB gRPC message limit.
+const downloadChunkSize = 1 << 20
+
+// maxEpisodeRange bounds the episodes a DownloadSubtitle episode range asks for, since each
+// episode is a separate scan of the season pack.
+const maxEpisodeRange = 100
+
 // DownloadSubtitle implements SuperSubtitlesServiceServer.DownloadSubtitle
 func (s *server) DownloadSubtitle(ctx context.Context, req *pb.DownloadSubtitleRequest) (*pb.DownloadSubtitleResponse, error) {
+	if req.HeadOnly {
+		return s.estimateDownload(ctx, req)
+	}
+
+	result, err := s.downloadSubtitle(ctx, "DownloadSubtitle", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DownloadSubtitleResponse{
+		Filename:        result.Filename,
+		SourceFilename:  result.SourceFilename,
+		Content:         result.Content,
+		ContentType:     result.ContentType,
+		Sha256:          result.Sha256,
+		DetectedCharset: result.DetectedCharset,
+	}, nil
+}
+
+// DownloadSubtitleStream downloads a subtitle like DownloadSubtitle and streams it: a metadata
+// message first, then the content in chunks of at most downloadChunkSize bytes.
+// A cancelled stream stops sending at the next chunk.
+func (s *server) DownloadSubtitleStream(req *pb.DownloadSubtitleRequest, stream grpc.ServerStreamingServer[pb.DownloadChunk]) error {
+	if req.HeadOnly {
+		return status.Error(codes.InvalidArgument, "head_only is only supported by DownloadSubtitle")
+	}
+
+	ctx := stream.Context()
+	result, err := s.downloadSubtitle(ctx, "DownloadSubtitleStream", req)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&pb.DownloadChunk{
+		Filename:        result.Filename,
+		SourceFilename:  result.SourceFilename,
+		ContentType:     result.ContentType,
+		Sha256:          result.Sha256,
+		Size:            int64(len(result.Content)),
+		DetectedCharset: result.DetectedCharset,
+	}); err != nil {
+		return err
+	}
+
+	chunks := 0
+	for offset := 0; offset < len(result.Content); offset += downloadChunkSize {
+		if err := ctx.Err(); err != nil {
+			s.logger.Debug().Str("subtitle_id", req.SubtitleId).Int("chunks_sent", chunks).Msg("DownloadSubtitleStream cancelled")
+			return status.FromContextError(err).Err()
+		}
+		end := min(offset+downloadChunkSize, len(result.Content))
+		if err := stream.Send(&pb.DownloadChunk{Data: result.Content[offset:end]}); err != nil {
+			return err
+		}
+		chunks++
+	}
+
+	s.logger.Debug().Str("subtitle_id", req.SubtitleId).Int("chunks", chunks).Msg("DownloadSubtitleStream sent all chunks")
+	return nil
+}
+
+// ExportShowSubtitles streams the subtitles of a show as one ZIP archive. The client writes the
+// archive into a pipe that is read here in chunks of downloadChunkSize, so only one chunk and the
+// file being added are held in memory. The metadata message goes out with the first chunk, so a
+// show whose listing fails is reported before anything is sent; it has no size or SHA-256, which
+// are only known at the end. A cancelled stream cancels the export and the downloads still to come.
+func (s *server) ExportShowSubtitles(req *pb.ExportShowSubtitlesRequest, stream grpc.ServerStreamingServer[pb.DownloadChunk]) error {
+	logEvent := s.logger.Debug().Int64("show_id", req.ShowId).Strs("languages", req.Languages)
+	if req.MaxTotalBytes != nil {
+		logEvent = logEvent.Int64("max_total_bytes", *req.MaxTotalBytes)
+	}
+	logEvent.Msg("ExportShowSubtitles called")
+
+	if req.ShowId <= 0 {
+		return status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+	if req.MaxTotalBytes != nil && *req.MaxTotalBytes <= 0 {
+		return status.Error(codes.InvalidArgument, "max_total_bytes must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	reader, writer := io.Pipe()
+	opts := models.ExportOptions{Languages: req.Languages, MaxTotalBytes: req.GetMaxTotalBytes()}
+	var manifest *models.ExportManifest
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var err error
+		manifest, err = s.client.ExportShowSubtitles(ctx, int(req.ShowId), opts, writer)
+		// A nil error ends the reads below with io.EOF
+		writer.CloseWithError(err)
+	}()
+	defer func() {
+		cancel()
+		_ = reader.Close() // Fails a write the export is blocked on
+		<-done
+	}()
+
+	metadataSent := false
+	chunks := 0
+	for {
+		data := make([]byte, downloadChunkSize)
+		n, err := io.ReadFull(reader, data)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				s.logger.Debug().Int64("show_id", req.ShowId).Int("chunks_sent", chunks).Msg("ExportShowSubtitles cancelled")
+				return status.FromContextError(ctxErr).Err()
+			}
+			reportGRPCError("ExportShowSubtitles", err, map[string]any{"show_id": req.ShowId, "chunks_sent": chunks})
+			s.logger.Error().Err(err).Int64("show_id", req.ShowId).Int("chunks_sent", chunks).Msg("Failed to export show subtitles")
+			return toStatusError("failed to export show subtitles", err)
+		}
+		if !metadataSent {
+			if err := stream.Send(&pb.DownloadChunk{
+				Filename:    fmt.Sprintf("show-%d-subtitles.zip", req.ShowId),
+				ContentType: "application/zip",
+			}); err != nil {
+				return err
+			}
+			metadataSent = true
+		}
+		if n > 0 {
+			if err := stream.Send(&pb.DownloadChunk{Data: data[:n]}); err != nil {
+				return err
+			}
+			chunks++
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	<-done
+	s.logger.Debug().
+		Int64("show_id", req.ShowId).
+		Int("files", len(manifest.Files)-manifest.Failed).
+		Int("failed", manifest.Failed).
+		Int("chunks", chunks).
+		Msg("ExportShowSubtitles completed")
+	return nil
+}
+
+// estimateDownload answers a head_only DownloadSubtitle request with the file's metadata and
+// size and no content. Episode selectors are ignored: the size is that of the whole file.
+func (s *server) estimateDownload(ctx context.Context, req *pb.DownloadSubtitleRequest) (*pb.DownloadSubtitleResponse, error) {
+	s.logger.Debug().Str("subtitle_id", req.SubtitleId).Msg("DownloadSubtitle head_only called")
+
+	if err := checkDownloadableID(req.SubtitleId); err != nil {
+		return nil, err
+	}
+
+	estimate, err := s.client.EstimateDownload(ctx, req.SubtitleId)
+	if err != nil {
+		reportGRPCError("DownloadSubtitle", err, map[string]any{"subtitle_id": req.SubtitleId, "head_only": true})
+		s.logger.Error().Err(err).Str("subtitle_id", req.SubtitleId).Msg("Failed to estimate subtitle download")
+		return nil, toStatusError("failed to estimate subtitle download", err)
+	}
+
+	s.logger.Debug().
+		Str("subtitle_id", req.SubtitleId).
+		Int64("content_length", estimate.ContentLength).
+		Bool("from_cache", estimate.FromCache).
+		Bool("length_unknown", estimate.LengthUnknown).
+		Msg("DownloadSubtitle head_only completed")
+
+	return &pb.DownloadSubtitleResponse{
+		Filename:      estimate.Filename,
+		ContentType:   estimate.ContentType,
+		ContentLength: estimate.ContentLength,
+		FromCache:     estimate.FromCache,
+		LengthUnknown: estimate.LengthUnknown,
+	}, nil
+}
+
+// checkDownloadableID rejects synthetic subtitle IDs: they are hashes of the download URL, so
+// they cannot be turned back into a link.
+func checkDownloadableID(subtitleID string) error {
+	if id, err := strconv.Atoi(subtitleID); err == nil && models.IsSyntheticSubtitleID(id) {
+		return status.Error(codes.InvalidArgument, "subtitle_id is synthetic; download the subtitle by its download_url with DownloadSubtitleByUrl")
+	}
+	return nil
+}
+
+// checkEpisodeRange validates the episode range of a download request that sets episode_end.
+func checkEpisodeRange(req *pb.DownloadSubtitleRequest) error {
+	if req.Episode == nil {
+		return status.Error(codes.InvalidArgument, "episode_end requires episode")
+	}
+	if *req.EpisodeEnd < *req.Episode {
+		return status.Error(codes.InvalidArgument, "episode_end must not be before episode")
+	}
+	if *req.EpisodeEnd-*req.Episode >= maxEpisodeRange {
+		return status.Errorf(codes.InvalidArgument, "episode range spans more than %d episodes", maxEpisodeRange)
+	}
+	return nil
+}
+
+// downloadSubtitle runs a download request through the client for the named RPC. Failures are
+// logged and reported, and returned as gRPC status errors.
+func (s *server) downloadSubtitle(ctx context.Context, method string, req *pb.DownloadSubtitleRequest) (*models.DownloadResult, error) {
 	logEvent := s.logger.Debug().
 		Str("subtitle_id", req.SubtitleId)
 	if req.Episode != nil {
 		logEvent = logEvent.Int32("episode", *req.Episode)
 	}
-	logEvent.Msg("DownloadSubtitle called")
+	if req.EpisodeTitle != nil {
+		logEvent = logEvent.Str("episode_title", *req.EpisodeTitle)
+	}
+	if req.MaxBytes != nil {
+		logEvent = logEvent.Int64("max_bytes", *req.MaxBytes)
+	}
+	if req.EpisodeEnd != nil {
+		logEvent = logEvent.Int32("episode_end", *req.EpisodeEnd)
+	}
+	if req.StripStyling {
+		logEvent = logEvent.Bool("strip_styling", true)
+	}
+	if req.Raw {
+		logEvent = logEvent.Bool("raw", true)
+	}
+	if req.ForceRefresh {
+		logEvent = logEvent.Bool("force_refresh", true)
+	}
+	if req.KeepBom {
+		logEvent = logEvent.Bool("keep_bom", true)
+	}
+	if req.FilenameTemplate != nil {
+		logEvent = logEvent.Str("filename_template", *req.FilenameTemplate)
+	}
+	if req.Validate {
+		logEvent = logEvent.Bool("validate", true)
+	}
+	if req.TimeOffsetMs != 0 {
+		logEvent = logEvent.Int32("time_offset_ms", req.TimeOffsetMs)
+	}
+	logEvent.Msg(method + " called")
 
-	// Convert optional proto int32 to optional Go int
-	var episode *int
+	if req.MaxBytes != nil && *req.MaxBytes <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_bytes must be positive")
+	}
+	if err := checkDownloadableID(req.SubtitleId); err != nil {
+		return nil, err
+	}
+	if req.EpisodeEnd != nil {
+		if err := checkEpisodeRange(req); err != nil {
+			return nil, err
+		}
+	}
+	if req.Raw && (req.StripStyling || req.EpisodeEnd != nil || req.TimeOffsetMs != 0) {
+		return nil, status.Error(codes.InvalidArgument, "raw cannot be combined with strip_styling, episode_end or time_offset_ms")
+	}
+
+	// Convert optional proto fields to download options
+	opts := models.DownloadOptions{
+		EpisodeTitle:   req.GetEpisodeTitle(),
+		SourceEncoding: req.GetSourceEncoding(),
+		MaxBytes:       req.GetMaxBytes(),
+		StripStyling:   req.GetStripStyling(),
+		Raw:            req.GetRaw(),
+		ForceRefresh:   req.GetForceRefresh(),
+		KeepBOM:        req.GetKeepBom(),
+		Validate:       req.GetValidate(),
+		TimeOffset:     time.Duration(req.GetTimeOffsetMs()) * time.Millisecond,
+	}
 	if req.Episode != nil {
 		e := int(*req.Episode)
-		episode = &e
+		opts.Episode = &e
+	}
+	if req.FilenameTemplate != nil {
+		tmpl, err := models.ParseFilenameTemplate(*req.FilenameTemplate)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filename_template: %v", err)
+		}
+		opts.FilenameTemplate = tmpl
 	}
 
-	result, err := s.client.DownloadSubtitle(ctx, req.SubtitleId, episode)
+	var result *models.DownloadResult
+	var err error
+	if req.EpisodeEnd != nil {
+		result, err = s.client.DownloadEpisodeRangeAsZip(ctx, req.SubtitleId, int(*req.Episode), int(*req.EpisodeEnd), req.GetMaxBytes())
+	} else {
+		result, err = s.client.DownloadSubtitle(ctx, req.SubtitleId, opts)
+	}
 	if err != nil {
 		contextFields := map[string]any{"subtitle_id": req.SubtitleId}
 		logEvent := s.logger.Error().Err(err).Str("subtitle_id", req.SubtitleId)
@@ -169,12 +621,20 @@ func (s *server) DownloadSubtitle(ctx context.Context, req *pb.DownloadSubtitleR
 			contextFields["episode"] = *req.Episode
 			logEvent = logEvent.Int32("episode", *req.Episode)
 		}
+		if req.EpisodeEnd != nil {
+			contextFields["episode_end"] = *req.EpisodeEnd
+			logEvent = logEvent.Int32("episode_end", *req.EpisodeEnd)
+		}
+		if req.EpisodeTitle != nil {
+			contextFields["episode_title"] = *req.EpisodeTitle
+			logEvent = logEvent.Str("episode_title", *req.EpisodeTitle)
+		}
 		var archiveErr *archive.ArchiveError
 		if errors.As(err, &archiveErr) && archiveErr.URL != "" {
 			contextFields["archive_url"] = archiveErr.URL
 			logEvent = logEvent.Str("archive_url", archiveErr.URL)
 		}
-		reportGRPCError("DownloadSubtitle", err, contextFields)
+		reportGRPCError(method, err, contextFields)
 		logEvent.Msg("Failed to download subtitle")
 		return nil, toStatusError("failed to download subtitle", err)
 	}
@@ -183,12 +643,54 @@ func (s *server) DownloadSubtitle(ctx context.Context, req *pb.DownloadSubtitleR
 		Str("subtitle_id", req.SubtitleId).
 		Str("filename", result.Filename).
 		Int("size", len(result.Content)).
-		Msg("DownloadSubtitle completed")
+		Msg(method + " completed")
+
+	return result, nil
+}
+
+// DownloadSubtitleByUrl downloads a subtitle from a full download link on the configured site
+func (s *server) DownloadSubtitleByUrl(ctx context.Context, req *pb.DownloadSubtitleByUrlRequest) (*pb.DownloadSubtitleResponse, error) {
+	logEvent := s.logger.Debug().
+		Str("url", req.Url)
+	if req.Episode != nil {
+		logEvent = logEvent.Int32("episode", *req.Episode)
+	}
+	logEvent.Msg("DownloadSubtitleByUrl called")
+
+	if req.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	var opts models.DownloadOptions
+	if req.Episode != nil {
+		e := int(*req.Episode)
+		opts.Episode = &e
+	}
+
+	result, err := s.client.DownloadSubtitleByURL(ctx, req.Url, opts)
+	if err != nil {
+		contextFields := map[string]any{"url": req.Url}
+		if req.Episode != nil {
+			contextFields["episode"] = *req.Episode
+		}
+		reportGRPCError("DownloadSubtitleByUrl", err, contextFields)
+		s.logger.Error().Err(err).Str("url", req.Url).Msg("Failed to download subtitle by URL")
+		return nil, toStatusError("failed to download subtitle", err)
+	}
+
+	s.logger.Debug().
+		Str("url", req.Url).
+		Str("filename", result.Filename).
+		Int("size", len(result.Content)).
+		Msg("DownloadSubtitleByUrl completed")
 
 	return &pb.DownloadSubtitleResponse{
-		Filename:    result.Filename,
-		Content:     result.Content,
-		ContentType: result.ContentType,
+		Filename:        result.Filename,
+		SourceFilename:  result.SourceFilename,
+		Content:         result.Content,
+		ContentType:     result.ContentType,
+		Sha256:          result.Sha256,
+		DetectedCharset: result.DetectedCharset,
 	}, nil
 }
 
@@ -197,9 +699,10 @@ func (s *server) GetRecentSubtitles(req *pb.GetRecentSubtitlesRequest, stream gr
 	s.logger.Debug().Int64("since_id", req.SinceId).Msg("GetRecentSubtitles called")
 
 	count := 0
+	partial := newPartialErrors("GetRecentSubtitles")
 	for result := range s.client.StreamRecentSubtitles(stream.Context(), int(req.SinceId)) {
 		if result.Err != nil {
-			if count == 0 {
+			if count == 0 && !isShowTimeout(result.Err) {
 				// No items sent yet — return error to client
 				reportGRPCError("GetRecentSubtitles", result.Err, map[string]any{"since_id": req.SinceId})
 				s.logger.Error().Err(result.Err).Int64("since_id", req.SinceId).Msg("Failed to get recent subtitles")
@@ -207,6 +710,7 @@ func (s *server) GetRecentSubtitles(req *pb.GetRecentSubtitlesRequest, stream gr
 			}
 			// Items already sent — log and continue to deliver partial results
 			s.logger.Warn().Err(result.Err).Msg("Error while streaming recent subtitles")
+			partial.add(result.Err)
 			continue
 		}
 
@@ -217,10 +721,303 @@ func (s *server) GetRecentSubtitles(req *pb.GetRecentSubtitlesRequest, stream gr
 		count++
 	}
 
-	s.logger.Debug().Int64("since_id", req.SinceId).Int("count", count).Msg("GetRecentSubtitles completed")
+	partial.setTrailer(stream)
+	s.logger.Debug().Int64("since_id", req.SinceId).Int("count", count).Int("partial_errors", partial.count).Msg("GetRecentSubtitles completed")
 	return nil
 }
 
+// InvalidateCache implements SuperSubtitlesServiceServer.InvalidateCache
+func (s *server) InvalidateCache(ctx context.Context, req *pb.InvalidateCacheRequest) (*pb.InvalidateCacheResponse, error) {
+	s.logger.Debug().Str("subtitle_id", req.SubtitleId).Msg("InvalidateCache called")
+
+	if req.SubtitleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "subtitle_id is required")
+	}
+
+	invalidated, err := s.client.InvalidateCache(req.SubtitleId)
+	if err != nil {
+		reportGRPCError("InvalidateCache", err, map[string]any{"subtitle_id": req.SubtitleId})
+		s.logger.Error().Err(err).Str("subtitle_id", req.SubtitleId).Msg("Failed to invalidate cache")
+		return nil, toStatusError("failed to invalidate cache", err)
+	}
+
+	s.logger.Info().
+		Str("subtitle_id", req.SubtitleId).
+		Bool("invalidated", invalidated).
+		Msg("InvalidateCache completed")
+
+	return &pb.InvalidateCacheResponse{Invalidated: invalidated}, nil
+}
+
+// ClearCache implements SuperSubtitlesServiceServer.ClearCache
+func (s *server) ClearCache(ctx context.Context, req *pb.ClearCacheRequest) (*pb.ClearCacheResponse, error) {
+	s.logger.Debug().Msg("ClearCache called")
+
+	entries := s.client.ClearCache()
+
+	s.logger.Info().Int("entries_cleared", entries).Msg("ClearCache completed")
+	return &pb.ClearCacheResponse{EntriesCleared: safeInt64(entries)}, nil
+}
+
+// GetLatestSubtitleId implements SuperSubtitlesServiceServer.GetLatestSubtitleId
+func (s *server) GetLatestSubtitleId(ctx context.Context, req *pb.GetLatestSubtitleIdRequest) (*pb.GetLatestSubtitleIdResponse, error) {
+	s.logger.Debug().Msg("GetLatestSubtitleId called")
+
+	latestID, err := s.client.GetLatestSubtitleID(ctx)
+	if err != nil {
+		reportGRPCError("GetLatestSubtitleId", err, nil)
+		s.logger.Error().Err(err).Msg("Failed to get latest subtitle ID")
+		return nil, toStatusError("failed to get latest subtitle ID", err)
+	}
+
+	s.logger.Debug().Int("subtitle_id", latestID).Msg("GetLatestSubtitleId completed")
+	return &pb.GetLatestSubtitleIdResponse{SubtitleId: safeInt64(latestID)}, nil
+}
+
+// GetLanguages implements SuperSubtitlesServiceServer.GetLanguages. The languages come from the
+// same table the parser uses to normalize language names, so no upstream request is made.
+func (s *server) GetLanguages(ctx context.Context, req *pb.GetLanguagesRequest) (*pb.GetLanguagesResponse, error) {
+	s.logger.Debug().Int("languages", len(models.Languages)).Msg("GetLanguages called")
+	return convertLanguagesToProto(models.Languages), nil
+}
+
+// GetStatus implements SuperSubtitlesServiceServer.GetStatus. It reports the client's mirror
+// state without making an upstream request.
+func (s *server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	status := s.client.UpstreamStatus()
+	s.logger.Debug().Str("active_mirror", status.ActiveMirror).Msg("GetStatus called")
+	return convertUpstreamStatusToProto(status), nil
+}
+
+// GetServerInfo implements SuperSubtitlesServiceServer.GetServerInfo
+func (s *server) GetServerInfo(ctx context.Context, req *pb.GetServerInfoRequest) (*pb.ServerInfo, error) {
+	info := newServerInfo(config.GetConfig(), config.Watching())
+	s.logger.Debug().Str("version", info.Version).Strs("features", info.Features).Msg("GetServerInfo called")
+	return convertServerInfoToProto(info), nil
+}
+
+// SelfCheck implements SuperSubtitlesServiceServer.SelfCheck. A failed check is reported with
+// ok set to false rather than as an error, so callers can tell site drift from an unreachable service.
+func (s *server) SelfCheck(ctx context.Context, req *pb.SelfCheckRequest) (*pb.SelfCheckResponse, error) {
+	s.logger.Debug().Msg("SelfCheck called")
+	result := s.client.SelfCheck(ctx)
+	s.logger.Debug().Bool("ok", result.OK).Str("details", result.Details).Msg("SelfCheck completed")
+	return convertSelfCheckToProto(result), nil
+}
+
+// GetShowImage implements SuperSubtitlesServiceServer.GetShowImage
+func (s *server) GetShowImage(ctx context.Context, req *pb.GetShowImageRequest) (*pb.ShowImage, error) {
+	s.logger.Debug().Int64("show_id", req.ShowId).Msg("GetShowImage called")
+
+	if req.ShowId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+
+	image, err := s.client.GetShowImage(ctx, int(req.ShowId))
+	if err != nil {
+		reportGRPCError("GetShowImage", err, map[string]any{"show_id": req.ShowId})
+		s.logger.Error().Err(err).Int64("show_id", req.ShowId).Msg("Failed to get show image")
+		return nil, toStatusError("failed to get show image", err)
+	}
+
+	s.logger.Debug().Int64("show_id", req.ShowId).Int("size", len(image.Content)).Str("content_type", image.ContentType).Msg("GetShowImage completed")
+	return &pb.ShowImage{Content: image.Content, ContentType: image.ContentType}, nil
+}
+
+// FindSubtitle implements SuperSubtitlesServiceServer.FindSubtitle
+func (s *server) FindSubtitle(ctx context.Context, req *pb.FindSubtitleRequest) (*pb.FindSubtitleResponse, error) {
+	s.logger.Debug().
+		Int64("show_id", req.ShowId).
+		Int32("season", req.Season).
+		Int32("episode", req.Episode).
+		Str("language", req.Language).
+		Msg("FindSubtitle called")
+
+	if req.ShowId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+	if req.Season < 0 || req.Episode < 0 {
+		return nil, status.Error(codes.InvalidArgument, "season and episode must not be negative")
+	}
+
+	subtitles, err := s.client.FindSubtitle(ctx, int(req.ShowId), int(req.Season), int(req.Episode), req.Language)
+	if err != nil {
+		reportGRPCError("FindSubtitle", err, map[string]any{"show_id": req.ShowId, "season": req.Season, "episode": req.Episode})
+		s.logger.Error().Err(err).Int64("show_id", req.ShowId).Msg("Failed to find subtitle")
+		return nil, toStatusError("failed to find subtitle", err)
+	}
+
+	pbSubtitles := make([]*pb.Subtitle, len(subtitles))
+	for i, subtitle := range subtitles {
+		pbSubtitles[i] = convertSubtitleToProto(subtitle)
+	}
+
+	s.logger.Debug().Int64("show_id", req.ShowId).Int("count", len(pbSubtitles)).Msg("FindSubtitle completed")
+	return &pb.FindSubtitleResponse{Subtitles: pbSubtitles}, nil
+}
+
+// GetBestSubtitles streams one subtitle per episode of a show, picked by language, quality and recency
+func (s *server) GetBestSubtitles(req *pb.GetBestSubtitlesRequest, stream grpc.ServerStreamingServer[pb.Subtitle]) error {
+	s.logger.Debug().
+		Int64("show_id", req.ShowId).
+		Str("language", req.Language).
+		Str("preferred_quality", req.PreferredQuality.String()).
+		Msg("GetBestSubtitles called")
+
+	if req.ShowId <= 0 {
+		return status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+
+	var subtitles []models.Subtitle
+	for result := range s.client.StreamSubtitles(stream.Context(), int(req.ShowId)) {
+		if result.Err != nil {
+			reportGRPCError("GetBestSubtitles", result.Err, map[string]any{"show_id": req.ShowId})
+			s.logger.Error().Err(result.Err).Int64("show_id", req.ShowId).Msg("Failed to get subtitles")
+			return toStatusError("failed to get subtitles", result.Err)
+		}
+		subtitles = append(subtitles, result.Value)
+	}
+
+	best := services.SelectBestSubtitles(subtitles, req.Language, convertQualityFromProto(req.PreferredQuality))
+	for _, pick := range best {
+		pbSubtitle := convertSubtitleToProto(pick.Subtitle)
+		// Season packs are streamed under the episode they were picked for
+		pbSubtitle.Episode = safeInt32(pick.Episode)
+		if err := stream.Send(pbSubtitle); err != nil {
+			return status.Errorf(codes.Internal, "failed to stream subtitle: %v", err)
+		}
+	}
+
+	s.logger.Debug().Int64("show_id", req.ShowId).Int("count", len(best)).Msg("GetBestSubtitles completed")
+	return nil
+}
+
+// GetShowLanguageStats returns per-language subtitle statistics for a show
+func (s *server) GetShowLanguageStats(ctx context.Context, req *pb.GetShowLanguageStatsRequest) (*pb.ShowLanguageStats, error) {
+	s.logger.Debug().Int64("show_id", req.ShowId).Msg("GetShowLanguageStats called")
+
+	if req.ShowId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+
+	var subtitles []models.Subtitle
+	for result := range s.client.StreamSubtitles(ctx, int(req.ShowId)) {
+		if result.Err != nil {
+			reportGRPCError("GetShowLanguageStats", result.Err, map[string]any{"show_id": req.ShowId})
+			s.logger.Error().Err(result.Err).Int64("show_id", req.ShowId).Msg("Failed to get subtitles")
+			return nil, toStatusError("failed to get subtitles", result.Err)
+		}
+		subtitles = append(subtitles, result.Value)
+	}
+
+	stats := services.AggregateLanguageStats(subtitles)
+
+	s.logger.Debug().Int64("show_id", req.ShowId).Int("languages", len(stats)).Msg("GetShowLanguageStats completed")
+	return convertLanguageStatsToProto(req.ShowId, stats), nil
+}
+
+// GetShowSeasons lists the seasons of a show that have subtitles
+func (s *server) GetShowSeasons(ctx context.Context, req *pb.GetShowSeasonsRequest) (*pb.ShowSeasons, error) {
+	s.logger.Debug().Int64("show_id", req.ShowId).Msg("GetShowSeasons called")
+
+	if req.ShowId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "show_id must be positive")
+	}
+
+	seasons, err := s.client.GetShowSeasons(ctx, int(req.ShowId))
+	if err != nil {
+		reportGRPCError("GetShowSeasons", err, map[string]any{"show_id": req.ShowId})
+		s.logger.Error().Err(err).Int64("show_id", req.ShowId).Msg("Failed to get show seasons")
+		return nil, toStatusError("failed to get show seasons", err)
+	}
+
+	s.logger.Debug().Int64("show_id", req.ShowId).Int("seasons", len(seasons.Seasons)).Msg("GetShowSeasons completed")
+	return convertShowSeasonsToProto(req.ShowId, seasons), nil
+}
+
+// FindShow returns the show matching a name, ignoring case and diacritics. The optional year
+// disambiguates shows sharing a name; several matches without one map to InvalidArgument.
+func (s *server) FindShow(ctx context.Context, req *pb.FindShowRequest) (*pb.Show, error) {
+	s.logger.Debug().Str("name", req.Name).Msg("FindShow called")
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	var year *int
+	if req.Year != nil {
+		if *req.Year <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "year must be positive")
+		}
+		year = new(int(*req.Year))
+	}
+
+	show, err := s.client.FindShow(ctx, req.Name, year)
+	if err != nil {
+		reportGRPCError("FindShow", err, map[string]any{"name": req.Name, "year": req.GetYear()})
+		s.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to find show")
+		return nil, toStatusError("failed to find show", err)
+	}
+
+	s.logger.Debug().Str("name", req.Name).Int("show_id", show.ID).Msg("FindShow completed")
+	return convertShowToProto(*show), nil
+}
+
+// GetSubtitleDetails returns the detail page of a subtitle, including the uploader's comment.
+// Unknown subtitle IDs map to NotFound.
+func (s *server) GetSubtitleDetails(ctx context.Context, req *pb.GetSubtitleDetailsRequest) (*pb.SubtitleDetails, error) {
+	s.logger.Debug().Int64("subtitle_id", req.SubtitleId).Msg("GetSubtitleDetails called")
+
+	if req.SubtitleId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "subtitle_id must be positive")
+	}
+
+	details, err := s.client.GetSubtitleDetails(ctx, int(req.SubtitleId))
+	if err != nil {
+		reportGRPCError("GetSubtitleDetails", err, map[string]any{"subtitle_id": req.SubtitleId})
+		s.logger.Error().Err(err).Int64("subtitle_id", req.SubtitleId).Msg("Failed to get subtitle details")
+		return nil, toStatusError("failed to get subtitle details", err)
+	}
+
+	s.logger.Debug().Int64("subtitle_id", req.SubtitleId).Bool("has_comment", details.Comment != "").Msg("GetSubtitleDetails completed")
+	return convertSubtitleDetailsToProto(details), nil
+}
+
+// GetSubtitle implements SuperSubtitlesServiceServer.GetSubtitle
+func (s *server) GetSubtitle(ctx context.Context, req *pb.GetSubtitleRequest) (*pb.Subtitle, error) {
+	s.logger.Debug().Int64("show_id", req.ShowId).Int64("subtitle_id", req.SubtitleId).Msg("GetSubtitle called")
+
+	if req.ShowId <= 0 || req.SubtitleId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "show_id and subtitle_id must be positive")
+	}
+
+	subtitle, err := s.client.GetSubtitle(ctx, int(req.ShowId), int(req.SubtitleId))
+	if err != nil {
+		reportGRPCError("GetSubtitle", err, map[string]any{"show_id": req.ShowId, "subtitle_id": req.SubtitleId})
+		s.logger.Error().Err(err).Int64("show_id", req.ShowId).Int64("subtitle_id", req.SubtitleId).Msg("Failed to get subtitle")
+		return nil, toStatusError("failed to get subtitle", err)
+	}
+
+	return convertSubtitleToProto(*subtitle), nil
+}
+
+// GetSubtitleById implements SuperSubtitlesServiceServer.GetSubtitleById
+func (s *server) GetSubtitleById(ctx context.Context, req *pb.GetSubtitleByIdRequest) (*pb.Subtitle, error) {
+	s.logger.Debug().Int64("subtitle_id", req.SubtitleId).Msg("GetSubtitleById called")
+
+	if req.SubtitleId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "subtitle_id must be positive")
+	}
+
+	subtitle, err := s.client.GetSubtitleInfo(ctx, int(req.SubtitleId))
+	if err != nil {
+		reportGRPCError("GetSubtitleById", err, map[string]any{"subtitle_id": req.SubtitleId})
+		s.logger.Error().Err(err).Int64("subtitle_id", req.SubtitleId).Msg("Failed to get subtitle by ID")
+		return nil, toStatusError("failed to get subtitle", err)
+	}
+
+	return convertSubtitleToProto(*subtitle), nil
+}
+
 func reportGRPCError(method string, err error, requestContext map[string]any) {
 	sentryio.CaptureException(err, func(scope *sentry.Scope) {
 		scope.SetTag("grpc.method", method)
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"github.com/Belphemur/SuperSubtitles/v2/internal/buildinfo"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+const (
+	// apiVersion is the version of the SuperSubtitlesService API, the last element of its
+	// proto package name.
+	apiVersion = "v1"
+	// maxRecvMsgSize is grpc-go's default limit on a received message, which the server keeps.
+	maxRecvMsgSize = 4 * 1024 * 1024
+)
+
+// newServerInfo describes the running server from the build information and cfg. watching
+// adds config.FeatureConfigWatcher, set when config file changes are applied without a restart.
+func newServerInfo(cfg *config.Config, watching bool) models.ServerInfo {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	features := cfg.Features()
+	if watching {
+		features = append(features, config.FeatureConfigWatcher)
+	}
+	limits := cfg.DownloadLimits()
+	return models.ServerInfo{
+		Version:    buildinfo.Version,
+		Commit:     buildinfo.Commit,
+		BuildDate:  buildinfo.Date,
+		APIVersion: apiVersion,
+		Features:   features,
+		Limits: models.ServerLimits{
+			MaxDownloadBytes: limits.MaxDownloadSize,
+			MaxFileBytes:     limits.MaxFileSize,
+			MaxArchiveBytes:  limits.MaxArchiveSize,
+			MaxMessageBytes:  maxRecvMsgSize,
+		},
+	}
+}
@@ -1,10 +1,15 @@
 package grpc
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -19,7 +24,10 @@ import (
 	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/archive"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/buildinfo"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/services"
 )
 
 // mockClient implements client.Client for testing
@@ -27,14 +35,33 @@ type mockClient struct {
 	getShowListFunc        func(ctx context.Context) ([]models.Show, error)
 	getSubtitlesFunc       func(ctx context.Context, showID int) (*models.SubtitleCollection, error)
 	getShowSubtitlesFunc   func(ctx context.Context, shows []models.Show) ([]models.ShowSubtitles, error)
-	checkForUpdatesFunc    func(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error)
-	downloadSubtitleFunc   func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error)
+	checkForUpdatesFunc    func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error)
+	downloadSubtitleFunc   func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error)
+	downloadByURLFunc      func(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error)
+	downloadRangeFunc      func(ctx context.Context, subtitleID string, start, end int, maxBytes int64) (*models.DownloadResult, error)
+	estimateDownloadFunc   func(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error)
+	exportShowFunc         func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error)
 	getRecentSubtitlesFunc func(ctx context.Context, sinceID int) ([]models.ShowSubtitles, error)
-
-	streamShowListFunc        func(ctx context.Context) <-chan models.StreamResult[models.Show]
+	getLatestSubtitleFunc  func(ctx context.Context) (int, error)
+	findSubtitleFunc       func(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error)
+	getShowSeasonsFunc     func(ctx context.Context, showID int) (*models.ShowSeasons, error)
+	findShowFunc           func(ctx context.Context, name string, year *int) (*models.Show, error)
+	getSubtitleDetailsFunc func(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error)
+	getSubtitleFunc        func(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error)
+	getSubtitleInfoFunc    func(ctx context.Context, subtitleID int) (*models.Subtitle, error)
+	getShowImageFunc       func(ctx context.Context, showID int) (*models.ShowImage, error)
+	invalidateCacheFunc    func(subtitleID string) (bool, error)
+	clearCacheFunc         func() int
+	upstreamStatus         models.UpstreamStatus
+	selfCheckResult        models.SelfCheckResult
+
+	streamShowListFunc        func(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show]
 	streamSubtitlesFunc       func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle]
-	streamShowSubtitlesFunc   func(ctx context.Context, shows []models.Show) <-chan models.StreamResult[models.ShowSubtitles]
+	streamMovieSubtitlesFunc  func(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle]
+	streamShowSubtitlesFunc   func(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles]
 	streamRecentSubtitlesFunc func(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles]
+
+	subtitleOrder models.SubtitleOrder // Order passed to the last StreamSubtitlesInOrder call
 }
 
 func (m *mockClient) GetShowList(ctx context.Context) ([]models.Show, error) {
@@ -58,20 +85,146 @@ func (m *mockClient) GetShowSubtitles(ctx context.Context, shows []models.Show)
 	return []models.ShowSubtitles{}, nil
 }
 
-func (m *mockClient) CheckForUpdates(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error) {
+func (m *mockClient) CheckForUpdates(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
 	if m.checkForUpdatesFunc != nil {
-		return m.checkForUpdatesFunc(ctx, contentID)
+		return m.checkForUpdatesFunc(ctx, contentID, forceRefresh)
 	}
 	return &models.UpdateCheckResult{}, nil
 }
 
-func (m *mockClient) DownloadSubtitle(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
+func (m *mockClient) DownloadSubtitle(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
 	if m.downloadSubtitleFunc != nil {
-		return m.downloadSubtitleFunc(ctx, subtitleID, episode)
+		return m.downloadSubtitleFunc(ctx, subtitleID, opts)
+	}
+	return &models.DownloadResult{}, nil
+}
+
+func (m *mockClient) DownloadEpisodeRangeAsZip(ctx context.Context, subtitleID string, start, end int, maxBytes int64) (*models.DownloadResult, error) {
+	if m.downloadRangeFunc != nil {
+		return m.downloadRangeFunc(ctx, subtitleID, start, end, maxBytes)
+	}
+	return &models.DownloadResult{}, nil
+}
+
+func (m *mockClient) DownloadSubtitleByURL(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+	if m.downloadByURLFunc != nil {
+		return m.downloadByURLFunc(ctx, downloadURL, opts)
 	}
 	return &models.DownloadResult{}, nil
 }
 
+func (m *mockClient) ExportShowSubtitles(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+	if m.exportShowFunc != nil {
+		return m.exportShowFunc(ctx, showID, opts, w)
+	}
+	return &models.ExportManifest{ShowID: showID}, nil
+}
+
+func (m *mockClient) ApplyConfig(*config.Config) {}
+
+func (m *mockClient) GetLatestSubtitleID(ctx context.Context) (int, error) {
+	if m.getLatestSubtitleFunc != nil {
+		return m.getLatestSubtitleFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *mockClient) FindSubtitle(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error) {
+	if m.findSubtitleFunc != nil {
+		return m.findSubtitleFunc(ctx, showID, season, episode, language)
+	}
+	return []models.Subtitle{}, nil
+}
+
+func (m *mockClient) GetShowSeasons(ctx context.Context, showID int) (*models.ShowSeasons, error) {
+	if m.getShowSeasonsFunc != nil {
+		return m.getShowSeasonsFunc(ctx, showID)
+	}
+	return &models.ShowSeasons{}, nil
+}
+
+func (m *mockClient) FindShow(ctx context.Context, name string, year *int) (*models.Show, error) {
+	if m.findShowFunc != nil {
+		return m.findShowFunc(ctx, name, year)
+	}
+	return &models.Show{}, nil
+}
+
+func (m *mockClient) GetSubtitleDetails(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error) {
+	if m.getSubtitleDetailsFunc != nil {
+		return m.getSubtitleDetailsFunc(ctx, subtitleID)
+	}
+	return &models.SubtitleDetails{}, nil
+}
+
+func (m *mockClient) GetSubtitle(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error) {
+	if m.getSubtitleFunc != nil {
+		return m.getSubtitleFunc(ctx, showID, subtitleID)
+	}
+	return &models.Subtitle{}, nil
+}
+
+func (m *mockClient) GetSubtitleInfo(ctx context.Context, subtitleID int) (*models.Subtitle, error) {
+	if m.getSubtitleInfoFunc != nil {
+		return m.getSubtitleInfoFunc(ctx, subtitleID)
+	}
+	return &models.Subtitle{}, nil
+}
+
+func (m *mockClient) EstimateDownload(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error) {
+	if m.estimateDownloadFunc != nil {
+		return m.estimateDownloadFunc(ctx, subtitleID)
+	}
+	return &models.DownloadEstimate{}, nil
+}
+
+func (m *mockClient) Preload(context.Context, []int) int {
+	return 0
+}
+
+func (m *mockClient) InvalidateCache(subtitleID string) (bool, error) {
+	if m.invalidateCacheFunc != nil {
+		return m.invalidateCacheFunc(subtitleID)
+	}
+	return false, nil
+}
+
+func (m *mockClient) UpstreamStatus() models.UpstreamStatus {
+	return m.upstreamStatus
+}
+
+func (m *mockClient) SelfCheck(ctx context.Context) models.SelfCheckResult {
+	return m.selfCheckResult
+}
+
+func (m *mockClient) StreamSubtitlesInOrder(ctx context.Context, showID int, order models.SubtitleOrder) <-chan models.StreamResult[models.Subtitle] {
+	m.subtitleOrder = order
+	return m.StreamSubtitles(ctx, showID)
+}
+
+func (m *mockClient) StreamMovieSubtitles(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle] {
+	if m.streamMovieSubtitlesFunc != nil {
+		return m.streamMovieSubtitlesFunc(ctx, movieID)
+	}
+	ch := make(chan models.StreamResult[models.Subtitle])
+	close(ch)
+	return ch
+}
+
+func (m *mockClient) GetShowImage(ctx context.Context, showID int) (*models.ShowImage, error) {
+	if m.getShowImageFunc != nil {
+		return m.getShowImageFunc(ctx, showID)
+	}
+	return &models.ShowImage{}, nil
+}
+
+func (m *mockClient) ClearCache() int {
+	if m.clearCacheFunc != nil {
+		return m.clearCacheFunc()
+	}
+	return 0
+}
+
 func (m *mockClient) Close() error {
 	return nil
 }
@@ -83,9 +236,9 @@ func (m *mockClient) GetRecentSubtitles(ctx context.Context, sinceID int) ([]mod
 	return []models.ShowSubtitles{}, nil
 }
 
-func (m *mockClient) StreamShowList(ctx context.Context) <-chan models.StreamResult[models.Show] {
+func (m *mockClient) StreamShowList(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show] {
 	if m.streamShowListFunc != nil {
-		return m.streamShowListFunc(ctx)
+		return m.streamShowListFunc(ctx, afterID)
 	}
 	ch := make(chan models.StreamResult[models.Show])
 	go func() {
@@ -96,7 +249,9 @@ func (m *mockClient) StreamShowList(ctx context.Context) <-chan models.StreamRes
 			return
 		}
 		for _, show := range shows {
-			ch <- models.StreamResult[models.Show]{Value: show}
+			if show.ID > afterID {
+				ch <- models.StreamResult[models.Show]{Value: show}
+			}
 		}
 	}()
 	return ch
@@ -121,9 +276,9 @@ func (m *mockClient) StreamSubtitles(ctx context.Context, showID int) <-chan mod
 	return ch
 }
 
-func (m *mockClient) StreamShowSubtitles(ctx context.Context, shows []models.Show) <-chan models.StreamResult[models.ShowSubtitles] {
+func (m *mockClient) StreamShowSubtitles(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles] {
 	if m.streamShowSubtitlesFunc != nil {
-		return m.streamShowSubtitlesFunc(ctx, shows)
+		return m.streamShowSubtitlesFunc(ctx, shows, languages)
 	}
 	ch := make(chan models.StreamResult[models.ShowSubtitles])
 	go func() {
@@ -162,8 +317,9 @@ func (m *mockClient) StreamRecentSubtitles(ctx context.Context, sinceID int) <-c
 // mockServerStream implements grpc.ServerStreamingServer for testing streaming RPCs
 type mockServerStream[T any] struct {
 	grpc.ServerStream
-	ctx   context.Context
-	items []*T
+	ctx     context.Context
+	items   []*T
+	trailer metadata.MD
 }
 
 func newMockServerStream[T any]() *mockServerStream[T] {
@@ -177,11 +333,28 @@ func (m *mockServerStream[T]) Send(item *T) error {
 
 func (m *mockServerStream[T]) SetHeader(metadata.MD) error  { return nil }
 func (m *mockServerStream[T]) SendHeader(metadata.MD) error { return nil }
-func (m *mockServerStream[T]) SetTrailer(metadata.MD)       {}
+func (m *mockServerStream[T]) SetTrailer(md metadata.MD)    { m.trailer = metadata.Join(m.trailer, md) }
 func (m *mockServerStream[T]) Context() context.Context     { return m.ctx }
 func (m *mockServerStream[T]) SendMsg(msg any) error        { return nil }
 func (m *mockServerStream[T]) RecvMsg(msg any) error        { return nil }
 
+// assertPartialErrorTrailer checks the partial-error summary a stream attached to its trailer.
+func assertPartialErrorTrailer(t *testing.T, trailer metadata.MD, wantCount string, wantDetails ...string) {
+	t.Helper()
+	if got := trailer.Get(partialErrorsKey); len(got) != 1 || got[0] != wantCount {
+		t.Errorf("Expected %s trailer %q, got %v", partialErrorsKey, wantCount, got)
+	}
+	got := trailer.Get(partialErrorDetailKey)
+	if len(got) != len(wantDetails) {
+		t.Fatalf("Expected %d %s values, got %v", len(wantDetails), partialErrorDetailKey, got)
+	}
+	for i, want := range wantDetails {
+		if got[i] != want {
+			t.Errorf("Expected detail %d to be %q, got %q", i, want, got[i])
+		}
+	}
+}
+
 // TestGetShowList_Success tests successful show list streaming
 func TestGetShowList_Success(t *testing.T) {
 	t.Parallel()
@@ -217,6 +390,9 @@ func TestGetShowList_Success(t *testing.T) {
 	if stream.items[1].Name != "Game of Thrones" {
 		t.Errorf("Expected show name 'Game of Thrones', got '%s'", stream.items[1].Name)
 	}
+	if stream.trailer != nil {
+		t.Errorf("Expected no trailer for a complete stream, got %v", stream.trailer)
+	}
 }
 
 // TestGetShowList_Error tests error handling in show list streaming
@@ -386,6 +562,77 @@ func TestGetShowSubtitles_Success(t *testing.T) {
 	}
 }
 
+// TestGetShowSubtitles_PreferredLanguages tests that preferred languages are sorted first, in request order
+func TestGetShowSubtitles_PreferredLanguages(t *testing.T) {
+	t.Parallel()
+	languages := []string{"de", "en", "hu", "fr", "en", "hu"}
+	subtitles := make([]models.Subtitle, len(languages))
+	for i, lang := range languages {
+		subtitles[i] = models.Subtitle{ID: 100 + i, ShowID: 1, Language: lang}
+	}
+
+	mock := &mockClient{
+		getShowSubtitlesFunc: func(ctx context.Context, shows []models.Show) ([]models.ShowSubtitles, error) {
+			return []models.ShowSubtitles{{
+				Show:               models.Show{Name: "Breaking Bad", ID: 1},
+				SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad", Subtitles: subtitles, Total: len(subtitles)},
+			}}, nil
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+	req := &pb.GetShowSubtitlesRequest{
+		Shows:              []*pb.Show{{Name: "Breaking Bad", Id: 1}},
+		PreferredLanguages: []string{"HU", "en"},
+	}
+
+	if err := srv.GetShowSubtitles(req, stream); err != nil {
+		t.Fatalf("GetShowSubtitles returned error: %v", err)
+	}
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	}
+
+	// hu first, then en, then the rest; original order is kept within each group
+	wantIDs := []int64{102, 105, 101, 104, 100, 103}
+	got := stream.items[0].Subtitles
+	if len(got) != len(wantIDs) {
+		t.Fatalf("Expected %d subtitles, got %d", len(wantIDs), len(got))
+	}
+	for i, want := range wantIDs {
+		if got[i].Id != want {
+			t.Errorf("Position %d: expected subtitle ID %d (%s), got %d (%s)", i, want, languages[want-100], got[i].Id, got[i].Language)
+		}
+	}
+}
+
+// TestGetShowSubtitles_LanguagesPassedToClient tests that the language filter reaches the client
+func TestGetShowSubtitles_LanguagesPassedToClient(t *testing.T) {
+	t.Parallel()
+	var gotLanguages []string
+	mock := &mockClient{
+		streamShowSubtitlesFunc: func(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles] {
+			gotLanguages = languages
+			ch := make(chan models.StreamResult[models.ShowSubtitles])
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	req := &pb.GetShowSubtitlesRequest{
+		Shows:     []*pb.Show{{Name: "Breaking Bad", Id: 1}},
+		Languages: []string{"hu"},
+	}
+	if err := srv.GetShowSubtitles(req, newMockServerStream[pb.ShowSubtitlesCollection]()); err != nil {
+		t.Fatalf("GetShowSubtitles returned error: %v", err)
+	}
+	if !slices.Equal(gotLanguages, []string{"hu"}) {
+		t.Errorf("Expected languages [hu] passed to the client, got %v", gotLanguages)
+	}
+}
+
 // TestGetShowSubtitles_NoValidShows tests error when no valid shows are provided
 func TestGetShowSubtitles_NoValidShows(t *testing.T) {
 	t.Parallel()
@@ -412,7 +659,7 @@ func TestCheckForUpdates_Success(t *testing.T) {
 	}
 
 	mock := &mockClient{
-		checkForUpdatesFunc: func(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error) {
+		checkForUpdatesFunc: func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
 			if contentID != 12345 {
 				t.Errorf("Expected content ID 12345, got %d", contentID)
 			}
@@ -439,6 +686,30 @@ func TestCheckForUpdates_Success(t *testing.T) {
 	}
 }
 
+// TestCheckForUpdates_ForceRefreshAndCheckedAt tests that force_refresh reaches the client and
+// the fetch time is returned as checked_at
+func TestCheckForUpdates_ForceRefreshAndCheckedAt(t *testing.T) {
+	t.Parallel()
+	checkedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := &mockClient{
+		checkForUpdatesFunc: func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
+			if !forceRefresh {
+				t.Error("Expected forceRefresh to be passed to the client")
+			}
+			return &models.UpdateCheckResult{CheckedAt: checkedAt}, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	resp, err := srv.CheckForUpdates(context.Background(), &pb.CheckForUpdatesRequest{ContentId: 12345, ForceRefresh: true})
+	if err != nil {
+		t.Fatalf("CheckForUpdates returned error: %v", err)
+	}
+	if !resp.CheckedAt.AsTime().Equal(checkedAt) {
+		t.Errorf("Expected checked_at %v, got %v", checkedAt, resp.CheckedAt.AsTime())
+	}
+}
+
 // TestDownloadSubtitle_Success tests successful subtitle download
 func TestDownloadSubtitle_Success(t *testing.T) {
 	t.Parallel()
@@ -446,15 +717,16 @@ func TestDownloadSubtitle_Success(t *testing.T) {
 		Filename:    "breaking.bad.s01e01.srt",
 		Content:     []byte("subtitle content"),
 		ContentType: "application/x-subrip",
+		Sha256:      "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0",
 	}
 
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
 			if subtitleID != "101" {
 				t.Errorf("Expected subtitle ID '101', got '%s'", subtitleID)
 			}
-			if episode == nil || *episode != 1 {
-				t.Errorf("Expected episode 1, got %v", episode)
+			if opts.Episode == nil || *opts.Episode != 1 {
+				t.Errorf("Expected episode 1, got %v", opts.Episode)
 			}
 			return mockResult, nil
 		},
@@ -482,6 +754,9 @@ func TestDownloadSubtitle_Success(t *testing.T) {
 	if resp.ContentType != "application/x-subrip" {
 		t.Errorf("Expected content type 'application/x-subrip', got '%s'", resp.ContentType)
 	}
+	if resp.Sha256 != mockResult.Sha256 {
+		t.Errorf("Expected sha256 '%s', got '%s'", mockResult.Sha256, resp.Sha256)
+	}
 }
 
 // TestDownloadSubtitle_NoEpisode tests subtitle download without specifying an episode
@@ -494,12 +769,12 @@ func TestDownloadSubtitle_NoEpisode(t *testing.T) {
 	}
 
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
 			if subtitleID != "999" {
 				t.Errorf("Expected subtitle ID '999', got '%s'", subtitleID)
 			}
-			if episode != nil {
-				t.Errorf("Expected episode to be nil, got %v", episode)
+			if opts.WantsEpisode() {
+				t.Errorf("Expected no episode selector, got %+v", opts)
 			}
 			return mockResult, nil
 		},
@@ -530,175 +805,1614 @@ func TestDownloadSubtitle_NoEpisode(t *testing.T) {
 	}
 }
 
-// TestDownloadSubtitle_EpisodeNotFoundInZip tests that ErrSubtitleNotFoundInArchive results in a NotFound gRPC status
-func TestDownloadSubtitle_EpisodeNotFoundInZip(t *testing.T) {
+// TestDownloadSubtitle_EpisodeRange tests that episode_end routes the request to the range download
+func TestDownloadSubtitle_EpisodeRange(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
-			return nil, fmt.Errorf("failed to extract episode %d from ZIP: %w", *episode, &apperrors.ErrSubtitleNotFoundInArchive{Episode: *episode, FileCount: 3})
+		downloadRangeFunc: func(ctx context.Context, subtitleID string, start, end int, maxBytes int64) (*models.DownloadResult, error) {
+			if subtitleID != "101" || start != 1 || end != 5 {
+				t.Errorf("Expected subtitle 101 episodes 1-5, got %s episodes %d-%d", subtitleID, start, end)
+			}
+			// The downloader applies max_bytes; the server only passes it on
+			if maxBytes != 0 && maxBytes < 3 {
+				return nil, &apperrors.ErrDownloadTooLarge{Size: 3, Limit: maxBytes}
+			}
+			return &models.DownloadResult{Filename: "101_E01-E05.zip", Content: []byte("zip"), ContentType: "application/zip"}, nil
+		},
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			t.Error("Expected the range download, not DownloadSubtitle")
+			return nil, nil
 		},
 	}
 
 	srv := NewServer(mock)
-	ctx := context.Background()
-
-	req := &pb.DownloadSubtitleRequest{
+	resp, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
 		SubtitleId: "101",
-		Episode:    proto.Int32(5),
+		Episode:    proto.Int32(1),
+		EpisodeEnd: proto.Int32(5),
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
-
-	_, err := srv.DownloadSubtitle(ctx, req)
-	if err == nil {
-		t.Fatal("Expected error, got nil")
+	if resp.Filename != "101_E01-E05.zip" || resp.ContentType != "application/zip" {
+		t.Errorf("Expected the range ZIP, got %q (%s)", resp.Filename, resp.ContentType)
 	}
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	_, err = srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId: "101",
+		Episode:    proto.Int32(1),
+		EpisodeEnd: proto.Int32(5),
+		MaxBytes:   proto.Int64(2),
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for a ZIP above max_bytes, got %v", err)
 	}
-	if st.Code() != codes.NotFound {
-		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+}
+
+// TestDownloadSubtitle_InvalidEpisodeRange tests the validation of episode_end
+func TestDownloadSubtitle_InvalidEpisodeRange(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
+	tests := []struct {
+		name string
+		req  *pb.DownloadSubtitleRequest
+	}{
+		{"missing episode", &pb.DownloadSubtitleRequest{SubtitleId: "101", EpisodeEnd: proto.Int32(5)}},
+		{"end before start", &pb.DownloadSubtitleRequest{SubtitleId: "101", Episode: proto.Int32(5), EpisodeEnd: proto.Int32(4)}},
+		{"range too long", &pb.DownloadSubtitleRequest{SubtitleId: "101", Episode: proto.Int32(1), EpisodeEnd: proto.Int32(1 + maxEpisodeRange)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := srv.DownloadSubtitle(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
 	}
 }
 
-// TestDownloadSubtitle_ResourceNotFound tests that ErrSubtitleResourceNotFound (HTTP 404) results in a NotFound gRPC status
-func TestDownloadSubtitle_ResourceNotFound(t *testing.T) {
+// TestDownloadSubtitle_EpisodeTitle tests that the episode title selector is forwarded to the client
+func TestDownloadSubtitle_EpisodeTitle(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
-			return nil, fmt.Errorf("failed to download subtitle: %w", &apperrors.ErrSubtitleResourceNotFound{URL: "http://example.com/download/101"})
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if opts.Episode != nil {
+				t.Errorf("Expected no episode number, got %d", *opts.Episode)
+			}
+			if opts.EpisodeTitle != "i said no" {
+				t.Errorf("Expected episode title 'i said no', got %q", opts.EpisodeTitle)
+			}
+			return &models.DownloadResult{Filename: "Show.S03E02.I.Said.No.srt"}, nil
 		},
 	}
 
 	srv := NewServer(mock)
-	ctx := context.Background()
-
-	req := &pb.DownloadSubtitleRequest{SubtitleId: "101"}
-
-	_, err := srv.DownloadSubtitle(ctx, req)
-	if err == nil {
-		t.Fatal("Expected error, got nil")
-	}
-
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	resp, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId:   "101",
+		EpisodeTitle: proto.String("i said no"),
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
-	if st.Code() != codes.NotFound {
-		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+	if resp.Filename != "Show.S03E02.I.Said.No.srt" {
+		t.Errorf("Expected filename 'Show.S03E02.I.Said.No.srt', got '%s'", resp.Filename)
 	}
 }
 
-func TestDownloadSubtitle_ArchiveErrorReturnsUnprocessableEntity(t *testing.T) {
+// TestDownloadSubtitle_SourceEncoding tests that the source encoding override is forwarded to the client
+func TestDownloadSubtitle_SourceEncoding(t *testing.T) {
 	t.Parallel()
-
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
-			return nil, archive.NewError("failed to extract episode 5 from ZIP", errors.New("ZIP bomb detected: suspicious compression ratio"))
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if opts.SourceEncoding != "windows-1250" {
+				t.Errorf("Expected source encoding 'windows-1250', got %q", opts.SourceEncoding)
+			}
+			return &models.DownloadResult{Filename: "101.srt"}, nil
 		},
 	}
 
 	srv := NewServer(mock)
-	ctx := context.Background()
-
-	req := &pb.DownloadSubtitleRequest{
-		SubtitleId: "101",
-		Episode:    proto.Int32(5),
+	_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId:     "101",
+		SourceEncoding: proto.String("windows-1250"),
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
+}
 
-	_, err := srv.DownloadSubtitle(ctx, req)
-	if err == nil {
-		t.Fatal("Expected error, got nil")
+// TestDownloadSubtitle_FilenameTemplate tests that filename_template is parsed and forwarded,
+// and that the source filename is returned next to the rendered one
+func TestDownloadSubtitle_FilenameTemplate(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if opts.FilenameTemplate == nil {
+				t.Fatal("Expected a filename template")
+			}
+			filename, err := models.RenderFilename(opts.FilenameTemplate, models.NewFilenameTemplateData("Dark.S01E03/English.srt", *opts.Episode))
+			if err != nil {
+				t.Fatalf("RenderFilename failed: %v", err)
+			}
+			return &models.DownloadResult{Filename: filename, SourceFilename: "English.srt"}, nil
+		},
 	}
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
-	}
-	if st.Code() != codes.FailedPrecondition {
-		t.Errorf("Expected codes.FailedPrecondition, got %v", st.Code())
+	srv := NewServer(mock)
+	resp, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId:       "101",
+		Episode:          proto.Int32(3),
+		FilenameTemplate: proto.String("{{.ShowName}} {{.Season}}x{{.Episode}}{{.Extension}}"),
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
-
-	if !strings.Contains(st.Message(), "ZIP bomb detected") {
-		t.Errorf("Expected status message to include original archive error, got %q", st.Message())
+	if resp.Filename != "Dark 1x3.srt" || resp.SourceFilename != "English.srt" {
+		t.Errorf("Expected the rendered and source filenames, got %q and %q", resp.Filename, resp.SourceFilename)
 	}
 
-	details := st.Details()
-	if len(details) == 0 {
-		t.Fatal("Expected status details with HTTP mapping metadata, got none")
+	_, err = srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId:       "101",
+		Episode:          proto.Int32(3),
+		FilenameTemplate: proto.String("{{.Title}}.srt"),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid template, got %v", err)
 	}
+}
 
-	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
-	if !ok {
-		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+// TestDownloadSubtitle_StripStyling tests that strip_styling is forwarded to the client
+func TestDownloadSubtitle_StripStyling(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if !opts.StripStyling {
+				t.Error("Expected strip styling to be set")
+			}
+			return &models.DownloadResult{Filename: "101.ass"}, nil
+		},
 	}
 
-	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusUnprocessableEntity); got != want {
-		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	srv := NewServer(mock)
+	_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{
+		SubtitleId:   "101",
+		StripStyling: true,
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
 }
 
-func TestDownloadSubtitle_UnrecoverableArchiveErrorReturnsDataLoss(t *testing.T) {
+// TestDownloadSubtitle_Raw tests that raw is forwarded and rejected with options that rewrite content
+func TestDownloadSubtitle_Raw(t *testing.T) {
 	t.Parallel()
-
 	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
-			return nil, archive.NewUnrecoverableError("archive is unsafe and permanently unusable", errors.New("ZIP bomb detected: suspicious compression ratio"))
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if !opts.Raw {
+				t.Error("Expected raw to be set")
+			}
+			return &models.DownloadResult{Filename: "101.srt"}, nil
 		},
 	}
 
 	srv := NewServer(mock)
-	ctx := context.Background()
+	if _, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", Raw: true}); err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
+	}
 
-	req := &pb.DownloadSubtitleRequest{
-		SubtitleId: "101",
-		Episode:    proto.Int32(5),
+	for _, req := range []*pb.DownloadSubtitleRequest{
+		{SubtitleId: "101", Raw: true, StripStyling: true},
+		{SubtitleId: "101", Raw: true, Episode: proto.Int32(1), EpisodeEnd: proto.Int32(3)},
+		{SubtitleId: "101", Raw: true, TimeOffsetMs: 1500},
+	} {
+		if _, err := srv.DownloadSubtitle(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
 	}
+}
 
-	_, err := srv.DownloadSubtitle(ctx, req)
-	if err == nil {
-		t.Fatal("Expected error, got nil")
+// TestDownloadSubtitleStream_MatchesUnary tests that reassembled chunks equal the unary download
+func TestDownloadSubtitleStream_MatchesUnary(t *testing.T) {
+	t.Parallel()
+	content := bytes.Repeat([]byte("0123456789abcdef"), (2*downloadChunkSize+downloadChunkSize/2)/16)
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return &models.DownloadResult{Filename: "pack.zip", Content: content, ContentType: "application/zip", Sha256: "abc"}, nil
+		},
 	}
+	srv := NewServer(mock)
+	req := &pb.DownloadSubtitleRequest{SubtitleId: "101"}
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	unary, err := srv.DownloadSubtitle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
 	}
-	if st.Code() != codes.DataLoss {
-		t.Errorf("Expected codes.DataLoss, got %v", st.Code())
+	stream := newMockServerStream[pb.DownloadChunk]()
+	if err := srv.DownloadSubtitleStream(req, stream); err != nil {
+		t.Fatalf("DownloadSubtitleStream returned error: %v", err)
 	}
 
-	details := st.Details()
-	if len(details) == 0 {
-		t.Fatal("Expected status details with HTTP mapping metadata, got none")
+	if len(stream.items) != 4 {
+		t.Fatalf("Expected metadata and 3 chunks, got %d messages", len(stream.items))
 	}
-
-	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
-	if !ok {
-		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	header := stream.items[0]
+	if header.Filename != unary.Filename || header.ContentType != unary.ContentType || header.Sha256 != unary.Sha256 ||
+		header.Size != int64(len(unary.Content)) || len(header.Data) != 0 {
+		t.Errorf("Unexpected metadata message: %v", header)
 	}
-
-	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusUnprocessableEntity); got != want {
-		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	var reassembled []byte
+	for _, chunk := range stream.items[1:] {
+		if len(chunk.Data) > downloadChunkSize {
+			t.Errorf("Chunk of %d bytes exceeds %d", len(chunk.Data), downloadChunkSize)
+		}
+		if chunk.Filename != "" || chunk.Size != 0 {
+			t.Errorf("Expected data-only chunk, got metadata %q/%d", chunk.Filename, chunk.Size)
+		}
+		reassembled = append(reassembled, chunk.Data...)
 	}
+	if !bytes.Equal(reassembled, unary.Content) {
+		t.Errorf("Reassembled %d bytes differ from the unary %d bytes", len(reassembled), len(unary.Content))
+	}
+}
+
+// cancelingChunkStream cancels its context once it has received a number of messages
+type cancelingChunkStream struct {
+	*mockServerStream[pb.DownloadChunk]
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (c *cancelingChunkStream) Send(item *pb.DownloadChunk) error {
+	if err := c.mockServerStream.Send(item); err != nil {
+		return err
+	}
+	if len(c.items) == c.cancelAfter {
+		c.cancel()
+	}
+	return nil
+}
+
+// TestDownloadSubtitleStream_Cancelled tests that a cancelled stream stops sending chunks
+func TestDownloadSubtitleStream_Cancelled(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return &models.DownloadResult{Filename: "pack.zip", Content: make([]byte, 5*downloadChunkSize)}, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &cancelingChunkStream{mockServerStream: newMockServerStream[pb.DownloadChunk](), cancelAfter: 2, cancel: cancel}
+	stream.ctx = ctx
+
+	err := srv.DownloadSubtitleStream(&pb.DownloadSubtitleRequest{SubtitleId: "101"}, stream)
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expected codes.Canceled, got %v", err)
+	}
+	if len(stream.items) != 2 {
+		t.Errorf("Expected sending to stop after 2 messages, got %d", len(stream.items))
+	}
+}
+
+// TestDownloadSubtitleStream_Error tests that a failed download is returned before any message
+func TestDownloadSubtitleStream_Error(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, &apperrors.ErrSubtitleResourceNotFound{URL: "http://example.com/download/101"}
+		},
+	}
+	srv := NewServer(mock)
+	stream := newMockServerStream[pb.DownloadChunk]()
+
+	err := srv.DownloadSubtitleStream(&pb.DownloadSubtitleRequest{SubtitleId: "101"}, stream)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
+	}
+	if len(stream.items) != 0 {
+		t.Errorf("Expected no messages, got %d", len(stream.items))
+	}
+}
+
+// TestExportShowSubtitles_StreamsArchive tests that the archive written by the client is streamed in chunks
+func TestExportShowSubtitles_StreamsArchive(t *testing.T) {
+	t.Parallel()
+	content := bytes.Repeat([]byte("0123456789abcdef"), (2*downloadChunkSize+downloadChunkSize/2)/16)
+	var gotOpts models.ExportOptions
+	mock := &mockClient{
+		exportShowFunc: func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+			gotOpts = opts
+			// Written in pieces, as the ZIP writer does
+			for offset := 0; offset < len(content); offset += 4096 {
+				if _, err := w.Write(content[offset:min(offset+4096, len(content))]); err != nil {
+					return nil, err
+				}
+			}
+			return &models.ExportManifest{ShowID: showID, Files: []models.ExportedFile{{SubtitleID: 1}}}, nil
+		},
+	}
+	srv := NewServer(mock)
+	stream := newMockServerStream[pb.DownloadChunk]()
+
+	req := &pb.ExportShowSubtitlesRequest{ShowId: 42, Languages: []string{"hu"}, MaxTotalBytes: proto.Int64(1 << 30)}
+	if err := srv.ExportShowSubtitles(req, stream); err != nil {
+		t.Fatalf("ExportShowSubtitles returned error: %v", err)
+	}
+
+	if !slices.Equal(gotOpts.Languages, []string{"hu"}) || gotOpts.MaxTotalBytes != 1<<30 {
+		t.Errorf("Expected the request options to reach the client, got %+v", gotOpts)
+	}
+	if len(stream.items) != 4 {
+		t.Fatalf("Expected metadata and 3 chunks, got %d messages", len(stream.items))
+	}
+	header := stream.items[0]
+	if header.Filename != "show-42-subtitles.zip" || header.ContentType != "application/zip" || len(header.Data) != 0 {
+		t.Errorf("Unexpected metadata message: %v", header)
+	}
+	var reassembled []byte
+	for _, chunk := range stream.items[1:] {
+		if len(chunk.Data) > downloadChunkSize {
+			t.Errorf("Chunk of %d bytes exceeds %d", len(chunk.Data), downloadChunkSize)
+		}
+		reassembled = append(reassembled, chunk.Data...)
+	}
+	if !bytes.Equal(reassembled, content) {
+		t.Errorf("Reassembled %d bytes differ from the %d bytes written", len(reassembled), len(content))
+	}
+}
+
+// TestExportShowSubtitles_Errors tests request validation and the mapping of export failures
+func TestExportShowSubtitles_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		req      *pb.ExportShowSubtitlesRequest
+		exportFn func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error)
+		wantCode codes.Code
+	}{
+		{name: "missing show", req: &pb.ExportShowSubtitlesRequest{}, wantCode: codes.InvalidArgument},
+		{name: "zero limit", req: &pb.ExportShowSubtitlesRequest{ShowId: 1, MaxTotalBytes: proto.Int64(0)}, wantCode: codes.InvalidArgument},
+		{
+			name: "limit exceeded",
+			req:  &pb.ExportShowSubtitlesRequest{ShowId: 1, MaxTotalBytes: proto.Int64(10)},
+			exportFn: func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+				_, _ = w.Write([]byte("PK"))
+				return nil, &apperrors.ErrDownloadTooLarge{Size: 12, Limit: 10}
+			},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name: "listing failed",
+			req:  &pb.ExportShowSubtitlesRequest{ShowId: 1},
+			exportFn: func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+				return nil, &apperrors.ErrNotFound{Resource: "show"}
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := NewServer(&mockClient{exportShowFunc: tt.exportFn})
+			err := srv.ExportShowSubtitles(tt.req, newMockServerStream[pb.DownloadChunk]())
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Expected %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+// TestExportShowSubtitles_Cancelled tests that cancelling the stream cancels the export
+func TestExportShowSubtitles_Cancelled(t *testing.T) {
+	t.Parallel()
+	exportCancelled := make(chan struct{})
+	mock := &mockClient{
+		exportShowFunc: func(ctx context.Context, showID int, opts models.ExportOptions, w io.Writer) (*models.ExportManifest, error) {
+			defer close(exportCancelled)
+			for {
+				if _, err := w.Write(make([]byte, downloadChunkSize)); err != nil {
+					return nil, err
+				}
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+		},
+	}
+	srv := NewServer(mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &cancelingChunkStream{mockServerStream: newMockServerStream[pb.DownloadChunk](), cancelAfter: 2, cancel: cancel}
+	stream.ctx = ctx
+
+	err := srv.ExportShowSubtitles(&pb.ExportShowSubtitlesRequest{ShowId: 1}, stream)
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expected codes.Canceled, got %v", err)
+	}
+	select {
+	case <-exportCancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the export to stop once the stream was cancelled")
+	}
+}
+
+// TestDownloadSubtitleByUrl_Success tests that an on-site link and episode are forwarded to the client
+func TestDownloadSubtitleByUrl_Success(t *testing.T) {
+	t.Parallel()
+	const downloadURL = "https://feliratok.eu/index.php?action=letolt&felirat=1700000000"
+	mock := &mockClient{
+		downloadByURLFunc: func(ctx context.Context, gotURL string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if gotURL != downloadURL {
+				t.Errorf("Expected URL %q, got %q", downloadURL, gotURL)
+			}
+			if opts.Episode == nil || *opts.Episode != 4 {
+				t.Errorf("Expected episode 4, got %v", opts.Episode)
+			}
+			return &models.DownloadResult{Filename: "Show.S01E04.srt", Content: []byte("sub"), ContentType: "application/x-subrip", Sha256: "abc"}, nil
+		},
+	}
+
+	srv := NewServer(mock)
+	resp, err := srv.DownloadSubtitleByUrl(context.Background(), &pb.DownloadSubtitleByUrlRequest{
+		Url:     downloadURL,
+		Episode: proto.Int32(4),
+	})
+	if err != nil {
+		t.Fatalf("DownloadSubtitleByUrl returned error: %v", err)
+	}
+	if resp.Filename != "Show.S01E04.srt" || string(resp.Content) != "sub" || resp.Sha256 != "abc" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+// TestDownloadSubtitleByUrl_RejectedURL tests that an off-site link results in an InvalidArgument gRPC status
+func TestDownloadSubtitleByUrl_RejectedURL(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadByURLFunc: func(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, &apperrors.ErrInvalidDownloadURL{URL: downloadURL, Reason: "host must be feliratok.eu"}
+		},
+	}
+
+	srv := NewServer(mock)
+	for _, downloadURL := range []string{"", "http://169.254.169.254/latest/meta-data"} {
+		_, err := srv.DownloadSubtitleByUrl(context.Background(), &pb.DownloadSubtitleByUrlRequest{Url: downloadURL})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("Expected gRPC status error for %q, got: %v", downloadURL, err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("Expected codes.InvalidArgument for %q, got %v", downloadURL, st.Code())
+		}
+	}
+}
+
+// TestDownloadSubtitle_EpisodeNotFoundInZip tests that ErrSubtitleNotFoundInArchive results in a NotFound gRPC status
+func TestDownloadSubtitle_EpisodeNotFoundInZip(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, fmt.Errorf("failed to extract episode %d from ZIP: %w", *opts.Episode, &apperrors.ErrSubtitleNotFoundInArchive{Episode: *opts.Episode, FileCount: 3})
+		},
+	}
+
+	srv := NewServer(mock)
+	ctx := context.Background()
+
+	req := &pb.DownloadSubtitleRequest{
+		SubtitleId: "101",
+		Episode:    proto.Int32(5),
+	}
+
+	_, err := srv.DownloadSubtitle(ctx, req)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+	}
+}
+
+// TestDownloadSubtitle_ResourceNotFound tests that ErrSubtitleResourceNotFound (HTTP 404) results in a NotFound gRPC status
+func TestDownloadSubtitle_ResourceNotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, fmt.Errorf("failed to download subtitle: %w", &apperrors.ErrSubtitleResourceNotFound{URL: "http://example.com/download/101"})
+		},
+	}
+
+	srv := NewServer(mock)
+	ctx := context.Background()
+
+	req := &pb.DownloadSubtitleRequest{SubtitleId: "101"}
+
+	_, err := srv.DownloadSubtitle(ctx, req)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+	}
+}
+
+func TestDownloadSubtitle_ArchiveErrorReturnsUnprocessableEntity(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, archive.NewError("failed to extract episode 5 from ZIP", errors.New("ZIP bomb detected: suspicious compression ratio"))
+		},
+	}
+
+	srv := NewServer(mock)
+	ctx := context.Background()
+
+	req := &pb.DownloadSubtitleRequest{
+		SubtitleId: "101",
+		Episode:    proto.Int32(5),
+	}
+
+	_, err := srv.DownloadSubtitle(ctx, req)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.FailedPrecondition {
+		t.Errorf("Expected codes.FailedPrecondition, got %v", st.Code())
+	}
+
+	if !strings.Contains(st.Message(), "ZIP bomb detected") {
+		t.Errorf("Expected status message to include original archive error, got %q", st.Message())
+	}
+
+	details := st.Details()
+	if len(details) == 0 {
+		t.Fatal("Expected status details with HTTP mapping metadata, got none")
+	}
+
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	}
+
+	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusUnprocessableEntity); got != want {
+		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	}
+}
+
+// TestDownloadSubtitle_ZipBombFromDownloader runs a ZIP bomb through the real downloader, so
+// the status code is checked against the error the downloader actually returns
+func TestDownloadSubtitle_ZipBombFromDownloader(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	entry, err := zipWriter.Create("malicious.s03e01.srt")
+	if err != nil {
+		t.Fatalf("Failed to create ZIP entry: %v", err)
+	}
+	// 25 MB compresses to a few KB and exceeds the 20 MB entry limit
+	if _, err := entry.Write(bytes.Repeat([]byte("Q"), 25*1024*1024)); err != nil {
+		t.Fatalf("Failed to write ZIP entry: %v", err)
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("Failed to close ZIP writer: %v", err)
+	}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer upstream.Close()
+
+	downloader := services.NewSubtitleDownloader(upstream.Client())
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return downloader.DownloadSubtitle(ctx, upstream.URL+"/index.php?action=letolt&felirat="+subtitleID, opts)
+		},
+	}
+
+	_, err = NewServer(mock).DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", Episode: proto.Int32(1)})
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got %v", err)
+	}
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Expected codes.ResourceExhausted for a ZIP bomb, got %v (%v)", st.Code(), err)
+	}
+	details := st.Details()
+	if len(details) == 0 {
+		t.Fatal("Expected status details with HTTP mapping metadata, got none")
+	}
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	}
+	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusUnprocessableEntity); got != want {
+		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	}
+}
+
+func TestDownloadSubtitle_UnrecoverableArchiveErrorReturnsDataLoss(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, archive.NewUnrecoverableError("archive is unsafe and permanently unusable", errors.New("ZIP bomb detected: suspicious compression ratio"))
+		},
+	}
+
+	srv := NewServer(mock)
+	ctx := context.Background()
+
+	req := &pb.DownloadSubtitleRequest{
+		SubtitleId: "101",
+		Episode:    proto.Int32(5),
+	}
+
+	_, err := srv.DownloadSubtitle(ctx, req)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.DataLoss {
+		t.Errorf("Expected codes.DataLoss, got %v", st.Code())
+	}
+
+	details := st.Details()
+	if len(details) == 0 {
+		t.Fatal("Expected status details with HTTP mapping metadata, got none")
+	}
+
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	}
+
+	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusUnprocessableEntity); got != want {
+		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	}
+}
+
+func TestDownloadSubtitle_ValidateMalformed(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if !opts.Validate {
+				t.Error("Expected validate to reach the client")
+			}
+			return nil, &apperrors.ErrMalformedSubtitle{Format: "srt", Line: 6, Reason: "unparseable timing line"}
+		},
+	}
+	srv := NewServer(mock)
+
+	_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", Validate: true})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.DataLoss {
+		t.Fatalf("Expected codes.DataLoss, got %v", st.Code())
+	}
+	details := st.Details()
+	if len(details) == 0 {
+		t.Fatal("Expected status details with the problem, got none")
+	}
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	}
+	if errorInfo.Metadata["format"] != "srt" || errorInfo.Metadata["line"] != "6" {
+		t.Errorf("Expected format and line metadata, got %v", errorInfo.Metadata)
+	}
+}
+
+func TestDownloadSubtitle_SourceCharset(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if opts.SourceEncoding == "utf-16le" {
+				return nil, &apperrors.ErrInvalidSourceEncoding{Encoding: opts.SourceEncoding, Reason: "invalid input"}
+			}
+			return &models.DownloadResult{Filename: "101.srt", ContentType: "application/x-subrip", DetectedCharset: "iso-8859-2"}, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	resp, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101"})
+	if err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
+	}
+	if resp.DetectedCharset != "iso-8859-2" {
+		t.Errorf("Expected detected_charset iso-8859-2, got %q", resp.DetectedCharset)
+	}
+
+	_, err = srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", SourceEncoding: proto.String("utf-16le")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a source_encoding that cannot decode the file, got %v", err)
+	}
+}
+
+func TestDownloadSubtitle_TimeOffset(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			if opts.TimeOffset != -2500*time.Millisecond {
+				t.Errorf("Expected time offset -2.5s to reach the client, got %v", opts.TimeOffset)
+			}
+			return &models.DownloadResult{Filename: "101.srt", ContentType: "application/x-subrip"}, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	if _, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", TimeOffsetMs: -2500}); err != nil {
+		t.Fatalf("DownloadSubtitle returned error: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_MaxBytes(t *testing.T) {
+	t.Parallel()
+
+	var gotMaxBytes int64
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			gotMaxBytes = opts.MaxBytes
+			return nil, &apperrors.ErrDownloadTooLarge{Size: 20 << 20, Limit: opts.MaxBytes}
+		},
+	}
+	srv := NewServer(mock)
+
+	_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", MaxBytes: proto.Int64(10 << 20)})
+	if gotMaxBytes != 10<<20 {
+		t.Errorf("Expected max_bytes %d to reach the client, got %d", 10<<20, gotMaxBytes)
+	}
+	st, _ := status.FromError(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Expected codes.ResourceExhausted, got %v", st.Code())
+	}
+	details := st.Details()
+	if len(details) == 0 {
+		t.Fatal("Expected status details with the limit, got none")
+	}
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected first detail to be ErrorInfo, got %T", details[0])
+	}
+	if got, want := errorInfo.Metadata["limit_bytes"], fmt.Sprintf("%d", 10<<20); got != want {
+		t.Errorf("Expected limit_bytes metadata %q, got %q", want, got)
+	}
+	if got, want := errorInfo.Metadata["http_status"], fmt.Sprintf("%d", http.StatusRequestEntityTooLarge); got != want {
+		t.Errorf("Expected http_status metadata %q, got %q", want, got)
+	}
+
+	for _, maxBytes := range []int64{0, -1} {
+		_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", MaxBytes: proto.Int64(maxBytes)})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected codes.InvalidArgument for max_bytes %d, got %v", maxBytes, err)
+		}
+	}
+}
+
+func TestDownloadSubtitle_HeadOnly(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			t.Error("Expected head_only not to download the content")
+			return nil, nil
+		},
+		estimateDownloadFunc: func(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error) {
+			if subtitleID != "101" {
+				t.Errorf("Expected subtitle ID 101, got %s", subtitleID)
+			}
+			return &models.DownloadEstimate{Filename: "101.zip", ContentType: "application/zip", ContentLength: 100 << 20, FromCache: true}, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	resp, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", HeadOnly: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.ContentLength != 100<<20 || !resp.FromCache || resp.LengthUnknown || len(resp.Content) != 0 {
+		t.Errorf("Unexpected head_only response: %+v", resp)
+	}
+	if resp.Filename != "101.zip" || resp.ContentType != "application/zip" {
+		t.Errorf("Unexpected metadata: %q %q", resp.Filename, resp.ContentType)
+	}
+
+	mock.estimateDownloadFunc = func(ctx context.Context, subtitleID string) (*models.DownloadEstimate, error) {
+		return nil, &apperrors.ErrSubtitleResourceNotFound{URL: "http://example.com/101"}
+	}
+	_, err = srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101", HeadOnly: true})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
+	}
+}
+
+func TestDownloadSubtitleStream_HeadOnlyRejected(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(&mockClient{})
+	err := srv.DownloadSubtitleStream(&pb.DownloadSubtitleRequest{SubtitleId: "101", HeadOnly: true}, nil)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected codes.InvalidArgument, got %v", err)
+	}
+}
+
+func TestDownloadSubtitle_SyntheticID(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			t.Errorf("Expected synthetic ID %s to be rejected before reaching the client", subtitleID)
+			return nil, nil
+		},
+	}
+	srv := NewServer(mock)
+
+	syntheticID := models.SyntheticSubtitleID("https://feliratok.eu/index.php?action=letolt&felirat=abc123")
+	_, err := srv.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: fmt.Sprintf("%d", syntheticID)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected codes.InvalidArgument for synthetic ID, got %v", err)
+	}
+}
+
+func TestDownloadSubtitle_TypedDownloadErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name:     "zip bomb",
+			err:      &apperrors.ErrZipBombDetected{Reason: "suspicious compression ratio", Err: archive.NewUnrecoverableError("ZIP bomb detected", nil)},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "download too large",
+			err:      &apperrors.ErrDownloadTooLarge{Size: 2049, Limit: 2048},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "invalid archive",
+			err:      &apperrors.ErrInvalidArchive{Err: archive.NewUnrecoverableError("failed to open ZIP archive", errors.New("zip: not a valid zip file"))},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "upstream 5xx",
+			err:      &apperrors.ErrUpstreamStatus{Code: http.StatusServiceUnavailable},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "upstream 404",
+			err:      &apperrors.ErrUpstreamStatus{Code: http.StatusNotFound},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "upstream 4xx",
+			err:      &apperrors.ErrUpstreamStatus{Code: http.StatusForbidden},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &mockClient{
+				downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+					return nil, fmt.Errorf("failed to download subtitle: %w", tt.err)
+				},
+			}
+
+			_, err := NewServer(mock).DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "101"})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Expected %v, got %v (%v)", tt.wantCode, got, err)
+			}
+		})
+	}
+}
+
+// TestGetSubtitles_ShowNotFound tests that ErrNotFound results in a NotFound gRPC status
+func TestGetSubtitles_OrderBy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		orderBy pb.SubtitleOrder
+		want    models.SubtitleOrder
+	}{
+		{name: "unspecified defaults to newest first", orderBy: pb.SubtitleOrder_SUBTITLE_ORDER_UNSPECIFIED, want: models.SubtitleOrderUploadTimeDesc},
+		{name: "upload time desc", orderBy: pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_DESC, want: models.SubtitleOrderUploadTimeDesc},
+		{name: "upload time asc", orderBy: pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_ASC, want: models.SubtitleOrderUploadTimeAsc},
+		{name: "season episode", orderBy: pb.SubtitleOrder_SUBTITLE_ORDER_SEASON_EPISODE, want: models.SubtitleOrderSeasonEpisode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := &mockClient{subtitleOrder: models.SubtitleOrderListing}
+			srv := NewServer(mock).(*server)
+			if err := srv.GetSubtitles(&pb.GetSubtitlesRequest{ShowId: 1, OrderBy: tt.orderBy}, newMockServerStream[pb.Subtitle]()); err != nil {
+				t.Fatalf("GetSubtitles returned error: %v", err)
+			}
+			if mock.subtitleOrder != tt.want {
+				t.Errorf("Expected order %d, got %d", tt.want, mock.subtitleOrder)
+			}
+		})
+	}
+}
+
+func TestGetMovieSubtitles(t *testing.T) {
+	t.Parallel()
+	var gotMovieID int
+	mock := &mockClient{
+		streamMovieSubtitlesFunc: func(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle] {
+			gotMovieID = movieID
+			ch := make(chan models.StreamResult[models.Subtitle], 1)
+			ch <- models.StreamResult[models.Subtitle]{Value: models.Subtitle{
+				ID:          1709380001,
+				ShowName:    "Oppenheimer",
+				MovieTitle:  "Oppenheimer",
+				MovieYear:   2023,
+				Season:      -1,
+				Episode:     -1,
+				ContentType: models.ContentTypeFilm,
+			}}
+			close(ch)
+			return ch
+		},
+	}
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.Subtitle]()
+
+	if err := srv.GetMovieSubtitles(&pb.GetMovieSubtitlesRequest{MovieId: 7}, stream); err != nil {
+		t.Fatalf("GetMovieSubtitles returned error: %v", err)
+	}
+	if gotMovieID != 7 {
+		t.Errorf("Expected movie ID 7, got %d", gotMovieID)
+	}
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 subtitle, got %d", len(stream.items))
+	}
+	got := stream.items[0]
+	if got.ContentType != pb.ContentType_CONTENT_TYPE_FILM || got.MovieTitle != "Oppenheimer" || got.MovieYear != 2023 || got.Season != -1 || got.Episode != -1 {
+		t.Errorf("Expected the film Oppenheimer (2023), got %+v", got)
+	}
+
+	err := srv.GetMovieSubtitles(&pb.GetMovieSubtitlesRequest{}, newMockServerStream[pb.Subtitle]())
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a movie ID, got %v", err)
+	}
+}
+
+func TestGetMovieSubtitles_NotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamMovieSubtitlesFunc: func(ctx context.Context, movieID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle], 1)
+			ch <- models.StreamResult[models.Subtitle]{Err: apperrors.NewNotFoundError("movie", movieID)}
+			close(ch)
+			return ch
+		},
+	}
+	srv := NewServer(mock).(*server)
+
+	err := srv.GetMovieSubtitles(&pb.GetMovieSubtitlesRequest{MovieId: 999}, newMockServerStream[pb.Subtitle]())
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestGetSubtitles_ShowNotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle], 1)
+			ch <- models.StreamResult[models.Subtitle]{Err: apperrors.NewNotFoundError("show", showID)}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.Subtitle]()
+
+	err := srv.GetSubtitles(&pb.GetSubtitlesRequest{ShowId: 999}, stream)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+	}
+}
+func TestGetRecentSubtitles_Success(t *testing.T) {
+	t.Parallel()
+	mockShowSubtitles := []models.ShowSubtitles{
+		{
+			Show: models.Show{Name: "Breaking Bad", ID: 1, Year: 2008},
+			ThirdPartyIds: models.ThirdPartyIds{
+				IMDBID: "tt0903747",
+				TVDBID: 81189,
+			},
+			SubtitleCollection: models.SubtitleCollection{
+				ShowName: "Breaking Bad",
+				Total:    2,
+				Subtitles: []models.Subtitle{
+					{ID: 101, ShowID: 1, ShowName: "Breaking Bad", Language: "hun"},
+					{ID: 102, ShowID: 1, ShowName: "Breaking Bad", Language: "eng"},
+				},
+			},
+		},
+	}
+
+	mock := &mockClient{
+		getRecentSubtitlesFunc: func(ctx context.Context, sinceID int) ([]models.ShowSubtitles, error) {
+			if sinceID != 100 {
+				t.Errorf("Expected since ID 100, got %d", sinceID)
+			}
+			return mockShowSubtitles, nil
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+
+	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	if err != nil {
+		t.Fatalf("GetRecentSubtitles returned error: %v", err)
+	}
+
+	// Expect 1 item: 1 ShowSubtitlesCollection containing ShowInfo + 2 Subtitles
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	}
+
+	collection := stream.items[0]
+
+	// Verify ShowInfo
+	showInfo := collection.GetShowInfo()
+	if showInfo == nil {
+		t.Fatal("Expected collection to have ShowInfo")
+		return
+	}
+	if showInfo.Show.Name != "Breaking Bad" {
+		t.Errorf("Expected show name 'Breaking Bad', got '%s'", showInfo.Show.Name)
+	}
+	if showInfo.Show.Id != 1 {
+		t.Errorf("Expected show ID 1, got %d", showInfo.Show.Id)
+	}
+	if showInfo.ThirdPartyIds.ImdbId != "tt0903747" {
+		t.Errorf("Expected IMDB ID 'tt0903747', got '%s'", showInfo.ThirdPartyIds.ImdbId)
+	}
+	if showInfo.ThirdPartyIds.TvdbId != 81189 {
+		t.Errorf("Expected TVDB ID 81189, got %d", showInfo.ThirdPartyIds.TvdbId)
+	}
+
+	// Verify Subtitles
+	if len(collection.Subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(collection.Subtitles))
+	}
+	if collection.Subtitles[0].Id != 101 {
+		t.Errorf("Expected first subtitle ID 101, got %d", collection.Subtitles[0].Id)
+	}
+	if collection.Subtitles[0].ShowId != 1 {
+		t.Errorf("Expected show ID 1, got %d", collection.Subtitles[0].ShowId)
+	}
+	if collection.Subtitles[1].Id != 102 {
+		t.Errorf("Expected second subtitle ID 102, got %d", collection.Subtitles[1].Id)
+	}
+	if collection.Subtitles[1].Language != "eng" {
+		t.Errorf("Expected language 'eng', got '%s'", collection.Subtitles[1].Language)
+	}
+}
+
+// errorOnSendStream is a mock stream that always returns an error on Send
+type errorOnSendStream[T any] struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *errorOnSendStream[T]) Send(item *T) error {
+	return fmt.Errorf("send failed")
+}
+func (m *errorOnSendStream[T]) SetHeader(metadata.MD) error  { return nil }
+func (m *errorOnSendStream[T]) SendHeader(metadata.MD) error { return nil }
+func (m *errorOnSendStream[T]) SetTrailer(metadata.MD)       {}
+func (m *errorOnSendStream[T]) Context() context.Context     { return m.ctx }
+func (m *errorOnSendStream[T]) SendMsg(msg any) error        { return nil }
+func (m *errorOnSendStream[T]) RecvMsg(msg any) error        { return nil }
+
+// TestGetShowList_StreamSendError tests that a stream.Send error returns Internal status
+func TestGetShowList_StreamSendError(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getShowListFunc: func(ctx context.Context) ([]models.Show, error) {
+			return []models.Show{{Name: "Breaking Bad", ID: 1}}, nil
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := &errorOnSendStream[pb.Show]{ctx: context.Background()}
+
+	err := srv.GetShowList(&pb.GetShowListRequest{}, stream)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestGetShowList_PartialSuccess tests that errors after successful sends are logged and streaming continues
+func TestGetShowList_PartialSuccess(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamShowListFunc: func(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show] {
+			ch := make(chan models.StreamResult[models.Show], 3)
+			ch <- models.StreamResult[models.Show]{Value: models.Show{Name: "Breaking Bad", ID: 1}}
+			ch <- models.StreamResult[models.Show]{Err: errors.New("page 2 failed")}
+			ch <- models.StreamResult[models.Show]{Err: errors.New("page 3 failed: Három")}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.Show]()
+
+	err := srv.GetShowList(&pb.GetShowListRequest{}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error (partial success), got: %v", err)
+	}
+
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 show streamed, got %d", len(stream.items))
+	}
+	if stream.items[0].Name != "Breaking Bad" {
+		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].Name)
+	}
+	assertPartialErrorTrailer(t, stream.trailer, "2", "page 2 failed", "page 3 failed: H?rom")
+}
+
+// TestGetShowList_ResumesWithPageToken tests that following the returned cursors yields every show exactly once
+func TestGetShowList_ResumesWithPageToken(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getShowListFunc: func(ctx context.Context) ([]models.Show, error) {
+			return []models.Show{
+				{Name: "Dallas", ID: 5},
+				{Name: "Lost", ID: 2},
+				{Name: "Fargo", ID: 9},
+				{Name: "Dark", ID: 1},
+				{Name: "Severance", ID: 7},
+			}, nil
+		},
+	}
+	srv := NewServer(mock).(*server)
+
+	var gotIDs []int64
+	var tokens []string
+	token := ""
+	for range 5 {
+		stream := newMockServerStream[pb.Show]()
+		if err := srv.GetShowList(&pb.GetShowListRequest{PageToken: token, PageSize: 2}, stream); err != nil {
+			t.Fatalf("GetShowList returned error: %v", err)
+		}
+		for _, show := range stream.items {
+			gotIDs = append(gotIDs, show.Id)
+		}
+		next := stream.trailer.Get(nextPageTokenKey)
+		if len(next) == 0 {
+			break
+		}
+		token = next[0]
+		tokens = append(tokens, token)
+	}
+
+	if want := []int64{1, 2, 5, 7, 9}; !slices.Equal(gotIDs, want) {
+		t.Errorf("Expected shows %v across pages, got %v", want, gotIDs)
+	}
+	if want := []string{"2", "7"}; !slices.Equal(tokens, want) {
+		t.Errorf("Expected cursors %v, got %v", want, tokens)
+	}
+}
+
+// TestGetShowList_InvalidPaging tests that malformed cursors and negative page sizes are rejected
+func TestGetShowList_InvalidPaging(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{}).(*server)
+
+	for _, req := range []*pb.GetShowListRequest{
+		{PageToken: "abc"},
+		{PageToken: "-3"},
+		{PageSize: -1},
+	} {
+		err := srv.GetShowList(req, newMockServerStream[pb.Show]())
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+// TestListShows_PagesWithoutGapsOrOverlaps tests that paging through ListShows returns every show
+// exactly once, ordered by year then name
+func TestListShows_PagesWithoutGapsOrOverlaps(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getShowListFunc: func(ctx context.Context) ([]models.Show, error) {
+			return []models.Show{
+				{Name: "Severance", ID: 7, Year: 2022},
+				{Name: "Dallas", ID: 5, Year: 1978},
+				{Name: "Lost", ID: 2, Year: 2004},
+				{Name: "Fargo", ID: 9, Year: 2014},
+				{Name: "Dark", ID: 1, Year: 2017},
+				{Name: "Andor", ID: 4, Year: 2022},
+				{Name: "Dark", ID: 3, Year: 2017},
+			}, nil
+		},
+	}
+	srv := NewServer(mock).(*server)
+
+	var gotIDs []int64
+	token := ""
+	for range 10 {
+		resp, err := srv.ListShows(context.Background(), &pb.ListShowsRequest{PageToken: token, PageSize: 3})
+		if err != nil {
+			t.Fatalf("ListShows returned error: %v", err)
+		}
+		if resp.TotalSize != 7 {
+			t.Errorf("Expected total size 7, got %d", resp.TotalSize)
+		}
+		if len(resp.Shows) > 3 {
+			t.Errorf("Expected at most 3 shows per page, got %d", len(resp.Shows))
+		}
+		for _, show := range resp.Shows {
+			gotIDs = append(gotIDs, show.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+
+	if want := []int64{5, 2, 9, 1, 3, 4, 7}; !slices.Equal(gotIDs, want) {
+		t.Errorf("Expected shows %v across pages, got %v", want, gotIDs)
+	}
+}
+
+// TestListShows_InvalidPaging tests that malformed tokens and negative page sizes are rejected
+func TestListShows_InvalidPaging(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{}).(*server)
+
+	for _, req := range []*pb.ListShowsRequest{
+		{PageToken: "abc"},
+		{PageToken: "7"},
+		{PageToken: encodeListShowsPageToken(-3)},
+		{PageSize: -1},
+	} {
+		if _, err := srv.ListShows(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+// TestGetSubtitles_GenericError tests that a non-NotFound error returns Internal status
+func TestGetSubtitles_GenericError(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle], 1)
+			ch <- models.StreamResult[models.Subtitle]{Err: errors.New("database error")}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.Subtitle]()
+
+	err := srv.GetSubtitles(&pb.GetSubtitlesRequest{ShowId: 1}, stream)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestGetShowSubtitles_ErrorAfterPartialSuccess tests that errors after partial sends are logged and streaming continues
+func TestGetShowSubtitles_ErrorAfterPartialSuccess(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamShowSubtitlesFunc: func(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles] {
+			ch := make(chan models.StreamResult[models.ShowSubtitles], 2)
+			ch <- models.StreamResult[models.ShowSubtitles]{
+				Value: models.ShowSubtitles{
+					Show: models.Show{Name: "Breaking Bad", ID: 1},
+					SubtitleCollection: models.SubtitleCollection{
+						ShowName:  "Breaking Bad",
+						Subtitles: []models.Subtitle{{ID: 101, ShowID: 1}},
+					},
+				},
+			}
+			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("fetch failed for show 2")}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+
+	req := &pb.GetShowSubtitlesRequest{
+		Shows: []*pb.Show{
+			{Name: "Breaking Bad", Id: 1},
+			{Name: "Game of Thrones", Id: 2},
+		},
+	}
+
+	err := srv.GetShowSubtitles(req, stream)
+	if err != nil {
+		t.Fatalf("Expected no error (partial success), got: %v", err)
+	}
+
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	}
+	if stream.items[0].GetShowInfo().Show.Name != "Breaking Bad" {
+		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].GetShowInfo().Show.Name)
+	}
+	assertPartialErrorTrailer(t, stream.trailer, "1", "fetch failed for show 2")
+}
+
+// TestGetShowSubtitles_ShowTimeoutBeforeFirstResult tests that a slow show reported before any
+// result is recorded as a partial error instead of failing the stream
+func TestGetShowSubtitles_ShowTimeoutBeforeFirstResult(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamShowSubtitlesFunc: func(ctx context.Context, shows []models.Show, languages []string) <-chan models.StreamResult[models.ShowSubtitles] {
+			ch := make(chan models.StreamResult[models.ShowSubtitles], 2)
+			ch <- models.StreamResult[models.ShowSubtitles]{Err: &apperrors.ErrShowTimeout{ShowID: 2, Timeout: 30 * time.Second}}
+			ch <- models.StreamResult[models.ShowSubtitles]{
+				Value: models.ShowSubtitles{
+					Show:               models.Show{Name: "Breaking Bad", ID: 1},
+					SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad"},
+				},
+			}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+
+	req := &pb.GetShowSubtitlesRequest{
+		Shows: []*pb.Show{
+			{Name: "Breaking Bad", Id: 1},
+			{Name: "Game of Thrones", Id: 2},
+		},
+	}
+
+	if err := srv.GetShowSubtitles(req, stream); err != nil {
+		t.Fatalf("Expected no error (partial success), got: %v", err)
+	}
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	}
+	assertPartialErrorTrailer(t, stream.trailer, "1", "show 2 timed out after 30s")
+}
+
+// TestGetShowSubtitles_StreamSendError tests that a stream.Send error returns Internal status
+func TestGetShowSubtitles_StreamSendError(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getShowSubtitlesFunc: func(ctx context.Context, shows []models.Show) ([]models.ShowSubtitles, error) {
+			return []models.ShowSubtitles{
+				{
+					Show:               models.Show{Name: "Breaking Bad", ID: 1},
+					SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad"},
+				},
+			}, nil
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := &errorOnSendStream[pb.ShowSubtitlesCollection]{ctx: context.Background()}
+
+	req := &pb.GetShowSubtitlesRequest{
+		Shows: []*pb.Show{{Name: "Breaking Bad", Id: 1}},
+	}
+
+	err := srv.GetShowSubtitles(req, stream)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestCheckForUpdates_Error tests that an error returns Internal status
+func TestCheckForUpdates_Error(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		checkForUpdatesFunc: func(ctx context.Context, contentID int64, forceRefresh bool) (*models.UpdateCheckResult, error) {
+			return nil, errors.New("service unavailable")
+		},
+	}
+
+	srv := NewServer(mock)
+	ctx := context.Background()
+
+	_, err := srv.CheckForUpdates(ctx, &pb.CheckForUpdatesRequest{ContentId: 12345})
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestGetRecentSubtitles_ErrorAsFirstResult tests that an error as the first result returns Internal status
+func TestGetRecentSubtitles_ErrorAsFirstResult(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamRecentSubtitlesFunc: func(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles] {
+			ch := make(chan models.StreamResult[models.ShowSubtitles], 1)
+			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("connection refused")}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+
+	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestGetRecentSubtitles_StreamSendError tests that a stream.Send error returns Internal status
+func TestGetRecentSubtitles_StreamSendError(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getRecentSubtitlesFunc: func(ctx context.Context, sinceID int) ([]models.ShowSubtitles, error) {
+			return []models.ShowSubtitles{
+				{
+					Show:               models.Show{Name: "Breaking Bad", ID: 1},
+					SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad"},
+				},
+			}, nil
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := &errorOnSendStream[pb.ShowSubtitlesCollection]{ctx: context.Background()}
+
+	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	if err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
+	}
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	}
+}
+
+// TestGetRecentSubtitles_ErrorAfterPartialSuccess tests that errors after partial sends are logged and streaming continues
+func TestGetRecentSubtitles_ErrorAfterPartialSuccess(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamRecentSubtitlesFunc: func(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles] {
+			ch := make(chan models.StreamResult[models.ShowSubtitles], 2)
+			ch <- models.StreamResult[models.ShowSubtitles]{
+				Value: models.ShowSubtitles{
+					Show: models.Show{Name: "Breaking Bad", ID: 1},
+					SubtitleCollection: models.SubtitleCollection{
+						ShowName:  "Breaking Bad",
+						Subtitles: []models.Subtitle{{ID: 101, ShowID: 1}},
+					},
+				},
+			}
+			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("page 2 failed")}
+			close(ch)
+			return ch
+		},
+	}
+
+	srv := NewServer(mock).(*server)
+	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+
+	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error (partial success), got: %v", err)
+	}
+
+	if len(stream.items) != 1 {
+		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	}
+	if stream.items[0].GetShowInfo().Show.Name != "Breaking Bad" {
+		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].GetShowInfo().Show.Name)
+	}
+	assertPartialErrorTrailer(t, stream.trailer, "1", "page 2 failed")
 }
 
-// TestGetSubtitles_ShowNotFound tests that ErrNotFound results in a NotFound gRPC status
-func TestGetSubtitles_ShowNotFound(t *testing.T) {
+// TestDownloadSubtitle_GenericError tests that a non-specific error returns Internal status
+func TestDownloadSubtitle_GenericError(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
-			ch := make(chan models.StreamResult[models.Subtitle], 1)
-			ch <- models.StreamResult[models.Subtitle]{Err: apperrors.NewNotFoundError("show", showID)}
-			close(ch)
-			return ch
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			return nil, errors.New("unexpected server error")
 		},
 	}
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.Subtitle]()
+	srv := NewServer(mock)
+	ctx := context.Background()
 
-	err := srv.GetSubtitles(&pb.GetSubtitlesRequest{ShowId: 999}, stream)
+	req := &pb.DownloadSubtitleRequest{SubtitleId: "101"}
+
+	_, err := srv.DownloadSubtitle(ctx, req)
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -707,417 +2421,754 @@ func TestGetSubtitles_ShowNotFound(t *testing.T) {
 	if !ok {
 		t.Fatalf("Expected gRPC status error, got: %v", err)
 	}
-	if st.Code() != codes.NotFound {
-		t.Errorf("Expected codes.NotFound, got %v", st.Code())
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
 	}
 }
-func TestGetRecentSubtitles_Success(t *testing.T) {
+
+// TestInvalidateCache_Success tests that the subtitle ID is forwarded and the result returned
+func TestInvalidateCache_Success(t *testing.T) {
 	t.Parallel()
-	mockShowSubtitles := []models.ShowSubtitles{
-		{
-			Show: models.Show{Name: "Breaking Bad", ID: 1, Year: 2008},
-			ThirdPartyIds: models.ThirdPartyIds{
-				IMDBID: "tt0903747",
-				TVDBID: 81189,
-			},
-			SubtitleCollection: models.SubtitleCollection{
-				ShowName: "Breaking Bad",
-				Total:    2,
-				Subtitles: []models.Subtitle{
-					{ID: 101, ShowID: 1, ShowName: "Breaking Bad", Language: "hun"},
-					{ID: 102, ShowID: 1, ShowName: "Breaking Bad", Language: "eng"},
-				},
-			},
+	var gotID string
+	mock := &mockClient{
+		invalidateCacheFunc: func(subtitleID string) (bool, error) {
+			gotID = subtitleID
+			return true, nil
 		},
 	}
 
+	srv := NewServer(mock)
+	resp, err := srv.InvalidateCache(context.Background(), &pb.InvalidateCacheRequest{SubtitleId: "101"})
+	if err != nil {
+		t.Fatalf("InvalidateCache returned error: %v", err)
+	}
+	if gotID != "101" {
+		t.Errorf("Expected subtitle ID '101', got '%s'", gotID)
+	}
+	if !resp.Invalidated {
+		t.Error("Expected Invalidated to be true")
+	}
+}
+
+// TestInvalidateCache_MissingSubtitleID tests that an empty subtitle ID is rejected
+func TestInvalidateCache_MissingSubtitleID(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
+
+	_, err := srv.InvalidateCache(context.Background(), &pb.InvalidateCacheRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected codes.InvalidArgument, got %v", status.Code(err))
+	}
+}
+
+// TestClearCache_Success tests that the cleared entry count is returned
+func TestClearCache_Success(t *testing.T) {
+	t.Parallel()
 	mock := &mockClient{
-		getRecentSubtitlesFunc: func(ctx context.Context, sinceID int) ([]models.ShowSubtitles, error) {
-			if sinceID != 100 {
-				t.Errorf("Expected since ID 100, got %d", sinceID)
-			}
-			return mockShowSubtitles, nil
+		clearCacheFunc: func() int { return 7 },
+	}
+
+	srv := NewServer(mock)
+	resp, err := srv.ClearCache(context.Background(), &pb.ClearCacheRequest{})
+	if err != nil {
+		t.Fatalf("ClearCache returned error: %v", err)
+	}
+	if resp.EntriesCleared != 7 {
+		t.Errorf("Expected 7 entries cleared, got %d", resp.EntriesCleared)
+	}
+}
+
+// TestGetLatestSubtitleId_Success tests that the latest subtitle ID is returned
+func TestGetLatestSubtitleId_Success(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getLatestSubtitleFunc: func(ctx context.Context) (int, error) {
+			return 1770600010, nil
 		},
 	}
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+	srv := NewServer(mock)
 
-	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	resp, err := srv.GetLatestSubtitleId(context.Background(), &pb.GetLatestSubtitleIdRequest{})
 	if err != nil {
-		t.Fatalf("GetRecentSubtitles returned error: %v", err)
+		t.Fatalf("GetLatestSubtitleId returned error: %v", err)
 	}
+	if resp.SubtitleId != 1770600010 {
+		t.Errorf("Expected subtitle ID 1770600010, got %d", resp.SubtitleId)
+	}
+}
 
-	// Expect 1 item: 1 ShowSubtitlesCollection containing ShowInfo + 2 Subtitles
-	if len(stream.items) != 1 {
-		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+// TestGetLatestSubtitleId_Error tests that an upstream error returns Internal status
+func TestGetLatestSubtitleId_Error(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getLatestSubtitleFunc: func(ctx context.Context) (int, error) {
+			return 0, errors.New("recent subtitles returned status 502")
+		},
 	}
 
-	collection := stream.items[0]
+	srv := NewServer(mock)
 
-	// Verify ShowInfo
-	showInfo := collection.GetShowInfo()
-	if showInfo == nil {
-		t.Fatal("Expected collection to have ShowInfo")
-		return
+	_, err := srv.GetLatestSubtitleId(context.Background(), &pb.GetLatestSubtitleIdRequest{})
+	if err == nil {
+		t.Fatal("Expected error but got nil")
 	}
-	if showInfo.Show.Name != "Breaking Bad" {
-		t.Errorf("Expected show name 'Breaking Bad', got '%s'", showInfo.Show.Name)
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("Expected gRPC status error, got: %v", err)
 	}
-	if showInfo.Show.Id != 1 {
-		t.Errorf("Expected show ID 1, got %d", showInfo.Show.Id)
+	if st.Code() != codes.Internal {
+		t.Errorf("Expected codes.Internal, got %v", st.Code())
 	}
-	if showInfo.ThirdPartyIds.ImdbId != "tt0903747" {
-		t.Errorf("Expected IMDB ID 'tt0903747', got '%s'", showInfo.ThirdPartyIds.ImdbId)
+}
+
+// TestFindSubtitle_Success tests that matches are converted in the order the client returns them
+func TestFindSubtitle_Success(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		findSubtitleFunc: func(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error) {
+			if showID != 123 || season != 2 || episode != 5 || language != "hu" {
+				t.Errorf("Unexpected lookup (%d, %d, %d, %q)", showID, season, episode, language)
+			}
+			return []models.Subtitle{
+				{ID: 201, ShowID: 123, Season: 2, Episode: 5, Language: "hu"},
+				{ID: 150, ShowID: 123, Season: 2, Episode: -1, Language: "hu", IsSeasonPack: true},
+			}, nil
+		},
 	}
-	if showInfo.ThirdPartyIds.TvdbId != 81189 {
-		t.Errorf("Expected TVDB ID 81189, got %d", showInfo.ThirdPartyIds.TvdbId)
+
+	srv := NewServer(mock)
+
+	resp, err := srv.FindSubtitle(context.Background(), &pb.FindSubtitleRequest{ShowId: 123, Season: 2, Episode: 5, Language: "hu"})
+	if err != nil {
+		t.Fatalf("FindSubtitle returned error: %v", err)
+	}
+	if len(resp.Subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(resp.Subtitles))
+	}
+	if resp.Subtitles[0].Id != 201 || resp.Subtitles[1].Id != 150 {
+		t.Errorf("Expected subtitle IDs [201 150], got [%d %d]", resp.Subtitles[0].Id, resp.Subtitles[1].Id)
 	}
+	if !resp.Subtitles[1].IsSeasonPack {
+		t.Error("Expected second subtitle to be a season pack")
+	}
+}
 
-	// Verify Subtitles
-	if len(collection.Subtitles) != 2 {
-		t.Fatalf("Expected 2 subtitles, got %d", len(collection.Subtitles))
+// TestFindSubtitle_InvalidArgument tests that invalid lookups are rejected before reaching the client
+func TestFindSubtitle_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		findSubtitleFunc: func(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error) {
+			t.Error("Client should not be called for an invalid request")
+			return nil, nil
+		},
 	}
-	if collection.Subtitles[0].Id != 101 {
-		t.Errorf("Expected first subtitle ID 101, got %d", collection.Subtitles[0].Id)
+	srv := NewServer(mock)
+
+	for _, req := range []*pb.FindSubtitleRequest{
+		{ShowId: 0, Season: 1, Episode: 1},
+		{ShowId: 1, Season: -1, Episode: 1},
+		{ShowId: 1, Season: 1, Episode: -1},
+	} {
+		_, err := srv.FindSubtitle(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
 	}
-	if collection.Subtitles[0].ShowId != 1 {
-		t.Errorf("Expected show ID 1, got %d", collection.Subtitles[0].ShowId)
+}
+
+// TestFindSubtitle_NotFound tests that an unknown show maps to NotFound
+func TestFindSubtitle_NotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		findSubtitleFunc: func(ctx context.Context, showID, season, episode int, language string) ([]models.Subtitle, error) {
+			return nil, fmt.Errorf("failed to fetch subtitles for show %d: %w", showID, apperrors.NewNotFoundError("show", showID))
+		},
 	}
-	if collection.Subtitles[1].Id != 102 {
-		t.Errorf("Expected second subtitle ID 102, got %d", collection.Subtitles[1].Id)
+	srv := NewServer(mock)
+
+	_, err := srv.FindSubtitle(context.Background(), &pb.FindSubtitleRequest{ShowId: 999, Season: 1, Episode: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
 	}
-	if collection.Subtitles[1].Language != "eng" {
-		t.Errorf("Expected language 'eng', got '%s'", collection.Subtitles[1].Language)
+}
+
+// TestGetBestSubtitles_Success tests that one subtitle per episode is streamed in episode order
+func TestGetBestSubtitles_Success(t *testing.T) {
+	t.Parallel()
+	uploadTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock := &mockClient{
+		getSubtitlesFunc: func(ctx context.Context, showID int) (*models.SubtitleCollection, error) {
+			if showID != 1 {
+				t.Errorf("Expected showID 1, got %d", showID)
+			}
+			return &models.SubtitleCollection{Subtitles: []models.Subtitle{
+				{ID: 101, ShowID: 1, Language: "en", Season: 1, Episode: 1, Qualities: []models.Quality{models.Quality1080p}, UploadedAt: uploadTime},
+				{ID: 102, ShowID: 1, Language: "hu", Season: 1, Episode: 1, Qualities: []models.Quality{models.Quality720p}, UploadedAt: uploadTime},
+				{ID: 103, ShowID: 1, Language: "hu", Season: 1, Episode: 1, Qualities: []models.Quality{models.Quality1080p}, UploadedAt: uploadTime},
+				{ID: 104, ShowID: 1, Language: "hu", Season: 1, Episode: -1, IsSeasonPack: true, RangeStart: new(1), RangeEnd: new(2), UploadedAt: uploadTime},
+			}}, nil
+		},
+	}
+
+	srv := NewServer(mock)
+	stream := newMockServerStream[pb.Subtitle]()
+
+	err := srv.GetBestSubtitles(&pb.GetBestSubtitlesRequest{ShowId: 1, Language: "hu", PreferredQuality: pb.Quality_QUALITY_1080P}, stream)
+	if err != nil {
+		t.Fatalf("GetBestSubtitles returned error: %v", err)
+	}
+
+	if len(stream.items) != 2 {
+		t.Fatalf("Expected 2 subtitles streamed, got %d", len(stream.items))
+	}
+	if stream.items[0].Id != 103 || stream.items[0].Episode != 1 {
+		t.Errorf("Expected subtitle 103 for episode 1, got %d for episode %d", stream.items[0].Id, stream.items[0].Episode)
+	}
+	// The season pack stands in for episode 2 and is reported under that episode
+	if stream.items[1].Id != 104 || stream.items[1].Episode != 2 || !stream.items[1].IsSeasonPack {
+		t.Errorf("Expected season pack 104 for episode 2, got %d for episode %d", stream.items[1].Id, stream.items[1].Episode)
 	}
 }
 
-// errorOnSendStream is a mock stream that always returns an error on Send
-type errorOnSendStream[T any] struct {
-	grpc.ServerStream
-	ctx context.Context
+// TestGetBestSubtitles_InvalidArgument tests that a non-positive show ID is rejected
+func TestGetBestSubtitles_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			t.Error("Client should not be called for an invalid request")
+			ch := make(chan models.StreamResult[models.Subtitle])
+			close(ch)
+			return ch
+		},
+	}
+	srv := NewServer(mock)
+
+	err := srv.GetBestSubtitles(&pb.GetBestSubtitlesRequest{ShowId: 0}, newMockServerStream[pb.Subtitle]())
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
 }
 
-func (m *errorOnSendStream[T]) Send(item *T) error {
-	return fmt.Errorf("send failed")
+// TestGetBestSubtitles_ShowNotFound tests that an unknown show maps to NotFound without streaming
+func TestGetBestSubtitles_ShowNotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle], 2)
+			ch <- models.StreamResult[models.Subtitle]{Value: models.Subtitle{ID: 1, Season: 1, Episode: 1}}
+			ch <- models.StreamResult[models.Subtitle]{Err: apperrors.NewNotFoundError("show", showID)}
+			close(ch)
+			return ch
+		},
+	}
+	srv := NewServer(mock)
+	stream := newMockServerStream[pb.Subtitle]()
+
+	err := srv.GetBestSubtitles(&pb.GetBestSubtitlesRequest{ShowId: 999}, stream)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
+	}
+	if len(stream.items) != 0 {
+		t.Errorf("Expected no subtitles streamed, got %d", len(stream.items))
+	}
 }
-func (m *errorOnSendStream[T]) SetHeader(metadata.MD) error  { return nil }
-func (m *errorOnSendStream[T]) SendHeader(metadata.MD) error { return nil }
-func (m *errorOnSendStream[T]) SetTrailer(metadata.MD)       {}
-func (m *errorOnSendStream[T]) Context() context.Context     { return m.ctx }
-func (m *errorOnSendStream[T]) SendMsg(msg any) error        { return nil }
-func (m *errorOnSendStream[T]) RecvMsg(msg any) error        { return nil }
 
-// TestGetShowList_StreamSendError tests that a stream.Send error returns Internal status
-func TestGetShowList_StreamSendError(t *testing.T) {
+// TestGetShowLanguageStats_Success tests per-language aggregation of a show's subtitles
+func TestGetShowLanguageStats_Success(t *testing.T) {
 	t.Parallel()
+	uploadTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
 	mock := &mockClient{
-		getShowListFunc: func(ctx context.Context) ([]models.Show, error) {
-			return []models.Show{{Name: "Breaking Bad", ID: 1}}, nil
+		getSubtitlesFunc: func(ctx context.Context, showID int) (*models.SubtitleCollection, error) {
+			return &models.SubtitleCollection{Subtitles: []models.Subtitle{
+				{ID: 1, ShowID: showID, Language: "hu", Season: 2, Episode: 1, UploadedAt: uploadTime},
+				{ID: 2, ShowID: showID, Language: "hu", Season: 1, Episode: -1, IsSeasonPack: true},
+				{ID: 3, ShowID: showID, Language: "Klingon", Season: 1, Episode: 1},
+			}}, nil
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := &errorOnSendStream[pb.Show]{ctx: context.Background()}
+	resp, err := srv.GetShowLanguageStats(context.Background(), &pb.GetShowLanguageStatsRequest{ShowId: 7})
+	if err != nil {
+		t.Fatalf("GetShowLanguageStats returned error: %v", err)
+	}
 
-	err := srv.GetShowList(&pb.GetShowListRequest{}, stream)
-	if err == nil {
-		t.Fatal("Expected error but got nil")
+	if resp.ShowId != 7 {
+		t.Errorf("Expected show ID 7, got %d", resp.ShowId)
+	}
+	if len(resp.Languages) != 2 {
+		t.Fatalf("Expected 2 languages, got %d", len(resp.Languages))
+	}
+	hu := resp.Languages[0]
+	if hu.Language != "hu" || hu.SubtitleCount != 2 || hu.SeasonPackCount != 1 {
+		t.Errorf("Unexpected hu stats: %v", hu)
+	}
+	if !hu.NewestUploadedAt.AsTime().Equal(uploadTime) {
+		t.Errorf("Expected newest upload %v, got %v", uploadTime, hu.NewestUploadedAt.AsTime())
+	}
+	if len(hu.Seasons) != 2 || hu.Seasons[0] != 1 || hu.Seasons[1] != 2 {
+		t.Errorf("Expected seasons [1 2], got %v", hu.Seasons)
+	}
+	other := resp.Languages[1]
+	if other.Language != "other" || other.SubtitleCount != 1 || other.NewestUploadedAt != nil {
+		t.Errorf("Unexpected other stats: %v", other)
+	}
+}
+
+// TestGetShowLanguageStats_InvalidArgument tests that a non-positive show ID is rejected
+func TestGetShowLanguageStats_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
+
+	_, err := srv.GetShowLanguageStats(context.Background(), &pb.GetShowLanguageStatsRequest{ShowId: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
 	}
+}
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+// TestGetShowLanguageStats_ShowNotFound tests that an unknown show maps to NotFound
+func TestGetShowLanguageStats_ShowNotFound(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		getSubtitlesFunc: func(ctx context.Context, showID int) (*models.SubtitleCollection, error) {
+			return nil, apperrors.NewNotFoundError("show", showID)
+		},
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	srv := NewServer(mock)
+
+	_, err := srv.GetShowLanguageStats(context.Background(), &pb.GetShowLanguageStatsRequest{ShowId: 999})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
 	}
 }
 
-// TestGetShowList_PartialSuccess tests that errors after successful sends are logged and streaming continues
-func TestGetShowList_PartialSuccess(t *testing.T) {
+// TestGetShowSeasons_Success tests conversion of a show's season summary
+func TestGetShowSeasons_Success(t *testing.T) {
 	t.Parallel()
+	uploadTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
 	mock := &mockClient{
-		streamShowListFunc: func(ctx context.Context) <-chan models.StreamResult[models.Show] {
-			ch := make(chan models.StreamResult[models.Show], 2)
-			ch <- models.StreamResult[models.Show]{Value: models.Show{Name: "Breaking Bad", ID: 1}}
-			ch <- models.StreamResult[models.Show]{Err: errors.New("page 2 failed")}
-			close(ch)
-			return ch
+		getShowSeasonsFunc: func(ctx context.Context, showID int) (*models.ShowSeasons, error) {
+			if showID != 7 {
+				t.Errorf("Expected show ID 7, got %d", showID)
+			}
+			return &models.ShowSeasons{
+				Seasons: []models.SeasonSummary{
+					{Season: 1, EpisodeCount: 10, SeasonPackAvailable: true},
+					{Season: 2, EpisodeCount: 3, LatestUpload: uploadTime},
+				},
+				UnknownCount: 2,
+			}, nil
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.Show]()
-
-	err := srv.GetShowList(&pb.GetShowListRequest{}, stream)
+	resp, err := srv.GetShowSeasons(context.Background(), &pb.GetShowSeasonsRequest{ShowId: 7})
 	if err != nil {
-		t.Fatalf("Expected no error (partial success), got: %v", err)
+		t.Fatalf("GetShowSeasons returned error: %v", err)
 	}
 
-	if len(stream.items) != 1 {
-		t.Fatalf("Expected 1 show streamed, got %d", len(stream.items))
+	if resp.ShowId != 7 || resp.UnknownCount != 2 {
+		t.Errorf("Expected show ID 7 with 2 unknown subtitles, got %v", resp)
 	}
-	if stream.items[0].Name != "Breaking Bad" {
-		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].Name)
+	if len(resp.Seasons) != 2 {
+		t.Fatalf("Expected 2 seasons, got %d", len(resp.Seasons))
+	}
+	first := resp.Seasons[0]
+	if first.Season != 1 || first.EpisodeCount != 10 || !first.SeasonPackAvailable || first.LatestUpload != nil {
+		t.Errorf("Unexpected season 1 summary: %v", first)
+	}
+	second := resp.Seasons[1]
+	if second.Season != 2 || second.EpisodeCount != 3 || second.SeasonPackAvailable || !second.LatestUpload.AsTime().Equal(uploadTime) {
+		t.Errorf("Unexpected season 2 summary: %v", second)
 	}
 }
 
-// TestGetSubtitles_GenericError tests that a non-NotFound error returns Internal status
-func TestGetSubtitles_GenericError(t *testing.T) {
+// TestGetShowSeasons_InvalidArgument tests that a non-positive show ID is rejected
+func TestGetShowSeasons_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
+
+	_, err := srv.GetShowSeasons(context.Background(), &pb.GetShowSeasonsRequest{ShowId: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+// TestGetShowSeasons_ShowNotFound tests that an unknown show maps to NotFound
+func TestGetShowSeasons_ShowNotFound(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
-			ch := make(chan models.StreamResult[models.Subtitle], 1)
-			ch <- models.StreamResult[models.Subtitle]{Err: errors.New("database error")}
-			close(ch)
-			return ch
+		getShowSeasonsFunc: func(ctx context.Context, showID int) (*models.ShowSeasons, error) {
+			return nil, fmt.Errorf("failed to fetch subtitles for show %d: %w", showID, apperrors.NewNotFoundError("show", showID))
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.Subtitle]()
+	_, err := srv.GetShowSeasons(context.Background(), &pb.GetShowSeasonsRequest{ShowId: 999})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected codes.NotFound, got %v", err)
+	}
+}
 
-	err := srv.GetSubtitles(&pb.GetSubtitlesRequest{ShowId: 1}, stream)
-	if err == nil {
-		t.Fatal("Expected error but got nil")
+// TestGetLanguages tests that the language table is returned with one entry per ISO code
+func TestGetLanguages(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
+
+	resp, err := srv.GetLanguages(context.Background(), &pb.GetLanguagesRequest{})
+	if err != nil {
+		t.Fatalf("GetLanguages returned error: %v", err)
 	}
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	byCode := make(map[string]*pb.Language)
+	pt := 0
+	for _, language := range resp.Languages {
+		byCode[language.IsoCode] = language
+		if language.IsoCode == "pt" {
+			pt++
+		}
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	if hu := byCode["hu"]; hu == nil || hu.HungarianName != "Magyar" || hu.EnglishName != "Hungarian" {
+		t.Errorf("Expected hu as Magyar/Hungarian, got %v", hu)
+	}
+	if en := byCode["en"]; en == nil || en.HungarianName != "Angol" || en.EnglishName != "English" {
+		t.Errorf("Expected en as Angol/English, got %v", en)
+	}
+	if pt != 1 {
+		t.Errorf("Expected pt once despite several source names, got %d", pt)
 	}
 }
 
-// TestGetShowSubtitles_ErrorAfterPartialSuccess tests that errors after partial sends are logged and streaming continues
-func TestGetShowSubtitles_ErrorAfterPartialSuccess(t *testing.T) {
+// TestFindShow_Success tests that the name and year reach the client and the show is converted
+func TestFindShow_Success(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		streamShowSubtitlesFunc: func(ctx context.Context, shows []models.Show) <-chan models.StreamResult[models.ShowSubtitles] {
-			ch := make(chan models.StreamResult[models.ShowSubtitles], 2)
-			ch <- models.StreamResult[models.ShowSubtitles]{
-				Value: models.ShowSubtitles{
-					Show: models.Show{Name: "Breaking Bad", ID: 1},
-					SubtitleCollection: models.SubtitleCollection{
-						ShowName:  "Breaking Bad",
-						Subtitles: []models.Subtitle{{ID: 101, ShowID: 1}},
-					},
-				},
+		findShowFunc: func(ctx context.Context, name string, year *int) (*models.Show, error) {
+			if name != "Dallas" || year == nil || *year != 2012 {
+				t.Errorf("Expected Dallas from 2012, got %q %v", name, year)
 			}
-			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("fetch failed for show 2")}
-			close(ch)
-			return ch
+			return &models.Show{ID: 2012, Name: "Dallas", Year: 2012}, nil
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+	resp, err := srv.FindShow(context.Background(), &pb.FindShowRequest{Name: "Dallas", Year: proto.Int32(2012)})
+	if err != nil {
+		t.Fatalf("FindShow returned error: %v", err)
+	}
+	if resp.Id != 2012 || resp.Name != "Dallas" || resp.Year != 2012 {
+		t.Errorf("Unexpected show: %v", resp)
+	}
+}
 
-	req := &pb.GetShowSubtitlesRequest{
-		Shows: []*pb.Show{
-			{Name: "Breaking Bad", Id: 1},
-			{Name: "Game of Thrones", Id: 2},
+// TestFindShow_InvalidArgument tests that a blank name or a non-positive year is rejected
+func TestFindShow_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{
+		findShowFunc: func(ctx context.Context, name string, year *int) (*models.Show, error) {
+			t.Error("Expected the client not to be called")
+			return nil, nil
 		},
-	}
+	})
 
-	err := srv.GetShowSubtitles(req, stream)
-	if err != nil {
-		t.Fatalf("Expected no error (partial success), got: %v", err)
+	for _, req := range []*pb.FindShowRequest{{Name: "  "}, {Name: "Dallas", Year: proto.Int32(0)}} {
+		if _, err := srv.FindShow(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
 	}
+}
 
-	if len(stream.items) != 1 {
-		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+// TestFindShow_Errors tests that ambiguous and unknown names map to their status codes
+func TestFindShow_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name: "ambiguous",
+			err: &apperrors.ErrAmbiguousShow{Name: "Dallas", Candidates: []models.Show{
+				{ID: 1978, Name: "Dallas", Year: 1978},
+				{ID: 2012, Name: "Dallas", Year: 2012},
+			}},
+			wantCode: codes.InvalidArgument,
+		},
+		{name: "not found", err: &apperrors.ErrNotFound{Resource: `show "Knots Landing"`}, wantCode: codes.NotFound},
 	}
-	if stream.items[0].GetShowInfo().Show.Name != "Breaking Bad" {
-		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].GetShowInfo().Show.Name)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := NewServer(&mockClient{
+				findShowFunc: func(ctx context.Context, name string, year *int) (*models.Show, error) {
+					return nil, tt.err
+				},
+			})
+
+			_, err := srv.FindShow(context.Background(), &pb.FindShowRequest{Name: "Dallas"})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Expected %v, got %v", tt.wantCode, err)
+			}
+			if tt.wantCode == codes.InvalidArgument && !strings.Contains(status.Convert(err).Message(), "Dallas (1978, ID 1978)") {
+				t.Errorf("Expected the candidates in the message, got %q", status.Convert(err).Message())
+			}
+		})
 	}
 }
 
-// TestGetShowSubtitles_StreamSendError tests that a stream.Send error returns Internal status
-func TestGetShowSubtitles_StreamSendError(t *testing.T) {
+// TestGetSubtitleDetails_Success tests that the subtitle ID reaches the client and the details are converted
+func TestGetSubtitleDetails_Success(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		getShowSubtitlesFunc: func(ctx context.Context, shows []models.Show) ([]models.ShowSubtitles, error) {
-			return []models.ShowSubtitles{
-				{
-					Show:               models.Show{Name: "Breaking Bad", ID: 1},
-					SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad"},
-				},
+		getSubtitleDetailsFunc: func(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error) {
+			if subtitleID != 1737439811 {
+				t.Errorf("Expected subtitle ID 1737439811, got %d", subtitleID)
+			}
+			return &models.SubtitleDetails{
+				SubtitleID:    subtitleID,
+				Filename:      "outlander.s07e16.srt",
+				Uploader:      "kissoreg",
+				Comment:       "csak a WEB-DL-hez jó",
+				ThirdPartyIds: models.ThirdPartyIds{IMDBID: "tt3006802", TVDBID: 270408},
 			}, nil
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := &errorOnSendStream[pb.ShowSubtitlesCollection]{ctx: context.Background()}
-
-	req := &pb.GetShowSubtitlesRequest{
-		Shows: []*pb.Show{{Name: "Breaking Bad", Id: 1}},
+	resp, err := srv.GetSubtitleDetails(context.Background(), &pb.GetSubtitleDetailsRequest{SubtitleId: 1737439811})
+	if err != nil {
+		t.Fatalf("GetSubtitleDetails returned error: %v", err)
 	}
-
-	err := srv.GetShowSubtitles(req, stream)
-	if err == nil {
-		t.Fatal("Expected error but got nil")
+	if resp.SubtitleId != 1737439811 || resp.Filename != "outlander.s07e16.srt" || resp.Uploader != "kissoreg" {
+		t.Errorf("Unexpected subtitle details: %v", resp)
 	}
-
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	if resp.Comment != "csak a WEB-DL-hez jó" {
+		t.Errorf("Expected comment %q, got %q", "csak a WEB-DL-hez jó", resp.Comment)
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	if resp.GetThirdPartyIds().GetImdbId() != "tt3006802" || resp.GetThirdPartyIds().GetTvdbId() != 270408 {
+		t.Errorf("Unexpected third-party IDs: %v", resp.ThirdPartyIds)
 	}
 }
 
-// TestCheckForUpdates_Error tests that an error returns Internal status
-func TestCheckForUpdates_Error(t *testing.T) {
+// TestGetSubtitleDetails_Errors tests request validation and that unknown subtitles map to NotFound
+func TestGetSubtitleDetails_Errors(t *testing.T) {
 	t.Parallel()
-	mock := &mockClient{
-		checkForUpdatesFunc: func(ctx context.Context, contentID int64) (*models.UpdateCheckResult, error) {
-			return nil, errors.New("service unavailable")
+	srv := NewServer(&mockClient{
+		getSubtitleDetailsFunc: func(ctx context.Context, subtitleID int) (*models.SubtitleDetails, error) {
+			return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
 		},
-	}
-
-	srv := NewServer(mock)
-	ctx := context.Background()
-
-	_, err := srv.CheckForUpdates(ctx, &pb.CheckForUpdatesRequest{ContentId: 12345})
-	if err == nil {
-		t.Fatal("Expected error but got nil")
-	}
+	})
 
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	if _, err := srv.GetSubtitleDetails(context.Background(), &pb.GetSubtitleDetailsRequest{SubtitleId: 0}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for subtitle_id 0, got %v", err)
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	if _, err := srv.GetSubtitleDetails(context.Background(), &pb.GetSubtitleDetailsRequest{SubtitleId: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown subtitle, got %v", err)
 	}
 }
 
-// TestGetRecentSubtitles_ErrorAsFirstResult tests that an error as the first result returns Internal status
-func TestGetRecentSubtitles_ErrorAsFirstResult(t *testing.T) {
+// TestGetSubtitle_Success tests that both IDs reach the client and the subtitle is converted
+func TestGetSubtitle_Success(t *testing.T) {
 	t.Parallel()
 	mock := &mockClient{
-		streamRecentSubtitlesFunc: func(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles] {
-			ch := make(chan models.StreamResult[models.ShowSubtitles], 1)
-			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("connection refused")}
-			close(ch)
-			return ch
+		getSubtitleFunc: func(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error) {
+			if showID != 3217 || subtitleID != 1737439811 {
+				t.Errorf("Expected show 3217 and subtitle 1737439811, got %d and %d", showID, subtitleID)
+			}
+			return &models.Subtitle{
+				ID:        subtitleID,
+				ShowID:    showID,
+				ShowName:  "Outlander",
+				Language:  "hu",
+				Season:    7,
+				Episode:   16,
+				Uploader:  "kissoreg",
+				Qualities: []models.Quality{models.Quality1080p},
+			}, nil
 		},
 	}
+	srv := NewServer(mock)
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
-
-	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
-	if err == nil {
-		t.Fatal("Expected error but got nil")
+	resp, err := srv.GetSubtitle(context.Background(), &pb.GetSubtitleRequest{ShowId: 3217, SubtitleId: 1737439811})
+	if err != nil {
+		t.Fatalf("GetSubtitle returned error: %v", err)
 	}
-
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	if resp.Id != 1737439811 || resp.ShowId != 3217 || resp.Language != "hu" || resp.Uploader != "kissoreg" {
+		t.Errorf("Unexpected subtitle: %v", resp)
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	if resp.Season != 7 || resp.Episode != 16 {
+		t.Errorf("Expected season 7 episode 16, got %d and %d", resp.Season, resp.Episode)
 	}
 }
 
-// TestGetRecentSubtitles_StreamSendError tests that a stream.Send error returns Internal status
-func TestGetRecentSubtitles_StreamSendError(t *testing.T) {
+// TestGetSubtitle_Errors tests request validation and that unknown subtitles map to NotFound
+func TestGetSubtitle_Errors(t *testing.T) {
 	t.Parallel()
-	mock := &mockClient{
-		getRecentSubtitlesFunc: func(ctx context.Context, sinceID int) ([]models.ShowSubtitles, error) {
-			return []models.ShowSubtitles{
-				{
-					Show:               models.Show{Name: "Breaking Bad", ID: 1},
-					SubtitleCollection: models.SubtitleCollection{ShowName: "Breaking Bad"},
-				},
-			}, nil
+	srv := NewServer(&mockClient{
+		getSubtitleFunc: func(ctx context.Context, showID, subtitleID int) (*models.Subtitle, error) {
+			return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
 		},
+	})
+
+	for _, req := range []*pb.GetSubtitleRequest{{ShowId: 0, SubtitleId: 42}, {ShowId: 3217, SubtitleId: -1}} {
+		if _, err := srv.GetSubtitle(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+	if _, err := srv.GetSubtitle(context.Background(), &pb.GetSubtitleRequest{ShowId: 3217, SubtitleId: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown subtitle, got %v", err)
 	}
+}
 
-	srv := NewServer(mock).(*server)
-	stream := &errorOnSendStream[pb.ShowSubtitlesCollection]{ctx: context.Background()}
+// TestGetSubtitleById tests that the ID reaches the client, the subtitle is converted and
+// unknown or invalid IDs map to NotFound and InvalidArgument
+func TestGetSubtitleById(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{
+		getSubtitleInfoFunc: func(ctx context.Context, subtitleID int) (*models.Subtitle, error) {
+			if subtitleID != 1737439811 {
+				return nil, apperrors.NewNotFoundError("subtitle", subtitleID)
+			}
+			return &models.Subtitle{ID: subtitleID, ShowID: 3217, ShowName: "Outlander", Language: "hu", Season: 7, Episode: 16}, nil
+		},
+	})
 
-	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
-	if err == nil {
-		t.Fatal("Expected error but got nil")
+	resp, err := srv.GetSubtitleById(context.Background(), &pb.GetSubtitleByIdRequest{SubtitleId: 1737439811})
+	if err != nil {
+		t.Fatalf("GetSubtitleById returned error: %v", err)
 	}
-
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	if resp.Id != 1737439811 || resp.ShowId != 3217 || resp.ShowName != "Outlander" || resp.Season != 7 || resp.Episode != 16 {
+		t.Errorf("Unexpected subtitle: %v", resp)
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+	if _, err := srv.GetSubtitleById(context.Background(), &pb.GetSubtitleByIdRequest{SubtitleId: 0}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for subtitle_id 0, got %v", err)
+	}
+	if _, err := srv.GetSubtitleById(context.Background(), &pb.GetSubtitleByIdRequest{SubtitleId: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown subtitle, got %v", err)
 	}
 }
 
-// TestGetRecentSubtitles_ErrorAfterPartialSuccess tests that errors after partial sends are logged and streaming continues
-func TestGetRecentSubtitles_ErrorAfterPartialSuccess(t *testing.T) {
+// TestGetStatus tests that the client's mirror state is converted
+func TestGetStatus(t *testing.T) {
 	t.Parallel()
-	mock := &mockClient{
-		streamRecentSubtitlesFunc: func(ctx context.Context, sinceID int) <-chan models.StreamResult[models.ShowSubtitles] {
-			ch := make(chan models.StreamResult[models.ShowSubtitles], 2)
-			ch <- models.StreamResult[models.ShowSubtitles]{
-				Value: models.ShowSubtitles{
-					Show: models.Show{Name: "Breaking Bad", ID: 1},
-					SubtitleCollection: models.SubtitleCollection{
-						ShowName:  "Breaking Bad",
-						Subtitles: []models.Subtitle{{ID: 101, ShowID: 1}},
-					},
-				},
-			}
-			ch <- models.StreamResult[models.ShowSubtitles]{Err: errors.New("page 2 failed")}
-			close(ch)
-			return ch
-		},
+	activeSince := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv := NewServer(&mockClient{upstreamStatus: models.UpstreamStatus{
+		ActiveMirror: "https://mirror.example.com",
+		Mirrors:      []string{"https://feliratok.eu", "https://mirror.example.com"},
+		ActiveSince:  activeSince,
+	}})
+
+	resp, err := srv.GetStatus(context.Background(), &pb.GetStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
 	}
+	if resp.ActiveMirror != "https://mirror.example.com" {
+		t.Errorf("Expected the secondary as active mirror, got %q", resp.ActiveMirror)
+	}
+	if len(resp.Mirrors) != 2 || resp.Mirrors[0] != "https://feliratok.eu" {
+		t.Errorf("Expected mirrors in failover order, got %v", resp.Mirrors)
+	}
+	if !resp.ActiveSince.AsTime().Equal(activeSince) {
+		t.Errorf("Expected active_since %v, got %v", activeSince, resp.ActiveSince.AsTime())
+	}
+}
 
-	srv := NewServer(mock).(*server)
-	stream := newMockServerStream[pb.ShowSubtitlesCollection]()
+func TestGetServerInfo(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{})
 
-	err := srv.GetRecentSubtitles(&pb.GetRecentSubtitlesRequest{SinceId: 100}, stream)
+	resp, err := srv.GetServerInfo(context.Background(), &pb.GetServerInfoRequest{})
 	if err != nil {
-		t.Fatalf("Expected no error (partial success), got: %v", err)
+		t.Fatalf("GetServerInfo returned error: %v", err)
 	}
-
-	if len(stream.items) != 1 {
-		t.Fatalf("Expected 1 streamed item, got %d", len(stream.items))
+	if resp.Version != buildinfo.Version || resp.Commit != buildinfo.Commit || resp.BuildDate != buildinfo.Date {
+		t.Errorf("Expected the build information, got %q, %q and %q", resp.Version, resp.Commit, resp.BuildDate)
 	}
-	if stream.items[0].GetShowInfo().Show.Name != "Breaking Bad" {
-		t.Errorf("Expected show name 'Breaking Bad', got '%s'", stream.items[0].GetShowInfo().Show.Name)
+	if resp.ApiVersion != "v1" {
+		t.Errorf("Expected api_version v1, got %q", resp.ApiVersion)
+	}
+	limits := config.GetConfig().DownloadLimits()
+	if resp.Limits.GetMaxDownloadBytes() != limits.MaxDownloadSize || resp.Limits.GetMaxMessageBytes() != 4*1024*1024 {
+		t.Errorf("Expected the configured download limit and the default message limit, got %+v", resp.Limits)
 	}
 }
 
-// TestDownloadSubtitle_GenericError tests that a non-specific error returns Internal status
-func TestDownloadSubtitle_GenericError(t *testing.T) {
+func TestNewServerInfo_FeaturesFollowConfig(t *testing.T) {
 	t.Parallel()
-	mock := &mockClient{
-		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, episode *int) (*models.DownloadResult, error) {
-			return nil, errors.New("unexpected server error")
-		},
+	cfg := &config.Config{SuperSubtitleDomain: "https://feliratok.eu"}
+	cfg.Download.MaxDownloadSizeMB = 10
+	info := newServerInfo(cfg, false)
+	if slices.Contains(info.Features, config.FeatureRedisCache) || slices.Contains(info.Features, config.FeatureAuth) {
+		t.Errorf("Expected no redis_cache or auth feature by default, got %v", info.Features)
+	}
+	if info.Limits.MaxDownloadBytes != 10*1024*1024 {
+		t.Errorf("Expected the configured download limit, got %d", info.Limits.MaxDownloadBytes)
+	}
+
+	cfg.Cache.Type = "redis"
+	cfg.Auth.Tokens = []string{"secret"}
+	cfg.Server.HTTPPort = 8081
+	info = newServerInfo(cfg, true)
+	for _, feature := range []string{config.FeatureRedisCache, config.FeatureAuth, config.FeatureWebSocketBridge, config.FeatureConfigWatcher} {
+		if !slices.Contains(info.Features, feature) {
+			t.Errorf("Expected feature %q once enabled, got %v", feature, info.Features)
+		}
 	}
+}
 
-	srv := NewServer(mock)
-	ctx := context.Background()
-
-	req := &pb.DownloadSubtitleRequest{SubtitleId: "101"}
+func TestSelfCheck(t *testing.T) {
+	t.Parallel()
+	srv := NewServer(&mockClient{selfCheckResult: models.SelfCheckResult{
+		Details:       "parsed 0 subtitles from show 3217, none with a language and a valid ID",
+		ShowID:        3217,
+		SubtitleCount: 0,
+	}})
 
-	_, err := srv.DownloadSubtitle(ctx, req)
-	if err == nil {
-		t.Fatal("Expected error, got nil")
+	resp, err := srv.SelfCheck(context.Background(), &pb.SelfCheckRequest{})
+	if err != nil {
+		t.Fatalf("Expected a failed check to be reported without error, got: %v", err)
 	}
-
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Expected gRPC status error, got: %v", err)
+	if resp.Ok || resp.ShowId != 3217 || resp.Details == "" {
+		t.Errorf("Unexpected response: %v", resp)
 	}
-	if st.Code() != codes.Internal {
-		t.Errorf("Expected codes.Internal, got %v", st.Code())
+}
+
+func TestGetShowImage(t *testing.T) {
+	t.Parallel()
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0}
+	srv := NewServer(&mockClient{getShowImageFunc: func(ctx context.Context, showID int) (*models.ShowImage, error) {
+		switch showID {
+		case 3217:
+			return &models.ShowImage{Content: jpeg, ContentType: "image/jpeg"}, nil
+		case 404:
+			return nil, apperrors.NewNotFoundError("show image", showID)
+		default:
+			return nil, &apperrors.ErrNotAnImage{URL: "https://feliratok.eu/sorozat_cat.php?kep=1", ContentType: "text/html"}
+		}
+	}})
+
+	resp, err := srv.GetShowImage(context.Background(), &pb.GetShowImageRequest{ShowId: 3217})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(resp.Content, jpeg) || resp.ContentType != "image/jpeg" {
+		t.Errorf("Unexpected response: %v", resp)
+	}
+
+	for _, tt := range []struct {
+		showID int64
+		want   codes.Code
+	}{
+		{0, codes.InvalidArgument},
+		{404, codes.NotFound},
+		{1, codes.FailedPrecondition},
+	} {
+		_, err := srv.GetShowImage(context.Background(), &pb.GetShowImageRequest{ShowId: tt.showID})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("show %d: expected %v, got %v (%v)", tt.showID, tt.want, got, err)
+		}
 	}
 }
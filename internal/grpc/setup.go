@@ -1,26 +1,35 @@
 package grpc
 
 import (
+	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// serviceName is the health check name of the SuperSubtitles service.
+const serviceName = "supersubtitles.v1.SuperSubtitlesService"
+
 var (
 	grpcServerMetrics         *grpcprom.ServerMetrics
 	registerServerMetricsOnce sync.Once
 )
 
 // NewGRPCServer creates a fully configured gRPC server with Prometheus metrics,
-// health checking, and reflection.
-func NewGRPCServer(c client.Client) *grpc.Server {
+// OpenTelemetry tracing, health checking, and reflection. Additional options (for example
+// from ServerOptionsFromConfig) are applied after the metrics interceptors.
+func NewGRPCServer(c client.Client, opts ...grpc.ServerOption) *grpc.Server {
 	// Set up Prometheus gRPC server metrics once per process
 	registerServerMetricsOnce.Do(func() {
 		grpcServerMetrics = grpcprom.NewServerMetrics(
@@ -31,19 +40,25 @@ func NewGRPCServer(c client.Client) *grpc.Server {
 
 	srvMetrics := grpcServerMetrics
 
-	// Create a gRPC server with Prometheus interceptors
-	grpcServer := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(srvMetrics.UnaryServerInterceptor()),
-		grpc.ChainStreamInterceptor(srvMetrics.StreamServerInterceptor()),
-	)
+	// Create a gRPC server with Prometheus interceptors and a span per RPC, which uses the
+	// global tracer provider and is a no-op unless tracing is enabled. The metrics interceptors
+	// run before the options' authentication and deadline interceptors, so the codes those
+	// return are counted too
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(srvMetrics.UnaryServerInterceptor(), requestMetricsUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(srvMetrics.StreamServerInterceptor(), requestMetricsStreamInterceptor()),
+	}
+	serverOpts = append(serverOpts, opts...)
+	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Register the SuperSubtitles service
 	pb.RegisterSuperSubtitlesServiceServer(grpcServer, NewServer(c))
 
 	// Register health check service
 	healthServer := health.NewServer()
-	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
-	healthServer.SetServingStatus("supersubtitles.v1.SuperSubtitlesService", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(grpcServer, &upstreamHealthServer{Server: healthServer, client: c})
+	healthServer.SetServingStatus(serviceName, grpc_health_v1.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Register reflection service for tools like grpcurl
@@ -54,3 +69,80 @@ func NewGRPCServer(c client.Client) *grpc.Server {
 
 	return grpcServer
 }
+
+// Shutdown stops srv gracefully: new RPCs are rejected while in-flight ones, such as
+// archive extractions, run to completion. RPCs still running after timeout are cancelled
+// by a hard stop. It reports whether every in-flight RPC finished in time.
+func Shutdown(srv *grpc.Server, timeout time.Duration) bool {
+	drained := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return true
+	case <-timer.C:
+		srv.Stop()
+		<-drained
+		return false
+	}
+}
+
+// ServerOptionsFromConfig builds the transport security, authentication and default
+// deadline options described by the server and auth configuration sections.
+// It returns an error when TLS is partially configured, the PEM files cannot be loaded
+// or an RPC timeout is not a valid duration.
+func ServerOptionsFromConfig(cfg *config.Config) ([]grpc.ServerOption, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var opts []grpc.ServerOption
+
+	if files := newTLSFiles(cfg); files.enabled() {
+		creds, err := loadTLSCredentials(files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure gRPC TLS: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	if auth := newTokenAuthenticator(cfg.Auth.Tokens); auth != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor()),
+		)
+	}
+
+	deadlines, err := newRPCDeadlines(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure RPC timeouts: %w", err)
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(deadlines.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(deadlines.StreamServerInterceptor()),
+	)
+
+	return opts, nil
+}
+
+// upstreamHealthServer reports the SuperSubtitles service as NOT_SERVING while the client is
+// blocked by the site, so readiness probes take the instance out of rotation. The server as a
+// whole ("") stays SERVING, so liveness probes do not restart an instance that is only banned.
+type upstreamHealthServer struct {
+	*health.Server
+	client client.Client
+}
+
+// Check answers from the embedded server, except for the service while upstream is blocked.
+func (s *upstreamHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	resp, err := s.Server.Check(ctx, req)
+	if err != nil || req.GetService() != serviceName || !s.client.UpstreamStatus().Blocked {
+		return resp, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 func TestNewGRPCServer_ReturnsNonNil(t *testing.T) {
@@ -60,6 +66,44 @@ func TestNewGRPCServer_HealthCheck(t *testing.T) {
 	}
 }
 
+func TestNewGRPCServer_HealthCheckUpstreamBlocked(t *testing.T) {
+	t.Parallel()
+	srv := NewGRPCServer(&mockClient{upstreamStatus: models.UpstreamStatus{Blocked: true, BlockedReason: "captcha page"}})
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.GracefulStop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+
+	resp, err := healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: serviceName})
+	if err != nil {
+		t.Fatalf("Service-specific health check failed: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING status for the blocked service, got %v", resp.Status)
+	}
+
+	// The server itself stays up, so liveness probes do not restart it
+	resp, err = healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: ""})
+	if err != nil {
+		t.Fatalf("Health check failed: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING status, got %v", resp.Status)
+	}
+}
+
 func TestNewGRPCServer_ReflectionEnabled(t *testing.T) {
 	t.Parallel()
 	srv := NewGRPCServer(&mockClient{})
@@ -128,3 +172,107 @@ func TestNewGRPCServer_CalledMultipleTimes(t *testing.T) {
 		t.Fatal("Expected non-nil servers from multiple calls")
 	}
 }
+
+// startBlockingDownloadServer serves a mock client whose DownloadSubtitle blocks until release is
+// closed or the request context is cancelled. started receives once the handler is running.
+func startBlockingDownloadServer(t *testing.T) (srv *grpc.Server, addr string, started <-chan struct{}, release chan struct{}) {
+	t.Helper()
+	startedCh := make(chan struct{}, 1)
+	release = make(chan struct{})
+	srv = NewGRPCServer(&mockClient{
+		downloadSubtitleFunc: func(ctx context.Context, subtitleID string, opts models.DownloadOptions) (*models.DownloadResult, error) {
+			startedCh <- struct{}{}
+			select {
+			case <-release:
+				return &models.DownloadResult{Filename: "episode.srt", Content: []byte("subtitle"), ContentType: "application/x-subrip"}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	})
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	return srv, lis.Addr().String(), startedCh, release
+}
+
+func newConnection(t *testing.T, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestShutdown_DrainsInFlightRequests(t *testing.T) {
+	t.Parallel()
+	srv, addr, started, release := startBlockingDownloadServer(t)
+
+	type result struct {
+		resp *pb.DownloadSubtitleResponse
+		err  error
+	}
+	client := pb.NewSuperSubtitlesServiceClient(newConnection(t, addr))
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := client.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "1"})
+		inFlight <- result{resp, err}
+	}()
+	<-started
+
+	drained := make(chan bool, 1)
+	go func() { drained <- Shutdown(srv, 5*time.Second) }()
+
+	// Once shutdown has begun, new connections are refused
+	deadline := time.Now().Add(5 * time.Second)
+	var err error
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err = grpc_health_v1.NewHealthClient(newConnection(t, addr)).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		cancel()
+		if err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected new request after shutdown to fail with Unavailable, got: %v", err)
+	}
+
+	close(release)
+	got := <-inFlight
+	if got.err != nil {
+		t.Fatalf("Expected in-flight request to complete, got: %v", got.err)
+	}
+	if string(got.resp.Content) != "subtitle" {
+		t.Errorf("Expected in-flight content %q, got %q", "subtitle", got.resp.Content)
+	}
+	if !<-drained {
+		t.Error("Expected Shutdown to report a complete drain")
+	}
+}
+
+func TestShutdown_ForcesStopAfterTimeout(t *testing.T) {
+	t.Parallel()
+	srv, addr, started, _ := startBlockingDownloadServer(t)
+
+	client := pb.NewSuperSubtitlesServiceClient(newConnection(t, addr))
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := client.DownloadSubtitle(context.Background(), &pb.DownloadSubtitleRequest{SubtitleId: "1"})
+		inFlight <- err
+	}()
+	<-started
+
+	if Shutdown(srv, 50*time.Millisecond) {
+		t.Error("Expected Shutdown to report that the drain timed out")
+	}
+	if err := <-inFlight; err == nil {
+		t.Error("Expected the blocked request to be cancelled by the forced stop")
+	}
+}
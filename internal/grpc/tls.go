@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsFiles holds the file paths used to build server transport credentials.
+type tlsFiles struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// enabled reports whether any TLS setting is present. A client CA alone counts, so a server
+// meant to require client certificates fails to start instead of serving plaintext.
+func (c tlsFiles) enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.ClientCAFile != ""
+}
+
+// newTLSFiles reads the TLS file paths of the server.tls section.
+func newTLSFiles(cfg *config.Config) tlsFiles {
+	return tlsFiles{
+		CertFile:     cfg.Server.TLS.CertFile,
+		KeyFile:      cfg.Server.TLS.KeyFile,
+		ClientCAFile: cfg.Server.TLS.ClientCAFile,
+	}
+}
+
+// loadTLSCredentials builds gRPC transport credentials from PEM files.
+// When ClientCAFile is set, clients must present a certificate signed by that CA (mTLS).
+func loadTLSCredentials(cfg tlsFiles) (credentials.TransportCredentials, error) {
+	tlsConfig, err := loadTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// loadTLSConfig builds the server TLS configuration shared by the gRPC server and the
+// WebSocket bridge, as described by loadTLSCredentials.
+func loadTLSConfig(cfg tlsFiles) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("both server.tls.cert_file and server.tls.key_file must be set to enable TLS or require client certificates")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
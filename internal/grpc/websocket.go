@@ -0,0 +1,317 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/client"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// WebSocketPath is where the WebSocket bridge accepts connections.
+	WebSocketPath = "/ws"
+	// webSocketTokenParam carries the bearer token for browsers, which cannot set headers on
+	// a WebSocket handshake.
+	webSocketTokenParam = "access_token"
+	// webSocketWriteTimeout bounds each message write, so a stalled browser cannot hold a stream.
+	webSocketWriteTimeout = 10 * time.Second
+	// webSocketMaxRequestSize bounds a request message; requests are small JSON objects.
+	webSocketMaxRequestSize = 64 * 1024
+)
+
+// webSocketRequest is a JSON request sent over the bridge. ID is echoed in every response
+// message so a client can tell the answers of successive requests apart.
+type webSocketRequest struct {
+	ID     string          `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+
+	malformed bool // The message was not a JSON object
+}
+
+// webSocketParams holds the parameters of every bridged method; each method reads its own.
+type webSocketParams struct {
+	AfterID int `json:"after_id"`
+	ShowID  int `json:"show_id"`
+	SinceID int `json:"since_id"`
+}
+
+// webSocketResponse is one JSON message sent back for a request: a streamed result, an error,
+// or the final message with done set.
+type webSocketResponse struct {
+	ID      string          `json:"id,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *webSocketError `json:"error,omitempty"`
+	Partial bool            `json:"partial,omitempty"` // The error was skipped and the stream goes on
+	Done    bool            `json:"done,omitempty"`
+	Count   *int            `json:"count,omitempty"` // Results sent, on the done message
+}
+
+// webSocketError describes a failed request with its gRPC status code name.
+type webSocketError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// WebSocketBridge serves the streaming RPCs GetShowList, GetSubtitles and GetRecentSubtitles as
+// JSON over WebSocket for browsers, which cannot speak gRPC. It calls the client directly instead
+// of going through the gRPC server, and applies the same bearer tokens and stream timeout.
+type WebSocketBridge struct {
+	client        client.Client
+	auth          *tokenAuthenticator
+	streamTimeout time.Duration
+	upgrader      websocket.Upgrader
+	logger        zerolog.Logger
+
+	ctx    context.Context // Cancelled by Close to end every connection
+	cancel context.CancelFunc
+	conns  sync.WaitGroup
+}
+
+// NewWebSocketBridge creates a bridge to c configured from the auth and server sections of cfg.
+// It returns an error when server.stream_timeout is not a valid duration.
+func NewWebSocketBridge(c client.Client, cfg *config.Config) (*WebSocketBridge, error) {
+	streamTimeout, err := timeoutOrDefault("server.stream_timeout", cfg.Server.StreamTimeout, defaultStreamTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebSocketBridge{
+		client:        c,
+		auth:          newTokenAuthenticator(cfg.Auth.Tokens),
+		streamTimeout: streamTimeout,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: newOriginChecker(cfg.Server.AllowedOrigins),
+		},
+		logger: config.GetLogger(),
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// newOriginChecker returns the handshake's origin check. Without allowed origins only pages
+// served from the bridge's own host may connect; otherwise pages from the listed origins may
+// too, and "*" lets any page connect. Requests without an Origin header do not come from a
+// browser page and are always accepted.
+func newOriginChecker(allowed []string) func(*http.Request) bool {
+	origins := make(map[string]bool, len(allowed))
+	for _, origin := range allowed {
+		origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || origins["*"] || origins[strings.ToLower(origin)] {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// NewWebSocketHTTPServer creates an HTTP server that serves bridge at WebSocketPath on
+// server.address and server.http_port. When server.tls is set the server gets the same TLS
+// configuration as the gRPC server, client certificates included, and must be started with
+// ListenAndServeTLS("", ""). It returns an error when the TLS files cannot be loaded.
+func NewWebSocketHTTPServer(cfg *config.Config, bridge *WebSocketBridge) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketPath, bridge)
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.HTTPPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if files := newTLSFiles(cfg); files.enabled() {
+		tlsConfig, err := loadTLSConfig(files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure WebSocket bridge TLS: %w", err)
+		}
+		server.TLSConfig = tlsConfig
+	}
+	return server, nil
+}
+
+// ServeHTTP upgrades the request to a WebSocket and answers the requests read from it one at a
+// time. A client disconnecting cancels the request being streamed.
+func (b *WebSocketBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if b.auth != nil && !b.authorized(r) {
+		http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already answered the request
+		b.logger.Debug().Err(err).Msg("WebSocket upgrade failed")
+		return
+	}
+	b.conns.Add(1)
+	defer b.conns.Done()
+	defer conn.Close()
+	conn.SetReadLimit(webSocketMaxRequestSize)
+
+	// Hijacked connections outlive the request context, so the connection is bound to the
+	// bridge instead and closed by Close
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+
+	// Only this goroutine reads, so a closed connection is noticed while a stream is written
+	requests := make(chan webSocketRequest)
+	go func() {
+		defer cancel()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req webSocketRequest
+			if err := json.Unmarshal(data, &req); err != nil {
+				req = webSocketRequest{malformed: true}
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-requests:
+			if err := b.serveRequest(ctx, conn, req); err != nil {
+				b.logger.Debug().Err(err).Str("method", req.Method).Msg("WebSocket write failed, closing connection")
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close ends every open connection and waits for their handlers to return.
+func (b *WebSocketBridge) Close() {
+	b.cancel()
+	b.conns.Wait()
+}
+
+// authorized checks the bearer token sent in the Authorization header or, for browsers, the
+// access_token query parameter.
+func (b *WebSocketBridge) authorized(r *http.Request) bool {
+	token := r.URL.Query().Get(webSocketTokenParam)
+	if header := strings.TrimSpace(r.Header.Get("Authorization")); header != "" {
+		if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			return false
+		}
+		token = header[len(bearerPrefix):]
+	}
+	token = strings.TrimSpace(token)
+	return token != "" && b.auth.isValid([]byte(token))
+}
+
+// serveRequest streams the answer to req. It returns an error only when writing to conn fails.
+func (b *WebSocketBridge) serveRequest(ctx context.Context, conn *websocket.Conn, req webSocketRequest) error {
+	if req.malformed {
+		return b.write(conn, webSocketResponse{Error: &webSocketError{Code: codes.InvalidArgument.String(), Message: "request must be a JSON object with a method"}})
+	}
+	var params webSocketParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return b.write(conn, webSocketResponse{ID: req.ID, Error: &webSocketError{Code: codes.InvalidArgument.String(), Message: "params must be a JSON object"}})
+		}
+	}
+
+	var cancel context.CancelFunc
+	if b.streamTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, b.streamTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	b.logger.Debug().Str("id", req.ID).Str("method", req.Method).Msg("WebSocket request received")
+	switch req.Method {
+	case "GetShowList":
+		return bridgeStream(b, conn, req, cancel, true, b.client.StreamShowList(ctx, params.AfterID))
+	case "GetSubtitles":
+		if params.ShowID <= 0 {
+			return b.write(conn, webSocketResponse{ID: req.ID, Error: &webSocketError{Code: codes.InvalidArgument.String(), Message: "show_id must be positive"}})
+		}
+		return bridgeStream(b, conn, req, cancel, false, b.client.StreamSubtitlesInOrder(ctx, params.ShowID, models.SubtitleOrderUploadTimeDesc))
+	case "GetRecentSubtitles":
+		return bridgeStream(b, conn, req, cancel, true, b.client.StreamRecentSubtitles(ctx, params.SinceID))
+	default:
+		return b.write(conn, webSocketResponse{ID: req.ID, Error: &webSocketError{
+			Code:    codes.Unimplemented.String(),
+			Message: fmt.Sprintf("unknown method %q, expected GetShowList, GetSubtitles or GetRecentSubtitles", req.Method),
+		}})
+	}
+}
+
+// bridgeStream writes every result of stream as a message, then a done message. Like the gRPC
+// handlers, an error before the first result ends the stream; later ones are sent as partial
+// errors when partial is set, and end the stream otherwise. On return the stream is cancelled
+// and drained, so its producer never blocks.
+func bridgeStream[T any](b *WebSocketBridge, conn *websocket.Conn, req webSocketRequest, cancel context.CancelFunc, partial bool, stream <-chan models.StreamResult[T]) error {
+	defer func() {
+		cancel()
+		for range stream {
+		}
+	}()
+
+	count := 0
+	for result := range stream {
+		if result.Err != nil {
+			failure := webSocketResponse{ID: req.ID, Error: webSocketErrorFrom(result.Err)}
+			if count == 0 || !partial {
+				b.logger.Warn().Err(result.Err).Str("method", req.Method).Msg("WebSocket request failed")
+				return b.write(conn, failure)
+			}
+			failure.Partial = true
+			if err := b.write(conn, failure); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := b.write(conn, webSocketResponse{ID: req.ID, Result: result.Value}); err != nil {
+			return err
+		}
+		count++
+	}
+	return b.write(conn, webSocketResponse{ID: req.ID, Done: true, Count: &count})
+}
+
+// webSocketErrorFrom maps err to the status code the gRPC handlers would return for it.
+func webSocketErrorFrom(err error) *webSocketError {
+	code := codes.Internal
+	var bindable apperrors.GRPCBindableError
+	switch {
+	case errors.As(err, &bindable):
+		code = bindable.GRPCCode()
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return &webSocketError{Code: code.String(), Message: err.Error()}
+}
+
+// write sends resp as one JSON text message.
+func (b *WebSocketBridge) write(conn *websocket.Conn, resp webSocketResponse) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(resp)
+}
@@ -0,0 +1,364 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// startWebSocketBridge serves a bridge to mock over httptest and returns its ws:// URL.
+func startWebSocketBridge(t *testing.T, mock *mockClient, tokens ...string) string {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Auth.Tokens = tokens
+	bridge, err := NewWebSocketBridge(mock, cfg)
+	if err != nil {
+		t.Fatalf("NewWebSocketBridge returned error: %v", err)
+	}
+	server := httptest.NewServer(bridge)
+	t.Cleanup(func() {
+		server.Close()
+		bridge.Close()
+	})
+	return "ws" + strings.TrimPrefix(server.URL, "http") + WebSocketPath
+}
+
+// readWebSocketResponse reads one response message, failing the test after a few seconds.
+func readWebSocketResponse(t *testing.T, conn *websocket.Conn) map[string]any {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetReadDeadline returned error: %v", err)
+	}
+	var resp map[string]any
+	if err := conn.ReadJSON(&resp); err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	return resp
+}
+
+func TestWebSocketBridge_ListsShows(t *testing.T) {
+	t.Parallel()
+	mock := &mockClient{
+		streamShowListFunc: func(ctx context.Context, afterID int) <-chan models.StreamResult[models.Show] {
+			ch := make(chan models.StreamResult[models.Show], 3)
+			if afterID == 0 {
+				ch <- models.StreamResult[models.Show]{Value: models.Show{Name: "Breaking Bad", ID: 1}}
+				ch <- models.StreamResult[models.Show]{Value: models.Show{Name: "Dark", ID: 2}}
+			}
+			ch <- models.StreamResult[models.Show]{Err: errors.New("page 2 failed")}
+			close(ch)
+			return ch
+		},
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(startWebSocketBridge(t, mock), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]any{"id": "1", "method": "GetShowList"}); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+
+	var names []string
+	for range 2 {
+		resp := readWebSocketResponse(t, conn)
+		if resp["id"] != "1" {
+			t.Errorf("Expected id 1, got %v", resp["id"])
+		}
+		result, ok := resp["result"].(map[string]any)
+		if !ok {
+			t.Fatalf("Expected a show result, got %v", resp)
+		}
+		names = append(names, result["name"].(string))
+	}
+	if strings.Join(names, ",") != "Breaking Bad,Dark" {
+		t.Errorf("Expected Breaking Bad then Dark, got %v", names)
+	}
+
+	partial := readWebSocketResponse(t, conn)
+	if partial["partial"] != true || partial["error"].(map[string]any)["message"] != "page 2 failed" {
+		t.Errorf("Expected the page failure as a partial error, got %v", partial)
+	}
+	done := readWebSocketResponse(t, conn)
+	if done["done"] != true || done["count"] != float64(2) {
+		t.Errorf("Expected done with count 2, got %v", done)
+	}
+
+	// The connection serves further requests; a failure before any result ends the stream
+	if err := conn.WriteJSON(map[string]any{"id": "2", "method": "GetShowList", "params": map[string]any{"after_id": 2}}); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+	failure := readWebSocketResponse(t, conn)
+	if failure["id"] != "2" || failure["partial"] != nil || failure["error"].(map[string]any)["code"] != "Internal" {
+		t.Errorf("Expected an Internal error for request 2, got %v", failure)
+	}
+}
+
+func TestWebSocketBridge_RejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+	conn, _, err := websocket.DefaultDialer.Dial(startWebSocketBridge(t, &mockClient{}), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name    string
+		message string
+		code    string
+	}{
+		{name: "not json", message: "hello", code: "InvalidArgument"},
+		{name: "unknown method", message: `{"method":"DownloadSubtitle"}`, code: "Unimplemented"},
+		{name: "params not an object", message: `{"method":"GetSubtitles","params":[1]}`, code: "InvalidArgument"},
+		{name: "missing show id", message: `{"method":"GetSubtitles"}`, code: "InvalidArgument"},
+	}
+	for _, tt := range tests {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(tt.message)); err != nil {
+			t.Fatalf("%s: failed to write request: %v", tt.name, err)
+		}
+		resp := readWebSocketResponse(t, conn)
+		respErr, ok := resp["error"].(map[string]any)
+		if !ok || respErr["code"] != tt.code {
+			t.Errorf("%s: expected %s error, got %v", tt.name, tt.code, resp)
+		}
+	}
+}
+
+func TestWebSocketBridge_RequiresToken(t *testing.T) {
+	t.Parallel()
+	url := startWebSocketBridge(t, &mockClient{}, "secret")
+
+	tests := []struct {
+		name   string
+		url    string
+		header http.Header
+		wantOK bool
+	}{
+		{name: "no token", url: url},
+		{name: "wrong token", url: url + "?access_token=nope"},
+		{name: "query token", url: url + "?access_token=secret", wantOK: true},
+		{name: "header token", url: url, header: http.Header{"Authorization": {"Bearer secret"}}, wantOK: true},
+		{name: "header overrides query", url: url + "?access_token=secret", header: http.Header{"Authorization": {"Bearer nope"}}},
+	}
+	for _, tt := range tests {
+		conn, resp, err := websocket.DefaultDialer.Dial(tt.url, tt.header)
+		if tt.wantOK {
+			if err != nil {
+				t.Errorf("%s: expected connection, got: %v", tt.name, err)
+				continue
+			}
+			_ = conn.Close()
+			continue
+		}
+		if err == nil {
+			_ = conn.Close()
+			t.Errorf("%s: expected the handshake to be rejected", tt.name)
+			continue
+		}
+		if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %v", tt.name, resp)
+		}
+	}
+}
+
+func TestWebSocketBridge_DisconnectCancelsStream(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	mock := &mockClient{
+		streamSubtitlesFunc: func(ctx context.Context, showID int) <-chan models.StreamResult[models.Subtitle] {
+			ch := make(chan models.StreamResult[models.Subtitle])
+			go func() {
+				defer close(ch)
+				close(started)
+				<-ctx.Done()
+				close(cancelled)
+			}()
+			return ch
+		},
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(startWebSocketBridge(t, mock), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	if err := conn.WriteJSON(map[string]any{"method": "GetSubtitles", "params": map[string]any{"show_id": 7}}); err != nil {
+		t.Fatalf("Failed to write request: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the stream to start")
+	}
+	_ = conn.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the disconnect to cancel the stream context")
+	}
+}
+
+func TestWebSocketBridge_ChecksOrigin(t *testing.T) {
+	t.Parallel()
+	dial := func(allowed []string, origin string) *http.Response {
+		cfg := &config.Config{}
+		cfg.Server.AllowedOrigins = allowed
+		bridge, err := NewWebSocketBridge(&mockClient{}, cfg)
+		if err != nil {
+			t.Fatalf("NewWebSocketBridge returned error: %v", err)
+		}
+		server := httptest.NewServer(bridge)
+		defer func() {
+			server.Close()
+			bridge.Close()
+		}()
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", strings.ReplaceAll(origin, "{server}", server.URL))
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+WebSocketPath, header)
+		if err == nil {
+			_ = conn.Close()
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		wantOK  bool
+	}{
+		{name: "no origin header", wantOK: true},
+		{name: "same origin", origin: "{server}", wantOK: true},
+		{name: "cross origin by default", origin: "https://evil.example.com"},
+		{name: "listed origin", allowed: []string{"https://UI.example.com/"}, origin: "https://ui.example.com", wantOK: true},
+		{name: "unlisted origin", allowed: []string{"https://ui.example.com"}, origin: "https://evil.example.com"},
+		{name: "same origin with a list", allowed: []string{"https://ui.example.com"}, origin: "{server}", wantOK: true},
+		{name: "any origin", allowed: []string{"*"}, origin: "https://evil.example.com", wantOK: true},
+	}
+	for _, tt := range tests {
+		resp := dial(tt.allowed, tt.origin)
+		if got := resp != nil && resp.StatusCode == http.StatusSwitchingProtocols; got != tt.wantOK {
+			t.Errorf("%s: expected accepted=%v, got response %v", tt.name, tt.wantOK, resp)
+		}
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate for 127.0.0.1 and its key to dir and
+// returns their paths and a pool trusting the certificate.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string, pool *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	certFile, keyFile = filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+func TestNewWebSocketHTTPServer_UsesServerTLS(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{}
+	cfg.Server.Address = "127.0.0.1"
+	certFile, keyFile, pool := writeTestCertificate(t, t.TempDir())
+	cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile = certFile, keyFile
+
+	bridge, err := NewWebSocketBridge(&mockClient{}, cfg)
+	if err != nil {
+		t.Fatalf("NewWebSocketBridge returned error: %v", err)
+	}
+	defer bridge.Close()
+	server, err := NewWebSocketHTTPServer(cfg, bridge)
+	if err != nil {
+		t.Fatalf("NewWebSocketHTTPServer returned error: %v", err)
+	}
+	if server.TLSConfig == nil {
+		t.Fatal("Expected the bridge server to get the server TLS configuration")
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() { _ = server.ServeTLS(lis, "", "") }()
+	defer server.Close()
+
+	url := "wss://" + lis.Addr().String() + WebSocketPath
+	dialer := websocket.Dialer{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, HandshakeTimeout: 5 * time.Second}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Expected a TLS handshake with the server certificate, got: %v", err)
+	}
+	_ = conn.Close()
+
+	if conn, _, err := websocket.DefaultDialer.Dial("ws://"+lis.Addr().String()+WebSocketPath, nil); err == nil {
+		_ = conn.Close()
+		t.Error("Expected a plaintext connection to be refused")
+	}
+}
+
+func TestNewWebSocketHTTPServer_PartialTLS(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{}
+	cfg.Server.TLS.ClientCAFile = "clients.pem"
+	bridge, err := NewWebSocketBridge(&mockClient{}, cfg)
+	if err != nil {
+		t.Fatalf("NewWebSocketBridge returned error: %v", err)
+	}
+	defer bridge.Close()
+	if _, err := NewWebSocketHTTPServer(cfg, bridge); err == nil {
+		t.Error("Expected an error instead of a plaintext bridge when the key pair is missing")
+	}
+}
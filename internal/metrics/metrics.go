@@ -4,6 +4,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// Recovery labels of SubtitleDownloadInterruptionsTotal
+const (
+	DownloadRecoveryResume  = "resume"  // The rest was requested with a Range request
+	DownloadRecoveryRestart = "restart" // The download started over from the first byte
+	DownloadRecoveryFailed  = "failed"  // No attempt was left or the new request failed
+)
+
 // Subtitle download metrics
 var (
 	SubtitleDownloadsTotal = prometheus.NewCounterVec(
@@ -13,10 +20,158 @@ var (
 		},
 		[]string{"status"},
 	)
+
+	// SubtitleDownloadsCoalescedTotal counts downloads that joined an identical
+	// in-flight upstream request instead of issuing their own.
+	SubtitleDownloadsCoalescedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "subtitle_downloads_coalesced_total",
+			Help: "Total number of subtitle downloads served by an identical in-flight upstream request.",
+		},
+	)
+
+	// SubtitleDownloadsNotFoundCachedTotal counts downloads answered with a remembered
+	// upstream 404 instead of asking upstream again.
+	SubtitleDownloadsNotFoundCachedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "subtitle_downloads_not_found_cached_total",
+			Help: "Total number of subtitle downloads answered from the not-found cache.",
+		},
+	)
+
+	// SubtitleDownloadInterruptionsTotal counts downloads whose connection dropped mid-body,
+	// labelled by how the download went on: DownloadRecoveryResume, DownloadRecoveryRestart or
+	// DownloadRecoveryFailed.
+	SubtitleDownloadInterruptionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subtitle_download_interruptions_total",
+			Help: "Total number of subtitle downloads cut off mid-body, by how the download went on.",
+		},
+		[]string{"recovery"},
+	)
+
+	// SubtitleDownloadDurationSeconds observes the end-to-end time of a subtitle download,
+	// labelled by outcome (success/error), kind (extraction/file) and cache_hit (true/false).
+	SubtitleDownloadDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "subtitle_download_duration_seconds",
+			Help: "Duration of subtitle downloads in seconds.",
+			// 10ms to ~38s: cached plain files up to slow season pack downloads
+			Buckets: prometheus.ExponentialBuckets(0.01, 2.5, 10),
+		},
+		[]string{"outcome", "kind", "cache_hit"},
+	)
+
+	// SubtitleDownloadBytes observes the size of the file or archive a download worked on,
+	// with the same labels as SubtitleDownloadDurationSeconds.
+	SubtitleDownloadBytes = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "subtitle_download_bytes",
+			Help: "Size in bytes of the subtitle file or archive handled by a download.",
+			// 10KiB to 160MiB: single SRT files up to the largest season pack archives
+			Buckets: prometheus.ExponentialBuckets(10*1024, 4, 8),
+		},
+		[]string{"outcome", "kind", "cache_hit"},
+	)
+
+	// SubtitleExtractionDurationSeconds observes the time spent processing archives,
+	// labelled by step: sanitize (including ZIP bomb scanning), rar_conversion or extract.
+	SubtitleExtractionDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "subtitle_extraction_duration_seconds",
+			Help: "Duration of archive processing steps during subtitle downloads in seconds.",
+			// 1ms to ~16s
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+		},
+		[]string{"step"},
+	)
+)
+
+// Subtitle listing metrics
+var (
+	// SubtitleListingDuplicatesDroppedTotal counts listing rows dropped because their subtitle was
+	// already sent from an earlier page of the same stream, labelled by listing kind (show/movie).
+	SubtitleListingDuplicatesDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subtitle_listing_duplicates_dropped_total",
+			Help: "Total number of subtitle listing rows dropped as repeats of a row from an earlier page.",
+		},
+		[]string{"kind"},
+	)
+
+	// SubtitleListingSuspectedGapsTotal counts page boundaries where the listing moved in a way
+	// that may have skipped a subtitle, labelled by listing kind (show/movie).
+	SubtitleListingSuspectedGapsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subtitle_listing_suspected_gaps_total",
+			Help: "Total number of subtitle listing page boundaries where a subtitle may have been skipped.",
+		},
+		[]string{"kind"},
+	)
+)
+
+// gRPC request metrics
+var (
+	// GRPCRequestsTotal counts finished RPCs, labelled by method name (such as "GetShowList")
+	// and the status code returned to the client (such as "OK" or "NotFound").
+	GRPCRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of finished gRPC requests, by method and status code.",
+		},
+		[]string{"method", "code"},
+	)
+
+	// GRPCRequestDurationSeconds observes the time from an RPC's arrival until its handler
+	// returns, labelled by method name. Streaming RPCs are observed when the stream ends.
+	GRPCRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "grpc_request_duration_seconds",
+			Help: "Duration of gRPC requests in seconds, until the response or the end of the stream.",
+			// 5ms to ~44min: cached lookups up to streams reaching the 30 minute stream timeout
+			Buckets: prometheus.ExponentialBuckets(0.005, 3, 13),
+		},
+		[]string{"method"},
+	)
+)
+
+// gRPC streaming metrics
+var (
+	// GRPCStreamPartialErrorsTotal counts non-fatal errors skipped by streaming RPCs
+	// that still delivered results, labelled by RPC method.
+	GRPCStreamPartialErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_stream_partial_errors_total",
+			Help: "Total number of non-fatal errors skipped by streaming RPCs that returned partial results.",
+		},
+		[]string{"method"},
+	)
+)
+
+// BuildInfo is always 1, labelled by the version, commit and build date of the running server,
+// so dashboards can tell deployments apart and spot a rollout.
+var BuildInfo = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "build_info",
+		Help: "Build information of the running server, always 1.",
+	},
+	[]string{"version", "commit", "build_date"},
 )
 
 func init() {
 	prometheus.MustRegister(
+		BuildInfo,
 		SubtitleDownloadsTotal,
+		SubtitleDownloadsCoalescedTotal,
+		SubtitleDownloadsNotFoundCachedTotal,
+		SubtitleDownloadInterruptionsTotal,
+		SubtitleDownloadDurationSeconds,
+		SubtitleDownloadBytes,
+		SubtitleExtractionDurationSeconds,
+		SubtitleListingDuplicatesDroppedTotal,
+		SubtitleListingSuspectedGapsTotal,
+		GRPCRequestsTotal,
+		GRPCRequestDurationSeconds,
+		GRPCStreamPartialErrorsTotal,
 	)
 }
@@ -1,6 +1,12 @@
 package metrics
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -39,6 +45,88 @@ func TestMetrics_SubtitleDownloadsTotal_Error(t *testing.T) {
 	}
 }
 
+func TestMetrics_SubtitleDownloadsCoalescedTotal(t *testing.T) {
+	var before, after dto.Metric
+	if err := SubtitleDownloadsCoalescedTotal.Write(&before); err != nil {
+		t.Fatalf("Failed to read counter: %v", err)
+	}
+	SubtitleDownloadsCoalescedTotal.Inc()
+	if err := SubtitleDownloadsCoalescedTotal.Write(&after); err != nil {
+		t.Fatalf("Failed to read counter: %v", err)
+	}
+	if diff := after.GetCounter().GetValue() - before.GetCounter().GetValue(); diff != 1 {
+		t.Errorf("Expected coalesced counter to increment by 1, got diff %.0f", diff)
+	}
+}
+
+func TestMetrics_UpstreamStatusClass(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		resp     *http.Response
+		err      error
+		expected string
+	}{
+		{name: "ok", resp: &http.Response{StatusCode: http.StatusOK}, expected: "2xx"},
+		{name: "redirect", resp: &http.Response{StatusCode: http.StatusFound}, expected: "3xx"},
+		{name: "forbidden", resp: &http.Response{StatusCode: http.StatusForbidden}, expected: "4xx"},
+		{name: "server error", resp: &http.Response{StatusCode: http.StatusBadGateway}, expected: "5xx"},
+		{name: "canceled", err: fmt.Errorf("do request: %w", context.Canceled), expected: "canceled"},
+		{name: "transport error", err: errors.New("connection refused"), expected: "error"},
+		{name: "deadline", err: context.DeadlineExceeded, expected: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := upstreamStatusClass(tt.resp, tt.err); got != tt.expected {
+				t.Errorf("upstreamStatusClass() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetrics_RecordUpstreamRequest(t *testing.T) {
+	before := getCounterVecValue(UpstreamRequestsTotal, UpstreamEndpointShowList, "5xx")
+	RecordUpstreamRequest(UpstreamEndpointShowList, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	after := getCounterVecValue(UpstreamRequestsTotal, UpstreamEndpointShowList, "5xx")
+
+	if after != before+1 {
+		t.Errorf("Expected showlist 5xx counter to increment by 1, got diff %.0f", after-before)
+	}
+}
+
+func TestMetrics_UpstreamBody(t *testing.T) {
+	var before dto.Metric
+	histogram := UpstreamResponseBytes.WithLabelValues(UpstreamEndpointUpdates).(prometheus.Metric)
+	if err := histogram.Write(&before); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+
+	body := NewUpstreamBody(UpstreamEndpointUpdates, strings.NewReader("0123456789"))
+	if _, err := io.ReadAll(body); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	// Reads past the end must not observe the body again
+	if _, err := body.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("Expected io.EOF, got %v", err)
+	}
+	if body.Bytes() != 10 {
+		t.Errorf("Expected 10 bytes read, got %d", body.Bytes())
+	}
+
+	var after dto.Metric
+	if err := histogram.Write(&after); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+	if diff := after.GetHistogram().GetSampleCount() - before.GetHistogram().GetSampleCount(); diff != 1 {
+		t.Errorf("Expected one observation, got %d", diff)
+	}
+	if diff := after.GetHistogram().GetSampleSum() - before.GetHistogram().GetSampleSum(); diff != 10 {
+		t.Errorf("Expected 10 observed bytes, got %.0f", diff)
+	}
+}
+
 func TestMetrics_NewHTTPServer(t *testing.T) {
 	t.Parallel()
 	srv := NewHTTPServer("localhost", 9090)
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Endpoint kinds used as the endpoint label of UpstreamRequestsTotal and UpstreamResponseBytes.
+const (
+	UpstreamEndpointShowList  = "showlist"
+	UpstreamEndpointSubtitles = "subtitles"
+	UpstreamEndpointDetail    = "detail"
+	UpstreamEndpointUpdates   = "updates"
+	UpstreamEndpointDownload  = "download"
+	UpstreamEndpointImage     = "image"
+)
+
+// UpstreamRequestsTotal counts HTTP requests sent to feliratok.eu, labelled by endpoint kind
+// and by status class: 2xx/3xx/4xx/5xx, "canceled" when the caller's context was canceled,
+// or "error" for any other transport failure.
+var UpstreamRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "upstream_requests_total",
+		Help: "Total number of HTTP requests sent to the upstream site, by endpoint kind and status class.",
+	},
+	[]string{"endpoint", "status"},
+)
+
+// UpstreamResponseBytes observes the body size of successful upstream responses, labelled by
+// endpoint kind, to correlate slow parsing with large pages.
+var UpstreamResponseBytes = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "upstream_response_bytes",
+		Help: "Size in bytes of upstream response bodies, by endpoint kind.",
+		// 1KiB to 256MiB: small detail pages up to the largest season pack archives
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+	},
+	[]string{"endpoint"},
+)
+
+// UpstreamActiveMirror is 1 for the upstream mirror requests are currently sent to and 0 for
+// the other configured mirrors, labelled by the mirror's base URL.
+var UpstreamActiveMirror = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "upstream_active_mirror",
+		Help: "Whether the upstream mirror is the one requests are sent to (1) or not (0).",
+	},
+	[]string{"domain"},
+)
+
+// UpstreamBlocked is 1 while the site answers with block pages, such as a captcha or a login
+// page, instead of listings, and 0 otherwise.
+var UpstreamBlocked = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "upstream_blocked",
+		Help: "Whether the upstream site is serving block pages such as a captcha instead of content (1) or not (0).",
+	},
+)
+
+// UpstreamHedgedRequestsTotal counts show list page requests that were still unanswered after
+// client.hedge_delay and were sent a second time, labelled by the attempt whose response was
+// used: "primary" or "hedge". Requests where both attempts failed are labelled "none".
+var UpstreamHedgedRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "upstream_hedged_requests_total",
+		Help: "Total number of slow show list page requests sent a second time, by the attempt whose response was used.",
+	},
+	[]string{"winner"},
+)
+
+// UpstreamCompressionTotal counts upstream responses with a body by the Content-Encoding the
+// server chose: "gzip", "deflate", "br" or "zstd", "identity" when it sent the body
+// uncompressed despite the Accept-Encoding offer, and "other" for encodings left undecoded.
+var UpstreamCompressionTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "upstream_compression_total",
+		Help: "Total number of upstream responses by negotiated content encoding.",
+	},
+	[]string{"encoding"},
+)
+
+// UpstreamCompressedBytesTotal counts upstream response body bytes as received on the wire, and
+// UpstreamDecompressedBytesTotal the same bodies after decompression, both labelled by
+// Content-Encoding ("identity" for uncompressed bodies). Their ratio is the compression saving.
+var (
+	UpstreamCompressedBytesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upstream_compressed_bytes_total",
+			Help: "Total upstream response body bytes received on the wire, by content encoding.",
+		},
+		[]string{"encoding"},
+	)
+	UpstreamDecompressedBytesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upstream_decompressed_bytes_total",
+			Help: "Total upstream response body bytes after decompression, by content encoding.",
+		},
+		[]string{"encoding"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(UpstreamRequestsTotal, UpstreamResponseBytes, UpstreamActiveMirror, UpstreamBlocked, UpstreamHedgedRequestsTotal, UpstreamCompressionTotal, UpstreamCompressedBytesTotal, UpstreamDecompressedBytesTotal)
+}
+
+// UpstreamBody counts the bytes read from an upstream response body and observes the total in
+// UpstreamResponseBytes once the body has been read to the end. A body abandoned before its end
+// is not observed.
+type UpstreamBody struct {
+	body     io.Reader
+	endpoint string
+	n        int64
+	observed bool
+}
+
+// NewUpstreamBody wraps the body of an upstream response of the given endpoint kind.
+func NewUpstreamBody(endpoint string, body io.Reader) *UpstreamBody {
+	return &UpstreamBody{body: body, endpoint: endpoint}
+}
+
+// Read implements io.Reader.
+func (b *UpstreamBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.n += int64(n)
+	if errors.Is(err, io.EOF) && !b.observed {
+		b.observed = true
+		UpstreamResponseBytes.WithLabelValues(b.endpoint).Observe(float64(b.n))
+	}
+	return n, err
+}
+
+// Bytes returns the number of bytes read so far.
+func (b *UpstreamBody) Bytes() int64 {
+	return b.n
+}
+
+// RecordUpstreamRequest counts one upstream request from the results of http.Client.Do.
+func RecordUpstreamRequest(endpoint string, resp *http.Response, err error) {
+	UpstreamRequestsTotal.WithLabelValues(endpoint, upstreamStatusClass(resp, err)).Inc()
+}
+
+// upstreamStatusClass maps the results of http.Client.Do to a status label.
+func upstreamStatusClass(resp *http.Response, err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case err != nil || resp == nil:
+		return "error"
+	case resp.StatusCode < 200 || resp.StatusCode > 599:
+		return "error"
+	default:
+		return strconv.Itoa(resp.StatusCode/100) + "xx"
+	}
+}
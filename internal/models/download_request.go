@@ -1,8 +1,83 @@
 package models
 
+import (
+	"text/template"
+	"time"
+)
+
 // DownloadResult represents the result of a subtitle download
 type DownloadResult struct {
-	Filename    string // Name of the subtitle file
-	Content     []byte // Content of the subtitle file
-	ContentType string // MIME type (e.g., "application/x-subrip", "application/zip")
+	Filename       string // Name of the subtitle file
+	SourceFilename string // Name of an episode extracted from a season pack as found in the archive, without its folders
+	Content        []byte // Content of the subtitle file
+	ContentType    string // MIME type (e.g., "application/x-subrip", "application/zip")
+	Sha256         string // Lowercase hex SHA-256 of Content
+
+	// DetectedCharset is the encoding a text subtitle was read as before its conversion to UTF-8,
+	// such as "iso-8859-2", or "utf-8" when it already was UTF-8 and passed through unchanged.
+	// Archive entries are converted when the archive is sanitized, which records their encoding
+	// for extracted episodes; an episode range lists the encodings of its entries, comma-separated.
+	// It is empty for raw downloads and whole archives.
+	DetectedCharset string
+}
+
+// DownloadOptions selects what to return from a subtitle download.
+// With no episode selector set, the whole file is returned.
+type DownloadOptions struct {
+	Episode      *int   // Episode number to extract from a season pack
+	EpisodeTitle string // Episode title to extract from a season pack, used only when Episode is nil
+
+	// SourceEncoding names the encoding of a text subtitle, such as "windows-1250", used instead
+	// of charset detection. It applies to plain files, extracted episodes and unwrapped
+	// single-file archives, which are then read from the archive sanitized without conversion.
+	// Whole archives and episode ranges are converted with detection. Empty means detect.
+	SourceEncoding string
+
+	// MaxBytes caps the size of the returned file below the configured download limit. It also
+	// lowers the download and archive size limits, so an oversized file fails while it is read.
+	// Zero uses the configured limit; a larger value never raises it.
+	MaxBytes int64
+
+	// StripStyling rewrites an ASS or SSA file as plain dialogue with one default style,
+	// dropping override tags such as karaoke timing. Other formats and archives are unchanged.
+	StripStyling bool
+
+	// Raw returns text subtitles with the bytes they were uploaded with, skipping the conversion
+	// to UTF-8. Archives are still sanitized and episodes still extracted.
+	Raw bool
+
+	// KeepBOM keeps a byte order mark at the start of a text subtitle, which is removed by
+	// default because some players render it as a stray glyph.
+	KeepBOM bool
+
+	// ForceRefresh asks upstream even when it recently answered the download with 404,
+	// so a subtitle uploaded since then is found.
+	ForceRefresh bool
+
+	// Validate checks the structure of an SRT or WebVTT file after conversion and fails the
+	// download with ErrMalformedSubtitle when it is clearly broken. Other formats are not checked.
+	Validate bool
+
+	// TimeOffset shifts every cue of an SRT or WebVTT file by this duration, after the
+	// conversion to UTF-8; cues moved before zero start at zero. Zero leaves timings unchanged.
+	TimeOffset time.Duration
+
+	// FilenameTemplate names an episode extracted from a season pack, overriding the
+	// configured download.filename_template. Nil uses the configured template, if any.
+	FilenameTemplate *template.Template
+}
+
+// WantsEpisode reports whether a single episode should be extracted from a season pack.
+func (o DownloadOptions) WantsEpisode() bool {
+	return o.Episode != nil || o.EpisodeTitle != ""
+}
+
+// DownloadEstimate describes a subtitle download without its content, so callers can check
+// the size before pulling a large season pack.
+type DownloadEstimate struct {
+	Filename      string // Name of the file, from Content-Disposition or derived from the subtitle ID
+	ContentType   string // MIME type reported for the file
+	ContentLength int64  // Size in bytes; zero when LengthUnknown is set
+	LengthUnknown bool   // Upstream did not report a size
+	FromCache     bool   // Answered from the archive cache without an upstream request
 }
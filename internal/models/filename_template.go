@@ -0,0 +1,113 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// FilenameTemplateData holds the fields a filename template for extracted episodes can use, as
+// in "{{.ShowName}} - S{{printf "%02d" .Season}}E{{printf "%02d" .Episode}}.{{.Language}}{{.Extension}}".
+// Fields that cannot be read from the archive entry are empty, or 0 for numbers.
+type FilenameTemplateData struct {
+	ShowName       string // Show name before the episode marker, such as "Dark" from "Dark.S01E03/English.srt"
+	Season         int    // Season from the episode marker
+	Episode        int    // Episode requested, or read from the episode marker
+	Language       string // ISO 639-1 code of a language named in the entry path, such as "en" for "English.srt" or "Show.S01E03.en.srt"
+	SourceFilename string // Name of the entry inside the archive, without its folders
+	Extension      string // Extension of SourceFilename with its dot, such as ".srt"
+}
+
+// episodeMarkerRegex finds an episode marker such as "S01E03" or "1x03" and the text before it
+var episodeMarkerRegex = regexp.MustCompile(`(?i)^(.*?)[ ._-]*\b(?:s(\d{1,2})e(\d{1,3})|(\d{1,2})x(\d{2,3}))(?:\D|$)`)
+
+// NewFilenameTemplateData reads the template fields of the archive entry at entryPath. episode,
+// when positive, is the episode that was requested and wins over the one in the path.
+func NewFilenameTemplateData(entryPath string, episode int) FilenameTemplateData {
+	entryPath = strings.ReplaceAll(entryPath, "\\", "/")
+	source := path.Base(entryPath)
+	data := FilenameTemplateData{
+		SourceFilename: source,
+		Extension:      path.Ext(source),
+		Episode:        max(episode, 0),
+	}
+
+	// The marker can be on the file ("Dark.S01E03.srt") or on its folder ("Dark.S01E03/English.srt")
+	segments := strings.Split(strings.TrimSuffix(entryPath, data.Extension), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		matches := episodeMarkerRegex.FindStringSubmatch(segments[i])
+		if matches == nil {
+			continue
+		}
+		seasonText, episodeText := matches[2], matches[3]
+		if seasonText == "" {
+			seasonText, episodeText = matches[4], matches[5]
+		}
+		data.Season, _ = strconv.Atoi(seasonText)
+		if data.Episode == 0 {
+			data.Episode, _ = strconv.Atoi(episodeText)
+		}
+		data.ShowName = strings.TrimSpace(strings.NewReplacer(".", " ", "_", " ").Replace(matches[1]))
+		break
+	}
+
+	// A language name anywhere in the path, or an ISO code ending the file name as in "Show.S01E03.en.srt"
+	var words []string
+	for _, segment := range segments {
+		words = strings.FieldsFunc(segment, func(r rune) bool { return strings.ContainsRune(" ._-[]()", r) })
+		for _, word := range words {
+			if code, ok := LanguageISOCode(word); ok {
+				data.Language = code
+				return data
+			}
+		}
+	}
+	if len(words) > 0 {
+		last := strings.ToLower(words[len(words)-1])
+		for _, language := range Languages {
+			if language.ISOCode == last {
+				data.Language = last
+				break
+			}
+		}
+	}
+	return data
+}
+
+// ParseFilenameTemplate parses a Go text/template for the filenames of extracted episodes. The
+// template is rendered once with sample data, so a template that names an unknown field fails
+// here instead of on a download.
+func ParseFilenameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("filename").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := FilenameTemplateData{ShowName: "Show", Season: 1, Episode: 1, Language: "en", SourceFilename: "Show.S01E01.srt", Extension: ".srt"}
+	if _, err := RenderFilename(tmpl, sample); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// RenderFilename renders tmpl with data into a filename. Path separators in the result are
+// replaced, so a show name cannot place the file in another folder; a result that is empty or
+// only dots is an error.
+func RenderFilename(tmpl *template.Template, data FilenameTemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	filename := strings.TrimSpace(strings.NewReplacer("/", "_", "\\", "_").Replace(buf.String()))
+	if strings.Trim(filename, ".") == "" {
+		return "", errors.New("filename template rendered an empty filename")
+	}
+	if strings.ContainsAny(filename, "\x00\n\r") {
+		return "", fmt.Errorf("filename template rendered %q, which contains control characters", filename)
+	}
+	return filename, nil
+}
@@ -0,0 +1,69 @@
+// Tests for filename_template.go — reading template fields from archive entry paths and rendering filenames.
+package models
+
+import "testing"
+
+func TestNewFilenameTemplateData(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		entryPath string
+		episode   int
+		want      FilenameTemplateData
+	}{
+		{
+			entryPath: "Dark.S01E03/English.srt",
+			episode:   3,
+			want:      FilenameTemplateData{ShowName: "Dark", Season: 1, Episode: 3, Language: "en", SourceFilename: "English.srt", Extension: ".srt"},
+		},
+		{
+			entryPath: "Pack\\The.Last.of.Us.S02E07.1080p.WEB.hu.ass",
+			want:      FilenameTemplateData{ShowName: "The Last of Us", Season: 2, Episode: 7, Language: "hu", SourceFilename: "The.Last.of.Us.S02E07.1080p.WEB.hu.ass", Extension: ".ass"},
+		},
+		{
+			entryPath: "Show/1x07/subtitle.srt",
+			want:      FilenameTemplateData{ShowName: "", Season: 1, Episode: 7, SourceFilename: "subtitle.srt", Extension: ".srt"},
+		},
+		{
+			entryPath: "episode.srt",
+			episode:   4,
+			want:      FilenameTemplateData{Episode: 4, SourceFilename: "episode.srt", Extension: ".srt"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := NewFilenameTemplateData(tt.entryPath, tt.episode); got != tt.want {
+			t.Errorf("NewFilenameTemplateData(%q, %d) = %+v, want %+v", tt.entryPath, tt.episode, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilenameTemplate(t *testing.T) {
+	t.Parallel()
+	tmpl, err := ParseFilenameTemplate(`{{.ShowName}} - S{{printf "%02d" .Season}}E{{printf "%02d" .Episode}}.{{.Language}}{{.Extension}}`)
+	if err != nil {
+		t.Fatalf("ParseFilenameTemplate failed: %v", err)
+	}
+	got, err := RenderFilename(tmpl, NewFilenameTemplateData("Dark.S01E03/English.srt", 3))
+	if err != nil || got != "Dark - S01E03.en.srt" {
+		t.Errorf("RenderFilename = %q, %v, want %q", got, err, "Dark - S01E03.en.srt")
+	}
+
+	// Unparsable, an unknown field, and an always empty filename
+	for _, text := range []string{"{{.ShowName", "{{.Title}}.srt", "{{if .ShowName}}{{end}}"} {
+		if _, err := ParseFilenameTemplate(text); err == nil {
+			t.Errorf("ParseFilenameTemplate(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestRenderFilename_ReplacesPathSeparators(t *testing.T) {
+	t.Parallel()
+	tmpl, err := ParseFilenameTemplate("{{.ShowName}}{{.Extension}}")
+	if err != nil {
+		t.Fatalf("ParseFilenameTemplate failed: %v", err)
+	}
+	got, err := RenderFilename(tmpl, FilenameTemplateData{ShowName: "../AC/DC", Extension: ".srt"})
+	if err != nil || got != ".._AC_DC.srt" {
+		t.Errorf("RenderFilename = %q, %v, want %q", got, err, ".._AC_DC.srt")
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// hearingImpairedTokenRegex matches an "SDH" or "CC" marker in any case, or an upper-case "HI",
+// standing as its own token such as "en[cc]", "(SDH)" or ".HI.". "HI" must be upper case so
+// ordinary words and the Hindi language code "hi" are not mistaken for it.
+var hearingImpairedTokenRegex = regexp.MustCompile(`(?:^|[^\pL\pN])(?:(?i:sdh|cc)|HI)(?:[^\pL\pN]|$)`)
+
+// hearingImpairedPhrase is the stem of "hallássérülteknek" ("for the hearing impaired")
+// used in Hungarian descriptions.
+const hearingImpairedPhrase = "hallássérült"
+
+// IsHearingImpaired reports whether a subtitle description or filename marks the subtitle as
+// made for the hearing impaired (SDH/CC), meaning it also describes sounds and speakers.
+func IsHearingImpaired(text string) bool {
+	return hearingImpairedTokenRegex.MatchString(text) ||
+		strings.Contains(strings.ToLower(text), hearingImpairedPhrase)
+}
@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestIsHearingImpaired(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Pokémon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en[cc].srt", true},
+		{"Show.S01E01.1080p.WEB.SDH.srt", true},
+		{"Show - 1x01 (SDH, 1080p-RelGroup)", true},
+		{"Show.S01E01.HI.srt", true},
+		{"Show - 1x01 - feliratozás hallássérülteknek", true},
+		{"Show - 1x01 - Hallássérült felirat", true},
+		{"Show.S01E01.1080p.WEB.srt", false},
+		{"Hit.and.Run.S01E01.srt", false},
+		{"Show.S01E01.hi.srt", false},
+		{"Accident.S01E01.srt", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsHearingImpaired(tt.text); got != tt.want {
+			t.Errorf("IsHearingImpaired(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
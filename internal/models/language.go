@@ -0,0 +1,70 @@
+package models
+
+import "strings"
+
+// Language describes a subtitle language known to feliratok.eu
+type Language struct {
+	ISOCode       string   // ISO 639-1 code
+	HungarianName string   // Name shown in the site's language column
+	EnglishName   string   // English name, also accepted when parsing
+	OtherNames    []string // Further site names that map to the same code
+}
+
+// Languages is the canonical table of languages found on feliratok.eu, ordered by ISO code.
+// Each ISO code appears once; names that share a code are listed in OtherNames.
+var Languages = []Language{
+	{ISOCode: "ar", HungarianName: "Arab", EnglishName: "Arabic"},
+	{ISOCode: "bg", HungarianName: "Bolgár", EnglishName: "Bulgarian"},
+	{ISOCode: "cs", HungarianName: "Cseh", EnglishName: "Czech"},
+	{ISOCode: "da", HungarianName: "Dán", EnglishName: "Danish"},
+	{ISOCode: "de", HungarianName: "Német", EnglishName: "German"},
+	{ISOCode: "el", HungarianName: "Görög", EnglishName: "Greek"},
+	{ISOCode: "en", HungarianName: "Angol", EnglishName: "English"},
+	{ISOCode: "es", HungarianName: "Spanyol", EnglishName: "Spanish"},
+	{ISOCode: "fa", HungarianName: "Perzsa", EnglishName: "Persian"},
+	{ISOCode: "fi", HungarianName: "Finn", EnglishName: "Finnish"},
+	{ISOCode: "fr", HungarianName: "Francia", EnglishName: "French"},
+	{ISOCode: "he", HungarianName: "Héber", EnglishName: "Hebrew"},
+	{ISOCode: "hi", HungarianName: "Hindi", EnglishName: "Hindi"},
+	{ISOCode: "hr", HungarianName: "Horvát", EnglishName: "Croatian"},
+	{ISOCode: "hu", HungarianName: "Magyar", EnglishName: "Hungarian"},
+	{ISOCode: "id", HungarianName: "Indonéz", EnglishName: "Indonesian"},
+	{ISOCode: "it", HungarianName: "Olasz", EnglishName: "Italian"},
+	{ISOCode: "ja", HungarianName: "Japán", EnglishName: "Japanese"},
+	{ISOCode: "ko", HungarianName: "Koreai", EnglishName: "Korean"},
+	{ISOCode: "nl", HungarianName: "Holland", EnglishName: "Dutch"},
+	{ISOCode: "no", HungarianName: "Norvég", EnglishName: "Norwegian"},
+	{ISOCode: "pl", HungarianName: "Lengyel", EnglishName: "Polish"},
+	{ISOCode: "pt", HungarianName: "Portugál", EnglishName: "Portuguese", OtherNames: []string{"Brazil"}}, // Brazilian Portuguese maps to pt
+	{ISOCode: "ro", HungarianName: "Román", EnglishName: "Romanian"},
+	{ISOCode: "ru", HungarianName: "Orosz", EnglishName: "Russian"},
+	{ISOCode: "sr", HungarianName: "Szerb", EnglishName: "Serbian"},
+	{ISOCode: "sv", HungarianName: "Svéd", EnglishName: "Swedish"},
+	{ISOCode: "th", HungarianName: "Thai", EnglishName: "Thai"},
+	{ISOCode: "tr", HungarianName: "Török", EnglishName: "Turkish"},
+	{ISOCode: "uk", HungarianName: "Ukrán", EnglishName: "Ukrainian"},
+	{ISOCode: "vi", HungarianName: "Vietnámi", EnglishName: "Vietnamese"},
+	{ISOCode: "zh", HungarianName: "Kínai", EnglishName: "Chinese"},
+}
+
+// languageNameToISO maps every lowercased name in Languages to its ISO code
+var languageNameToISO = buildLanguageNameIndex()
+
+func buildLanguageNameIndex() map[string]string {
+	index := make(map[string]string)
+	for _, language := range Languages {
+		index[strings.ToLower(language.HungarianName)] = language.ISOCode
+		index[strings.ToLower(language.EnglishName)] = language.ISOCode
+		for _, name := range language.OtherNames {
+			index[strings.ToLower(name)] = language.ISOCode
+		}
+	}
+	return index
+}
+
+// LanguageISOCode returns the ISO 639-1 code for a Hungarian or English language name,
+// compared case-insensitively after trimming whitespace
+func LanguageISOCode(name string) (string, bool) {
+	isoCode, ok := languageNameToISO[strings.ToLower(strings.TrimSpace(name))]
+	return isoCode, ok
+}
@@ -0,0 +1,62 @@
+// Tests for language.go — the canonical language table and LanguageISOCode lookups.
+package models
+
+import "testing"
+
+func TestLanguageISOCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Magyar", want: "hu"},
+		{name: "angol", want: "en"},
+		{name: "  Német ", want: "de"},
+		{name: "PORTUGUESE", want: "pt"},
+		{name: "Brazil", want: "pt"},
+		{name: "Perzsa", want: "fa"},
+		{name: "Persian", want: "fa"},
+	}
+
+	for _, tt := range tests {
+		if got, ok := LanguageISOCode(tt.name); !ok || got != tt.want {
+			t.Errorf("LanguageISOCode(%q) = %q, %v, want %q", tt.name, got, ok, tt.want)
+		}
+	}
+	if got, ok := LanguageISOCode("Klingon"); ok {
+		t.Errorf("LanguageISOCode(\"Klingon\") = %q, want no match", got)
+	}
+}
+
+func TestLanguages_UniqueISOCodes(t *testing.T) {
+	t.Parallel()
+	seen := make(map[string]bool)
+	for i, language := range Languages {
+		if seen[language.ISOCode] {
+			t.Errorf("ISO code %q appears more than once", language.ISOCode)
+		}
+		seen[language.ISOCode] = true
+		if language.HungarianName == "" || language.EnglishName == "" {
+			t.Errorf("Language %q is missing a name: %+v", language.ISOCode, language)
+		}
+		if i > 0 && Languages[i-1].ISOCode >= language.ISOCode {
+			t.Errorf("Languages not ordered by ISO code at %q", language.ISOCode)
+		}
+	}
+
+	// Portugál, Portuguese and Brazil all map to pt, which is still listed once
+	pt := 0
+	for _, language := range Languages {
+		if language.ISOCode == "pt" {
+			pt++
+		}
+	}
+	if pt != 1 {
+		t.Errorf("Expected pt once, got %d", pt)
+	}
+	for _, code := range []string{"hu", "en", "de"} {
+		if !seen[code] {
+			t.Errorf("Expected %q in Languages", code)
+		}
+	}
+}
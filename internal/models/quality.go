@@ -50,6 +50,26 @@ func ParseQuality(qualityStr string) Quality {
 	}
 }
 
+// DetectQuality returns the highest resolution marker found anywhere in text, such as a
+// release name or filename. "4k" counts as 2160p.
+func DetectQuality(text string) Quality {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "2160p") || strings.Contains(lower, "4k"):
+		return Quality2160p
+	case strings.Contains(lower, "1080p"):
+		return Quality1080p
+	case strings.Contains(lower, "720p"):
+		return Quality720p
+	case strings.Contains(lower, "480p"):
+		return Quality480p
+	case strings.Contains(lower, "360p"):
+		return Quality360p
+	default:
+		return QualityUnknown
+	}
+}
+
 // MarshalJSON implements json.Marshaler interface
 func (q Quality) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + q.String() + `"`), nil
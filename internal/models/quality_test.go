@@ -1,4 +1,4 @@
-// Tests for quality.go — Quality type String(), ParseQuality(), MarshalJSON(), and UnmarshalJSON().
+// Tests for quality.go — Quality type String(), ParseQuality(), DetectQuality(), MarshalJSON(), and UnmarshalJSON().
 package models
 
 import (
@@ -64,6 +64,26 @@ func TestParseQuality(t *testing.T) {
 	}
 }
 
+func TestDetectQuality(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  Quality
+	}{
+		{"Show.S01E01.1080p.WEB.srt", Quality1080p},
+		{"Show.S01E01.720P.HDTV.srt", Quality720p},
+		{"Show.S01E01.4K.WEB.srt", Quality2160p},
+		{"Show - 1x01 (WEB.2160p, 1080p-Group)", Quality2160p},
+		{"Show.S01E01.WEB.srt", QualityUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := DetectQuality(tt.input); got != tt.want {
+			t.Errorf("DetectQuality(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestQuality_MarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,11 @@
+package models
+
+// ReleaseVariant is one comma-separated release of a subtitle's release info, such as
+// "AMZN.WEB-DL.720p-FLUX" in "AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab". Keeping the
+// parts of each release together tells which group released which quality.
+type ReleaseVariant struct {
+	Source  string  `json:"source"`  // Origin such as "AMZN", "NF" or "WEB"; empty when the release names none
+	RipType string  `json:"ripType"` // Rip type such as "WEB-DL", "WEBRip" or "HDTV"; empty when the release names none
+	Quality Quality `json:"quality"` // QualityUnknown when the release names no resolution
+	Group   string  `json:"group"`   // Release group after the last dash; empty when the release names none
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SeasonSummary summarizes the subtitles available for one season of a show
+type SeasonSummary struct {
+	Season              int       `json:"season"`
+	EpisodeCount        int       `json:"episodeCount"`        // Distinct episodes with at least one subtitle, ranged season packs included
+	SeasonPackAvailable bool      `json:"seasonPackAvailable"` // True if any season pack covers the season
+	LatestUpload        time.Time `json:"latestUpload"`        // Zero when no subtitle has a known upload date
+}
+
+// ShowSeasons lists the seasons of a show that have subtitles
+type ShowSeasons struct {
+	Seasons      []SeasonSummary `json:"seasons"`      // Ordered by season
+	UnknownCount int             `json:"unknownCount"` // Subtitles whose season could not be parsed, left out of Seasons
+}
@@ -0,0 +1,9 @@
+package models
+
+// SelfCheckResult reports whether the listing of a known show still parses into plausible subtitles
+type SelfCheckResult struct {
+	OK            bool   `json:"ok"`            // At least one subtitle with a language and a valid ID was parsed
+	Details       string `json:"details"`       // What was checked, or why the check failed
+	ShowID        int    `json:"showId"`        // Show whose listing was fetched
+	SubtitleCount int    `json:"subtitleCount"` // Subtitles parsed from the first listing page
+}
@@ -0,0 +1,20 @@
+package models
+
+// ServerInfo describes the running server: its build, the API version it serves, the optional
+// features its configuration enables and the limits it applies
+type ServerInfo struct {
+	Version    string       `json:"version"`    // Semantic version of the build, "dev" for local builds
+	Commit     string       `json:"commit"`     // Git commit the server was built from
+	BuildDate  string       `json:"buildDate"`  // When the server was built
+	APIVersion string       `json:"apiVersion"` // Version of the gRPC API, such as "v1"
+	Features   []string     `json:"features"`   // Enabled optional features, such as "redis_cache"
+	Limits     ServerLimits `json:"limits"`
+}
+
+// ServerLimits are the size limits a server applies to requests and downloads
+type ServerLimits struct {
+	MaxDownloadBytes int64 `json:"maxDownloadBytes"` // Largest file downloaded from the site
+	MaxFileBytes     int64 `json:"maxFileBytes"`     // Largest subtitle extracted from an archive
+	MaxArchiveBytes  int64 `json:"maxArchiveBytes"`  // Largest total uncompressed size of an archive
+	MaxMessageBytes  int64 `json:"maxMessageBytes"`  // Largest gRPC request message accepted
+}
@@ -2,8 +2,27 @@ package models
 
 // Show represents a TV show with basic information
 type Show struct {
-	Name     string `json:"name"`
-	ID       int    `json:"id"`
-	Year     int    `json:"year"`
-	ImageURL string `json:"imageUrl"`
+	Name     string       `json:"name"`
+	ID       int          `json:"id"`
+	Year     int          `json:"year"`
+	ImageURL string       `json:"imageUrl"`
+	Aliases  []string     `json:"aliases"` // Distinct titles the show is known by (original and Hungarian), when known
+	Status   string       `json:"status"`  // ShowStatusRunning or ShowStatusEnded from the detail page; empty when unknown
+	Sources  []ShowSource `json:"sources"` // Show list endpoints the show was listed on; empty outside the show list
 }
+
+// Show statuses read from the detail page
+const (
+	ShowStatusRunning = "running"
+	ShowStatusEnded   = "ended"
+)
+
+// ShowSource is a show list endpoint a show was listed on
+type ShowSource string
+
+// Show list endpoints, in the order they are reported in Show.Sources
+const (
+	ShowSourceWaiting             ShowSource = "waiting"               // varakozik-subrip: subtitles requested, nobody translating yet
+	ShowSourceUnderTranslation    ShowSource = "under_translation"     // alatt-subrip: a subtitle is being translated
+	ShowSourceNotUnderTranslation ShowSource = "not_under_translation" // nem-all-forditas-alatt: nothing is being translated
+)
@@ -0,0 +1,27 @@
+package models
+
+// ExportOptions selects what a show export contains
+type ExportOptions struct {
+	Languages     []string // Only subtitles in these languages, ignoring case; empty exports every language
+	MaxTotalBytes int64    // Cap on the summed size of the exported files; zero means no cap
+}
+
+// ExportManifest is written as manifest.json at the end of a show export
+type ExportManifest struct {
+	ShowID int            `json:"showId"`
+	Files  []ExportedFile `json:"files"`
+	Failed int            `json:"failed"` // Subtitles whose download failed, listed in Files with Error set
+}
+
+// ExportedFile describes one subtitle of a show export. A failed download has Error set and no Path.
+type ExportedFile struct {
+	SubtitleID int    `json:"subtitleId"`
+	Name       string `json:"name"`
+	Language   string `json:"language"`
+	Season     int    `json:"season"`
+	Episode    int    `json:"episode"`
+	Path       string `json:"path,omitempty"` // Entry in the export archive
+	Size       int64  `json:"size,omitempty"`
+	Sha256     string `json:"sha256,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
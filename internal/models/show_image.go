@@ -0,0 +1,7 @@
+package models
+
+// ShowImage is the poster image of a show
+type ShowImage struct {
+	Content     []byte `json:"content"`     // Image bytes
+	ContentType string `json:"contentType"` // "image/jpeg", "image/png" or "image/webp", detected from Content
+}
@@ -6,25 +6,46 @@ import (
 
 // Subtitle represents a normalized subtitle in our application
 type Subtitle struct {
-	ID            int       `json:"id"`
-	ShowID        int       `json:"showId"`   // Show ID from feliratok.eu (extracted from category link)
-	ShowName      string    `json:"showName"` // Show name (may be empty in HTML parsing)
-	Name          string    `json:"name"`     // Subtitle name/title from HTML
-	Language      string    `json:"language"`
-	Season        int       `json:"season"`
-	Episode       int       `json:"episode"`
-	Filename      string    `json:"filename"` // Subtitle filename from download URL
-	DownloadURL   string    `json:"downloadUrl"`
-	Uploader      string    `json:"uploader"`
-	UploadedAt    time.Time `json:"uploadedAt"`
-	Qualities     []Quality `json:"qualities"`     // All matching qualities
-	ReleaseGroups []string  `json:"releaseGroups"` // Multiple release groups (comma-separated in HTML)
-	Release       string    `json:"release"`       // Release info (formats, quality) from HTML
-	IsSeasonPack  bool      `json:"isSeasonPack"`
-	RangeStart    *int      `json:"rangeStart"` // Season-pack range start episode (null for non-ranged subtitles)
-	RangeEnd      *int      `json:"rangeEnd"`   // Season-pack range end episode (null for non-ranged subtitles)
+	ID                int              `json:"id"`
+	IDIsSynthetic     bool             `json:"idIsSynthetic"`     // ID is a negative hash of the download URL because the link carries no numeric felirat ID
+	ShowID            int              `json:"showId"`            // Show ID from feliratok.eu (extracted from category link)
+	ShowName          string           `json:"showName"`          // Show name (may be empty in HTML parsing)
+	HungarianShowName string           `json:"hungarianShowName"` // Hungarian show title from the listing (may be empty)
+	Name              string           `json:"name"`              // Episode title from HTML, with source artifacts such as "Addic7ed.com" removed unless title normalization is off
+	RawName           string           `json:"rawName"`           // Episode title exactly as it appears in the listing
+	Language          string           `json:"language"`
+	Season            int              `json:"season"`
+	Episode           int              `json:"episode"`
+	Filename          string           `json:"filename"` // Subtitle filename from download URL
+	DownloadURL       string           `json:"downloadUrl"`
+	Uploader          string           `json:"uploader"`
+	UploaderID        string           `json:"uploaderId"`        // Uploader profile identifier from the uploader link (felt name or numeric user id); empty when not linked
+	UploaderVerified  bool             `json:"uploaderVerified"`  // Uploader name is bold in the listing, which marks official translators and fansub teams
+	IsHearingImpaired bool             `json:"isHearingImpaired"` // Description or filename marks the subtitle as SDH/CC for the hearing impaired
+	UploadedAt        time.Time        `json:"uploadedAt"`
+	Qualities         []Quality        `json:"qualities"`       // All matching qualities
+	ReleaseGroups     []string         `json:"releaseGroups"`   // Multiple release groups (comma-separated in HTML)
+	ReleaseVariants   []ReleaseVariant `json:"releaseVariants"` // Each comma-separated release with its own source, rip type, quality and group
+	Release           string           `json:"release"`         // Release info (formats, quality) from HTML
+	IsSeasonPack      bool             `json:"isSeasonPack"`
+	SeasonEnd         *int             `json:"seasonEnd"`   // Last season of a multi-season pack such as "(1-3. évad)" (null otherwise)
+	RangeStart        *int             `json:"rangeStart"`  // Season-pack range start episode (null for non-ranged subtitles)
+	RangeEnd          *int             `json:"rangeEnd"`    // Season-pack range end episode (null for non-ranged subtitles)
+	MovieTitle        string           `json:"movieTitle"`  // Movie title from a movie listing row; empty for shows
+	MovieYear         int              `json:"movieYear"`   // Movie release year from the title, such as "Title (2023)"; 0 when missing or for shows
+	ContentType       ContentType      `json:"contentType"` // Whether the subtitle is for a series episode or a film
+	AirDate           time.Time        `json:"airDate"`     // Original air date named in the episode's titles, such as "(2024-05-01)"; zero when they name none
 }
 
+// ContentType is the kind of content a subtitle is for
+type ContentType string
+
+// Content types, matching the site's "sorozat" and "film" tabs
+const (
+	ContentTypeSeries ContentType = "series"
+	ContentTypeFilm   ContentType = "film"
+)
+
 // SubtitleCollection represents a collection of subtitles for a show
 type SubtitleCollection struct {
 	ShowName  string     `json:"showName"`
@@ -0,0 +1,12 @@
+package models
+
+// SubtitleDetails holds what the detail page (adatlap) of a single subtitle shows
+type SubtitleDetails struct {
+	SubtitleID    int           `json:"subtitleId"`
+	Filename      string        `json:"filename"`
+	Uploader      string        `json:"uploader"`
+	Comment       string        `json:"comment"` // Uploader's note (megjegyzés), such as the release it fits; empty when missing
+	ThirdPartyIds ThirdPartyIds `json:"thirdPartyIds"`
+	ShowYear      int           `json:"showYear"`   // Year the show first aired (év); 0 when missing
+	ShowStatus    string        `json:"showStatus"` // ShowStatusRunning or ShowStatusEnded (állapot); empty when missing or unrecognized
+}
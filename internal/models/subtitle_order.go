@@ -0,0 +1,42 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SubtitleOrder is the order subtitles of a show are sent in
+type SubtitleOrder int
+
+const (
+	SubtitleOrderListing        SubtitleOrder = iota // As listed by the site, unsorted
+	SubtitleOrderUploadTimeDesc                      // Newest upload first
+	SubtitleOrderUploadTimeAsc                       // Oldest upload first
+	SubtitleOrderSeasonEpisode                       // By season then episode, newest upload first within an episode
+)
+
+// SortSubtitles sorts subtitles in place by order. Ties are broken by ID, descending for the
+// descending orders and ascending otherwise, so the same subtitles always sort the same way
+// whatever order they arrived in; the sort is stable for rows sharing an ID, such as rows
+// without one. SubtitleOrderListing leaves subtitles untouched.
+func SortSubtitles(subtitles []Subtitle, order SubtitleOrder) {
+	switch order {
+	case SubtitleOrderUploadTimeDesc:
+		slices.SortStableFunc(subtitles, func(a, b Subtitle) int {
+			return cmp.Or(b.UploadedAt.Compare(a.UploadedAt), cmp.Compare(b.ID, a.ID))
+		})
+	case SubtitleOrderUploadTimeAsc:
+		slices.SortStableFunc(subtitles, func(a, b Subtitle) int {
+			return cmp.Or(a.UploadedAt.Compare(b.UploadedAt), cmp.Compare(a.ID, b.ID))
+		})
+	case SubtitleOrderSeasonEpisode:
+		slices.SortStableFunc(subtitles, func(a, b Subtitle) int {
+			return cmp.Or(
+				cmp.Compare(a.Season, b.Season),
+				cmp.Compare(a.Episode, b.Episode),
+				b.UploadedAt.Compare(a.UploadedAt),
+				cmp.Compare(b.ID, a.ID),
+			)
+		})
+	}
+}
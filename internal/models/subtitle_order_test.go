@@ -0,0 +1,74 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortSubtitles(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.February, d, 0, 0, 0, 0, time.UTC) }
+	subtitles := []Subtitle{
+		{ID: 4, Season: 2, Episode: 1, UploadedAt: day(3)},
+		{ID: 1, Season: 1, Episode: 2, UploadedAt: day(1)},
+		{ID: 3, Season: 1, Episode: 1, UploadedAt: day(2)},
+		{ID: 2, Season: 1, Episode: 1, UploadedAt: day(2)},
+		{ID: 5, Season: 1, Episode: 2, UploadedAt: day(4)},
+	}
+
+	tests := []struct {
+		name  string
+		order SubtitleOrder
+		want  []int
+	}{
+		{name: "listing", order: SubtitleOrderListing, want: []int{4, 1, 3, 2, 5}},
+		{name: "upload time desc", order: SubtitleOrderUploadTimeDesc, want: []int{5, 4, 3, 2, 1}},
+		{name: "upload time asc", order: SubtitleOrderUploadTimeAsc, want: []int{1, 2, 3, 4, 5}},
+		{name: "season episode", order: SubtitleOrderSeasonEpisode, want: []int{3, 2, 5, 1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := slices.Clone(subtitles)
+			SortSubtitles(sorted, tt.order)
+			if got := subtitleIDs(sorted); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSortSubtitles_IgnoresArrivalOrder(t *testing.T) {
+	day := time.Date(2025, time.February, 8, 0, 0, 0, 0, time.UTC)
+	subtitles := []Subtitle{
+		{ID: 10, Season: 1, Episode: 1, UploadedAt: day},
+		{ID: 11, Season: 1, Episode: 1, UploadedAt: day},
+		{ID: 12, Season: 1, Episode: 1, UploadedAt: day},
+	}
+	for _, order := range []SubtitleOrder{SubtitleOrderUploadTimeDesc, SubtitleOrderUploadTimeAsc, SubtitleOrderSeasonEpisode} {
+		var first []int
+		// Every arrival order of subtitles uploaded at the same time sorts the same way
+		for _, permutation := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 2, 0}, {0, 2, 1}} {
+			arrived := make([]Subtitle, 0, len(subtitles))
+			for _, i := range permutation {
+				arrived = append(arrived, subtitles[i])
+			}
+			SortSubtitles(arrived, order)
+			got := subtitleIDs(arrived)
+			if first == nil {
+				first = got
+				continue
+			}
+			if !slices.Equal(got, first) {
+				t.Errorf("Order %d: expected %v for every arrival order, got %v", order, first, got)
+			}
+		}
+	}
+}
+
+func subtitleIDs(subtitles []Subtitle) []int {
+	ids := make([]int, len(subtitles))
+	for i, subtitle := range subtitles {
+		ids[i] = subtitle.ID
+	}
+	return ids
+}
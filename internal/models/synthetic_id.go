@@ -0,0 +1,25 @@
+package models
+
+import "hash/fnv"
+
+// syntheticIDBase is the magnitude every synthetic subtitle ID exceeds. Real felirat IDs are
+// positive, so negated hashes can never collide with them, and the offset keeps synthetic IDs
+// clear of the -1 "no ID" sentinel.
+const syntheticIDBase = 1 << 32
+
+// syntheticIDHashMask keeps the hashed part of a synthetic ID to 48 bits, so the result also
+// fits the int64 proto field.
+const syntheticIDHashMask = 1<<48 - 1
+
+// SyntheticSubtitleID derives a stable negative subtitle ID from the FNV-1a hash of a
+// normalized download URL, for uploads whose download link carries no numeric felirat ID.
+func SyntheticSubtitleID(downloadURL string) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(downloadURL))
+	return -(syntheticIDBase + int(h.Sum64()&syntheticIDHashMask))
+}
+
+// IsSyntheticSubtitleID reports whether id lies in the range reserved for synthetic IDs.
+func IsSyntheticSubtitleID(id int) bool {
+	return id <= -syntheticIDBase
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestSyntheticSubtitleID(t *testing.T) {
+	t.Parallel()
+	url := "https://feliratok.eu/index.php?action=letolt&felirat=abc123"
+
+	id := SyntheticSubtitleID(url)
+	if id != SyntheticSubtitleID(url) {
+		t.Errorf("SyntheticSubtitleID(%q) is not stable", url)
+	}
+	if !IsSyntheticSubtitleID(id) {
+		t.Errorf("IsSyntheticSubtitleID(%d) = false, want true", id)
+	}
+	if other := SyntheticSubtitleID(url + "x"); other == id {
+		t.Errorf("SyntheticSubtitleID gave %d for two different URLs", id)
+	}
+}
+
+func TestIsSyntheticSubtitleID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		id   int
+		want bool
+	}{
+		{1700000000, false},
+		{1, false},
+		{0, false},
+		{-1, false},
+		{-(1 << 32), true},
+		{-(1<<32 + 1<<47), true},
+	}
+
+	for _, tt := range tests {
+		if got := IsSyntheticSubtitleID(tt.id); got != tt.want {
+			t.Errorf("IsSyntheticSubtitleID(%d) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
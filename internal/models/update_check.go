@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 // UpdateCheckResponse represents the response from the recheck endpoint
@@ -56,7 +57,8 @@ func (u *UpdateCheckResponse) UnmarshalJSON(data []byte) error {
 
 // UpdateCheckResult represents the normalized result of an update check
 type UpdateCheckResult struct {
-	FilmCount   int  `json:"filmCount"`   // Number of films available since the given episode ID
-	SeriesCount int  `json:"seriesCount"` // Number of series episodes available since the given episode ID
-	HasUpdates  bool `json:"hasUpdates"`  // True if there are any updates available (count > 0)
+	FilmCount   int       `json:"filmCount"`   // Number of films available since the given episode ID
+	SeriesCount int       `json:"seriesCount"` // Number of series episodes available since the given episode ID
+	HasUpdates  bool      `json:"hasUpdates"`  // True if there are any updates available (count > 0)
+	CheckedAt   time.Time `json:"checkedAt"`   // When the counts were fetched from the site; cached results keep the original time
 }
@@ -4,6 +4,7 @@ package models
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestUpdateCheckResponse_UnmarshalJSON(t *testing.T) {
@@ -132,6 +133,7 @@ func TestUpdateCheckResult_JSONRoundTrip(t *testing.T) {
 		FilmCount:   5,
 		SeriesCount: 10,
 		HasUpdates:  true,
+		CheckedAt:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
 	}
 
 	data, err := json.Marshal(original)
@@ -153,4 +155,7 @@ func TestUpdateCheckResult_JSONRoundTrip(t *testing.T) {
 	if decoded.HasUpdates != original.HasUpdates {
 		t.Errorf("HasUpdates = %v, want %v", decoded.HasUpdates, original.HasUpdates)
 	}
+	if !decoded.CheckedAt.Equal(original.CheckedAt) {
+		t.Errorf("CheckedAt = %v, want %v", decoded.CheckedAt, original.CheckedAt)
+	}
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UpstreamStatus reports which upstream mirror requests are sent to
+type UpstreamStatus struct {
+	ActiveMirror string    `json:"activeMirror"` // Base URL requests currently go to
+	Mirrors      []string  `json:"mirrors"`      // Configured base URLs in failover order, primary first
+	ActiveSince  time.Time `json:"activeSince"`  // When the active mirror was selected; the client's start time if it never changed
+
+	Blocked       bool      `json:"blocked"`       // The last listing fetched was a captcha, login or other block page
+	BlockedReason string    `json:"blockedReason"` // What marked the page as a block page; empty when not blocked
+	BlockedSince  time.Time `json:"blockedSince"`  // When the first block page of the current block was seen; zero when not blocked
+}
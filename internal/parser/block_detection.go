@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// captchaSelectors match the widgets and forms of the captcha and bot-challenge pages served
+// instead of content to clients the site or its CDN throttles.
+var captchaSelectors = []string{
+	".g-recaptcha",
+	".h-captcha",
+	".cf-turnstile",
+	"#challenge-form",
+	"[name*=captcha i]",
+	"[id*=captcha i]",
+	"form[action*=captcha i]",
+	`script[src*="recaptcha"]`,
+	`script[src*="hcaptcha"]`,
+}
+
+// maintenanceMarkers are phrases, in lower case, that the site's maintenance page and the error
+// pages of its web server or database put in the page title or a top heading.
+var maintenanceMarkers = []string{
+	"karbantartás",
+	"karbantartas",
+	"maintenance",
+	"átmenetileg nem elérhető",
+	"service unavailable",
+	"temporarily unavailable",
+	"adatbázis hiba",
+	"database error",
+	"too many connections",
+}
+
+// detectBlockPage reports why doc looks like a page served to a blocked client instead of the
+// requested content, or returns an empty string when it does not. It recognizes captcha and
+// bot-challenge pages and login forms.
+func detectBlockPage(doc *goquery.Document) string {
+	for _, selector := range captchaSelectors {
+		if doc.Find(selector).Length() > 0 {
+			return "captcha page"
+		}
+	}
+	if doc.Find(`input[type="password" i]`).Length() > 0 {
+		return "login page"
+	}
+	title := strings.ToLower(doc.Find("title").Text())
+	if strings.Contains(title, "captcha") || strings.Contains(title, "just a moment") || strings.Contains(title, "access denied") {
+		return "block page titled " + strings.TrimSpace(doc.Find("title").Text())
+	}
+	return ""
+}
+
+// detectMaintenancePage reports why doc looks like a maintenance or server error page served
+// instead of the requested content, or returns an empty string when it does not. Only the title
+// and h1/h2 headings are read: a marker in the page text could be a show or episode name.
+func detectMaintenancePage(doc *goquery.Document) string {
+	for _, selector := range []string{"title", "h1", "h2"} {
+		var reason string
+		doc.Find(selector).EachWithBreak(func(_ int, heading *goquery.Selection) bool {
+			text := strings.Join(strings.Fields(heading.Text()), " ")
+			lower := strings.ToLower(text)
+			for _, marker := range maintenanceMarkers {
+				if strings.Contains(lower, marker) {
+					reason = "maintenance page with " + selector + " " + text
+					return false
+				}
+			}
+			return true
+		})
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// releaseSources maps lowercase source tokens to their usual spelling
+var releaseSources = map[string]string{
+	"amzn": "AMZN",
+	"atvp": "ATVP",
+	"dsnp": "DSNP",
+	"hmax": "HMAX",
+	"hulu": "HULU",
+	"max":  "MAX",
+	"nf":   "NF",
+	"pcok": "PCOK",
+	"pmtp": "PMTP",
+	"skst": "SKST",
+	"web":  "WEB",
+}
+
+// releaseRipTypes maps lowercase rip type tokens to their usual spelling
+var releaseRipTypes = map[string]string{
+	"bdrip":   "BDRip",
+	"blu-ray": "BluRay",
+	"bluray":  "BluRay",
+	"brrip":   "BRRip",
+	"dvdrip":  "DVDRip",
+	"hdrip":   "HDRip",
+	"hdtv":    "HDTV",
+	"web-dl":  "WEB-DL",
+	"webdl":   "WEB-DL",
+	"webrip":  "WEBRip",
+}
+
+// parseReleaseVariant splits a single release such as "AMZN.WEB-DL.720p-FLUX" into its source,
+// rip type, quality and group. Parts the release does not name are left empty.
+// Example: "AMZN.WEB-DL.720p-FLUX" -> {AMZN, WEB-DL, 720p, FLUX}; "HDTV.720p" -> {"", HDTV, 720p, ""}
+func (p *SubtitleParser) parseReleaseVariant(release string) models.ReleaseVariant {
+	variant := models.ReleaseVariant{Quality: p.detectQuality(release)}
+
+	rest := release
+	if idx := strings.LastIndex(release, "-"); idx != -1 {
+		group := strings.TrimSpace(release[idx+1:])
+		// The dash inside a rip type such as "WEB-DL" does not start a group
+		if group != "" && !strings.ContainsAny(group, ". ") && !endsWithRipType(release) {
+			variant.Group = group
+			rest = release[:idx]
+		}
+	}
+
+	for _, token := range strings.FieldsFunc(rest, func(r rune) bool { return r == '.' || r == ' ' || r == '_' }) {
+		token = strings.ToLower(token)
+		if ripType, ok := releaseRipTypes[token]; ok && variant.RipType == "" {
+			variant.RipType = ripType
+		} else if source, ok := releaseSources[token]; ok && variant.Source == "" {
+			variant.Source = source
+		}
+	}
+	return variant
+}
+
+// endsWithRipType reports whether release ends with a rip type spelled with a dash, such as "WEB-DL"
+func endsWithRipType(release string) bool {
+	lower := strings.ToLower(release)
+	return strings.HasSuffix(lower, "web-dl") || strings.HasSuffix(lower, "blu-ray")
+}
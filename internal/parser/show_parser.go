@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 
@@ -24,7 +25,9 @@ func NewShowParser(baseURL string) *ShowParser {
 	}
 }
 
-// ParseHtml parses the HTML response and extracts show information
+// ParseHtml parses the HTML response and extracts show information. A page without shows
+// that looks like a captcha or login page returns apperrors.ErrUpstreamBlocked, and one that
+// looks like a maintenance or server error page returns apperrors.ErrUpstreamMaintenance.
 func (p *ShowParser) ParseHtml(body io.Reader) ([]models.Show, error) {
 	logger := config.GetLogger()
 	logger.Info().Msg("Starting HTML parsing for shows")
@@ -47,11 +50,21 @@ func (p *ShowParser) ParseHtml(body io.Reader) ([]models.Show, error) {
 	var shows []models.Show
 	var currentYear int
 
-	// Find all table rows that contain show information
+	// Find all table rows that contain show information.
+	// Listings are laid out as a grid with one or more shows per row: each show is an
+	// image cell holding the index.php?sid= link followed by its td.sangol name cell.
+	// Year headers are rows with a single cell spanning the whole grid.
 	doc.Find("tr").Each(func(i int, row *goquery.Selection) {
+		// Only links wrapping the show image identify a show; this keeps any text link
+		// to the same show from producing a duplicate entry.
+		showLinks := row.Find(`a[href*="index.php?sid="]:has(img)`)
+
 		// Check if this is a year header row
-		yearCell := row.Find(`td[colspan="10"]`)
-		if yearCell.Length() > 0 {
+		if showLinks.Length() == 0 {
+			yearCell := row.Find(`td[colspan]`)
+			if yearCell.Length() != 1 || yearCell.AttrOr("colspan", "1") == "1" {
+				return
+			}
 			yearText := strings.TrimSpace(yearCell.Text())
 			if year, err := strconv.Atoi(yearText); err == nil {
 				currentYear = year
@@ -63,7 +76,6 @@ func (p *ShowParser) ParseHtml(body io.Reader) ([]models.Show, error) {
 		}
 
 		// Check if this row contains show information
-		showLinks := row.Find(`a[href*="index.php?sid="]`)
 		if showLinks.Length() > 0 {
 			logger.Debug().Int("row", i).Int("links", showLinks.Length()).Msg("Found show links in row")
 			showLinks.Each(func(j int, link *goquery.Selection) {
@@ -81,6 +93,19 @@ func (p *ShowParser) ParseHtml(body io.Reader) ([]models.Show, error) {
 		}
 	})
 
+	// Show list pages have no fixed table to check, so an empty page is only rejected when it
+	// looks like a captcha, login or maintenance page
+	if len(shows) == 0 {
+		if reason := detectBlockPage(doc); reason != "" {
+			logger.Error().Str("reason", reason).Msg("Upstream served a block page instead of the show list")
+			return nil, &apperrors.ErrUpstreamBlocked{Reason: reason}
+		}
+		if reason := detectMaintenancePage(doc); reason != "" {
+			logger.Error().Str("reason", reason).Msg("Upstream served a maintenance page instead of the show list")
+			return nil, &apperrors.ErrUpstreamMaintenance{Reason: reason}
+		}
+	}
+
 	logger.Info().Int("total_shows", len(shows)).Msg("Completed HTML parsing for shows")
 	return shows, nil
 }
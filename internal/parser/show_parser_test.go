@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
 )
@@ -274,6 +277,42 @@ func TestShowParser_ParseHtml_MultipleShowsPerRow(t *testing.T) {
 	}
 }
 
+func TestShowParser_ParseHtml_MultiColumnGrid(t *testing.T) {
+	t.Parallel()
+	// Five shows across a 2-column grid: the year change splits the grid with a
+	// full-width header whose colspan matches the grid rather than the usual 10.
+	htmlContent := testutil.GenerateShowTableHTMLMultiColumn([]testutil.ShowRowOptions{
+		{ShowID: 13076, ShowName: "Cash Queens  (Les Lionnes)", Year: 2026},
+		{ShowID: 13043, ShowName: "Finding Her Edge", Year: 2026, LinkName: true},
+		{ShowID: 13007, ShowName: "Love from 9 to 5  (Amor de oficina)", Year: 2026},
+		{ShowID: 12549, ShowName: "A Thousand Blows", Year: 2025, YearHeaderColspan: 4},
+		{ShowID: 12076, ShowName: "Adults", Year: 2025, LinkName: true},
+	}, 2)
+
+	parser := NewShowParser("https://feliratok.eu")
+	shows, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+
+	expectedShows := []models.Show{
+		{Name: "Cash Queens  (Les Lionnes)", ID: 13076, Year: 2026, ImageURL: "https://feliratok.eu/sorozat_cat.php?kep=13076"},
+		{Name: "Finding Her Edge", ID: 13043, Year: 2026, ImageURL: "https://feliratok.eu/sorozat_cat.php?kep=13043"},
+		{Name: "Love from 9 to 5  (Amor de oficina)", ID: 13007, Year: 2026, ImageURL: "https://feliratok.eu/sorozat_cat.php?kep=13007"},
+		{Name: "A Thousand Blows", ID: 12549, Year: 2025, ImageURL: "https://feliratok.eu/sorozat_cat.php?kep=12549"},
+		{Name: "Adults", ID: 12076, Year: 2025, ImageURL: "https://feliratok.eu/sorozat_cat.php?kep=12076"},
+	}
+
+	if len(shows) != len(expectedShows) {
+		t.Fatalf("Expected %d shows, got %d: %+v", len(expectedShows), len(shows), shows)
+	}
+	for i, expected := range expectedShows {
+		if !reflect.DeepEqual(shows[i], expected) {
+			t.Errorf("Show %d: expected %+v, got %+v", i, expected, shows[i])
+		}
+	}
+}
+
 func TestShowParser_extractIDFromHref(t *testing.T) {
 	t.Parallel()
 	parser := NewShowParser("https://feliratok.eu")
@@ -380,3 +419,24 @@ func TestShowParser_ExtractLastPage_EmptyHTML(t *testing.T) {
 		t.Errorf("Expected last page 1 for empty HTML, got %d", lastPage)
 	}
 }
+
+func TestShowParser_ParseHtml_CaptchaAndMaintenancePages(t *testing.T) {
+	t.Parallel()
+	parser := NewShowParser("https://feliratok.eu")
+
+	_, err := parser.ParseHtml(strings.NewReader(testutil.GenerateCaptchaPageHTML()))
+	if !errors.Is(err, &apperrors.ErrUpstreamBlocked{}) {
+		t.Fatalf("Expected ErrUpstreamBlocked, got %v", err)
+	}
+
+	_, err = parser.ParseHtml(strings.NewReader(testutil.GenerateMaintenancePageHTML()))
+	if !errors.Is(err, &apperrors.ErrUpstreamMaintenance{}) {
+		t.Fatalf("Expected ErrUpstreamMaintenance, got %v", err)
+	}
+
+	// A page without shows or block markers is still an empty list
+	shows, err := parser.ParseHtml(strings.NewReader(testutil.GenerateEmptyHTML()))
+	if err != nil || len(shows) != 0 {
+		t.Errorf("Expected an empty list without error, got %v, %v", shows, err)
+	}
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ErrSubtitleDetailsNotFound is returned when a detail page carries no subtitle data,
+// which is how feliratok.eu answers for unknown subtitle IDs
+var ErrSubtitleDetailsNotFound = errors.New("subtitle details not found")
+
+// Labels of the adatlapRow fields read by SubtitleDetailsParser
+const (
+	detailsFilenameLabel = "Fájlnév:"
+	detailsUploaderLabel = "Feltöltő:"
+	detailsYearLabel     = "Év:"
+	detailsStatusLabel   = "Állapot:"
+)
+
+// detailsYearRegex matches the four-digit air year in the Év field, such as "2019" or "2019-2023"
+var detailsYearRegex = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+
+// SubtitleDetailsParser implements the SingleResultParser interface for the per-subtitle detail page (adatlap)
+type SubtitleDetailsParser struct {
+	thirdPartyParser ThirdPartyIdParser
+}
+
+// NewSubtitleDetailsParser creates a new subtitle details parser instance
+func NewSubtitleDetailsParser() SingleResultParser[models.SubtitleDetails] {
+	return &SubtitleDetailsParser{}
+}
+
+// ParseHtml parses a detail page and extracts the filename, uploader, comment and third-party IDs.
+// The subtitle ID is not part of the page and is left for the caller to set.
+// A missing or empty comment yields an empty Comment; a page without subtitle data returns ErrSubtitleDetailsNotFound.
+func (p *SubtitleDetailsParser) ParseHtml(body io.Reader) (models.SubtitleDetails, error) {
+	logger := config.GetLogger()
+
+	// Convert any character encoding to UTF-8 before parsing
+	utf8Body, err := NewUTF8Reader(body)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to convert HTML to UTF-8")
+		return models.SubtitleDetails{}, fmt.Errorf("failed to convert HTML to UTF-8: %w", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(utf8Body)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to parse HTML document")
+		return models.SubtitleDetails{}, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if doc.Find("div.adatlapAdat").Length() == 0 {
+		logger.Debug().Msg("Detail page has no subtitle data")
+		return models.SubtitleDetails{}, ErrSubtitleDetailsNotFound
+	}
+
+	result := models.SubtitleDetails{}
+	doc.Find("div.adatlapRow").Each(func(_ int, row *goquery.Selection) {
+		spans := row.ChildrenFiltered("span")
+		if spans.Length() < 2 {
+			return
+		}
+
+		value := strings.TrimSpace(spans.Eq(1).Text())
+		switch strings.TrimSpace(spans.Eq(0).Text()) {
+		case detailsFilenameLabel:
+			result.Filename = value
+		case detailsUploaderLabel:
+			result.Uploader = value
+		case detailsYearLabel:
+			if year, err := strconv.Atoi(detailsYearRegex.FindString(value)); err == nil {
+				result.ShowYear = year
+			}
+		case detailsStatusLabel:
+			result.ShowStatus = parseShowStatus(value)
+			if result.ShowStatus == "" {
+				logger.Debug().Str("status", value).Msg("Unrecognized show status on detail page")
+			}
+		}
+	})
+
+	// Uploaders break longer notes over several lines
+	comment := doc.Find("span.megjegyzes").First()
+	comment.Find("br").ReplaceWithHtml("\n")
+	result.Comment = strings.TrimSpace(comment.Text())
+
+	result.ThirdPartyIds = p.thirdPartyParser.extractThirdPartyIds(doc)
+
+	logger.Debug().
+		Str("filename", result.Filename).
+		Str("uploader", result.Uploader).
+		Bool("hasComment", result.Comment != "").
+		Int("showYear", result.ShowYear).
+		Str("showStatus", result.ShowStatus).
+		Msg("Completed subtitle details extraction")
+
+	return result, nil
+}
+
+// parseShowStatus maps the Hungarian status of the detail page to models.ShowStatusRunning or
+// models.ShowStatusEnded. Unrecognized values return "".
+func parseShowStatus(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case strings.HasPrefix(value, "fut"), strings.HasPrefix(value, "folyamatban"):
+		return models.ShowStatusRunning
+	case strings.HasPrefix(value, "befejez"), strings.HasPrefix(value, "véget ért"), strings.HasPrefix(value, "lezárult"):
+		return models.ShowStatusEnded
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestSubtitleDetailsParser_ParseHtml(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{
+		Filename: "The.Pitt.S01E05.720p.WEB.h264-ETHEL.srt",
+		Uploader: "gricsi",
+		Comment:  "csak a WEB-DL-hez jó<br>Az AMZN verzióhoz igazítani kell",
+		IMDBID:   "tt31938062",
+		TVDBID:   448176,
+		TVMazeID: 74353,
+		TraktID:  448176,
+	})
+
+	result, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+
+	expected := models.SubtitleDetails{
+		Filename: "The.Pitt.S01E05.720p.WEB.h264-ETHEL.srt",
+		Uploader: "gricsi",
+		Comment:  "csak a WEB-DL-hez jó\nAz AMZN verzióhoz igazítani kell",
+		ThirdPartyIds: models.ThirdPartyIds{
+			IMDBID:   "tt31938062",
+			TVDBID:   448176,
+			TVMazeID: 74353,
+			TraktID:  448176,
+		},
+	}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestSubtitleDetailsParser_ParseHtml_EmptyComment(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateThirdPartyIDHTML("", 0, 0, 0)
+
+	result, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Expected no error for an empty comment, got: %v", err)
+	}
+	if result.Comment != "" {
+		t.Errorf("Expected empty comment, got %q", result.Comment)
+	}
+	if result.Filename != "Show.S01E01.srt" || result.Uploader != "TestUser" {
+		t.Errorf("Expected filename and uploader to be parsed, got %q and %q", result.Filename, result.Uploader)
+	}
+}
+
+func TestSubtitleDetailsParser_ParseHtml_MissingCommentRow(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateHTMLWithBody(`<div class="adatlapAdat">
+	<div class="adatlapRow"><span>Fájlnév:</span><span>show.s02e03.srt</span></div>
+	<div class="adatlapRow"><span>Feltöltő:</span><span>kissoreg</span></div>
+</div>`)
+
+	result, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Expected no error without a comment row, got: %v", err)
+	}
+	if result.Comment != "" {
+		t.Errorf("Expected empty comment, got %q", result.Comment)
+	}
+	if result.Filename != "show.s02e03.srt" || result.Uploader != "kissoreg" {
+		t.Errorf("Expected filename and uploader to be parsed, got %q and %q", result.Filename, result.Uploader)
+	}
+}
+
+func TestSubtitleDetailsParser_ParseHtml_UnknownSubtitle(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateEmptyHTML()
+
+	_, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+	if !errors.Is(err, ErrSubtitleDetailsNotFound) {
+		t.Errorf("Expected ErrSubtitleDetailsNotFound, got: %v", err)
+	}
+}
+
+func TestSubtitleDetailsParser_ParseHtml_YearAndStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		year           int
+		status         string
+		expectedYear   int
+		expectedStatus string
+	}{
+		{name: "running", year: 2025, status: "Fut", expectedYear: 2025, expectedStatus: models.ShowStatusRunning},
+		{name: "ended", year: 2011, status: "Befejezett", expectedYear: 2011, expectedStatus: models.ShowStatusEnded},
+		{name: "unrecognized status", year: 2019, status: "Szünetel", expectedYear: 2019},
+		{name: "missing rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			htmlContent := testutil.GenerateSubtitleDetailsHTML(testutil.SubtitleDetailsOptions{
+				Filename: "Show.S01E01.srt",
+				Uploader: "TestUser",
+				IMDBID:   "tt31938062",
+				Year:     tt.year,
+				Status:   tt.status,
+			})
+
+			result, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+			if err != nil {
+				t.Fatalf("ParseHtml failed: %v", err)
+			}
+			if result.ShowYear != tt.expectedYear {
+				t.Errorf("Expected year %d, got %d", tt.expectedYear, result.ShowYear)
+			}
+			if result.ShowStatus != tt.expectedStatus {
+				t.Errorf("Expected status %q, got %q", tt.expectedStatus, result.ShowStatus)
+			}
+			if result.ThirdPartyIds.IMDBID != "tt31938062" {
+				t.Errorf("Expected IMDB ID to be parsed alongside, got %q", result.ThirdPartyIds.IMDBID)
+			}
+		})
+	}
+}
+
+func TestSubtitleDetailsParser_ParseHtml_YearRange(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateHTMLWithBody(`<div class="adatlapAdat">
+	<div class="adatlapRow"><span>Év:</span><span>2008 - 2013</span></div>
+</div>`)
+
+	result, err := NewSubtitleDetailsParser().ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if result.ShowYear != 2008 {
+		t.Errorf("Expected the first year of the range, got %d", result.ShowYear)
+	}
+}
@@ -9,94 +9,48 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/rs/zerolog"
 )
 
 // Pre-compiled regex patterns for performance
 var (
-	seasonPackRegex   = regexp.MustCompile(`\(Season\s+(\d+)\)`)
-	episodeRegex      = regexp.MustCompile(`(\d+)x(\d+)`)
-	episodeRangeRegex = regexp.MustCompile(`(\d+)x(\d{1,2})\s*-\s*(\d{1,2})\s*(?:\(|$)`)
-	odalPageRegex     = regexp.MustCompile(`(?:oldal|page)=(\d+)`)
-	parenthesesRegex  = regexp.MustCompile(`\s*\([^)]*\)`)
+	// Season-level marker of an original title: "(Season 2)" or the multi-season "(Season 1-3)"
+	seasonPackRegex = regexp.MustCompile(`\(Season\s+(\d+)(?:\s*-\s*(\d+))?\)`)
+	// Season-level marker of a Hungarian title: "(2. évad)" or the multi-season "(1-3. évad)"
+	hungarianSeasonPackRegex = regexp.MustCompile(`\((\d+)(?:\s*-\s*(\d+))?\.\s*[ée]vad\)`)
+	episodeRegex             = regexp.MustCompile(`(\d+)x(\d+)`)
+	episodeRangeRegex        = regexp.MustCompile(`(\d+)x(\d{1,2})\s*-\s*(\d{1,2})\s*(?:\(|$)`)
+	odalPageRegex            = regexp.MustCompile(`(?:oldal|page)=(\d+)`)
+	parenthesesRegex         = regexp.MustCompile(`\s*\([^)]*\)`)
+	relativeDateRegex        = regexp.MustCompile(`^(\d+)\s*(napja|órája|perce)$`)
+	// Episode ("- 7x16") or Hungarian season ("(1. évad)") suffix of a Hungarian title, with anything after it
+	hungarianTitleSuffixRegex = regexp.MustCompile(`\s*(?:-\s*\d+x\d+|\(\d+(?:\s*-\s*\d+)?\.\s*[ée]vad\)).*$`)
+	// Scene-style filename: show name followed by "S01E04", "S01" or "1x04"
+	filenameEpisodeRegex   = regexp.MustCompile(`(?i)^(.+?)[ ._-]+(?:S(\d{1,2})(?:E(\d{1,4}))?|(\d{1,2})x(\d{1,4}))(?:[ ._-]|$)`)
+	filenameSeparatorRegex = regexp.MustCompile(`[._\s]+`)
+	// Movie title with its release year: "Oppenheimer (2023)", optionally followed by release info
+	movieTitleRegex = regexp.MustCompile(`^(.+?)\s*\(((?:19|20)\d{2})\)(.*)$`)
+	// Calendar date in a title, as "2024-05-01", "2024.05.01." or the Hungarian "2024. 05. 01."
+	airDateRegex = regexp.MustCompile(`(?:^|\D)((?:19|20)\d{2})(?:-|\.\s?)(\d{1,2})(?:-|\.\s?)(\d{1,2})(?:\D|$)`)
+	// Addic7ed filename tail of a title copied from the file: ".EDITH.English.C.orig.Addic7ed.com"
+	// is the release, language, optional one- or two-letter tag, "orig" and the site
+	addic7edTailRegex = regexp.MustCompile(`(?i)\.[^.\s]+\.[a-z]+(?:\.[a-z]{1,2})?(?:\.orig)?\.addic7ed\.com$`)
+	// Source marker at the end of a title, bare or in brackets: "orig", "Addic7ed" or "Addic7ed.com"
+	titleSourceSuffixRegex = regexp.MustCompile(`(?i)[\s._-]*(?:[(\[]\s*(?:orig|addic7ed(?:\.com)?)\s*[)\]]|\borig|\baddic7ed(?:\.com)?)$`)
 )
 
-// languageToISO maps Hungarian language names to ISO 639-1 codes
-// Based on common languages found on feliratok.eu
-var languageToISO = map[string]string{
-	// Hungarian names (lowercase for case-insensitive matching)
-	"magyar":   "hu",
-	"angol":    "en",
-	"német":    "de",
-	"francia":  "fr",
-	"spanyol":  "es",
-	"olasz":    "it",
-	"orosz":    "ru",
-	"portugál": "pt",
-	"holland":  "nl",
-	"lengyel":  "pl",
-	"török":    "tr",
-	"arab":     "ar",
-	"héber":    "he",
-	"japán":    "ja",
-	"kínai":    "zh",
-	"koreai":   "ko",
-	"cseh":     "cs",
-	"dán":      "da",
-	"finn":     "fi",
-	"görög":    "el",
-	"norvég":   "no",
-	"svéd":     "sv",
-	"román":    "ro",
-	"szerb":    "sr",
-	"horvát":   "hr",
-	"bolgár":   "bg",
-	"ukrán":    "uk",
-	"thai":     "th",
-	"vietnámi": "vi",
-	"indonéz":  "id",
-	"hindi":    "hi",
-	"perzsa":   "fa",
-	"brazil":   "pt", // Brazilian Portuguese maps to pt
-
-	// English names (fallback)
-	"hungarian":  "hu",
-	"english":    "en",
-	"german":     "de",
-	"french":     "fr",
-	"spanish":    "es",
-	"italian":    "it",
-	"russian":    "ru",
-	"portuguese": "pt",
-	"dutch":      "nl",
-	"polish":     "pl",
-	"turkish":    "tr",
-	"arabic":     "ar",
-	"hebrew":     "he",
-	"japanese":   "ja",
-	"chinese":    "zh",
-	"korean":     "ko",
-	"czech":      "cs",
-	"danish":     "da",
-	"finnish":    "fi",
-	"greek":      "el",
-	"norwegian":  "no",
-	"swedish":    "sv",
-	"romanian":   "ro",
-	"serbian":    "sr",
-	"croatian":   "hr",
-	"bulgarian":  "bg",
-	"ukrainian":  "uk",
-	"vietnamese": "vi",
-	"indonesian": "id",
-}
-
 // SubtitleParser implements the Parser interface for parsing HTML subtitle listings
 type SubtitleParser struct {
 	baseURL string
+	now     func() time.Time
+	logger  *zerolog.Logger // nil logs through the service logger
+	// rawTitles keeps episode titles as listed instead of passing them through NormalizeEpisodeTitle
+	rawTitles bool
 }
 
 // SubtitlePageResult contains parsed subtitles and pagination information
@@ -109,39 +63,69 @@ type SubtitlePageResult struct {
 
 // NewSubtitleParser creates a new subtitle parser instance
 func NewSubtitleParser(baseURL string) *SubtitleParser {
+	return NewSubtitleParserWithClock(baseURL, time.Now)
+}
+
+// NewSubtitleParserWithClock creates a subtitle parser that resolves relative upload
+// dates ("ma", "tegnap", "3 napja", ...) against now instead of the wall clock.
+func NewSubtitleParserWithClock(baseURL string, now func() time.Time) *SubtitleParser {
 	return &SubtitleParser{
 		baseURL: baseURL,
+		now:     now,
 	}
 }
 
-// convertLanguageToISO converts a language name (Hungarian or English) to ISO 639-1 code
+// WithLogger makes the parser log through logger instead of the service logger and returns it.
+// Parsers used outside the service set one so parsing never loads the service configuration.
+func (p *SubtitleParser) WithLogger(logger zerolog.Logger) *SubtitleParser {
+	p.logger = &logger
+	return p
+}
+
+// WithTitleNormalization sets whether episode titles go through NormalizeEpisodeTitle, which
+// is the default, and returns the parser. The listed title is kept in Subtitle.RawName either way.
+func (p *SubtitleParser) WithTitleNormalization(enabled bool) *SubtitleParser {
+	p.rawTitles = !enabled
+	return p
+}
+
+// log returns the logger the parser writes to
+func (p *SubtitleParser) log() zerolog.Logger {
+	if p.logger != nil {
+		return *p.logger
+	}
+	return config.GetLogger()
+}
+
+// ConvertLanguageToISO converts a language name (Hungarian or English) to ISO 639-1 code
 // Returns the ISO code if found, otherwise returns the original input
-func convertLanguageToISO(languageName string) string {
+func ConvertLanguageToISO(languageName string) string {
+	isoCode, _ := languageToISO(languageName)
+	return isoCode
+}
+
+// languageToISO is ConvertLanguageToISO that also reports whether the name was recognized
+func languageToISO(languageName string) (string, bool) {
 	// Normalize to lowercase and trim
 	normalized := strings.ToLower(strings.TrimSpace(languageName))
 
 	if normalized == "" {
-		return ""
+		return "", true
 	}
 
-	// Look up in the map
-	if isoCode, exists := languageToISO[normalized]; exists {
-		return isoCode
+	// Look up in the canonical language table
+	if isoCode, exists := models.LanguageISOCode(normalized); exists {
+		return isoCode, true
 	}
 
 	// If already looks like an ISO code (2-3 letters), return as-is
 	if len(normalized) == 2 || len(normalized) == 3 {
 		// Could be already an ISO code
-		return normalized
+		return normalized, true
 	}
 
-	logger := config.GetLogger()
-	logger.Debug().
-		Str("languageName", languageName).
-		Msg("Unknown language name, returning original value")
-
 	// Return original if no mapping found
-	return languageName
+	return languageName, false
 }
 
 // ParseHtml implements the Parser[models.Subtitle] interface
@@ -153,9 +137,13 @@ func (p *SubtitleParser) ParseHtml(body io.Reader) ([]models.Subtitle, error) {
 	return result.Subtitles, nil
 }
 
-// ParseHtmlWithPagination parses HTML and returns both subtitles and pagination info
+// ParseHtmlWithPagination parses HTML and returns both subtitles and pagination info.
+// A page without the listing's result table is not an empty listing: it returns
+// apperrors.ErrUpstreamBlocked when it looks like a captcha or login page,
+// apperrors.ErrUpstreamMaintenance when it looks like a maintenance or server error page,
+// and apperrors.ErrUnexpectedPage otherwise.
 func (p *SubtitleParser) ParseHtmlWithPagination(body io.Reader) (*SubtitlePageResult, error) {
-	logger := config.GetLogger()
+	logger := p.log()
 	logger.Info().Msg("Starting HTML parsing for subtitles")
 
 	// Convert any character encoding to UTF-8 before parsing
@@ -171,6 +159,19 @@ func (p *SubtitleParser) ParseHtmlWithPagination(body io.Reader) (*SubtitlePageR
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	if doc.Find("table.result").Length() == 0 {
+		if reason := detectBlockPage(doc); reason != "" {
+			logger.Error().Str("reason", reason).Msg("Upstream served a block page instead of the subtitle listing")
+			return nil, &apperrors.ErrUpstreamBlocked{Reason: reason}
+		}
+		if reason := detectMaintenancePage(doc); reason != "" {
+			logger.Error().Str("reason", reason).Msg("Upstream served a maintenance page instead of the subtitle listing")
+			return nil, &apperrors.ErrUpstreamMaintenance{Reason: reason}
+		}
+		logger.Error().Msg("Page has no subtitle listing table")
+		return nil, &apperrors.ErrUnexpectedPage{Page: "subtitle listing", Reason: "no result table"}
+	}
+
 	logger.Debug().Msg("HTML document parsed successfully, starting subtitle extraction")
 
 	var subtitles []models.Subtitle
@@ -215,38 +216,55 @@ func (p *SubtitleParser) ParseHtmlWithPagination(body io.Reader) (*SubtitlePageR
 
 // extractSubtitleFromRow extracts subtitle information from a table row
 func (p *SubtitleParser) extractSubtitleFromRow(tds *goquery.Selection) *models.Subtitle {
-	logger := config.GetLogger()
-
-	// Expected structure: | Category | Language | Description | Uploader | Date | Download |
-	// That's exactly 6 columns
+	logger := p.log()
 
-	if tds.Length() < 6 {
+	// Show listings: | Category | Language | Description | Uploader | Date | Download |
+	// Movie listings drop the category column: | Language | Description | Uploader | Date | Download |
+	isMovie := isMovieRow(tds)
+	if !isMovie && tds.Length() < 6 {
 		return nil
 	}
+	first := 1
+	if isMovie {
+		first = 0
+	}
+	languageTd, descriptionCol, uploaderTd, dateTd, downloadTd := tds.Eq(first), tds.Eq(first+1), tds.Eq(first+2), tds.Eq(first+3), tds.Eq(first+4)
 
-	// Extract show ID from category column (column 0)
-	// The category column contains a link like: <a href="index.php?sid=13051">
-	categoryTd := tds.Eq(0)
-	showID := p.extractShowIDFromCategory(categoryTd)
+	// Extract show ID from the category column, which links to the show like <a href="index.php?sid=13051">
+	showID := 0
+	if !isMovie {
+		showID = p.extractShowIDFromCategory(tds.Eq(0))
+	}
 
-	// Extract language from column 1
-	language := strings.TrimSpace(tds.Eq(1).Text())
+	// Extract language
+	language := strings.TrimSpace(languageTd.Text())
 	if language == "" {
 		return nil
 	}
 
 	// Convert language name to ISO 639-1 code
-	languageISO := convertLanguageToISO(language)
+	languageISO, known := languageToISO(language)
+	if !known {
+		logger.Debug().
+			Str("languageName", language).
+			Msg("Unknown language name, returning original value")
+	}
 
-	// Extract description (show name, episode, release info) from column 2
-	descriptionTd := tds.Eq(2).Find(".eredeti")
-	description := strings.TrimSpace(descriptionTd.Text())
+	// Extract description (show name, episode, release info)
+	description := strings.TrimSpace(descriptionCol.Find(".eredeti").Text())
+	magyarTitle := strings.TrimSpace(descriptionCol.Find(".magyar").Text())
+	if description == "" {
+		// Some rows only carry the Hungarian title, which uses the same layout
+		description = magyarTitle
+	}
 	if description == "" {
 		return nil
 	}
 
-	// Extract download link from column 5 (the last column)
-	downloadTd := tds.Eq(5)
+	// Extract the Hungarian show title from the localized title
+	hungarianShowName := extractHungarianShowName(magyarTitle)
+
+	// Extract download link from the last column
 	downloadLink, exists := downloadTd.Find("a").Attr("href")
 	if !exists {
 		logger.Debug().Str("description", description).Msg("No download link found")
@@ -263,10 +281,43 @@ func (p *SubtitleParser) extractSubtitleFromRow(tds *goquery.Selection) *models.
 	// Normalize the URL by decoding it (ensures properly formed URLs)
 	downloadURL = p.normalizeDownloadURL(downloadURL)
 
+	if isMovie {
+		return p.buildMovieSubtitle(description, magyarTitle, languageISO, downloadLink, downloadURL, uploaderTd, dateTd)
+	}
+
 	// Parse description to extract show name, season, episode, and release info.
 	// Archive filename extension is the only source of truth for season-pack classification.
 	showName, season, episode, releaseInfo := p.parseDescription(description)
+	_, _, _, seasonEnd, _ := parseSeasonMarker(description)
+	if season == -1 {
+		// A malformed original title can still come with a season-level Hungarian title ("(2. évad)")
+		if _, _, magyarSeason, magyarSeasonEnd, ok := parseSeasonMarker(magyarTitle); ok {
+			logger.Debug().
+				Str("description", description).
+				Str("magyarTitle", magyarTitle).
+				Int("season", magyarSeason).
+				Msg("Recovered season from Hungarian title")
+			showName = strings.TrimSpace(parenthesesRegex.ReplaceAllString(description, ""))
+			season, episode, seasonEnd = magyarSeason, -1, magyarSeasonEnd
+		}
+	}
+	if season == -1 {
+		// The description had no season/episode pattern, so try the scene-style download filename
+		if name, fileSeason, fileEpisode, ok := parseFilenameEpisode(p.extractFilenameFromDownloadLink(downloadLink)); ok {
+			logger.Debug().
+				Str("description", description).
+				Str("showName", name).
+				Int("season", fileSeason).
+				Int("episode", fileEpisode).
+				Msg("Recovered show name and episode from download filename")
+			showName, season, episode = name, fileSeason, fileEpisode
+		}
+	}
 	isSeasonPack := p.isArchiveSeasonPack(downloadLink)
+	if season == -1 && showID == 0 && !isSeasonPack {
+		// A row that links to no show and names no episode is a film listed in the show layout
+		return p.buildMovieSubtitle(description, magyarTitle, languageISO, downloadLink, downloadURL, uploaderTd, dateTd)
+	}
 	var rangeStart, rangeEnd *int
 
 	if isSeasonPack {
@@ -285,48 +336,135 @@ func (p *SubtitleParser) extractSubtitleFromRow(tds *goquery.Selection) *models.
 	}
 
 	// Extract qualities and release groups from release info
-	qualities, releaseGroups := p.parseReleaseInfo(releaseInfo)
+	qualities, releaseGroups, releaseVariants := p.ParseReleaseInfo(releaseInfo)
 
-	// Extract uploader from column 3
-	uploader := strings.TrimSpace(tds.Eq(3).Text())
+	// Extract uploader
+	uploader := strings.TrimSpace(uploaderTd.Text())
+	uploaderID := p.extractUploaderID(uploaderTd)
+	uploaderVerified := isUploaderVerified(uploaderTd)
 
-	// Extract and parse date from column 4
-	dateStr := strings.TrimSpace(tds.Eq(4).Text())
+	// Extract and parse date
+	dateStr := strings.TrimSpace(dateTd.Text())
 	uploadedAt := p.parseDate(dateStr)
 
-	// Generate ID from download link
-	subtitleID := p.extractIDFromDownloadLink(downloadLink)
+	subtitleID, idIsSynthetic := p.subtitleIDFromDownloadLink(downloadLink, downloadURL)
 
 	// Extract filename from download link
 	filename := p.extractFilenameFromDownloadLink(downloadLink)
 
+	airDate := extractAirDate(description, magyarTitle)
+
 	// Extract only the episode title from description
-	episodeTitle := ""
+	rawTitle := ""
 	if !isSeasonPack {
-		episodeTitle = extractEpisodeTitle(description)
+		rawTitle = ExtractEpisodeTitle(description)
+	}
+	episodeTitle := rawTitle
+	if !p.rawTitles {
+		episodeTitle = NormalizeEpisodeTitle(rawTitle)
 	}
 
 	return &models.Subtitle{
-		ID:            subtitleID,
-		ShowID:        showID,
-		Name:          episodeTitle,
-		ShowName:      showName,
-		Language:      languageISO,
-		Season:        season,
-		Episode:       episode,
-		Filename:      filename,
-		DownloadURL:   downloadURL,
-		Uploader:      uploader,
-		UploadedAt:    uploadedAt,
-		Qualities:     qualities,
-		ReleaseGroups: releaseGroups,
-		Release:       releaseInfo,
-		IsSeasonPack:  isSeasonPack,
-		RangeStart:    rangeStart,
-		RangeEnd:      rangeEnd,
+		ID:                subtitleID,
+		IDIsSynthetic:     idIsSynthetic,
+		ShowID:            showID,
+		Name:              episodeTitle,
+		RawName:           rawTitle,
+		ShowName:          showName,
+		HungarianShowName: hungarianShowName,
+		Language:          languageISO,
+		Season:            season,
+		Episode:           episode,
+		Filename:          filename,
+		DownloadURL:       downloadURL,
+		Uploader:          uploader,
+		UploaderID:        uploaderID,
+		UploaderVerified:  uploaderVerified,
+		IsHearingImpaired: models.IsHearingImpaired(description) || models.IsHearingImpaired(filename),
+		UploadedAt:        uploadedAt,
+		Qualities:         qualities,
+		ReleaseGroups:     releaseGroups,
+		ReleaseVariants:   releaseVariants,
+		Release:           releaseInfo,
+		IsSeasonPack:      isSeasonPack,
+		SeasonEnd:         seasonEnd,
+		RangeStart:        rangeStart,
+		RangeEnd:          rangeEnd,
+		ContentType:       models.ContentTypeSeries,
+		AirDate:           airDate,
 	}
 }
 
+// buildMovieSubtitle builds the subtitle of a movie listing row. Movies have no show ID,
+// season or episode; the title and release year are read from the description.
+func (p *SubtitleParser) buildMovieSubtitle(description, magyarTitle, languageISO, downloadLink, downloadURL string, uploaderTd, dateTd *goquery.Selection) *models.Subtitle {
+	movieTitle, movieYear, releaseInfo := p.parseMovieDescription(description)
+	qualities, releaseGroups, releaseVariants := p.ParseReleaseInfo(releaseInfo)
+	subtitleID, idIsSynthetic := p.subtitleIDFromDownloadLink(downloadLink, downloadURL)
+	filename := p.extractFilenameFromDownloadLink(downloadLink)
+
+	return &models.Subtitle{
+		ID:                subtitleID,
+		IDIsSynthetic:     idIsSynthetic,
+		ShowName:          movieTitle,
+		HungarianShowName: strings.TrimSpace(parenthesesRegex.ReplaceAllString(magyarTitle, "")),
+		MovieTitle:        movieTitle,
+		MovieYear:         movieYear,
+		Language:          languageISO,
+		Season:            -1,
+		Episode:           -1,
+		Filename:          filename,
+		DownloadURL:       downloadURL,
+		Uploader:          strings.TrimSpace(uploaderTd.Text()),
+		UploaderID:        p.extractUploaderID(uploaderTd),
+		UploaderVerified:  isUploaderVerified(uploaderTd),
+		IsHearingImpaired: models.IsHearingImpaired(description) || models.IsHearingImpaired(filename),
+		UploadedAt:        p.parseDate(strings.TrimSpace(dateTd.Text())),
+		Qualities:         qualities,
+		ReleaseGroups:     releaseGroups,
+		ReleaseVariants:   releaseVariants,
+		Release:           releaseInfo,
+		ContentType:       models.ContentTypeFilm,
+	}
+}
+
+// parseMovieDescription splits a movie description into title, release year and release info.
+// Example: "Oppenheimer (2023) (WEB.1080p-FLUX)" -> "Oppenheimer", 2023, "WEB.1080p-FLUX"
+// A description without a year keeps its title without parentheses and a zero year.
+func (p *SubtitleParser) parseMovieDescription(description string) (title string, year int, releaseInfo string) {
+	if matches := movieTitleRegex.FindStringSubmatch(description); matches != nil {
+		year, _ = strconv.Atoi(matches[2])
+		return strings.TrimSpace(matches[1]), year, p.extractReleaseInfo(matches[3])
+	}
+	return strings.TrimSpace(parenthesesRegex.ReplaceAllString(description, "")), 0, p.extractReleaseInfo(description)
+}
+
+// isMovieRow reports whether a listing row uses the 5-column movie layout, which has no
+// category column linking to a show.
+func isMovieRow(tds *goquery.Selection) bool {
+	if tds.Length() != 5 {
+		return false
+	}
+	href, _ := tds.Eq(0).Find("a").Attr("href")
+	return !strings.Contains(href, "sid=")
+}
+
+// subtitleIDFromDownloadLink returns the subtitle ID of a download link, falling back to a
+// synthetic ID hashed from the download URL when the link has no numeric ID.
+func (p *SubtitleParser) subtitleIDFromDownloadLink(downloadLink, downloadURL string) (int, bool) {
+	if subtitleID := p.extractIDFromDownloadLink(downloadLink); subtitleID > 0 {
+		return subtitleID, false
+	}
+
+	subtitleID := models.SyntheticSubtitleID(downloadURL)
+	logger := p.log()
+	logger.Debug().
+		Str("downloadLink", downloadLink).
+		Int("subtitleID", subtitleID).
+		Msg("Download link has no numeric subtitle ID; using synthetic ID")
+	return subtitleID, true
+}
+
 // isArchiveSeasonPack returns true when the download filename extension indicates
 // a multi-subtitle archive package.
 func (p *SubtitleParser) isArchiveSeasonPack(downloadLink string) bool {
@@ -365,7 +503,7 @@ func (p *SubtitleParser) extractEpisodeRange(description string) (*int, *int) {
 // extractShowIDFromCategory extracts the show ID from the category column's link
 // Example: <a href="index.php?sid=13051"> or <a href="/index.php?sid=13051">
 func (p *SubtitleParser) extractShowIDFromCategory(categoryTd *goquery.Selection) int {
-	logger := config.GetLogger()
+	logger := p.log()
 
 	// Find the link in the category column
 	href, exists := categoryTd.Find("a").Attr("href")
@@ -394,29 +532,63 @@ func (p *SubtitleParser) extractShowIDFromCategory(categoryTd *goquery.Selection
 	return showID
 }
 
+// extractUploaderID extracts the uploader identifier from the uploader column's profile link.
+// Example: <a href="index.php?felt=Kovacs"> or <a href="/index.php?tab=profil&id=1234">
+// Plain-text uploaders (e.g. "Anonymus") have no link and yield an empty ID.
+func (p *SubtitleParser) extractUploaderID(uploaderTd *goquery.Selection) string {
+	logger := p.log()
+
+	href, exists := uploaderTd.Find("a").Attr("href")
+	if !exists {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(href)
+	if err != nil {
+		logger.Debug().Str("href", href).Err(err).Msg("Failed to parse uploader link")
+		return ""
+	}
+
+	queryParams := parsedURL.Query()
+	if felt := strings.TrimSpace(queryParams.Get("felt")); felt != "" {
+		return felt
+	}
+	if id := queryParams.Get("id"); id != "" {
+		if _, err := strconv.Atoi(id); err == nil {
+			return id
+		}
+		logger.Debug().Str("id", id).Msg("Ignoring non-numeric uploader id")
+	}
+
+	return ""
+}
+
+// isUploaderVerified reports whether the uploader name is bold, which the site uses for
+// official translators and fansub teams.
+// Example: <a href="index.php?felt=Kovacs"><b>Kovacs</b></a>
+func isUploaderVerified(uploaderTd *goquery.Selection) bool {
+	return strings.TrimSpace(uploaderTd.Find("b").Text()) != ""
+}
+
 // parseDescription extracts show name, season, episode, and release info from a title.
 // Example: "Outlander - Az idegen - 7x16 Outlander - 7x16 - A Hundred Thousand Angels (AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab)"
 // Example: "- Billy the Kid (Season 2) (WEB.720p-EDITH, AMZN.WEB-DL.720p-FLUX)"
 func (p *SubtitleParser) parseDescription(description string) (showName string, season int, episode int, releaseInfo string) {
-	logger := config.GetLogger()
+	logger := p.log()
 
 	// Season-level titles expose the season number without an episode number.
-	if matches := seasonPackRegex.FindStringSubmatch(description); len(matches) > 1 {
-		seasonNum, _ := strconv.Atoi(matches[1])
+	if start, end, seasonNum, _, ok := parseSeasonMarker(description); ok {
 		season = seasonNum
 		episode = -1
 
-		// Extract show name (everything before "(Season")
-		parts := strings.Split(description, "(Season")
-		if len(parts) > 0 {
-			showName = strings.TrimSpace(parts[0])
-			// Remove leading dash if present
-			showName = strings.TrimPrefix(showName, "- ")
-			showName = strings.TrimSpace(showName)
-		}
+		// Extract show name (everything before the season marker)
+		showName = strings.TrimSpace(description[:start])
+		// Remove leading dash if present
+		showName = strings.TrimPrefix(showName, "- ")
+		showName = strings.TrimSpace(showName)
 
-		// Extract release info from last parentheses
-		releaseInfo = p.extractReleaseInfo(description)
+		// Extract release info from the last parentheses after the season marker
+		releaseInfo = p.extractReleaseInfo(description[end:])
 
 		logger.Debug().
 			Str("description", description).
@@ -492,9 +664,66 @@ func (p *SubtitleParser) parseDescription(description string) (showName string,
 	showName = description
 	season = -1
 	episode = -1
+	// Release info can still be recovered from the title's last parentheses
+	releaseInfo = p.extractReleaseInfo(description)
 	return
 }
 
+// parseSeasonMarker finds the season-level marker of a title: "(Season 2)" or "(2. évad)", or the
+// multi-season "(Season 1-3)" and "(1-3. évad)". It returns the marker's byte offsets, the first
+// season and, for multi-season markers, the last season.
+// Example: "Billy the Kid (Season 2) (WEB.720p-EDITH)" -> 14, 24, 2, nil, true
+// Example: "Az iroda (1-3. évad)" -> season 1, seasonEnd 3
+func parseSeasonMarker(title string) (start, end, season int, seasonEnd *int, ok bool) {
+	loc := seasonPackRegex.FindStringSubmatchIndex(title)
+	if loc == nil {
+		loc = hungarianSeasonPackRegex.FindStringSubmatchIndex(title)
+	}
+	if loc == nil {
+		return 0, 0, -1, nil, false
+	}
+
+	season, _ = strconv.Atoi(title[loc[2]:loc[3]])
+	if loc[4] != -1 {
+		last, _ := strconv.Atoi(title[loc[4]:loc[5]])
+		if last < season {
+			season, last = last, season
+		}
+		if last > season {
+			seasonEnd = &last
+		}
+	}
+	return loc[0], loc[1], season, seasonEnd, true
+}
+
+// parseFilenameEpisode extracts the show name, season and episode from a scene-style filename.
+// It is the fallback for descriptions without a season/episode pattern.
+// Example: "The.Copenhagen.Test.S01E04.720p.WEB.srt" -> "The Copenhagen Test", 1, 4
+// Example: "Billy.the.Kid.S02.zip" -> "Billy the Kid", 2, -1
+func parseFilenameEpisode(filename string) (showName string, season int, episode int, ok bool) {
+	matches := filenameEpisodeRegex.FindStringSubmatch(strings.TrimSpace(filename))
+	if matches == nil {
+		return "", -1, -1, false
+	}
+
+	showName = strings.TrimSpace(filenameSeparatorRegex.ReplaceAllString(matches[1], " "))
+	showName = strings.TrimSpace(strings.TrimRight(showName, "-"))
+	if showName == "" {
+		return "", -1, -1, false
+	}
+
+	seasonStr, episodeStr := matches[2], matches[3]
+	if seasonStr == "" {
+		seasonStr, episodeStr = matches[4], matches[5]
+	}
+	season, _ = strconv.Atoi(seasonStr)
+	episode = -1
+	if episodeStr != "" {
+		episode, _ = strconv.Atoi(episodeStr)
+	}
+	return showName, season, episode, true
+}
+
 // extractReleaseInfo extracts the release info from the last parentheses in a description string
 func (p *SubtitleParser) extractReleaseInfo(description string) string {
 	idx := strings.LastIndex(description, "(")
@@ -510,16 +739,18 @@ func (p *SubtitleParser) extractReleaseInfo(description string) string {
 	return description[idx+1 : idx+endIdx]
 }
 
-// parseReleaseInfo extracts qualities and multiple release groups from release info string
+// ParseReleaseInfo extracts qualities and multiple release groups from release info string
 // Example: "AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab"
 // Release groups are deduplicated case-insensitively (e.g., "FLUX" and "flux" are treated as the same)
-func (p *SubtitleParser) parseReleaseInfo(releaseInfo string) (qualities []models.Quality, releaseGroups []string) {
+// Variants keep every release apart, in order and without deduplication.
+func (p *SubtitleParser) ParseReleaseInfo(releaseInfo string) (qualities []models.Quality, releaseGroups []string, variants []models.ReleaseVariant) {
 	if releaseInfo == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	releaseGroups = make([]string, 0)
 	qualities = make([]models.Quality, 0)
+	variants = make([]models.ReleaseVariant, 0)
 	seenQualities := make(map[models.Quality]struct{})
 	seenGroups := make(map[string]struct{}) // Track groups case-insensitively (key is lowercase)
 
@@ -531,6 +762,7 @@ func (p *SubtitleParser) parseReleaseInfo(releaseInfo string) (qualities []model
 		if release == "" {
 			continue
 		}
+		variants = append(variants, p.parseReleaseVariant(release))
 
 		// Extract release group (after the last dash)
 		if idx := strings.LastIndex(release, "-"); idx != -1 {
@@ -554,29 +786,19 @@ func (p *SubtitleParser) parseReleaseInfo(releaseInfo string) (qualities []model
 		}
 	}
 
-	return qualities, releaseGroups
+	return qualities, releaseGroups, variants
 }
 
 // detectQuality detects video quality from a release string
 func (p *SubtitleParser) detectQuality(release string) models.Quality {
-	lowerRelease := strings.ToLower(release)
-	switch {
-	case strings.Contains(lowerRelease, "2160p") || strings.Contains(lowerRelease, "4k"):
-		return models.Quality2160p
-	case strings.Contains(lowerRelease, "1080p"):
-		return models.Quality1080p
-	case strings.Contains(lowerRelease, "720p"):
-		return models.Quality720p
-	case strings.Contains(lowerRelease, "480p"):
-		return models.Quality480p
-	case strings.Contains(lowerRelease, "360p"):
-		return models.Quality360p
-	default:
-		return models.QualityUnknown
-	}
-}
-
-// parseDate parses a date string in the format "YYYY-MM-DD"
+	return models.DetectQuality(release)
+}
+
+// parseDate parses a date string in the format "YYYY-MM-DD", or one of the relative
+// Hungarian forms the site uses for recent uploads: "ma" (today), "tegnap" (yesterday),
+// "tegnapelőtt" (the day before yesterday), "N napja" (N days ago), "N órája" (N hours ago)
+// and "N perce" (N minutes ago). Day-granular forms resolve to midnight UTC of the
+// computed calendar day, matching absolute dates; hour and minute forms keep the time.
 func (p *SubtitleParser) parseDate(dateStr string) time.Time {
 	if dateStr == "" {
 		return time.Time{}
@@ -584,13 +806,74 @@ func (p *SubtitleParser) parseDate(dateStr string) time.Time {
 
 	// Try parsing in YYYY-MM-DD format
 	t, err := time.Parse("2006-01-02", dateStr)
+	if err == nil {
+		return t
+	}
+
+	if relative, ok := p.parseRelativeDate(dateStr); ok {
+		return relative
+	}
+
+	logger := p.log()
+	logger.Debug().Str("dateStr", dateStr).Err(err).Msg("Failed to parse date")
+	return time.Time{}
+}
+
+// extractAirDate returns the first valid calendar date named in titles, such as the
+// "(2024-05-01)" after an episode title or the date in a daily show's release
+// "Show.2024.05.01.720p.WEB-GRP", at midnight UTC. It returns the zero time when
+// no title names one; an impossible date such as "2024-13-40" is skipped.
+func extractAirDate(titles ...string) time.Time {
+	for _, title := range titles {
+		for _, match := range airDateRegex.FindAllStringSubmatch(title, -1) {
+			year, _ := strconv.Atoi(match[1])
+			month, _ := strconv.Atoi(match[2])
+			day, _ := strconv.Atoi(match[3])
+			date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+			// time.Date normalizes out-of-range values, so "2024-02-30" comes back as March 1
+			if date.Month() == time.Month(month) && date.Day() == day {
+				return date
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// parseRelativeDate resolves a relative Hungarian date against the parser's clock.
+func (p *SubtitleParser) parseRelativeDate(dateStr string) (time.Time, bool) {
+	now := p.now()
+	daysAgo := func(days int) time.Time {
+		day := now.AddDate(0, 0, -days)
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	normalized := strings.ToLower(strings.Join(strings.Fields(dateStr), " "))
+	switch normalized {
+	case "ma":
+		return daysAgo(0), true
+	case "tegnap":
+		return daysAgo(1), true
+	case "tegnapelőtt":
+		return daysAgo(2), true
+	}
+
+	matches := relativeDateRegex.FindStringSubmatch(normalized)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	amount, err := strconv.Atoi(matches[1])
 	if err != nil {
-		logger := config.GetLogger()
-		logger.Debug().Str("dateStr", dateStr).Err(err).Msg("Failed to parse date")
-		return time.Time{}
+		return time.Time{}, false
 	}
 
-	return t
+	switch matches[2] {
+	case "napja":
+		return daysAgo(amount), true
+	case "órája":
+		return now.Add(-time.Duration(amount) * time.Hour).UTC(), true
+	default: // "perce"
+		return now.Add(-time.Duration(amount) * time.Minute).UTC(), true
+	}
 }
 
 // constructDownloadURL constructs the full download URL from a relative link
@@ -616,7 +899,7 @@ func (p *SubtitleParser) constructDownloadURL(link string) string {
 // normalizeDownloadURL ensures the download URL is properly decoded and normalized
 // It parses the URL and reconstructs it with properly decoded query parameters
 func (p *SubtitleParser) normalizeDownloadURL(downloadURL string) string {
-	logger := config.GetLogger()
+	logger := p.log()
 
 	// Parse the URL
 	parsedURL, err := url.Parse(downloadURL)
@@ -688,14 +971,14 @@ func (p *SubtitleParser) extractIDFromDownloadLink(link string) int {
 	}
 
 	// Last resort: log and return a sentinel invalid ID (-1)
-	logger := config.GetLogger()
+	logger := p.log()
 	logger.Debug().Str("link", link).Msg("Failed to extract ID from download link; returning invalid ID sentinel")
 	return -1
 }
 
 // extractFilenameFromDownloadLink extracts the filename from the fnev parameter in the download link
 func (p *SubtitleParser) extractFilenameFromDownloadLink(link string) string {
-	logger := config.GetLogger()
+	logger := p.log()
 
 	// Look for fnev parameter in the URL
 	re := regexp.MustCompile(`fnev=([^&]+)`)
@@ -727,17 +1010,29 @@ func removeParentheticalContent(text string) string {
 	return result
 }
 
-// extractEpisodeTitle extracts only the episode title from a subtitle description
+// extractHungarianShowName extracts the show name from the Hungarian title of a subtitle row
+// Example: "Outlander - Az idegen - 7x16" -> "Outlander - Az idegen"
+// Example: "Vészhelyzet Pittsburghben (1. évad)" -> "Vészhelyzet Pittsburghben"
+// Example: "Pursuit of Jade" -> "Pursuit of Jade"
+func extractHungarianShowName(title string) string {
+	title = strings.TrimSpace(title)
+	if loc := hungarianTitleSuffixRegex.FindStringIndex(title); loc != nil {
+		title = title[:loc[0]]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(title), "-"))
+}
+
+// ExtractEpisodeTitle extracts only the episode title from a subtitle description
 // Example: "Outlander - Az idegen - 7x16 Outlander - 7x16 - A Hundred Thousand Angels (AMZN...)" -> "A Hundred Thousand Angels"
 // Example: "Billy the Kid (Season 2) (WEB...)" -> "" (season-level titles have no episode title)
 // Example: "Show - 2x05 - Title With - Many - Dashes (Release)" -> "Title With - Many - Dashes"
-func extractEpisodeTitle(description string) string {
+func ExtractEpisodeTitle(description string) string {
 	if description == "" {
 		return ""
 	}
 
 	// Season-level titles do not carry an episode title.
-	if seasonPackRegex.MatchString(description) {
+	if _, _, _, _, ok := parseSeasonMarker(description); ok {
 		return ""
 	}
 
@@ -779,9 +1074,28 @@ func extractEpisodeTitle(description string) string {
 	return strings.TrimRight(withoutParens, ".- ")
 }
 
+// NormalizeEpisodeTitle removes source artifacts that titles copied from a filename carry, such
+// as the Addic7ed tail of "The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com" or a trailing
+// "[orig]", and collapses runs of whitespace. A title that is only such artifacts is kept as is.
+func NormalizeEpisodeTitle(title string) string {
+	normalized := addic7edTailRegex.ReplaceAllString(title, "")
+	for {
+		trimmed := titleSourceSuffixRegex.ReplaceAllString(normalized, "")
+		if trimmed == normalized {
+			break
+		}
+		normalized = trimmed
+	}
+	normalized = strings.Join(strings.Fields(normalized), " ")
+	if normalized == "" {
+		return strings.Join(strings.Fields(title), " ")
+	}
+	return normalized
+}
+
 // extractPaginationInfo extracts current page and total pages from the document
 func (p *SubtitleParser) extractPaginationInfo(doc *goquery.Document) (currentPage int, totalPages int) {
-	logger := config.GetLogger()
+	logger := p.log()
 
 	// Default values
 	currentPage = 1
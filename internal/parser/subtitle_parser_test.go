@@ -1,12 +1,14 @@
 package parser
 
 import (
+	"errors"
 	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
 )
@@ -54,6 +56,10 @@ func TestSubtitleParser_ParseHtmlWithPagination_ExampleOutlander(t *testing.T) {
 	if subtitle.ShowName != "Outlander" {
 		t.Errorf("Expected show name %q, got %q", "Outlander", subtitle.ShowName)
 	}
+	// The Hungarian show name is extracted from magyar
+	if subtitle.HungarianShowName != "Outlander - Az idegen" {
+		t.Errorf("Expected Hungarian show name %q, got %q", "Outlander - Az idegen", subtitle.HungarianShowName)
+	}
 	if subtitle.Season != 7 || subtitle.Episode != 16 {
 		t.Errorf("Expected season 7 episode 16, got %d %d", subtitle.Season, subtitle.Episode)
 	}
@@ -108,6 +114,14 @@ func TestSubtitleParser_ParseHtmlWithPagination_ExampleOutlander(t *testing.T) {
 		t.Errorf("Expected release info %q, got %q", "AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab", subtitle.Release)
 	}
 
+	expectedVariants := []models.ReleaseVariant{
+		{Source: "AMZN", RipType: "WEB-DL", Quality: models.Quality720p, Group: "FLUX"},
+		{Source: "WEB", Quality: models.Quality1080p, Group: "SuccessfulCrab"},
+	}
+	if !reflect.DeepEqual(subtitle.ReleaseVariants, expectedVariants) {
+		t.Errorf("Expected release variants %+v, got %+v", expectedVariants, subtitle.ReleaseVariants)
+	}
+
 	if result.CurrentPage != 1 || result.TotalPages != 1 || result.HasNextPage {
 		t.Errorf("Expected pagination 1/1 with no next page, got %d/%d next=%v", result.CurrentPage, result.TotalPages, result.HasNextPage)
 	}
@@ -173,6 +187,73 @@ func TestSubtitleParser_ParseHtmlWithPagination_SeasonPack(t *testing.T) {
 	}
 }
 
+func TestSubtitleParser_ParseHtmlWithPagination_HungarianSeasonPack(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "Billy the Kid (2. évad)",
+			EredetiTitle:     "Billy the Kid (WEB.720p-EDITH)",
+			Uploader:         "gricsi",
+			UploadDate:       "2024-09-14",
+			DownloadAction:   "letolt",
+			DownloadFilename: "billy.zip",
+			SubtitleID:       1726325506,
+		},
+		{
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "Az iroda (1-3. évad)",
+			Uploader:         "gricsi",
+			UploadDate:       "2024-09-14",
+			DownloadAction:   "letolt",
+			DownloadFilename: "the.office.zip",
+			SubtitleID:       1726325507,
+		},
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	result, err := parser.ParseHtmlWithPagination(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtmlWithPagination failed: %v", err)
+	}
+
+	if len(result.Subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(result.Subtitles))
+	}
+
+	// The original title has no season marker, so the season comes from the Hungarian title
+	single := result.Subtitles[0]
+	if single.ShowName != "Billy the Kid" || single.HungarianShowName != "Billy the Kid" {
+		t.Errorf("Expected show name %q, got %q / %q", "Billy the Kid", single.ShowName, single.HungarianShowName)
+	}
+	if single.Season != 2 || single.Episode != -1 || !single.IsSeasonPack {
+		t.Errorf("Expected season pack 2 episode -1, got %d %d %v", single.Season, single.Episode, single.IsSeasonPack)
+	}
+	if single.SeasonEnd != nil {
+		t.Errorf("Expected nil season end for a single season, got %d", *single.SeasonEnd)
+	}
+	if single.Release != "WEB.720p-EDITH" {
+		t.Errorf("Expected release info from the original title, got %q", single.Release)
+	}
+
+	// Without an original title the Hungarian title is parsed instead
+	multi := result.Subtitles[1]
+	if multi.ShowName != "Az iroda" {
+		t.Errorf("Expected show name %q, got %q", "Az iroda", multi.ShowName)
+	}
+	if multi.Season != 1 || multi.Episode != -1 || !multi.IsSeasonPack {
+		t.Errorf("Expected season pack 1 episode -1, got %d %d %v", multi.Season, multi.Episode, multi.IsSeasonPack)
+	}
+	if multi.SeasonEnd == nil || *multi.SeasonEnd != 3 {
+		t.Errorf("Expected season end 3, got %v", multi.SeasonEnd)
+	}
+	if multi.Release != "" {
+		t.Errorf("Expected no release info, got %q", multi.Release)
+	}
+}
+
 func TestSubtitleParser_ParseHtmlWithPagination_RangedEpisodeSeasonPack(t *testing.T) {
 	t.Parallel()
 
@@ -311,6 +392,67 @@ func TestSubtitleParser_ParseHtmlWithPagination_SeasonTitleNonArchiveIsNotSeason
 	}
 }
 
+func TestSubtitleParser_ParseHtmlWithPagination_UnparseableDescriptionUsesFilename(t *testing.T) {
+	t.Parallel()
+
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{
+			ShowID:           8101,
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "A koppenhágai teszt",
+			EredetiTitle:     "The Copenhagen Test - Brave New World (WEB.1080p-NTb)",
+			Uploader:         "gricsi",
+			UploadDate:       "2025-04-02",
+			DownloadAction:   "letolt",
+			DownloadFilename: "The.Copenhagen.Test.S01E04.1080p.WEB.h264-NTb.srt",
+			SubtitleID:       1743600001,
+		},
+		{
+			ShowID:           8102,
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Rejtély",
+			EredetiTitle:     "Mystery Special",
+			Uploader:         "gricsi",
+			UploadDate:       "2025-04-02",
+			DownloadAction:   "letolt",
+			DownloadFilename: "mystery_special.srt",
+			SubtitleID:       1743600002,
+		},
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	result, err := parser.ParseHtmlWithPagination(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtmlWithPagination failed: %v", err)
+	}
+
+	if len(result.Subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(result.Subtitles))
+	}
+
+	recovered := result.Subtitles[0]
+	if recovered.ShowName != "The Copenhagen Test" {
+		t.Errorf("Expected show name %q from filename, got %q", "The Copenhagen Test", recovered.ShowName)
+	}
+	if recovered.Season != 1 || recovered.Episode != 4 {
+		t.Errorf("Expected season 1 episode 4 from filename, got %d %d", recovered.Season, recovered.Episode)
+	}
+	if recovered.Release != "WEB.1080p-NTb" {
+		t.Errorf("Expected release info from description, got %q", recovered.Release)
+	}
+
+	// Without a scene-style filename the whole description remains the show name
+	unrecovered := result.Subtitles[1]
+	if unrecovered.ShowName != "Mystery Special" {
+		t.Errorf("Expected description as show name, got %q", unrecovered.ShowName)
+	}
+	if unrecovered.Season != -1 || unrecovered.Episode != -1 {
+		t.Errorf("Expected season -1 episode -1, got %d %d", unrecovered.Season, unrecovered.Episode)
+	}
+}
+
 func TestSubtitleParser_ParseHtmlWithPagination_OldalPagination(t *testing.T) {
 	t.Parallel()
 	// Generate proper HTML with oldal-based pagination
@@ -467,9 +609,9 @@ func TestConvertLanguageToISO(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := convertLanguageToISO(tt.input)
+			result := ConvertLanguageToISO(tt.input)
 			if result != tt.expected {
-				t.Errorf("convertLanguageToISO(%q) = %q, expected %q", tt.input, result, tt.expected)
+				t.Errorf("ConvertLanguageToISO(%q) = %q, expected %q", tt.input, result, tt.expected)
 			}
 		})
 	}
@@ -622,9 +764,85 @@ func TestExtractEpisodeTitle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := extractEpisodeTitle(tt.input)
+			result := ExtractEpisodeTitle(tt.input)
 			if result != tt.expected {
-				t.Errorf("extractEpisodeTitle(%q) = %q, expected %q", tt.input, result, tt.expected)
+				t.Errorf("ExtractEpisodeTitle(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeEpisodeTitle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Clean title", input: "A Hundred Thousand Angels", expected: "A Hundred Thousand Angels"},
+		{name: "Addic7ed filename tail", input: "The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com", expected: "The Shepherds Hut"},
+		{name: "Addic7ed tail with hearing impaired tag", input: "Pilot.KILLERS.English.HI.Addic7ed.com", expected: "Pilot"},
+		{name: "Addic7ed tail without orig", input: "The Shepherds Hut.EDITH.English.Addic7ed.com", expected: "The Shepherds Hut"},
+		{name: "Bare site suffix", input: "The Shepherds Hut - Addic7ed.com", expected: "The Shepherds Hut"},
+		{name: "Bracketed orig", input: "The Shepherds Hut [orig]", expected: "The Shepherds Hut"},
+		{name: "Parenthesized site and orig", input: "The Shepherds Hut (orig) (Addic7ed)", expected: "The Shepherds Hut"},
+		{name: "Doubled spaces", input: "The  Shepherds   Hut ", expected: "The Shepherds Hut"},
+		{name: "Orig inside a word", input: "Aborigines", expected: "Aborigines"},
+		{name: "Only artifacts", input: "Addic7ed.com", expected: "Addic7ed.com"},
+		{name: "Empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if result := NormalizeEpisodeTitle(tt.input); result != tt.expected {
+				t.Errorf("NormalizeEpisodeTitle(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_TitleNormalization(t *testing.T) {
+	t.Parallel()
+	// The Addic7ed upload of the URL-encoded filename fixture, listed under its filename
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{{
+		Language:         "Angol",
+		FlagImage:        "uk.gif",
+		MagyarTitle:      "Billy the Kid - 3x04",
+		EredetiTitle:     "Billy The Kid - 03x04 - The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com (WEB.1080p-EDITH)",
+		Uploader:         "Feliratozó",
+		UploadDate:       "2025-10-20",
+		DownloadAction:   "letolt",
+		DownloadFilename: "Billy The Kid - 03x04 - The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com.srt",
+		SubtitleID:       1760949698,
+	}})
+	const rawTitle = "The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com"
+
+	tests := []struct {
+		name      string
+		normalize bool
+		expected  string
+	}{
+		{name: "Normalized", normalize: true, expected: "The Shepherds Hut"},
+		{name: "Raw", normalize: false, expected: rawTitle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			parser := NewSubtitleParser("https://feliratok.eu").WithTitleNormalization(tt.normalize)
+			subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+			if err != nil {
+				t.Fatalf("ParseHtml failed: %v", err)
+			}
+			if len(subtitles) != 1 {
+				t.Fatalf("Expected 1 subtitle, got %d", len(subtitles))
+			}
+			if subtitles[0].Name != tt.expected || subtitles[0].RawName != rawTitle {
+				t.Errorf("Expected name %q and raw name %q, got %q and %q", tt.expected, rawTitle, subtitles[0].Name, subtitles[0].RawName)
+			}
+			if subtitles[0].Season != 3 || subtitles[0].Episode != 4 {
+				t.Errorf("Expected episode 3x04, got %dx%d", subtitles[0].Season, subtitles[0].Episode)
 			}
 		})
 	}
@@ -691,6 +909,226 @@ func TestSubtitleParser_ExtractShowIDFromHTML(t *testing.T) {
 	}
 }
 
+func TestSubtitleParser_ExtractUploaderID(t *testing.T) {
+	t.Parallel()
+	row := func(subtitleID int, uploader, href string, bold bool) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "The Copenhagen Test - 1x04 (SubRip)",
+			EredetiTitle:     "The Copenhagen Test - 1x04 - Obsidian (WEB.720p-SYLiX)",
+			Uploader:         uploader,
+			UploaderBold:     bold,
+			UploaderHref:     href,
+			UploadDate:       "2026-02-09",
+			DownloadAction:   "letolt",
+			DownloadFilename: "The.Copenhagen.Test.S01E04.srt",
+			SubtitleID:       subtitleID,
+		}
+	}
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		row(1770617276, "Kovács Béla", "index.php?felt=Kov%C3%A1cs+B%C3%A9la", true),
+		row(1770617277, "J1GG4", "/index.php?tab=profil&id=4821", false),
+		row(1770617278, "Anonymus", "", false),
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 3 {
+		t.Fatalf("Expected 3 subtitles, got %d", len(subtitles))
+	}
+
+	tests := []struct {
+		uploader   string
+		uploaderID string
+	}{
+		{"Kovács Béla", "Kovács Béla"},
+		{"J1GG4", "4821"},
+		{"Anonymus", ""},
+	}
+	for i, tt := range tests {
+		if subtitles[i].Uploader != tt.uploader {
+			t.Errorf("Subtitle %d: expected uploader %q, got %q", i, tt.uploader, subtitles[i].Uploader)
+		}
+		if subtitles[i].UploaderID != tt.uploaderID {
+			t.Errorf("Subtitle %d: expected uploader ID %q, got %q", i, tt.uploaderID, subtitles[i].UploaderID)
+		}
+	}
+}
+
+func TestSubtitleParser_HearingImpaired(t *testing.T) {
+	t.Parallel()
+	row := func(subtitleID int, eredetiTitle, filename string) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Pokémon - 1x01",
+			EredetiTitle:     eredetiTitle,
+			Uploader:         "Feliratozó",
+			UploadDate:       "2026-02-16",
+			DownloadAction:   "letolt",
+			DownloadFilename: filename,
+			SubtitleID:       subtitleID,
+		}
+	}
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		row(1771222101, "Pokémon - 1x01 (WEBRip.Netflix)", "Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en[cc].srt"),
+		row(1771222102, "Pokémon - 1x01 (SDH, WEBRip.Netflix)", "Pokemon.S01E01.srt"),
+		row(1771222103, "Pokémon - 1x01 - feliratozás hallássérülteknek (WEBRip)", "Pokemon.S01E01.srt"),
+		row(1771222104, "Pokémon - 1x01 (WEBRip.Netflix)", "Pokemon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.srt"),
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 4 {
+		t.Fatalf("Expected 4 subtitles, got %d", len(subtitles))
+	}
+
+	expected := []bool{true, true, true, false}
+	for i, want := range expected {
+		if subtitles[i].IsHearingImpaired != want {
+			t.Errorf("Subtitle %d (%s): expected IsHearingImpaired %v, got %v", i, subtitles[i].Filename, want, subtitles[i].IsHearingImpaired)
+		}
+	}
+}
+
+func TestSubtitleParser_AirDate(t *testing.T) {
+	t.Parallel()
+	row := func(subtitleID int, eredetiTitle string) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Jeopardy! - 41x150",
+			EredetiTitle:     eredetiTitle,
+			Uploader:         "Feliratozó",
+			UploadDate:       "2025-03-06",
+			DownloadAction:   "letolt",
+			DownloadFilename: "Jeopardy.S41E150.srt",
+			SubtitleID:       subtitleID,
+		}
+	}
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		row(1741222101, "Jeopardy! - 41x150 - Tournament of Champions (2025-03-04) (WEB.720p-NTb)"),
+		row(1741222102, "Jeopardy! - 41x150 (WEB.720p-NTb)"),
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(subtitles))
+	}
+
+	withDate := subtitles[0]
+	if want := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC); !withDate.AirDate.Equal(want) {
+		t.Errorf("Expected air date %v, got %v", want, withDate.AirDate)
+	}
+	if withDate.Name != "Tournament of Champions" || withDate.Release != "WEB.720p-NTb" || withDate.Episode != 150 {
+		t.Errorf("Expected the air date to leave the rest of the row alone, got %+v", withDate)
+	}
+	if !subtitles[1].AirDate.IsZero() {
+		t.Errorf("Expected no air date, got %v", subtitles[1].AirDate)
+	}
+}
+
+func TestSubtitleParser_UploaderVerified(t *testing.T) {
+	t.Parallel()
+	row := func(subtitleID int, uploader, href string, bold bool) testutil.SubtitleRowOptions {
+		return testutil.SubtitleRowOptions{
+			Language:         "Magyar",
+			FlagImage:        "hungary.gif",
+			MagyarTitle:      "The Copenhagen Test - 1x05 (SubRip)",
+			EredetiTitle:     "The Copenhagen Test - 1x05 - Cipher (WEB.720p-SYLiX)",
+			Uploader:         uploader,
+			UploaderBold:     bold,
+			UploaderHref:     href,
+			UploadDate:       "2026-02-16",
+			DownloadAction:   "letolt",
+			DownloadFilename: "The.Copenhagen.Test.S01E05.srt",
+			SubtitleID:       subtitleID,
+		}
+	}
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		row(1771222001, "HunSubTeam", "index.php?felt=HunSubTeam", true),
+		row(1771222002, "Feliratozó", "", true),
+		row(1771222003, "J1GG4", "/index.php?tab=profil&id=4821", false),
+		row(1771222004, "Anonymus", "", false),
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 4 {
+		t.Fatalf("Expected 4 subtitles, got %d", len(subtitles))
+	}
+
+	expected := []bool{true, true, false, false}
+	for i, want := range expected {
+		if subtitles[i].UploaderVerified != want {
+			t.Errorf("Subtitle %d (%s): expected UploaderVerified %v, got %v", i, subtitles[i].Uploader, want, subtitles[i].UploaderVerified)
+		}
+	}
+	if subtitles[0].Uploader != "HunSubTeam" {
+		t.Errorf("Expected bold uploader name without markup, got %q", subtitles[0].Uploader)
+	}
+}
+
+func TestSubtitleParser_SyntheticID(t *testing.T) {
+	t.Parallel()
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{
+			Language:           "Angol",
+			FlagImage:          "uk.gif",
+			MagyarTitle:        "Pokémon - 1x01",
+			EredetiTitle:       "Pokémon - 1x01 (WEBRip)",
+			Uploader:           "Feliratozó",
+			UploadDate:         "2026-02-16",
+			CustomDownloadHref: "/index.php?action=letolt&fnev=pokemon.srt&felirat=abc",
+		},
+		{
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Pokémon - 1x02",
+			EredetiTitle:     "Pokémon - 1x02 (WEBRip)",
+			Uploader:         "Feliratozó",
+			UploadDate:       "2026-02-16",
+			DownloadAction:   "letolt",
+			DownloadFilename: "pokemon.srt",
+			SubtitleID:       1771222101,
+		},
+	})
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+	subtitles, err := parser.ParseHtml(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(subtitles))
+	}
+
+	synthetic := subtitles[0]
+	if !synthetic.IDIsSynthetic {
+		t.Errorf("Expected non-numeric download token to give a synthetic ID, got %d", synthetic.ID)
+	}
+	if want := models.SyntheticSubtitleID(synthetic.DownloadURL); synthetic.ID != want {
+		t.Errorf("Expected synthetic ID %d derived from the download URL, got %d", want, synthetic.ID)
+	}
+	if subtitles[1].IDIsSynthetic || subtitles[1].ID != 1771222101 {
+		t.Errorf("Expected numeric ID 1771222101, got %d (synthetic=%v)", subtitles[1].ID, subtitles[1].IDIsSynthetic)
+	}
+}
+
 func TestSubtitleParser_ParseReleaseInfo_CaseInsensitiveGroupDeduplication(t *testing.T) {
 	t.Parallel()
 	parser := NewSubtitleParser("https://feliratok.eu")
@@ -730,7 +1168,7 @@ func TestSubtitleParser_ParseReleaseInfo_CaseInsensitiveGroupDeduplication(t *te
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			qualities, groups := parser.parseReleaseInfo(tt.releaseInfo)
+			qualities, groups, _ := parser.ParseReleaseInfo(tt.releaseInfo)
 
 			if !reflect.DeepEqual(groups, tt.expectedGroups) {
 				t.Errorf("Expected release groups %v, got %v", tt.expectedGroups, groups)
@@ -742,3 +1180,302 @@ func TestSubtitleParser_ParseReleaseInfo_CaseInsensitiveGroupDeduplication(t *te
 		})
 	}
 }
+
+func TestSubtitleParser_ParseReleaseInfo_Variants(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	tests := []struct {
+		name        string
+		releaseInfo string
+		expected    []models.ReleaseVariant
+	}{
+		{
+			name:        "Groups stay paired with their qualities",
+			releaseInfo: "AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab",
+			expected: []models.ReleaseVariant{
+				{Source: "AMZN", RipType: "WEB-DL", Quality: models.Quality720p, Group: "FLUX"},
+				{Source: "WEB", Quality: models.Quality1080p, Group: "SuccessfulCrab"},
+			},
+		},
+		{
+			name:        "Season pack releases",
+			releaseInfo: "WEB.720p-EDITH, AMZN.WEB-DL.2160p-RAWR",
+			expected: []models.ReleaseVariant{
+				{Source: "WEB", Quality: models.Quality720p, Group: "EDITH"},
+				{Source: "AMZN", RipType: "WEB-DL", Quality: models.Quality2160p, Group: "RAWR"},
+			},
+		},
+		{
+			name:        "Duplicate groups are kept per variant",
+			releaseInfo: "WEB.720p-FLUX, WEB.1080p-flux",
+			expected: []models.ReleaseVariant{
+				{Source: "WEB", Quality: models.Quality720p, Group: "FLUX"},
+				{Source: "WEB", Quality: models.Quality1080p, Group: "flux"},
+			},
+		},
+		{
+			name:        "Segments without a group",
+			releaseInfo: "HDTV.720p, NF.WEB-DL.1080p",
+			expected: []models.ReleaseVariant{
+				{RipType: "HDTV", Quality: models.Quality720p},
+				{Source: "NF", RipType: "WEB-DL", Quality: models.Quality1080p},
+			},
+		},
+		{
+			name:        "Segments without a quality",
+			releaseInfo: "HDTV-LOL, WEBRip.x264-ION10",
+			expected: []models.ReleaseVariant{
+				{RipType: "HDTV", Group: "LOL"},
+				{RipType: "WEBRip", Group: "ION10"},
+			},
+		},
+		{
+			name:        "Segment naming nothing known",
+			releaseInfo: "720p, unknown",
+			expected: []models.ReleaseVariant{
+				{Quality: models.Quality720p},
+				{},
+			},
+		},
+		{
+			name:        "Empty segments are skipped",
+			releaseInfo: "WEB.1080p-NTb, ,",
+			expected: []models.ReleaseVariant{
+				{Source: "WEB", Quality: models.Quality1080p, Group: "NTb"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, variants := parser.ParseReleaseInfo(tt.releaseInfo)
+			if !reflect.DeepEqual(variants, tt.expected) {
+				t.Errorf("Expected variants %+v, got %+v", tt.expected, variants)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_MovieLayout(t *testing.T) {
+	t.Parallel()
+	showRow := testutil.SubtitleRowOptions{
+		ShowID:           2967,
+		MagyarTitle:      "Outlander - Az idegen - 7x16",
+		EredetiTitle:     "Outlander - 7x16 - A Hundred Thousand Angels (WEB.1080p-SuccessfulCrab)",
+		Uploader:         "kissoreg",
+		UploadDate:       "2025-01-21",
+		DownloadAction:   "letolt",
+		DownloadFilename: "outlander.s07e16.srt",
+		SubtitleID:       1737439811,
+	}
+	movieRows := []testutil.SubtitleRowOptions{
+		{
+			Language:         "Angol",
+			MagyarTitle:      "Oppenheimer (2023)",
+			EredetiTitle:     "Oppenheimer (2023) (AMZN.WEB-DL.1080p-FLUX)",
+			Uploader:         "gricsi",
+			UploadDate:       "2024-03-02",
+			DownloadAction:   "letolt",
+			DownloadFilename: "oppenheimer.2023.1080p.srt",
+			SubtitleID:       1709380001,
+		},
+		{
+			MagyarTitle:      "Ismeretlen film",
+			EredetiTitle:     "Untitled Film",
+			Uploader:         "Anonymus",
+			UploadDate:       "2024-03-01",
+			DownloadAction:   "letolt",
+			DownloadFilename: "untitled.srt",
+			SubtitleID:       1709380002,
+		},
+	}
+
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	shows, err := parser.ParseHtml(strings.NewReader(testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{showRow})))
+	if err != nil {
+		t.Fatalf("ParseHtml failed for the show layout: %v", err)
+	}
+	if len(shows) != 1 {
+		t.Fatalf("Expected 1 show subtitle, got %d", len(shows))
+	}
+	if shows[0].ShowID != 2967 || shows[0].Season != 7 || shows[0].Episode != 16 || shows[0].MovieTitle != "" || shows[0].ContentType != models.ContentTypeSeries {
+		t.Errorf("Expected series subtitle 2967 7x16 without movie title, got %+v", shows[0])
+	}
+
+	movies, err := parser.ParseHtml(strings.NewReader(testutil.GenerateMovieSubtitleTableHTML(movieRows)))
+	if err != nil {
+		t.Fatalf("ParseHtml failed for the movie layout: %v", err)
+	}
+	if len(movies) != 2 {
+		t.Fatalf("Expected 2 movie subtitles, got %d", len(movies))
+	}
+
+	movie := movies[0]
+	if movie.ID != 1709380001 || movie.ShowID != 0 {
+		t.Errorf("Expected ID 1709380001 and show ID 0, got %d and %d", movie.ID, movie.ShowID)
+	}
+	if movie.MovieTitle != "Oppenheimer" || movie.MovieYear != 2023 || movie.ShowName != "Oppenheimer" {
+		t.Errorf("Expected Oppenheimer (2023), got title %q, year %d, show name %q", movie.MovieTitle, movie.MovieYear, movie.ShowName)
+	}
+	if movie.Language != "en" || movie.Uploader != "gricsi" || movie.Filename != "oppenheimer.2023.1080p.srt" {
+		t.Errorf("Unexpected language, uploader or filename: %q, %q, %q", movie.Language, movie.Uploader, movie.Filename)
+	}
+	if movie.Season != -1 || movie.Episode != -1 || movie.IsSeasonPack || movie.ContentType != models.ContentTypeFilm {
+		t.Errorf("Expected a film without season or episode, got %d, %d, season pack %v, content type %q", movie.Season, movie.Episode, movie.IsSeasonPack, movie.ContentType)
+	}
+	if movie.Release != "AMZN.WEB-DL.1080p-FLUX" || !reflect.DeepEqual(movie.ReleaseGroups, []string{"FLUX"}) {
+		t.Errorf("Expected release AMZN.WEB-DL.1080p-FLUX with group FLUX, got %q and %v", movie.Release, movie.ReleaseGroups)
+	}
+	if !movie.UploadedAt.Equal(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected upload date 2024-03-02, got %v", movie.UploadedAt)
+	}
+
+	if movies[1].MovieTitle != "Untitled Film" || movies[1].MovieYear != 0 {
+		t.Errorf("Expected a movie without year to keep its title, got %q (%d)", movies[1].MovieTitle, movies[1].MovieYear)
+	}
+}
+
+func TestSubtitleParser_FilmInShowLayout(t *testing.T) {
+	t.Parallel()
+	rows := []testutil.SubtitleRowOptions{
+		{
+			SkipShowIDDefault: true,
+			MagyarTitle:       "Dűne: Második rész (2024)",
+			EredetiTitle:      "Dune: Part Two (2024) (WEB.1080p-FLUX)",
+			DownloadAction:    "letolt",
+			DownloadFilename:  "dune.part.two.srt",
+			SubtitleID:        1712000001,
+		},
+		{
+			// Without a show link, a row naming an episode is still an episode
+			SkipShowIDDefault: true,
+			EredetiTitle:      "Dark - 1x01 (WEB)",
+			DownloadAction:    "letolt",
+			DownloadFilename:  "dark.s01e01.srt",
+			SubtitleID:        1712000002,
+		},
+	}
+
+	subtitles, err := NewSubtitleParser("https://feliratok.eu").ParseHtml(strings.NewReader(testutil.GenerateSubtitleTableHTML(rows)))
+	if err != nil {
+		t.Fatalf("ParseHtml failed: %v", err)
+	}
+	if len(subtitles) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(subtitles))
+	}
+	film := subtitles[0]
+	if film.ContentType != models.ContentTypeFilm || film.MovieTitle != "Dune: Part Two" || film.MovieYear != 2024 || film.Season != -1 || film.Episode != -1 {
+		t.Errorf("Expected the film Dune: Part Two (2024), got %+v", film)
+	}
+	if film.Release != "WEB.1080p-FLUX" {
+		t.Errorf("Expected release WEB.1080p-FLUX, got %q", film.Release)
+	}
+	if episode := subtitles[1]; episode.ContentType != models.ContentTypeSeries || episode.Season != 1 || episode.Episode != 1 {
+		t.Errorf("Expected series episode 1x01, got %+v", episode)
+	}
+}
+
+func TestSubtitleParser_RejectsPagesThatAreNotListings(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	tests := []struct {
+		name        string
+		html        string
+		wantBlocked string
+	}{
+		{name: "captcha page", html: testutil.GenerateCaptchaPageHTML(), wantBlocked: "captcha page"},
+		{name: "login page", html: testutil.GenerateLoginPageHTML(), wantBlocked: "login page"},
+		{name: "cloudflare challenge", html: testutil.GenerateHTMLWithBody(`<form id="challenge-form" action="/cdn-cgi/challenge-platform"></form>`), wantBlocked: "captcha page"},
+		{name: "redesigned page", html: testutil.GenerateHTMLWithBody(`<div class="listing">Outlander - 7x16</div>`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parser.ParseHtmlWithPagination(strings.NewReader(tt.html))
+			var blocked *apperrors.ErrUpstreamBlocked
+			if tt.wantBlocked != "" {
+				if !errors.As(err, &blocked) || blocked.Reason != tt.wantBlocked {
+					t.Fatalf("Expected ErrUpstreamBlocked for %q, got %v", tt.wantBlocked, err)
+				}
+				return
+			}
+			if !errors.Is(err, &apperrors.ErrUnexpectedPage{}) {
+				t.Fatalf("Expected ErrUnexpectedPage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_MaintenancePage(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	tests := []struct {
+		name       string
+		html       string
+		wantReason string
+	}{
+		{name: "site maintenance page", html: testutil.GenerateMaintenancePageHTML(), wantReason: "maintenance page with title Feliratok.eu - Karbantartás"},
+		{name: "web server error page", html: `<html><head><title>503 Service Unavailable</title></head><body><h1>503 Service Unavailable</h1></body></html>`, wantReason: "maintenance page with title 503 Service Unavailable"},
+		{name: "database error heading", html: testutil.GenerateHTMLWithBody(`<h2>Database Error</h2><p>Too many connections</p>`), wantReason: "maintenance page with h2 Database Error"},
+		// Markers in the page text alone are not enough
+		{name: "marker in text only", html: testutil.GenerateHTMLWithBody(`<p>Karbantartás miatt átmenetileg nem elérhető</p>`)},
+		{name: "marker in a lower heading", html: testutil.GenerateHTMLWithBody(`<h3>Maintenance</h3>`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parser.ParseHtmlWithPagination(strings.NewReader(tt.html))
+			var maintenance *apperrors.ErrUpstreamMaintenance
+			if tt.wantReason != "" {
+				if !errors.As(err, &maintenance) || maintenance.Reason != tt.wantReason {
+					t.Fatalf("Expected ErrUpstreamMaintenance with reason %q, got %v", tt.wantReason, err)
+				}
+				return
+			}
+			if !errors.Is(err, &apperrors.ErrUnexpectedPage{}) {
+				t.Fatalf("Expected ErrUnexpectedPage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_ListingNamingMaintenanceIsNotAnError(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	// An episode named after a marker is listed in a result table, so the page is never checked
+	htmlContent := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{{
+		SubtitleID:       1770600001,
+		ShowID:           1,
+		Language:         "Angol",
+		FlagImage:        "uk.gif",
+		MagyarTitle:      "Severance - 1x05",
+		EredetiTitle:     "Severance - 1x05 - Service Unavailable (WEB)",
+		DownloadFilename: "severance.s01e05.srt",
+	}})
+	result, err := parser.ParseHtmlWithPagination(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Expected the listing to parse, got %v", err)
+	}
+	if len(result.Subtitles) != 1 || result.Subtitles[0].Name != "Service Unavailable" {
+		t.Errorf("Expected the episode named Service Unavailable, got %+v", result.Subtitles)
+	}
+}
+
+func TestSubtitleParser_EmptyListingIsNotAnError(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	result, err := parser.ParseHtmlWithPagination(strings.NewReader(testutil.GenerateSubtitleTableHTML(nil)))
+	if err != nil {
+		t.Fatalf("Expected an empty listing to parse, got %v", err)
+	}
+	if len(result.Subtitles) != 0 {
+		t.Errorf("Expected no subtitles, got %d", len(result.Subtitles))
+	}
+}
@@ -77,6 +77,81 @@ func TestSubtitleParser_parseDate(t *testing.T) {
 	}
 }
 
+func TestExtractAirDate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		titles []string
+		want   time.Time
+	}{
+		{"iso date", []string{"Jeopardy! - 41x150 - Episode (2025-03-04) (WEB.720p-NTb)"}, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"hungarian date", []string{"Jeopardy! - 41x150 (2025. 03. 04.)"}, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"daily show release", []string{"The Daily Show - 30x42 (The.Daily.Show.2025.03.04.720p.WEB-NTb)"}, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"from the hungarian title", []string{"Jeopardy! - 41x150 (WEB)", "Jeopardy! - 41x150 (2025.3.4.)"}, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"impossible date skipped", []string{"Show - 1x01 (2025-02-30) (2025-03-04)"}, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"no date", []string{"Outlander - 7x16 - A Hundred Thousand Angels (AMZN.WEB-DL.2160p-FLUX)"}, time.Time{}},
+		{"year only", []string{"Oppenheimer (2023) (BluRay.1080p)"}, time.Time{}},
+		{"empty", []string{"", ""}, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := extractAirDate(tt.titles...); !got.Equal(tt.want) {
+				t.Errorf("extractAirDate(%q) = %v, want %v", tt.titles, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_parseDate_Relative(t *testing.T) {
+	t.Parallel()
+	// 00:30 on March 1st so day arithmetic crosses a month boundary
+	now := time.Date(2026, 3, 1, 0, 30, 0, 0, time.UTC)
+	parser := NewSubtitleParserWithClock("https://feliratok.eu", func() time.Time { return now })
+
+	tests := []struct {
+		name    string
+		dateStr string
+		want    time.Time
+	}{
+		{"today", "ma", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"today uppercase", "Ma", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", "tegnap", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"day before yesterday", "tegnapelőtt", time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"days ago", "3 napja", time.Date(2026, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{"days ago with extra spaces", "  10   napja ", time.Date(2026, 2, 19, 0, 0, 0, 0, time.UTC)},
+		{"hours ago", "2 órája", time.Date(2026, 2, 28, 22, 30, 0, 0, time.UTC)},
+		{"minutes ago", "15 perce", time.Date(2026, 3, 1, 0, 15, 0, 0, time.UTC)},
+		{"unknown unit", "3 hete", time.Time{}},
+		{"unknown phrase", "holnap", time.Time{}},
+		{"missing amount", "napja", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := parser.parseDate(tt.dateStr)
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, want %v", tt.dateStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_parseDate_RelativeUsesLocalCalendarDay(t *testing.T) {
+	t.Parallel()
+	// 00:30 in Budapest is still the previous day in UTC; "ma" follows the clock's own calendar day
+	budapest := time.FixedZone("CET", 60*60)
+	now := time.Date(2026, 3, 1, 0, 30, 0, 0, budapest)
+	parser := NewSubtitleParserWithClock("https://feliratok.eu", func() time.Time { return now })
+
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := parser.parseDate("ma"); !got.Equal(want) {
+		t.Errorf("parseDate(%q) = %v, want %v", "ma", got, want)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // constructDownloadURL
 // ---------------------------------------------------------------------------
@@ -228,6 +303,44 @@ func TestSubtitleParser_parseDescription_fallback(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// parseFilenameEpisode – fallback for descriptions without season/episode
+// ---------------------------------------------------------------------------
+
+func TestParseFilenameEpisode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filename string
+		showName string
+		season   int
+		episode  int
+		ok       bool
+	}{
+		{"The.Copenhagen.Test.S01E04.srt", "The Copenhagen Test", 1, 4, true},
+		{"The.Copenhagen.Test.S01E04.1080p.WEB.h264-NTb.srt", "The Copenhagen Test", 1, 4, true},
+		{"the_sopranos_s06e21_made_in_america.srt", "the sopranos", 6, 21, true},
+		{"Doctor Who - 12x03 - Orphan 55.srt", "Doctor Who", 12, 3, true},
+		{"One.Piece.S01E1089.srt", "One Piece", 1, 1089, true},
+		{"Billy.the.Kid.S02.WEB.zip", "Billy the Kid", 2, -1, true},
+		{"Dallas.2012.S03E01.srt", "Dallas 2012", 3, 1, true},
+		{"Some Movie Title.srt", "", -1, -1, false},
+		{"S01E04.srt", "", -1, -1, false},
+		{"", "", -1, -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			t.Parallel()
+			showName, season, episode, ok := parseFilenameEpisode(tt.filename)
+			if ok != tt.ok || showName != tt.showName || season != tt.season || episode != tt.episode {
+				t.Errorf("parseFilenameEpisode(%q) = (%q, %d, %d, %v), want (%q, %d, %d, %v)",
+					tt.filename, showName, season, episode, ok, tt.showName, tt.season, tt.episode, tt.ok)
+			}
+		})
+	}
+}
+
 func TestSubtitleParser_parseDescription_episodeRangeSeasonPack(t *testing.T) {
 	t.Parallel()
 	parser := NewSubtitleParser("https://feliratok.eu")
@@ -249,6 +362,91 @@ func TestSubtitleParser_parseDescription_episodeRangeSeasonPack(t *testing.T) {
 	}
 }
 
+func TestParseSeasonMarker(t *testing.T) {
+	t.Parallel()
+	three := 3
+
+	tests := []struct {
+		title         string
+		wantSeason    int
+		wantSeasonEnd *int
+		wantOK        bool
+	}{
+		{"Billy the Kid (Season 2) (WEB.720p-EDITH)", 2, nil, true},
+		{"The Office (Season 1-3)", 1, &three, true},
+		{"Vészhelyzet Pittsburghben (1. évad)", 1, nil, true},
+		{"Billy the Kid (2. evad)", 2, nil, true},
+		{"Az iroda (1-3. évad)", 1, &three, true},
+		{"Az iroda (3-1. évad)", 1, &three, true},
+		{"Az iroda (2-2. évad)", 2, nil, true},
+		{"Outlander - Az idegen - 7x16", -1, nil, false},
+		{"", -1, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			_, _, season, seasonEnd, ok := parseSeasonMarker(tt.title)
+			if ok != tt.wantOK || season != tt.wantSeason {
+				t.Errorf("parseSeasonMarker(%q) = (%d, %v), want (%d, %v)", tt.title, season, ok, tt.wantSeason, tt.wantOK)
+			}
+			if (seasonEnd == nil) != (tt.wantSeasonEnd == nil) || (seasonEnd != nil && *seasonEnd != *tt.wantSeasonEnd) {
+				t.Errorf("parseSeasonMarker(%q) seasonEnd = %v, want %v", tt.title, seasonEnd, tt.wantSeasonEnd)
+			}
+		})
+	}
+}
+
+func TestSubtitleParser_parseDescription_hungarianSeasonPack(t *testing.T) {
+	t.Parallel()
+	parser := NewSubtitleParser("https://feliratok.eu")
+
+	showName, season, episode, releaseInfo := parser.parseDescription("Billy the Kid (2. évad) (WEB.720p-EDITH)")
+
+	if showName != "Billy the Kid" {
+		t.Errorf("showName = %q, want %q", showName, "Billy the Kid")
+	}
+	if season != 2 {
+		t.Errorf("season = %d, want 2", season)
+	}
+	if episode != -1 {
+		t.Errorf("episode = %d, want -1", episode)
+	}
+	if releaseInfo != "WEB.720p-EDITH" {
+		t.Errorf("releaseInfo = %q, want %q", releaseInfo, "WEB.720p-EDITH")
+	}
+
+	// A lone season marker is not release info
+	if _, _, _, releaseInfo := parser.parseDescription("Az iroda (1-3. évad)"); releaseInfo != "" {
+		t.Errorf("releaseInfo = %q, want empty", releaseInfo)
+	}
+}
+
+func TestExtractHungarianShowName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Outlander - Az idegen - 7x16", "Outlander - Az idegen"},
+		{"Vészhelyzet Pittsburghben (1. évad)", "Vészhelyzet Pittsburghben"},
+		{"Billy the Kid (2. evad)", "Billy the Kid"},
+		{"Az iroda (1-3. évad)", "Az iroda"},
+		{"The Copenhagen Test - 1x04 (SubRip)", "The Copenhagen Test"},
+		{"  Pursuit of Jade  ", "Pursuit of Jade"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			if got := extractHungarianShowName(tt.title); got != tt.want {
+				t.Errorf("extractHungarianShowName(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSubtitleParser_isArchiveSeasonPack(t *testing.T) {
 	t.Parallel()
 	parser := NewSubtitleParser("https://feliratok.eu")
@@ -42,6 +42,12 @@ func (p *ThirdPartyIdParser) ParseHtml(body io.Reader) (models.ThirdPartyIds, er
 
 	logger.Debug().Msg("HTML document parsed successfully, searching for third-party links")
 
+	return p.extractThirdPartyIds(doc), nil
+}
+
+// extractThirdPartyIds collects the third-party IDs linked from the adatlapRow elements of a detail page
+func (p *ThirdPartyIdParser) extractThirdPartyIds(doc *goquery.Document) models.ThirdPartyIds {
+	logger := config.GetLogger()
 	result := models.ThirdPartyIds{}
 
 	// Find all links in the adatlapRow that contains third-party service links
@@ -93,7 +99,7 @@ func (p *ThirdPartyIdParser) ParseHtml(body io.Reader) (models.ThirdPartyIds, er
 		Int("traktId", result.TraktID).
 		Msg("Completed third-party ID extraction")
 
-	return result, nil
+	return result
 }
 
 // extractIMDBIDFromURL extracts the IMDB ID from an IMDB URL
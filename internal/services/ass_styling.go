@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// assCue is one dialogue line of an ASS/SSA subtitle with its styling removed.
+type assCue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string // Plain text; line breaks are kept as the ASS escape \N
+}
+
+// assDefaultPlayResY is the script height players assume when [Script Info] sets no PlayResY.
+const assDefaultPlayResY = 288
+
+// StripASSStyling rewrites an ASS or SSA subtitle as a plain ASS file: one default style, and
+// dialogue lines with their override tags ({\pos(...)}, {\k20}, ...) and vector drawings removed.
+// Comment lines, other event types and the [Fonts] and [Graphics] sections are dropped. Cues are
+// ordered by start time, and copies left identical by the stripping, as karaoke effects layer
+// them, are kept once. It returns false and the content unchanged when no [Events] section with
+// a Format line is found.
+func StripASSStyling(content []byte) ([]byte, bool) {
+	scriptInfo, cues, ok := parseASS(content)
+	if !ok {
+		return content, false
+	}
+
+	var b bytes.Buffer
+	b.WriteString("[Script Info]\n")
+	playResY := assDefaultPlayResY
+	for _, line := range scriptInfo {
+		key, value, _ := strings.Cut(line, ":")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "scripttype":
+			// The styles below use the V4+ format whatever the source was
+			continue
+		case "playresy":
+			if y, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && y > 0 {
+				playResY = y
+			}
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString("ScriptType: v4.00+\n\n")
+
+	// 20 points at the default script height, scaled to the script's own resolution
+	fontSize := int(math.Round(float64(playResY) * 20 / assDefaultPlayResY))
+	b.WriteString("[V4+ Styles]\n")
+	b.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	fmt.Fprintf(&b, "Style: Default,Arial,%d,&H00FFFFFF,&H000000FF,&H00000000,&H00000000,0,0,0,0,100,100,0,0,1,2,0,2,10,10,10,1\n\n", fontSize)
+
+	b.WriteString("[Events]\n")
+	b.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, cue := range cues {
+		fmt.Fprintf(&b, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n", formatASSTime(cue.Start), formatASSTime(cue.End), cue.Text)
+	}
+	return b.Bytes(), true
+}
+
+// parseASS returns the [Script Info] lines without comments and the dialogue cues of an ASS or
+// SSA subtitle. ok is false when the file has no [Events] section with a Format line.
+func parseASS(content []byte) (scriptInfo []string, cues []assCue, ok bool) {
+	text := strings.TrimPrefix(string(content), "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var section string
+	var fields []string
+	startIdx, endIdx, textIdx := -1, -1, -1
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(line)
+			continue
+		}
+
+		switch section {
+		case "[script info]":
+			scriptInfo = append(scriptInfo, line)
+		case "[events]":
+			kind, rest, found := strings.Cut(line, ":")
+			if !found {
+				continue
+			}
+			switch strings.ToLower(strings.TrimSpace(kind)) {
+			case "format":
+				fields = strings.Split(rest, ",")
+				for i, field := range fields {
+					switch strings.ToLower(strings.TrimSpace(field)) {
+					case "start":
+						startIdx = i
+					case "end":
+						endIdx = i
+					case "text":
+						textIdx = i
+					}
+				}
+				ok = startIdx >= 0 && endIdx >= 0 && textIdx == len(fields)-1
+			case "dialogue":
+				if !ok {
+					continue
+				}
+				values := strings.SplitN(rest, ",", len(fields))
+				if len(values) != len(fields) {
+					continue
+				}
+				start, err := parseASSTime(values[startIdx])
+				if err != nil {
+					continue
+				}
+				end, err := parseASSTime(values[endIdx])
+				if err != nil {
+					continue
+				}
+				if plain := stripASSOverrides(values[textIdx]); plain != "" {
+					cues = append(cues, assCue{Start: start, End: end, Text: plain})
+				}
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, false
+	}
+
+	slices.SortStableFunc(cues, func(a, b assCue) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.End, b.End))
+	})
+	seen := make(map[assCue]bool, len(cues))
+	cues = slices.DeleteFunc(cues, func(cue assCue) bool {
+		if seen[cue] {
+			return true
+		}
+		seen[cue] = true
+		return false
+	})
+	return scriptInfo, cues, true
+}
+
+// stripASSOverrides removes override blocks from a dialogue text and the vector drawings that
+// \p1 and higher turn on, keeping the \N, \n and \h escapes. It returns an empty string when no
+// visible text is left. An unclosed brace is kept as text, as players show it.
+func stripASSOverrides(text string) string {
+	var b strings.Builder
+	drawing := false
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			if !drawing {
+				b.WriteString(text)
+			}
+			break
+		}
+		closing := strings.IndexByte(text[open:], '}')
+		if closing < 0 {
+			if !drawing {
+				b.WriteString(text)
+			}
+			break
+		}
+		if !drawing {
+			b.WriteString(text[:open])
+		}
+		if scale, found := drawingScale(text[open+1 : open+closing]); found {
+			drawing = scale > 0
+		}
+		text = text[open+closing+1:]
+	}
+
+	plain := strings.TrimSpace(b.String())
+	for {
+		trimmed := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(plain, `\N`), `\N`))
+		if trimmed == plain {
+			break
+		}
+		plain = trimmed
+	}
+	visible := strings.NewReplacer(`\N`, "", `\n`, "", `\h`, "").Replace(plain)
+	if strings.TrimSpace(visible) == "" {
+		return ""
+	}
+	return plain
+}
+
+// drawingScale returns the value of the last \p tag in an override block. \pos and \pbo are not
+// drawing tags.
+func drawingScale(block string) (int, bool) {
+	scale, found := 0, false
+	for tag := range strings.SplitSeq(block, `\`) {
+		digits, ok := strings.CutPrefix(tag, "p")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(digits)); err == nil {
+			scale, found = n, true
+		}
+	}
+	return scale, found
+}
+
+// parseASSTime parses an ASS timestamp such as 0:01:02.50 (hours, minutes, seconds and centiseconds).
+func parseASSTime(value string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid ASS timestamp %q", value)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid ASS timestamp %q: %w", value, err)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid ASS timestamp %q: %w", value, err)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ASS timestamp %q: %w", value, err)
+	}
+	if hours < 0 || minutes < 0 || seconds < 0 {
+		return 0, fmt.Errorf("invalid ASS timestamp %q: negative component", value)
+	}
+	total := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(math.Round(seconds*100))*10*time.Millisecond
+	return total, nil
+}
+
+// formatASSTime formats a duration as an ASS timestamp, H:MM:SS.cc.
+func formatASSTime(d time.Duration) string {
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const karaokeASS = "\uFEFF[Script Info]\r\n" +
+	"; Script generated by Aegisub 3.2.2\r\n" +
+	"Title: Szívek szállodája 1x01\r\n" +
+	"ScriptType: v4.00+\r\n" +
+	"PlayResX: 1920\r\n" +
+	"PlayResY: 1080\r\n" +
+	"\r\n" +
+	"[Aegisub Project Garbage]\r\n" +
+	"Video File: ../gilmore.mkv\r\n" +
+	"\r\n" +
+	"[V4+ Styles]\r\n" +
+	"Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\r\n" +
+	"Style: Karaoke,Comic Sans MS,96,&H0000FFFF,&H00FF00FF,&H00000000,&H80000000,-1,0,0,0,100,100,0,0,1,4,2,8,10,10,30,1\r\n" +
+	"\r\n" +
+	"[Events]\r\n" +
+	"Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\r\n" +
+	"Dialogue: 1,0:00:05.00,0:00:07.50,Karaoke,,0,0,0,karaoke,{\\k20}Hol {\\k35}vagy, {\\k40}Lorelai?\r\n" +
+	"Dialogue: 0,0:00:05.00,0:00:07.50,Karaoke,,0,0,0,karaoke,{\\blur3\\3c&HFFFFFF&\\k20}Hol {\\k35}vagy, {\\k40}Lorelai?\r\n" +
+	"Comment: 0,0:00:06.00,0:00:07.00,Default,,0,0,0,,Fordító megjegyzése: ezt még át kell nézni\r\n" +
+	"Dialogue: 0,0:00:01.20,0:00:04.00,Default,Rory,0,0,0,,{\\pos(960,1000)\\fad(200,200)}Anya, kávét kérsz?\\N{\\i1}Persze,{\\i0} mindig.\r\n" +
+	"Dialogue: 0,0:00:08.00,0:00:09.00,Sign,,0,0,0,,{\\p1}m 0 0 l 100 0 100 100 0 100{\\p0}\r\n" +
+	"Dialogue: 0,0:00:10.00,0:00:12.00,Default,,0,0,0,,{\\an8}Luke's Diner\r\n" +
+	"\r\n" +
+	"[Fonts]\r\n" +
+	"fontname: ComicSans_0.ttf\r\n" +
+	"begin 644 ComicSans_0.ttf\r\n" +
+	"M``$````2`0``!``@1T1%1@`2`!,``.\r\n" +
+	"\r\n" +
+	"[Graphics]\r\n" +
+	"filename: logo.png\r\n" +
+	"M(5!.1PT*&@H````-24A$4@```\r\n"
+
+func TestStripASSStyling_KaraokeScript(t *testing.T) {
+	t.Parallel()
+
+	got, ok := StripASSStyling([]byte(karaokeASS))
+	if !ok {
+		t.Fatal("Expected the script to be recognized")
+	}
+	out := string(got)
+
+	wantDialogue := []string{
+		`Dialogue: 0,0:00:01.20,0:00:04.00,Default,,0,0,0,,Anya, kávét kérsz?\NPersze, mindig.`,
+		`Dialogue: 0,0:00:05.00,0:00:07.50,Default,,0,0,0,,Hol vagy, Lorelai?`,
+		`Dialogue: 0,0:00:10.00,0:00:12.00,Default,,0,0,0,,Luke's Diner`,
+	}
+	var dialogue []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if strings.HasPrefix(line, "Dialogue:") {
+			dialogue = append(dialogue, line)
+		}
+	}
+	if strings.Join(dialogue, "\n") != strings.Join(wantDialogue, "\n") {
+		t.Errorf("Unexpected dialogue lines:\n%s\nwant:\n%s", strings.Join(dialogue, "\n"), strings.Join(wantDialogue, "\n"))
+	}
+
+	for _, dropped := range []string{"Comment:", "Fordító", "[Fonts]", "ComicSans_0.ttf", "[Graphics]", "logo.png", "Aegisub Project Garbage", "Comic Sans MS", "{", "m 0 0 l"} {
+		if strings.Contains(out, dropped) {
+			t.Errorf("Expected %q to be dropped, got:\n%s", dropped, out)
+		}
+	}
+	for _, kept := range []string{"Title: Szívek szállodája 1x01", "PlayResY: 1080", "ScriptType: v4.00+", "Style: Default,Arial,75,"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("Expected output to contain %q, got:\n%s", kept, out)
+		}
+	}
+	if strings.Count(out, "ScriptType:") != 1 {
+		t.Errorf("Expected a single ScriptType line, got:\n%s", out)
+	}
+	if strings.Contains(out, "\r") {
+		t.Error("Expected LF line endings")
+	}
+}
+
+func TestStripASSStyling_SSAv4(t *testing.T) {
+	t.Parallel()
+
+	ssa := "[Script Info]\n" +
+		"ScriptType: v4.00\n" +
+		"\n" +
+		"[V4 Styles]\n" +
+		"Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, TertiaryColour, BackColour, Bold, Italic, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, AlphaLevel, Encoding\n" +
+		"Style: Default,Tahoma,24,16777215,65535,65535,-2147483640,-1,0,1,2,3,2,30,30,10,0,238\n" +
+		"\n" +
+		"[Events]\n" +
+		"Format: Marked, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n" +
+		"Dialogue: Marked=0,0:01:02.05,0:01:04.00,Default,,0000,0000,0000,,{\\c&H00FFFF&}Sziasztok,\\nhogy vagytok?\n"
+
+	got, ok := StripASSStyling([]byte(ssa))
+	if !ok {
+		t.Fatal("Expected the SSA script to be recognized")
+	}
+	out := string(got)
+	if !strings.Contains(out, "[V4+ Styles]") || strings.Contains(out, "[V4 Styles]") || strings.Contains(out, "Tahoma") {
+		t.Errorf("Expected SSA styles to be replaced with the V4+ default, got:\n%s", out)
+	}
+	if !strings.Contains(out, `Dialogue: 0,0:01:02.05,0:01:04.00,Default,,0,0,0,,Sziasztok,\nhogy vagytok?`) {
+		t.Errorf("Expected the dialogue with commas in the text kept, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Style: Default,Arial,20,") {
+		t.Errorf("Expected the default font size without PlayResY, got:\n%s", out)
+	}
+}
+
+func TestStripASSStyling_NoEvents(t *testing.T) {
+	t.Parallel()
+
+	for name, content := range map[string]string{
+		"styles only":      "[Script Info]\nTitle: x\n\n[V4+ Styles]\nStyle: Default,Arial,20\n",
+		"no format line":   "[Script Info]\n\n[Events]\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hello\n",
+		"text not last":    "[Events]\nFormat: Layer, Start, End, Text, Style\nDialogue: 0,0:00:01.00,0:00:02.00,Hello,Default\n",
+		"srt with a brace": "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}Hello\n",
+	} {
+		got, ok := StripASSStyling([]byte(content))
+		if ok {
+			t.Errorf("%s: expected the content not to be recognized", name)
+		}
+		if string(got) != content {
+			t.Errorf("%s: expected the content unchanged, got %q", name, got)
+		}
+	}
+}
+
+func TestStripASSOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "Hello", "Hello"},
+		{"karaoke", `{\k20}Hel{\kf30}lo`, "Hello"},
+		{"nested tags", `{\t(0,500,\frz360)\pos(10,20)}Spin`, "Spin"},
+		{"soft and hard breaks", `Line one\nLine two\NLine three`, `Line one\nLine two\NLine three`},
+		{"leading and trailing breaks", `\N{\i1}\NHello\N `, "Hello"},
+		{"hard space only", `{\an8}\h\h`, ""},
+		{"drawing", `{\p1}m 0 0 l 10 0 10 10{\p0}`, ""},
+		{"text after drawing", `{\p2}m 0 0 l 5 5{\p0}Sign text`, "Sign text"},
+		{"pos is not a drawing", `{\pos(1,2)\pbo5}Visible`, "Visible"},
+		{"unclosed brace", `Hello {world`, "Hello {world"},
+		{"empty", `{\fad(100,100)}`, ""},
+	}
+	for _, tt := range tests {
+		if got := stripASSOverrides(tt.text); got != tt.want {
+			t.Errorf("%s: stripASSOverrides(%q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseASSTime(t *testing.T) {
+	t.Parallel()
+
+	got, err := parseASSTime(" 1:02:03.45")
+	want := time.Hour + 2*time.Minute + 3*time.Second + 450*time.Millisecond
+	if err != nil || got != want {
+		t.Errorf("parseASSTime = %v, %v; want %v", got, err, want)
+	}
+	if formatted := formatASSTime(got); formatted != "1:02:03.45" {
+		t.Errorf("formatASSTime = %q, want 1:02:03.45", formatted)
+	}
+	for _, invalid := range []string{"", "1:02", "a:02:03.45", "0:-1:00.00", "0:00:xx"} {
+		if _, err := parseASSTime(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// seasonPackEpisode is the episode number the parser gives season packs.
+const seasonPackEpisode = -1
+
+// BestSubtitle is the subtitle picked for one episode of a show.
+type BestSubtitle struct {
+	Season   int
+	Episode  int // Episode the subtitle was picked for; seasonPackEpisode for a season known only from an unranged pack
+	Subtitle models.Subtitle
+}
+
+// SelectBestSubtitles picks one subtitle per (season, episode) from a show's subtitles.
+// Candidates are ranked by language match, then preferred quality, then most recent upload,
+// with the higher ID breaking ties. An empty language or models.QualityUnknown skips that criterion.
+//
+// Season packs stand in for an episode of their season when no per-episode subtitle in the
+// requested language exists (any language when none is requested). Episodes come from the
+// per-episode subtitles and from the ranges of ranged packs; a season that only has unranged
+// packs yields its best pack once, under seasonPackEpisode. Results are ordered by season,
+// then episode.
+func SelectBestSubtitles(subtitles []models.Subtitle, language string, preferredQuality models.Quality) []BestSubtitle {
+	type episodeKey struct{ season, episode int }
+
+	language = normalizeIndexLanguage(language)
+	episodes := make(map[episodeKey][]models.Subtitle)
+	packs := make(map[int][]models.Subtitle)
+	for _, subtitle := range subtitles {
+		if !subtitle.IsSeasonPack {
+			key := episodeKey{subtitle.Season, subtitle.Episode}
+			episodes[key] = append(episodes[key], subtitle)
+			continue
+		}
+		packs[subtitle.Season] = append(packs[subtitle.Season], subtitle)
+		if subtitle.RangeStart != nil && subtitle.RangeEnd != nil {
+			for episode := *subtitle.RangeStart; episode <= *subtitle.RangeEnd; episode++ {
+				key := episodeKey{subtitle.Season, episode}
+				if _, ok := episodes[key]; !ok {
+					episodes[key] = nil
+				}
+			}
+		}
+	}
+
+	better := func(a, b models.Subtitle) bool {
+		return compareSubtitleRank(a, b, language, preferredQuality) < 0
+	}
+	best := func(candidates []models.Subtitle) models.Subtitle {
+		winner := candidates[0]
+		for _, candidate := range candidates[1:] {
+			if better(candidate, winner) {
+				winner = candidate
+			}
+		}
+		return winner
+	}
+
+	results := make([]BestSubtitle, 0, len(episodes))
+	seasonsWithEpisodes := make(map[int]bool)
+	for key, candidates := range episodes {
+		if !slices.ContainsFunc(candidates, func(s models.Subtitle) bool { return matchesLanguage(s, language) }) {
+			for _, pack := range packs[key.season] {
+				if seasonPackCovers(pack, key.episode) {
+					candidates = append(candidates, pack)
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		results = append(results, BestSubtitle{Season: key.season, Episode: key.episode, Subtitle: best(candidates)})
+		seasonsWithEpisodes[key.season] = true
+	}
+	for season, seasonPacks := range packs {
+		if !seasonsWithEpisodes[season] {
+			results = append(results, BestSubtitle{Season: season, Episode: seasonPackEpisode, Subtitle: best(seasonPacks)})
+		}
+	}
+
+	slices.SortFunc(results, func(a, b BestSubtitle) int {
+		return cmp.Or(cmp.Compare(a.Season, b.Season), cmp.Compare(a.Episode, b.Episode))
+	})
+	return results
+}
+
+// compareSubtitleRank orders a before b (negative result) when a is the better match.
+func compareSubtitleRank(a, b models.Subtitle, language string, preferredQuality models.Quality) int {
+	return cmp.Or(
+		compareBoolDesc(matchesLanguage(a, language), matchesLanguage(b, language)),
+		compareBoolDesc(hasQuality(a, preferredQuality), hasQuality(b, preferredQuality)),
+		b.UploadedAt.Compare(a.UploadedAt),
+		cmp.Compare(b.ID, a.ID),
+	)
+}
+
+// matchesLanguage reports whether subtitle is in the normalized language; an empty language matches every subtitle.
+func matchesLanguage(subtitle models.Subtitle, language string) bool {
+	return language == "" || normalizeIndexLanguage(subtitle.Language) == language
+}
+
+// hasQuality reports whether subtitle lists quality; models.QualityUnknown matches every subtitle.
+func hasQuality(subtitle models.Subtitle, quality models.Quality) bool {
+	return quality == models.QualityUnknown || slices.Contains(subtitle.Qualities, quality)
+}
+
+// compareBoolDesc orders true before false.
+func compareBoolDesc(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	default:
+		return 1
+	}
+}
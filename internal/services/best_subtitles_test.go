@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+type bestPick struct{ season, episode, id int }
+
+func bestPicks(results []BestSubtitle) []bestPick {
+	picks := make([]bestPick, len(results))
+	for i, result := range results {
+		picks[i] = bestPick{result.Season, result.Episode, result.Subtitle.ID}
+	}
+	return picks
+}
+
+func TestSelectBestSubtitles(t *testing.T) {
+	t.Parallel()
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	subtitles := []models.Subtitle{
+		// S1E1: language beats quality, quality beats recency
+		{ID: 1, Season: 1, Episode: 1, Language: "en", Qualities: []models.Quality{models.Quality1080p}, UploadedAt: day(9)},
+		{ID: 2, Season: 1, Episode: 1, Language: "hu", Qualities: []models.Quality{models.Quality720p}, UploadedAt: day(8)},
+		{ID: 3, Season: 1, Episode: 1, Language: "HU", Qualities: []models.Quality{models.Quality1080p}, UploadedAt: day(2)},
+		{ID: 4, Season: 1, Episode: 1, Language: "hu", Qualities: []models.Quality{models.Quality1080p}, UploadedAt: day(1)},
+		// S1E2: only another language, so the covering pack competes and wins on language
+		{ID: 5, Season: 1, Episode: 2, Language: "en", Qualities: []models.Quality{models.Quality1080p}, UploadedAt: day(5)},
+		// S1E3: only known from the ranged pack
+		{ID: 6, Season: 1, Episode: seasonPackEpisode, Language: "hu", IsSeasonPack: true, RangeStart: new(1), RangeEnd: new(3), UploadedAt: day(3)},
+		// S1E1 has a Hungarian file, so this newer pack must not replace it
+		{ID: 7, Season: 1, Episode: seasonPackEpisode, Language: "hu", IsSeasonPack: true, Qualities: []models.Quality{models.Quality1080p}, UploadedAt: day(20)},
+		// S2: unranged packs only
+		{ID: 8, Season: 2, Episode: seasonPackEpisode, Language: "hu", IsSeasonPack: true, UploadedAt: day(4)},
+		{ID: 9, Season: 2, Episode: seasonPackEpisode, Language: "hu", IsSeasonPack: true, UploadedAt: day(6)},
+	}
+
+	results := SelectBestSubtitles(subtitles, "hu", models.Quality1080p)
+
+	want := []bestPick{
+		{1, 1, 3},
+		{1, 2, 7},
+		{1, 3, 7},
+		{2, seasonPackEpisode, 9},
+	}
+	got := bestPicks(results)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d picks, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Pick %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestSelectBestSubtitles_NoPreferences(t *testing.T) {
+	t.Parallel()
+	uploadedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	subtitles := []models.Subtitle{
+		{ID: 1, Season: 1, Episode: 1, Language: "en", UploadedAt: uploadedAt},
+		{ID: 2, Season: 1, Episode: 1, Language: "hu", UploadedAt: uploadedAt},
+		{ID: 3, Season: 1, Episode: 2, Language: "hu", UploadedAt: uploadedAt.Add(time.Hour)},
+		{ID: 4, Season: 1, Episode: seasonPackEpisode, Language: "hu", IsSeasonPack: true, UploadedAt: uploadedAt.Add(2 * time.Hour)},
+	}
+
+	got := bestPicks(SelectBestSubtitles(subtitles, "", models.QualityUnknown))
+
+	// Same upload time falls back to the higher ID; any per-episode file keeps the pack out
+	want := []bestPick{{1, 1, 2}, {1, 2, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d picks, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Pick %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestSelectBestSubtitles_Empty(t *testing.T) {
+	t.Parallel()
+	if got := SelectBestSubtitles(nil, "hu", models.Quality720p); len(got) != 0 {
+		t.Errorf("Expected no picks, got %+v", got)
+	}
+}
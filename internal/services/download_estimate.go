@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/archive"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// EstimateDownload reports the size and type of the file at downloadURL without downloading it.
+// A cached archive answers without any upstream request. Otherwise a HEAD request is sent,
+// falling back to a GET of the first byte when upstream rejects HEAD.
+func (d *DefaultSubtitleDownloader) EstimateDownload(ctx context.Context, downloadURL string) (*models.DownloadEstimate, error) {
+	logger := config.GetLogger()
+	subtitleID := extractSubtitleID(downloadURL)
+
+	if cached, found := d.cachedArchive(downloadURL); found {
+		logger.Debug().Str("url", downloadURL).Int("size", len(cached)).Msg("Estimated download from cached archive")
+		return &models.DownloadEstimate{
+			Filename:      generateFilename(subtitleID, "application/zip"),
+			ContentType:   "application/zip",
+			ContentLength: int64(len(cached)),
+			FromCache:     true,
+		}, nil
+	}
+
+	resp, err := d.probe(ctx, downloadURL, http.MethodHead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate download %s: %w", downloadURL, err)
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		logger.Debug().Str("url", downloadURL).Int("status", resp.StatusCode).Msg("Upstream rejected HEAD, probing with a ranged GET")
+		resp, err = d.probe(ctx, downloadURL, http.MethodGet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate download %s: %w", downloadURL, err)
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		d.notFound.remove(downloadURL)
+	case http.StatusNotFound:
+		d.notFound.add(downloadURL)
+		return nil, &apperrors.ErrSubtitleResourceNotFound{URL: downloadURL}
+	default:
+		return nil, &apperrors.ErrUpstreamStatus{Code: resp.StatusCode}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if isHTMLContentType(contentType) {
+		return nil, archive.NewUnrecoverableErrorWithURL(
+			fmt.Sprintf("received HTML content instead of subtitle download (content-type: %s)", contentType),
+			downloadURL,
+			nil,
+		)
+	}
+	contentType = archive.NormalizeContentType(contentType, archive.DetectFormat(nil, contentType))
+
+	estimate := &models.DownloadEstimate{
+		Filename:    dispositionFilename(resp.Header.Get("Content-Disposition")),
+		ContentType: contentType,
+	}
+	if estimate.Filename == "" {
+		estimate.Filename = generateFilename(subtitleID, contentType)
+	}
+	estimate.ContentLength, estimate.LengthUnknown = responseLength(resp)
+
+	logger.Debug().
+		Str("url", downloadURL).
+		Str("contentType", estimate.ContentType).
+		Int64("contentLength", estimate.ContentLength).
+		Bool("lengthUnknown", estimate.LengthUnknown).
+		Msg("Estimated download from upstream headers")
+	return estimate, nil
+}
+
+// cachedArchive returns the archive cached for downloadURL by either download path.
+func (d *DefaultSubtitleDownloader) cachedArchive(downloadURL string) ([]byte, bool) {
+	if cached, found := d.getCachedArchive(normalizedArchiveCacheKey(downloadURL), legacyNormalizedArchiveCacheKey(downloadURL)); found {
+		return cached, true
+	}
+	return d.getCachedArchive(episodeArchiveCacheKey(downloadURL), legacyEpisodeArchiveCacheKey(downloadURL))
+}
+
+// probe sends a request for the headers of downloadURL. GET requests ask for the first byte
+// only, and the body is closed without being read.
+func (d *DefaultSubtitleDownloader) probe(ctx context.Context, downloadURL, method string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+
+	resp, err := d.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointDownload, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	_ = resp.Body.Close()
+	return resp, nil
+}
+
+// responseLength returns the full size of the resource behind resp, reading the total from
+// Content-Range for partial responses. unknown is true when upstream reported no size.
+func responseLength(resp *http.Response) (length int64, unknown bool) {
+	if resp.StatusCode == http.StatusPartialContent {
+		// Content-Range: bytes 0-0/12345; the total is "*" when unknown
+		_, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/")
+		if size, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64); ok && err == nil && size >= 0 {
+			return size, false
+		}
+		return 0, true
+	}
+	if size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil && size >= 0 {
+		return size, false
+	}
+	return 0, true
+}
+
+// dispositionFilename returns the base name of the filename parameter of a Content-Disposition
+// header, or "" when there is none.
+func dispositionFilename(header string) string {
+	if header == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(strings.ReplaceAll(params["filename"], `\`, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
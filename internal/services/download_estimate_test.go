@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestEstimateDownload_Head(t *testing.T) {
+	t.Parallel()
+	var getRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			getRequests.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="Show.S01.zip"`)
+		w.Header().Set("Content-Length", "104857600")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	estimate, err := downloader.EstimateDownload(context.Background(), buildDownloadURL(server.URL, "1801"))
+	if err != nil {
+		t.Fatalf("EstimateDownload failed: %v", err)
+	}
+
+	want := models.DownloadEstimate{Filename: "Show.S01.zip", ContentType: "application/zip", ContentLength: 100 << 20}
+	if *estimate != want {
+		t.Errorf("Expected %+v, got %+v", want, *estimate)
+	}
+	if got := getRequests.Load(); got != 0 {
+		t.Errorf("Expected only a HEAD request, got %d other requests", got)
+	}
+}
+
+func TestEstimateDownload_RangedGetFallback(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if got := r.Header.Get("Range"); got != "bytes=0-0" {
+			t.Errorf("Expected a one-byte range request, got Range %q", got)
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Range", "bytes 0-0/5120")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("1"))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	estimate, err := downloader.EstimateDownload(context.Background(), buildDownloadURL(server.URL, "1802"))
+	if err != nil {
+		t.Fatalf("EstimateDownload failed: %v", err)
+	}
+	if estimate.ContentLength != 5120 || estimate.LengthUnknown {
+		t.Errorf("Expected length 5120 from Content-Range, got %d (unknown=%v)", estimate.ContentLength, estimate.LengthUnknown)
+	}
+	if estimate.Filename != "1802.srt" {
+		t.Errorf("Expected filename derived from the subtitle ID, got %q", estimate.Filename)
+	}
+}
+
+func TestEstimateDownload_LengthUnknown(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	estimate, err := downloader.EstimateDownload(context.Background(), buildDownloadURL(server.URL, "1803"))
+	if err != nil {
+		t.Fatalf("EstimateDownload failed: %v", err)
+	}
+	if !estimate.LengthUnknown || estimate.ContentLength != 0 {
+		t.Errorf("Expected an unknown length, got %d (unknown=%v)", estimate.ContentLength, estimate.LengthUnknown)
+	}
+}
+
+func TestEstimateDownload_FromCache(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s01e01.srt": "Episode 1 content",
+		"show.s01e02.srt": "Episode 2 content",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Length", strconv.Itoa(len(zipContent)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "1804")
+	if _, err := downloader.PreloadArchive(context.Background(), downloadURL); err != nil {
+		t.Fatalf("PreloadArchive failed: %v", err)
+	}
+
+	estimate, err := downloader.EstimateDownload(context.Background(), downloadURL)
+	if err != nil {
+		t.Fatalf("EstimateDownload failed: %v", err)
+	}
+	if !estimate.FromCache || estimate.ContentLength == 0 || estimate.ContentType != "application/zip" {
+		t.Errorf("Expected a cached ZIP estimate, got %+v", *estimate)
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected only the preload to reach upstream, got %d requests", got)
+	}
+}
+
+func TestEstimateDownload_UpstreamErrors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("felirat") == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	_, err := downloader.EstimateDownload(context.Background(), buildDownloadURL(server.URL, "404"))
+	if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+		t.Errorf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	}
+	_, err = downloader.EstimateDownload(context.Background(), buildDownloadURL(server.URL, "503"))
+	var statusErr *apperrors.ErrUpstreamStatus
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected ErrUpstreamStatus 503, got: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+)
+
+const (
+	// defaultMaxResumes is how many times a download cut off mid-body is resumed with a Range
+	// request when download.max_resume_attempts is zero.
+	defaultMaxResumes = 3
+	// defaultMaxAttempts mirrors the retry policy's default of three attempts, which bounds
+	// full restarts of a download whose server does not accept ranges.
+	defaultMaxAttempts = 3
+)
+
+// resolveBodyRecovery returns how many times a download cut off mid-body may be resumed from
+// the bytes already received and restarted from the beginning, from download.max_resume_attempts
+// and retry.max_attempts.
+func resolveBodyRecovery(cfg *config.Config) (maxResumes, maxRestarts int) {
+	maxResumes, maxAttempts := defaultMaxResumes, defaultMaxAttempts
+	if cfg != nil {
+		switch {
+		case cfg.Download.MaxResumeAttempts < 0:
+			maxResumes = 0
+		case cfg.Download.MaxResumeAttempts > 0:
+			maxResumes = cfg.Download.MaxResumeAttempts
+		}
+		if cfg.Retry.MaxAttempts > 0 {
+			maxAttempts = cfg.Retry.MaxAttempts
+		}
+	}
+	return maxResumes, maxAttempts - 1
+}
+
+// readDownloadBody copies the body of resp, a 200 answer for url, into a new spool that the
+// caller must close. Reading stops one byte past maxSize so the caller can tell an oversized
+// download apart.
+//
+// When the connection drops mid-body and resp advertised "Accept-Ranges: bytes", the rest is
+// requested with a Range request from the bytes already received, up to maxResumes times, and
+// appended to the spool, so the size and archive checks see the whole file. The resumed part
+// must come from the same file: If-Range carries resp's ETag or Last-Modified, and a server
+// answering with the whole file instead makes the download start over. Servers that do not
+// accept ranges, and responses that were decompressed in transit, are downloaded again from
+// the beginning, up to maxRestarts times.
+func (d *DefaultSubtitleDownloader) readDownloadBody(ctx context.Context, url string, resp *http.Response, maxSize int64) (*spool, error) {
+	logger := config.GetLogger()
+	body := newSpool()
+	resumable := !resp.Uncompressed && strings.EqualFold(strings.TrimSpace(resp.Header.Get("Accept-Ranges")), "bytes")
+	validator := resp.Header.Get("ETag")
+	if validator == "" {
+		validator = resp.Header.Get("Last-Modified")
+	}
+
+	current := resp
+	resumes, restarts := 0, 0
+	for {
+		_, err := io.Copy(body, io.LimitReader(metrics.NewUpstreamBody(metrics.UpstreamEndpointDownload, current.Body), maxSize+1-body.Size()))
+		if current != resp {
+			_ = current.Body.Close()
+		}
+		if err == nil {
+			return body, nil
+		}
+		if !isRetryableBodyError(ctx, err) {
+			_ = body.Close()
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		var next *http.Response
+		switch {
+		case resumable && resumes < d.maxResumes:
+			resumes++
+			logger.Warn().Err(err).Str("url", url).Int64("received", body.Size()).Int("resume", resumes).Msg("Download interrupted, resuming from the received bytes")
+			next, err = d.requestDownloadRange(ctx, url, body.Size(), validator)
+			if err != nil {
+				_ = body.Close()
+				metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryFailed).Inc()
+				return nil, fmt.Errorf("failed to resume download: %w", err)
+			}
+			if next.StatusCode == http.StatusPartialContent {
+				metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryResume).Inc()
+				current = next
+				continue
+			}
+			// The whole file came back: the server ignored the range or the file changed
+			logger.Warn().Str("url", url).Msg("Server answered the resumed download with the whole file, starting over")
+		case restarts < d.maxRestarts:
+			restarts++
+			logger.Warn().Err(err).Str("url", url).Int64("received", body.Size()).Int("restart", restarts).Msg("Download interrupted, starting over")
+			next, err = d.requestDownload(ctx, url)
+			if err != nil {
+				_ = body.Close()
+				metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryFailed).Inc()
+				return nil, err
+			}
+		default:
+			_ = body.Close()
+			metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryFailed).Inc()
+			return nil, fmt.Errorf("failed to read response body after %d resumes and %d restarts: %w", resumes, restarts, err)
+		}
+
+		metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryRestart).Inc()
+		_ = body.Close()
+		body = newSpool()
+		current = next
+	}
+}
+
+// requestDownload sends a new GET for url and returns its response when it is a 200 answer;
+// other statuses are returned as the errors downloadFile gives for the first request.
+func (d *DefaultSubtitleDownloader) requestDownload(ctx context.Context, url string) (*http.Response, error) {
+	resp, err := d.sendDownloadRequest(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			d.notFound.add(url)
+			return nil, &apperrors.ErrSubtitleResourceNotFound{URL: url}
+		}
+		return nil, &apperrors.ErrUpstreamStatus{Code: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+// requestDownloadRange asks for url from byte offset on, sent uncompressed so the offset counts
+// the same bytes as the interrupted response. It returns a 206 answer starting at offset or a
+// 200 answer with the whole file; anything else is an error.
+func (d *DefaultSubtitleDownloader) requestDownloadRange(ctx context.Context, url string, offset int64, validator string) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	header.Set("Accept-Encoding", "identity")
+	if validator != "" {
+		header.Set("If-Range", validator)
+	}
+	resp, err := d.sendDownloadRequest(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); ok && start == offset {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected Content-Range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+	default:
+		_ = resp.Body.Close()
+		return nil, &apperrors.ErrUpstreamStatus{Code: resp.StatusCode}
+	}
+}
+
+// sendDownloadRequest sends a GET for url with the service User-Agent and the given headers,
+// counting it as a download request.
+func (d *DefaultSubtitleDownloader) sendDownloadRequest(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := d.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointDownload, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp, nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range header such as
+// "bytes 1024-2047/4096".
+func contentRangeStart(contentRange string) (int64, bool) {
+	unit, rest, ok := strings.Cut(strings.TrimSpace(contentRange), " ")
+	if !ok || !strings.EqualFold(unit, "bytes") {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	return start, err == nil && start >= 0
+}
+
+// isRetryableBodyError reports whether err, returned while reading a download body, is the
+// connection failing rather than the caller giving up or the spool failing to write.
+func isRetryableBodyError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.As(err, &netErr)
+}
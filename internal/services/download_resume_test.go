@@ -0,0 +1,274 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// resumeTestContent is a season-pack-sized stand-in whose bytes differ by position, so a part
+// stitched at the wrong offset is caught.
+var resumeTestContent = func() []byte {
+	content := make([]byte, 256*1024)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	return content
+}()
+
+// writeThenDrop answers with the headers of the whole file, writes its first n bytes and drops
+// the connection.
+func writeThenDrop(w http.ResponseWriter, content []byte, n int) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content[:n])
+	w.(http.Flusher).Flush()
+	panic(http.ErrAbortHandler)
+}
+
+// newResumeTestDownloader returns a downloader for server with the given recovery limits.
+func newResumeTestDownloader(t *testing.T, server *httptest.Server, maxResumes, maxRestarts int) *DefaultSubtitleDownloader {
+	t.Helper()
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloader.maxResumes, downloader.maxRestarts = maxResumes, maxRestarts
+	return downloader
+}
+
+// downloadAll runs downloadFile and returns the downloaded bytes.
+func downloadAll(t *testing.T, downloader *DefaultSubtitleDownloader, url string) ([]byte, error) {
+	t.Helper()
+	body, _, err := downloader.downloadFile(context.Background(), url, downloader.maxDownloadSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body.Reader())
+}
+
+// Not parallel: reads the global download interruption counter
+func TestDownloadFile_ResumesWithRange(t *testing.T) {
+	var requests atomic.Int32
+	var resumeHeaders atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("ETag", `"pack-v1"`)
+		if requests.Add(1) == 1 {
+			writeThenDrop(w, resumeTestContent, 100_000)
+		}
+		resumeHeaders.Store(r.Header.Get("Range") + " " + r.Header.Get("If-Range"))
+		http.ServeContent(w, r, "pack.zip", time.Time{}, bytes.NewReader(resumeTestContent))
+	}))
+	defer server.Close()
+	downloader := newResumeTestDownloader(t, server, 3, 0)
+	resumed := promtestutil.ToFloat64(metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryResume))
+
+	content, err := downloadAll(t, downloader, server.URL+"/index.php?action=letolt&felirat=1")
+	if err != nil {
+		t.Fatalf("Expected the interrupted download to resume, got: %v", err)
+	}
+	if !bytes.Equal(content, resumeTestContent) {
+		t.Fatalf("Expected the stitched download to match the file, got %d bytes", len(content))
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+	if got := resumeHeaders.Load(); got != `bytes=100000- "pack-v1"` {
+		t.Errorf("Expected a Range request from the received offset, got %q", got)
+	}
+	if got := promtestutil.ToFloat64(metrics.SubtitleDownloadInterruptionsTotal.WithLabelValues(metrics.DownloadRecoveryResume)) - resumed; got != 1 {
+		t.Errorf("Expected one resume to be counted, got %v", got)
+	}
+}
+
+func TestDownloadFile_ResumesSeveralTimes(t *testing.T) {
+	t.Parallel()
+	// Every response drops after 60000 bytes, so the file needs five parts
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Accept-Ranges", "bytes")
+		var start int
+		if r.Header.Get("Range") != "" {
+			start, _ = strconv.Atoi(r.Header.Get("Range")[len("bytes=") : len(r.Header.Get("Range"))-1])
+		}
+		part := resumeTestContent[start:]
+		if start > 0 {
+			w.Header().Set("Content-Range", "bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(len(resumeTestContent)-1)+"/"+strconv.Itoa(len(resumeTestContent)))
+			w.Header().Set("Content-Length", strconv.Itoa(len(part)))
+			w.WriteHeader(http.StatusPartialContent)
+			if len(part) <= 60_000 {
+				_, _ = w.Write(part)
+				return
+			}
+			_, _ = w.Write(part[:60_000])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		writeThenDrop(w, part, 60_000)
+	}))
+	defer server.Close()
+
+	content, err := downloadAll(t, newResumeTestDownloader(t, server, 4, 0), server.URL+"/index.php?action=letolt&felirat=2")
+	if err != nil {
+		t.Fatalf("Expected four resumes to complete the download, got: %v", err)
+	}
+	if !bytes.Equal(content, resumeTestContent) {
+		t.Errorf("Expected the stitched download to match the file, got %d bytes", len(content))
+	}
+
+	_, err = downloadAll(t, newResumeTestDownloader(t, server, 3, 0), server.URL+"/index.php?action=letolt&felirat=2")
+	if err == nil || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected the download to fail once its resumes are used up, got: %v", err)
+	}
+}
+
+func TestDownloadFile_RestartsWithoutRangeSupport(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	var rangeRequested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		if r.Header.Get("Range") != "" {
+			rangeRequested.Store(true)
+		}
+		if requests.Add(1) == 1 {
+			writeThenDrop(w, resumeTestContent, 100_000)
+		}
+		_, _ = w.Write(resumeTestContent)
+	}))
+	defer server.Close()
+
+	content, err := downloadAll(t, newResumeTestDownloader(t, server, 3, 2), server.URL+"/index.php?action=letolt&felirat=3")
+	if err != nil {
+		t.Fatalf("Expected the interrupted download to start over, got: %v", err)
+	}
+	if !bytes.Equal(content, resumeTestContent) {
+		t.Errorf("Expected the restarted download to match the file, got %d bytes", len(content))
+	}
+	if rangeRequested.Load() {
+		t.Error("Expected no Range request to a server that does not accept ranges")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+
+	// Without restarts the interruption fails the download
+	requests.Store(0)
+	if _, err := downloadAll(t, newResumeTestDownloader(t, server, 3, 0), server.URL+"/index.php?action=letolt&felirat=3"); err == nil {
+		t.Error("Expected the interruption to fail the download without restarts")
+	}
+}
+
+func TestDownloadFile_ResumeAnsweredWithWholeFile(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	// Advertises ranges, then sends the whole file anyway, as when the file changed (If-Range)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Accept-Ranges", "bytes")
+		if requests.Add(1) == 1 {
+			writeThenDrop(w, resumeTestContent, 100_000)
+		}
+		_, _ = w.Write(resumeTestContent)
+	}))
+	defer server.Close()
+
+	content, err := downloadAll(t, newResumeTestDownloader(t, server, 3, 0), server.URL+"/index.php?action=letolt&felirat=4")
+	if err != nil {
+		t.Fatalf("Expected the whole file answer to replace the partial download, got: %v", err)
+	}
+	if !bytes.Equal(content, resumeTestContent) {
+		t.Errorf("Expected the download to match the file once, got %d bytes", len(content))
+	}
+}
+
+func TestDownloadFile_ResumeKeepsSizeLimit(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Accept-Ranges", "bytes")
+		if requests.Add(1) == 1 {
+			writeThenDrop(w, resumeTestContent, 100_000)
+		}
+		http.ServeContent(w, r, "pack.zip", time.Time{}, bytes.NewReader(resumeTestContent))
+	}))
+	defer server.Close()
+	downloader := newResumeTestDownloader(t, server, 3, 0)
+	downloader.maxDownloadSize = 150_000
+
+	_, err := downloadAll(t, downloader, server.URL+"/index.php?action=letolt&felirat=5")
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) || tooLarge.Size != 150_001 {
+		t.Errorf("Expected the size limit to cover both parts, got: %v", err)
+	}
+}
+
+func TestContentRangeStart(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		header string
+		start  int64
+		ok     bool
+	}{
+		{header: "bytes 1024-2047/4096", start: 1024, ok: true},
+		{header: "bytes 0-99/*", start: 0, ok: true},
+		{header: "bytes */4096"},
+		{header: "items 1-2/3"},
+		{header: ""},
+	}
+	for _, tt := range tests {
+		start, ok := contentRangeStart(tt.header)
+		if start != tt.start || ok != tt.ok {
+			t.Errorf("contentRangeStart(%q) = %d, %v, expected %d, %v", tt.header, start, ok, tt.start, tt.ok)
+		}
+	}
+}
+
+func TestResolveBodyRecovery(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		wantResumes  int
+		wantRestarts int
+	}{
+		{name: "nil config", cfg: nil, wantResumes: 3, wantRestarts: 2},
+		{name: "configured", cfg: func() *config.Config {
+			cfg := &config.Config{}
+			cfg.Download.MaxResumeAttempts = 5
+			cfg.Retry.MaxAttempts = 4
+			return cfg
+		}(), wantResumes: 5, wantRestarts: 3},
+		{name: "resumes disabled", cfg: func() *config.Config {
+			cfg := &config.Config{}
+			cfg.Download.MaxResumeAttempts = -1
+			cfg.Retry.MaxAttempts = 1
+			return cfg
+		}(), wantResumes: 0, wantRestarts: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resumes, restarts := resolveBodyRecovery(tt.cfg)
+			if resumes != tt.wantResumes || restarts != tt.wantRestarts {
+				t.Errorf("resolveBodyRecovery() = %d, %d, expected %d, %d", resumes, restarts, tt.wantResumes, tt.wantRestarts)
+			}
+		})
+	}
+}
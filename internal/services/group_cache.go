@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+)
+
+// newGroupCache creates the cache for providerCfg.Group on the backend selected by cache.type,
+// filling in the Redis settings from cfg. With Redis the group gets its own key prefix, so its
+// entries never count against or evict cached archives. A backend that cannot be created falls
+// back to memory. Returns the cache and the backend type in use.
+func newGroupCache(cfg *config.Config, providerCfg cache.ProviderConfig) (cache.Cache, string) {
+	logger := config.GetLogger()
+	providerCfg.Logger = &zerologCacheLogger{logger: logger}
+
+	cacheType := "memory"
+	if cfg != nil {
+		if cfg.Cache.Type != "" {
+			cacheType = cfg.Cache.Type
+		}
+		providerCfg.RedisAddress = cfg.Cache.Redis.Address
+		providerCfg.RedisPassword = cfg.Cache.Redis.Password
+		providerCfg.RedisDB = cfg.Cache.Redis.DB
+		providerCfg.RedisKeyPrefix = providerCfg.Group
+		if cfg.Cache.Redis.KeyPrefix != "" {
+			providerCfg.RedisKeyPrefix = cfg.Cache.Redis.KeyPrefix + ":" + providerCfg.Group
+		}
+	}
+
+	groupCache, err := cache.New(cacheType, providerCfg)
+	if err != nil {
+		logger.Warn().Err(err).
+			Str("cacheType", cacheType).
+			Str("group", providerCfg.Group).
+			Msg("Failed to create cache, falling back to memory")
+		cacheType = "memory"
+		groupCache, err = cache.New("memory", providerCfg)
+		if err != nil {
+			logger.Fatal().Err(err).Str("group", providerCfg.Group).Msg("Failed to create fallback memory cache")
+		}
+	}
+	return groupCache, cacheType
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightCall is a single shared archive load. Its result fields are written once
+// by the leader before done is closed and are read-only afterwards.
+type inflightCall struct {
+	done        chan struct{}
+	content     []byte
+	contentType string
+	err         error
+}
+
+// wait blocks until the call completes or ctx is done, whichever comes first.
+// Giving up on ctx only detaches this caller; the shared load keeps running.
+func (c *inflightCall) wait(ctx context.Context) ([]byte, string, error) {
+	select {
+	case <-c.done:
+		return c.content, c.contentType, c.err
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+}
+
+// inflightGroup coalesces concurrent archive loads for the same key so that
+// callers missing the cache at the same time share one upstream download.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+func newInflightGroup() *inflightGroup {
+	return &inflightGroup{calls: make(map[string]*inflightCall)}
+}
+
+// lookup returns the call currently in flight for key, or nil.
+func (g *inflightGroup) lookup(key string) *inflightCall {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.calls[key]
+}
+
+// do runs load for key unless a call for the same key is already in flight, in which
+// case the caller waits for that call instead. shared reports whether the result came
+// from another caller's load. load runs detached from ctx cancellation so that one
+// caller giving up never fails the others; the HTTP client timeout still bounds it.
+func (g *inflightGroup) do(ctx context.Context, key string, load func(ctx context.Context) ([]byte, string, error)) (content []byte, contentType string, shared bool, err error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		content, contentType, err = call.wait(ctx)
+		return content, contentType, true, err
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	go func() {
+		call.content, call.contentType, call.err = load(context.WithoutCancel(ctx))
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	content, contentType, err = call.wait(ctx)
+	return content, contentType, false, err
+}
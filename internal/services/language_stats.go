@@ -0,0 +1,75 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// OtherLanguage is the bucket for languages the parser could not map to an ISO code.
+const OtherLanguage = "other"
+
+// LanguageStats aggregates a show's subtitles in one language.
+type LanguageStats struct {
+	Language         string
+	SubtitleCount    int // All subtitles in the language, season packs included
+	SeasonPackCount  int
+	NewestUploadedAt time.Time // Zero when no subtitle has a known upload date
+	Seasons          []int     // Distinct seasons covered, ascending
+}
+
+// AggregateLanguageStats groups a show's subtitles by language code and summarizes each group.
+// Languages that are not ISO 639 codes, such as the raw names the parser passes through for
+// unknown languages, are counted under OtherLanguage. Results are ordered by language.
+func AggregateLanguageStats(subtitles []models.Subtitle) []LanguageStats {
+	byLanguage := make(map[string]*LanguageStats)
+	seasons := make(map[string]map[int]bool)
+	for _, subtitle := range subtitles {
+		language := statsLanguage(subtitle.Language)
+		stats, ok := byLanguage[language]
+		if !ok {
+			stats = &LanguageStats{Language: language}
+			byLanguage[language] = stats
+			seasons[language] = make(map[int]bool)
+		}
+
+		stats.SubtitleCount++
+		if subtitle.IsSeasonPack {
+			stats.SeasonPackCount++
+		}
+		if subtitle.UploadedAt.After(stats.NewestUploadedAt) {
+			stats.NewestUploadedAt = subtitle.UploadedAt
+		}
+		if subtitle.Season >= 0 && !seasons[language][subtitle.Season] {
+			seasons[language][subtitle.Season] = true
+			stats.Seasons = append(stats.Seasons, subtitle.Season)
+		}
+	}
+
+	results := make([]LanguageStats, 0, len(byLanguage))
+	for _, stats := range byLanguage {
+		slices.Sort(stats.Seasons)
+		results = append(results, *stats)
+	}
+	slices.SortFunc(results, func(a, b LanguageStats) int {
+		return cmp.Compare(a.Language, b.Language)
+	})
+	return results
+}
+
+// statsLanguage returns the normalized ISO code of language, or OtherLanguage when it is not
+// a two or three letter code.
+func statsLanguage(language string) string {
+	language = normalizeIndexLanguage(language)
+	if len(language) < 2 || len(language) > 3 {
+		return OtherLanguage
+	}
+	for _, r := range language {
+		if r < 'a' || r > 'z' {
+			return OtherLanguage
+		}
+	}
+	return language
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestAggregateLanguageStats(t *testing.T) {
+	t.Parallel()
+	older := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	subtitles := []models.Subtitle{
+		{ID: 1, Language: "hu", Season: 2, Episode: 1, UploadedAt: older},
+		{ID: 2, Language: "HU", Season: 1, Episode: 3, UploadedAt: newer},
+		{ID: 3, Language: "hu", Season: 2, Episode: -1, IsSeasonPack: true, UploadedAt: older},
+		{ID: 4, Language: "en", Season: 1, Episode: 1},
+		{ID: 5, Language: "Klingon", Season: 3, Episode: 1, UploadedAt: older},
+		{ID: 6, Language: "", Season: 4, Episode: 2, UploadedAt: newer},
+	}
+
+	got := AggregateLanguageStats(subtitles)
+
+	want := []LanguageStats{
+		{Language: "en", SubtitleCount: 1, Seasons: []int{1}},
+		{Language: "hu", SubtitleCount: 3, SeasonPackCount: 1, NewestUploadedAt: newer, Seasons: []int{1, 2}},
+		{Language: OtherLanguage, SubtitleCount: 2, NewestUploadedAt: newer, Seasons: []int{3, 4}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d languages, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Language != w.Language || g.SubtitleCount != w.SubtitleCount || g.SeasonPackCount != w.SeasonPackCount ||
+			!g.NewestUploadedAt.Equal(w.NewestUploadedAt) || !slices.Equal(g.Seasons, w.Seasons) {
+			t.Errorf("Language %d: expected %+v, got %+v", i, w, g)
+		}
+	}
+}
+
+func TestAggregateLanguageStats_Empty(t *testing.T) {
+	t.Parallel()
+	if got := AggregateLanguageStats(nil); len(got) != 0 {
+		t.Errorf("Expected no languages, got %+v", got)
+	}
+}
+
+func TestStatsLanguage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{"hu", "hu"},
+		{" EN ", "en"},
+		{"hun", "hun"},
+		{"Klingon", OtherLanguage},
+		{"pt-br", OtherLanguage},
+		{"", OtherLanguage},
+	}
+
+	for _, tt := range tests {
+		if got := statsLanguage(tt.language); got != tt.expected {
+			t.Errorf("statsLanguage(%q) = %q, want %q", tt.language, got, tt.expected)
+		}
+	}
+}
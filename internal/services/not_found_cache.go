@@ -0,0 +1,83 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// defaultNotFoundTTL is how long an upstream 404 is remembered when download.not_found_ttl is empty.
+	defaultNotFoundTTL = 10 * time.Minute
+	// notFoundCacheSize bounds how many downloads keep a remembered 404.
+	notFoundCacheSize = 4096
+)
+
+// notFoundCache remembers downloads that upstream answered with 404 for a short TTL, so
+// clients retrying a missing subtitle do not send every attempt upstream. Entries are keyed
+// like the archive cache, so different spellings of one download share an entry.
+type notFoundCache struct {
+	entries *lru.LRU[string, struct{}] // nil when disabled
+}
+
+// newNotFoundCache creates a not-found cache. A zero ttl disables it.
+func newNotFoundCache(ttl time.Duration) *notFoundCache {
+	n := &notFoundCache{}
+	if ttl > 0 {
+		n.entries = lru.NewLRU[string, struct{}](notFoundCacheSize, nil, ttl)
+	}
+	return n
+}
+
+// resolveNotFoundTTL returns the not-found TTL from cfg, falling back to the default when it
+// is unset or invalid.
+func resolveNotFoundTTL(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Download.NotFoundTTL == "" {
+		return defaultNotFoundTTL
+	}
+	ttl, err := config.ParseDuration("download.not_found_ttl", cfg.Download.NotFoundTTL)
+	if err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).
+			Str("notFoundTTL", cfg.Download.NotFoundTTL).
+			Dur("defaultTTL", defaultNotFoundTTL).
+			Msg("Invalid not-found TTL in configuration, falling back to default")
+		return defaultNotFoundTTL
+	}
+	return ttl
+}
+
+// contains reports whether upstream answered downloadURL with 404 within the TTL.
+func (n *notFoundCache) contains(downloadURL string) bool {
+	if n.entries == nil {
+		return false
+	}
+	_, found := n.entries.Get(canonicalDownloadKey(downloadURL))
+	return found
+}
+
+// add remembers that upstream answered downloadURL with 404.
+func (n *notFoundCache) add(downloadURL string) {
+	if n.entries != nil {
+		n.entries.Add(canonicalDownloadKey(downloadURL), struct{}{})
+	}
+}
+
+// remove forgets the 404 remembered for downloadURL and reports whether there was one.
+func (n *notFoundCache) remove(downloadURL string) bool {
+	if n.entries == nil {
+		return false
+	}
+	return n.entries.Remove(canonicalDownloadKey(downloadURL))
+}
+
+// clear forgets every remembered 404 and returns how many there were.
+func (n *notFoundCache) clear() int {
+	if n.entries == nil {
+		return 0
+	}
+	count := n.entries.Len()
+	n.entries.Purge()
+	return count
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// SummarizeSeasons groups a show's subtitles by season and counts the distinct episodes each season has
+// subtitles for. A ranged season pack counts every episode in its range; an unranged pack only marks the
+// season as having a pack. Subtitles with an unparsed season (-1) are only counted in UnknownCount.
+func SummarizeSeasons(subtitles []models.Subtitle) models.ShowSeasons {
+	var result models.ShowSeasons
+	bySeason := make(map[int]*models.SeasonSummary)
+	episodes := make(map[int]map[int]bool)
+	for _, subtitle := range subtitles {
+		if subtitle.Season < 0 {
+			result.UnknownCount++
+			continue
+		}
+
+		summary, ok := bySeason[subtitle.Season]
+		if !ok {
+			summary = &models.SeasonSummary{Season: subtitle.Season}
+			bySeason[subtitle.Season] = summary
+			episodes[subtitle.Season] = make(map[int]bool)
+		}
+
+		if subtitle.UploadedAt.After(summary.LatestUpload) {
+			summary.LatestUpload = subtitle.UploadedAt
+		}
+		if subtitle.IsSeasonPack {
+			summary.SeasonPackAvailable = true
+			if subtitle.RangeStart != nil && subtitle.RangeEnd != nil {
+				for episode := *subtitle.RangeStart; episode <= *subtitle.RangeEnd; episode++ {
+					episodes[subtitle.Season][episode] = true
+				}
+			}
+			continue
+		}
+		if subtitle.Episode > 0 {
+			episodes[subtitle.Season][subtitle.Episode] = true
+		}
+	}
+
+	result.Seasons = make([]models.SeasonSummary, 0, len(bySeason))
+	for season, summary := range bySeason {
+		summary.EpisodeCount = len(episodes[season])
+		result.Seasons = append(result.Seasons, *summary)
+	}
+	slices.SortFunc(result.Seasons, func(a, b models.SeasonSummary) int {
+		return cmp.Compare(a.Season, b.Season)
+	})
+	return result
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestSummarizeSeasons(t *testing.T) {
+	t.Parallel()
+	older := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	subtitles := []models.Subtitle{
+		{ID: 1, Season: 2, Episode: 1, UploadedAt: older},
+		{ID: 2, Season: 2, Episode: 1, Language: "en", UploadedAt: newer},
+		{ID: 3, Season: 2, Episode: 2, UploadedAt: older},
+		{ID: 4, Season: 1, Episode: seasonPackEpisode, IsSeasonPack: true, RangeStart: new(1), RangeEnd: new(3), UploadedAt: older},
+		{ID: 5, Season: 1, Episode: 3},
+		{ID: 6, Season: 3, Episode: seasonPackEpisode, IsSeasonPack: true},
+		{ID: 7, Season: -1, Episode: -1, UploadedAt: newer},
+		{ID: 8, Season: -1, Episode: -1},
+	}
+
+	got := SummarizeSeasons(subtitles)
+
+	want := []models.SeasonSummary{
+		{Season: 1, EpisodeCount: 3, SeasonPackAvailable: true, LatestUpload: older},
+		{Season: 2, EpisodeCount: 2, LatestUpload: newer},
+		{Season: 3, SeasonPackAvailable: true},
+	}
+	if got.UnknownCount != 2 {
+		t.Errorf("Expected 2 unknown subtitles, got %d", got.UnknownCount)
+	}
+	if len(got.Seasons) != len(want) {
+		t.Fatalf("Expected %d seasons, got %d: %+v", len(want), len(got.Seasons), got.Seasons)
+	}
+	for i := range want {
+		g, w := got.Seasons[i], want[i]
+		if g.Season != w.Season || g.EpisodeCount != w.EpisodeCount || g.SeasonPackAvailable != w.SeasonPackAvailable ||
+			!g.LatestUpload.Equal(w.LatestUpload) {
+			t.Errorf("Season %d: expected %+v, got %+v", i, w, g)
+		}
+	}
+}
+
+func TestSummarizeSeasons_Empty(t *testing.T) {
+	t.Parallel()
+	got := SummarizeSeasons(nil)
+	if len(got.Seasons) != 0 || got.UnknownCount != 0 {
+		t.Errorf("Expected an empty summary, got %+v", got)
+	}
+}
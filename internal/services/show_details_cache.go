@@ -0,0 +1,116 @@
+package services
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+const (
+	// defaultThirdPartyTTL is how long a show's details are cached when cache.third_party_ttl is empty.
+	defaultThirdPartyTTL = 7 * 24 * time.Hour
+	// showDetailsCacheSize bounds how many shows' details are cached.
+	showDetailsCacheSize = 10000
+)
+
+// ShowDetailsCache keeps the show-level fields of a subtitle detail page (third-party IDs, year
+// and status) by show ID, so show listings do not fetch a detail page every time. These fields
+// are the same on the detail page of every subtitle of a show and rarely change.
+type ShowDetailsCache struct {
+	cache cache.Cache // nil when caching is disabled
+}
+
+// NewShowDetailsCache creates the show details cache in the "thirdparty" cache group, on the
+// backend selected by cache.type, for cache.third_party_ttl (default 7 days). A TTL of 0
+// disables it: Get always misses and Set does nothing, as with a nil *ShowDetailsCache.
+func NewShowDetailsCache() *ShowDetailsCache {
+	cfg := config.GetConfig()
+	logger := config.GetLogger()
+	ttl := resolveThirdPartyTTL(cfg)
+	if ttl == 0 {
+		logger.Info().Msg("Show details cache disabled")
+		return &ShowDetailsCache{}
+	}
+
+	detailsCache, cacheType := newGroupCache(cfg, cache.ProviderConfig{
+		Size:  showDetailsCacheSize,
+		TTL:   ttl,
+		Group: "thirdparty",
+	})
+
+	logger.Info().
+		Str("cacheType", cacheType).
+		Dur("cacheTTL", ttl).
+		Msg("Show details cache initialized")
+
+	return &ShowDetailsCache{cache: detailsCache}
+}
+
+// resolveThirdPartyTTL returns the show details cache TTL from cfg, falling back to the default
+// when it is unset or invalid.
+func resolveThirdPartyTTL(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Cache.ThirdPartyTTL == "" {
+		return defaultThirdPartyTTL
+	}
+	ttl, err := config.ParseDuration("cache.third_party_ttl", cfg.Cache.ThirdPartyTTL)
+	if err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).
+			Str("thirdPartyTTL", cfg.Cache.ThirdPartyTTL).
+			Dur("defaultTTL", defaultThirdPartyTTL).
+			Msg("Invalid third-party ID cache TTL in configuration, falling back to default")
+		return defaultThirdPartyTTL
+	}
+	return ttl
+}
+
+// Get returns the cached details of showID. Only ThirdPartyIds, ShowYear and ShowStatus are set.
+func (c *ShowDetailsCache) Get(showID int) (models.SubtitleDetails, bool) {
+	if c == nil || c.cache == nil {
+		return models.SubtitleDetails{}, false
+	}
+	value, ok := c.cache.Get(showDetailsKey(showID))
+	if !ok {
+		return models.SubtitleDetails{}, false
+	}
+	var details models.SubtitleDetails
+	if err := json.Unmarshal(value, &details); err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).Int("showID", showID).Msg("Dropping unreadable cached show details")
+		c.cache.Delete(showDetailsKey(showID))
+		return models.SubtitleDetails{}, false
+	}
+	return details, true
+}
+
+// Set caches the show-level fields of details, a detail page of a subtitle of showID.
+func (c *ShowDetailsCache) Set(showID int, details models.SubtitleDetails) {
+	if c == nil || c.cache == nil {
+		return
+	}
+	value, err := json.Marshal(models.SubtitleDetails{
+		ThirdPartyIds: details.ThirdPartyIds,
+		ShowYear:      details.ShowYear,
+		ShowStatus:    details.ShowStatus,
+	})
+	if err != nil {
+		return
+	}
+	c.cache.Set(showDetailsKey(showID), value)
+}
+
+// Close releases the cache.
+func (c *ShowDetailsCache) Close() error {
+	if c == nil || c.cache == nil {
+		return nil
+	}
+	return c.cache.Close()
+}
+
+func showDetailsKey(showID int) string {
+	return "show:" + strconv.Itoa(showID)
+}
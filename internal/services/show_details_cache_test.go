@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestShowDetailsCache_KeepsShowFields(t *testing.T) {
+	t.Parallel()
+	memory, err := cache.New("memory", cache.ProviderConfig{Size: 10, TTL: time.Hour, Group: "thirdparty"})
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+	detailsCache := &ShowDetailsCache{cache: memory}
+	defer detailsCache.Close()
+
+	if _, ok := detailsCache.Get(1); ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+	detailsCache.Set(1, models.SubtitleDetails{
+		SubtitleID:    1770600001,
+		Filename:      "show.s01e01.srt",
+		Uploader:      "TestUser",
+		ThirdPartyIds: models.ThirdPartyIds{IMDBID: "tt12345678", TVDBID: 987654},
+		ShowYear:      2019,
+		ShowStatus:    models.ShowStatusEnded,
+	})
+
+	got, ok := detailsCache.Get(1)
+	want := models.SubtitleDetails{
+		ThirdPartyIds: models.ThirdPartyIds{IMDBID: "tt12345678", TVDBID: 987654},
+		ShowYear:      2019,
+		ShowStatus:    models.ShowStatusEnded,
+	}
+	if !ok || got != want {
+		t.Errorf("Expected only the show fields %+v, got %+v (hit %v)", want, got, ok)
+	}
+	if _, ok := detailsCache.Get(2); ok {
+		t.Error("Expected a miss for another show")
+	}
+}
+
+func TestShowDetailsCache_Disabled(t *testing.T) {
+	t.Parallel()
+	var detailsCache *ShowDetailsCache
+	detailsCache.Set(1, models.SubtitleDetails{ShowYear: 2019})
+	if _, ok := detailsCache.Get(1); ok {
+		t.Error("Expected a nil cache to always miss")
+	}
+	if err := detailsCache.Close(); err != nil {
+		t.Errorf("Expected Close on a nil cache to succeed, got %v", err)
+	}
+}
+
+func TestResolveThirdPartyTTL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultThirdPartyTTL},
+		{"24h", 24 * time.Hour},
+		{"0s", 0},
+		{"a week", defaultThirdPartyTTL},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{}
+		cfg.Cache.ThirdPartyTTL = tt.value
+		if got := resolveThirdPartyTTL(cfg); got != tt.want {
+			t.Errorf("resolveThirdPartyTTL(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// ShowImageFetcher defines the interface for fetching show poster images
+type ShowImageFetcher interface {
+	// FetchShowImage returns the poster of a show from the image cache, or downloads and caches it.
+	// Returns apperrors.ErrNotFound if upstream answers HTTP 404, and apperrors.ErrUpstreamStatus
+	// for any other non-200 status. Returns apperrors.ErrNotAnImage if the content is not a JPEG,
+	// PNG or WebP image, and apperrors.ErrDownloadTooLarge if it exceeds the image size limit.
+	FetchShowImage(ctx context.Context, showID int) (*models.ShowImage, error)
+
+	// Close releases any resources held by the fetcher (e.g., cache connections).
+	Close() error
+}
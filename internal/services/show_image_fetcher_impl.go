@@ -0,0 +1,170 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// defaultImageTTL is how long a poster is cached when cache.image_ttl is empty.
+	defaultImageTTL = 7 * 24 * time.Hour
+	// imageCacheSize bounds how many posters are cached.
+	imageCacheSize = 1000
+	// imageCacheMaxBytes bounds the total size of the posters cached in memory.
+	imageCacheMaxBytes = 64 * 1024 * 1024
+	// maxShowImageSize is the largest poster accepted from upstream.
+	maxShowImageSize = 5 * 1024 * 1024
+)
+
+// DefaultShowImageFetcher implements ShowImageFetcher with caching
+type DefaultShowImageFetcher struct {
+	httpClient *http.Client
+	baseURL    string
+	imageCache cache.Cache
+	inflight   *inflightGroup
+}
+
+// NewShowImageFetcher creates a poster fetcher that downloads through httpClient from the site at
+// baseURL. Posters are cached in the "images" cache group, on the backend selected by cache.type,
+// for cache.image_ttl (default 7 days).
+func NewShowImageFetcher(httpClient *http.Client, baseURL string) ShowImageFetcher {
+	cfg := config.GetConfig()
+	logger := config.GetLogger()
+	ttl := resolveImageTTL(cfg)
+
+	imageCache, cacheType := newGroupCache(cfg, cache.ProviderConfig{
+		Size:     imageCacheSize,
+		MaxBytes: imageCacheMaxBytes,
+		TTL:      ttl,
+		Group:    "images",
+	})
+
+	logger.Info().
+		Str("cacheType", cacheType).
+		Dur("cacheTTL", ttl).
+		Msg("Show image cache initialized")
+
+	return &DefaultShowImageFetcher{
+		httpClient: httpClient,
+		baseURL:    baseURL,
+		imageCache: imageCache,
+		inflight:   newInflightGroup(),
+	}
+}
+
+// resolveImageTTL returns the poster cache TTL from cfg, falling back to the default when it is
+// unset or invalid.
+func resolveImageTTL(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Cache.ImageTTL == "" {
+		return defaultImageTTL
+	}
+	ttl, err := config.ParseDuration("cache.image_ttl", cfg.Cache.ImageTTL)
+	if err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).
+			Str("imageTTL", cfg.Cache.ImageTTL).
+			Dur("defaultTTL", defaultImageTTL).
+			Msg("Invalid image cache TTL in configuration, falling back to default")
+		return defaultImageTTL
+	}
+	return ttl
+}
+
+// FetchShowImage returns the poster of showID, from the cache when possible. Concurrent misses
+// for the same show share one upstream request.
+func (f *DefaultShowImageFetcher) FetchShowImage(ctx context.Context, showID int) (image *models.ShowImage, err error) {
+	ctx, span := tracing.Start(ctx, "images.FetchShowImage", attribute.Int("show_id", showID))
+	defer func() { tracing.End(span, err) }()
+
+	key := "image:" + strconv.Itoa(showID)
+	if content, ok := f.imageCache.Get(key); ok {
+		span.SetAttributes(attribute.Bool("cache_hit", true))
+		return &models.ShowImage{Content: content, ContentType: detectImageType(content)}, nil
+	}
+	span.SetAttributes(attribute.Bool("cache_hit", false))
+
+	imageURL := fmt.Sprintf("%s/sorozat_cat.php?kep=%d", f.baseURL, showID)
+	content, contentType, _, err := f.inflight.do(ctx, key, func(ctx context.Context) ([]byte, string, error) {
+		content, contentType, err := f.download(ctx, showID, imageURL)
+		if err != nil {
+			return nil, "", err
+		}
+		f.imageCache.Set(key, content)
+		return content, contentType, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.ShowImage{Content: content, ContentType: contentType}, nil
+}
+
+// download fetches the poster of showID from imageURL and returns its content and the image type
+// detected from it.
+func (f *DefaultShowImageFetcher) download(ctx context.Context, showID int, imageURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.GetUserAgent())
+
+	resp, err := f.httpClient.Do(req)
+	metrics.RecordUpstreamRequest(metrics.UpstreamEndpointImage, resp, err)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", apperrors.NewNotFoundError("show image", showID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", &apperrors.ErrUpstreamStatus{Code: resp.StatusCode}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(metrics.NewUpstreamBody(metrics.UpstreamEndpointImage, resp.Body), maxShowImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(content) > maxShowImageSize {
+		return nil, "", &apperrors.ErrDownloadTooLarge{Size: int64(len(content)), Limit: maxShowImageSize}
+	}
+
+	contentType := detectImageType(content)
+	if contentType == "" {
+		return nil, "", &apperrors.ErrNotAnImage{URL: imageURL, ContentType: resp.Header.Get("Content-Type")}
+	}
+	return content, contentType, nil
+}
+
+// detectImageType returns the MIME type of a JPEG, PNG or WebP image from its magic bytes, or an
+// empty string for anything else.
+func detectImageType(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg"
+	case bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case len(content) >= 12 && bytes.Equal(content[:4], []byte("RIFF")) && bytes.Equal(content[8:12], []byte("WEBP")):
+		return "image/webp"
+	default:
+		return ""
+	}
+}
+
+// Close releases the image cache.
+func (f *DefaultShowImageFetcher) Close() error {
+	return f.imageCache.Close()
+}
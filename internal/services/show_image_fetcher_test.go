@@ -0,0 +1,134 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var (
+	testJPEG = []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F'}
+	testPNG  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	testWebP = []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+)
+
+func TestDetectImageType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"jpeg", testJPEG, "image/jpeg"},
+		{"png", testPNG, "image/png"},
+		{"webp", testWebP, "image/webp"},
+		{"riff without webp", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), ""},
+		{"html", []byte("<!DOCTYPE html><html>"), ""},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectImageType(tt.content); got != tt.want {
+			t.Errorf("%s: detectImageType() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShowImageFetcher_FetchShowImage(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/sorozat_cat.php" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		switch r.URL.Query().Get("kep") {
+		case "1":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write(testJPEG)
+		case "2":
+			// The site labels every poster as JPEG; the type comes from the content
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write(testWebP)
+		case "3":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html>Hotlinking is not allowed</html>"))
+		case "4":
+			_, _ = w.Write(append(bytes.Clone(testPNG), make([]byte, maxShowImageSize)...))
+		case "5":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewShowImageFetcher(server.Client(), server.URL)
+	defer fetcher.Close()
+
+	hits := promtestutil.ToFloat64(cache.HitsTotal.WithLabelValues("images"))
+	misses := promtestutil.ToFloat64(cache.MissesTotal.WithLabelValues("images"))
+
+	image, err := fetcher.FetchShowImage(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(image.Content, testJPEG) || image.ContentType != "image/jpeg" {
+		t.Errorf("Unexpected image: %+v", image)
+	}
+
+	// The second fetch is answered from the cache
+	image, err = fetcher.FetchShowImage(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error from the cache, got: %v", err)
+	}
+	if image.ContentType != "image/jpeg" || requests.Load() != 1 {
+		t.Errorf("Expected a cached JPEG without a new request, got %+v after %d requests", image, requests.Load())
+	}
+	if got := promtestutil.ToFloat64(cache.HitsTotal.WithLabelValues("images")) - hits; got != 1 {
+		t.Errorf("Expected 1 image cache hit, got %v", got)
+	}
+	if got := promtestutil.ToFloat64(cache.MissesTotal.WithLabelValues("images")) - misses; got != 1 {
+		t.Errorf("Expected 1 image cache miss, got %v", got)
+	}
+
+	image, err = fetcher.FetchShowImage(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if image.ContentType != "image/webp" {
+		t.Errorf("Expected the type detected from the content, got %q", image.ContentType)
+	}
+
+	tests := []struct {
+		name   string
+		showID int
+		want   error
+	}{
+		{"not an image", 3, &apperrors.ErrNotAnImage{}},
+		{"too large", 4, &apperrors.ErrDownloadTooLarge{}},
+		{"other status", 5, &apperrors.ErrUpstreamStatus{}},
+		{"not found", 6, &apperrors.ErrNotFound{}},
+	}
+	for _, tt := range tests {
+		if _, err := fetcher.FetchShowImage(context.Background(), tt.showID); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %T, got: %v", tt.name, tt.want, err)
+		}
+	}
+
+	// Failures are not cached
+	before := requests.Load()
+	if _, err := fetcher.FetchShowImage(context.Background(), 3); err == nil {
+		t.Fatal("Expected the non-image response to fail again")
+	}
+	if requests.Load() == before {
+		t.Error("Expected a failed fetch to be retried upstream")
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MatchShows returns the shows whose name or alias equals name, ignoring case, diacritics and
+// repeated whitespace, so "Szívek szállodája" matches "szivek szallodaja". When year is non-nil,
+// only shows from that year are returned. Matches keep their order in shows.
+func MatchShows(shows []models.Show, name string, year *int) []models.Show {
+	query := FoldShowName(name)
+	if query == "" {
+		return nil
+	}
+
+	var matches []models.Show
+	for _, show := range shows {
+		if year != nil && show.Year != *year {
+			continue
+		}
+		if showNameMatches(show, query) {
+			matches = append(matches, show)
+		}
+	}
+	return matches
+}
+
+func showNameMatches(show models.Show, query string) bool {
+	if FoldShowName(show.Name) == query {
+		return true
+	}
+	for _, alias := range show.Aliases {
+		if FoldShowName(alias) == query {
+			return true
+		}
+	}
+	return false
+}
+
+// FoldShowName lowercases name, strips diacritics (á→a, ő→o, ű→u) and collapses runs of
+// whitespace, giving the form show names are compared in.
+func FoldShowName(name string) string {
+	stripDiacritics := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripDiacritics, name)
+	if err != nil {
+		folded = name
+	}
+	return strings.Join(strings.Fields(strings.ToLower(folded)), " ")
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestMatchShows(t *testing.T) {
+	t.Parallel()
+	shows := []models.Show{
+		{ID: 1, Name: "Dallas", Year: 1978},
+		{ID: 2, Name: "Dallas", Year: 2012},
+		{ID: 3, Name: "Dallas Cowboys Cheerleaders", Year: 2024},
+		{ID: 4, Name: "Szívek szállodája", Year: 2000, Aliases: []string{"Gilmore Girls"}},
+		{ID: 5, Name: "Ötös  Ütős Tűzoltók", Year: 2015},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		year  *int
+		want  []int
+	}{
+		{name: "duplicate names without year", query: "Dallas", want: []int{1, 2}},
+		{name: "duplicate names with year", query: "dallas", year: new(2012), want: []int{2}},
+		{name: "year without a match", query: "Dallas", year: new(1999), want: nil},
+		{name: "no prefix matching", query: "Dallas Cowboys", want: nil},
+		{name: "accent-insensitive query", query: "szivek szallodaja", want: []int{4}},
+		{name: "alias", query: "GILMORE GIRLS", want: []int{4}},
+		{name: "double acute and repeated spaces", query: "otos utos  tuzoltok", want: []int{5}},
+		{name: "empty query", query: "  ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int
+			for _, show := range MatchShows(shows, tt.query, tt.year) {
+				got = append(got, show.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MatchShows(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldShowName(t *testing.T) {
+	t.Parallel()
+	if got, want := FoldShowName("  Árvíztűrő   TÜKÖRFÚRÓGÉP "), "arvizturo tukorfurogep"; got != want {
+		t.Errorf("FoldShowName() = %q, want %q", got, want)
+	}
+}
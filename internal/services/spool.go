@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// spoolMemoryLimit is how many bytes a spool keeps in memory before spilling to a temporary file.
+const spoolMemoryLimit = 4 << 20
+
+// spool buffers a download or an intermediate archive. Content up to memLimit bytes
+// stays in memory; anything larger is moved to a temporary file, so a large season
+// pack costs disk space instead of heap. A spool is written once, then read through
+// ReadAt, Head, Reader or Bytes, and must be closed to remove its file.
+type spool struct {
+	memLimit int
+	mem      []byte
+	file     *os.File
+	size     int64
+}
+
+func newSpool() *spool {
+	return &spool{memLimit: spoolMemoryLimit}
+}
+
+// Write appends p, spilling everything written so far to a temporary file once the
+// total would exceed memLimit.
+func (s *spool) Write(p []byte) (int, error) {
+	if s.file == nil && len(s.mem)+len(p) > s.memLimit {
+		if err := s.spill(); err != nil {
+			return 0, err
+		}
+	}
+	if s.file != nil {
+		n, err := s.file.Write(p)
+		s.size += int64(n)
+		return n, err
+	}
+	if need := len(s.mem) + len(p); need > cap(s.mem) {
+		// Double rather than let append grow large slices by 25%, which would
+		// allocate several times memLimit before spilling.
+		grown := make([]byte, len(s.mem), min(max(2*cap(s.mem), need), s.memLimit))
+		copy(grown, s.mem)
+		s.mem = grown
+	}
+	s.mem = append(s.mem, p...)
+	s.size += int64(len(p))
+	return len(p), nil
+}
+
+func (s *spool) spill() error {
+	file, err := os.CreateTemp("", "supersubtitles-spool-*")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(s.mem); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+	s.file = file
+	s.mem = nil
+	return nil
+}
+
+// ReadAt implements io.ReaderAt over the spooled content.
+func (s *spool) ReadAt(p []byte, off int64) (int, error) {
+	if s.file != nil {
+		return s.file.ReadAt(p, off)
+	}
+	return bytes.NewReader(s.mem).ReadAt(p, off)
+}
+
+// Size returns the number of bytes written.
+func (s *spool) Size() int64 {
+	return s.size
+}
+
+// Spilled reports whether the content was moved to a temporary file.
+func (s *spool) Spilled() bool {
+	return s.file != nil
+}
+
+// Head returns up to n leading bytes, for signature checks that must not load the whole content.
+func (s *spool) Head(n int) []byte {
+	head := make([]byte, min(int64(n), s.size))
+	read, _ := s.ReadAt(head, 0)
+	return head[:read]
+}
+
+// Reader returns a reader over the whole content, independent of other readers.
+func (s *spool) Reader() io.Reader {
+	return io.NewSectionReader(s, 0, s.size)
+}
+
+// Bytes returns the whole content. In-memory content is returned without copying.
+func (s *spool) Bytes() ([]byte, error) {
+	if s.file == nil {
+		return s.mem, nil
+	}
+	content := make([]byte, s.size)
+	if _, err := s.file.ReadAt(content, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Close removes the temporary file, if any. It is safe to call more than once.
+func (s *spool) Close() error {
+	s.mem = nil
+	if s.file == nil {
+		return nil
+	}
+	file := s.file
+	s.file = nil
+	closeErr := file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestSpool_StaysInMemoryBelowLimit(t *testing.T) {
+	t.Parallel()
+
+	s := &spool{memLimit: 16}
+	defer s.Close()
+
+	if _, err := s.Write([]byte("hello spool")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if s.Spilled() {
+		t.Fatal("Expected content below the memory limit to stay in memory")
+	}
+
+	content, err := s.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if string(content) != "hello spool" {
+		t.Errorf("Expected %q, got %q", "hello spool", content)
+	}
+	if got := string(s.Head(5)); got != "hello" {
+		t.Errorf("Expected head %q, got %q", "hello", got)
+	}
+}
+
+func TestSpool_SpillsToTemporaryFile(t *testing.T) {
+	t.Parallel()
+
+	s := &spool{memLimit: 8}
+	want := []byte("0123456789abcdefghij")
+	for _, chunk := range [][]byte{want[:6], want[6:12], want[12:]} {
+		if _, err := s.Write(chunk); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if !s.Spilled() {
+		t.Fatal("Expected content above the memory limit to spill to a file")
+	}
+	if s.Size() != int64(len(want)) {
+		t.Errorf("Expected size %d, got %d", len(want), s.Size())
+	}
+	fileName := s.file.Name()
+
+	content, err := s.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if !bytes.Equal(content, want) {
+		t.Errorf("Expected %q, got %q", want, content)
+	}
+
+	streamed, err := io.ReadAll(s.Reader())
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(streamed, want) {
+		t.Errorf("Expected reader to yield %q, got %q", want, streamed)
+	}
+
+	part := make([]byte, 4)
+	if _, err := s.ReadAt(part, 10); err != nil {
+		t.Fatalf("ReadAt: %v", err)
+	}
+	if string(part) != "abcd" {
+		t.Errorf("Expected ReadAt to return %q, got %q", "abcd", part)
+	}
+	if got := string(s.Head(100)); got != string(want) {
+		t.Errorf("Expected head longer than the content to return all of it, got %q", got)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Errorf("Expected Close to remove %s, got: %v", fileName, err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Expected a second Close to be a no-op, got: %v", err)
+	}
+}
@@ -3,17 +3,56 @@ package services
 import (
 	"context"
 
+	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 )
 
 // SubtitleDownloader defines the interface for downloading subtitles
 type SubtitleDownloader interface {
 	// DownloadSubtitle downloads a subtitle, optionally extracting a specific episode from a season pack.
-	// If episode is nil, whole-archive downloads may be normalized before returning.
+	// The episode is selected by number, or by title when no number is given.
+	// If opts selects no episode, whole-archive downloads may be normalized before returning.
 	// Returns apperrors.ErrSubtitleNotFoundInArchive if the requested episode is not found in a season-pack archive.
-	// Returns apperrors.ErrSubtitleResourceNotFound if the subtitle URL returns HTTP 404.
-	// Returns archive.ArchiveError for archive processing failures.
-	DownloadSubtitle(ctx context.Context, downloadURL string, episode *int) (*models.DownloadResult, error)
+	// Returns apperrors.ErrSubtitleResourceNotFound if the subtitle URL returns HTTP 404, and
+	// apperrors.ErrUpstreamStatus for any other non-200 status. A 404 is remembered for the
+	// not-found TTL and answered without asking upstream, unless opts.ForceRefresh is set.
+	// Returns apperrors.ErrDownloadTooLarge if the response exceeds the download size limit, or
+	// the returned file exceeds opts.MaxBytes. A positive opts.MaxBytes also lowers the download
+	// and archive size limits, so an oversized download fails before it is read in full.
+	// Returns apperrors.ErrZipBombDetected or apperrors.ErrInvalidArchive, wrapping the
+	// archive.ArchiveError, when an archive exceeds the size limits or cannot be read.
+	// Returns archive.ArchiveError for other archive processing failures.
+	DownloadSubtitle(ctx context.Context, downloadURL string, opts models.DownloadOptions) (*models.DownloadResult, error)
+
+	// DownloadEpisodeRangeAsZip extracts episodes start through end from the season pack at
+	// downloadURL and returns them as one ZIP archive. Episodes missing from the pack are skipped;
+	// returns apperrors.ErrSubtitleNotFoundInArchive when none of them is found, and the same
+	// download and archive errors as DownloadSubtitle otherwise. A positive maxBytes applies
+	// like opts.MaxBytes of DownloadSubtitle, the packed archive being the returned file.
+	DownloadEpisodeRangeAsZip(ctx context.Context, downloadURL string, start, end int, maxBytes int64) (*models.DownloadResult, error)
+
+	// EstimateDownload reports the filename, type and size of the file at downloadURL without
+	// downloading its content. A cached archive is reported from the cache; otherwise the size
+	// comes from upstream headers, and LengthUnknown is set when upstream does not send one.
+	// Returns the same status errors as DownloadSubtitle.
+	EstimateDownload(ctx context.Context, downloadURL string) (*models.DownloadEstimate, error)
+
+	// PreloadArchive downloads the archive at downloadURL into the cache used for episode
+	// extraction, so a later episode download is a cache hit. It reports whether the archive
+	// was already cached, in which case nothing is downloaded.
+	PreloadArchive(ctx context.Context, downloadURL string) (bool, error)
+
+	// InvalidateCache removes every cached archive derived from downloadURL, and a remembered 404.
+	// Returns true if at least one cached entry existed.
+	InvalidateCache(downloadURL string) bool
+
+	// ClearCache removes every cached archive and remembered 404, and returns the number of
+	// archive entries that were present.
+	ClearCache() int
+
+	// ApplyConfig applies the dynamic cache settings of a reloaded configuration.
+	// A new cache TTL applies to entries stored from now on.
+	ApplyConfig(cfg *config.Config)
 
 	// Close releases any resources held by the downloader (e.g., cache connections).
 	Close() error
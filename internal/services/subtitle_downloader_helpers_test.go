@@ -66,6 +66,16 @@ func Test_extractSubtitleID(t *testing.T) {
 			url:  "https://feliratok.eu/index.php?felirat=12345",
 			want: "12345",
 		},
+		{
+			name: "feliratid param when felirat is missing",
+			url:  "https://feliratok.eu/index.php?feliratid=67890",
+			want: "67890",
+		},
+		{
+			name: "felirat param wins over feliratid",
+			url:  "https://feliratok.eu/index.php?feliratid=67890&felirat=12345",
+			want: "12345",
+		},
 		{
 			name: "URL without felirat param",
 			url:  "https://feliratok.eu/index.php?other=abc",
@@ -89,6 +99,45 @@ func Test_extractSubtitleID(t *testing.T) {
 	}
 }
 
+func Test_canonicalDownloadKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "subtitle ID ignores host, fnev and parameter order",
+			url:  "https://FELIRATOK.eu/index.php?fnev=Show.S01.zip&action=letolt&felirat=12345",
+			want: "id:12345",
+		},
+		{
+			name: "feliratid is used when felirat is missing",
+			url:  "https://feliratok.eu/index.php?feliratid=67890",
+			want: "id:67890",
+		},
+		{
+			name: "URL without ID gets sorted params and lowercased host",
+			url:  "HTTPS://Feliratok.EU/index.php?b=2&a=1#top",
+			want: "https://feliratok.eu/index.php?a=1&b=2",
+		},
+		{
+			name: "unparseable URL is kept as is",
+			url:  ":",
+			want: ":",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := canonicalDownloadKey(tt.url); got != tt.want {
+				t.Errorf("canonicalDownloadKey(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_isTextSubtitleContentType(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -171,11 +220,43 @@ func Test_zerologCacheLogger_Error(t *testing.T) {
 	cacheLogger.Error("test error", fmt.Errorf("test"))
 }
 
+func Test_zerologCacheLogger_Evicted(t *testing.T) {
+	t.Parallel()
+	cacheLogger := &zerologCacheLogger{logger: zerolog.New(io.Discard)}
+	// Should not panic, including without a value
+	cacheLogger.Evicted("archive-1", nil, cache.EvictionInfo{Reason: cache.EvictReasonExpired, Age: time.Second})
+}
+
+func Test_stripBOM(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "UTF-8 BOM", content: "\xEF\xBB\xBF1\n", want: "1\n"},
+		{name: "UTF-16LE BOM", content: "\xFF\xFE1\x00", want: "1\x00"},
+		{name: "UTF-16BE BOM", content: "\xFE\xFF\x001", want: "\x001"},
+		{name: "no BOM", content: "1\n", want: "1\n"},
+		{name: "BOM not at the start", content: "1\xEF\xBB\xBF", want: "1\xEF\xBB\xBF"},
+		{name: "empty", content: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := string(stripBOM([]byte(tt.content))); got != tt.want {
+				t.Errorf("stripBOM(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_convertToUTF8(t *testing.T) {
 	t.Parallel()
 	t.Run("empty content returns empty", func(t *testing.T) {
 		t.Parallel()
-		got := convertToUTF8([]byte{})
+		got, _ := convertToUTF8([]byte{}, "")
 		if len(got) != 0 {
 			t.Errorf("convertToUTF8(empty) returned %d bytes, want 0", len(got))
 		}
@@ -183,7 +264,7 @@ func Test_convertToUTF8(t *testing.T) {
 
 	t.Run("nil content returns nil", func(t *testing.T) {
 		t.Parallel()
-		got := convertToUTF8(nil)
+		got, _ := convertToUTF8(nil, "")
 		if got != nil {
 			t.Errorf("convertToUTF8(nil) returned non-nil")
 		}
@@ -192,7 +273,7 @@ func Test_convertToUTF8(t *testing.T) {
 	t.Run("valid UTF-8 content returned as-is", func(t *testing.T) {
 		t.Parallel()
 		input := []byte("Hello, world! Héllo àccénts")
-		got := convertToUTF8(input)
+		got, _ := convertToUTF8(input, "")
 		if string(got) != string(input) {
 			t.Errorf("convertToUTF8(valid UTF-8) = %q, want %q", got, input)
 		}
@@ -202,7 +283,7 @@ func Test_convertToUTF8(t *testing.T) {
 		t.Parallel()
 		// Latin-1 encoded "café" (0xe9 = é in Latin-1)
 		input := []byte{0x63, 0x61, 0x66, 0xe9}
-		got := convertToUTF8(input)
+		got, _ := convertToUTF8(input, "")
 		// After conversion, it should be valid UTF-8
 		if len(got) == 0 {
 			t.Error("convertToUTF8(latin1) returned empty result")
@@ -1,14 +1,20 @@
 package services
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
 	"mime"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 	"unicode/utf8"
 
@@ -18,24 +24,55 @@ import (
 	"github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/tracing"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/htmlindex"
 	"golang.org/x/text/transform"
 )
 
 const (
 	cacheKeyNormalizedArchivePrefix = "normalized:"
 	cacheKeyEpisodeArchivePrefix    = "episode:"
+	// Archives whose entries keep their uploaded encoding, for raw downloads
+	cacheKeyRawNormalizedArchivePrefix = "raw-normalized:"
+	cacheKeyRawEpisodeArchivePrefix    = "raw-episode:"
+)
+
+// charsetUTF8 is the source charset reported for text that already was UTF-8 and is returned unchanged
+const charsetUTF8 = "utf-8"
+
+// Label values for the subtitle download metrics.
+const (
+	downloadOutcomeSuccess = "success"
+	downloadOutcomeError   = "error"
+
+	// downloadKindExtraction marks downloads that worked on an archive: episode extraction
+	// from a season pack, or a whole-file download that returned or unwrapped a ZIP.
+	downloadKindExtraction = "extraction"
+	downloadKindFile       = "file"
 
-	// Maximum download size to prevent OOM before archive processing (150 MB)
-	maxDownloadSize = 150 * 1024 * 1024
+	extractionStepSanitize      = "sanitize"
+	extractionStepRarConversion = "rar_conversion"
+	extractionStepExtract       = "extract"
 )
 
 // DefaultSubtitleDownloader implements SubtitleDownloader with caching
 type DefaultSubtitleDownloader struct {
-	httpClient   *http.Client
-	archiveCache cache.Cache
+	httpClient      *http.Client
+	archiveCache    cache.Cache
+	inflight        *inflightGroup
+	limits          archive.Limits // Uncompressed size limits enforced on archives
+	maxDownloadSize int64          // Largest response body read before archive processing, to prevent OOM
+	notFound        *notFoundCache // Downloads upstream recently answered with 404
+	maxResumes      int            // Range requests resuming a download cut off mid-body
+	maxRestarts     int            // Full restarts of a download cut off mid-body that cannot be resumed
+	defaultCharset  string         // Encoding assumed for text that is not valid UTF-8; empty detects it
+
+	// filenameTemplate names episodes extracted from season packs; nil keeps the archive entry name
+	filenameTemplate *template.Template
 }
 
 // resolveCacheConfig returns the cache size and TTL from cfg, with fallback defaults.
@@ -52,11 +89,11 @@ func resolveCacheConfig(cfg *config.Config) (size int, ttl time.Duration) {
 		size = cfg.Cache.Size
 	}
 	if cfg.Cache.TTL != "" {
-		if d, err := time.ParseDuration(cfg.Cache.TTL); err == nil {
+		if d, err := config.ParseDuration("cache.ttl", cfg.Cache.TTL); err == nil {
 			ttl = d
 		} else {
 			logger := config.GetLogger()
-			logger.Warn().
+			logger.Warn().Err(err).
 				Str("cacheTTL", cfg.Cache.TTL).
 				Dur("defaultTTL", ttl).
 				Msg("Invalid cache TTL in configuration, falling back to default")
@@ -78,16 +115,20 @@ func NewSubtitleDownloader(httpClient *http.Client) SubtitleDownloader {
 		cacheType = cfg.Cache.Type
 	}
 
+	cacheLogger := &zerologCacheLogger{logger: config.GetLogger()}
 	providerCfg := cache.ProviderConfig{
-		Size:   cacheSize,
-		TTL:    cacheTTL,
-		Group:  "archive",
-		Logger: &zerologCacheLogger{logger: config.GetLogger()},
+		Size:    cacheSize,
+		TTL:     cacheTTL,
+		Group:   "archive",
+		Logger:  cacheLogger,
+		OnEvict: cacheLogger.Evicted,
 	}
 	if cfg != nil {
+		providerCfg.MaxBytes = cfg.Cache.MaxBytes
 		providerCfg.RedisAddress = cfg.Cache.Redis.Address
 		providerCfg.RedisPassword = cfg.Cache.Redis.Password
 		providerCfg.RedisDB = cfg.Cache.Redis.DB
+		providerCfg.RedisKeyPrefix = cfg.Cache.Redis.KeyPrefix
 	}
 
 	logger := config.GetLogger()
@@ -110,9 +151,57 @@ func NewSubtitleDownloader(httpClient *http.Client) SubtitleDownloader {
 		Dur("cacheTTL", cacheTTL).
 		Msg("Subtitle downloader cache initialized")
 
+	limits := cfg.DownloadLimits()
+	logger.Info().
+		Int64("maxFileSize", limits.MaxFileSize).
+		Int64("maxAssFileSize", limits.MaxAssFileSize).
+		Int64("maxArchiveSize", limits.MaxArchiveSize).
+		Int64("maxDownloadSize", limits.MaxDownloadSize).
+		Msg("Subtitle downloader size limits configured")
+
+	deniedExtensions := archive.DefaultDeniedExtensions
+	if cfg != nil && len(cfg.Download.ExtractionExtensionDenylist) > 0 {
+		deniedExtensions = cfg.Download.ExtractionExtensionDenylist
+	}
+
+	notFoundTTL := resolveNotFoundTTL(cfg)
+	logger.Info().Dur("notFoundTTL", notFoundTTL).Msg("Subtitle downloader not-found cache configured")
+
+	maxResumes, maxRestarts := resolveBodyRecovery(cfg)
+
+	var defaultCharset string
+	if cfg != nil {
+		defaultCharset = cfg.Download.DefaultCharset
+	}
+
+	var filenameTemplate *template.Template
+	if cfg != nil && cfg.Download.FilenameTemplate != "" {
+		filenameTemplate, err = models.ParseFilenameTemplate(cfg.Download.FilenameTemplate)
+		if err != nil {
+			logger.Warn().Err(err).
+				Str("filenameTemplate", cfg.Download.FilenameTemplate).
+				Msg("Invalid download.filename_template, keeping archive entry names")
+			filenameTemplate = nil
+		}
+	}
+
 	return &DefaultSubtitleDownloader{
 		httpClient:   httpClient,
 		archiveCache: archiveCache,
+		inflight:     newInflightGroup(),
+		limits: archive.Limits{
+			MaxFileSize:      limits.MaxFileSize,
+			MaxAssFileSize:   limits.MaxAssFileSize,
+			MaxTotalSize:     limits.MaxArchiveSize,
+			DeniedExtensions: deniedExtensions,
+			DefaultCharset:   defaultCharset,
+		},
+		maxDownloadSize:  limits.MaxDownloadSize,
+		notFound:         newNotFoundCache(notFoundTTL),
+		maxResumes:       maxResumes,
+		maxRestarts:      maxRestarts,
+		defaultCharset:   defaultCharset,
+		filenameTemplate: filenameTemplate,
 	}
 }
 
@@ -124,6 +213,72 @@ func (d *DefaultSubtitleDownloader) Close() error {
 	return nil
 }
 
+// PreloadArchive caches the archive at downloadURL under its episode cache key, sharing the
+// download with concurrent requests for the same archive. An archive that is already cached
+// is not fetched again.
+func (d *DefaultSubtitleDownloader) PreloadArchive(ctx context.Context, downloadURL string) (bool, error) {
+	if d.archiveCache.Contains(episodeArchiveCacheKey(downloadURL)) {
+		return true, nil
+	}
+
+	_, _, cacheHit, err := d.downloadArchiveForEpisode(ctx, downloadURL, false, d.requestLimits(0))
+	if err != nil {
+		return false, fmt.Errorf("failed to preload archive %s: %w", downloadURL, err)
+	}
+	return cacheHit, nil
+}
+
+// InvalidateCache removes the normalized and episode archive entries cached for downloadURL,
+// along with a remembered 404, forcing the next download to hit upstream again.
+func (d *DefaultSubtitleDownloader) InvalidateCache(downloadURL string) bool {
+	found := false
+	keys := []string{
+		normalizedArchiveCacheKey(downloadURL),
+		episodeArchiveCacheKey(downloadURL),
+		legacyNormalizedArchiveCacheKey(downloadURL),
+		legacyEpisodeArchiveCacheKey(downloadURL),
+		rawNormalizedArchiveCacheKey(downloadURL),
+		rawEpisodeArchiveCacheKey(downloadURL),
+	}
+	for _, key := range keys {
+		if d.archiveCache.Contains(key) {
+			found = true
+		}
+		d.archiveCache.Delete(key)
+	}
+	if d.notFound.remove(downloadURL) {
+		found = true
+	}
+
+	logger := config.GetLogger()
+	logger.Info().
+		Str("url", downloadURL).
+		Bool("found", found).
+		Msg("Invalidated cached archives for subtitle")
+	return found
+}
+
+// ClearCache flushes the archive cache and returns the number of entries removed.
+// Remembered 404s are forgotten as well but not counted.
+func (d *DefaultSubtitleDownloader) ClearCache() int {
+	entries := d.archiveCache.Len()
+	d.archiveCache.Clear()
+	notFound := d.notFound.clear()
+
+	logger := config.GetLogger()
+	logger.Info().Int("entries", entries).Int("notFound", notFound).Msg("Cleared archive cache")
+	return entries
+}
+
+// ApplyConfig updates the archive cache TTL from a reloaded configuration.
+func (d *DefaultSubtitleDownloader) ApplyConfig(cfg *config.Config) {
+	_, ttl := resolveCacheConfig(cfg)
+	d.archiveCache.SetTTL(ttl)
+
+	logger := config.GetLogger()
+	logger.Info().Dur("cacheTTL", ttl).Msg("Applied archive cache TTL")
+}
+
 // zerologCacheLogger adapts zerolog to the cache.Logger interface.
 type zerologCacheLogger struct {
 	logger zerolog.Logger
@@ -133,59 +288,172 @@ func (z *zerologCacheLogger) Error(msg string, err error) {
 	z.logger.Error().Err(err).Msg(msg)
 }
 
+// Evicted logs an archive leaving the cache, with why and how long it was cached.
+func (z *zerologCacheLogger) Evicted(key string, _ []byte, info cache.EvictionInfo) {
+	z.logger.Debug().
+		Str("key", key).
+		Str("reason", info.Reason.String()).
+		Dur("age", info.Age).
+		Int("size", info.Size).
+		Msg("Archive evicted from cache")
+}
+
 // DownloadSubtitle downloads a subtitle file, with support for extracting episodes from season packs.
-// If episode is nil, the entire file is returned without extraction.
-func (d *DefaultSubtitleDownloader) DownloadSubtitle(ctx context.Context, downloadURL string, episode *int) (*models.DownloadResult, error) {
+// If opts selects no episode, the entire file is returned without extraction, except that an
+// archive holding a single subtitle file is unwrapped and that file returned directly.
+func (d *DefaultSubtitleDownloader) DownloadSubtitle(ctx context.Context, downloadURL string, opts models.DownloadOptions) (result *models.DownloadResult, err error) {
+	ctx, span := tracing.Start(ctx, "downloader.DownloadSubtitle", attribute.String("url", downloadURL))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("size", len(result.Content)))
+		}
+		tracing.End(span, err)
+	}()
+
 	logger := config.GetLogger()
 	subtitleID := extractSubtitleID(downloadURL)
 	logEvent := logger.Info().
 		Str("url", downloadURL).
 		Str("subtitleID", subtitleID)
-	if episode != nil {
-		logEvent = logEvent.Int("episode", *episode)
+	if opts.Episode != nil {
+		logEvent = logEvent.Int("episode", *opts.Episode)
+		span.SetAttributes(attribute.Int("episode", *opts.Episode))
+	}
+	if opts.EpisodeTitle != "" {
+		logEvent = logEvent.Str("episodeTitle", opts.EpisodeTitle)
+	}
+	if opts.Raw {
+		logEvent = logEvent.Bool("raw", true)
 	}
 	logEvent.Msg("Downloading subtitle")
 
-	if episode == nil {
-		content, contentType, err := d.downloadSubtitleContent(ctx, downloadURL)
+	if !opts.ForceRefresh {
+		if err := d.rememberedNotFound(downloadURL); err != nil {
+			return nil, fmt.Errorf("failed to download subtitle %s: %w", downloadURL, err)
+		}
+	}
+
+	startedAt := time.Now()
+	limits := d.requestLimits(opts.MaxBytes)
+
+	if !opts.WantsEpisode() {
+		content, contentType, cacheHit, err := d.downloadSubtitleContent(ctx, downloadURL, opts.Raw, limits)
+		span.SetAttributes(attribute.Bool("cache_hit", cacheHit))
 		if err != nil {
-			metrics.SubtitleDownloadsTotal.WithLabelValues("error").Inc()
+			recordDownload(startedAt, downloadOutcomeError, downloadKindFile, cacheHit, -1)
 			return nil, fmt.Errorf("failed to download subtitle %s: %w", downloadURL, err)
 		}
 
+		if contentType == "application/zip" {
+			extractStartedAt := time.Now()
+			singleFile, err := archive.ExtractSingleSubtitleFromZip(content, limits.archive)
+			recordExtraction(extractionStepExtract, extractStartedAt)
+			if err != nil {
+				recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+				return nil, wrapArchiveError("failed to inspect subtitle archive", downloadURL, err)
+			}
+			if singleFile != nil {
+				// Uploads flagged as season packs sometimes hold a single episode; unwrap it
+				// so callers get the subtitle itself rather than a one-entry archive.
+				logger.Warn().
+					Str("subtitleID", subtitleID).
+					Str("filename", singleFile.Filename).
+					Msg("Archive contains a single subtitle file, returning it directly")
+
+				singleContentType := archive.ContentTypeForFilename(singleFile.Filename)
+				singleContent, detectedCharset := singleFile.Content, singleFile.Charset
+				if opts.SourceEncoding != "" && isTextSubtitleContentType(singleContentType) && !opts.Raw {
+					singleContent, detectedCharset, err = d.decodeUploadedSingleSubtitle(ctx, downloadURL, opts, limits)
+					if err != nil {
+						recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+						return nil, err
+					}
+				}
+				singleContent = applyStripBOM(singleContent, singleContentType, opts)
+				singleContent = applyStripStyling(singleContent, singleContentType, opts)
+				singleContent = applyTimeOffset(singleContent, singleContentType, opts)
+				if err := d.checkRequestLimit(downloadURL, len(singleContent), opts); err != nil {
+					recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+					return nil, err
+				}
+				if err := applyValidation(singleContent, singleContentType, opts); err != nil {
+					recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+					return nil, err
+				}
+
+				recordDownload(startedAt, downloadOutcomeSuccess, downloadKindExtraction, cacheHit, len(content))
+				return &models.DownloadResult{
+					Filename:        singleFile.Filename,
+					Content:         singleContent,
+					ContentType:     singleContentType,
+					Sha256:          contentSha256(singleContent),
+					DetectedCharset: detectedCharset,
+				}, nil
+			}
+		}
+
 		logger.Info().
 			Str("contentType", contentType).
 			Int("size", len(content)).
 			Msg("Returning downloaded subtitle file")
 
-		if isTextSubtitleContentType(contentType) {
-			content = convertToUTF8(content)
+		kind := downloadKindFile
+		if contentType == "application/zip" {
+			kind = downloadKindExtraction
+		}
+		size := len(content)
+
+		var detectedCharset string
+		if isTextSubtitleContentType(contentType) && !opts.Raw {
+			content, detectedCharset, err = decodeSubtitleContent(content, opts.SourceEncoding, d.defaultCharset)
+			if err != nil {
+				recordDownload(startedAt, downloadOutcomeError, kind, cacheHit, size)
+				return nil, err
+			}
+		}
+		content = applyStripBOM(content, contentType, opts)
+		content = applyStripStyling(content, contentType, opts)
+		content = applyTimeOffset(content, contentType, opts)
+		if err := d.checkRequestLimit(downloadURL, len(content), opts); err != nil {
+			recordDownload(startedAt, downloadOutcomeError, kind, cacheHit, size)
+			return nil, err
+		}
+		if err := applyValidation(content, contentType, opts); err != nil {
+			recordDownload(startedAt, downloadOutcomeError, kind, cacheHit, size)
+			return nil, err
 		}
 
-		metrics.SubtitleDownloadsTotal.WithLabelValues("success").Inc()
+		recordDownload(startedAt, downloadOutcomeSuccess, kind, cacheHit, size)
 		return &models.DownloadResult{
-			Filename:    generateFilename(subtitleID, contentType),
-			Content:     content,
-			ContentType: contentType,
+			Filename:        generateFilename(subtitleID, contentType),
+			Content:         content,
+			ContentType:     contentType,
+			Sha256:          contentSha256(content),
+			DetectedCharset: detectedCharset,
 		}, nil
 	}
 
-	content, _, err := d.downloadArchiveForEpisode(ctx, downloadURL)
+	// A source_encoding override applies to the bytes an entry was uploaded with, so the
+	// archive is fetched without the conversion to UTF-8 and the episode decoded below
+	content, _, cacheHit, err := d.downloadArchiveForEpisode(ctx, downloadURL, opts.Raw || opts.SourceEncoding != "", limits)
+	span.SetAttributes(attribute.Bool("cache_hit", cacheHit))
 	if err != nil {
-		metrics.SubtitleDownloadsTotal.WithLabelValues("error").Inc()
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, -1)
 		return nil, fmt.Errorf("failed to download subtitle %s: %w", downloadURL, err)
 	}
 
 	// downloadArchiveForEpisode guarantees ZIP content (RAR is converted, unknown format errors).
 	logger.Info().
-		Int("episode", *episode).
+		Str("selector", describeEpisodeSelector(opts)).
 		Int("zipSize", len(content)).
 		Msg("Extracting episode from season pack ZIP")
 
-	episodeFile, err := d.extractEpisodeFromZip(content, *episode)
+	extractStartedAt := time.Now()
+	episodeFile, err := d.extractEpisodeFromZip(ctx, content, opts, limits.archive)
+	recordExtraction(extractionStepExtract, extractStartedAt)
 	if err != nil {
-		metrics.SubtitleDownloadsTotal.WithLabelValues("error").Inc()
-		return nil, wrapArchiveError(fmt.Sprintf("failed to extract episode %d from archive", *episode), downloadURL, err)
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, wrapArchiveError(fmt.Sprintf("failed to extract %s from archive", describeEpisodeSelector(opts)), downloadURL, err)
 	}
 
 	logger.Info().
@@ -193,10 +461,205 @@ func (d *DefaultSubtitleDownloader) DownloadSubtitle(ctx context.Context, downlo
 		Int("size", len(episodeFile.Content)).
 		Msg("Successfully extracted episode from season pack")
 
-	metrics.SubtitleDownloadsTotal.WithLabelValues("success").Inc()
+	if opts.SourceEncoding != "" && isTextSubtitleContentType(episodeFile.ContentType) && !opts.Raw {
+		episodeFile.Content, episodeFile.DetectedCharset, err = decodeSubtitleContent(episodeFile.Content, opts.SourceEncoding, d.defaultCharset)
+		if err != nil {
+			recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+			return nil, err
+		}
+	}
+	episodeFile.Content = applyStripBOM(episodeFile.Content, episodeFile.ContentType, opts)
+	episodeFile.Content = applyStripStyling(episodeFile.Content, episodeFile.ContentType, opts)
+	episodeFile.Content = applyTimeOffset(episodeFile.Content, episodeFile.ContentType, opts)
+	if err := d.checkRequestLimit(downloadURL, len(episodeFile.Content), opts); err != nil {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, err
+	}
+	if err := applyValidation(episodeFile.Content, episodeFile.ContentType, opts); err != nil {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, err
+	}
+	episodeFile.Sha256 = contentSha256(episodeFile.Content)
+
+	recordDownload(startedAt, downloadOutcomeSuccess, downloadKindExtraction, cacheHit, len(content))
 	return episodeFile, nil
 }
 
+// DownloadEpisodeRangeAsZip extracts episodes start through end from the season pack at
+// downloadURL and packs them into a new ZIP archive. The season pack is downloaded once, through
+// the same cache as single-episode downloads. Episodes missing from the pack are skipped with a
+// warning, and a file holding several episodes of the range is included once. A positive
+// maxBytes lowers the size limits as DownloadOptions.MaxBytes does, and applies to the packed ZIP.
+func (d *DefaultSubtitleDownloader) DownloadEpisodeRangeAsZip(ctx context.Context, downloadURL string, start, end int, maxBytes int64) (*models.DownloadResult, error) {
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid episode range %d-%d", start, end)
+	}
+
+	logger := config.GetLogger()
+	subtitleID := extractSubtitleID(downloadURL)
+	logger.Info().
+		Str("url", downloadURL).
+		Str("subtitleID", subtitleID).
+		Int("episodeStart", start).
+		Int("episodeEnd", end).
+		Msg("Downloading episode range")
+
+	startedAt := time.Now()
+	limits := d.requestLimits(maxBytes)
+	content, _, cacheHit, err := d.downloadArchiveForEpisode(ctx, downloadURL, false, limits)
+	if err != nil {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, -1)
+		return nil, fmt.Errorf("failed to download subtitle %s: %w", downloadURL, err)
+	}
+
+	extractStartedAt := time.Now()
+	var files []*archive.EpisodeFile
+	included := make(map[string]bool)
+	fileCount := 0
+	for episode := start; episode <= end; episode++ {
+		episodeFile, err := archive.ExtractEpisodeFromZip(content, episode, limits.archive, logger)
+		var notFound *archive.ErrEpisodeNotFound
+		if errors.As(err, &notFound) {
+			fileCount = notFound.FileCount
+			logger.Warn().
+				Str("subtitleID", subtitleID).
+				Int("episode", episode).
+				Msg("Episode of the requested range not found in season pack, skipping")
+			continue
+		}
+		if err != nil {
+			recordExtraction(extractionStepExtract, extractStartedAt)
+			recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+			return nil, wrapArchiveError(fmt.Sprintf("failed to extract episode %d from archive", episode), downloadURL, err)
+		}
+		// A multi-episode file such as S01E01E02 matches each of its episodes
+		if included[episodeFile.Filename] {
+			continue
+		}
+		included[episodeFile.Filename] = true
+		files = append(files, episodeFile)
+	}
+	recordExtraction(extractionStepExtract, extractStartedAt)
+
+	if len(files) == 0 {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, &apperrors.ErrSubtitleNotFoundInArchive{Episode: start, EpisodeEnd: end, FileCount: fileCount}
+	}
+
+	charsets := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.Charset != "" && !slices.Contains(charsets, file.Charset) {
+			charsets = append(charsets, file.Charset)
+		}
+	}
+	slices.Sort(charsets)
+
+	packed, err := archive.PackZip(files)
+	if err != nil {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, wrapProcessingArchiveError("failed to pack episode range", err)
+	}
+
+	if err := d.checkRequestLimit(downloadURL, len(packed), models.DownloadOptions{MaxBytes: maxBytes}); err != nil {
+		recordDownload(startedAt, downloadOutcomeError, downloadKindExtraction, cacheHit, len(content))
+		return nil, err
+	}
+
+	logger.Info().
+		Str("subtitleID", subtitleID).
+		Int("episodes", len(files)).
+		Int("size", len(packed)).
+		Msg("Packed episode range into ZIP")
+
+	recordDownload(startedAt, downloadOutcomeSuccess, downloadKindExtraction, cacheHit, len(content))
+	return &models.DownloadResult{
+		Filename:        episodeRangeFilename(subtitleID, start, end),
+		Content:         packed,
+		ContentType:     "application/zip",
+		Sha256:          contentSha256(packed),
+		DetectedCharset: strings.Join(charsets, ","),
+	}, nil
+}
+
+// downloadLimits bounds one download: the response body read from upstream, and the
+// uncompressed sizes enforced while its archive is sanitized and extracted.
+type downloadLimits struct {
+	maxDownloadSize int64
+	archive         archive.Limits
+	maxBytes        int64 // The caller's lower limit, or zero when the configured limits apply
+}
+
+// requestLimits returns the configured limits, lowered to maxBytes when it is positive and
+// smaller than the download limit.
+func (d *DefaultSubtitleDownloader) requestLimits(maxBytes int64) downloadLimits {
+	limits := downloadLimits{maxDownloadSize: d.maxDownloadSize, archive: d.limits}
+	if maxBytes <= 0 || maxBytes >= d.maxDownloadSize {
+		return limits
+	}
+	limits.maxDownloadSize = maxBytes
+	limits.archive.MaxFileSize = min(limits.archive.MaxFileSize, maxBytes)
+	limits.archive.MaxAssFileSize = min(limits.archive.MaxAssFileSize, maxBytes)
+	limits.archive.MaxTotalSize = min(limits.archive.MaxTotalSize, maxBytes)
+	limits.maxBytes = maxBytes
+	return limits
+}
+
+// inflightKey returns the key a download cached under cacheKey is shared under. A download
+// read with a caller's lower limits fails where the configured limits would not, so it is
+// only shared with callers asking for the same limit. What it caches is the same archive.
+func (l downloadLimits) inflightKey(cacheKey string) string {
+	if l.maxBytes == 0 {
+		return cacheKey
+	}
+	return cacheKey + "|max_bytes=" + strconv.FormatInt(l.maxBytes, 10)
+}
+
+// checkRequestLimit returns apperrors.ErrDownloadTooLarge when a file of size bytes is larger than
+// the limit the caller asked for with opts.MaxBytes. The download and archive were already read
+// within that limit, see requestLimits; this applies it to the file the caller receives, which
+// conversion can make larger and an archive cached by an earlier download was not read for.
+func (d *DefaultSubtitleDownloader) checkRequestLimit(downloadURL string, size int, opts models.DownloadOptions) error {
+	if opts.MaxBytes <= 0 {
+		return nil
+	}
+	limit := min(opts.MaxBytes, d.maxDownloadSize)
+	if int64(size) <= limit {
+		return nil
+	}
+
+	logger := config.GetLogger()
+	logger.Warn().
+		Str("url", downloadURL).
+		Int("size", size).
+		Int64("limit", limit).
+		Msg("Subtitle exceeded the requested size limit")
+	return &apperrors.ErrDownloadTooLarge{Size: int64(size), Limit: limit}
+}
+
+// recordDownload updates the download counter and histograms for one DownloadSubtitle call.
+// size is the length of the file or archive the download worked on, or -1 when the download
+// failed before any content was obtained, in which case no size is observed.
+func recordDownload(startedAt time.Time, outcome, kind string, cacheHit bool, size int) {
+	metrics.SubtitleDownloadsTotal.WithLabelValues(outcome).Inc()
+	labels := []string{outcome, kind, strconv.FormatBool(cacheHit)}
+	metrics.SubtitleDownloadDurationSeconds.WithLabelValues(labels...).Observe(time.Since(startedAt).Seconds())
+	if size >= 0 {
+		metrics.SubtitleDownloadBytes.WithLabelValues(labels...).Observe(float64(size))
+	}
+}
+
+// recordExtraction observes the duration of one archive processing step.
+func recordExtraction(step string, startedAt time.Time) {
+	metrics.SubtitleExtractionDurationSeconds.WithLabelValues(step).Observe(time.Since(startedAt).Seconds())
+}
+
+// contentSha256 returns the lowercase hex SHA-256 of content. It is computed after any
+// UTF-8 conversion so it matches the bytes returned to the caller.
+func contentSha256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
 // generateFilename creates a filename with appropriate extension based on content type
 func generateFilename(subtitleID, contentType string) string {
 	if subtitleID == "" {
@@ -206,55 +669,143 @@ func generateFilename(subtitleID, contentType string) string {
 	return fmt.Sprintf("%s%s", subtitleID, ext)
 }
 
+// episodeRangeFilename names the ZIP returned by DownloadEpisodeRangeAsZip, such as "1234_E01-E05.zip".
+func episodeRangeFilename(subtitleID string, start, end int) string {
+	if subtitleID == "" {
+		subtitleID = "subtitle"
+	}
+	return fmt.Sprintf("%s_E%02d-E%02d.zip", subtitleID, start, end)
+}
+
+// extractSubtitleID returns the subtitle ID of a download URL, read from the felirat
+// query parameter or, failing that, from feliratid. It returns "" when neither is set.
 func extractSubtitleID(downloadURL string) string {
 	parsedURL, err := url.Parse(downloadURL)
 	if err != nil {
 		return ""
 	}
 
-	return parsedURL.Query().Get("felirat")
+	query := parsedURL.Query()
+	if id := query.Get("felirat"); id != "" {
+		return id
+	}
+	return query.Get("feliratid")
+}
+
+// describeEpisodeSelector renders the episode selector of opts for logs and error messages.
+func describeEpisodeSelector(opts models.DownloadOptions) string {
+	if opts.Episode != nil {
+		return fmt.Sprintf("episode %d", *opts.Episode)
+	}
+	return fmt.Sprintf("episode titled %q", opts.EpisodeTitle)
 }
 
 func normalizedArchiveCacheKey(url string) string {
-	return cacheKeyNormalizedArchivePrefix + url
+	return cacheKeyNormalizedArchivePrefix + canonicalDownloadKey(url)
 }
 
 func episodeArchiveCacheKey(url string) string {
+	return cacheKeyEpisodeArchivePrefix + canonicalDownloadKey(url)
+}
+
+// legacyNormalizedArchiveCacheKey and legacyEpisodeArchiveCacheKey return the raw-URL keys
+// archives were cached under before keys were canonicalized. They are only read on a miss
+// so entries written by older releases to a shared cache are still used until they expire.
+func legacyNormalizedArchiveCacheKey(url string) string {
+	return cacheKeyNormalizedArchivePrefix + url
+}
+
+func legacyEpisodeArchiveCacheKey(url string) string {
 	return cacheKeyEpisodeArchivePrefix + url
 }
 
+func rawNormalizedArchiveCacheKey(url string) string {
+	return cacheKeyRawNormalizedArchivePrefix + canonicalDownloadKey(url)
+}
+
+func rawEpisodeArchiveCacheKey(url string) string {
+	return cacheKeyRawEpisodeArchivePrefix + canonicalDownloadKey(url)
+}
+
+// canonicalDownloadKey identifies the subtitle behind a download URL so that different
+// spellings of the same download share one cache entry. It is "id:" followed by the
+// subtitle ID when the URL carries one. Otherwise it is the URL with a lowercased scheme
+// and host and sorted query parameters, or the raw URL if it cannot be parsed.
+func canonicalDownloadKey(downloadURL string) string {
+	if id := extractSubtitleID(downloadURL); id != "" {
+		return "id:" + id
+	}
+
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil {
+		return downloadURL
+	}
+	parsedURL.Scheme = strings.ToLower(parsedURL.Scheme)
+	parsedURL.Host = strings.ToLower(parsedURL.Host)
+	parsedURL.RawQuery = parsedURL.Query().Encode()
+	parsedURL.Fragment = ""
+	return parsedURL.String()
+}
+
 func wrapArchiveError(message, url string, err error) error {
 	if err == nil {
 		return nil
 	}
 	var episodeErr *archive.ErrEpisodeNotFound
 	if errors.As(err, &episodeErr) {
-		return &apperrors.ErrSubtitleNotFoundInArchive{Episode: episodeErr.Episode, FileCount: episodeErr.FileCount}
+		return &apperrors.ErrSubtitleNotFoundInArchive{
+			Episode:         episodeErr.Episode,
+			EpisodeTitle:    episodeErr.Title,
+			FileCount:       episodeErr.FileCount,
+			AvailableTitles: episodeErr.Available,
+		}
 	}
-	if errors.Is(err, &apperrors.ErrSubtitleNotFoundInArchive{}) {
+	if errors.Is(err, &apperrors.ErrSubtitleNotFoundInArchive{}) || isTypedArchiveError(err) {
 		return err
 	}
 	var archiveErr *archive.ArchiveError
 	if errors.As(err, &archiveErr) {
 		if archiveErr.URL != "" {
-			return err
+			return typedArchiveError(url, err)
 		}
 		if archiveErr.Unrecoverable {
-			return archive.NewUnrecoverableErrorWithURL(message, url, archiveErr)
+			return typedArchiveError(url, archive.NewUnrecoverableErrorWithURL(message, url, archiveErr))
 		}
-		return archive.NewErrorWithURL(message, url, archiveErr)
+		return typedArchiveError(url, archive.NewErrorWithURL(message, url, archiveErr))
 	}
 	return archive.NewErrorWithURL(message, url, err)
 }
 
 func wrapProcessingArchiveError(message string, err error) error {
+	if isTypedArchiveError(err) {
+		return err
+	}
 	var archiveErr *archive.ArchiveError
 	if errors.As(err, &archiveErr) {
-		return err
+		return typedArchiveError("", err)
 	}
 	return archive.NewError(message, err)
 }
 
+// typedArchiveError wraps archive errors caused by a decompression bomb or malformed
+// archive content in the matching apperrors type, so callers can tell them apart with
+// errors.Is and the gRPC layer maps them to their own status codes. Other errors are
+// returned unchanged.
+func typedArchiveError(url string, err error) error {
+	var bombErr *archive.ErrDecompressionBomb
+	if errors.As(err, &bombErr) {
+		return &apperrors.ErrZipBombDetected{URL: url, Reason: bombErr.Reason, Err: err}
+	}
+	if errors.Is(err, &archive.ErrMalformedArchive{}) {
+		return &apperrors.ErrInvalidArchive{Err: err}
+	}
+	return err
+}
+
+func isTypedArchiveError(err error) bool {
+	return errors.Is(err, &apperrors.ErrZipBombDetected{}) || errors.Is(err, &apperrors.ErrInvalidArchive{})
+}
+
 // isTextSubtitleContentType checks if the content type is a text-based subtitle format
 // that should be converted to UTF-8
 func isTextSubtitleContentType(contentType string) bool {
@@ -271,6 +822,61 @@ func isTextSubtitleContentType(contentType string) bool {
 	return false
 }
 
+// utf8BOM, utf16LEBOM and utf16BEBOM are the byte order marks removed by stripBOM.
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// applyStripBOM removes a leading byte order mark from a text subtitle, which some players
+// render as a stray glyph on the first cue. Nothing is removed when opts.KeepBOM or opts.Raw
+// is set, or from archives and other binary content.
+func applyStripBOM(content []byte, contentType string, opts models.DownloadOptions) []byte {
+	if opts.KeepBOM || opts.Raw || !isTextSubtitleContentType(contentType) {
+		return content
+	}
+	return stripBOM(content)
+}
+
+// stripBOM removes a leading UTF-8 or UTF-16 byte order mark from content.
+func stripBOM(content []byte) []byte {
+	for _, bom := range [][]byte{utf8BOM, utf16LEBOM, utf16BEBOM} {
+		if bytes.HasPrefix(content, bom) {
+			return content[len(bom):]
+		}
+	}
+	return content
+}
+
+// applyStripStyling rewrites an ASS or SSA file with StripASSStyling when opts.StripStyling is set.
+// Files are recognized by content type or, for generic types such as application/octet-stream,
+// by their [Script Info] header.
+func applyStripStyling(content []byte, contentType string, opts models.DownloadOptions) []byte {
+	if !opts.StripStyling {
+		return content
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	switch mediaType {
+	case "application/x-ass", "text/ass", "text/x-ssa":
+	default:
+		if !archive.IsGenericContentType(contentType) || archive.SniffSubtitleContentType(content) != "application/x-ass" {
+			return content
+		}
+	}
+
+	stripped, ok := StripASSStyling(content)
+	if !ok {
+		logger := config.GetLogger()
+		logger.Warn().Str("contentType", contentType).Msg("ASS subtitle has no [Events] section, returning it unchanged")
+		return content
+	}
+	return stripped
+}
+
 func isHTMLContentType(contentType string) bool {
 	mediaType, _, err := mime.ParseMediaType(contentType)
 	if err != nil {
@@ -285,18 +891,51 @@ func isHTMLContentType(contentType string) bool {
 	}
 }
 
-// convertToUTF8 detects the character encoding of text content and converts it to UTF-8.
-// It handles BOM detection and uses heuristic charset detection.
-// If the content is already valid UTF-8, this is a no-op.
-func convertToUTF8(content []byte) []byte {
+// decodeSubtitleContent converts text content to UTF-8 and returns the name of the encoding it
+// was read as. A non-empty sourceEncoding, such as "windows-1250", is used instead of detection.
+// A name that is not a known encoding is logged and content converted by convertToUTF8, as it
+// is without one; content a known encoding cannot decode returns
+// *apperrors.ErrInvalidSourceEncoding rather than bytes that are not UTF-8.
+func decodeSubtitleContent(content []byte, sourceEncoding, defaultCharset string) ([]byte, string, error) {
+	if sourceEncoding == "" {
+		decoded, charsetName := convertToUTF8(content, defaultCharset)
+		return decoded, charsetName, nil
+	}
+
+	enc, err := htmlindex.Get(sourceEncoding)
+	if err != nil {
+		logger := config.GetLogger()
+		logger.Warn().
+			Str("sourceEncoding", sourceEncoding).
+			Msg("Unknown source encoding, detecting the subtitle encoding instead")
+		decoded, charsetName := convertToUTF8(content, defaultCharset)
+		return decoded, charsetName, nil
+	}
+	decoded, _, err := transform.Bytes(enc.NewDecoder(), content)
+	if err != nil {
+		return nil, "", &apperrors.ErrInvalidSourceEncoding{Encoding: sourceEncoding, Reason: err.Error()}
+	}
+	name, _ := htmlindex.Name(enc)
+	return decoded, cmp.Or(name, strings.ToLower(sourceEncoding)), nil
+}
+
+// convertToUTF8 converts text content to UTF-8 and returns the name of the encoding it was
+// read as, such as "iso-8859-2". Content that already is valid UTF-8 is returned unchanged as
+// "utf-8". Otherwise a byte order mark decides, then defaultCharset when set, then the
+// heuristics of charset.DetermineEncoding. If the conversion fails, the original content is
+// returned with an empty name.
+func convertToUTF8(content []byte, defaultCharset string) ([]byte, string) {
 	if len(content) == 0 || utf8.Valid(content) {
-		return content
+		return content, charsetUTF8
 	}
 
-	// Try to detect encoding from the content
-	// We pass a fake "text/plain" content type so charset.DetermineEncoding uses
-	// the BOM and content heuristics rather than a declared charset
-	encoding, _, _ := charset.DetermineEncoding(content, "text/plain")
+	// A "text/plain" content type makes charset.DetermineEncoding use the BOM and content
+	// heuristics; the configured default is passed as its declared charset
+	contentType := "text/plain"
+	if defaultCharset != "" {
+		contentType += "; charset=" + defaultCharset
+	}
+	encoding, charsetName, _ := charset.DetermineEncoding(content, contentType)
 
 	// Transform the content to UTF-8
 	decoded, _, err := transform.Bytes(encoding.NewDecoder(), content)
@@ -304,55 +943,44 @@ func convertToUTF8(content []byte) []byte {
 		// If transformation fails, return original content
 		logger := config.GetLogger()
 		logger.Warn().Err(err).Msg("Failed to convert subtitle content to UTF-8, returning original")
-		return content
+		return content, ""
 	}
 
-	return decoded
+	return decoded, charsetName
 }
 
-// downloadFile downloads a file from the given URL without archive normalization.
-func (d *DefaultSubtitleDownloader) downloadFile(ctx context.Context, url string) ([]byte, string, error) {
-	logger := config.GetLogger()
-
-	// Download from URL
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create request: %w", err)
+// rememberedNotFound returns apperrors.ErrSubtitleResourceNotFound when upstream answered
+// downloadURL with 404 within the not-found TTL, and nil otherwise.
+func (d *DefaultSubtitleDownloader) rememberedNotFound(downloadURL string) error {
+	if !d.notFound.contains(downloadURL) {
+		return nil
 	}
+	metrics.SubtitleDownloadsNotFoundCachedTotal.Inc()
+	logger := config.GetLogger()
+	logger.Debug().Str("url", downloadURL).Msg("Answering download with a remembered upstream 404")
+	return &apperrors.ErrSubtitleResourceNotFound{URL: downloadURL}
+}
 
-	req.Header.Set("User-Agent", config.GetUserAgent())
+// downloadFile downloads a file from the given URL without archive normalization.
+// The response body is written to a spool, so large archives are buffered in a
+// temporary file rather than in memory. A body larger than maxSize returns
+// apperrors.ErrDownloadTooLarge. The caller must close the returned spool.
+func (d *DefaultSubtitleDownloader) downloadFile(ctx context.Context, url string, maxSize int64) (downloaded *spool, downloadedType string, err error) {
+	logger := config.GetLogger()
+	ctx, span := tracing.Start(ctx, "downloader.downloadFile", attribute.String("url", url))
+	defer func() {
+		if downloaded != nil {
+			span.SetAttributes(attribute.Int64("size", downloaded.Size()), attribute.String("content_type", downloadedType))
+		}
+		tracing.End(span, err)
+	}()
 
-	resp, err := d.httpClient.Do(req)
+	resp, err := d.requestDownload(ctx, url)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to execute request: %w", err)
+		return nil, "", err
 	}
 	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, "", &apperrors.ErrSubtitleResourceNotFound{URL: url}
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	// Limit reading to prevent OOM with very large files
-	// Use LimitReader to cap at maxDownloadSize + 1 byte to detect oversized responses
-	limitedReader := io.LimitReader(resp.Body, int64(maxDownloadSize+1))
-	content, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check if download exceeded size limit
-	if len(content) > maxDownloadSize {
-		logger.Warn().
-			Str("url", url).
-			Int("size", len(content)).
-			Int("limit", maxDownloadSize).
-			Msg("Download exceeded size limit")
-		return nil, "", fmt.Errorf("download size (%d bytes) exceeds limit (%d bytes)", len(content), maxDownloadSize)
-	}
+	d.notFound.remove(url)
 
 	contentType := resp.Header.Get("Content-Type")
 	if contentType == "" {
@@ -366,135 +994,403 @@ func (d *DefaultSubtitleDownloader) downloadFile(ctx context.Context, url string
 		)
 	}
 
-	return content, contentType, nil
+	// Reads up to maxSize + 1 bytes to detect oversized responses
+	body, err := d.readDownloadBody(ctx, url, resp, maxSize)
+	if err != nil {
+		return nil, "", err
+	}
+	size := body.Size()
+
+	// Check if download exceeded size limit
+	if size > maxSize {
+		_ = body.Close()
+		logger.Warn().
+			Str("url", url).
+			Int64("size", size).
+			Int64("limit", maxSize).
+			Msg("Download exceeded size limit")
+		return nil, "", &apperrors.ErrDownloadTooLarge{Size: size, Limit: maxSize}
+	}
+
+	return body, contentType, nil
 }
 
 // downloadSubtitleContent downloads a subtitle resource and returns its content.
 // The response may be a plain text subtitle (e.g. SRT), a ZIP archive, or a RAR archive.
 // ZIP files are returned as-is, RAR files are normalized to ZIP, and text files are
-// returned with their original content type. Only archives are cached.
-func (d *DefaultSubtitleDownloader) downloadSubtitleContent(ctx context.Context, url string) ([]byte, string, error) {
+// returned with their original content type. Only archives are cached; cacheHit reports
+// whether the content came from the archive cache. With keepEncoding, archive entries keep
+// their uploaded encoding and are cached apart from the converted archive. A download is
+// read within limits.
+func (d *DefaultSubtitleDownloader) downloadSubtitleContent(ctx context.Context, url string, keepEncoding bool, limits downloadLimits) (content []byte, contentType string, cacheHit bool, err error) {
 	logger := config.GetLogger()
 
-	cacheKey := normalizedArchiveCacheKey(url)
-	if cached, found := d.archiveCache.Get(cacheKey); found {
+	cacheKey, legacyKey := normalizedArchiveCacheKey(url), legacyNormalizedArchiveCacheKey(url)
+	if keepEncoding {
+		cacheKey = rawNormalizedArchiveCacheKey(url)
+		legacyKey = cacheKey
+	}
+	if call := d.inflight.lookup(limits.inflightKey(cacheKey)); call != nil {
+		content, contentType, err = d.awaitInflight(ctx, call, url)
+		return content, contentType, false, err
+	}
+	if cached, found := d.getCachedArchive(cacheKey, legacyKey); found {
 		logger.Debug().
 			Str("url", url).
+			Bool("keepEncoding", keepEncoding).
 			Msg("Retrieved normalized download archive from cache")
-		return cached, "application/zip", nil
+		return cached, "application/zip", true, nil
 	}
 
-	content, contentType, err := d.downloadFile(ctx, url)
+	content, contentType, err = d.loadShared(ctx, limits.inflightKey(cacheKey), url, func(ctx context.Context, url string) ([]byte, string, error) {
+		return d.fetchSubtitleContent(ctx, url, cacheKey, keepEncoding, limits)
+	})
+	return content, contentType, false, err
+}
+
+// fetchSubtitleContent downloads url and normalizes archives for whole-file downloads,
+// caching the result under cacheKey.
+func (d *DefaultSubtitleDownloader) fetchSubtitleContent(ctx context.Context, url, cacheKey string, keepEncoding bool, limits downloadLimits) ([]byte, string, error) {
+	logger := config.GetLogger()
+
+	body, contentType, err := d.downloadFile(ctx, url, limits.maxDownloadSize)
 	if err != nil {
 		return nil, "", err
 	}
+	defer body.Close()
 
-	archiveFormat := archive.DetectFormat(content, contentType)
+	archiveFormat := archive.DetectFormat(body.Head(archive.SignatureSize), contentType)
 	switch archiveFormat {
 	case archive.FormatZIP:
-		sanitized, err := archive.SanitizeZip(content)
+		sanitized, err := d.sanitizeZip(ctx, body, keepEncoding, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to sanitize ZIP archive", err)
 		}
-		d.archiveCache.Set(cacheKey, sanitized)
+		defer sanitized.Close()
+
+		content, err := d.cacheArchive(cacheKey, sanitized)
+		if err != nil {
+			return nil, "", err
+		}
 		logger.Debug().
 			Str("url", url).
-			Int("originalSize", len(content)).
-			Int("sanitizedSize", len(sanitized)).
+			Int64("originalSize", body.Size()).
+			Int("sanitizedSize", len(content)).
 			Msg("Sanitized and cached ZIP download archive")
-		return sanitized, "application/zip", nil
+		return content, "application/zip", nil
 	case archive.FormatRAR:
-		normalized, err := archive.ConvertRarToZip(content)
+		normalized, err := d.convertRarToZip(body, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to normalize RAR archive to ZIP", err)
 		}
-		sanitized, err := archive.SanitizeZip(normalized)
+		defer normalized.Close()
+
+		sanitized, err := d.sanitizeZip(ctx, normalized, keepEncoding, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to sanitize converted RAR archive", err)
 		}
+		defer sanitized.Close()
 
-		d.archiveCache.Set(cacheKey, sanitized)
+		content, err := d.cacheArchive(cacheKey, sanitized)
+		if err != nil {
+			return nil, "", err
+		}
 		logger.Info().
 			Str("url", url).
-			Int("rarSize", len(content)).
-			Int("zipSize", len(sanitized)).
+			Int64("rarSize", body.Size()).
+			Int("zipSize", len(content)).
 			Msg("Normalized RAR archive to ZIP, sanitized, and cached it")
-		return sanitized, "application/zip", nil
+		return content, "application/zip", nil
 	default:
+		content, err := body.Bytes()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read downloaded subtitle: %w", err)
+		}
+		// Upstream often labels plain subtitles application/octet-stream; sniff the format so
+		// the filename extension and UTF-8 conversion follow the actual content.
+		if archive.IsGenericContentType(contentType) {
+			if sniffed := archive.SniffSubtitleContentType(content); sniffed != "" {
+				logger.Debug().
+					Str("url", url).
+					Str("declaredContentType", contentType).
+					Str("sniffedContentType", sniffed).
+					Msg("Sniffed subtitle content type")
+				return content, sniffed, nil
+			}
+		}
 		return content, archive.NormalizeContentType(contentType, archiveFormat), nil
 	}
 }
 
 // downloadArchiveForEpisode downloads and returns a ZIP archive suitable for episode extraction.
-// RAR archives are automatically converted to ZIP before caching.
-func (d *DefaultSubtitleDownloader) downloadArchiveForEpisode(ctx context.Context, url string) ([]byte, string, error) {
+// RAR archives are automatically converted to ZIP before caching. cacheHit reports whether
+// the archive came from the archive cache. With keepEncoding, entries keep their uploaded
+// encoding and the archive is cached apart from the converted one. A download is read
+// within limits.
+func (d *DefaultSubtitleDownloader) downloadArchiveForEpisode(ctx context.Context, url string, keepEncoding bool, limits downloadLimits) (content []byte, contentType string, cacheHit bool, err error) {
 	logger := config.GetLogger()
 
-	cacheKey := episodeArchiveCacheKey(url)
-	if cached, found := d.archiveCache.Get(cacheKey); found {
+	cacheKey, legacyKey := episodeArchiveCacheKey(url), legacyEpisodeArchiveCacheKey(url)
+	if keepEncoding {
+		cacheKey = rawEpisodeArchiveCacheKey(url)
+		legacyKey = cacheKey
+	}
+	if call := d.inflight.lookup(limits.inflightKey(cacheKey)); call != nil {
+		content, contentType, err = d.awaitInflight(ctx, call, url)
+		return content, contentType, false, err
+	}
+	if cached, found := d.getCachedArchive(cacheKey, legacyKey); found {
 		logger.Debug().
 			Str("url", url).
+			Bool("keepEncoding", keepEncoding).
 			Msg("Retrieved episode archive from cache")
-		return cached, "application/zip", nil
+		return cached, "application/zip", true, nil
 	}
 
-	content, contentType, err := d.downloadFile(ctx, url)
+	content, contentType, err = d.loadShared(ctx, limits.inflightKey(cacheKey), url, func(ctx context.Context, url string) ([]byte, string, error) {
+		return d.fetchArchiveForEpisode(ctx, url, cacheKey, keepEncoding, limits)
+	})
+	return content, contentType, false, err
+}
+
+// fetchArchiveForEpisode downloads url and converts it to a sanitized ZIP for episode
+// extraction, caching the result under cacheKey.
+func (d *DefaultSubtitleDownloader) fetchArchiveForEpisode(ctx context.Context, url, cacheKey string, keepEncoding bool, limits downloadLimits) ([]byte, string, error) {
+	logger := config.GetLogger()
+
+	body, contentType, err := d.downloadFile(ctx, url, limits.maxDownloadSize)
 	if err != nil {
 		return nil, "", err
 	}
+	defer body.Close()
 
-	archiveFormat := archive.DetectFormat(content, contentType)
+	archiveFormat := archive.DetectFormat(body.Head(archive.SignatureSize), contentType)
 	switch archiveFormat {
 	case archive.FormatZIP:
-		sanitized, err := archive.SanitizeZip(content)
+		sanitized, err := d.sanitizeZip(ctx, body, keepEncoding, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to sanitize ZIP archive for episode extraction", err)
 		}
-		d.archiveCache.Set(cacheKey, sanitized)
+		defer sanitized.Close()
+
+		content, err := d.cacheArchive(cacheKey, sanitized)
+		if err != nil {
+			return nil, "", err
+		}
 		logger.Debug().
 			Str("url", url).
-			Int("originalSize", len(content)).
-			Int("sanitizedSize", len(sanitized)).
+			Int64("originalSize", body.Size()).
+			Int("sanitizedSize", len(content)).
 			Msg("Sanitized and cached ZIP episode archive")
-		return sanitized, "application/zip", nil
+		return content, "application/zip", nil
 	case archive.FormatRAR:
-		normalized, err := archive.ConvertRarToZip(content)
+		normalized, err := d.convertRarToZip(body, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to convert RAR archive to ZIP for episode extraction", err)
 		}
-		sanitized, err := archive.SanitizeZip(normalized)
+		defer normalized.Close()
+
+		sanitized, err := d.sanitizeZip(ctx, normalized, keepEncoding, limits.archive)
 		if err != nil {
 			return nil, "", wrapProcessingArchiveError("failed to sanitize converted RAR archive for episode extraction", err)
 		}
-		d.archiveCache.Set(cacheKey, sanitized)
+		defer sanitized.Close()
+
+		content, err := d.cacheArchive(cacheKey, sanitized)
+		if err != nil {
+			return nil, "", err
+		}
 		logger.Info().
 			Str("url", url).
-			Int("rarSize", len(content)).
-			Int("zipSize", len(sanitized)).
+			Int64("rarSize", body.Size()).
+			Int("zipSize", len(content)).
 			Msg("Converted RAR to ZIP, sanitized, and cached for episode extraction")
-		return sanitized, "application/zip", nil
+		return content, "application/zip", nil
 	default:
-		return nil, "", archive.NewUnrecoverableError(
+		return nil, "", &apperrors.ErrInvalidArchive{Err: archive.NewUnrecoverableError(
 			fmt.Sprintf("unsupported archive format for episode extraction (content-type: %s)", contentType),
 			nil,
-		)
+		)}
 	}
 }
 
-// extractEpisodeFromZip extracts a specific episode's subtitle from a season pack ZIP.
-func (d *DefaultSubtitleDownloader) extractEpisodeFromZip(zipContent []byte, episode int) (*models.DownloadResult, error) {
+// sanitizeZip runs archive.SanitizeZipTo on a spooled ZIP, including ZIP bomb detection
+// within limits, and records its duration. With keepEncoding, archive.SanitizeZipKeepEncodingTo
+// is run instead. The caller must close the returned spool.
+func (d *DefaultSubtitleDownloader) sanitizeZip(ctx context.Context, src *spool, keepEncoding bool, limits archive.Limits) (*spool, error) {
+	defer recordExtraction(extractionStepSanitize, time.Now())
+	_, span := tracing.Start(ctx, "downloader.sanitizeZip", attribute.Int64("size", src.Size()))
+	sanitize := archive.SanitizeZipTo
+	if keepEncoding {
+		sanitize = archive.SanitizeZipKeepEncodingTo
+	}
+	sanitized := newSpool()
+	if err := sanitize(sanitized, src, src.Size(), limits); err != nil {
+		_ = sanitized.Close()
+		tracing.End(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("sanitized_size", sanitized.Size()))
+	tracing.End(span, nil)
+	return sanitized, nil
+}
+
+// convertRarToZip runs archive.ConvertRarToZipTo on a spooled RAR within limits and records
+// its duration. The caller must close the returned spool.
+func (d *DefaultSubtitleDownloader) convertRarToZip(src *spool, limits archive.Limits) (*spool, error) {
+	defer recordExtraction(extractionStepRarConversion, time.Now())
+	normalized := newSpool()
+	if err := archive.ConvertRarToZipTo(normalized, src.Reader(), limits); err != nil {
+		_ = normalized.Close()
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// cacheArchive stores a sanitized archive under key and returns its content. An archive
+// that spilled to disk is streamed into the cache, so a provider implementing
+// cache.ReaderSetter uploads it in chunks instead of in one command.
+func (d *DefaultSubtitleDownloader) cacheArchive(key string, sanitized *spool) ([]byte, error) {
+	content, err := sanitized.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sanitized archive: %w", err)
+	}
+	if !sanitized.Spilled() {
+		d.archiveCache.Set(key, content)
+		return content, nil
+	}
+	if err := cache.SetFromReader(d.archiveCache, key, sanitized.Reader()); err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).
+			Str("cacheKey", key).
+			Msg("Failed to cache sanitized archive")
+	}
+	return content, nil
+}
+
+// getCachedArchive looks up key and, on a miss, the legacy raw-URL key. A legacy hit is
+// copied under key so later lookups find it directly.
+func (d *DefaultSubtitleDownloader) getCachedArchive(key, legacyKey string) ([]byte, bool) {
+	if cached, found := d.archiveCache.Get(key); found {
+		return cached, true
+	}
+	// Contains first so a legacy miss is not counted as a second cache miss
+	if legacyKey == key || !d.archiveCache.Contains(legacyKey) {
+		return nil, false
+	}
+	cached, found := d.archiveCache.Get(legacyKey)
+	if found {
+		d.archiveCache.Set(key, cached)
+	}
+	return cached, found
+}
+
+// loadShared runs fetch for a cache miss, sharing one upstream download between all
+// callers that miss the same cache key concurrently. Only the leader runs fetch and
+// populates the cache; the other callers are counted as coalesced.
+func (d *DefaultSubtitleDownloader) loadShared(
+	ctx context.Context,
+	cacheKey, url string,
+	fetch func(ctx context.Context, url string) ([]byte, string, error),
+) ([]byte, string, error) {
+	content, contentType, shared, err := d.inflight.do(ctx, cacheKey, func(ctx context.Context) ([]byte, string, error) {
+		return fetch(ctx, url)
+	})
+	if shared {
+		recordCoalescedDownload(url)
+	}
+	return content, contentType, err
+}
+
+// awaitInflight waits for a download of url that another caller already started.
+func (d *DefaultSubtitleDownloader) awaitInflight(ctx context.Context, call *inflightCall, url string) ([]byte, string, error) {
+	recordCoalescedDownload(url)
+	return call.wait(ctx)
+}
+
+func recordCoalescedDownload(url string) {
+	metrics.SubtitleDownloadsCoalescedTotal.Inc()
 	logger := config.GetLogger()
+	logger.Debug().
+		Str("url", url).
+		Msg("Joined in-flight download of the same archive")
+}
 
-	episodeFile, err := archive.ExtractEpisodeFromZip(zipContent, episode, logger)
+// extractEpisodeFromZip extracts a specific episode's subtitle from a season pack ZIP.
+// The episode number takes precedence; the episode title is only used when no number is given.
+func (d *DefaultSubtitleDownloader) extractEpisodeFromZip(ctx context.Context, zipContent []byte, opts models.DownloadOptions, limits archive.Limits) (*models.DownloadResult, error) {
+	logger := config.GetLogger()
+	_, span := tracing.Start(ctx, "downloader.extractEpisodeFromZip", attribute.Int("size", len(zipContent)))
+
+	var episodeFile *archive.EpisodeFile
+	var err error
+	if opts.Episode != nil {
+		span.SetAttributes(attribute.Int("episode", *opts.Episode))
+		episodeFile, err = archive.ExtractEpisodeFromZip(zipContent, *opts.Episode, limits, logger)
+	} else {
+		span.SetAttributes(attribute.String("episode_title", opts.EpisodeTitle))
+		episodeFile, err = archive.ExtractEpisodeByTitleFromZip(zipContent, opts.EpisodeTitle, limits, logger)
+	}
 	if err != nil {
+		tracing.End(span, err)
 		return nil, err
 	}
+	span.SetAttributes(attribute.String("filename", episodeFile.Filename))
+	tracing.End(span, nil)
 
 	contentType := archive.ContentTypeForFilename(episodeFile.Filename)
 
 	return &models.DownloadResult{
-		Filename:    episodeFile.Filename,
-		Content:     episodeFile.Content,
-		ContentType: contentType,
+		Filename:        d.episodeFilename(episodeFile, opts),
+		SourceFilename:  episodeFile.Filename,
+		Content:         episodeFile.Content,
+		ContentType:     contentType,
+		DetectedCharset: episodeFile.Charset,
 	}, nil
 }
+
+// decodeUploadedSingleSubtitle returns the only subtitle of the archive at downloadURL decoded
+// with the source_encoding override, and the name of the encoding it was read as. The archive
+// returned for whole-file downloads was converted to UTF-8 when it was sanitized, so the
+// override is applied to the archive fetched, and cached, with its uploaded encoding.
+func (d *DefaultSubtitleDownloader) decodeUploadedSingleSubtitle(ctx context.Context, downloadURL string, opts models.DownloadOptions, limits downloadLimits) ([]byte, string, error) {
+	content, _, _, err := d.downloadSubtitleContent(ctx, downloadURL, true, limits)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download subtitle %s: %w", downloadURL, err)
+	}
+	singleFile, err := archive.ExtractSingleSubtitleFromZip(content, limits.archive)
+	if err != nil {
+		return nil, "", wrapArchiveError("failed to inspect subtitle archive", downloadURL, err)
+	}
+	if singleFile == nil {
+		return nil, "", fmt.Errorf("archive %s no longer holds a single subtitle file", downloadURL)
+	}
+	return decodeSubtitleContent(singleFile.Content, opts.SourceEncoding, d.defaultCharset)
+}
+
+// episodeFilename names an extracted episode with the request's filename template, or the
+// configured one. Without a template, or when rendering fails, the archive entry name is kept.
+func (d *DefaultSubtitleDownloader) episodeFilename(episodeFile *archive.EpisodeFile, opts models.DownloadOptions) string {
+	tmpl := opts.FilenameTemplate
+	if tmpl == nil {
+		tmpl = d.filenameTemplate
+	}
+	if tmpl == nil {
+		return episodeFile.Filename
+	}
+
+	episode := 0
+	if opts.Episode != nil {
+		episode = *opts.Episode
+	}
+	data := models.NewFilenameTemplateData(cmp.Or(episodeFile.Path, episodeFile.Filename), episode)
+	filename, err := models.RenderFilename(tmpl, data)
+	if err != nil {
+		logger := config.GetLogger()
+		logger.Warn().Err(err).
+			Str("sourceFilename", episodeFile.Filename).
+			Msg("Failed to render filename template, keeping archive entry name")
+		return episodeFile.Filename
+	}
+	return filename
+}
@@ -4,8 +4,12 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,6 +17,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,8 +27,14 @@ import (
 	"github.com/Belphemur/SuperSubtitles/v2/internal/cache"
 	internalConfig "github.com/Belphemur/SuperSubtitles/v2/internal/config"
 	"github.com/Belphemur/SuperSubtitles/v2/internal/metrics"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 	"google.golang.org/grpc/codes"
 )
 
@@ -52,6 +64,30 @@ func createTestZip(t *testing.T, files map[string]string) []byte {
 	return buf.Bytes()
 }
 
+// readTestZip returns the content of each entry of a ZIP file by entry name
+func readTestZip(t *testing.T, zipContent []byte) map[string]string {
+	t.Helper()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+	if err != nil {
+		t.Fatalf("Failed to open ZIP: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, file := range zipReader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file.Name, err)
+		}
+		entries[file.Name] = string(content)
+	}
+	return entries
+}
+
 func buildDownloadURL(baseURL, subtitleID string) string {
 	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
@@ -107,7 +143,7 @@ func TestDownloadSubtitle_NonZipFile(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		nil,
+		models.DownloadOptions{},
 	)
 
 	if err != nil {
@@ -130,15 +166,27 @@ func TestDownloadSubtitle_NonZipFile(t *testing.T) {
 	if result.ContentType != "application/x-subrip" {
 		t.Errorf("Expected content type 'application/x-subrip', got '%s'", result.ContentType)
 	}
+
+	assertContentSha256(t, result)
+}
+
+// assertContentSha256 checks that the result checksum matches its returned content.
+func assertContentSha256(t *testing.T, result *models.DownloadResult) {
+	t.Helper()
+	sum := sha256.Sum256(result.Content)
+	if expected := hex.EncodeToString(sum[:]); result.Sha256 != expected {
+		t.Errorf("Expected sha256 '%s', got '%s'", expected, result.Sha256)
+	}
 }
 
 func TestDownloadSubtitle_ZipFileNoEpisode(t *testing.T) {
 	t.Parallel()
 	// Create test ZIP
-	zipContent := createTestZip(t, map[string]string{
+	files := map[string]string{
 		"Show.S03E01.srt": "Episode 1 content",
 		"Show.S03E02.srt": "Episode 2 content",
-	})
+	}
+	zipContent := createTestZip(t, files)
 
 	// Create test HTTP server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -155,7 +203,7 @@ func TestDownloadSubtitle_ZipFileNoEpisode(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		nil,
+		models.DownloadOptions{},
 	)
 
 	if err != nil {
@@ -167,8 +215,9 @@ func TestDownloadSubtitle_ZipFileNoEpisode(t *testing.T) {
 		return
 	}
 
-	if !bytes.Equal(result.Content, zipContent) {
-		t.Error("Expected ZIP content to be returned as-is")
+	// The sanitized archive records the encoding of each entry, so only the entries are unchanged
+	if !maps.Equal(readTestZip(t, result.Content), files) {
+		t.Error("Expected ZIP entries to be returned as-is")
 	}
 
 	if result.ContentType != "application/zip" {
@@ -182,6 +231,45 @@ func TestDownloadSubtitle_ZipFileNoEpisode(t *testing.T) {
 	}
 }
 
+func TestDownloadSubtitle_SingleFileSeasonPackNoEpisode(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Season 3/Show.S03E01.srt": "Episode 1 content",
+		"readme.txt":               "Downloaded from feliratok.eu",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	result, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.Filename != "Show.S03E01.srt" {
+		t.Errorf("Expected the lone subtitle's filename, got '%s'", result.Filename)
+	}
+	if result.ContentType != "application/x-subrip" {
+		t.Errorf("Expected content type 'application/x-subrip', got '%s'", result.ContentType)
+	}
+	if string(result.Content) != "Episode 1 content" {
+		t.Errorf("Expected the lone subtitle's content, got '%s'", string(result.Content))
+	}
+	if result.Sha256 != contentSha256(result.Content) {
+		t.Errorf("Expected checksum of the returned content, got '%s'", result.Sha256)
+	}
+}
+
 func TestDownloadSubtitle_RarFileNoEpisode(t *testing.T) {
 	t.Parallel()
 
@@ -201,7 +289,7 @@ func TestDownloadSubtitle_RarFileNoEpisode(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "987654321"),
-		nil,
+		models.DownloadOptions{},
 	)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -222,7 +310,7 @@ func TestDownloadSubtitle_RarFileNoEpisode(t *testing.T) {
 	secondResult, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "987654321"),
-		nil,
+		models.DownloadOptions{},
 	)
 	if err != nil {
 		t.Fatalf("Expected no error on cached download, got: %v", err)
@@ -256,7 +344,7 @@ func TestDownloadSubtitle_ExtractEpisodeFromRarFixture(t *testing.T) {
 	resultEpisodeFive, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "rar-pack"),
-		new(5),
+		models.DownloadOptions{Episode: new(5)},
 	)
 	if err != nil {
 		t.Fatalf("Episode 5 extraction failed: %v", err)
@@ -281,7 +369,7 @@ func TestDownloadSubtitle_ExtractEpisodeFromRarFixture(t *testing.T) {
 	resultEpisodeSix, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "rar-pack"),
-		new(6),
+		models.DownloadOptions{Episode: new(6)},
 	)
 	if err != nil {
 		t.Fatalf("Episode 6 extraction failed: %v", err)
@@ -319,7 +407,7 @@ func TestDownloadSubtitle_RarDownloadAndEpisodeExtractionUseSeparateCaches(t *te
 	wholeArchive, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "rar-pack"),
-		nil,
+		models.DownloadOptions{},
 	)
 	if err != nil {
 		t.Fatalf("Whole archive download failed: %v", err)
@@ -331,7 +419,7 @@ func TestDownloadSubtitle_RarDownloadAndEpisodeExtractionUseSeparateCaches(t *te
 	episodeFile, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "rar-pack"),
-		new(5),
+		models.DownloadOptions{Episode: new(5)},
 	)
 	if err != nil {
 		t.Fatalf("Episode extraction failed: %v", err)
@@ -423,6 +511,41 @@ func TestDownloadSubtitle_ExtractEpisodeFromZip(t *testing.T) {
 			requestEpisode: new(1),
 			shouldFail:     true,
 		},
+		{
+			name: "Extract three-digit episode E100",
+			zipFiles: map[string]string{
+				"One.Piece.E099.srt": "Episode 99 content",
+				"One.Piece.E100.srt": "Episode 100 content",
+				"One.Piece.E101.srt": "Episode 101 content",
+			},
+			requestEpisode:  new(100),
+			expectedFile:    "One.Piece.E100.srt",
+			expectedContent: "Episode 100 content",
+			shouldFail:      false,
+		},
+		{
+			name: "Extract unpadded episode E5",
+			zipFiles: map[string]string{
+				"show.E4.srt":  "Episode 4 content",
+				"show.E5.srt":  "Episode 5 content",
+				"show.E50.srt": "Episode 50 content",
+			},
+			requestEpisode:  new(5),
+			expectedFile:    "show.E5.srt",
+			expectedContent: "Episode 5 content",
+			shouldFail:      false,
+		},
+		{
+			name: "Extract special episode 0",
+			zipFiles: map[string]string{
+				"show.S00E00.Pilot.srt": "Special content",
+				"show.S01E01.srt":       "Episode 1 content",
+			},
+			requestEpisode:  new(0),
+			expectedFile:    "show.S00E00.Pilot.srt",
+			expectedContent: "Special content",
+			shouldFail:      false,
+		},
 		{
 			name: "Episode not found in ZIP",
 			zipFiles: map[string]string{
@@ -455,7 +578,7 @@ func TestDownloadSubtitle_ExtractEpisodeFromZip(t *testing.T) {
 			result, err := downloader.DownloadSubtitle(
 				context.Background(),
 				buildDownloadURL(server.URL, "123456789"),
-				tt.requestEpisode,
+				models.DownloadOptions{Episode: tt.requestEpisode},
 			)
 
 			if tt.shouldFail {
@@ -491,10 +614,59 @@ func TestDownloadSubtitle_ExtractEpisodeFromZip(t *testing.T) {
 			if result.ContentType != "application/x-subrip" {
 				t.Errorf("Expected content type 'application/x-subrip', got '%s'", result.ContentType)
 			}
+
+			assertContentSha256(t, result)
 		})
 	}
 }
 
+func TestDownloadSubtitle_ExtractEpisodeByTitle(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S03E01.Pilot.srt":     "Episode 1 content",
+		"Show.S03E02.I.Said.No.srt": "Episode 2 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+
+	result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{EpisodeTitle: "i said no"})
+	if err != nil {
+		t.Fatalf("Download by title failed: %v", err)
+	}
+	if result.Filename != "Show.S03E02.I.Said.No.srt" {
+		t.Errorf("Expected filename 'Show.S03E02.I.Said.No.srt', got '%s'", result.Filename)
+	}
+	if result.ContentType != "application/x-subrip" {
+		t.Errorf("Expected content type 'application/x-subrip', got '%s'", result.ContentType)
+	}
+
+	// The episode number takes precedence over the title
+	result, err = downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(1), EpisodeTitle: "i said no"})
+	if err != nil {
+		t.Fatalf("Download by number failed: %v", err)
+	}
+	if result.Filename != "Show.S03E01.Pilot.srt" {
+		t.Errorf("Expected episode number to win, got '%s'", result.Filename)
+	}
+
+	_, err = downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{EpisodeTitle: "finale"})
+	var notFound *apperrors.ErrSubtitleNotFoundInArchive
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected ErrSubtitleNotFoundInArchive, got: %v", err)
+	}
+	if notFound.EpisodeTitle != "finale" || len(notFound.AvailableTitles) != 2 {
+		t.Errorf("Expected title and available titles in error, got %+v", notFound)
+	}
+}
+
 func TestDownloadSubtitle_Caching(t *testing.T) {
 	t.Parallel()
 	requestCount := 0
@@ -519,7 +691,7 @@ func TestDownloadSubtitle_Caching(t *testing.T) {
 	result1, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 	if err != nil {
 		t.Fatalf("First request failed: %v", err)
@@ -532,7 +704,7 @@ func TestDownloadSubtitle_Caching(t *testing.T) {
 	result2, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(2),
+		models.DownloadOptions{Episode: new(2)},
 	)
 	if err != nil {
 		t.Fatalf("Second request failed: %v", err)
@@ -550,185 +722,747 @@ func TestDownloadSubtitle_Caching(t *testing.T) {
 	}
 }
 
-func TestDownloadSubtitle_HTTPError(t *testing.T) {
+func TestDownloadSubtitle_InvalidateCacheRefetches(t *testing.T) {
 	t.Parallel()
-	// Create test HTTP server that returns error
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+	})
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
 	}))
 	defer server.Close()
 
-	// Create downloader
 	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
 
-	// Test download
-	_, err := downloader.DownloadSubtitle(
-		context.Background(),
-		buildDownloadURL(server.URL, "123456789"),
-		nil,
-	)
+	// Populate both the whole-archive and the episode caches
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{}); err != nil {
+		t.Fatalf("Whole-archive download failed: %v", err)
+	}
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(1)}); err != nil {
+		t.Fatalf("Episode download failed: %v", err)
+	}
+	if got := requestCount.Load(); got != 2 {
+		t.Fatalf("Expected 2 upstream requests before invalidation, got %d", got)
+	}
 
-	if err == nil {
-		t.Fatal("Expected error for 404 response, got nil")
+	if !downloader.InvalidateCache(downloadURL) {
+		t.Error("Expected InvalidateCache to report a cached entry")
+	}
+	if downloader.InvalidateCache(downloadURL) {
+		t.Error("Expected second InvalidateCache to find nothing")
 	}
 
-	if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
-		t.Errorf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{}); err != nil {
+		t.Fatalf("Whole-archive download after invalidation failed: %v", err)
+	}
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(1)}); err != nil {
+		t.Fatalf("Episode download after invalidation failed: %v", err)
+	}
+	if got := requestCount.Load(); got != 4 {
+		t.Errorf("Expected 4 upstream requests after invalidation, got %d", got)
 	}
 }
 
-func TestDownloadSubtitle_HTMLContentTypeReturnsUnrecoverableArchiveError(t *testing.T) {
+func TestPreloadArchive_CachesArchiveForEpisodeDownloads(t *testing.T) {
 	t.Parallel()
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+		"show.s03e02.srt": "Episode 2 content",
+	})
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("<html><body>blocked</body></html>"))
+		_, _ = w.Write(zipContent)
 	}))
 	defer server.Close()
 
 	downloader := NewSubtitleDownloader(server.Client())
-	downloadURL := buildDownloadURL(server.URL, "html-content")
-
-	_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, nil)
-	if err == nil {
-		t.Fatal("Expected error for HTML content type, got nil")
+	d, ok := downloader.(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatalf("NewSubtitleDownloader returned %T, want *DefaultSubtitleDownloader", downloader)
 	}
+	downloadURL := buildDownloadURL(server.URL, "1702")
 
-	if !errors.Is(err, &archive.ArchiveError{}) {
-		t.Fatalf("Expected errors.Is to match ArchiveError, got: %v", err)
+	cached, err := downloader.PreloadArchive(context.Background(), downloadURL)
+	if err != nil {
+		t.Fatalf("PreloadArchive failed: %v", err)
 	}
-
-	var archiveErr *archive.ArchiveError
-	if !errors.As(err, &archiveErr) {
-		t.Fatalf("Expected wrapped ArchiveError, got: %v", err)
+	if cached {
+		t.Error("Expected the first preload to report a cache miss")
 	}
-
-	if !archiveErr.Unrecoverable {
-		t.Fatalf("Expected ArchiveError to be unrecoverable, got recoverable: %v", archiveErr)
+	if !d.archiveCache.Contains(episodeArchiveCacheKey(downloadURL)) {
+		t.Fatal("Expected the archive to be cached under its episode cache key")
 	}
 
-	if archiveErr.URL != downloadURL {
-		t.Fatalf("Expected ArchiveError URL %q, got %q", downloadURL, archiveErr.URL)
+	cached, err = downloader.PreloadArchive(context.Background(), downloadURL)
+	if err != nil {
+		t.Fatalf("Second PreloadArchive failed: %v", err)
 	}
-
-	if archiveErr.GRPCCode() != codes.DataLoss {
-		t.Fatalf("Expected ArchiveError to map to codes.DataLoss, got: %v", archiveErr.GRPCCode())
+	if !cached {
+		t.Error("Expected the second preload to report the archive as cached")
 	}
 
-	if !strings.Contains(err.Error(), "received HTML content") {
-		t.Fatalf("Expected HTML content error message, got: %v", err)
+	result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(2)})
+	if err != nil {
+		t.Fatalf("Episode download failed: %v", err)
 	}
-	if !strings.Contains(err.Error(), "text/html; charset=utf-8") {
-		t.Fatalf("Expected content type in error message, got: %v", err)
+	if string(result.Content) != "Episode 2 content" {
+		t.Errorf("Expected episode 2 content, got %q", result.Content)
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected only the preload to reach upstream, got %d requests", got)
 	}
 }
 
-func TestDownloadSubtitle_InvalidZip(t *testing.T) {
+func TestDownloadEpisodeRangeAsZip_PacksEpisodesInRange(t *testing.T) {
 	t.Parallel()
-	// Create test HTTP server with invalid ZIP content
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s02e01.srt":     "Episode 1 content",
+		"show.s02e02.srt":     "Episode 2 content",
+		"show.s02e03e04.srt":  "Episodes 3 and 4 content",
+		"show.s02e06.srt":     "Episode 6 content",
+		"show.s02e07.srt":     "Episode 7 content",
+		"Extras/readme.txt":   "Not an episode",
+		"show.s02e02.sdh.srt": "Episode 2 hearing impaired",
+	})
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
 		w.Header().Set("Content-Type", "application/zip")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("This is not a valid ZIP file"))
+		_, _ = w.Write(zipContent)
 	}))
 	defer server.Close()
 
-	// Create downloader
 	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "1703")
 
-	// Test download with episode extraction from invalid ZIP
-	_, err := downloader.DownloadSubtitle(
-		context.Background(),
-		buildDownloadURL(server.URL, "123456789"),
-		new(1),
-	)
+	result, err := downloader.DownloadEpisodeRangeAsZip(context.Background(), downloadURL, 1, 6, 0)
+	if err != nil {
+		t.Fatalf("DownloadEpisodeRangeAsZip failed: %v", err)
+	}
+	if result.ContentType != "application/zip" {
+		t.Errorf("Expected content type application/zip, got %q", result.ContentType)
+	}
+	if result.Filename != "1703_E01-E06.zip" {
+		t.Errorf("Expected filename 1703_E01-E06.zip, got %q", result.Filename)
+	}
+	if result.Sha256 != contentSha256(result.Content) {
+		t.Error("Expected Sha256 to match the packed content")
+	}
 
-	if err == nil {
-		t.Fatal("Expected error for invalid ZIP, got nil")
+	// All entries are ASCII, so they were read as UTF-8
+	if result.DetectedCharset != "utf-8" {
+		t.Errorf("Expected the packed entries reported as utf-8, got %q", result.DetectedCharset)
+	}
+
+	entries := readTestZip(t, result.Content)
+
+	// Episode 5 is missing and skipped; the double episode file is included once
+	want := map[string]string{
+		"show.s02e01.srt":    "Episode 1 content",
+		"show.s02e02.srt":    "Episode 2 content",
+		"show.s02e03e04.srt": "Episodes 3 and 4 content",
+		"show.s02e06.srt":    "Episode 6 content",
+	}
+	if len(entries) != len(want) {
+		t.Errorf("Expected %d entries, got %d: %v", len(want), len(entries), entries)
+	}
+	for name, content := range want {
+		if entries[name] != content {
+			t.Errorf("Expected entry %s with %q, got %q", name, content, entries[name])
+		}
 	}
 
-	if !strings.Contains(err.Error(), "ZIP") {
-		t.Errorf("Expected error message to mention ZIP, got: %v", err)
+	// A second range reuses the cached season pack
+	if _, err := downloader.DownloadEpisodeRangeAsZip(context.Background(), downloadURL, 6, 7, 0); err != nil {
+		t.Fatalf("Second DownloadEpisodeRangeAsZip failed: %v", err)
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected one upstream download, got %d", got)
 	}
 }
 
-func TestDownloadSubtitle_ContextCancellation(t *testing.T) {
+func TestDownloadEpisodeRangeAsZip_NoEpisodeFound(t *testing.T) {
 	t.Parallel()
-	// Create test HTTP server with delay
+	zipContent := createTestZip(t, map[string]string{
+		"show.s02e01.srt": "Episode 1 content",
+		"show.s02e02.srt": "Episode 2 content",
+	})
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(100 * time.Millisecond)
-		w.Header().Set("Content-Type", "application/x-subrip")
+		w.Header().Set("Content-Type", "application/zip")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("content"))
+		_, _ = w.Write(zipContent)
 	}))
 	defer server.Close()
 
-	// Create downloader
 	downloader := NewSubtitleDownloader(server.Client())
+	_, err := downloader.DownloadEpisodeRangeAsZip(context.Background(), buildDownloadURL(server.URL, "1704"), 5, 8, 0)
 
-	// Create context that's already cancelled
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
-
-	// Test download with cancelled context
-	_, err := downloader.DownloadSubtitle(
-		ctx,
-		buildDownloadURL(server.URL, "123456789"),
-		nil,
-	)
-
-	if err == nil {
-		t.Fatal("Expected error for cancelled context, got nil")
+	var notFound *apperrors.ErrSubtitleNotFoundInArchive
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected ErrSubtitleNotFoundInArchive, got %v", err)
 	}
-}
-
-func BenchmarkDownloadSubtitle_ExtractFromZip(b *testing.B) {
-	// Create large season pack
-	zipFiles := make(map[string]string)
-	for i := 1; i <= 20; i++ {
-		filename := fmt.Sprintf("show.s03e%02d.srt", i)
-		zipFiles[filename] = strings.Repeat("Subtitle content line\n", 100)
+	if notFound.Episode != 5 || notFound.EpisodeEnd != 8 || notFound.FileCount != 2 {
+		t.Errorf("Expected episodes 5-8 in 2 files, got %+v", notFound)
 	}
+}
 
-	// Create ZIP using a simple inline implementation for benchmarks
-	buf := new(bytes.Buffer)
-	w := zip.NewWriter(buf)
-	for filename, content := range zipFiles {
-		f, err := w.Create(filename)
-		if err != nil {
-			b.Fatalf("Failed to create file %s in ZIP: %v", filename, err)
-		}
-		_, err = f.Write([]byte(content))
-		if err != nil {
-			b.Fatalf("Failed to write content to %s in ZIP: %v", filename, err)
-		}
-	}
-	if err := w.Close(); err != nil {
-		b.Fatalf("Failed to close ZIP writer: %v", err)
+func TestDownloadEpisodeRangeAsZip_InvalidRange(t *testing.T) {
+	t.Parallel()
+	downloader := NewSubtitleDownloader(http.DefaultClient)
+	if _, err := downloader.DownloadEpisodeRangeAsZip(context.Background(), "http://example.invalid/index.php?action=letolt&felirat=1", 5, 4, 0); err == nil {
+		t.Fatal("Expected an error for an end before the start")
 	}
-	zipContent := buf.Bytes()
+}
 
+func TestPreloadArchive_ReturnsDownloadErrors(t *testing.T) {
+	t.Parallel()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/zip")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(zipContent)
+		w.WriteHeader(http.StatusNotFound)
 	}))
 	defer server.Close()
 
 	downloader := NewSubtitleDownloader(server.Client())
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		// Alternate between different episodes to test cache
-		episode := (i % 20) + 1
-		_, err := downloader.DownloadSubtitle(
-			context.Background(),
-			buildDownloadURL(server.URL, "123456789"),
-			new(episode),
-		)
-		if err != nil {
-			b.Fatalf("Download failed: %v", err)
-		}
+	_, err := downloader.PreloadArchive(context.Background(), buildDownloadURL(server.URL, "1703"))
+	if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+		t.Errorf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_NotFoundIsRemembered(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "1704")
+
+	for range 2 {
+		_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{})
+		if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+			t.Fatalf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+		}
+	}
+	if n := requestCount.Load(); n != 1 {
+		t.Errorf("Expected the second download to be answered from the not-found cache, got %d upstream requests", n)
+	}
+
+	_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{ForceRefresh: true})
+	if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+		t.Fatalf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	}
+	if n := requestCount.Load(); n != 2 {
+		t.Errorf("Expected force refresh to ask upstream, got %d upstream requests", n)
+	}
+
+	if !downloader.InvalidateCache(downloadURL) {
+		t.Error("Expected InvalidateCache to report the remembered 404")
+	}
+	_, _ = downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{})
+	if n := requestCount.Load(); n != 3 {
+		t.Errorf("Expected an invalidated 404 to ask upstream, got %d upstream requests", n)
+	}
+}
+
+func TestDownloadSubtitle_NotFoundExpires(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nUploaded later\n"))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	downloader.notFound = newNotFoundCache(50 * time.Millisecond)
+	downloadURL := buildDownloadURL(server.URL, "1705")
+
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{}); !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+		t.Fatalf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{})
+	if err != nil {
+		t.Fatalf("Expected the expired 404 to be probed again, got: %v", err)
+	}
+	if !strings.Contains(string(result.Content), "Uploaded later") {
+		t.Errorf("Unexpected content: %q", result.Content)
+	}
+	if n := requestCount.Load(); n != 2 {
+		t.Errorf("Expected 2 upstream requests, got %d", n)
+	}
+}
+
+func TestDownloadSubtitle_ClearCacheRefetches(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	firstURL := buildDownloadURL(server.URL, "1")
+	secondURL := buildDownloadURL(server.URL, "2")
+
+	for _, u := range []string{firstURL, secondURL} {
+		if _, err := downloader.DownloadSubtitle(context.Background(), u, models.DownloadOptions{Episode: new(1)}); err != nil {
+			t.Fatalf("Download of %s failed: %v", u, err)
+		}
+	}
+
+	if cleared := downloader.ClearCache(); cleared != 2 {
+		t.Errorf("Expected 2 entries cleared, got %d", cleared)
+	}
+
+	for _, u := range []string{firstURL, secondURL} {
+		if _, err := downloader.DownloadSubtitle(context.Background(), u, models.DownloadOptions{Episode: new(1)}); err != nil {
+			t.Fatalf("Download of %s after clear failed: %v", u, err)
+		}
+	}
+	if got := requestCount.Load(); got != 4 {
+		t.Errorf("Expected 4 upstream requests after clear, got %d", got)
+	}
+}
+
+func TestDownloadSubtitle_URLSpellingsShareCacheEntry(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+		"show.s03e02.srt": "Episode 2 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	d, ok := downloader.(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatalf("NewSubtitleDownloader returned %T, want *DefaultSubtitleDownloader", downloader)
+	}
+
+	// Same felirat ID, different parameter order and fnev
+	spellings := []string{
+		server.URL + "/index.php?action=letolt&fnev=Show.S03.zip&felirat=1700",
+		server.URL + "/index.php?felirat=1700&fnev=show-s03-pack.zip&action=letolt",
+	}
+	for i, u := range spellings {
+		if _, err := downloader.DownloadSubtitle(context.Background(), u, models.DownloadOptions{Episode: new(i + 1)}); err != nil {
+			t.Fatalf("Download of %s failed: %v", u, err)
+		}
+	}
+
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected 1 upstream request for both spellings, got %d", got)
+	}
+	if got := d.archiveCache.Len(); got != 1 {
+		t.Errorf("Expected 1 cache entry for both spellings, got %d", got)
+	}
+}
+
+func TestDownloadSubtitle_LegacyCacheKeyIsUsedOnMiss(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	d, ok := downloader.(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatalf("NewSubtitleDownloader returned %T, want *DefaultSubtitleDownloader", downloader)
+	}
+
+	downloadURL := buildDownloadURL(server.URL, "1701")
+	// An entry written by a release that keyed archives by the raw URL
+	d.archiveCache.Set(legacyEpisodeArchiveCacheKey(downloadURL), createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+	}))
+
+	result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(1)})
+	if err != nil {
+		t.Fatalf("Download from legacy cache entry failed: %v", err)
+	}
+	if string(result.Content) != "Episode 1 content" {
+		t.Errorf("Expected legacy cached content, got %q", result.Content)
+	}
+	if got := requestCount.Load(); got != 0 {
+		t.Errorf("Expected no upstream request, got %d", got)
+	}
+	if !d.archiveCache.Contains(episodeArchiveCacheKey(downloadURL)) {
+		t.Error("Expected legacy entry to be copied under the canonical key")
+	}
+
+	if !downloader.InvalidateCache(downloadURL) {
+		t.Error("Expected InvalidateCache to report a cached entry")
+	}
+	if got := d.archiveCache.Len(); got != 0 {
+		t.Errorf("Expected InvalidateCache to remove canonical and legacy entries, got %d left", got)
+	}
+}
+
+// TestDownloadSubtitle_CoalescesConcurrentDownloads is not parallel because it asserts
+// an exact delta on the global coalesced-downloads counter.
+func TestDownloadSubtitle_CoalescesConcurrentDownloads(t *testing.T) {
+	const callers = 8
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+		"show.s03e02.srt": "Episode 2 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		time.Sleep(300 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+	before := getCounterValue(metrics.SubtitleDownloadsCoalescedTotal)
+
+	var wg sync.WaitGroup
+	results := make([]*models.DownloadResult, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Go(func() {
+			results[i], errs[i] = downloader.DownloadSubtitle(
+				context.Background(),
+				downloadURL,
+				models.DownloadOptions{Episode: new(i%2 + 1)},
+			)
+		})
+	}
+	wg.Wait()
+
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("Caller %d failed: %v", i, errs[i])
+		}
+		want := fmt.Sprintf("Episode %d content", i%2+1)
+		if string(results[i].Content) != want {
+			t.Errorf("Caller %d: expected %q, got %q", i, want, string(results[i].Content))
+		}
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected exactly 1 upstream request for %d concurrent callers, got %d", callers, got)
+	}
+	if delta := getCounterValue(metrics.SubtitleDownloadsCoalescedTotal) - before; delta != callers-1 {
+		t.Errorf("Expected %d coalesced downloads, got %.0f", callers-1, delta)
+	}
+}
+
+func TestDownloadSubtitle_CancelledCallerDoesNotCancelSharedDownload(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+
+	// The leader gives up while the upstream request is still running.
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := downloader.DownloadSubtitle(leaderCtx, downloadURL, models.DownloadOptions{Episode: new(1)})
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan *models.DownloadResult, 1)
+	followerErr := make(chan error, 1)
+	go func() {
+		result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(1)})
+		followerResult <- result
+		followerErr <- err
+	}()
+
+	// A cancelled follower stops waiting immediately.
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := downloader.DownloadSubtitle(cancelledCtx, downloadURL, models.DownloadOptions{Episode: new(1)}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for cancelled follower, got: %v", err)
+	}
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for cancelled leader, got: %v", err)
+	}
+
+	close(release)
+	if err := <-followerErr; err != nil {
+		t.Fatalf("Follower failed after other callers cancelled: %v", err)
+	}
+	if result := <-followerResult; string(result.Content) != "Episode 1 content" {
+		t.Errorf("Expected 'Episode 1 content', got %q", string(result.Content))
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("Expected exactly 1 upstream request, got %d", got)
+	}
+}
+
+func TestDownloadSubtitle_HTTPError(t *testing.T) {
+	t.Parallel()
+	// Create test HTTP server that returns error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// Create downloader
+	downloader := NewSubtitleDownloader(server.Client())
+
+	// Test download
+	_, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{},
+	)
+
+	if err == nil {
+		t.Fatal("Expected error for 404 response, got nil")
+	}
+
+	if !errors.Is(err, &apperrors.ErrSubtitleResourceNotFound{}) {
+		t.Errorf("Expected errors.Is to match ErrSubtitleResourceNotFound, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_UpstreamServerErrorReturnsUpstreamStatus(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	_, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{},
+	)
+
+	var statusErr *apperrors.ErrUpstreamStatus
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected ErrUpstreamStatus, got: %v", err)
+	}
+	if statusErr.Code != http.StatusBadGateway {
+		t.Errorf("Expected upstream status %d, got %d", http.StatusBadGateway, statusErr.Code)
+	}
+	if statusErr.GRPCCode() != codes.Unavailable {
+		t.Errorf("Expected ErrUpstreamStatus to map to codes.Unavailable, got: %v", statusErr.GRPCCode())
+	}
+}
+
+func TestDownloadSubtitle_HTMLContentTypeReturnsUnrecoverableArchiveError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("<html><body>blocked</body></html>"))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "html-content")
+
+	_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{})
+	if err == nil {
+		t.Fatal("Expected error for HTML content type, got nil")
+	}
+
+	if !errors.Is(err, &archive.ArchiveError{}) {
+		t.Fatalf("Expected errors.Is to match ArchiveError, got: %v", err)
+	}
+
+	var archiveErr *archive.ArchiveError
+	if !errors.As(err, &archiveErr) {
+		t.Fatalf("Expected wrapped ArchiveError, got: %v", err)
+	}
+
+	if !archiveErr.Unrecoverable {
+		t.Fatalf("Expected ArchiveError to be unrecoverable, got recoverable: %v", archiveErr)
+	}
+
+	if archiveErr.URL != downloadURL {
+		t.Fatalf("Expected ArchiveError URL %q, got %q", downloadURL, archiveErr.URL)
+	}
+
+	if archiveErr.GRPCCode() != codes.DataLoss {
+		t.Fatalf("Expected ArchiveError to map to codes.DataLoss, got: %v", archiveErr.GRPCCode())
+	}
+
+	if !strings.Contains(err.Error(), "received HTML content") {
+		t.Fatalf("Expected HTML content error message, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "text/html; charset=utf-8") {
+		t.Fatalf("Expected content type in error message, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_InvalidZip(t *testing.T) {
+	t.Parallel()
+	// Create test HTTP server with invalid ZIP content
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("This is not a valid ZIP file"))
+	}))
+	defer server.Close()
+
+	// Create downloader
+	downloader := NewSubtitleDownloader(server.Client())
+
+	// Test download with episode extraction from invalid ZIP
+	_, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{Episode: new(1)},
+	)
+
+	if err == nil {
+		t.Fatal("Expected error for invalid ZIP, got nil")
+	}
+
+	if !errors.Is(err, &apperrors.ErrInvalidArchive{}) {
+		t.Errorf("Expected errors.Is to match ErrInvalidArchive, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_ContextCancellation(t *testing.T) {
+	t.Parallel()
+	// Create test HTTP server with delay
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	// Create downloader
+	downloader := NewSubtitleDownloader(server.Client())
+
+	// Create context that's already cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Test download with cancelled context
+	_, err := downloader.DownloadSubtitle(
+		ctx,
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{},
+	)
+
+	if err == nil {
+		t.Fatal("Expected error for cancelled context, got nil")
+	}
+}
+
+func BenchmarkDownloadSubtitle_ExtractFromZip(b *testing.B) {
+	// Create large season pack
+	zipFiles := make(map[string]string)
+	for i := 1; i <= 20; i++ {
+		filename := fmt.Sprintf("show.s03e%02d.srt", i)
+		zipFiles[filename] = strings.Repeat("Subtitle content line\n", 100)
+	}
+
+	// Create ZIP using a simple inline implementation for benchmarks
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for filename, content := range zipFiles {
+		f, err := w.Create(filename)
+		if err != nil {
+			b.Fatalf("Failed to create file %s in ZIP: %v", filename, err)
+		}
+		_, err = f.Write([]byte(content))
+		if err != nil {
+			b.Fatalf("Failed to write content to %s in ZIP: %v", filename, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		b.Fatalf("Failed to close ZIP writer: %v", err)
+	}
+	zipContent := buf.Bytes()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Alternate between different episodes to test cache
+		episode := (i % 20) + 1
+		_, err := downloader.DownloadSubtitle(
+			context.Background(),
+			buildDownloadURL(server.URL, "123456789"),
+			models.DownloadOptions{Episode: new(episode)},
+		)
+		if err != nil {
+			b.Fatalf("Download failed: %v", err)
+		}
 	}
 }
 
@@ -771,8 +1505,9 @@ func TestDownloadSubtitle_DifferentFileTypes(t *testing.T) {
 			t.Parallel()
 			var payload []byte
 			if tt.contentType == "application/zip" {
-				// ZIP content type requires a real ZIP archive for sanitization
-				payload = createTestZip(t, map[string]string{"subtitle.srt": "Test content"})
+				// ZIP content type requires a real ZIP archive for sanitization; a single
+				// subtitle would be unwrapped, so the archive holds two
+				payload = createTestZip(t, map[string]string{"subtitle.srt": "Test content", "subtitle.ass": "Test content"})
 			} else {
 				payload = []byte("Test content")
 			}
@@ -788,7 +1523,7 @@ func TestDownloadSubtitle_DifferentFileTypes(t *testing.T) {
 			result, err := downloader.DownloadSubtitle(
 				context.Background(),
 				buildDownloadURL(server.URL, "123456789"),
-				nil,
+				models.DownloadOptions{},
 			)
 
 			if err != nil {
@@ -882,7 +1617,7 @@ func TestExtractEpisodeFromZip_DifferentFileTypes(t *testing.T) {
 			result, err := downloader.DownloadSubtitle(
 				context.Background(),
 				buildDownloadURL(server.URL, "123456789"),
-				new(1),
+				models.DownloadOptions{Episode: new(1)},
 			)
 
 			if tt.expectError {
@@ -927,7 +1662,7 @@ func TestExtractEpisodeFromZip_ZipBombProtection(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 
 	if err == nil {
@@ -946,8 +1681,216 @@ func TestExtractEpisodeFromZip_ZipBombProtection(t *testing.T) {
 		t.Fatalf("Expected ArchiveError to map to codes.DataLoss, got: %v", archiveErr.GRPCCode())
 	}
 
-	if !strings.Contains(err.Error(), "ZIP bomb detected") {
-		t.Errorf("Expected 'ZIP bomb detected' error, got: %v", err)
+	if !errors.Is(err, &apperrors.ErrZipBombDetected{}) {
+		t.Errorf("Expected errors.Is to match ErrZipBombDetected, got: %v", err)
+	}
+	var bindable apperrors.GRPCBindableError
+	if !errors.As(err, &bindable) || bindable.GRPCCode() != codes.ResourceExhausted {
+		t.Errorf("Expected the outermost bindable error to map to codes.ResourceExhausted, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_ConfiguredLimitsAllowLargerEntries(t *testing.T) {
+	t.Parallel()
+	// 25 MB exceeds the 20 MB default per-file limit
+	zipContent := createTestZip(t, map[string]string{
+		"large.s03e01.srt": strings.Repeat("Q", 25*1024*1024),
+		"large.s03e02.srt": "Episode 2 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	download := func(downloader SubtitleDownloader) (*models.DownloadResult, error) {
+		return downloader.DownloadSubtitle(
+			context.Background(),
+			buildDownloadURL(server.URL, "123456789"),
+			models.DownloadOptions{Episode: new(1)},
+		)
+	}
+
+	if _, err := download(NewSubtitleDownloader(server.Client())); err == nil {
+		t.Fatal("Expected the default limits to reject a 25 MB entry")
+	}
+
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloader.limits.MaxFileSize = 30 * 1024 * 1024
+
+	result, err := download(downloader)
+	if err != nil {
+		t.Fatalf("Expected a 30 MB per-file limit to accept a 25 MB entry, got: %v", err)
+	}
+	if len(result.Content) != 25*1024*1024 {
+		t.Errorf("Expected 25 MB of content, got %d bytes", len(result.Content))
+	}
+}
+
+func TestDownloadSubtitle_ConfiguredDownloadSizeLimit(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(make([]byte, 2*1024*1024))
+	}))
+	defer server.Close()
+
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloader.maxDownloadSize = 1024 * 1024
+
+	_, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "123456789"), models.DownloadOptions{})
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Expected the configured 1 MB download limit to reject a 2 MB response, got: %v", err)
+	}
+	if tooLarge.Limit != 1024*1024 {
+		t.Errorf("Expected limit %d, got %d", 1024*1024, tooLarge.Limit)
+	}
+}
+
+func TestDownloadSubtitle_RequestMaxBytes(t *testing.T) {
+	t.Parallel()
+	content := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+
+	// A per-request limit below the file size rejects it
+	_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{MaxBytes: 8})
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Expected ErrDownloadTooLarge for an 8-byte limit, got: %v", err)
+	}
+	if tooLarge.Limit != 8 {
+		t.Errorf("Expected limit 8, got %d", tooLarge.Limit)
+	}
+
+	// A per-request limit above the configured one does not raise it
+	downloader.maxDownloadSize = int64(len(content))
+	if _, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{MaxBytes: 1 << 20}); err != nil {
+		t.Fatalf("Expected a file within both limits to download, got: %v", err)
+	}
+	downloader.maxDownloadSize = int64(len(content)) - 1
+	_, err = downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{MaxBytes: 1 << 20})
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != int64(len(content))-1 {
+		t.Errorf("Expected the configured limit to apply, got: %v", err)
+	}
+}
+
+func TestDownloadSubtitle_RequestMaxBytesLimitsDownload(t *testing.T) {
+	t.Parallel()
+	body := bytes.Repeat([]byte("x"), 1<<20)
+	var written atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		n, _ := w.Write(body)
+		written.Add(int64(n))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	_, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "123456789"), models.DownloadOptions{MaxBytes: 1024})
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Expected ErrDownloadTooLarge, got: %v", err)
+	}
+	// Reading stops one byte past the caller's limit instead of the configured one
+	if tooLarge.Size != 1025 || tooLarge.Limit != 1024 {
+		t.Errorf("Expected the download cut off at 1025 bytes against a 1024-byte limit, got %d against %d", tooLarge.Size, tooLarge.Limit)
+	}
+}
+
+func TestDownloadSubtitle_RequestMaxBytesLimitsArchive(t *testing.T) {
+	t.Parallel()
+	// Repetitive entries compress far below max_bytes, so only the archive limits catch them
+	large := strings.Repeat("1\n00:00:01,000 --> 00:00:02,000\nHello\n\n", 2000)
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": large,
+		"Show.S01E02.srt": "Episode 2 content",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	maxBytes := int64(len(zipContent)) * 2
+	if int64(len(large)) <= maxBytes {
+		t.Fatalf("Expected the entry (%d bytes) to exceed max_bytes (%d bytes)", len(large), maxBytes)
+	}
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+
+	_, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(2), MaxBytes: maxBytes})
+	if !errors.Is(err, &apperrors.ErrZipBombDetected{}) {
+		t.Fatalf("Expected the entry above max_bytes to fail sanitization, got: %v", err)
+	}
+
+	_, err = downloader.DownloadEpisodeRangeAsZip(context.Background(), downloadURL, 1, 2, maxBytes)
+	if !errors.Is(err, &apperrors.ErrZipBombDetected{}) {
+		t.Errorf("Expected the range download to apply max_bytes to the archive, got: %v", err)
+	}
+
+	// The configured limits still allow the archive
+	result, err := downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(2)})
+	if err != nil {
+		t.Fatalf("Expected the archive within the configured limits to download, got: %v", err)
+	}
+	if string(result.Content) != "Episode 2 content" {
+		t.Errorf("Expected episode 2, got %q", result.Content)
+	}
+
+	// An archive cached by that download is still extracted within the caller's limit
+	_, err = downloader.DownloadSubtitle(context.Background(), downloadURL, models.DownloadOptions{Episode: new(2), MaxBytes: maxBytes})
+	if !errors.Is(err, &apperrors.ErrZipBombDetected{}) {
+		t.Errorf("Expected the cached archive to be checked against max_bytes, got: %v", err)
+	}
+}
+
+func TestDownloadEpisodeRangeAsZip_MaxBytes(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": "Episode 1 content",
+		"Show.S01E02.srt": "Episode 2 content",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	downloadURL := buildDownloadURL(server.URL, "123456789")
+	result, err := downloader.DownloadEpisodeRangeAsZip(context.Background(), downloadURL, 1, 2, 0)
+	if err != nil {
+		t.Fatalf("DownloadEpisodeRangeAsZip failed: %v", err)
+	}
+
+	// The packed archive is larger than its entries, which fit in a limit it exceeds
+	maxBytes := int64(len(result.Content)) - 1
+	_, err = downloader.DownloadEpisodeRangeAsZip(context.Background(), downloadURL, 1, 2, maxBytes)
+	var tooLarge *apperrors.ErrDownloadTooLarge
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != maxBytes || tooLarge.Size != int64(len(result.Content)) {
+		t.Errorf("Expected ErrDownloadTooLarge for the packed archive above max_bytes, got: %v", err)
 	}
 }
 
@@ -975,7 +1918,7 @@ func TestDownloadSubtitle_NestedFolderStructure(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(2),
+		models.DownloadOptions{Episode: new(2)},
 	)
 
 	if err != nil {
@@ -997,10 +1940,63 @@ func TestDownloadSubtitle_NestedFolderStructure(t *testing.T) {
 		t.Errorf("Expected filename to contain S03E02, got: %s", result.Filename)
 	}
 
-	// Verify content type
+	// Verify content type
+	if result.ContentType != "application/x-subrip" {
+		t.Errorf("Expected content type 'application/x-subrip', got: %s", result.ContentType)
+	}
+}
+
+func TestDownloadSubtitle_FilenameTemplate(t *testing.T) {
+	t.Parallel()
+	// Season pack with one folder per episode, each holding a file named only by its language
+	zipContent := createTestZip(t, map[string]string{
+		"Dark.S01E02/English.srt": "Episode 2 content",
+		"Dark.S01E03/English.srt": "Episode 3 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	configured, err := models.ParseFilenameTemplate(`{{.ShowName}} - S{{printf "%02d" .Season}}E{{printf "%02d" .Episode}}.{{.Language}}{{.Extension}}`)
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	downloader := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	downloader.filenameTemplate = configured
+
+	result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "123456789"), models.DownloadOptions{Episode: new(3)})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(result.Content) != "Episode 3 content" {
+		t.Errorf("Expected episode 3 content, got: %s", result.Content)
+	}
+	if result.Filename != "Dark - S01E03.en.srt" {
+		t.Errorf("Expected the configured template to name the file, got %q", result.Filename)
+	}
+	if result.SourceFilename != "English.srt" {
+		t.Errorf("Expected SourceFilename to keep the inner name, got %q", result.SourceFilename)
+	}
 	if result.ContentType != "application/x-subrip" {
 		t.Errorf("Expected content type 'application/x-subrip', got: %s", result.ContentType)
 	}
+
+	// A request template overrides the configured one
+	override, err := models.ParseFilenameTemplate("{{.Episode}}-{{.SourceFilename}}")
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	result, err = downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "123456789"), models.DownloadOptions{Episode: new(2), FilenameTemplate: override})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Filename != "2-English.srt" || result.SourceFilename != "English.srt" {
+		t.Errorf("Expected the request template to name the file, got %q from %q", result.Filename, result.SourceFilename)
+	}
 }
 
 func TestDownloadSubtitle_ExceedsDownloadSizeLimit(t *testing.T) {
@@ -1009,7 +2005,7 @@ func TestDownloadSubtitle_ExceedsDownloadSizeLimit(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/zip")
 		w.WriteHeader(http.StatusOK)
-		// Write more than maxDownloadSize (150 MB)
+		// Write more than the default download size limit (150 MB)
 		// Write in chunks to avoid memory issues in test
 		chunk := make([]byte, 1024*1024) // 1 MB chunks
 		for range 151 {
@@ -1024,15 +2020,86 @@ func TestDownloadSubtitle_ExceedsDownloadSizeLimit(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		nil,
+		models.DownloadOptions{},
 	)
 
 	if err == nil {
 		t.Fatal("Expected error for oversized download, got nil")
 	}
 
-	if !strings.Contains(err.Error(), "exceeds limit") {
-		t.Errorf("Expected error message about size limit, got: %v", err)
+	if !errors.Is(err, &apperrors.ErrDownloadTooLarge{}) {
+		t.Errorf("Expected errors.Is to match ErrDownloadTooLarge, got: %v", err)
+	}
+}
+
+// TestDownloadSubtitle_LargeDownloadAllocationStaysBounded downloads a 100 MB season pack that
+// is mostly non-subtitle padding and checks that the download allocates a small fraction of its
+// size, because the body is spooled to a temporary file instead of being read into memory.
+// It does not call t.Parallel because it measures process-wide allocation.
+func TestDownloadSubtitle_LargeDownloadAllocationStaysBounded(t *testing.T) {
+	const (
+		paddingEntries = 4
+		paddingSize    = 25 * 1024 * 1024
+		allocBudget    = 32 * 1024 * 1024
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+
+		// Stream the archive so the test server does not hold it in memory either.
+		zw := zip.NewWriter(w)
+		chunk := bytes.Repeat([]byte{0xA5}, 64*1024)
+		for i := range paddingEntries {
+			entry, err := zw.CreateHeader(&zip.FileHeader{Name: fmt.Sprintf("extras/sample%d.mkv", i), Method: zip.Store})
+			if err != nil {
+				return
+			}
+			for range paddingSize / len(chunk) {
+				if _, err := entry.Write(chunk); err != nil {
+					return
+				}
+			}
+		}
+		entry, err := zw.Create("Show.S01E01.srt")
+		if err != nil {
+			return
+		}
+		_, _ = entry.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloader.limits.MaxFileSize = 32 * 1024 * 1024
+	downloader.limits.MaxTotalSize = 128 * 1024 * 1024
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	result, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{Episode: new(1)},
+	)
+
+	runtime.ReadMemStats(&after)
+
+	if err != nil {
+		t.Fatalf("Expected the large season pack to download, got: %v", err)
+	}
+	if !strings.Contains(string(result.Content), "Hello") {
+		t.Errorf("Expected the extracted episode, got %q", result.Content)
+	}
+
+	allocated := after.TotalAlloc - before.TotalAlloc
+	if allocated > allocBudget {
+		t.Errorf("Expected a %d MB download to allocate at most %d MB, allocated %d MB",
+			paddingEntries*paddingSize>>20, allocBudget>>20, allocated>>20)
 	}
 }
 
@@ -1064,7 +2131,7 @@ func TestExtractEpisodeFromZip_MultipleMatches(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 
 	if err != nil {
@@ -1114,7 +2181,7 @@ func TestExtractEpisodeFromZip_PreferSubtitleOverNonSubtitle(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(2),
+		models.DownloadOptions{Episode: new(2)},
 	)
 
 	if err != nil {
@@ -1228,7 +2295,7 @@ func TestExtractEpisodeFromZip_InvalidUTF8Filename(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 
 	if err != nil {
@@ -1248,6 +2315,43 @@ func TestExtractEpisodeFromZip_InvalidUTF8Filename(t *testing.T) {
 	if len(result.Content) == 0 {
 		t.Error("Expected non-empty content")
 	}
+
+	if !models.IsHearingImpaired(result.Filename) {
+		t.Errorf("Expected sanitized filename %q to be detected as hearing impaired", result.Filename)
+	}
+}
+
+// TestExtractEpisodeFromZip_InvalidUTF8PrefersNonHearingImpaired tests that the [cc] variant of an
+// episode loses to the plain subtitle when both share the same format.
+func TestExtractEpisodeFromZip_InvalidUTF8PrefersNonHearingImpaired(t *testing.T) {
+	t.Parallel()
+	zipContent := createTestZip(t, map[string]string{
+		"Pok\xe9mon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en[cc].srt": "1\n00:00:01,000 --> 00:00:02,000\n[music playing]\n",
+		"Pok\xe9mon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.srt":     "1\n00:00:01,000 --> 00:00:02,000\nHello\n",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	result, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456790"),
+		models.DownloadOptions{Episode: new(1)},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedFilename := "Pok�mon.the.Series_.XYZ.S01E01.WEBRip.Netflix.en.srt"
+	if result.Filename != expectedFilename {
+		t.Errorf("Expected the plain subtitle %q, got %q", expectedFilename, result.Filename)
+	}
 }
 
 // TestExtractEpisodeFromZip_MultipleInvalidUTF8Filenames tests that the correct episode is
@@ -1276,7 +2380,7 @@ func TestExtractEpisodeFromZip_MultipleInvalidUTF8Filenames(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(2),
+		models.DownloadOptions{Episode: new(2)},
 	)
 
 	if err != nil {
@@ -1317,7 +2421,7 @@ func TestExtractEpisodeFromZip_Windows1252Filename(t *testing.T) {
 	result, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 
 	if err != nil {
@@ -1337,12 +2441,341 @@ func TestConvertToUTF8_ISO88591Content(t *testing.T) {
 	// SRT content with ISO-8859-1 encoded "é" (0xE9)
 	iso88591Content := []byte("1\r\n00:00:01,000 --> 00:00:02,000\r\nCaf\xe9\r\n")
 
-	result := convertToUTF8(iso88591Content)
+	result, charsetName := convertToUTF8(iso88591Content, "")
 
 	resultStr := string(result)
 	if !strings.Contains(resultStr, "Café") {
 		t.Errorf("Expected converted content to contain 'Café', got %q", resultStr)
 	}
+	if charsetName != "windows-1252" {
+		t.Errorf("Expected the detected charset to be reported as windows-1252, got %q", charsetName)
+	}
+}
+
+// TestDecodeSubtitleContent_SourceEncoding tests that an explicit source encoding decodes
+// Windows-1250 Hungarian text that charset detection misreads as Windows-1252
+func TestDecodeSubtitleContent_SourceEncoding(t *testing.T) {
+	t.Parallel()
+	// "Tűz és ő" in Windows-1250: ű = 0xFB, é = 0xE9, ő = 0xF5
+	windows1250Content := []byte("T\xfbz \xe9s \xf5")
+	decode := func(sourceEncoding string) (string, string) {
+		t.Helper()
+		decoded, charsetName, err := decodeSubtitleContent(windows1250Content, sourceEncoding, "")
+		if err != nil {
+			t.Fatalf("Expected %q to decode, got %v", sourceEncoding, err)
+		}
+		return string(decoded), charsetName
+	}
+
+	if got, charsetName := decode("windows-1250"); got != "Tűz és ő" || charsetName != "windows-1250" {
+		t.Errorf("Expected 'Tűz és ő' read as windows-1250, got %q read as %q", got, charsetName)
+	}
+	if got, charsetName := decode("ISO-8859-2"); got != "Tűz és ő" || charsetName != "iso-8859-2" {
+		t.Errorf("Expected 'Tűz és ő' read as iso-8859-2, got %q read as %q", got, charsetName)
+	}
+
+	heuristic, _ := convertToUTF8(windows1250Content, "")
+	if string(heuristic) == "Tűz és ő" {
+		t.Fatalf("Expected charset detection to misread Windows-1250 content, got %q", heuristic)
+	}
+	if got, _ := decode(""); got != string(heuristic) {
+		t.Errorf("Expected empty source encoding to use detection %q, got %q", heuristic, got)
+	}
+	if got, charsetName := decode("klingon-8"); got != string(heuristic) || charsetName == "klingon-8" {
+		t.Errorf("Expected an unknown source encoding to fall back to detection %q, got %q read as %q", heuristic, got, charsetName)
+	}
+}
+
+// TestConvertToUTF8_DefaultCharset tests that the configured default charset reads ISO-8859-2
+// Hungarian text that the heuristics alone read as Windows-1252, and that it does not apply to
+// UTF-8 content or content with a byte order mark
+func TestConvertToUTF8_DefaultCharset(t *testing.T) {
+	t.Parallel()
+	// "Őszi szél fújt, ű" in ISO-8859-2: Ő = 0xD5, é = 0xE9, ú = 0xFA, ű = 0xFB
+	iso88592Content := []byte("1\n00:00:01,000 --> 00:00:02,000\n\xd5szi sz\xe9l f\xfajt, \xfb\n")
+	const want = "1\n00:00:01,000 --> 00:00:02,000\nŐszi szél fújt, ű\n"
+
+	heuristic, charsetName := convertToUTF8(iso88592Content, "")
+	if string(heuristic) == want || charsetName != "windows-1252" {
+		t.Fatalf("Expected the heuristics alone to misread ISO-8859-2 as windows-1252, got %q read as %q", heuristic, charsetName)
+	}
+
+	got, charsetName := convertToUTF8(iso88592Content, "iso-8859-2")
+	if string(got) != want || charsetName != "iso-8859-2" {
+		t.Errorf("Expected %q read as iso-8859-2, got %q read as %q", want, got, charsetName)
+	}
+
+	if got, charsetName := convertToUTF8([]byte(want), "iso-8859-2"); string(got) != want || charsetName != "utf-8" {
+		t.Errorf("Expected UTF-8 content to pass through despite the default, got %q read as %q", got, charsetName)
+	}
+	utf16 := []byte{0xFF, 0xFE, 'O', 0, 'k', 0}
+	if got, charsetName := convertToUTF8(utf16, "iso-8859-2"); string(got) != "\ufeffOk" || charsetName != "utf-16le" {
+		t.Errorf("Expected the byte order mark to win over the default, got %q read as %q", got, charsetName)
+	}
+}
+
+func TestDownloadSubtitle_SourceEncoding(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("1\r\n00:00:01,000 --> 00:00:02,000\r\nT\xfbz \xe9s \xf5\r\n"))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	result, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{SourceEncoding: "windows-1250"},
+	)
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if !strings.Contains(string(result.Content), "Tűz és ő") {
+		t.Errorf("Expected content decoded as Windows-1250, got %q", result.Content)
+	}
+	if result.Sha256 != contentSha256(result.Content) {
+		t.Error("Expected SHA-256 of the decoded content")
+	}
+	if result.DetectedCharset != "windows-1250" {
+		t.Errorf("Expected the source encoding to be reported, got %q", result.DetectedCharset)
+	}
+
+	detected, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{SourceEncoding: "klingon-8"},
+	)
+	if err != nil {
+		t.Fatalf("Expected an unknown source encoding to fall back to detection, got %v", err)
+	}
+	if detected.DetectedCharset != "windows-1252" {
+		t.Errorf("Expected the detected charset to be reported, got %q", detected.DetectedCharset)
+	}
+}
+
+func TestDownloadSubtitle_SourceEncodingAppliesToArchiveEntries(t *testing.T) {
+	t.Parallel()
+	// "Tűz és ő" in Windows-1250, which detection reads as Windows-1252
+	const original = "1\r\n00:00:01,000 --> 00:00:02,000\r\nT\xfbz \xe9s \xf5\r\n"
+	single := createTestZip(t, map[string]string{"Show.S01E01.srt": original})
+	pack := createTestZip(t, map[string]string{"Show.S01E01.srt": original, "Show.S01E02.srt": original})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		if r.URL.Query().Get("felirat") == "single" {
+			_, _ = w.Write(single)
+			return
+		}
+		_, _ = w.Write(pack)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	tests := []struct {
+		name       string
+		subtitleID string
+		opts       models.DownloadOptions
+	}{
+		{name: "single file archive", subtitleID: "single", opts: models.DownloadOptions{SourceEncoding: "windows-1250"}},
+		{name: "season pack episode", subtitleID: "pack", opts: models.DownloadOptions{Episode: new(1), SourceEncoding: "windows-1250"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without the override the entry is read as detected when the archive is converted
+			detected, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, tt.subtitleID), models.DownloadOptions{Episode: tt.opts.Episode})
+			if err != nil {
+				t.Fatalf("Download failed: %v", err)
+			}
+			if detected.DetectedCharset != "windows-1252" {
+				t.Errorf("Expected the detected charset recorded with the archive, got %q", detected.DetectedCharset)
+			}
+
+			result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, tt.subtitleID), tt.opts)
+			if err != nil {
+				t.Fatalf("Download failed: %v", err)
+			}
+			if !strings.Contains(string(result.Content), "Tűz és ő") {
+				t.Errorf("Expected the entry decoded as Windows-1250, got %q", result.Content)
+			}
+			if result.DetectedCharset != "windows-1250" {
+				t.Errorf("Expected the source encoding to be reported, got %q", result.DetectedCharset)
+			}
+			assertContentSha256(t, result)
+		})
+	}
+}
+
+func TestDownloadSubtitle_DefaultCharset(t *testing.T) {
+	t.Parallel()
+	// "Őszi szél" in ISO-8859-2, which detection alone reads as Windows-1252
+	const original = "1\r\n00:00:01,000 --> 00:00:02,000\r\n\xd5szi sz\xe9l\r\n"
+	zipContent := createTestZip(t, map[string]string{"Show.S01E01.srt": original, "Show.S01E02.srt": original})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("felirat") == "file" {
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(original))
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader, ok := NewSubtitleDownloader(server.Client()).(*DefaultSubtitleDownloader)
+	if !ok {
+		t.Fatal("NewSubtitleDownloader did not return *DefaultSubtitleDownloader")
+	}
+	downloader.defaultCharset = "iso-8859-2"
+	downloader.limits.DefaultCharset = "iso-8859-2"
+
+	result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "file"), models.DownloadOptions{})
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if !strings.Contains(string(result.Content), "Őszi szél") || result.DetectedCharset != "iso-8859-2" {
+		t.Errorf("Expected the file read as iso-8859-2, got %q read as %q", result.Content, result.DetectedCharset)
+	}
+
+	episode, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "pack"), models.DownloadOptions{Episode: new(1)})
+	if err != nil {
+		t.Fatalf("Episode download failed: %v", err)
+	}
+	if !strings.Contains(string(episode.Content), "Őszi szél") {
+		t.Errorf("Expected the archive entry read as iso-8859-2, got %q", episode.Content)
+	}
+	if episode.DetectedCharset != "iso-8859-2" {
+		t.Errorf("Expected the charset recorded when the archive was converted, got %q", episode.DetectedCharset)
+	}
+}
+
+func TestDownloadSubtitle_StripStyling(t *testing.T) {
+	t.Parallel()
+	const styledASS = "[Script Info]\nScriptType: v4.00+\n\n[Events]\n" +
+		"Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n" +
+		"Dialogue: 0,0:00:01.00,0:00:02.00,Karaoke,,0,0,0,,{\\k20}Hol {\\k30}vagy?\n"
+	const srt = "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}Hol vagy?\n"
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.ass": styledASS,
+		"Show.S01E02.srt": srt,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("felirat") {
+		case "ass":
+			// Generic type: recognized by the [Script Info] header
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte(styledASS))
+		case "srt":
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(srt))
+		default:
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(zipContent)
+		}
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	download := func(subtitleID string, opts models.DownloadOptions) *models.DownloadResult {
+		t.Helper()
+		opts.StripStyling = true
+		result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, subtitleID), opts)
+		if err != nil {
+			t.Fatalf("Download of %s failed: %v", subtitleID, err)
+		}
+		return result
+	}
+
+	for name, result := range map[string]*models.DownloadResult{
+		"file":    download("ass", models.DownloadOptions{}),
+		"episode": download("pack", models.DownloadOptions{Episode: new(1)}),
+	} {
+		content := string(result.Content)
+		if !strings.Contains(content, "Dialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hol vagy?") || strings.Contains(content, "Karaoke") {
+			t.Errorf("%s: expected stripped dialogue, got:\n%s", name, content)
+		}
+		if result.Sha256 != contentSha256(result.Content) {
+			t.Errorf("%s: expected SHA-256 of the stripped content", name)
+		}
+	}
+
+	if got := string(download("srt", models.DownloadOptions{}).Content); got != srt {
+		t.Errorf("Expected SRT unchanged, got %q", got)
+	}
+	if got := string(download("pack", models.DownloadOptions{Episode: new(2)}).Content); got != srt {
+		t.Errorf("Expected extracted SRT unchanged, got %q", got)
+	}
+}
+
+// TestDownloadSubtitle_Raw tests that raw downloads keep ISO-8859-1 bytes, for plain files and
+// episodes extracted from a season pack, without sharing the converted archive in the cache
+func TestDownloadSubtitle_Raw(t *testing.T) {
+	t.Parallel()
+	const iso88591 = "1\r\n00:00:01,000 --> 00:00:02,000\r\nCaf\xe9\r\n"
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": iso88591,
+		"Show.S01E02.srt": iso88591,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("felirat") == "srt" {
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(iso88591))
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	download := func(subtitleID string, opts models.DownloadOptions) *models.DownloadResult {
+		t.Helper()
+		result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, subtitleID), opts)
+		if err != nil {
+			t.Fatalf("Download of %s failed: %v", subtitleID, err)
+		}
+		return result
+	}
+
+	// Cache the converted season pack first; the raw download must not be served from it
+	if got := string(download("pack", models.DownloadOptions{Episode: new(1)}).Content); !strings.Contains(got, "Café") {
+		t.Fatalf("Expected converted episode to contain 'Café', got %q", got)
+	}
+
+	for name, result := range map[string]*models.DownloadResult{
+		"file":    download("srt", models.DownloadOptions{Raw: true}),
+		"episode": download("pack", models.DownloadOptions{Episode: new(1), Raw: true}),
+	} {
+		if string(result.Content) != iso88591 {
+			t.Errorf("%s: expected the ISO-8859-1 bytes unchanged, got %q", name, result.Content)
+		}
+		if result.Sha256 != contentSha256([]byte(iso88591)) {
+			t.Errorf("%s: expected SHA-256 of the original bytes", name)
+		}
+	}
+
+	packed := download("pack", models.DownloadOptions{Raw: true}).Content
+	zipReader, err := zip.NewReader(bytes.NewReader(packed), int64(len(packed)))
+	if err != nil {
+		t.Fatalf("Failed to open raw archive: %v", err)
+	}
+	for _, file := range zipReader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file.Name, err)
+		}
+		if string(content) != iso88591 {
+			t.Errorf("Expected %s in the raw archive to keep the ISO-8859-1 bytes, got %q", file.Name, content)
+		}
+	}
 }
 
 // TestConvertToUTF8_AlreadyUTF8 tests that valid UTF-8 content passes through unchanged
@@ -1350,17 +2783,20 @@ func TestConvertToUTF8_AlreadyUTF8(t *testing.T) {
 	t.Parallel()
 	utf8Content := []byte("1\r\n00:00:01,000 --> 00:00:02,000\r\nCafé\r\n")
 
-	result := convertToUTF8(utf8Content)
+	result, charsetName := convertToUTF8(utf8Content, "")
 
 	if !bytes.Equal(result, utf8Content) {
 		t.Errorf("Expected UTF-8 content to pass through unchanged")
 	}
+	if charsetName != "utf-8" {
+		t.Errorf("Expected passed through content to be reported as utf-8, got %q", charsetName)
+	}
 }
 
 // TestConvertToUTF8_EmptyContent tests that empty content is handled
 func TestConvertToUTF8_EmptyContent(t *testing.T) {
 	t.Parallel()
-	result := convertToUTF8([]byte{})
+	result, _ := convertToUTF8([]byte{}, "")
 	if len(result) != 0 {
 		t.Errorf("Expected empty result, got %d bytes", len(result))
 	}
@@ -1410,6 +2846,14 @@ func getCounterVecValue(cv *prometheus.CounterVec, labels ...string) float64 {
 	return m.GetCounter().GetValue()
 }
 
+func getCounterValue(c prometheus.Counter) float64 {
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
+
 func TestDownloadSubtitle_Metrics_SuccessIncrement(t *testing.T) {
 	content := "1\n00:00:01,000 --> 00:00:02,000\nTest subtitle\n"
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1426,37 +2870,65 @@ func TestDownloadSubtitle_Metrics_SuccessIncrement(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "123456789"),
-		nil,
+		models.DownloadOptions{},
 	)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 
-	after := getCounterVecValue(metrics.SubtitleDownloadsTotal, "success")
+	after := getCounterVecValue(metrics.SubtitleDownloadsTotal, "success")
+	if after != before+1 {
+		t.Errorf("Expected success counter to increment by 1, got diff %.0f", after-before)
+	}
+}
+
+func TestDownloadSubtitle_Metrics_ErrorIncrement(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+
+	before := getCounterVecValue(metrics.SubtitleDownloadsTotal, "error")
+
+	_, _ = downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "123456789"),
+		models.DownloadOptions{},
+	)
+
+	after := getCounterVecValue(metrics.SubtitleDownloadsTotal, "error")
 	if after != before+1 {
-		t.Errorf("Expected success counter to increment by 1, got diff %.0f", after-before)
+		t.Errorf("Expected error counter to increment by 1, got diff %.0f", after-before)
 	}
 }
 
-func TestDownloadSubtitle_Metrics_ErrorIncrement(t *testing.T) {
+func TestDownloadSubtitle_Metrics_UpstreamNotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusNotFound)
 	}))
 	defer server.Close()
 
 	downloader := NewSubtitleDownloader(server.Client())
 
-	before := getCounterVecValue(metrics.SubtitleDownloadsTotal, "error")
+	before4xx := getCounterVecValue(metrics.UpstreamRequestsTotal, "download", "4xx")
+	before2xx := getCounterVecValue(metrics.UpstreamRequestsTotal, "download", "2xx")
 
-	_, _ = downloader.DownloadSubtitle(
+	_, err := downloader.DownloadSubtitle(
 		context.Background(),
-		buildDownloadURL(server.URL, "123456789"),
-		nil,
+		buildDownloadURL(server.URL, "upstream-404"),
+		models.DownloadOptions{},
 	)
+	if err == nil {
+		t.Fatal("Expected error for 404 download, got nil")
+	}
 
-	after := getCounterVecValue(metrics.SubtitleDownloadsTotal, "error")
-	if after != before+1 {
-		t.Errorf("Expected error counter to increment by 1, got diff %.0f", after-before)
+	if diff := getCounterVecValue(metrics.UpstreamRequestsTotal, "download", "4xx") - before4xx; diff != 1 {
+		t.Errorf("Expected upstream 4xx counter to increment by 1, got diff %.0f", diff)
+	}
+	if diff := getCounterVecValue(metrics.UpstreamRequestsTotal, "download", "2xx") - before2xx; diff != 0 {
+		t.Errorf("Expected upstream 2xx counter to stay unchanged, got diff %.0f", diff)
 	}
 }
 
@@ -1482,7 +2954,7 @@ func TestDownloadSubtitle_Metrics_CacheHitMiss(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "metrics-test"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 	if err != nil {
 		t.Fatalf("First request failed: %v", err)
@@ -1497,7 +2969,7 @@ func TestDownloadSubtitle_Metrics_CacheHitMiss(t *testing.T) {
 	_, err = downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "metrics-test"),
-		new(2),
+		models.DownloadOptions{Episode: new(2)},
 	)
 	if err != nil {
 		t.Fatalf("Second request failed: %v", err)
@@ -1537,7 +3009,7 @@ func TestDownloadSubtitle_Metrics_CacheEntriesGauge(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "gauge-test-unique"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 	if err != nil {
 		t.Fatalf("Download failed: %v", err)
@@ -1570,6 +3042,138 @@ func gatherCacheEntriesMetric(group string) float64 {
 	return -1
 }
 
+// gatherHistogramMetric reads the histogram name{labels} from the default Prometheus
+// registry, returning an empty histogram if no sample has been observed yet.
+func gatherHistogramMetric(name string, labels map[string]string) *dto.Histogram {
+	mfs, _ := prometheus.DefaultGatherer.Gather()
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			matched := 0
+			for _, lp := range m.GetLabel() {
+				if value, ok := labels[lp.GetName()]; ok && value == lp.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return m.GetHistogram()
+			}
+		}
+	}
+	return &dto.Histogram{}
+}
+
+// histogramBucketCount returns the cumulative count of the bucket with upper bound le.
+func histogramBucketCount(h *dto.Histogram, le float64) uint64 {
+	for _, b := range h.GetBucket() {
+		if b.GetUpperBound() == le {
+			return b.GetCumulativeCount()
+		}
+	}
+	return 0
+}
+
+func TestDownloadSubtitle_Metrics_PlainFileHistograms(t *testing.T) {
+	// 50KiB lands above the 40KiB bucket and within the 160KiB one
+	content := strings.Repeat("1\n00:00:01,000 --> 00:00:02,000\nLine\n\n", 50*1024/38)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-subrip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	labels := map[string]string{"outcome": "success", "kind": "file", "cache_hit": "false"}
+
+	bytesBefore := gatherHistogramMetric("subtitle_download_bytes", labels)
+	durationBefore := gatherHistogramMetric("subtitle_download_duration_seconds", labels)
+
+	_, err := downloader.DownloadSubtitle(
+		context.Background(),
+		buildDownloadURL(server.URL, "histogram-plain"),
+		models.DownloadOptions{},
+	)
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	bytesAfter := gatherHistogramMetric("subtitle_download_bytes", labels)
+	if diff := bytesAfter.GetSampleCount() - bytesBefore.GetSampleCount(); diff != 1 {
+		t.Fatalf("Expected 1 new byte sample, got %d", diff)
+	}
+	if diff := bytesAfter.GetSampleSum() - bytesBefore.GetSampleSum(); diff != float64(len(content)) {
+		t.Errorf("Expected byte sum to grow by %d, got %.0f", len(content), diff)
+	}
+	if diff := histogramBucketCount(bytesAfter, 40*1024) - histogramBucketCount(bytesBefore, 40*1024); diff != 0 {
+		t.Errorf("Expected no new sample in the 40KiB bucket, got %d", diff)
+	}
+	if diff := histogramBucketCount(bytesAfter, 160*1024) - histogramBucketCount(bytesBefore, 160*1024); diff != 1 {
+		t.Errorf("Expected 1 new sample in the 160KiB bucket, got %d", diff)
+	}
+
+	durationAfter := gatherHistogramMetric("subtitle_download_duration_seconds", labels)
+	if diff := durationAfter.GetSampleCount() - durationBefore.GetSampleCount(); diff != 1 {
+		t.Errorf("Expected 1 new duration sample, got %d", diff)
+	}
+}
+
+func TestDownloadSubtitle_Metrics_ExtractionHistograms(t *testing.T) {
+	zipContent := createTestZip(t, map[string]string{
+		"show.s03e01.srt": "Episode 1 content",
+		"show.s03e02.srt": "Episode 2 content",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	missLabels := map[string]string{"outcome": "success", "kind": "extraction", "cache_hit": "false"}
+	hitLabels := map[string]string{"outcome": "success", "kind": "extraction", "cache_hit": "true"}
+
+	missBefore := gatherHistogramMetric("subtitle_download_bytes", missLabels)
+	hitBefore := gatherHistogramMetric("subtitle_download_bytes", hitLabels)
+	sanitizeBefore := gatherHistogramMetric("subtitle_extraction_duration_seconds", map[string]string{"step": "sanitize"})
+	extractBefore := gatherHistogramMetric("subtitle_extraction_duration_seconds", map[string]string{"step": "extract"})
+
+	for _, episode := range []int{1, 2} {
+		_, err := downloader.DownloadSubtitle(
+			context.Background(),
+			buildDownloadURL(server.URL, "histogram-extraction"),
+			models.DownloadOptions{Episode: new(episode)},
+		)
+		if err != nil {
+			t.Fatalf("Download of episode %d failed: %v", episode, err)
+		}
+	}
+
+	// The first download misses the cache and the second hits it; both archives are well under 10KiB
+	missAfter := gatherHistogramMetric("subtitle_download_bytes", missLabels)
+	if diff := histogramBucketCount(missAfter, 10*1024) - histogramBucketCount(missBefore, 10*1024); diff != 1 {
+		t.Errorf("Expected 1 new cache-miss sample in the 10KiB bucket, got %d", diff)
+	}
+	hitAfter := gatherHistogramMetric("subtitle_download_bytes", hitLabels)
+	if diff := histogramBucketCount(hitAfter, 10*1024) - histogramBucketCount(hitBefore, 10*1024); diff != 1 {
+		t.Errorf("Expected 1 new cache-hit sample in the 10KiB bucket, got %d", diff)
+	}
+
+	// Only the uncached download sanitizes the archive, but both extract an episode
+	sanitizeAfter := gatherHistogramMetric("subtitle_extraction_duration_seconds", map[string]string{"step": "sanitize"})
+	if diff := sanitizeAfter.GetSampleCount() - sanitizeBefore.GetSampleCount(); diff != 1 {
+		t.Errorf("Expected 1 new sanitize sample, got %d", diff)
+	}
+	extractAfter := gatherHistogramMetric("subtitle_extraction_duration_seconds", map[string]string{"step": "extract"})
+	if diff := extractAfter.GetSampleCount() - extractBefore.GetSampleCount(); diff != 2 {
+		t.Errorf("Expected 2 new extract samples, got %d", diff)
+	}
+}
+
 func TestDownloadSubtitle_Metrics_ZipEpisodeExtractionSuccess(t *testing.T) {
 	zipContent := createTestZip(t, map[string]string{
 		"show.s03e01.srt": "Episode 1 content",
@@ -1590,7 +3194,7 @@ func TestDownloadSubtitle_Metrics_ZipEpisodeExtractionSuccess(t *testing.T) {
 	_, err := downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "zip-success-test"),
-		new(1),
+		models.DownloadOptions{Episode: new(1)},
 	)
 	if err != nil {
 		t.Fatalf("Download failed: %v", err)
@@ -1622,7 +3226,7 @@ func TestDownloadSubtitle_Metrics_ZipEpisodeExtractionError(t *testing.T) {
 	_, _ = downloader.DownloadSubtitle(
 		context.Background(),
 		buildDownloadURL(server.URL, "zip-error-test"),
-		new(99),
+		models.DownloadOptions{Episode: new(99)},
 	)
 
 	after := getCounterVecValue(metrics.SubtitleDownloadsTotal, "error")
@@ -1630,3 +3234,348 @@ func TestDownloadSubtitle_Metrics_ZipEpisodeExtractionError(t *testing.T) {
 		t.Errorf("Expected error counter to increment by 1 for failed ZIP extraction, got diff %.0f", after-before)
 	}
 }
+
+func TestDownloadSubtitle_ApplyConfigShortensCacheTTL(t *testing.T) {
+	t.Parallel()
+	var requestCount atomic.Int32
+	zipContent := createTestZip(t, map[string]string{"show.s03e01.srt": "Episode 1 content"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	defer downloader.Close()
+
+	cfg := &internalConfig.Config{}
+	cfg.Cache.TTL = "50ms"
+	downloader.ApplyConfig(cfg)
+
+	download := func() {
+		t.Helper()
+		if _, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, "123456789"), models.DownloadOptions{Episode: new(1)}); err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+	}
+
+	download()
+	download()
+	if n := requestCount.Load(); n != 1 {
+		t.Fatalf("Expected cached archive to be reused within the TTL, got %d requests", n)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	download()
+	if n := requestCount.Load(); n != 2 {
+		t.Errorf("Expected archive to be re-fetched after the reloaded TTL expired, got %d requests", n)
+	}
+}
+
+// TestDownloadSubtitle_SniffsGenericContentType tests that subtitles served with a generic
+// content type get their type, extension and UTF-8 conversion from the sniffed format
+func TestDownloadSubtitle_SniffsGenericContentType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		contentType         string
+		payload             string
+		expectedFilename    string
+		expectedContentType string
+		expectedContent     string
+	}{
+		{
+			name:                "WebVTT as octet-stream",
+			contentType:         "application/octet-stream",
+			payload:             "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n",
+			expectedFilename:    "123456789.vtt",
+			expectedContentType: "text/vtt",
+			expectedContent:     "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n",
+		},
+		{
+			name:                "ASS as octet-stream",
+			contentType:         "application/octet-stream",
+			payload:             "[Script Info]\nTitle: T\xfbz\n",
+			expectedFilename:    "123456789.ass",
+			expectedContentType: "application/x-ass",
+			expectedContent:     "[Script Info]\nTitle: Tűz\n",
+		},
+		{
+			name:                "SRT as plain text",
+			contentType:         "text/plain",
+			payload:             "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n",
+			expectedFilename:    "123456789.srt",
+			expectedContentType: "application/x-subrip",
+			expectedContent:     "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n",
+		},
+		{
+			name:                "binary blob stays octet-stream",
+			contentType:         "application/octet-stream",
+			payload:             "\x00\x01\xfb\xe9\xff\xfe",
+			expectedFilename:    "123456789.srt",
+			expectedContentType: "application/octet-stream",
+			expectedContent:     "\x00\x01\xfb\xe9\xff\xfe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.payload))
+			}))
+			defer server.Close()
+
+			downloader := NewSubtitleDownloader(server.Client())
+
+			result, err := downloader.DownloadSubtitle(
+				context.Background(),
+				buildDownloadURL(server.URL, "123456789"),
+				models.DownloadOptions{SourceEncoding: "windows-1250"},
+			)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if result.Filename != tt.expectedFilename {
+				t.Errorf("Expected filename '%s', got '%s'", tt.expectedFilename, result.Filename)
+			}
+			if result.ContentType != tt.expectedContentType {
+				t.Errorf("Expected content type '%s', got '%s'", tt.expectedContentType, result.ContentType)
+			}
+			if string(result.Content) != tt.expectedContent {
+				t.Errorf("Expected content %q, got %q", tt.expectedContent, result.Content)
+			}
+		})
+	}
+}
+
+func TestDownloadSubtitle_StripsBOM(t *testing.T) {
+	t.Parallel()
+	const srt = "1\r\n00:00:01,000 --> 00:00:02,000\r\nFirst cue\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nSecond cue\r\n"
+	withBOM := "\xEF\xBB\xBF" + srt
+	// UTF-16LE with its BOM, as some editors save subtitles
+	utf16 := []byte{0xFF, 0xFE}
+	for _, r := range srt {
+		utf16 = append(utf16, byte(r), 0)
+	}
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": withBOM,
+		"Show.S01E02.srt": withBOM,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("felirat") {
+		case "utf8":
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(withBOM))
+		case "utf16":
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write(utf16)
+		default:
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(zipContent)
+		}
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	download := func(subtitleID string, opts models.DownloadOptions) *models.DownloadResult {
+		t.Helper()
+		result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, subtitleID), opts)
+		if err != nil {
+			t.Fatalf("Download of %s failed: %v", subtitleID, err)
+		}
+		return result
+	}
+
+	for name, result := range map[string]*models.DownloadResult{
+		"utf8":    download("utf8", models.DownloadOptions{}),
+		"utf16":   download("utf16", models.DownloadOptions{}),
+		"episode": download("pack", models.DownloadOptions{Episode: new(1)}),
+	} {
+		if string(result.Content) != srt {
+			t.Errorf("%s: expected the cues without a BOM, got %q", name, result.Content)
+		}
+		if result.Sha256 != contentSha256([]byte(srt)) {
+			t.Errorf("%s: expected SHA-256 of the content without a BOM", name)
+		}
+	}
+
+	for name, result := range map[string]*models.DownloadResult{
+		"utf8":    download("utf8", models.DownloadOptions{KeepBOM: true}),
+		"episode": download("pack", models.DownloadOptions{Episode: new(1), KeepBOM: true}),
+	} {
+		if string(result.Content) != withBOM {
+			t.Errorf("%s: expected keep BOM to return the BOM, got %q", name, result.Content)
+		}
+	}
+}
+
+// TestDownloadSubtitle_Spans replaces the global tracer provider, so it must not run in
+// parallel with other tests.
+func TestDownloadSubtitle_Spans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracenoop.NewTracerProvider())
+		_ = provider.Shutdown(context.Background())
+	})
+
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": "1\n00:00:01,000 --> 00:00:02,000\nFirst\n",
+		"Show.S01E02.srt": "1\n00:00:01,000 --> 00:00:02,000\nSecond\n",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	ctx, root := provider.Tracer("test").Start(context.Background(), "rpc")
+	if _, err := downloader.DownloadSubtitle(ctx, buildDownloadURL(server.URL, "1706"), models.DownloadOptions{Episode: new(2)}); err != nil {
+		t.Fatalf("DownloadSubtitle failed: %v", err)
+	}
+	root.End()
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID() == root.SpanContext().TraceID() {
+			spans[span.Name] = span
+		}
+	}
+	parents := map[string]string{
+		"downloader.DownloadSubtitle":      "rpc",
+		"downloader.downloadFile":          "downloader.DownloadSubtitle",
+		"downloader.sanitizeZip":           "downloader.DownloadSubtitle",
+		"downloader.extractEpisodeFromZip": "downloader.DownloadSubtitle",
+	}
+	for name, parent := range parents {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("Expected a %s span, got %d spans", name, len(spans))
+			continue
+		}
+		if span.Parent.SpanID() != spans[parent].SpanContext.SpanID() {
+			t.Errorf("Expected %s to be a child of %s", name, parent)
+		}
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range spans["downloader.DownloadSubtitle"].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["episode"].AsInt64() != 2 || attrs["cache_hit"].AsBool() || attrs["url"].AsString() == "" {
+		t.Errorf("Unexpected DownloadSubtitle attributes: %v", spans["downloader.DownloadSubtitle"].Attributes)
+	}
+}
+
+func TestDownloadSubtitle_Validate(t *testing.T) {
+	t.Parallel()
+	const valid = "1\n00:00:01,000 --> 00:00:02,000\nFirst cue\n\n2\n00:00:03,000 --> 00:00:04,000\nSecond cue\n"
+	const broken = "1\n00:00:01,000 --> 00:00:02,000\nFirst cue\n\n2\n00:00:03 --> 00:00:04\nSecond cue\n"
+	zipContent := createTestZip(t, map[string]string{
+		"Show.S01E01.srt": valid,
+		"Show.S01E02.srt": broken,
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("felirat") {
+		case "valid":
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(valid))
+		case "broken":
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(broken))
+		default:
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(zipContent)
+		}
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	download := func(subtitleID string, opts models.DownloadOptions) error {
+		_, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, subtitleID), opts)
+		return err
+	}
+
+	if err := download("broken", models.DownloadOptions{}); err != nil {
+		t.Errorf("Expected no check without validate, got %v", err)
+	}
+	for name, err := range map[string]error{
+		"valid file":    download("valid", models.DownloadOptions{Validate: true}),
+		"valid episode": download("pack", models.DownloadOptions{Episode: new(1), Validate: true}),
+	} {
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	for name, err := range map[string]error{
+		"broken file":    download("broken", models.DownloadOptions{Validate: true}),
+		"broken episode": download("pack", models.DownloadOptions{Episode: new(2), Validate: true}),
+	} {
+		var malformed *apperrors.ErrMalformedSubtitle
+		if !errors.As(err, &malformed) || malformed.Line != 6 {
+			t.Errorf("%s: expected ErrMalformedSubtitle at line 6, got %v", name, err)
+		}
+	}
+}
+
+func TestDownloadSubtitle_TimeOffset(t *testing.T) {
+	t.Parallel()
+	// "Őszi szél" in Windows-1250, so the shift runs on the converted file
+	const original = "1\r\n00:00:01,000 --> 00:00:02,800\r\n\xd5szi sz\xe9l\r\n"
+	const episode = "1\n00:59:59,900 --> 01:00:00,500\nHello\n"
+	zipContent := createTestZip(t, map[string]string{"Show.S01E01.srt": episode, "Show.S01E02.srt": episode})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("felirat") == "file" {
+			w.Header().Set("Content-Type", "application/x-subrip")
+			_, _ = w.Write([]byte(original))
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(zipContent)
+	}))
+	defer server.Close()
+
+	downloader := NewSubtitleDownloader(server.Client())
+	tests := []struct {
+		name       string
+		subtitleID string
+		opts       models.DownloadOptions
+		want       string
+	}{
+		{
+			name:       "file",
+			subtitleID: "file",
+			opts:       models.DownloadOptions{TimeOffset: 250 * time.Millisecond, SourceEncoding: "windows-1250"},
+			want:       "1\r\n00:00:01,250 --> 00:00:03,050\r\nŐszi szél\r\n",
+		},
+		{
+			name:       "episode",
+			subtitleID: "pack",
+			opts:       models.DownloadOptions{TimeOffset: 250 * time.Millisecond, Episode: new(1)},
+			want:       "1\n01:00:00,150 --> 01:00:00,750\nHello\n",
+		},
+	}
+	for _, tt := range tests {
+		result, err := downloader.DownloadSubtitle(context.Background(), buildDownloadURL(server.URL, tt.subtitleID), tt.opts)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		if string(result.Content) != tt.want {
+			t.Errorf("%s: expected shifted cues %q, got %q", tt.name, tt.want, result.Content)
+		}
+		if result.Sha256 != contentSha256([]byte(tt.want)) {
+			t.Errorf("%s: expected the checksum of the shifted file", tt.name)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// SubtitleIndex defines the interface for an in-memory subtitle lookup by show, season, episode and language
+type SubtitleIndex interface {
+	// Ingest indexes the complete subtitle list of a show, replacing anything indexed for it before.
+	Ingest(showSubtitles models.ShowSubtitles)
+
+	// Lookup returns the subtitles for one episode in the given language (empty matches every language):
+	// episode subtitles first, then season packs whose range covers the episode.
+	// The boolean reports whether the show is indexed; an indexed show may still have no match.
+	Lookup(showID, season, episode int, language string) ([]models.Subtitle, bool)
+
+	// LookupByID returns the subtitle of a show with the given ID. indexed reports whether the show
+	// is indexed; found is false when it is not, or when the show has no such subtitle.
+	LookupByID(showID, subtitleID int) (subtitle models.Subtitle, found, indexed bool)
+
+	// Clear drops every indexed show, so the next lookups fetch and index them again.
+	Clear()
+}
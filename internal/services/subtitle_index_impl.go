@@ -0,0 +1,139 @@
+package services
+
+import (
+	"slices"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// DefaultSubtitleIndexMaxShows is the number of shows kept in the index when no limit is configured.
+const DefaultSubtitleIndexMaxShows = 500
+
+// subtitleIndexKey identifies a group of subtitles within a show. Season packs use episode -1,
+// the value the parser assigns them.
+type subtitleIndexKey struct {
+	season   int
+	episode  int
+	language string
+}
+
+// indexedShow holds one show's subtitles in listing order and, per key, the positions of the
+// subtitles in that group. It is built once by Ingest and only read afterwards.
+type indexedShow struct {
+	subtitles []models.Subtitle
+	positions map[subtitleIndexKey][]int
+}
+
+// DefaultSubtitleIndex implements SubtitleIndex with an LRU of shows, so memory stays bounded
+// by the number of shows rather than by how many subtitles each one has.
+type DefaultSubtitleIndex struct {
+	shows *lru.Cache[int, *indexedShow]
+}
+
+// NewSubtitleIndex creates an index holding at most maxShows shows; the least recently used show
+// is evicted when a new one is ingested. maxShows <= 0 uses DefaultSubtitleIndexMaxShows.
+func NewSubtitleIndex(maxShows int) SubtitleIndex {
+	if maxShows <= 0 {
+		maxShows = DefaultSubtitleIndexMaxShows
+	}
+	shows, err := lru.New[int, *indexedShow](maxShows)
+	if err != nil {
+		// Only returned for a non-positive size, which is ruled out above
+		panic(err)
+	}
+	return &DefaultSubtitleIndex{shows: shows}
+}
+
+// Ingest implements SubtitleIndex.Ingest
+func (i *DefaultSubtitleIndex) Ingest(showSubtitles models.ShowSubtitles) {
+	subtitles := slices.Clone(showSubtitles.SubtitleCollection.Subtitles)
+	show := &indexedShow{subtitles: subtitles, positions: make(map[subtitleIndexKey][]int)}
+	for pos, subtitle := range subtitles {
+		key := subtitleIndexKey{season: subtitle.Season, episode: subtitle.Episode, language: normalizeIndexLanguage(subtitle.Language)}
+		if subtitle.IsSeasonPack {
+			key.episode = -1
+		}
+		show.positions[key] = append(show.positions[key], pos)
+	}
+	i.shows.Add(showSubtitles.Show.ID, show)
+}
+
+// LookupByID implements SubtitleIndex.LookupByID
+func (i *DefaultSubtitleIndex) LookupByID(showID, subtitleID int) (models.Subtitle, bool, bool) {
+	show, ok := i.shows.Get(showID)
+	if !ok {
+		return models.Subtitle{}, false, false
+	}
+	for _, subtitle := range show.subtitles {
+		if subtitle.ID == subtitleID {
+			return subtitle, true, true
+		}
+	}
+	return models.Subtitle{}, false, true
+}
+
+// Clear implements SubtitleIndex.Clear
+func (i *DefaultSubtitleIndex) Clear() {
+	i.shows.Purge()
+}
+
+// Lookup implements SubtitleIndex.Lookup
+func (i *DefaultSubtitleIndex) Lookup(showID, season, episode int, language string) ([]models.Subtitle, bool) {
+	show, ok := i.shows.Get(showID)
+	if !ok {
+		return nil, false
+	}
+	language = normalizeIndexLanguage(language)
+
+	var episodes, seasonPacks []int
+	if episode < 0 {
+		// -1 is the season-pack marker, not an episode a pack can cover
+		return []models.Subtitle{}, true
+	}
+	if language != "" {
+		episodes = show.positions[subtitleIndexKey{season: season, episode: episode, language: language}]
+		seasonPacks = show.positions[subtitleIndexKey{season: season, episode: -1, language: language}]
+	} else {
+		// Every language matches: merge the groups and restore listing order
+		for key, positions := range show.positions {
+			switch {
+			case key.season != season:
+			case key.episode == episode:
+				episodes = append(episodes, positions...)
+			case key.episode == -1:
+				seasonPacks = append(seasonPacks, positions...)
+			}
+		}
+		slices.Sort(episodes)
+		slices.Sort(seasonPacks)
+	}
+
+	result := make([]models.Subtitle, 0, len(episodes)+len(seasonPacks))
+	for _, pos := range episodes {
+		result = append(result, show.subtitles[pos])
+	}
+	for _, pos := range seasonPacks {
+		if seasonPackCovers(show.subtitles[pos], episode) {
+			result = append(result, show.subtitles[pos])
+		}
+	}
+	return result, true
+}
+
+// seasonPackCovers reports whether a season pack contains episode. Packs without a range cover the whole season.
+func seasonPackCovers(subtitle models.Subtitle, episode int) bool {
+	if subtitle.RangeStart != nil && episode < *subtitle.RangeStart {
+		return false
+	}
+	if subtitle.RangeEnd != nil && episode > *subtitle.RangeEnd {
+		return false
+	}
+	return true
+}
+
+func normalizeIndexLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func indexedTestShow() models.ShowSubtitles {
+	return models.ShowSubtitles{
+		Show: models.Show{ID: 123, Name: "Billy the Kid"},
+		SubtitleCollection: models.SubtitleCollection{
+			Subtitles: []models.Subtitle{
+				{ID: 10, Season: 2, Episode: 5, Language: "hu"},
+				{ID: 9, Season: 2, Episode: -1, Language: "hu", IsSeasonPack: true},
+				{ID: 8, Season: 2, Episode: 5, Language: "en"},
+				{ID: 7, Season: 2, Episode: -1, Language: "hu", IsSeasonPack: true, RangeStart: new(1), RangeEnd: new(4)},
+				{ID: 6, Season: 2, Episode: 5, Language: "HU"},
+				{ID: 5, Season: 2, Episode: 6, Language: "hu"},
+				{ID: 4, Season: 1, Episode: 5, Language: "hu"},
+				{ID: 3, Season: 2, Episode: -1, Language: "en", IsSeasonPack: true, RangeStart: new(5), RangeEnd: new(8)},
+			},
+		},
+	}
+}
+
+func subtitleIDs(subtitles []models.Subtitle) []int {
+	ids := make([]int, len(subtitles))
+	for i, subtitle := range subtitles {
+		ids[i] = subtitle.ID
+	}
+	return ids
+}
+
+func TestSubtitleIndex_Lookup(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(10)
+	index.Ingest(indexedTestShow())
+
+	tests := []struct {
+		name     string
+		season   int
+		episode  int
+		language string
+		want     []int
+	}{
+		{"episode then covering season pack", 2, 5, "hu", []int{10, 6, 9}},
+		{"language is case-insensitive", 2, 5, "HU", []int{10, 6, 9}},
+		{"ranged pack covering the episode", 2, 3, "hu", []int{9, 7}},
+		{"every language in listing order", 2, 5, "", []int{10, 8, 6, 9, 3}},
+		{"other season", 1, 5, "hu", []int{4}},
+		{"no match in an indexed show", 3, 1, "hu", []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			subtitles, ok := index.Lookup(123, tt.season, tt.episode, tt.language)
+			if !ok {
+				t.Fatal("Expected indexed show to be found")
+			}
+			if got := subtitleIDs(subtitles); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected subtitle IDs %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubtitleIndex_LookupUnknownShow(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(10)
+
+	if subtitles, ok := index.Lookup(123, 1, 1, "hu"); ok || subtitles != nil {
+		t.Errorf("Expected miss for a show that was never ingested, got %v, %v", subtitles, ok)
+	}
+}
+
+func TestSubtitleIndex_LookupByID(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(10)
+	index.Ingest(indexedTestShow())
+
+	if subtitle, found, indexed := index.LookupByID(123, 7); !found || !indexed || subtitle.RangeEnd == nil || *subtitle.RangeEnd != 4 {
+		t.Errorf("Expected season pack 7 to be found, got %+v, found=%v, indexed=%v", subtitle, found, indexed)
+	}
+	if _, found, indexed := index.LookupByID(123, 99); found || !indexed {
+		t.Errorf("Expected an unknown subtitle of an indexed show to be missing, got found=%v, indexed=%v", found, indexed)
+	}
+	if _, found, indexed := index.LookupByID(456, 7); found || indexed {
+		t.Errorf("Expected a show that was never ingested to be unindexed, got found=%v, indexed=%v", found, indexed)
+	}
+
+	index.Clear()
+	if _, _, indexed := index.LookupByID(123, 7); indexed {
+		t.Error("Expected Clear to drop the indexed show")
+	}
+}
+
+func TestSubtitleIndex_IngestReplacesShow(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(10)
+	index.Ingest(indexedTestShow())
+	index.Ingest(models.ShowSubtitles{
+		Show: models.Show{ID: 123},
+		SubtitleCollection: models.SubtitleCollection{
+			Subtitles: []models.Subtitle{{ID: 11, Season: 2, Episode: 5, Language: "hu"}},
+		},
+	})
+
+	subtitles, _ := index.Lookup(123, 2, 5, "hu")
+	if got := subtitleIDs(subtitles); !slices.Equal(got, []int{11}) {
+		t.Errorf("Expected only the re-ingested subtitle, got %v", got)
+	}
+}
+
+func TestSubtitleIndex_EvictsLeastRecentlyUsedShow(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(2)
+	for showID := 1; showID <= 2; showID++ {
+		index.Ingest(models.ShowSubtitles{Show: models.Show{ID: showID}})
+	}
+
+	// Touch show 1 so that show 2 is the least recently used
+	if _, ok := index.Lookup(1, 1, 1, ""); !ok {
+		t.Fatal("Expected show 1 to be indexed")
+	}
+	index.Ingest(models.ShowSubtitles{Show: models.Show{ID: 3}})
+
+	for showID, want := range map[int]bool{1: true, 2: false, 3: true} {
+		if _, ok := index.Lookup(showID, 1, 1, ""); ok != want {
+			t.Errorf("Show %d: expected indexed=%v, got %v", showID, want, ok)
+		}
+	}
+}
+
+func TestSubtitleIndex_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	index := NewSubtitleIndex(5)
+
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Go(func() {
+			for i := range 100 {
+				showID := (worker+i)%10 + 1
+				index.Ingest(models.ShowSubtitles{
+					Show: models.Show{ID: showID},
+					SubtitleCollection: models.SubtitleCollection{
+						Subtitles: []models.Subtitle{{ID: i, Season: 1, Episode: 1, Language: fmt.Sprint("l", worker)}},
+					},
+				})
+				index.Lookup(showID, 1, 1, "")
+			}
+		})
+	}
+	wg.Wait()
+}
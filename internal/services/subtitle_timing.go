@@ -0,0 +1,85 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/archive"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// cueTimestampRegex matches a timestamp of an SRT or WebVTT timing line, such as "00:01:02,345"
+// or "01:02.345". WebVTT leaves out the hours below one hour.
+var cueTimestampRegex = regexp.MustCompile(`(?:(\d{1,3}):)?([0-5]\d):([0-5]\d)([,.])(\d{1,3})`)
+
+// applyTimeOffset shifts the cues of an SRT or WebVTT file by opts.TimeOffset with
+// ShiftSubtitleTimings. Raw downloads, archives and other formats are returned unchanged. Files
+// are recognized by content type or, for generic types such as text/plain, by their first cue.
+func applyTimeOffset(content []byte, contentType string, opts models.DownloadOptions) []byte {
+	if opts.TimeOffset == 0 || opts.Raw {
+		return content
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	if archive.IsGenericContentType(contentType) {
+		mediaType = archive.SniffSubtitleContentType(content)
+	}
+	switch mediaType {
+	case "application/x-subrip", "text/vtt", "text/webvtt":
+		return ShiftSubtitleTimings(content, opts.TimeOffset)
+	default:
+		return content
+	}
+}
+
+// ShiftSubtitleTimings adds offset to every timestamp on the timing lines ("-->") of an SRT or
+// WebVTT file, such as "00:01:02,345 --> 00:01:04,000". A negative offset moves cues earlier;
+// timestamps that would fall before zero are clamped at zero. Each timestamp keeps its
+// millisecond separator, and WebVTT timestamps without hours gain them once they pass one hour.
+// Other lines, cue settings and line endings are left as they are.
+func ShiftSubtitleTimings(content []byte, offset time.Duration) []byte {
+	if offset == 0 {
+		return content
+	}
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	for i, line := range lines {
+		if !bytes.Contains(line, []byte("-->")) {
+			continue
+		}
+		lines[i] = cueTimestampRegex.ReplaceAllFunc(line, func(timestamp []byte) []byte {
+			return shiftTimestamp(timestamp, offset)
+		})
+	}
+	return bytes.Join(lines, nil)
+}
+
+// shiftTimestamp adds offset to one timestamp matched by cueTimestampRegex.
+func shiftTimestamp(timestamp []byte, offset time.Duration) []byte {
+	parts := cueTimestampRegex.FindSubmatch(timestamp)
+	hours, _ := strconv.Atoi(string(parts[1]))
+	minutes, _ := strconv.Atoi(string(parts[2]))
+	seconds, _ := strconv.Atoi(string(parts[3]))
+	// A short fraction is read as a decimal one, so ",5" is 500 milliseconds
+	millis, _ := strconv.Atoi((string(parts[5]) + "00")[:3])
+
+	at := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond + offset
+	at = max(at, 0)
+
+	hours = int(at / time.Hour)
+	minutes = int(at % time.Hour / time.Minute)
+	seconds = int(at % time.Minute / time.Second)
+	millis = int(at % time.Second / time.Millisecond)
+	separator := string(parts[4])
+	if len(parts[1]) == 0 && hours == 0 {
+		return fmt.Appendf(nil, "%02d:%02d%s%03d", minutes, seconds, separator, millis)
+	}
+	// Hours keep their original width, and at least two digits
+	return fmt.Appendf(nil, "%0*d:%02d:%02d%s%03d", max(len(parts[1]), 2), hours, minutes, seconds, separator, millis)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+func TestShiftSubtitleTimings_SRT(t *testing.T) {
+	t.Parallel()
+	content := "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n\r\n" +
+		"2\r\n00:00:59,750 --> 00:01:00,999 X1:10 X2:20\r\nThe 00:00:05,000 in the text stays\r\n\r\n" +
+		"3\r\n00:59:59,600 --> 01:00:00,100\r\nLast\r\n"
+	want := "1\r\n00:00:01,450 --> 00:00:02,950\r\nHello\r\n\r\n" +
+		"2\r\n00:01:00,200 --> 00:01:01,449 X1:10 X2:20\r\nThe 00:00:05,000 in the text stays\r\n\r\n" +
+		"3\r\n01:00:00,050 --> 01:00:00,550\r\nLast\r\n"
+
+	if got := string(ShiftSubtitleTimings([]byte(content), 450*time.Millisecond)); got != want {
+		t.Errorf("ShiftSubtitleTimings() =\n%q\nexpected\n%q", got, want)
+	}
+}
+
+func TestShiftSubtitleTimings_NegativeClampsAtZero(t *testing.T) {
+	t.Parallel()
+	content := "1\n00:00:01,200 --> 00:00:03,000\nHello\n\n2\n00:01:00,000 --> 00:01:02,000\nWorld\n"
+	want := "1\n00:00:00,000 --> 00:00:01,000\nHello\n\n2\n00:00:58,000 --> 00:01:00,000\nWorld\n"
+
+	if got := string(ShiftSubtitleTimings([]byte(content), -2*time.Second)); got != want {
+		t.Errorf("ShiftSubtitleTimings() =\n%q\nexpected\n%q", got, want)
+	}
+}
+
+func TestShiftSubtitleTimings_VTT(t *testing.T) {
+	t.Parallel()
+	content := "WEBVTT\n\nintro\n59:58.500 --> 59:59.900 align:start\nHello\n\n01:02:03.004 --> 01:02:04.000\nWorld\n"
+	// Timestamps without hours gain them once they pass one hour
+	want := "WEBVTT\n\nintro\n59:59.700 --> 01:00:01.100 align:start\nHello\n\n01:02:04.204 --> 01:02:05.200\nWorld\n"
+
+	if got := string(ShiftSubtitleTimings([]byte(content), 1200*time.Millisecond)); got != want {
+		t.Errorf("ShiftSubtitleTimings() =\n%q\nexpected\n%q", got, want)
+	}
+}
+
+func TestApplyTimeOffset(t *testing.T) {
+	t.Parallel()
+	srt := "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+	shifted := "1\n00:00:02,000 --> 00:00:03,000\nHello\n"
+	ass := "[Events]\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hello --> there\n"
+	tests := []struct {
+		name        string
+		content     string
+		contentType string
+		opts        models.DownloadOptions
+		want        string
+	}{
+		{name: "srt", content: srt, contentType: "application/x-subrip", opts: models.DownloadOptions{TimeOffset: time.Second}, want: shifted},
+		{name: "srt sniffed from a generic type", content: srt, contentType: "text/plain; charset=utf-8", opts: models.DownloadOptions{TimeOffset: time.Second}, want: shifted},
+		{name: "no offset", content: srt, contentType: "application/x-subrip", want: srt},
+		{name: "raw", content: srt, contentType: "application/x-subrip", opts: models.DownloadOptions{TimeOffset: time.Second, Raw: true}, want: srt},
+		{name: "ass unchanged", content: ass, contentType: "application/x-ass", opts: models.DownloadOptions{TimeOffset: time.Second}, want: ass},
+		{name: "archive unchanged", content: srt, contentType: "application/zip", opts: models.DownloadOptions{TimeOffset: time.Second}, want: srt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := string(applyTimeOffset([]byte(tt.content), tt.contentType, tt.opts)); got != tt.want {
+				t.Errorf("applyTimeOffset() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/archive"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+var (
+	// srtTimingRegex matches an SRT timing line such as "00:01:02,345 --> 00:01:04,000"; some
+	// files use a dot for the milliseconds, and some add coordinates after the end time.
+	srtTimingRegex = regexp.MustCompile(`^(\d{1,3}):([0-5]\d):([0-5]\d)[,.]\d{1,3}\s*-->\s*(\d{1,3}):([0-5]\d):([0-5]\d)[,.]\d{1,3}(?:\s.*)?$`)
+	// vttTimingRegex matches a WebVTT timing line such as "01:02.345 --> 01:04.000 align:start",
+	// where the hours are optional.
+	vttTimingRegex = regexp.MustCompile(`^(?:\d{2,}:)?[0-5]\d:[0-5]\d\.\d{3}[ \t]+-->[ \t]+(?:\d{2,}:)?[0-5]\d:[0-5]\d\.\d{3}(?:[ \t].*)?$`)
+)
+
+// applyValidation runs CheckSubtitleStructure on content when opts.Validate is set.
+func applyValidation(content []byte, contentType string, opts models.DownloadOptions) error {
+	if !opts.Validate {
+		return nil
+	}
+	return CheckSubtitleStructure(content, contentType)
+}
+
+// CheckSubtitleStructure reports an *apperrors.ErrMalformedSubtitle when an SRT or WebVTT file
+// is clearly broken: a cue whose number does not follow the previous one (SRT), a timing line
+// that does not parse, a cue without text, or no cue at all. Files are recognized by content
+// type or, for generic types such as text/plain, by their first cue; other content, including
+// ASS files and archives, is not checked.
+func CheckSubtitleStructure(content []byte, contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	if archive.IsGenericContentType(contentType) {
+		mediaType = archive.SniffSubtitleContentType(content)
+	}
+	switch mediaType {
+	case "application/x-subrip":
+		return checkSRTStructure(subtitleLines(content))
+	case "text/vtt", "text/webvtt":
+		return checkVTTStructure(subtitleLines(content))
+	default:
+		return nil
+	}
+}
+
+// checkSRTStructure checks numbered cues made of a number, a timing line and text. A block of
+// text without a timing line after a cue is taken as that cue's text continuing past a blank line.
+func checkSRTStructure(lines []string) error {
+	cues, previous := 0, 0
+	for start, end := range subtitleBlocks(lines) {
+		block := lines[start:end]
+		number, err := strconv.Atoi(strings.TrimSpace(block[0]))
+		if err != nil {
+			if cues > 0 && !hasTimingLine(block) {
+				continue
+			}
+			return malformedSubtitle("srt", start+1, fmt.Sprintf("expected a cue number, got %q", shortLine(block[0])))
+		}
+		if cues > 0 && number != previous+1 {
+			return malformedSubtitle("srt", start+1, fmt.Sprintf("cue %d follows cue %d", number, previous))
+		}
+		if len(block) < 2 {
+			return malformedSubtitle("srt", start+1, fmt.Sprintf("cue %d has no timing line", number))
+		}
+		if !srtTimingRegex.MatchString(strings.TrimSpace(block[1])) {
+			return malformedSubtitle("srt", start+2, fmt.Sprintf("unparseable timing line %q", shortLine(block[1])))
+		}
+		if len(block) < 3 {
+			return malformedSubtitle("srt", start+2, fmt.Sprintf("cue %d has no text", number))
+		}
+		cues, previous = cues+1, number
+	}
+	if cues == 0 {
+		return malformedSubtitle("srt", 0, "no cues")
+	}
+	return nil
+}
+
+// checkVTTStructure checks the WEBVTT header and cues made of an optional identifier, a timing
+// line and text. NOTE, STYLE and REGION blocks are skipped.
+func checkVTTStructure(lines []string) error {
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "WEBVTT") || (len(lines[0]) > 6 && !strings.ContainsRune(" \t", rune(lines[0][6]))) {
+		return malformedSubtitle("vtt", 1, "missing WEBVTT header")
+	}
+	cues := 0
+	first := true
+	for start, end := range subtitleBlocks(lines) {
+		if first {
+			// The header block holds WEBVTT and its optional metadata lines
+			first = false
+			continue
+		}
+		block := lines[start:end]
+		if keyword, _, _ := strings.Cut(block[0], " "); keyword == "NOTE" || keyword == "STYLE" || keyword == "REGION" {
+			continue
+		}
+		timing := 0
+		if !strings.Contains(block[0], "-->") {
+			timing = 1 // Cue identifier first
+		}
+		if timing >= len(block) {
+			return malformedSubtitle("vtt", start+1, fmt.Sprintf("expected a cue timing line, got %q", shortLine(block[0])))
+		}
+		if !vttTimingRegex.MatchString(strings.TrimSpace(block[timing])) {
+			return malformedSubtitle("vtt", start+timing+1, fmt.Sprintf("unparseable timing line %q", shortLine(block[timing])))
+		}
+		if timing+1 >= len(block) {
+			return malformedSubtitle("vtt", start+timing+1, "cue has no text")
+		}
+		cues++
+	}
+	if cues == 0 {
+		return malformedSubtitle("vtt", 0, "no cues")
+	}
+	return nil
+}
+
+// subtitleLines splits content into lines, without a leading UTF-8 byte order mark and with
+// CRLF and CR line endings treated as LF.
+func subtitleLines(content []byte) []string {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	text := strings.ReplaceAll(strings.ReplaceAll(string(content), "\r\n", "\n"), "\r", "\n")
+	return strings.Split(text, "\n")
+}
+
+// subtitleBlocks yields the start and end line indexes of each run of non-blank lines.
+func subtitleBlocks(lines []string) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i := 0; i < len(lines); {
+			if strings.TrimSpace(lines[i]) == "" {
+				i++
+				continue
+			}
+			start := i
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				i++
+			}
+			if !yield(start, i) {
+				return
+			}
+		}
+	}
+}
+
+// hasTimingLine reports whether any line of block looks like a cue timing line.
+func hasTimingLine(block []string) bool {
+	for _, line := range block {
+		if strings.Contains(line, "-->") {
+			return true
+		}
+	}
+	return false
+}
+
+// shortLine trims line and cuts it to 40 characters for an error message.
+func shortLine(line string) string {
+	line = strings.TrimSpace(line)
+	if runes := []rune(line); len(runes) > 40 {
+		return string(runes[:40]) + "…"
+	}
+	return line
+}
+
+func malformedSubtitle(format string, line int, reason string) error {
+	return &apperrors.ErrMalformedSubtitle{Format: format, Line: line, Reason: reason}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+)
+
+func TestCheckSubtitleStructure(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		content     string
+		contentType string
+		wantLine    int    // 0 with an empty wantReason means valid
+		wantReason  string // Substring of the reason
+	}{
+		{
+			name:        "valid srt",
+			content:     "\xEF\xBB\xBF1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000 X1:10 X2:20\r\nSecond line\r\nwith two lines\r\n\r\nand a blank line inside\r\n",
+			contentType: "application/x-subrip",
+		},
+		{
+			name:        "malformed srt timecode",
+			content:     "1\n00:00:01,000 --> 00:00:02,500\nHello\n\n2\n00:00:0x,000 -> 00:00:04,000\nWorld\n",
+			contentType: "application/x-subrip",
+			wantLine:    6,
+			wantReason:  "unparseable timing line",
+		},
+		{
+			name:        "empty srt",
+			content:     "",
+			contentType: "application/x-subrip",
+			wantReason:  "no cues",
+		},
+		{
+			name:        "srt cue numbers skip",
+			content:     "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n3\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			contentType: "application/x-subrip",
+			wantLine:    5,
+			wantReason:  "cue 3 follows cue 1",
+		},
+		{
+			name:        "truncated srt",
+			content:     "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\n",
+			contentType: "application/x-subrip",
+			wantLine:    6,
+			wantReason:  "cue 2 has no text",
+		},
+		{
+			name:        "srt sniffed from a generic type",
+			content:     "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n7\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			contentType: "text/plain; charset=utf-8",
+			wantLine:    5,
+			wantReason:  "cue 7 follows cue 1",
+		},
+		{
+			name:        "valid vtt",
+			content:     "WEBVTT - Dark\nKind: captions\n\nNOTE translated by\nthe team\n\nintro\n00:01.000 --> 00:02.000 align:start\nHello\n\n01:00:03.000 --> 01:00:04.000\nWorld\n",
+			contentType: "text/vtt",
+		},
+		{
+			name:        "vtt without header",
+			content:     "00:01.000 --> 00:02.000\nHello\n",
+			contentType: "text/vtt",
+			wantLine:    1,
+			wantReason:  "missing WEBVTT header",
+		},
+		{
+			name:        "malformed vtt timecode",
+			content:     "WEBVTT\n\n00:01,000 --> 00:02,000\nHello\n",
+			contentType: "text/vtt",
+			wantLine:    3,
+			wantReason:  "unparseable timing line",
+		},
+		{
+			name:        "ass is not checked",
+			content:     "[Script Info]\nTitle: broken",
+			contentType: "application/x-ass",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := CheckSubtitleStructure([]byte(tt.content), tt.contentType)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("Expected a valid file, got %v", err)
+				}
+				return
+			}
+			var malformed *apperrors.ErrMalformedSubtitle
+			if !errors.As(err, &malformed) {
+				t.Fatalf("Expected ErrMalformedSubtitle, got %v", err)
+			}
+			if malformed.Line != tt.wantLine || !strings.Contains(malformed.Reason, tt.wantReason) {
+				t.Errorf("Expected line %d with %q, got line %d with %q", tt.wantLine, tt.wantReason, malformed.Line, malformed.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"strings"
+)
+
+// UploaderFilter keeps or drops subtitles by uploader name. Names are compared
+// case-insensitively and must match exactly. A nil filter keeps every subtitle.
+type UploaderFilter struct {
+	blocked map[string]struct{}
+	allowed map[string]struct{}
+}
+
+// NewUploaderFilter builds a filter dropping the blocked uploaders and, when allowed is
+// not empty, every uploader missing from it. Blank names are ignored. It returns nil when
+// both lists are empty, so callers can skip filtering entirely.
+func NewUploaderFilter(blocked, allowed []string) *UploaderFilter {
+	filter := &UploaderFilter{
+		blocked: uploaderSet(blocked),
+		allowed: uploaderSet(allowed),
+	}
+	if len(filter.blocked) == 0 && len(filter.allowed) == 0 {
+		return nil
+	}
+	return filter
+}
+
+// Allows reports whether subtitles uploaded by uploader are kept.
+func (f *UploaderFilter) Allows(uploader string) bool {
+	if f == nil {
+		return true
+	}
+	key := normalizeUploader(uploader)
+	if _, blocked := f.blocked[key]; blocked {
+		return false
+	}
+	if len(f.allowed) == 0 {
+		return true
+	}
+	_, allowed := f.allowed[key]
+	return allowed
+}
+
+func uploaderSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if key := normalizeUploader(name); key != "" {
+			set[key] = struct{}{}
+		}
+	}
+	return set
+}
+
+func normalizeUploader(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
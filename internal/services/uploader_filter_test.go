@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestUploaderFilter_Allows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		blocked  []string
+		allowed  []string
+		uploader string
+		want     bool
+	}{
+		{name: "no lists", uploader: "gricsi", want: true},
+		{name: "blocked", blocked: []string{"AutoSub"}, uploader: "autosub", want: false},
+		{name: "blocked with spaces", blocked: []string{" AutoSub "}, uploader: "AUTOSUB", want: false},
+		{name: "not blocked", blocked: []string{"AutoSub"}, uploader: "gricsi", want: true},
+		{name: "blocked is exact", blocked: []string{"Auto"}, uploader: "AutoSub", want: true},
+		{name: "allowed", allowed: []string{"Gricsi"}, uploader: "gricsi", want: true},
+		{name: "not allowed", allowed: []string{"Gricsi"}, uploader: "Anonymus", want: false},
+		{name: "blocked wins over allowed", blocked: []string{"gricsi"}, allowed: []string{"gricsi"}, uploader: "gricsi", want: false},
+		{name: "blank names ignored", blocked: []string{""}, allowed: []string{"  "}, uploader: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filter := NewUploaderFilter(tt.blocked, tt.allowed)
+			if got := filter.Allows(tt.uploader); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.uploader, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUploaderFilter_EmptyListsReturnNil(t *testing.T) {
+	t.Parallel()
+	if filter := NewUploaderFilter(nil, []string{" "}); filter != nil {
+		t.Errorf("Expected a nil filter without any names, got %+v", filter)
+	}
+}
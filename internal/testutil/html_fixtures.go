@@ -29,6 +29,7 @@ type SubtitleRowOptions struct {
 	EredetiTitle       string
 	Uploader           string
 	UploaderBold       bool
+	UploaderHref       string // When non-empty, wraps the uploader in a profile link (e.g. "index.php?felt=Name")
 	UploadDate         string
 	DownloadAction     string
 	DownloadFilename   string
@@ -49,6 +50,12 @@ type ShowRowOptions struct {
 	IncludeImage    *bool
 	IncludeName     *bool
 	YearHeaderLabel string
+	// YearHeaderColspan overrides the colspan of the year header emitted before this show
+	// (default 10). Only used by GenerateShowTableHTMLMultiColumn.
+	YearHeaderColspan int
+	// LinkName wraps the show name in its own index.php?sid= link. Only used by
+	// GenerateShowTableHTMLMultiColumn.
+	LinkName bool
 }
 
 // GenerateSubtitleTableHTML generates a proper HTML table structure for subtitle listings
@@ -109,6 +116,9 @@ func GenerateSubtitleTableHTML(rows []SubtitleRowOptions) string {
 		if row.UploaderBold {
 			uploaderTag = fmt.Sprintf("<b>%s</b>", row.Uploader)
 		}
+		if row.UploaderHref != "" {
+			uploaderTag = fmt.Sprintf(`<a href="%s">%s</a>`, row.UploaderHref, uploaderTag)
+		}
 
 		statusDiv := ""
 		if row.Status != "" {
@@ -179,6 +189,72 @@ func GenerateSubtitleTableHTML(rows []SubtitleRowOptions) string {
 	return sb.String()
 }
 
+// GenerateMovieSubtitleTableHTML generates the 5-column subtitle listing of the movie pages,
+// which has no category column linking to a show. ShowID and Status are ignored; movie titles
+// go in EredetiTitle as "Title (2023) (release)".
+func GenerateMovieSubtitleTableHTML(rows []SubtitleRowOptions) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<html>
+<body>
+<table width="100%" align="center" border="0" cellspacing="0" cellpadding="5" class="result">
+	<thead>
+		<tr height="30">
+			<th width="35px">Nyelv</th>
+			<th width="50%">
+				<div style="float:left; margin-left:70px;">Magyar cím</div>
+				<div style="float:right; margin-right:70px;">Külföldi cím</div>
+			</th>
+			<th style="text-align: center;">Feltöltő</th>
+			<th width="65px" nowrap="">Idő</th>
+			<th width="35px">Letöltés</th>
+		</tr>
+	</thead>
+	<tbody>
+`)
+
+	for i, row := range rows {
+		if row.Language == "" {
+			row.Language = "Magyar"
+		}
+		if row.SubtitleID == 0 {
+			row.SubtitleID = 1737439811 + i
+		}
+
+		downloadHref := fmt.Sprintf("/index.php?action=%s&fnev=%s&felirat=%d", row.DownloadAction, row.DownloadFilename, row.SubtitleID)
+		if row.CustomDownloadHref != "" {
+			downloadHref = row.CustomDownloadHref
+		}
+
+		fmt.Fprintf(&sb, `
+		<tr id="vilagit">
+			<td align="center" class="lang" onclick="adatlapnyitas('a_%d')">%s</td>
+			<td align="left" onclick="adatlapnyitas('a_%d')">
+					<div class="magyar">%s</div>
+					<div class="eredeti">%s</div>
+			</td>
+			<td align="center" onclick="adatlapnyitas('a_%d')">%s</td>
+			<td align="center" onclick="adatlapnyitas('a_%d')">%s</td>
+			<td align="center">
+				<a href="%s"><img src="img/download.png" border="0" alt="Letöltés" width="20"></a>
+			</td>
+		</tr>`,
+			row.SubtitleID, row.Language,
+			row.SubtitleID, row.MagyarTitle, row.EredetiTitle,
+			row.SubtitleID, row.Uploader,
+			row.SubtitleID, row.UploadDate,
+			html.EscapeString(downloadHref),
+		)
+	}
+
+	sb.WriteString(`	</tbody>
+</table>
+</body>
+</html>`)
+
+	return sb.String()
+}
+
 // GenerateSubtitleTableHTMLWithPagination generates HTML with pagination elements included
 // This avoids brittle string manipulation by building the complete HTML structure
 func GenerateSubtitleTableHTMLWithPagination(rows []SubtitleRowOptions, currentPage, totalPages int, useOldalParam bool) string {
@@ -237,6 +313,9 @@ func GenerateSubtitleTableHTMLWithPagination(rows []SubtitleRowOptions, currentP
 		if row.UploaderBold {
 			uploaderTag = fmt.Sprintf("<b>%s</b>", row.Uploader)
 		}
+		if row.UploaderHref != "" {
+			uploaderTag = fmt.Sprintf(`<a href="%s">%s</a>`, row.UploaderHref, uploaderTag)
+		}
 
 		statusDiv := ""
 		if row.Status != "" {
@@ -402,7 +481,9 @@ func GenerateShowTableHTML(shows []ShowRowOptions) string {
 
 // GenerateShowTableHTMLMultiColumn generates HTML with multiple shows per row
 // This matches the actual website structure for special show listing pages
-// where shows are displayed in a grid layout (typically 2 columns)
+// where shows are displayed in a grid layout (typically 2 columns).
+// A year change always starts a new row after a full-width year header, so a
+// row may hold fewer than columnsPerRow shows.
 func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int) string {
 	if columnsPerRow < 1 {
 		columnsPerRow = 2 // Default to 2 columns
@@ -419,7 +500,13 @@ func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int)
 	currentYear := 0
 	rowIndex := 0
 
-	for i := 0; i < len(shows); i += columnsPerRow {
+	for i := 0; i < len(shows); {
+		// Shows for this row: up to columnsPerRow, stopping early at a year change
+		rowEnd := i + 1
+		for rowEnd < len(shows) && rowEnd-i < columnsPerRow && shows[rowEnd].Year == shows[i].Year {
+			rowEnd++
+		}
+
 		// Check if we need a year header
 		if shows[i].Year != currentYear {
 			currentYear = shows[i].Year
@@ -427,12 +514,16 @@ func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int)
 			if shows[i].YearHeaderLabel != "" {
 				yearLabel = shows[i].YearHeaderLabel
 			}
+			colspan := 10
+			if shows[i].YearHeaderColspan > 0 {
+				colspan = shows[i].YearHeaderColspan
+			}
 			fmt.Fprintf(&sb, `
 		<tr>
-			<td colspan="10" style="text-align: center; background-color: #DDDDDD; font-size: 12pt; color:#0000CC; border-top: 2px solid #9B9B9B;">
+			<td colspan="%d" style="text-align: center; background-color: #DDDDDD; font-size: 12pt; color:#0000CC; border-top: 2px solid #9B9B9B;">
 				%s
 			</td>
-		</tr>`, yearLabel)
+		</tr>`, colspan, yearLabel)
 		}
 
 		// Determine row background color
@@ -448,9 +539,8 @@ func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int)
 		fmt.Fprintf(&sb, `
 		<tr style="background-color: %s">`, bgColor)
 
-		// Add shows for this row (up to columnsPerRow)
-		for j := 0; j < columnsPerRow && i+j < len(shows); j++ {
-			show := shows[i+j]
+		// Add shows for this row
+		for _, show := range shows[i:rowEnd] {
 
 			includeImage := true
 			if show.IncludeImage != nil {
@@ -474,6 +564,10 @@ func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int)
 
 			nameHTML := fmt.Sprintf(`<div>%s</div>
 				<div class="sev"></div>`, show.ShowName)
+			if show.LinkName {
+				nameHTML = fmt.Sprintf(`<div><a href="index.php?sid=%d">%s</a></div>
+				<div class="sev"></div>`, show.ShowID, show.ShowName)
+			}
 			if !includeName {
 				nameHTML = `<div class="sev"></div>`
 			}
@@ -490,6 +584,7 @@ func GenerateShowTableHTMLMultiColumn(shows []ShowRowOptions, columnsPerRow int)
 		sb.WriteString(`
 		</tr>`)
 		rowIndex++
+		i = rowEnd
 	}
 
 	sb.WriteString(`	</tbody>
@@ -608,17 +703,78 @@ func GenerateInvalidThirdPartyHTML() string {
 	return GenerateHTMLWithBody(`<div>Invalid structure</div>`)
 }
 
+// GenerateCaptchaPageHTML returns the kind of captcha page served with HTTP 200 to a throttled
+// or banned client in place of the requested listing.
+func GenerateCaptchaPageHTML() string {
+	return `<html>
+<head><title>Ellenőrzés</title><script src="https://www.google.com/recaptcha/api.js" async defer></script></head>
+<body>
+<p>Túl sok kérés érkezett erről az IP-címről. Kérjük, igazolja, hogy nem robot.</p>
+<form method="post" action="/captcha.php">
+	<div class="g-recaptcha" data-sitekey="6Lc_test"></div>
+	<input type="submit" value="Tovább">
+</form>
+</body>
+</html>`
+}
+
+// GenerateMaintenancePageHTML returns the kind of maintenance page served with HTTP 200 while
+// the site is down, in place of the requested listing.
+func GenerateMaintenancePageHTML() string {
+	return `<html>
+<head><title>Feliratok.eu - Karbantartás</title><meta charset="utf-8"></head>
+<body>
+<div style="text-align: center; margin-top: 100px;">
+	<img src="/img/logo.png" alt="Feliratok.eu">
+	<h1>Karbantartás</h1>
+	<p>Az oldal karbantartás miatt átmenetileg nem elérhető. Kérjük, nézz vissza később!</p>
+</div>
+</body>
+</html>`
+}
+
+// GenerateLoginPageHTML returns a login form served in place of the requested listing.
+func GenerateLoginPageHTML() string {
+	return GenerateHTMLWithBody(`<form method="post" action="/index.php?tab=belepes"><input type="text" name="nev"><input type="password" name="jelszo"><input type="submit" value="Belépés"></form>`)
+}
+
 // GenerateHTMLWithBody wraps custom body content in a standard HTML shell.
 func GenerateHTMLWithBody(bodyHTML string) string {
 	return `<html><body>` + bodyHTML + `</body></html>`
 }
 
+// SubtitleDetailsOptions contains options for generating a subtitle detail page
+type SubtitleDetailsOptions struct {
+	Filename string
+	Uploader string
+	Comment  string // Written as raw HTML into span.megjegyzes, so "<br>" line breaks are kept
+	IMDBID   string
+	TVDBID   int
+	TVMazeID int
+	TraktID  int
+	Year     int    // Show air year (Év:) row, omitted when zero
+	Status   string // Show status (Állapot:) row such as "Fut" or "Befejezett", omitted when empty
+}
+
 // GenerateThirdPartyIDHTML generates a proper HTML structure for third-party ID details page
 // based on the real feliratok.eu episode detail page structure
 func GenerateThirdPartyIDHTML(imdbID string, tvdbID, tvmazeID, traktID int) string {
+	return GenerateSubtitleDetailsHTML(SubtitleDetailsOptions{
+		Filename: "Show.S01E01.srt",
+		Uploader: "TestUser",
+		IMDBID:   imdbID,
+		TVDBID:   tvdbID,
+		TVMazeID: tvmazeID,
+		TraktID:  traktID,
+	})
+}
+
+// GenerateSubtitleDetailsHTML generates the detail page (adatlap) of a single subtitle
+// based on the real feliratok.eu structure, with filename, uploader, comment and third-party links
+func GenerateSubtitleDetailsHTML(opts SubtitleDetailsOptions) string {
 	var sb strings.Builder
 
-	sb.WriteString(`<html>
+	fmt.Fprintf(&sb, `<html>
 <body>
 	<div class="adatlapTabla">
 		<div class="adatlapKep">
@@ -627,34 +783,51 @@ func GenerateThirdPartyIDHTML(imdbID string, tvdbID, tvmazeID, traktID int) stri
 		<div class="adatlapAdat">
 			<div class="adatlapRow">
 				<span>Fájlnév:</span>
-				<span>Show.S01E01.srt</span>
+				<span>%s</span>
 			</div>
 			<div class="adatlapRow">
 				<span>Feltöltő:</span>
-				<span>TestUser</span>
+				<span>%s</span>
 			</div>
 			<div class="adatlapRow paddingb5">
 				<span>Megjegyzés:</span>
-				<span class="megjegyzes"></span>
+				<span class="megjegyzes">%s</span>
 			</div>
-			<div class="adatlapRow">
+`, html.EscapeString(opts.Filename), html.EscapeString(opts.Uploader), opts.Comment)
+
+	if opts.Year != 0 {
+		fmt.Fprintf(&sb, `			<div class="adatlapRow">
+				<span>Év:</span>
+				<span>%d</span>
+			</div>
+`, opts.Year)
+	}
+	if opts.Status != "" {
+		fmt.Fprintf(&sb, `			<div class="adatlapRow">
+				<span>Állapot:</span>
+				<span>%s</span>
+			</div>
+`, html.EscapeString(opts.Status))
+	}
+
+	sb.WriteString(`			<div class="adatlapRow">
 `)
 
-	if imdbID != "" {
+	if opts.IMDBID != "" {
 		fmt.Fprintf(&sb, `				<a href="http://www.imdb.com/title/%s/" target="_blank" alt="iMDB" ><img src="img/adatlap/imdb.png" alt="iMDB" /></a><input type="hidden" id="imdb_adatlap" value="%s" />
-`, imdbID, imdbID)
+`, opts.IMDBID, opts.IMDBID)
 	}
-	if tvdbID != 0 {
+	if opts.TVDBID != 0 {
 		fmt.Fprintf(&sb, `				<a href="http://thetvdb.com/?tab=series&id=%d" target="_blank" alt="TheTVDB"><img src="img/adatlap/tvdb.png" alt="TheTVDB"/></a>
-`, tvdbID)
+`, opts.TVDBID)
 	}
-	if tvmazeID != 0 {
+	if opts.TVMazeID != 0 {
 		fmt.Fprintf(&sb, `				<a href="http://www.tvmaze.com/shows/%d" target="_blank" alt="TVMaze"><img src="img/adatlap/tvmaze.png" alt="TVMaze"/></a>
-`, tvmazeID)
+`, opts.TVMazeID)
 	}
-	if traktID != 0 {
+	if opts.TraktID != 0 {
 		fmt.Fprintf(&sb, `				<a href="http://trakt.tv/search/tvdb?utf8=%%E2%%9C%%93&query=%d" target="_blank" alt="trakt" ><img src="img/adatlap/trakt.png?v=20250411" alt="trakt" /></a>
-`, traktID)
+`, opts.TraktID)
 	}
 
 	sb.WriteString(`			</div>
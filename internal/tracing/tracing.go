@@ -0,0 +1,90 @@
+// Package tracing configures OpenTelemetry tracing and provides the helpers used to create
+// spans. Spans are created through the global tracer provider, so they cost next to nothing
+// until Setup installs an exporting provider.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName identifies the spans created by this module.
+	instrumentationName = "github.com/Belphemur/SuperSubtitles/v2"
+	// serviceName is reported as the service.name resource attribute.
+	serviceName = "supersubtitles"
+	// defaultSampleRatio is the fraction of root traces sampled when SampleRatio is zero.
+	defaultSampleRatio = 1.0
+)
+
+// Config controls optional trace export over OTLP/gRPC.
+type Config struct {
+	Enabled     bool
+	Endpoint    string  // Collector address such as "localhost:4317"
+	Insecure    bool    // Connect to the collector without TLS
+	SampleRatio float64 // Fraction of root traces sampled, between 0 and 1 (0 uses default of 1)
+	Version     string  // Reported as the service.version resource attribute
+}
+
+// Setup installs a tracer provider exporting to cfg.Endpoint as the global provider, along
+// with W3C trace context propagation. The returned function flushes queued spans and stops
+// the exporter. When tracing is disabled, nothing is installed and the function does nothing.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if strings.TrimSpace(cfg.Endpoint) == "" {
+		return nil, errors.New("tracing endpoint is required when tracing is enabled")
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 {
+		ratio = defaultSampleRatio
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(cfg.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End marks span as failed when err is not nil, then ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
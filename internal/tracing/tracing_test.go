@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	t.Parallel()
+	shutdown, err := Setup(context.Background(), Config{Endpoint: "localhost:4317"})
+	if err != nil {
+		t.Fatalf("Expected no error when tracing is disabled, got: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected a no-op shutdown, got: %v", err)
+	}
+}
+
+func TestSetup_RequiresEndpoint(t *testing.T) {
+	t.Parallel()
+	if _, err := Setup(context.Background(), Config{Enabled: true}); err == nil {
+		t.Fatal("Expected an error for tracing without an endpoint")
+	}
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	t.Parallel()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	_, failed := provider.Tracer("test").Start(context.Background(), "failed")
+	End(failed, errors.New("upstream returned 500"))
+	_, succeeded := provider.Tracer("test").Start(context.Background(), "succeeded")
+	End(succeeded, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 ended spans, got %d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error || spans[0].Status.Description != "upstream returned 500" || len(spans[0].Events) != 1 {
+		t.Errorf("Expected the failed span to carry the error, got %+v", spans[0].Status)
+	}
+	if spans[1].Status.Code != codes.Unset {
+		t.Errorf("Expected the successful span to keep an unset status, got %+v", spans[1].Status)
+	}
+}
@@ -0,0 +1,542 @@
+// Package client is a Go client for the SuperSubtitles gRPC API. It wraps the generated stubs
+// with methods that mirror the service's internal client: collections are returned as iterators,
+// downloads are reassembled from the chunked stream and checked against their SHA-256, and
+// results are converted back to the service's domain types.
+//
+// Errors are gRPC status errors; use status.Code to tell NotFound or InvalidArgument apart.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// downloadPreallocLimit caps how much of a download's announced size is allocated before any content arrives
+const downloadPreallocLimit = 32 << 20
+
+// Client calls a SuperSubtitles server. It is safe for concurrent use.
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.SuperSubtitlesServiceClient
+	opts    options
+}
+
+// New creates a client for the server at target, such as "localhost:8080". The connection is
+// established lazily on the first call.
+func New(target string, opts ...Option) (*Client, error) {
+	o := options{retryAttempts: defaultRetryAttempts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialOptions, err := o.buildDialOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dial options: %w", err)
+	}
+
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", target, err)
+	}
+
+	return &Client{conn: conn, service: pb.NewSuperSubtitlesServiceClient(conn), opts: o}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// callContext applies the configured timeout to ctx unless it already has a deadline
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || c.opts.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.opts.timeout)
+}
+
+// streamSeq opens a server stream and yields its messages converted by convert. Stopping the
+// iteration early cancels the stream. An error ends the iteration after being yielded once.
+func streamSeq[M, T any](ctx context.Context, open func(ctx context.Context) (grpc.ServerStreamingClient[M], error), convert func(*M) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var zero T
+		stream, err := open(ctx)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for {
+			msg, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(convert(msg), nil) {
+				return
+			}
+		}
+	}
+}
+
+// ListShows returns every show listed on the site.
+func (c *Client) ListShows(ctx context.Context) ([]Show, error) {
+	var shows []Show
+	for show, err := range streamSeq(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.Show], error) {
+		return c.service.GetShowList(ctx, &pb.GetShowListRequest{})
+	}, showFromProto) {
+		if err != nil {
+			return nil, err
+		}
+		shows = append(shows, show)
+	}
+	return shows, nil
+}
+
+// ShowsPage returns one page of the show list, ordered by year then name, along with the token
+// for the next page. Pass an empty pageToken for the first page; an empty next token means the
+// list is exhausted. A pageSize of 0 uses the server default.
+func (c *Client) ShowsPage(ctx context.Context, pageSize int, pageToken string) ([]Show, string, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.ListShows(ctx, &pb.ListShowsRequest{PageSize: int32(pageSize), PageToken: pageToken})
+	if err != nil {
+		return nil, "", err
+	}
+	shows := make([]Show, 0, len(resp.Shows))
+	for _, show := range resp.Shows {
+		shows = append(shows, showFromProto(show))
+	}
+	return shows, resp.NextPageToken, nil
+}
+
+// SubtitlesForShow yields the subtitles of a show as the server streams them, newest upload
+// first within each listing page.
+func (c *Client) SubtitlesForShow(ctx context.Context, showID int) iter.Seq2[Subtitle, error] {
+	return c.SubtitlesForShowInOrder(ctx, showID, SubtitleOrderUploadTimeDesc)
+}
+
+// SubtitlesForShowInOrder yields the subtitles of a show with each listing page sorted by
+// order. Pages arrive in listing order, so only the subtitles of one page are sorted together.
+func (c *Client) SubtitlesForShowInOrder(ctx context.Context, showID int, order SubtitleOrder) iter.Seq2[Subtitle, error] {
+	return streamSeq(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.Subtitle], error) {
+		return c.service.GetSubtitles(ctx, &pb.GetSubtitlesRequest{ShowId: int64(showID), OrderBy: subtitleOrderToProto(order)})
+	}, subtitleFromProto)
+}
+
+// SubtitlesForMovie yields the subtitles of a film as the server streams them. Films have no
+// season or episode, so both are -1.
+func (c *Client) SubtitlesForMovie(ctx context.Context, movieID int) iter.Seq2[Subtitle, error] {
+	return streamSeq(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.Subtitle], error) {
+		return c.service.GetMovieSubtitles(ctx, &pb.GetMovieSubtitlesRequest{MovieId: int64(movieID)})
+	}, subtitleFromProto)
+}
+
+// ShowSubtitles yields each show with its third-party IDs and subtitles. Subtitles in
+// preferredLanguages are sorted to the front of each show's collection, in that order.
+func (c *Client) ShowSubtitles(ctx context.Context, shows []Show, preferredLanguages ...string) iter.Seq2[ShowSubtitles, error] {
+	pbShows := make([]*pb.Show, len(shows))
+	for i, show := range shows {
+		pbShows[i] = showToProto(show)
+	}
+	return streamSeq(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.ShowSubtitlesCollection], error) {
+		return c.service.GetShowSubtitles(ctx, &pb.GetShowSubtitlesRequest{Shows: pbShows, PreferredLanguages: preferredLanguages})
+	}, showSubtitlesFromProto)
+}
+
+// RecentSubtitles yields the shows with subtitles uploaded after sinceID.
+func (c *Client) RecentSubtitles(ctx context.Context, sinceID int) iter.Seq2[ShowSubtitles, error] {
+	return streamSeq(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.ShowSubtitlesCollection], error) {
+		return c.service.GetRecentSubtitles(ctx, &pb.GetRecentSubtitlesRequest{SinceId: int64(sinceID)})
+	}, showSubtitlesFromProto)
+}
+
+// LatestSubtitleID returns the newest subtitle ID on the site, or 0 when the listing is empty.
+func (c *Client) LatestSubtitleID(ctx context.Context) (int, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetLatestSubtitleId(ctx, &pb.GetLatestSubtitleIdRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.SubtitleId), nil
+}
+
+// FindSubtitle returns the subtitles for one episode in a language (empty matches every language),
+// episode subtitles first, then season packs covering the episode.
+func (c *Client) FindSubtitle(ctx context.Context, showID, season, episode int, language string) ([]Subtitle, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.FindSubtitle(ctx, &pb.FindSubtitleRequest{
+		ShowId:   int64(showID),
+		Season:   int32(season),
+		Episode:  int32(episode),
+		Language: language,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subtitlesFromProto(resp.Subtitles), nil
+}
+
+// FindShow returns the show whose name or alias matches name, ignoring case and diacritics.
+// A non-nil year tells apart shows sharing a name.
+func (c *Client) FindShow(ctx context.Context, name string, year *int) (*Show, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	req := &pb.FindShowRequest{Name: name}
+	if year != nil {
+		req.Year = proto.Int32(int32(*year))
+	}
+	resp, err := c.service.FindShow(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	show := showFromProto(resp)
+	return &show, nil
+}
+
+// Subtitle returns one subtitle of a show by ID, such as one kept from an earlier listing.
+func (c *Client) Subtitle(ctx context.Context, showID, subtitleID int) (*Subtitle, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetSubtitle(ctx, &pb.GetSubtitleRequest{ShowId: int64(showID), SubtitleId: int64(subtitleID)})
+	if err != nil {
+		return nil, err
+	}
+	subtitle := subtitleFromProto(resp)
+	return &subtitle, nil
+}
+
+// SubtitleByID returns one subtitle by ID without its show, such as one from an old bookmark.
+// Subtitles older than the recent uploads are built from their detail page and filename, so
+// their release, qualities and upload date are empty.
+func (c *Client) SubtitleByID(ctx context.Context, subtitleID int) (*Subtitle, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetSubtitleById(ctx, &pb.GetSubtitleByIdRequest{SubtitleId: int64(subtitleID)})
+	if err != nil {
+		return nil, err
+	}
+	subtitle := subtitleFromProto(resp)
+	return &subtitle, nil
+}
+
+// ShowSeasons summarizes the seasons of a show that have subtitles.
+func (c *Client) ShowSeasons(ctx context.Context, showID int) (*ShowSeasons, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetShowSeasons(ctx, &pb.GetShowSeasonsRequest{ShowId: int64(showID)})
+	if err != nil {
+		return nil, err
+	}
+	return showSeasonsFromProto(resp), nil
+}
+
+// SubtitleDetails returns the detail page of a subtitle, including the uploader's comment.
+func (c *Client) SubtitleDetails(ctx context.Context, subtitleID int) (*SubtitleDetails, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetSubtitleDetails(ctx, &pb.GetSubtitleDetailsRequest{SubtitleId: int64(subtitleID)})
+	if err != nil {
+		return nil, err
+	}
+	return subtitleDetailsFromProto(resp), nil
+}
+
+// ShowImage returns the poster of a show, served from the server's image cache.
+func (c *Client) ShowImage(ctx context.Context, showID int) (*ShowImage, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetShowImage(ctx, &pb.GetShowImageRequest{ShowId: int64(showID)})
+	if err != nil {
+		return nil, err
+	}
+	return &ShowImage{Content: resp.Content, ContentType: resp.ContentType}, nil
+}
+
+// Languages lists the subtitle languages the server recognizes, ordered by ISO code.
+func (c *Client) Languages(ctx context.Context) ([]Language, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetLanguages(ctx, &pb.GetLanguagesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return languagesFromProto(resp), nil
+}
+
+// Status reports which upstream mirror the server sends requests to.
+func (c *Client) Status(ctx context.Context) (*UpstreamStatus, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetStatus(ctx, &pb.GetStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return upstreamStatusFromProto(resp), nil
+}
+
+// ServerInfo reports the server's version, API version, enabled optional features and limits.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return serverInfoFromProto(resp), nil
+}
+
+// SelfCheck asks the server to parse the listing of a known show and reports whether it still
+// yields plausible subtitles. A failed check is a result with OK false, not an error.
+func (c *Client) SelfCheck(ctx context.Context) (*SelfCheckResult, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.SelfCheck(ctx, &pb.SelfCheckRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return selfCheckFromProto(resp), nil
+}
+
+// CheckForUpdates counts the films and episodes added since contentID. The server reuses results
+// for a short time; forceRefresh asks it to check the site again.
+func (c *Client) CheckForUpdates(ctx context.Context, contentID int64, forceRefresh bool) (*UpdateCheckResult, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.CheckForUpdates(ctx, &pb.CheckForUpdatesRequest{ContentId: contentID, ForceRefresh: forceRefresh})
+	if err != nil {
+		return nil, err
+	}
+	return updateCheckFromProto(resp), nil
+}
+
+// InvalidateCache drops the server's cached archives for a subtitle. Returns true if one existed.
+func (c *Client) InvalidateCache(ctx context.Context, subtitleID string) (bool, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.InvalidateCache(ctx, &pb.InvalidateCacheRequest{SubtitleId: subtitleID})
+	if err != nil {
+		return false, err
+	}
+	return resp.Invalidated, nil
+}
+
+// ClearCache flushes the server's archive cache and returns the number of entries removed.
+func (c *Client) ClearCache(ctx context.Context) (int, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.ClearCache(ctx, &pb.ClearCacheRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.EntriesCleared), nil
+}
+
+// DownloadOption selects what Download returns
+type DownloadOption func(*pb.DownloadSubtitleRequest)
+
+// WithEpisode extracts one episode from a season pack.
+func WithEpisode(episode int) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.Episode = proto.Int32(int32(episode))
+	}
+}
+
+// WithEpisodeRange extracts episodes start through end from a season pack and returns them
+// as one ZIP archive. Episodes missing from the pack are left out.
+func WithEpisodeRange(start, end int) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.Episode = proto.Int32(int32(start))
+		req.EpisodeEnd = proto.Int32(int32(end))
+	}
+}
+
+// WithEpisodeTitle extracts the episode whose file name contains title from a season pack.
+// It is ignored when WithEpisode is also given.
+func WithEpisodeTitle(title string) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.EpisodeTitle = proto.String(title)
+	}
+}
+
+// WithSourceEncoding names the encoding of the subtitle file, such as "windows-1250",
+// instead of letting the server detect it.
+func WithSourceEncoding(encoding string) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.SourceEncoding = proto.String(encoding)
+	}
+}
+
+// WithMaxBytes makes the server refuse files larger than maxBytes with RESOURCE_EXHAUSTED.
+// It can only lower the server's own download limit.
+func WithMaxBytes(maxBytes int64) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.MaxBytes = proto.Int64(maxBytes)
+	}
+}
+
+// WithStripStyling makes the server rewrite an ASS or SSA file as plain dialogue with one default
+// style. Other formats are returned unchanged.
+func WithStripStyling() DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.StripStyling = true
+	}
+}
+
+// WithRaw makes the server return a text subtitle with the bytes it was uploaded with, skipping
+// the conversion to UTF-8. It cannot be combined with WithStripStyling or WithEpisodeRange.
+func WithRaw() DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.Raw = true
+	}
+}
+
+// WithTimeOffset makes the server shift every cue of an SRT or WebVTT file by offset, in whole
+// milliseconds; a negative offset moves cues earlier. Other formats are returned unchanged.
+func WithTimeOffset(offset time.Duration) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.TimeOffsetMs = int32(offset / time.Millisecond)
+	}
+}
+
+// WithKeepBOM makes the server keep a byte order mark at the start of a text subtitle, which it
+// removes by default.
+func WithKeepBOM() DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.KeepBom = true
+	}
+}
+
+// WithForceRefresh makes the server ask upstream even when it recently answered the subtitle
+// with not found, for a subtitle that may have been uploaded since.
+func WithForceRefresh() DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.ForceRefresh = true
+	}
+}
+
+// WithFilenameTemplate names an episode extracted from a season pack with a Go text/template,
+// overriding the server's download.filename_template. The template can use ShowName, Season,
+// Episode, Language, SourceFilename and Extension; DownloadResult.SourceFilename keeps the name
+// found in the archive.
+func WithFilenameTemplate(text string) DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.FilenameTemplate = &text
+	}
+}
+
+// WithValidate makes the server check the structure of an SRT or WebVTT subtitle and fail the
+// download with codes.DataLoss when the file is clearly malformed.
+func WithValidate() DownloadOption {
+	return func(req *pb.DownloadSubtitleRequest) {
+		req.Validate = true
+	}
+}
+
+// EstimateDownload reports the filename, content type and size of a subtitle download without
+// transferring its content, for checking the size of a season pack before downloading it.
+func (c *Client) EstimateDownload(ctx context.Context, subtitleID string) (*DownloadEstimate, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := c.service.DownloadSubtitle(ctx, &pb.DownloadSubtitleRequest{SubtitleId: subtitleID, HeadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &DownloadEstimate{
+		Filename:      resp.Filename,
+		ContentType:   resp.ContentType,
+		ContentLength: resp.ContentLength,
+		LengthUnknown: resp.LengthUnknown,
+		FromCache:     resp.FromCache,
+	}, nil
+}
+
+// Download downloads a subtitle. Without options the whole file is returned. The content is
+// received in chunks, so season packs larger than the gRPC message limit download too, and is
+// checked against the size and SHA-256 the server announced.
+func (c *Client) Download(ctx context.Context, subtitleID string, opts ...DownloadOption) (*DownloadResult, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	req := &pb.DownloadSubtitleRequest{SubtitleId: subtitleID}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	stream, err := c.service.DownloadSubtitleStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	// The announced size only sizes the buffer up front; the received bytes are checked against it below
+	var content bytes.Buffer
+	content.Grow(int(min(max(metadata.Size, 0), downloadPreallocLimit)))
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content.Write(chunk.Data)
+	}
+
+	if int64(content.Len()) != metadata.Size {
+		return nil, fmt.Errorf("download of subtitle %s is incomplete: got %d of %d bytes", subtitleID, content.Len(), metadata.Size)
+	}
+	checksum := sha256.Sum256(content.Bytes())
+	if sum := hex.EncodeToString(checksum[:]); sum != metadata.Sha256 {
+		return nil, fmt.Errorf("download of subtitle %s failed its checksum: got %s, want %s", subtitleID, sum, metadata.Sha256)
+	}
+
+	return &DownloadResult{
+		Filename:        metadata.Filename,
+		SourceFilename:  metadata.SourceFilename,
+		Content:         content.Bytes(),
+		ContentType:     metadata.ContentType,
+		Sha256:          metadata.Sha256,
+		DetectedCharset: metadata.DetectedCharset,
+	}, nil
+}
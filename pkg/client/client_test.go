@@ -0,0 +1,392 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeServer is an in-process SuperSubtitles server answering from canned data
+type fakeServer struct {
+	pb.UnimplementedSuperSubtitlesServiceServer
+
+	shows          []*pb.Show
+	subtitles      []*pb.Subtitle
+	download       []byte
+	downloadSha256 string       // Overrides the announced checksum when set
+	orderBy        atomic.Int32 // OrderBy of the last GetSubtitles request
+	unavailable    atomic.Int32
+	latestCalls    atomic.Int32
+}
+
+func (s *fakeServer) GetShowList(_ *pb.GetShowListRequest, stream grpc.ServerStreamingServer[pb.Show]) error {
+	for _, show := range s.shows {
+		if err := stream.Send(show); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeServer) GetSubtitles(req *pb.GetSubtitlesRequest, stream grpc.ServerStreamingServer[pb.Subtitle]) error {
+	s.orderBy.Store(int32(req.OrderBy))
+	if req.ShowId != 1 {
+		return status.Error(codes.NotFound, "show not found")
+	}
+	for _, subtitle := range s.subtitles {
+		if err := stream.Send(subtitle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeServer) GetMovieSubtitles(req *pb.GetMovieSubtitlesRequest, stream grpc.ServerStreamingServer[pb.Subtitle]) error {
+	if req.MovieId != 7 {
+		return status.Error(codes.NotFound, "movie not found")
+	}
+	return stream.Send(&pb.Subtitle{
+		Id:          1709380001,
+		ShowName:    "Oppenheimer",
+		Season:      -1,
+		Episode:     -1,
+		ContentType: pb.ContentType_CONTENT_TYPE_FILM,
+		MovieTitle:  "Oppenheimer",
+		MovieYear:   2023,
+	})
+}
+
+func (s *fakeServer) GetLatestSubtitleId(context.Context, *pb.GetLatestSubtitleIdRequest) (*pb.GetLatestSubtitleIdResponse, error) {
+	s.latestCalls.Add(1)
+	if s.unavailable.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "upstream unavailable")
+	}
+	return &pb.GetLatestSubtitleIdResponse{SubtitleId: 1737439811}, nil
+}
+
+func (s *fakeServer) GetSubtitleDetails(ctx context.Context, req *pb.GetSubtitleDetailsRequest) (*pb.SubtitleDetails, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) == 0 || values[0] != "Bearer secret" {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return &pb.SubtitleDetails{
+		SubtitleId:    req.SubtitleId,
+		Filename:      "outlander.s07e16.srt",
+		Comment:       "csak a WEB-DL-hez jó",
+		ThirdPartyIds: &pb.ThirdPartyIds{ImdbId: "tt3006802", TvdbId: 270408},
+	}, nil
+}
+
+func (s *fakeServer) DownloadSubtitleStream(req *pb.DownloadSubtitleRequest, stream grpc.ServerStreamingServer[pb.DownloadChunk]) error {
+	if req.GetEpisode() != 3 {
+		return status.Error(codes.InvalidArgument, "expected episode 3")
+	}
+	checksum := sha256.Sum256(s.download)
+	announced := hex.EncodeToString(checksum[:])
+	if s.downloadSha256 != "" {
+		announced = s.downloadSha256
+	}
+	metadata := &pb.DownloadChunk{
+		Filename:    "show.s01e03.srt",
+		ContentType: "application/x-subrip",
+		Sha256:      announced,
+		Size:        int64(len(s.download)),
+	}
+	if req.FilenameTemplate != nil {
+		metadata.Filename, metadata.SourceFilename = "Show - "+req.GetFilenameTemplate(), metadata.Filename
+	}
+	if err := stream.Send(metadata); err != nil {
+		return err
+	}
+	// Two chunks so the client has to reassemble them
+	half := len(s.download) / 2
+	for _, data := range [][]byte{s.download[:half], s.download[half:]} {
+		if err := stream.Send(&pb.DownloadChunk{Data: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeServer) DownloadSubtitle(_ context.Context, req *pb.DownloadSubtitleRequest) (*pb.DownloadSubtitleResponse, error) {
+	if !req.HeadOnly {
+		return nil, status.Error(codes.InvalidArgument, "expected head_only")
+	}
+	return &pb.DownloadSubtitleResponse{
+		Filename:      "show.s01.zip",
+		ContentType:   "application/zip",
+		ContentLength: int64(len(s.download)),
+		FromCache:     true,
+	}, nil
+}
+
+// newTestClient serves fake over bufconn and returns a client connected to it
+func newTestClient(t *testing.T, fake *fakeServer, opts ...Option) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterSuperSubtitlesServiceServer(server, fake)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+	c, err := New("passthrough:///bufnet", append(opts, WithDialOptions(dialer))...)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClient_ListShows(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{shows: []*pb.Show{
+		{Id: 1978, Name: "Dallas", Year: 1978},
+		{Id: 2012, Name: "Dallas", Year: 2012, Aliases: []string{"Dallas"}},
+	}})
+
+	shows, err := c.ListShows(context.Background())
+	if err != nil {
+		t.Fatalf("ListShows failed: %v", err)
+	}
+	if len(shows) != 2 || shows[0].ID != 1978 || shows[1].Year != 2012 || len(shows[1].Aliases) != 1 {
+		t.Errorf("Unexpected shows: %+v", shows)
+	}
+}
+
+func TestClient_SubtitlesForShow(t *testing.T) {
+	t.Parallel()
+	uploadedAt := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	c := newTestClient(t, &fakeServer{subtitles: []*pb.Subtitle{
+		{
+			Id:         1737439811,
+			ShowId:     1,
+			Language:   "hu",
+			Season:     7,
+			Episode:    16,
+			UploadedAt: timestamppb.New(uploadedAt),
+			Qualities:  []pb.Quality{pb.Quality_QUALITY_720P, pb.Quality_QUALITY_1080P},
+		},
+		{Id: 1737439812, ShowId: 1, Season: 7, Episode: -1, IsSeasonPack: true, RangeStart: new(int32(1)), RangeEnd: new(int32(9))},
+	}})
+
+	var got []Subtitle
+	for subtitle, err := range c.SubtitlesForShow(context.Background(), 1) {
+		if err != nil {
+			t.Fatalf("SubtitlesForShow failed: %v", err)
+		}
+		got = append(got, subtitle)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 subtitles, got %d", len(got))
+	}
+	if !got[0].UploadedAt.Equal(uploadedAt) || len(got[0].Qualities) != 2 || got[0].Qualities[1] != Quality1080p {
+		t.Errorf("Unexpected first subtitle: %+v", got[0])
+	}
+	if !got[1].UploadedAt.IsZero() {
+		t.Errorf("Expected an unset upload date to stay zero, got %v", got[1].UploadedAt)
+	}
+	if got[1].RangeStart == nil || *got[1].RangeStart != 1 || got[1].RangeEnd == nil || *got[1].RangeEnd != 9 {
+		t.Errorf("Expected range 1-9, got %v-%v", got[1].RangeStart, got[1].RangeEnd)
+	}
+}
+
+func TestClient_SubtitlesForMovie(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{})
+
+	var got []Subtitle
+	for subtitle, err := range c.SubtitlesForMovie(context.Background(), 7) {
+		if err != nil {
+			t.Fatalf("SubtitlesForMovie failed: %v", err)
+		}
+		got = append(got, subtitle)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 subtitle, got %d", len(got))
+	}
+	if got[0].ContentType != ContentTypeFilm || got[0].MovieTitle != "Oppenheimer" || got[0].MovieYear != 2023 || got[0].Episode != -1 {
+		t.Errorf("Expected the film Oppenheimer (2023), got %+v", got[0])
+	}
+
+	for _, err := range c.SubtitlesForMovie(context.Background(), 8) {
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound for an unknown film, got %v", err)
+		}
+	}
+}
+
+func TestClient_SubtitlesForShow_StopsEarlyAndReportsErrors(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{subtitles: []*pb.Subtitle{{Id: 1}, {Id: 2}, {Id: 3}}})
+
+	count := 0
+	for _, err := range c.SubtitlesForShow(context.Background(), 1) {
+		if err != nil {
+			t.Fatalf("SubtitlesForShow failed: %v", err)
+		}
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected the loop to stop after one subtitle, got %d", count)
+	}
+
+	for _, err := range c.SubtitlesForShow(context.Background(), 42) {
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound for an unknown show, got %v", err)
+		}
+	}
+}
+
+func TestClient_SubtitlesForShowInOrder(t *testing.T) {
+	t.Parallel()
+	server := &fakeServer{subtitles: []*pb.Subtitle{{Id: 1}}}
+	c := newTestClient(t, server)
+
+	tests := []struct {
+		name  string
+		order SubtitleOrder
+		want  pb.SubtitleOrder
+	}{
+		{name: "upload time desc", order: SubtitleOrderUploadTimeDesc, want: pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_DESC},
+		{name: "upload time asc", order: SubtitleOrderUploadTimeAsc, want: pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_ASC},
+		{name: "season episode", order: SubtitleOrderSeasonEpisode, want: pb.SubtitleOrder_SUBTITLE_ORDER_SEASON_EPISODE},
+	}
+	for _, tt := range tests {
+		for _, err := range c.SubtitlesForShowInOrder(context.Background(), 1, tt.order) {
+			if err != nil {
+				t.Fatalf("%s: SubtitlesForShowInOrder failed: %v", tt.name, err)
+			}
+		}
+		if got := pb.SubtitleOrder(server.orderBy.Load()); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestClient_Download(t *testing.T) {
+	t.Parallel()
+	content := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	c := newTestClient(t, &fakeServer{download: content})
+
+	result, err := c.Download(context.Background(), "1737439811", WithEpisode(3))
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if string(result.Content) != string(content) {
+		t.Errorf("Expected reassembled content %q, got %q", content, result.Content)
+	}
+	if result.Filename != "show.s01e03.srt" || result.ContentType != "application/x-subrip" {
+		t.Errorf("Unexpected metadata: %q %q", result.Filename, result.ContentType)
+	}
+
+	result, err = c.Download(context.Background(), "1737439811", WithEpisode(3), WithFilenameTemplate("E03.srt"))
+	if err != nil {
+		t.Fatalf("Download with a filename template failed: %v", err)
+	}
+	if result.Filename != "Show - E03.srt" || result.SourceFilename != "show.s01e03.srt" {
+		t.Errorf("Expected the rendered filename and the source filename, got %q and %q", result.Filename, result.SourceFilename)
+	}
+
+	if _, err := c.Download(context.Background(), "1737439811"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected the server error without an episode, got %v", err)
+	}
+}
+
+func TestClient_Download_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{download: []byte("subtitle"), downloadSha256: "0000"})
+
+	if _, err := c.Download(context.Background(), "1737439811", WithEpisode(3)); err == nil {
+		t.Error("Expected a checksum error")
+	}
+}
+
+func TestClient_EstimateDownload(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{download: []byte("season pack")})
+
+	estimate, err := c.EstimateDownload(context.Background(), "1737439811")
+	if err != nil {
+		t.Fatalf("EstimateDownload failed: %v", err)
+	}
+	if estimate.ContentLength != int64(len("season pack")) || !estimate.FromCache || estimate.LengthUnknown {
+		t.Errorf("Unexpected estimate: %+v", estimate)
+	}
+	if estimate.Filename != "show.s01.zip" || estimate.ContentType != "application/zip" {
+		t.Errorf("Unexpected metadata: %q %q", estimate.Filename, estimate.ContentType)
+	}
+}
+
+func TestClient_RetriesUnavailable(t *testing.T) {
+	t.Parallel()
+	fake := &fakeServer{}
+	fake.unavailable.Store(2)
+	c := newTestClient(t, fake, WithRetry(3))
+
+	latestID, err := c.LatestSubtitleID(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got: %v", err)
+	}
+	if latestID != 1737439811 || fake.latestCalls.Load() != 3 {
+		t.Errorf("Expected ID 1737439811 after 3 calls, got %d after %d", latestID, fake.latestCalls.Load())
+	}
+}
+
+func TestClient_RetryDisabled(t *testing.T) {
+	t.Parallel()
+	fake := &fakeServer{}
+	fake.unavailable.Store(1)
+	c := newTestClient(t, fake, WithRetry(1))
+
+	if _, err := c.LatestSubtitleID(context.Background()); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable without retries, got %v", err)
+	}
+	if calls := fake.latestCalls.Load(); calls != 1 {
+		t.Errorf("Expected a single call, got %d", calls)
+	}
+}
+
+func TestClient_BearerToken(t *testing.T) {
+	t.Parallel()
+	fake := &fakeServer{}
+
+	details, err := newTestClient(t, fake, WithBearerToken("secret")).SubtitleDetails(context.Background(), 1737439811)
+	if err != nil {
+		t.Fatalf("SubtitleDetails failed: %v", err)
+	}
+	if details.SubtitleID != 1737439811 || details.Comment != "csak a WEB-DL-hez jó" || details.ThirdPartyIds.TVDBID != 270408 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+
+	if _, err := newTestClient(t, fake).SubtitleDetails(context.Background(), 1737439811); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	t.Parallel()
+	c := newTestClient(t, &fakeServer{}, WithTimeout(time.Nanosecond))
+
+	_, err := c.Languages(context.Background())
+	if status.Code(err) != codes.DeadlineExceeded && !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the call to time out, got %v", err)
+	}
+}
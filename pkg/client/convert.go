@@ -0,0 +1,300 @@
+package client
+
+import (
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// These converters are the reverse of internal/grpc/converters.go.
+
+// showFromProto converts a proto Show message to a models.Show
+func showFromProto(pbShow *pb.Show) models.Show {
+	if pbShow == nil {
+		return models.Show{}
+	}
+	return models.Show{
+		Name:     pbShow.Name,
+		ID:       int(pbShow.Id),
+		Year:     int(pbShow.Year),
+		ImageURL: pbShow.ImageUrl,
+		Aliases:  pbShow.Aliases,
+		Sources:  showSourcesFromProto(pbShow.Sources),
+	}
+}
+
+// showSourcesFromProto converts proto ShowSource enums to models.ShowSource values,
+// dropping unspecified ones
+func showSourcesFromProto(sources []pb.ShowSource) []models.ShowSource {
+	var result []models.ShowSource
+	for _, source := range sources {
+		switch source {
+		case pb.ShowSource_SHOW_SOURCE_WAITING:
+			result = append(result, models.ShowSourceWaiting)
+		case pb.ShowSource_SHOW_SOURCE_UNDER_TRANSLATION:
+			result = append(result, models.ShowSourceUnderTranslation)
+		case pb.ShowSource_SHOW_SOURCE_NOT_UNDER_TRANSLATION:
+			result = append(result, models.ShowSourceNotUnderTranslation)
+		}
+	}
+	return result
+}
+
+// showToProto converts a models.Show to a proto Show message for requests
+func showToProto(show models.Show) *pb.Show {
+	return &pb.Show{
+		Name:     show.Name,
+		Id:       int64(show.ID),
+		Year:     int32(show.Year),
+		ImageUrl: show.ImageURL,
+		Aliases:  show.Aliases,
+	}
+}
+
+// subtitleOrderToProto converts a models.SubtitleOrder to a proto SubtitleOrder enum. Listing
+// order cannot be requested, so it leaves the server default.
+func subtitleOrderToProto(order models.SubtitleOrder) pb.SubtitleOrder {
+	switch order {
+	case models.SubtitleOrderUploadTimeDesc:
+		return pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_DESC
+	case models.SubtitleOrderUploadTimeAsc:
+		return pb.SubtitleOrder_SUBTITLE_ORDER_UPLOAD_TIME_ASC
+	case models.SubtitleOrderSeasonEpisode:
+		return pb.SubtitleOrder_SUBTITLE_ORDER_SEASON_EPISODE
+	default:
+		return pb.SubtitleOrder_SUBTITLE_ORDER_UNSPECIFIED
+	}
+}
+
+// thirdPartyIdsFromProto converts a proto ThirdPartyIds message to models.ThirdPartyIds
+func thirdPartyIdsFromProto(ids *pb.ThirdPartyIds) models.ThirdPartyIds {
+	return models.ThirdPartyIds{
+		IMDBID:   ids.GetImdbId(),
+		TVDBID:   int(ids.GetTvdbId()),
+		TVMazeID: int(ids.GetTvMazeId()),
+		TraktID:  int(ids.GetTraktId()),
+	}
+}
+
+// qualityFromProto converts a proto Quality enum to a models.Quality
+func qualityFromProto(quality pb.Quality) models.Quality {
+	switch quality {
+	case pb.Quality_QUALITY_360P:
+		return models.Quality360p
+	case pb.Quality_QUALITY_480P:
+		return models.Quality480p
+	case pb.Quality_QUALITY_720P:
+		return models.Quality720p
+	case pb.Quality_QUALITY_1080P:
+		return models.Quality1080p
+	case pb.Quality_QUALITY_2160P:
+		return models.Quality2160p
+	default:
+		return models.QualityUnknown
+	}
+}
+
+// optionalInt converts an optional proto int32 to *int
+func optionalInt(val *int32) *int {
+	if val == nil {
+		return nil
+	}
+	converted := int(*val)
+	return &converted
+}
+
+// subtitleFromProto converts a proto Subtitle message to a models.Subtitle
+func subtitleFromProto(subtitle *pb.Subtitle) models.Subtitle {
+	qualities := make([]models.Quality, len(subtitle.Qualities))
+	for i, q := range subtitle.Qualities {
+		qualities[i] = qualityFromProto(q)
+	}
+
+	result := models.Subtitle{
+		ID:                int(subtitle.Id),
+		IDIsSynthetic:     subtitle.IdIsSynthetic,
+		ShowID:            int(subtitle.ShowId),
+		ShowName:          subtitle.ShowName,
+		Name:              subtitle.Name,
+		RawName:           subtitle.RawName,
+		Language:          subtitle.Language,
+		Season:            int(subtitle.Season),
+		Episode:           int(subtitle.Episode),
+		Filename:          subtitle.Filename,
+		DownloadURL:       subtitle.DownloadUrl,
+		Uploader:          subtitle.Uploader,
+		UploaderID:        subtitle.UploaderId,
+		UploaderVerified:  subtitle.UploaderVerified,
+		IsHearingImpaired: subtitle.IsHearingImpaired,
+		Qualities:         qualities,
+		ReleaseGroups:     subtitle.ReleaseGroups,
+		ReleaseVariants:   releaseVariantsFromProto(subtitle.ReleaseVariants),
+		Release:           subtitle.Release,
+		IsSeasonPack:      subtitle.IsSeasonPack,
+		RangeStart:        optionalInt(subtitle.RangeStart),
+		RangeEnd:          optionalInt(subtitle.RangeEnd),
+		SeasonEnd:         optionalInt(subtitle.SeasonEnd),
+		ContentType:       contentTypeFromProto(subtitle.ContentType),
+		MovieTitle:        subtitle.MovieTitle,
+		MovieYear:         int(subtitle.MovieYear),
+	}
+	// An unset upload date stays the zero time, as it was before the server converted it
+	if subtitle.UploadedAt != nil {
+		result.UploadedAt = subtitle.UploadedAt.AsTime()
+	}
+	if subtitle.AirDate != nil {
+		result.AirDate = subtitle.AirDate.AsTime()
+	}
+	return result
+}
+
+// contentTypeFromProto converts a proto ContentType enum to a models.ContentType, empty when unspecified
+func contentTypeFromProto(contentType pb.ContentType) models.ContentType {
+	switch contentType {
+	case pb.ContentType_CONTENT_TYPE_SERIES:
+		return models.ContentTypeSeries
+	case pb.ContentType_CONTENT_TYPE_FILM:
+		return models.ContentTypeFilm
+	default:
+		return ""
+	}
+}
+
+// releaseVariantsFromProto converts proto ReleaseVariant messages to models.ReleaseVariant values
+func releaseVariantsFromProto(variants []*pb.ReleaseVariant) []models.ReleaseVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]models.ReleaseVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = models.ReleaseVariant{
+			Source:  variant.Source,
+			RipType: variant.RipType,
+			Quality: qualityFromProto(variant.Quality),
+			Group:   variant.Group,
+		}
+	}
+	return result
+}
+
+// subtitlesFromProto converts a list of proto Subtitle messages
+func subtitlesFromProto(subtitles []*pb.Subtitle) []models.Subtitle {
+	result := make([]models.Subtitle, len(subtitles))
+	for i, subtitle := range subtitles {
+		result[i] = subtitleFromProto(subtitle)
+	}
+	return result
+}
+
+// showSubtitlesFromProto converts a proto ShowSubtitlesCollection to a models.ShowSubtitles
+func showSubtitlesFromProto(collection *pb.ShowSubtitlesCollection) models.ShowSubtitles {
+	show := showFromProto(collection.GetShowInfo().GetShow())
+	show.Status = collection.GetShowInfo().GetStatus()
+	subtitles := subtitlesFromProto(collection.Subtitles)
+	return models.ShowSubtitles{
+		Show:          show,
+		ThirdPartyIds: thirdPartyIdsFromProto(collection.GetShowInfo().GetThirdPartyIds()),
+		SubtitleCollection: models.SubtitleCollection{
+			ShowName:  show.Name,
+			Subtitles: subtitles,
+			Total:     len(subtitles),
+		},
+	}
+}
+
+// showSeasonsFromProto converts a proto ShowSeasons message to models.ShowSeasons
+func showSeasonsFromProto(pbSeasons *pb.ShowSeasons) *models.ShowSeasons {
+	seasons := make([]models.SeasonSummary, len(pbSeasons.Seasons))
+	for i, s := range pbSeasons.Seasons {
+		seasons[i] = models.SeasonSummary{
+			Season:              int(s.Season),
+			EpisodeCount:        int(s.EpisodeCount),
+			SeasonPackAvailable: s.SeasonPackAvailable,
+		}
+		if s.LatestUpload != nil {
+			seasons[i].LatestUpload = s.LatestUpload.AsTime()
+		}
+	}
+	return &models.ShowSeasons{Seasons: seasons, UnknownCount: int(pbSeasons.UnknownCount)}
+}
+
+// subtitleDetailsFromProto converts a proto SubtitleDetails message to models.SubtitleDetails
+func subtitleDetailsFromProto(details *pb.SubtitleDetails) *models.SubtitleDetails {
+	return &models.SubtitleDetails{
+		SubtitleID:    int(details.SubtitleId),
+		Filename:      details.Filename,
+		Uploader:      details.Uploader,
+		Comment:       details.Comment,
+		ThirdPartyIds: thirdPartyIdsFromProto(details.ThirdPartyIds),
+	}
+}
+
+// languagesFromProto converts a proto GetLanguagesResponse to models.Language values
+func languagesFromProto(resp *pb.GetLanguagesResponse) []models.Language {
+	languages := make([]models.Language, len(resp.Languages))
+	for i, language := range resp.Languages {
+		languages[i] = models.Language{
+			ISOCode:       language.IsoCode,
+			HungarianName: language.HungarianName,
+			EnglishName:   language.EnglishName,
+		}
+	}
+	return languages
+}
+
+// upstreamStatusFromProto converts a proto GetStatusResponse to models.UpstreamStatus
+func upstreamStatusFromProto(resp *pb.GetStatusResponse) *models.UpstreamStatus {
+	status := &models.UpstreamStatus{
+		ActiveMirror:  resp.ActiveMirror,
+		Mirrors:       resp.Mirrors,
+		Blocked:       resp.Blocked,
+		BlockedReason: resp.BlockedReason,
+	}
+	if resp.ActiveSince != nil {
+		status.ActiveSince = resp.ActiveSince.AsTime()
+	}
+	if resp.BlockedSince != nil {
+		status.BlockedSince = resp.BlockedSince.AsTime()
+	}
+	return status
+}
+
+// serverInfoFromProto converts a proto ServerInfo to models.ServerInfo
+func serverInfoFromProto(resp *pb.ServerInfo) *models.ServerInfo {
+	limits := resp.GetLimits()
+	return &models.ServerInfo{
+		Version:    resp.Version,
+		Commit:     resp.Commit,
+		BuildDate:  resp.BuildDate,
+		APIVersion: resp.ApiVersion,
+		Features:   resp.Features,
+		Limits: models.ServerLimits{
+			MaxDownloadBytes: limits.GetMaxDownloadBytes(),
+			MaxFileBytes:     limits.GetMaxFileBytes(),
+			MaxArchiveBytes:  limits.GetMaxArchiveBytes(),
+			MaxMessageBytes:  limits.GetMaxMessageBytes(),
+		},
+	}
+}
+
+// selfCheckFromProto converts a proto SelfCheckResponse to models.SelfCheckResult
+func selfCheckFromProto(resp *pb.SelfCheckResponse) *models.SelfCheckResult {
+	return &models.SelfCheckResult{
+		OK:            resp.Ok,
+		Details:       resp.Details,
+		ShowID:        int(resp.ShowId),
+		SubtitleCount: int(resp.SubtitleCount),
+	}
+}
+
+// updateCheckFromProto converts a proto CheckForUpdatesResponse to models.UpdateCheckResult
+func updateCheckFromProto(resp *pb.CheckForUpdatesResponse) *models.UpdateCheckResult {
+	result := &models.UpdateCheckResult{
+		FilmCount:   int(resp.FilmCount),
+		SeriesCount: int(resp.SeriesCount),
+		HasUpdates:  resp.HasUpdates,
+	}
+	if resp.CheckedAt != nil {
+		result.CheckedAt = resp.CheckedAt.AsTime()
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	pb "github.com/Belphemur/SuperSubtitles/v2/api/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// defaultRetryAttempts is how many times an idempotent call is attempted when WithRetry is not used.
+	defaultRetryAttempts = 3
+	// maxRetryAttempts is the highest attempt count gRPC honors in a retry policy.
+	maxRetryAttempts = 5
+)
+
+// idempotentMethods are the RPCs retried on UNAVAILABLE. None of them changes data on the
+// site; InvalidateCache and ClearCache only drop cached archives, so repeating them is harmless.
+var idempotentMethods = []string{
+	"GetShowList",
+	"ListShows",
+	"SelfCheck",
+	"GetServerInfo",
+	"GetShowImage",
+	"GetSubtitles",
+	"GetShowSubtitles",
+	"GetRecentSubtitles",
+	"GetLatestSubtitleId",
+	"FindSubtitle",
+	"GetShowSeasons",
+	"FindShow",
+	"GetLanguages",
+	"GetSubtitleDetails",
+	"GetSubtitle",
+	"GetSubtitleById",
+	"CheckForUpdates",
+	"DownloadSubtitleStream",
+	"InvalidateCache",
+	"ClearCache",
+}
+
+// options holds the settings collected from Option values
+type options struct {
+	timeout       time.Duration
+	tlsConfig     *tls.Config
+	token         string
+	retryAttempts int
+	dialOptions   []grpc.DialOption
+}
+
+// Option configures a Client
+type Option func(*options)
+
+// WithTimeout bounds every call that returns a single result, downloads included, unless the
+// caller's context already has a deadline. Streams returned as iterators are not bounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTLS connects over TLS with the given configuration. Without it the connection is plaintext.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithBearerToken sends token as "authorization: Bearer <token>" metadata on every call,
+// matching one of the server's auth.tokens.
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithRetry sets how many times an idempotent call is attempted when the server answers
+// UNAVAILABLE, backing off between attempts. Streams are only retried until their first
+// message arrives. A value of 1 or less disables retries; gRPC caps it at 5. Default 3.
+func WithRetry(maxAttempts int) Option {
+	return func(o *options) {
+		o.retryAttempts = maxAttempts
+	}
+}
+
+// WithDialOptions appends raw gRPC dial options, such as a custom dialer.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// bearerToken attaches a static bearer token to every call
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (b bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials. The token is allowed over
+// plaintext when TLS is not configured, for servers behind a TLS-terminating proxy or on localhost.
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.requireTLS
+}
+
+// buildDialOptions turns the collected options into gRPC dial options
+func (o *options) buildDialOptions() ([]grpc.DialOption, error) {
+	transportCredentials := insecure.NewCredentials()
+	if o.tlsConfig != nil {
+		transportCredentials = credentials.NewTLS(o.tlsConfig)
+	}
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+
+	if o.token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(bearerToken{token: o.token, requireTLS: o.tlsConfig != nil}))
+	}
+
+	if o.retryAttempts > 1 {
+		serviceConfig, err := retryServiceConfig(min(o.retryAttempts, maxRetryAttempts))
+		if err != nil {
+			return nil, err
+		}
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithDisableRetry())
+	}
+
+	return append(dialOptions, o.dialOptions...), nil
+}
+
+// retryServiceConfig builds a gRPC service config that retries idempotentMethods on UNAVAILABLE
+func retryServiceConfig(maxAttempts int) (string, error) {
+	type methodName struct {
+		Service string `json:"service"`
+		Method  string `json:"method"`
+	}
+	names := make([]methodName, len(idempotentMethods))
+	for i, method := range idempotentMethods {
+		names[i] = methodName{Service: pb.SuperSubtitlesService_ServiceDesc.ServiceName, Method: method}
+	}
+
+	config := map[string]any{
+		"methodConfig": []any{map[string]any{
+			"name": names,
+			"retryPolicy": map[string]any{
+				"maxAttempts":          maxAttempts,
+				"initialBackoff":       "0.1s",
+				"maxBackoff":           "2s",
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}},
+	}
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import "github.com/Belphemur/SuperSubtitles/v2/internal/models"
+
+// The client returns the service's domain types. They are aliases so values convert freely
+// between this package and the server's own code, while staying nameable outside the module.
+type (
+	Show              = models.Show
+	ThirdPartyIds     = models.ThirdPartyIds
+	Subtitle          = models.Subtitle
+	ShowSubtitles     = models.ShowSubtitles
+	Quality           = models.Quality
+	UpdateCheckResult = models.UpdateCheckResult
+	DownloadResult    = models.DownloadResult
+	DownloadEstimate  = models.DownloadEstimate
+	ShowSeasons       = models.ShowSeasons
+	SeasonSummary     = models.SeasonSummary
+	SubtitleDetails   = models.SubtitleDetails
+	Language          = models.Language
+	UpstreamStatus    = models.UpstreamStatus
+	ShowSource        = models.ShowSource
+	SelfCheckResult   = models.SelfCheckResult
+	ServerInfo        = models.ServerInfo
+	ServerLimits      = models.ServerLimits
+	ReleaseVariant    = models.ReleaseVariant
+	ShowImage         = models.ShowImage
+	SubtitleOrder     = models.SubtitleOrder
+	ContentType       = models.ContentType
+)
+
+// Orders the subtitles of each listing page can be streamed in
+const (
+	SubtitleOrderUploadTimeDesc = models.SubtitleOrderUploadTimeDesc
+	SubtitleOrderUploadTimeAsc  = models.SubtitleOrderUploadTimeAsc
+	SubtitleOrderSeasonEpisode  = models.SubtitleOrderSeasonEpisode
+)
+
+// Kinds of content a subtitle can be for
+const (
+	ContentTypeSeries = models.ContentTypeSeries
+	ContentTypeFilm   = models.ContentTypeFilm
+)
+
+// Qualities a subtitle can list
+const (
+	QualityUnknown = models.QualityUnknown
+	Quality360p    = models.Quality360p
+	Quality480p    = models.Quality480p
+	Quality720p    = models.Quality720p
+	Quality1080p   = models.Quality1080p
+	Quality2160p   = models.Quality2160p
+)
+
+// Show list endpoints a show can be listed on
+const (
+	ShowSourceWaiting             = models.ShowSourceWaiting
+	ShowSourceUnderTranslation    = models.ShowSourceUnderTranslation
+	ShowSourceNotUnderTranslation = models.ShowSourceNotUnderTranslation
+)
@@ -0,0 +1,105 @@
+package feliratok
+
+import (
+	"errors"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/apperrors"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+)
+
+// The parser returns the service's internal types, which change with the service. They are
+// copied into this package's types here, so the public API only changes when this file does.
+
+// fromSubtitle converts a parsed subtitle
+func fromSubtitle(s models.Subtitle) Subtitle {
+	return Subtitle{
+		ID:                s.ID,
+		IDIsSynthetic:     s.IDIsSynthetic,
+		ShowID:            s.ShowID,
+		ShowName:          s.ShowName,
+		HungarianShowName: s.HungarianShowName,
+		Name:              s.Name,
+		RawName:           s.RawName,
+		Language:          s.Language,
+		Season:            s.Season,
+		Episode:           s.Episode,
+		Filename:          s.Filename,
+		DownloadURL:       s.DownloadURL,
+		Uploader:          s.Uploader,
+		UploaderID:        s.UploaderID,
+		UploaderVerified:  s.UploaderVerified,
+		IsHearingImpaired: s.IsHearingImpaired,
+		UploadedAt:        s.UploadedAt,
+		Qualities:         fromQualities(s.Qualities),
+		ReleaseGroups:     s.ReleaseGroups,
+		ReleaseVariants:   fromReleaseVariants(s.ReleaseVariants),
+		Release:           s.Release,
+		IsSeasonPack:      s.IsSeasonPack,
+		SeasonEnd:         s.SeasonEnd,
+		RangeStart:        s.RangeStart,
+		RangeEnd:          s.RangeEnd,
+		MovieTitle:        s.MovieTitle,
+		MovieYear:         s.MovieYear,
+		ContentType:       ContentType(s.ContentType),
+		AirDate:           s.AirDate,
+	}
+}
+
+// fromQuality converts a parsed quality by name, so the two enumerations may number differently
+func fromQuality(q models.Quality) Quality {
+	switch q {
+	case models.Quality360p:
+		return Quality360p
+	case models.Quality480p:
+		return Quality480p
+	case models.Quality720p:
+		return Quality720p
+	case models.Quality1080p:
+		return Quality1080p
+	case models.Quality2160p:
+		return Quality2160p
+	default:
+		return QualityUnknown
+	}
+}
+
+// fromQualities converts parsed qualities, keeping nil as nil
+func fromQualities(qualities []models.Quality) []Quality {
+	if qualities == nil {
+		return nil
+	}
+	converted := make([]Quality, len(qualities))
+	for i, q := range qualities {
+		converted[i] = fromQuality(q)
+	}
+	return converted
+}
+
+// fromReleaseVariants converts parsed release variants, keeping nil as nil
+func fromReleaseVariants(variants []models.ReleaseVariant) []ReleaseVariant {
+	if variants == nil {
+		return nil
+	}
+	converted := make([]ReleaseVariant, len(variants))
+	for i, v := range variants {
+		converted[i] = ReleaseVariant{Source: v.Source, RipType: v.RipType, Quality: fromQuality(v.Quality), Group: v.Group}
+	}
+	return converted
+}
+
+// fromError converts the parser's page errors to this package's; other errors are returned unchanged
+func fromError(err error) error {
+	var blocked *apperrors.ErrUpstreamBlocked
+	var maintenance *apperrors.ErrUpstreamMaintenance
+	var unexpected *apperrors.ErrUnexpectedPage
+	switch {
+	case errors.As(err, &blocked):
+		return &ErrUpstreamBlocked{Reason: blocked.Reason}
+	case errors.As(err, &maintenance):
+		return &ErrUpstreamMaintenance{Reason: maintenance.Reason}
+	case errors.As(err, &unexpected):
+		return &ErrUnexpectedPage{Page: unexpected.Page, Reason: unexpected.Reason}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,111 @@
+// Package feliratok parses feliratok.eu pages without running the SuperSubtitles service. It
+// exposes the parser the service itself uses: subtitle listing pages, release info, language
+// names and episode titles are read exactly as the server reads them.
+//
+// Nothing here loads the service configuration or writes to its logs. Parsing is silent unless
+// a Logger is passed with WithLogger.
+package feliratok
+
+import (
+	"io"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/parser"
+	"github.com/rs/zerolog"
+)
+
+// DefaultBaseURL is the site download links are resolved against unless WithBaseURL is used
+const DefaultBaseURL = "https://feliratok.eu"
+
+// Option configures ParseSubtitleListing
+type Option func(*options)
+
+type options struct {
+	baseURL string
+	logger  Logger
+	now     func() time.Time
+}
+
+// WithBaseURL resolves relative download links against baseURL, such as a mirror of the site
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) { o.baseURL = baseURL }
+}
+
+// WithLogger sends the parser's log messages to logger
+func WithLogger(logger Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithClock resolves relative upload dates, such as "tegnap" or "3 napja", against now
+// instead of the wall clock
+func WithClock(now func() time.Time) Option {
+	return func(o *options) { o.now = now }
+}
+
+// ParseSubtitleListing parses one page of a feliratok.eu subtitle listing, in any character
+// encoding. A page without the listing's result table returns *ErrUpstreamBlocked when it looks
+// like a captcha or login page, *ErrUpstreamMaintenance when it looks like a maintenance or
+// server error page, and *ErrUnexpectedPage otherwise.
+func ParseSubtitleListing(r io.Reader, opts ...Option) (*SubtitleListing, error) {
+	o := options{baseURL: DefaultBaseURL, now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := parser.NewSubtitleParserWithClock(o.baseURL, o.now).WithLogger(newZerologLogger(o.logger))
+	result, err := p.ParseHtmlWithPagination(r)
+	if err != nil {
+		return nil, fromError(err)
+	}
+	subtitles := make([]Subtitle, len(result.Subtitles))
+	for i, subtitle := range result.Subtitles {
+		subtitles[i] = fromSubtitle(subtitle)
+	}
+	return &SubtitleListing{
+		Subtitles:   subtitles,
+		CurrentPage: result.CurrentPage,
+		TotalPages:  result.TotalPages,
+		HasNextPage: result.HasNextPage,
+	}, nil
+}
+
+// ParseReleaseInfo reads the qualities and release groups of a release description, such as
+// "AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab". Both are listed once each in order of
+// appearance; release groups are compared ignoring case.
+func ParseReleaseInfo(releaseInfo string) ([]Quality, []string) {
+	qualities, releaseGroups, _ := releaseParser().ParseReleaseInfo(releaseInfo)
+	return fromQualities(qualities), releaseGroups
+}
+
+// ParseReleaseVariants splits a release description into its comma-separated releases, each
+// with its own source, rip type, quality and group.
+func ParseReleaseVariants(releaseInfo string) []ReleaseVariant {
+	_, _, variants := releaseParser().ParseReleaseInfo(releaseInfo)
+	return fromReleaseVariants(variants)
+}
+
+// ConvertLanguageToISO converts a Hungarian or English language name, such as "Magyar" or
+// "English", to its ISO 639-1 code. A name that is already a code is returned lowercased, and
+// an unknown name is returned unchanged.
+func ConvertLanguageToISO(languageName string) string {
+	return parser.ConvertLanguageToISO(languageName)
+}
+
+// ExtractEpisodeTitle returns the episode title of a listing description, as "A Hundred
+// Thousand Angels" from "Outlander - 7x16 - A Hundred Thousand Angels (AMZN.WEB-DL.720p-FLUX)".
+// Season-level descriptions have no episode title and return "".
+func ExtractEpisodeTitle(description string) string {
+	return parser.ExtractEpisodeTitle(description)
+}
+
+// NormalizeEpisodeTitle removes source artifacts from an episode title, as "The Shepherds Hut"
+// from "The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com", and collapses whitespace.
+// ParseSubtitleListing already applies it to Subtitle.Name and keeps the listed title in RawName.
+func NormalizeEpisodeTitle(title string) string {
+	return parser.NormalizeEpisodeTitle(title)
+}
+
+// releaseParser returns a parser for release descriptions, which need neither a base URL nor a log
+func releaseParser() *parser.SubtitleParser {
+	return parser.NewSubtitleParser(DefaultBaseURL).WithLogger(zerolog.Nop())
+}
@@ -0,0 +1,191 @@
+package feliratok
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Belphemur/SuperSubtitles/v2/internal/models"
+	"github.com/Belphemur/SuperSubtitles/v2/internal/testutil"
+)
+
+func TestParseSubtitleListing(t *testing.T) {
+	t.Parallel()
+	html := testutil.GenerateSubtitleTableHTMLWithPagination([]testutil.SubtitleRowOptions{
+		{
+			SubtitleID:       1770600001,
+			ShowID:           1,
+			Language:         "Angol",
+			FlagImage:        "uk.gif",
+			MagyarTitle:      "Dark - 1x03",
+			EredetiTitle:     "Dark - 1x03 - Past and Present (WEB.1080p-NTb)",
+			Uploader:         "Alice",
+			UploadDate:       "2024-05-01",
+			DownloadFilename: "dark.s01e03.srt",
+		},
+	}, 1, 3, true)
+
+	listing, err := ParseSubtitleListing(strings.NewReader(html), WithBaseURL("https://mirror.example"))
+	if err != nil {
+		t.Fatalf("ParseSubtitleListing failed: %v", err)
+	}
+	if listing.CurrentPage != 1 || listing.TotalPages != 3 || !listing.HasNextPage {
+		t.Errorf("Expected page 1 of 3 with a next page, got %+v", listing)
+	}
+	if len(listing.Subtitles) != 1 {
+		t.Fatalf("Expected 1 subtitle, got %d", len(listing.Subtitles))
+	}
+	subtitle := listing.Subtitles[0]
+	if subtitle.ID != 1770600001 || subtitle.Language != "en" || subtitle.Season != 1 || subtitle.Episode != 3 {
+		t.Errorf("Unexpected subtitle %+v", subtitle)
+	}
+	if !slices.Equal(subtitle.Qualities, []Quality{Quality1080p}) || !slices.Equal(subtitle.ReleaseGroups, []string{"NTb"}) {
+		t.Errorf("Expected 1080p from NTb, got %v from %v", subtitle.Qualities, subtitle.ReleaseGroups)
+	}
+	if !strings.HasPrefix(subtitle.DownloadURL, "https://mirror.example/") {
+		t.Errorf("Expected the download URL on the mirror, got %q", subtitle.DownloadURL)
+	}
+}
+
+func TestParseSubtitleListing_RelativeDateUsesClock(t *testing.T) {
+	t.Parallel()
+	html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{SubtitleID: 1, ShowID: 1, Language: "Magyar", FlagImage: "hungary.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB)", UploadDate: "tegnap", DownloadFilename: "dark.s01e01.srt"},
+	})
+	now := time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC)
+
+	listing, err := ParseSubtitleListing(strings.NewReader(html), WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("ParseSubtitleListing failed: %v", err)
+	}
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC); !listing.Subtitles[0].UploadedAt.Equal(want) {
+		t.Errorf("Expected upload date %v, got %v", want, listing.Subtitles[0].UploadedAt)
+	}
+}
+
+func TestParseSubtitleListing_NotAListing(t *testing.T) {
+	t.Parallel()
+	_, err := ParseSubtitleListing(strings.NewReader(testutil.GenerateCaptchaPageHTML()))
+	var blocked *ErrUpstreamBlocked
+	if !errors.As(err, &blocked) {
+		t.Errorf("Expected ErrUpstreamBlocked for a captcha page, got %v", err)
+	}
+
+	_, err = ParseSubtitleListing(strings.NewReader(testutil.GenerateMaintenancePageHTML()))
+	var maintenance *ErrUpstreamMaintenance
+	if !errors.As(err, &maintenance) {
+		t.Errorf("Expected ErrUpstreamMaintenance for a maintenance page, got %v", err)
+	}
+
+	_, err = ParseSubtitleListing(strings.NewReader(testutil.GenerateHTMLWithBody(strings.Repeat("<p>Nothing to see here.</p>", 200))))
+	var unexpected *ErrUnexpectedPage
+	if !errors.As(err, &unexpected) {
+		t.Errorf("Expected ErrUnexpectedPage for a page without a listing, got %v", err)
+	}
+}
+
+func TestParseSubtitleListing_WithLogger(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	html := testutil.GenerateSubtitleTableHTML([]testutil.SubtitleRowOptions{
+		{SubtitleID: 1, ShowID: 1, Language: "Klingon", FlagImage: "klingon.gif", MagyarTitle: "Dark - 1x01", EredetiTitle: "Dark - 1x01 (WEB)", DownloadFilename: "dark.s01e01.srt"},
+	})
+
+	if _, err := ParseSubtitleListing(strings.NewReader(html), WithLogger(logger)); err != nil {
+		t.Fatalf("ParseSubtitleListing failed: %v", err)
+	}
+	logged := buf.String()
+	if !strings.Contains(logged, `level=INFO msg="Completed HTML parsing for subtitles"`) || !strings.Contains(logged, "total_subtitles=1") {
+		t.Errorf("Expected the completion message with its fields, got:\n%s", logged)
+	}
+	if !strings.Contains(logged, `level=DEBUG msg="Unknown language name, returning original value" languageName=Klingon`) {
+		t.Errorf("Expected the unknown language at debug level, got:\n%s", logged)
+	}
+}
+
+func TestParseReleaseInfo(t *testing.T) {
+	t.Parallel()
+	qualities, groups := ParseReleaseInfo("AMZN.WEB-DL.720p-FLUX, WEB.1080p-SuccessfulCrab, WEB.720p-flux")
+	if !slices.Equal(qualities, []Quality{Quality720p, Quality1080p}) {
+		t.Errorf("Expected 720p and 1080p, got %v", qualities)
+	}
+	if !slices.Equal(groups, []string{"FLUX", "SuccessfulCrab"}) {
+		t.Errorf("Expected FLUX and SuccessfulCrab, got %v", groups)
+	}
+	if variants := ParseReleaseVariants("AMZN.WEB-DL.720p-FLUX, WEB.720p-flux"); len(variants) != 2 || variants[1].Group != "flux" {
+		t.Errorf("Expected both releases as variants, got %+v", variants)
+	}
+}
+
+func TestConvertLanguageToISO(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{"Magyar": "hu", "Angol": "en", "English": "en", "DE": "de", "Klingon": "Klingon", "": ""}
+	for name, want := range tests {
+		if got := ConvertLanguageToISO(name); got != want {
+			t.Errorf("ConvertLanguageToISO(%q) = %q, expected %q", name, got, want)
+		}
+	}
+}
+
+func TestExtractEpisodeTitle(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"Outlander - 7x16 - A Hundred Thousand Angels (AMZN.WEB-DL.720p-FLUX)": "A Hundred Thousand Angels",
+		"Billy the Kid (Season 2) (WEB.720p-SuccessfulCrab)":                   "",
+	}
+	for description, want := range tests {
+		if got := ExtractEpisodeTitle(description); got != want {
+			t.Errorf("ExtractEpisodeTitle(%q) = %q, expected %q", description, got, want)
+		}
+	}
+}
+
+func TestNormalizeEpisodeTitle(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"The Shepherds Hut.EDITH.English.C.orig.Addic7ed.com": "The Shepherds Hut",
+		"A Hundred  Thousand Angels":                          "A Hundred Thousand Angels",
+	}
+	for title, want := range tests {
+		if got := NormalizeEpisodeTitle(title); got != want {
+			t.Errorf("NormalizeEpisodeTitle(%q) = %q, expected %q", title, got, want)
+		}
+	}
+}
+
+// TestSubtitle_CoversParsedFields fails when the parser gains a field that fromSubtitle does
+// not copy, so the public type is extended deliberately
+func TestSubtitle_CoversParsedFields(t *testing.T) {
+	t.Parallel()
+	public := reflect.TypeFor[Subtitle]()
+	for field := range reflect.TypeFor[models.Subtitle]().Fields() {
+		if _, ok := public.FieldByName(field.Name); !ok {
+			t.Errorf("Parsed field %s is missing from feliratok.Subtitle", field.Name)
+		}
+	}
+}
+
+func TestQuality_JSON(t *testing.T) {
+	t.Parallel()
+	encoded, err := json.Marshal([]Quality{Quality720p, QualityUnknown})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(encoded) != `["720p","unknown"]` {
+		t.Errorf("Expected qualities encoded by name, got %s", encoded)
+	}
+	var decoded []Quality
+	if err := json.Unmarshal([]byte(`["2160P","720p","8k"]`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(decoded, []Quality{Quality2160p, Quality720p, QualityUnknown}) {
+		t.Errorf("Expected 2160p, 720p and unknown, got %v", decoded)
+	}
+}
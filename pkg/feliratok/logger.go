@@ -0,0 +1,66 @@
+package feliratok
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/rs/zerolog"
+)
+
+// Logger receives the parser's log messages, with their fields as alternating keys and values.
+// *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// newZerologLogger returns a zerolog logger that hands every event to logger, or one that
+// discards everything when logger is nil.
+func newZerologLogger(logger Logger) zerolog.Logger {
+	if logger == nil {
+		return zerolog.Nop()
+	}
+	return zerolog.New(loggerWriter{logger: logger}).Level(zerolog.DebugLevel)
+}
+
+// loggerWriter decodes the JSON events zerolog writes, one per call, back into a message and fields
+type loggerWriter struct {
+	logger Logger
+}
+
+// Write implements io.Writer for events without a level, which are logged as Info
+func (w loggerWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter
+func (w loggerWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(p, &fields); err != nil {
+		w.logger.Error("Undecodable parser log event", "event", string(p), "error", err)
+		return len(p), nil
+	}
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+
+	args := make([]any, 0, 2*len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		args = append(args, key, fields[key])
+	}
+
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		w.logger.Debug(msg, args...)
+	case zerolog.WarnLevel:
+		w.logger.Warn(msg, args...)
+	case zerolog.ErrorLevel, zerolog.FatalLevel, zerolog.PanicLevel:
+		w.logger.Error(msg, args...)
+	default:
+		w.logger.Info(msg, args...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,149 @@
+package feliratok
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Subtitle is one row of a subtitle listing
+type Subtitle struct {
+	ID                int              `json:"id"`
+	IDIsSynthetic     bool             `json:"idIsSynthetic"`     // ID is a negative hash of the download URL because the link carries no numeric felirat ID
+	ShowID            int              `json:"showId"`            // Show ID from the category link; 0 for films
+	ShowName          string           `json:"showName"`          // Show name (may be empty)
+	HungarianShowName string           `json:"hungarianShowName"` // Hungarian show title from the listing (may be empty)
+	Name              string           `json:"name"`              // Episode title with source artifacts such as "Addic7ed.com" removed, see NormalizeEpisodeTitle
+	RawName           string           `json:"rawName"`           // Episode title exactly as it appears in the listing
+	Language          string           `json:"language"`          // ISO 639-1 code, see ConvertLanguageToISO
+	Season            int              `json:"season"`
+	Episode           int              `json:"episode"`
+	Filename          string           `json:"filename"` // Subtitle filename from the download URL
+	DownloadURL       string           `json:"downloadUrl"`
+	Uploader          string           `json:"uploader"`
+	UploaderID        string           `json:"uploaderId"`        // Uploader profile identifier from the uploader link; empty when not linked
+	UploaderVerified  bool             `json:"uploaderVerified"`  // Uploader name is bold in the listing, which marks official translators and fansub teams
+	IsHearingImpaired bool             `json:"isHearingImpaired"` // Description or filename marks the subtitle as SDH/CC for the hearing impaired
+	UploadedAt        time.Time        `json:"uploadedAt"`
+	Qualities         []Quality        `json:"qualities"`       // All qualities the release names
+	ReleaseGroups     []string         `json:"releaseGroups"`   // Release groups, once each
+	ReleaseVariants   []ReleaseVariant `json:"releaseVariants"` // Each comma-separated release with its own source, rip type, quality and group
+	Release           string           `json:"release"`         // Release description as listed
+	IsSeasonPack      bool             `json:"isSeasonPack"`
+	SeasonEnd         *int             `json:"seasonEnd"`   // Last season of a multi-season pack such as "(1-3. évad)" (nil otherwise)
+	RangeStart        *int             `json:"rangeStart"`  // Season-pack range start episode (nil for non-ranged subtitles)
+	RangeEnd          *int             `json:"rangeEnd"`    // Season-pack range end episode (nil for non-ranged subtitles)
+	MovieTitle        string           `json:"movieTitle"`  // Movie title from a film row; empty for shows
+	MovieYear         int              `json:"movieYear"`   // Movie release year, such as 2023 from "Title (2023)"; 0 when missing or for shows
+	ContentType       ContentType      `json:"contentType"` // Whether the subtitle is for a series episode or a film
+	AirDate           time.Time        `json:"airDate"`     // Original air date named in the episode's titles; zero when they name none
+}
+
+// ReleaseVariant is one comma-separated release of a release description
+type ReleaseVariant struct {
+	Source  string  `json:"source"`  // Origin such as "AMZN", "NF" or "WEB"; empty when the release names none
+	RipType string  `json:"ripType"` // Rip type such as "WEB-DL", "WEBRip" or "HDTV"; empty when the release names none
+	Quality Quality `json:"quality"` // QualityUnknown when the release names no resolution
+	Group   string  `json:"group"`   // Release group after the last dash; empty when the release names none
+}
+
+// Quality is a video resolution a release can name
+type Quality int
+
+// Video qualities a release can name
+const (
+	QualityUnknown Quality = iota
+	Quality360p
+	Quality480p
+	Quality720p
+	Quality1080p
+	Quality2160p // 4K
+)
+
+// String returns the resolution, such as "1080p", or "unknown"
+func (q Quality) String() string {
+	switch q {
+	case Quality360p:
+		return "360p"
+	case Quality480p:
+		return "480p"
+	case Quality720p:
+		return "720p"
+	case Quality1080p:
+		return "1080p"
+	case Quality2160p:
+		return "2160p"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes the quality as its String
+func (q Quality) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + q.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a quality encoded by MarshalJSON; other strings read as QualityUnknown
+func (q *Quality) UnmarshalJSON(data []byte) error {
+	str := strings.ToLower(strings.Trim(string(data), `"`))
+	*q = QualityUnknown
+	for _, quality := range []Quality{Quality360p, Quality480p, Quality720p, Quality1080p, Quality2160p} {
+		if quality.String() == str {
+			*q = quality
+		}
+	}
+	return nil
+}
+
+// ContentType is the kind of content a subtitle is for
+type ContentType string
+
+// Kinds of content a subtitle can be for, matching the site's "sorozat" and "film" tabs
+const (
+	ContentTypeSeries ContentType = "series"
+	ContentTypeFilm   ContentType = "film"
+)
+
+// Errors returned for a page that is not a subtitle listing. Check for them with errors.As.
+
+// ErrUpstreamBlocked is a captcha, login or other block page served instead of the listing.
+// Reason names the marker that was found.
+type ErrUpstreamBlocked struct {
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ErrUpstreamBlocked) Error() string {
+	return fmt.Sprintf("upstream blocked the request: %s", e.Reason)
+}
+
+// ErrUpstreamMaintenance is a maintenance or server error page served instead of the listing.
+// Reason names the marker that was found.
+type ErrUpstreamMaintenance struct {
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ErrUpstreamMaintenance) Error() string {
+	return fmt.Sprintf("upstream is unavailable: %s", e.Reason)
+}
+
+// ErrUnexpectedPage is any other page without the listing's result table. Page names the kind
+// of page expected and Reason what was missing.
+type ErrUnexpectedPage struct {
+	Page   string
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ErrUnexpectedPage) Error() string {
+	return fmt.Sprintf("upstream page is not a %s: %s", e.Page, e.Reason)
+}
+
+// SubtitleListing is one parsed page of a subtitle listing
+type SubtitleListing struct {
+	Subtitles   []Subtitle
+	CurrentPage int
+	TotalPages  int
+	HasNextPage bool
+}